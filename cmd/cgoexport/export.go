@@ -0,0 +1,160 @@
+//go:build cgo
+
+package main
+
+/*
+#define IOTA_CRYPTO_CONSTANTS_ONLY
+#include "iota_crypto.h"
+*/
+import "C"
+
+import (
+	"strings"
+	"unsafe"
+
+	"github.com/iotaledger/iota-crypto-demo/internal/rand"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bech32/address"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip32path"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip39"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip39/wordlist"
+	"github.com/iotaledger/iota-crypto-demo/pkg/ed25519"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10/eddsa"
+)
+
+//nolint:revive,stylecheck // name of the C function
+//export iota_abi_version
+func iota_abi_version() C.int {
+	return C.IOTA_CRYPTO_ABI_VERSION
+}
+
+//nolint:revive,stylecheck // name of the C function
+//export iota_generate_mnemonic
+func iota_generate_mnemonic(bits C.int, language *C.char, out *C.char, outSize C.size_t) C.int {
+	if language == nil || out == nil {
+		return C.IOTA_ERR_INVALID_ARGUMENT
+	}
+	list, err := wordList(language)
+	if err != nil {
+		return C.IOTA_ERR_INVALID_ARGUMENT
+	}
+	if bits < 128 || bits > 256 || bits%32 != 0 {
+		return C.IOTA_ERR_INVALID_ARGUMENT
+	}
+	entropy := make([]byte, bits/8)
+	defer clear(entropy)
+	if _, err := rand.Reader.Read(entropy); err != nil {
+		return C.IOTA_ERR_INTERNAL
+	}
+	mnemonic, err := bip39.EntropyToMnemonicWithList(entropy, list)
+	if err != nil {
+		return C.IOTA_ERR_INTERNAL
+	}
+	return writeString(out, outSize, mnemonic.String())
+}
+
+//nolint:revive,stylecheck // name of the C function
+//export iota_mnemonic_to_seed
+func iota_mnemonic_to_seed(mnemonicString *C.char, passphrase *C.char, language *C.char, seed *C.uint8_t) C.int {
+	if mnemonicString == nil || passphrase == nil || language == nil || seed == nil {
+		return C.IOTA_ERR_INVALID_ARGUMENT
+	}
+	list, err := wordList(language)
+	if err != nil {
+		return C.IOTA_ERR_INVALID_ARGUMENT
+	}
+	mnemonic := bip39.ParseMnemonic(C.GoString(mnemonicString))
+	entropy, err := bip39.MnemonicToEntropyWithList(mnemonic, list)
+	if err != nil {
+		return C.IOTA_ERR_INVALID_MNEMONIC
+	}
+	clear(entropy)
+	s, err := bip39.MnemonicToSeedWithList(mnemonic, C.GoString(passphrase), list)
+	if err != nil {
+		return C.IOTA_ERR_INTERNAL
+	}
+	defer clear(s)
+	copy(bytes(seed, C.IOTA_SEED_SIZE), s)
+	return C.IOTA_OK
+}
+
+//nolint:revive,stylecheck // name of the C function
+//export iota_derive_key
+func iota_derive_key(seed *C.uint8_t, seedLen C.size_t, pathString *C.char, privateKey *C.uint8_t, publicKey *C.uint8_t) C.int {
+	if seed == nil || pathString == nil || privateKey == nil || publicKey == nil {
+		return C.IOTA_ERR_INVALID_ARGUMENT
+	}
+	path, err := bip32path.ParsePath(C.GoString(pathString))
+	if err != nil {
+		return C.IOTA_ERR_INVALID_PATH
+	}
+	// SLIP-10 only defines hardened derivation for Ed25519
+	for _, idx := range path {
+		if idx < slip10.Hardened {
+			return C.IOTA_ERR_INVALID_PATH
+		}
+	}
+	key, err := slip10.DeriveKeyFromPath(bytes(seed, seedLen), eddsa.Ed25519(), path)
+	if err != nil {
+		return C.IOTA_ERR_INTERNAL
+	}
+	public, private := key.Key.(eddsa.Seed).Ed25519Key() //nolint:forcetypeassert
+	defer clear(private)
+	copy(bytes(privateKey, C.IOTA_PRIVATE_KEY_SIZE), private.Seed())
+	copy(bytes(publicKey, C.IOTA_PUBLIC_KEY_SIZE), public)
+	return C.IOTA_OK
+}
+
+//nolint:revive,stylecheck // name of the C function
+//export iota_encode_address
+func iota_encode_address(publicKey *C.uint8_t, prefixString *C.char, out *C.char, outSize C.size_t) C.int {
+	if publicKey == nil || prefixString == nil || out == nil {
+		return C.IOTA_ERR_INVALID_ARGUMENT
+	}
+	prefix, err := address.ParsePrefix(C.GoString(prefixString))
+	if err != nil {
+		return C.IOTA_ERR_INVALID_ARGUMENT
+	}
+	public := ed25519.PublicKey(bytes(publicKey, C.IOTA_PUBLIC_KEY_SIZE))
+	s, err := address.Bech32(prefix, address.AddressFromPublicKey(public))
+	if err != nil {
+		return C.IOTA_ERR_INVALID_ARGUMENT
+	}
+	return writeString(out, outSize, s)
+}
+
+//nolint:revive,stylecheck // name of the C function
+//export iota_sign
+func iota_sign(privateKey *C.uint8_t, message *C.uint8_t, messageLen C.size_t, signature *C.uint8_t) C.int {
+	if privateKey == nil || (message == nil && messageLen > 0) || signature == nil {
+		return C.IOTA_ERR_INVALID_ARGUMENT
+	}
+	private := ed25519.NewKeyFromSeed(bytes(privateKey, C.IOTA_PRIVATE_KEY_SIZE))
+	defer clear(private)
+	copy(bytes(signature, C.IOTA_SIGNATURE_SIZE), ed25519.Sign(private, bytes(message, messageLen)))
+	return C.IOTA_OK
+}
+
+// wordList returns the word list of the C string language. In contrast to bip39.SetWordList, it does not modify
+// global state, so that the exported functions can be called concurrently with different languages.
+func wordList(language *C.char) (wordlist.List, error) {
+	return bip39.WordList(strings.ToLower(C.GoString(language)))
+}
+
+// bytes returns the C buffer p of n bytes as slice without copying it.
+func bytes[T C.uint8_t | C.char](p *T, n C.size_t) []byte {
+	if n == 0 {
+		return nil
+	}
+	return unsafe.Slice((*byte)(unsafe.Pointer(p)), int(n))
+}
+
+// writeString writes s as NUL-terminated string to the C buffer out of outSize bytes.
+func writeString(out *C.char, outSize C.size_t, s string) C.int {
+	if C.size_t(len(s)) >= outSize {
+		return C.IOTA_ERR_BUFFER_TOO_SMALL
+	}
+	buf := bytes(out, outSize)
+	buf[copy(buf, s)] = 0
+	return C.IOTA_OK
+}
@@ -0,0 +1,91 @@
+/*
+ * iota_crypto.h - C interface of the iota-crypto-demo reference implementation.
+ *
+ * Build the shared library with
+ *
+ *     go build -buildmode=c-shared -o libiotacrypto.so ./cmd/cgoexport
+ *
+ * and include this header instead of the one generated by the Go tool chain, which is not stable between versions.
+ *
+ * All functions write their results into buffers allocated by the caller and return IOTA_OK or a negative error
+ * code. Strings are UTF-8 and NUL-terminated. Private keys are 32-byte Ed25519 seeds as defined by RFC 8032.
+ *
+ * The functions do not share any mutable state and can be called concurrently from multiple threads, also with
+ * different languages.
+ */
+#ifndef IOTA_CRYPTO_H
+#define IOTA_CRYPTO_H
+
+#include <stddef.h>
+#include <stdint.h>
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+/* IOTA_CRYPTO_ABI_VERSION is incremented whenever a function or constant of this header changes incompatibly. */
+#define IOTA_CRYPTO_ABI_VERSION 1
+
+/* Return codes. */
+#define IOTA_OK 0
+#define IOTA_ERR_INVALID_ARGUMENT -1
+#define IOTA_ERR_INVALID_MNEMONIC -2
+#define IOTA_ERR_INVALID_PATH -3
+#define IOTA_ERR_BUFFER_TOO_SMALL -4
+#define IOTA_ERR_INTERNAL -5
+
+/* Sizes, in bytes, of the fixed-size buffers. */
+#define IOTA_SEED_SIZE 64
+#define IOTA_PRIVATE_KEY_SIZE 32
+#define IOTA_PUBLIC_KEY_SIZE 32
+#define IOTA_SIGNATURE_SIZE 64
+/* Sizes, in bytes, of string buffers large enough for any result including the terminating NUL. */
+#define IOTA_MNEMONIC_MAX_SIZE 1024
+#define IOTA_ADDRESS_MAX_SIZE 91
+
+/*
+ * The Go implementation only uses the constants, as cgo declares the exported functions without const qualifiers.
+ */
+#ifndef IOTA_CRYPTO_CONSTANTS_ONLY
+
+/* iota_abi_version returns the IOTA_CRYPTO_ABI_VERSION the library was built with. */
+int iota_abi_version(void);
+
+/*
+ * iota_generate_mnemonic writes a random BIP-39 mnemonic with the given entropy size, 128-256 bits in steps of 32, in
+ * the given language, e.g. "english", to out of out_size bytes.
+ */
+int iota_generate_mnemonic(int bits, const char *language, char *out, size_t out_size);
+
+/*
+ * iota_mnemonic_to_seed validates the BIP-39 mnemonic in the given language and writes the seed derived with the
+ * passphrase, which can be empty, to seed.
+ */
+int iota_mnemonic_to_seed(const char *mnemonic, const char *passphrase, const char *language,
+                          uint8_t seed[IOTA_SEED_SIZE]);
+
+/*
+ * iota_derive_key derives the SLIP-10 Ed25519 key of the BIP-32 path, e.g. "m/44'/4218'/0'/0'/0'", from the seed of
+ * seed_len bytes and writes its private and public key. All indices of the path must be hardened.
+ */
+int iota_derive_key(const uint8_t *seed, size_t seed_len, const char *path,
+                    uint8_t private_key[IOTA_PRIVATE_KEY_SIZE], uint8_t public_key[IOTA_PUBLIC_KEY_SIZE]);
+
+/*
+ * iota_encode_address writes the bech32 address of the Ed25519 public key with the network prefix, e.g. "iota", to
+ * out of out_size bytes.
+ */
+int iota_encode_address(const uint8_t public_key[IOTA_PUBLIC_KEY_SIZE], const char *prefix, char *out,
+                        size_t out_size);
+
+/* iota_sign writes the Ed25519 signature of the message of message_len bytes with the private key to signature. */
+int iota_sign(const uint8_t private_key[IOTA_PRIVATE_KEY_SIZE], const uint8_t *message, size_t message_len,
+              uint8_t signature[IOTA_SIGNATURE_SIZE]);
+
+#endif /* IOTA_CRYPTO_CONSTANTS_ONLY */
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif /* IOTA_CRYPTO_H */
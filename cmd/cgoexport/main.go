@@ -0,0 +1,11 @@
+// Command cgoexport builds the reference implementation as C shared library exporting mnemonic generation, key
+// derivation, address encoding and signing, so that mobile apps and other languages can link against it.
+//
+// The C interface is declared in iota_crypto.h, which is maintained by hand to keep it stable:
+//
+//	go build -buildmode=c-shared -o libiotacrypto.so ./cmd/cgoexport
+//
+// The exported functions are only available when cgo is enabled.
+package main
+
+func main() {}
@@ -0,0 +1,98 @@
+// Command iota-crypto-wasm exposes the mnemonic generation, seed derivation and address encoding of this repository to
+// JavaScript, when built for the js/wasm target.
+//
+// The bindings in this file are plain Go functions, which only take and return strings, so that they do not depend on
+// syscall/js and the JavaScript glue in main_js.go stays as thin as possible.
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/iotaledger/iota-crypto-demo/internal/rand"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bech32/address"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip32path"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip39"
+	"github.com/iotaledger/iota-crypto-demo/pkg/ed25519"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10/eddsa"
+)
+
+// generateMnemonic returns a random mnemonic with the given entropy size in the given language.
+func generateMnemonic(bits int, language string) (string, error) {
+	if err := bip39.SetWordList(strings.ToLower(language)); err != nil {
+		return "", err
+	}
+	if bits < 128 || bits > 256 || bits%32 != 0 {
+		return "", fmt.Errorf("invalid entropy size: %d bits", bits)
+	}
+	entropy := make([]byte, bits/8)
+	if _, err := rand.Reader.Read(entropy); err != nil {
+		return "", err
+	}
+	mnemonic, err := bip39.EntropyToMnemonic(entropy)
+	if err != nil {
+		return "", err
+	}
+	return mnemonic.String(), nil
+}
+
+// deriveSeed validates the mnemonic in the given language and returns the hex encoded BIP-39 seed.
+func deriveSeed(mnemonicString string, passphrase string, language string) (string, error) {
+	if err := bip39.SetWordList(strings.ToLower(language)); err != nil {
+		return "", err
+	}
+	mnemonic := bip39.ParseMnemonic(mnemonicString)
+	if _, err := bip39.MnemonicToEntropy(mnemonic); err != nil {
+		return "", fmt.Errorf("invalid mnemonic: %w", err)
+	}
+	seed, err := bip39.MnemonicToSeed(mnemonic, passphrase)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(seed), nil
+}
+
+// deriveAddress derives the SLIP-10 Ed25519 key of the path from the hex encoded seed and returns the hex encoded
+// public key together with its bech32 address.
+func deriveAddress(seedHex string, pathString string, prefixString string) (map[string]any, error) {
+	seed, err := hex.DecodeString(seedHex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid seed: %w", err)
+	}
+	path, err := bip32path.ParsePath(pathString)
+	if err != nil {
+		return nil, fmt.Errorf("invalid path: %w", err)
+	}
+	key, err := slip10.DeriveKeyFromPath(seed, eddsa.Ed25519(), path)
+	if err != nil {
+		return nil, fmt.Errorf("failed deriving key: %w", err)
+	}
+	public, _ := key.Key.(eddsa.Seed).Ed25519Key() //nolint:forcetypeassert
+	addr, err := encodeAddress(hex.EncodeToString(public), prefixString)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"path":      path.String(),
+		"publicKey": hex.EncodeToString(public),
+		"address":   addr,
+	}, nil
+}
+
+// encodeAddress returns the bech32 address of the hex encoded Ed25519 public key with the given network prefix.
+func encodeAddress(publicKeyHex string, prefixString string) (string, error) {
+	publicKey, err := hex.DecodeString(publicKeyHex)
+	if err != nil {
+		return "", fmt.Errorf("invalid public key: %w", err)
+	}
+	if len(publicKey) != ed25519.PublicKeySize {
+		return "", fmt.Errorf("invalid public key length: %d bytes", len(publicKey))
+	}
+	prefix, err := address.ParsePrefix(prefixString)
+	if err != nil {
+		return "", fmt.Errorf("invalid prefix: %w", err)
+	}
+	return address.Bech32(prefix, address.AddressFromPublicKey(publicKey))
+}
@@ -0,0 +1,118 @@
+//go:build js && wasm
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"syscall/js"
+)
+
+// defaults of the optional arguments
+const (
+	defaultBits     = 256
+	defaultLanguage = "english"
+	defaultPrefix   = "iota"
+)
+
+var errInvalidArgument = errors.New("invalid argument")
+
+func main() {
+	js.Global().Set("iotaCrypto", js.ValueOf(map[string]any{
+		"generateMnemonic": export(func(args []js.Value) (any, error) {
+			bits, err := intArg(args, 0, defaultBits)
+			if err != nil {
+				return nil, err
+			}
+			language, err := stringArg(args, 1, defaultLanguage)
+			if err != nil {
+				return nil, err
+			}
+			return generateMnemonic(bits, language)
+		}),
+		"deriveSeed": export(func(args []js.Value) (any, error) {
+			mnemonic, err := stringArg(args, 0, "")
+			if err != nil {
+				return nil, err
+			}
+			passphrase, err := stringArg(args, 1, "")
+			if err != nil {
+				return nil, err
+			}
+			language, err := stringArg(args, 2, defaultLanguage)
+			if err != nil {
+				return nil, err
+			}
+			return deriveSeed(mnemonic, passphrase, language)
+		}),
+		"deriveAddress": export(func(args []js.Value) (any, error) {
+			seed, err := stringArg(args, 0, "")
+			if err != nil {
+				return nil, err
+			}
+			path, err := stringArg(args, 1, "")
+			if err != nil {
+				return nil, err
+			}
+			prefix, err := stringArg(args, 2, defaultPrefix)
+			if err != nil {
+				return nil, err
+			}
+			return deriveAddress(seed, path, prefix)
+		}),
+		"encodeAddress": export(func(args []js.Value) (any, error) {
+			publicKey, err := stringArg(args, 0, "")
+			if err != nil {
+				return nil, err
+			}
+			prefix, err := stringArg(args, 1, defaultPrefix)
+			if err != nil {
+				return nil, err
+			}
+			return encodeAddress(publicKey, prefix)
+		}),
+	}))
+	// keep the exported functions alive
+	select {}
+}
+
+// export wraps f into a JavaScript function returning a Promise, which resolves to the result of f or is rejected with
+// an Error, so that failures surface as exceptions when awaited.
+func export(f func(args []js.Value) (any, error)) js.Func {
+	return js.FuncOf(func(_ js.Value, args []js.Value) any {
+		return js.Global().Get("Promise").New(js.FuncOf(func(_ js.Value, callbacks []js.Value) any {
+			resolve, reject := callbacks[0], callbacks[1]
+			go func() {
+				res, err := f(args)
+				if err != nil {
+					reject.Invoke(js.Global().Get("Error").New(err.Error()))
+					return
+				}
+				resolve.Invoke(js.ValueOf(res))
+			}()
+			return nil
+		}))
+	})
+}
+
+// stringArg returns the i-th argument as string or def, if it is missing or undefined.
+func stringArg(args []js.Value, i int, def string) (string, error) {
+	if i >= len(args) || args[i].IsUndefined() {
+		return def, nil
+	}
+	if args[i].Type() != js.TypeString {
+		return "", fmt.Errorf("%w %d: expected string, got %s", errInvalidArgument, i, args[i].Type())
+	}
+	return args[i].String(), nil
+}
+
+// intArg returns the i-th argument as integer or def, if it is missing or undefined.
+func intArg(args []js.Value, i int, def int) (int, error) {
+	if i >= len(args) || args[i].IsUndefined() {
+		return def, nil
+	}
+	if args[i].Type() != js.TypeNumber {
+		return 0, fmt.Errorf("%w %d: expected number, got %s", errInvalidArgument, i, args[i].Type())
+	}
+	return args[i].Int(), nil
+}
@@ -0,0 +1,13 @@
+//go:build !(js && wasm)
+
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+func main() {
+	fmt.Fprintln(os.Stderr, "iota-crypto-wasm must be built with GOOS=js GOARCH=wasm and loaded with wasm_exec.js")
+	os.Exit(2)
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"encoding/hex"
+	"flag"
+	"fmt"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/bech32/address"
+	"github.com/iotaledger/iota-crypto-demo/pkg/ed25519"
+)
+
+var addressCommand = &command{
+	name:  "address",
+	usage: "encode and decode bech32 addresses",
+	actions: []*action{
+		{name: "encode", usage: "encode a public key or output ID as bech32 address", flags: addressEncode},
+		{name: "decode", usage: "decode a bech32 address", flags: addressDecode},
+	},
+}
+
+func addressEncode(fs *flag.FlagSet) func([]string) (*result, error) {
+	prefixString := fs.String("prefix", address.IOTAMainnet.String(), "network prefix")
+	versionString := fs.String("version", address.Ed25519.String(), "address version")
+	keyString := fs.String("key", "", "hex-encoded public key / output ID")
+	return func([]string) (*result, error) {
+		prefix, err := address.ParsePrefix(*prefixString)
+		if err != nil {
+			return nil, fmt.Errorf("invalid prefix: %w", err)
+		}
+		version, err := address.ParseVersion(*versionString)
+		if err != nil {
+			return nil, fmt.Errorf("invalid address version: %w", err)
+		}
+		key, err := hex.DecodeString(*keyString)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key: %w", err)
+		}
+
+		var addr address.Address
+		switch version {
+		case address.Ed25519:
+			if len(key) != ed25519.PublicKeySize {
+				return nil, fmt.Errorf("invalid public key: length %d", len(key))
+			}
+			addr = address.AddressFromPublicKey(key)
+		case address.Alias, address.NFT:
+			if len(key) != address.OutputIDLength {
+				return nil, fmt.Errorf("invalid output ID: length %d", len(key))
+			}
+			var outputID [address.OutputIDLength]byte
+			copy(outputID[:], key)
+			if version == address.Alias {
+				addr = address.AliasAddressFromOutputID(outputID)
+			} else {
+				addr = address.NFTAddressFromOutputID(outputID)
+			}
+		default:
+			return nil, fmt.Errorf("unsupported address version: %s", version)
+		}
+
+		s, err := address.Bech32(prefix, addr)
+		if err != nil {
+			return nil, err
+		}
+		return addressResult(s, prefix, addr), nil
+	}
+}
+
+func addressDecode(fs *flag.FlagSet) func([]string) (*result, error) {
+	addressString := fs.String("address", "", "bech32 encoded address; the first argument is used if empty")
+	return func(args []string) (*result, error) {
+		s := *addressString
+		if s == "" && len(args) > 0 {
+			s = args[0]
+		}
+		prefix, addr, err := address.ParseBech32(s)
+		if err != nil {
+			return nil, err
+		}
+		return addressResult(s, prefix, addr), nil
+	}
+}
+
+func addressResult(s string, prefix address.Prefix, addr address.Address) *result {
+	return newResult().
+		add("address", s).
+		add("network", prefix.String()).
+		add("version", addr.Version().String()).
+		add("hash", addr.Hex()).
+		add("bytes", hex.EncodeToString(addr.Bytes()))
+}
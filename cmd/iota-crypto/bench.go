@@ -0,0 +1,121 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"runtime"
+	"time"
+
+	"github.com/iotaledger/iota-crypto-demo/internal/rand"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip32path"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip39"
+	"github.com/iotaledger/iota-crypto-demo/pkg/ed25519"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10/eddsa"
+)
+
+var benchCommand = &command{
+	name:  "bench",
+	usage: "measure the throughput of the cryptographic operations on this host",
+	actions: []*action{
+		{name: "run", usage: "run all benchmarks and print the operations per second", flags: benchRun},
+	},
+}
+
+// benchmark is a single measured operation.
+type benchmark struct {
+	name string
+	op   func() error
+}
+
+// opsPerSecond is the result of a benchmark.
+type opsPerSecond float64
+
+func (o opsPerSecond) String() string { return fmt.Sprintf("%.1f ops/s", float64(o)) }
+
+func benchRun(fs *flag.FlagSet) func([]string) (*result, error) {
+	duration := fs.Duration("duration", time.Second, "minimum duration of each benchmark")
+	pathString := fs.String("path", "m/44'/4218'/0'/0'/0'", "BIP-32 path of the SLIP-10 derivations")
+	return func([]string) (*result, error) {
+		if *duration <= 0 {
+			return nil, errors.New("invalid duration")
+		}
+		path, err := bip32path.ParsePath(*pathString)
+		if err != nil {
+			return nil, fmt.Errorf("invalid path: %w", err)
+		}
+
+		entropy := make([]byte, 32)
+		if _, err := rand.Reader.Read(entropy); err != nil {
+			return nil, err
+		}
+		mnemonic, err := bip39.EntropyToMnemonic(entropy)
+		if err != nil {
+			return nil, err
+		}
+		seed, err := bip39.MnemonicToSeed(mnemonic, "")
+		if err != nil {
+			return nil, err
+		}
+		key, err := slip10.DeriveKeyFromPath(seed, eddsa.Ed25519(), path)
+		if err != nil {
+			return nil, err
+		}
+		public, private := key.Key.(eddsa.Seed).Ed25519Key()
+		message := make([]byte, 32)
+		signature := ed25519.Sign(private, message)
+
+		benchmarks := []benchmark{
+			{"mnemonics", func() error {
+				_, err := bip39.EntropyToMnemonic(entropy)
+				return err
+			}},
+			{"seeds", func() error {
+				_, err := bip39.MnemonicToSeed(mnemonic, "")
+				return err
+			}},
+			{"slip10", func() error {
+				_, err := slip10.DeriveKeyFromPath(seed, eddsa.Ed25519(), path)
+				return err
+			}},
+			{"signatures", func() error {
+				ed25519.Sign(private, message)
+				return nil
+			}},
+			{"verifications", func() error {
+				if !ed25519.Verify(public, message, signature) {
+					return errors.New("invalid signature")
+				}
+				return nil
+			}},
+		}
+
+		res := newResult().
+			add("goVersion", runtime.Version()).
+			add("platform", runtime.GOOS+"/"+runtime.GOARCH).
+			add("cpus", runtime.NumCPU()).
+			add("slip10Path", path.String())
+		for _, b := range benchmarks {
+			ops, err := measure(*duration, b.op)
+			if err != nil {
+				return nil, fmt.Errorf("benchmark %s failed: %w", b.name, err)
+			}
+			res.add(b.name, ops)
+		}
+		return res, nil
+	}
+}
+
+// measure runs op sequentially for at least duration and returns the achieved operations per second.
+func measure(duration time.Duration, op func() error) (opsPerSecond, error) {
+	var n int
+	start := time.Now()
+	for time.Since(start) < duration {
+		if err := op(); err != nil {
+			return 0, err
+		}
+		n++
+	}
+	return opsPerSecond(float64(n) / time.Since(start).Seconds()), nil
+}
@@ -0,0 +1,196 @@
+// Command iota-crypto combines the functionality of the examples in a single command line tool.
+//
+// Every command prints its results as aligned "key: value" lines or, with the -json flag, as a single JSON object,
+// so that the tool can easily be used in scripts.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+)
+
+// command is a subcommand consisting of the actions it supports.
+type command struct {
+	name    string
+	usage   string
+	actions []*action
+}
+
+// action is a single action of a command with its own flags.
+type action struct {
+	name  string
+	usage string
+	flags func(fs *flag.FlagSet) func(args []string) (*result, error)
+}
+
+// errInvalid is returned by actions, when the result has been printed but the input was found invalid.
+var errInvalid = errors.New("invalid")
+
+var commands = []*command{
+	mnemonicCommand,
+	seedCommand,
+	addressCommand,
+	merkleCommand,
+	shamirCommand,
+	vectorsCommand,
+	benchCommand,
+	wordlistCommand,
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) < 1 {
+		usage(stderr)
+		return 2
+	}
+	cmd := findCommand(args[0])
+	if cmd == nil || len(args) < 2 {
+		usage(stderr)
+		return 2
+	}
+	act := cmd.findAction(args[1])
+	if act == nil {
+		cmd.printUsage(stderr)
+		return 2
+	}
+
+	fs := flag.NewFlagSet(cmd.name+" "+act.name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	asJSON := fs.Bool("json", false, "print the result as JSON object")
+	exec := act.flags(fs)
+	if err := fs.Parse(args[2:]); err != nil {
+		return 2
+	}
+
+	res, err := exec(fs.Args())
+	if res != nil {
+		if err := res.print(stdout, *asJSON); err != nil {
+			fmt.Fprintf(stderr, "Error: %s\n", err)
+			return 1
+		}
+	}
+	if err != nil {
+		if !errors.Is(err, errInvalid) {
+			fmt.Fprintf(stderr, "Error: %s\n", err)
+		}
+		return 1
+	}
+	return 0
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintf(w, "Usage of iota-crypto:\n")
+	fmt.Fprintf(w, "\t<command> <action> [arguments]\n\n")
+	fmt.Fprintf(w, "The commands are:\n")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "\t%s\t%s\n", cmd.name, cmd.usage)
+	}
+	fmt.Fprintf(w, "\nUse \"iota-crypto <command>\" to list the actions of a command.\n")
+}
+
+func findCommand(name string) *command {
+	for _, cmd := range commands {
+		if cmd.name == name {
+			return cmd
+		}
+	}
+	return nil
+}
+
+func (c *command) findAction(name string) *action {
+	for _, act := range c.actions {
+		if act.name == name {
+			return act
+		}
+	}
+	return nil
+}
+
+func (c *command) printUsage(w io.Writer) {
+	fmt.Fprintf(w, "Usage of iota-crypto %s:\n", c.name)
+	fmt.Fprintf(w, "\t%s <action> [arguments]\n\n", c.name)
+	fmt.Fprintf(w, "The actions are:\n")
+	for _, act := range c.actions {
+		fmt.Fprintf(w, "\t%s\t%s\n", act.name, act.usage)
+	}
+}
+
+// result is an ordered list of named values.
+// Multi-line values like QR codes are added as blocks, which are printed after the aligned values.
+type result struct {
+	keys   []string
+	values map[string]any
+	blocks map[string]bool
+}
+
+func newResult() *result {
+	return &result{values: map[string]any{}, blocks: map[string]bool{}}
+}
+
+// add adds the named value to the result.
+func (r *result) add(key string, value any) *result {
+	if _, ok := r.values[key]; !ok {
+		r.keys = append(r.keys, key)
+	}
+	r.values[key] = value
+	return r
+}
+
+// addBlock adds the named value, whose text form spans multiple lines, to the result.
+func (r *result) addBlock(key string, value any) *result {
+	r.blocks[key] = true
+	return r.add(key, value)
+}
+
+// MarshalJSON encodes the result as JSON object, keeping the order of the values.
+func (r *result) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range r.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, _ := json.Marshal(key)
+		v, err := json.Marshal(r.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+func (r *result) print(w io.Writer, asJSON bool) error {
+	if asJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(r)
+	}
+	tw := tabwriter.NewWriter(w, 0, 8, 1, ' ', 0)
+	for _, key := range r.keys {
+		if !r.blocks[key] {
+			fmt.Fprintf(tw, "%s:\t%v\n", key, r.values[key])
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	for _, key := range r.keys {
+		if r.blocks[key] {
+			fmt.Fprintf(w, "\n%s:\n%v", key, r.values[key])
+		}
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"crypto"
+	"encoding"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/merkle"
+	_ "golang.org/x/crypto/blake2b" // BLAKE2b_256 is the default hashing algorithm
+)
+
+var merkleCommand = &command{
+	name:  "merkle",
+	usage: "compute Merkle tree hashes and inclusion proofs",
+	actions: []*action{
+		{name: "root", usage: "compute the Merkle tree hash of the hex-encoded leaves", flags: merkleRoot},
+		{name: "proof", usage: "compute the inclusion proof of a leaf", flags: merkleProof},
+	},
+}
+
+type leaf []byte
+
+func (l leaf) MarshalBinary() ([]byte, error) { return l, nil }
+
+// proofStep is the JSON and text representation of a merkle.ProofStep.
+type proofStep struct {
+	Hash string `json:"hash"`
+	Left bool   `json:"left"`
+}
+
+type proofSteps []proofStep
+
+func (p proofSteps) String() string {
+	parts := make([]string, len(p))
+	for i, step := range p {
+		side := "R"
+		if step.Left {
+			side = "L"
+		}
+		parts[i] = side + ":" + step.Hash
+	}
+	return strings.Join(parts, " ")
+}
+
+func merkleRoot(*flag.FlagSet) func([]string) (*result, error) {
+	return func(args []string) (*result, error) {
+		leaves, err := parseLeaves(args)
+		if err != nil {
+			return nil, err
+		}
+		root, err := merkle.NewHasher(crypto.BLAKE2b_256).Hash(leaves)
+		if err != nil {
+			return nil, err
+		}
+		return newResult().add("leaves", len(leaves)).add("root", hex.EncodeToString(root)), nil
+	}
+}
+
+func merkleProof(fs *flag.FlagSet) func([]string) (*result, error) {
+	index := fs.Int("index", 0, "index of the proven leaf")
+	return func(args []string) (*result, error) {
+		leaves, err := parseLeaves(args)
+		if err != nil {
+			return nil, err
+		}
+		hasher := merkle.NewHasher(crypto.BLAKE2b_256)
+		root, err := hasher.Hash(leaves)
+		if err != nil {
+			return nil, err
+		}
+		proof, err := hasher.Proof(leaves, *index)
+		if err != nil {
+			return nil, err
+		}
+		return newResult().
+			add("leaves", len(leaves)).
+			add("root", hex.EncodeToString(root)).
+			add("index", *index).
+			add("proof", newProofSteps(proof)), nil
+	}
+}
+
+func newProofSteps(proof merkle.Proof) proofSteps {
+	steps := make(proofSteps, len(proof))
+	for i, step := range proof {
+		steps[i] = proofStep{Hash: hex.EncodeToString(step.Hash), Left: step.Left}
+	}
+	return steps
+}
+
+func parseLeaves(args []string) ([]encoding.BinaryMarshaler, error) {
+	if len(args) == 0 {
+		return nil, errors.New("missing leaves")
+	}
+	leaves := make([]encoding.BinaryMarshaler, len(args))
+	for i, arg := range args {
+		b, err := hex.DecodeString(arg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid leaf %d: %w", i, err)
+		}
+		leaves[i] = leaf(b)
+	}
+	return leaves, nil
+}
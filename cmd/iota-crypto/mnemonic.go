@@ -0,0 +1,188 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/iotaledger/iota-crypto-demo/internal/hexutil"
+	"github.com/iotaledger/iota-crypto-demo/internal/rand"
+	"github.com/iotaledger/iota-crypto-demo/internal/secret"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip39"
+	"github.com/iotaledger/iota-crypto-demo/pkg/mnemonicfile"
+)
+
+var mnemonicCommand = &command{
+	name:  "mnemonic",
+	usage: "generate, validate and encrypt BIP-39 mnemonics",
+	actions: []*action{
+		{name: "generate", usage: "generate a random mnemonic", flags: mnemonicGenerate},
+		{name: "validate", usage: "validate a mnemonic and print its entropy", flags: mnemonicValidate},
+		{name: "encrypt", usage: "write a mnemonic to a password encrypted file", flags: mnemonicEncrypt},
+		{name: "decrypt", usage: "read a mnemonic from a password encrypted file", flags: mnemonicDecrypt},
+	},
+}
+
+func mnemonicGenerate(fs *flag.FlagSet) func([]string) (*result, error) {
+	bits := fs.Int("bits", 256, "entropy size in bits, 128-256 in steps of 32")
+	language := fs.String("language", "english", "language of the mnemonic")
+	return func([]string) (*result, error) {
+		if err := bip39.SetWordList(strings.ToLower(*language)); err != nil {
+			return nil, err
+		}
+		if *bits < 128 || *bits > 256 || *bits%32 != 0 {
+			return nil, fmt.Errorf("invalid entropy size: %d bits", *bits)
+		}
+		entropy := make([]byte, *bits/8)
+		if _, err := rand.Reader.Read(entropy); err != nil {
+			return nil, err
+		}
+		mnemonic, err := bip39.EntropyToMnemonic(entropy)
+		if err != nil {
+			return nil, err
+		}
+		return newResult().
+			add("entropy", hexutil.Bytes(entropy).String()).
+			add("mnemonic", mnemonic.String()).
+			add("words", len(mnemonic)), nil
+	}
+}
+
+func mnemonicValidate(fs *flag.FlagSet) func([]string) (*result, error) {
+	mnemonicString := fs.String("mnemonic", "", "mnemonic sentence; the remaining arguments are used if empty and no secret source is selected")
+	language := fs.String("language", "english", "language of the mnemonic")
+	secretFlags := secret.RegisterFlags(fs)
+	return func(args []string) (*result, error) {
+		if err := bip39.SetWordList(strings.ToLower(*language)); err != nil {
+			return nil, err
+		}
+		s := *mnemonicString
+		src, err := secretFlags.Source()
+		if err != nil {
+			return nil, err
+		}
+		if src != nil {
+			if s, err = src.Read("mnemonic: "); err != nil {
+				return nil, err
+			}
+		}
+		if s == "" {
+			s = strings.Join(args, " ")
+		}
+		if s == "" {
+			return nil, errors.New("missing mnemonic")
+		}
+		mnemonic := bip39.ParseMnemonic(s)
+		res := newResult().add("words", len(mnemonic))
+		entropy, err := bip39.MnemonicToEntropy(mnemonic)
+		if err != nil {
+			res.add("valid", false).add("error", err.Error())
+			return res, errInvalid
+		}
+		return res.add("valid", true).add("entropy", hexutil.Bytes(entropy).String()), nil
+	}
+}
+
+func mnemonicEncrypt(fs *flag.FlagSet) func([]string) (*result, error) {
+	file := fs.String("file", "", "name of the encrypted mnemonic file to create")
+	language := fs.String("language", "english", "language of the mnemonic")
+	kdf := fs.String("kdf", mnemonicfile.Scrypt.String(), "key derivation function: scrypt or argon2id")
+	cost := fs.Uint("cost", 0, "log2 of the scrypt N or the Argon2id memory in KiB; 0 selects the default")
+	secretFlags := secret.RegisterFlags(fs)
+	return func([]string) (*result, error) {
+		if *file == "" {
+			return nil, errors.New("missing file")
+		}
+		if err := bip39.SetWordList(strings.ToLower(*language)); err != nil {
+			return nil, err
+		}
+		opts := &mnemonicfile.Options{Cost: uint32(*cost)}
+		switch strings.ToLower(*kdf) {
+		case mnemonicfile.Scrypt.String():
+			opts.KDF = mnemonicfile.Scrypt
+		case mnemonicfile.Argon2id.String():
+			opts.KDF = mnemonicfile.Argon2id
+		default:
+			return nil, fmt.Errorf("unknown key derivation function: %s", *kdf)
+		}
+		src, err := secretFlags.Source()
+		if err != nil {
+			return nil, err
+		}
+		if src == nil {
+			src = secret.FromTerminal()
+		}
+		s, err := src.Read("mnemonic: ")
+		if err != nil {
+			return nil, err
+		}
+		mnemonic := bip39.ParseMnemonic(s)
+		if _, err := bip39.MnemonicToEntropy(mnemonic); err != nil {
+			return nil, fmt.Errorf("invalid mnemonic: %w", err)
+		}
+		password, err := src.Read("password: ")
+		if err != nil {
+			return nil, err
+		}
+		if password == "" {
+			return nil, errors.New("missing password")
+		}
+		if err := mnemonicfile.WriteFile(*file, mnemonic, password, opts); err != nil {
+			return nil, err
+		}
+		return mnemonicFileResult(*file, nil)
+	}
+}
+
+func mnemonicDecrypt(fs *flag.FlagSet) func([]string) (*result, error) {
+	file := fs.String("file", "", "name of the encrypted mnemonic file")
+	secretFlags := secret.RegisterFlags(fs)
+	return func([]string) (*result, error) {
+		if *file == "" {
+			return nil, errors.New("missing file")
+		}
+		src, err := secretFlags.Source()
+		if err != nil {
+			return nil, err
+		}
+		if src == nil {
+			src = secret.FromTerminal()
+		}
+		password, err := src.Read("password: ")
+		if err != nil {
+			return nil, err
+		}
+		mnemonic, err := mnemonicfile.ReadFile(*file, password)
+		if err != nil {
+			return nil, err
+		}
+		return mnemonicFileResult(*file, mnemonic)
+	}
+}
+
+// mnemonicFileResult returns the unencrypted parameters of the file name and, if not nil, the decrypted mnemonic.
+func mnemonicFileResult(name string, mnemonic bip39.Mnemonic) (*result, error) {
+	armored, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	data, err := mnemonicfile.Dearmor(armored)
+	if err != nil {
+		return nil, err
+	}
+	h, err := mnemonicfile.ParseHeader(data)
+	if err != nil {
+		return nil, err
+	}
+	res := newResult().
+		add("file", name).
+		add("version", h.Version).
+		add("kdf", h.KDF.String()).
+		add("params", fmt.Sprint(h.Params))
+	if mnemonic != nil {
+		res.add("mnemonic", mnemonic.String()).add("words", len(mnemonic))
+	}
+	return res, nil
+}
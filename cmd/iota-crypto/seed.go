@@ -0,0 +1,253 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/iotaledger/iota-crypto-demo/internal/hexutil"
+	"github.com/iotaledger/iota-crypto-demo/internal/secret"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bech32/address"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip32path"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip39"
+	"github.com/iotaledger/iota-crypto-demo/pkg/crosscheck"
+	"github.com/iotaledger/iota-crypto-demo/pkg/keystore/backend"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10/eddsa"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10/elliptic"
+	"github.com/iotaledger/iota-crypto-demo/pkg/wallet"
+)
+
+var seedCommand = &command{
+	name:  "seed",
+	usage: "derive keys from a BIP-39 mnemonic",
+	actions: []*action{
+		{name: "derive", usage: "derive the SLIP-10 key and address of a path", flags: seedDerive},
+		{name: "passphrases", usage: "compare the addresses of the wallets of several passphrases", flags: seedPassphrases},
+	},
+}
+
+func seedDerive(fs *flag.FlagSet) func([]string) (*result, error) {
+	mnemonicString := fs.String("mnemonic", "", "mnemonic sentence according to BIP-39")
+	language := fs.String("language", "english", "language of the mnemonic")
+	passphrase := fs.String("passphrase", "", "secret passphrase to generate the master seed; can be empty")
+	pathString := fs.String("path", "m/44'/4218'/0'/0'/0'", "BIP-32 path of the derived key")
+	curveName := fs.String("curve", eddsa.Ed25519().Name(), "SLIP-10 curve: ed25519, secp256k1 or nist256p1")
+	prefixString := fs.String("prefix", address.IOTAMainnet.String(), "network prefix of the Ed25519 address")
+	secretFlags := secret.RegisterFlags(fs)
+	keyStoreSpec := fs.String("keystore", "", "key store backend like \"file:<name>\" or \"keyring:<service>/<account>\" to load the seed from instead of the mnemonic")
+	return func([]string) (*result, error) {
+		path, err := bip32path.ParsePath(*pathString)
+		if err != nil {
+			return nil, fmt.Errorf("invalid path: %w", err)
+		}
+		curve, err := crosscheck.Curve(*curveName)
+		if err != nil {
+			return nil, err
+		}
+		prefix, err := address.ParsePrefix(*prefixString)
+		if err != nil {
+			return nil, fmt.Errorf("invalid prefix: %w", err)
+		}
+		src, err := secretFlags.Source()
+		if err != nil {
+			return nil, err
+		}
+
+		var seed []byte
+		if *keyStoreSpec != "" {
+			if seed, err = loadSeed(*keyStoreSpec, src); err != nil {
+				return nil, err
+			}
+		} else {
+			if err := bip39.SetWordList(strings.ToLower(*language)); err != nil {
+				return nil, err
+			}
+			if src != nil {
+				if *mnemonicString, err = src.Read("mnemonic: "); err != nil {
+					return nil, err
+				}
+				// the passphrase can be omitted at the end of the input
+				if *passphrase, err = src.Read("passphrase: "); err != nil && !errors.Is(err, io.EOF) {
+					return nil, err
+				}
+			}
+			mnemonic := bip39.ParseMnemonic(*mnemonicString)
+			if _, err := bip39.MnemonicToEntropy(mnemonic); err != nil {
+				return nil, fmt.Errorf("invalid mnemonic: %w", err)
+			}
+			if seed, err = bip39.MnemonicToSeed(mnemonic, *passphrase); err != nil {
+				return nil, err
+			}
+		}
+		key, err := slip10.DeriveKeyFromPath(seed, curve, path)
+		if err != nil {
+			return nil, fmt.Errorf("failed deriving %s key: %w", curve.Name(), err)
+		}
+
+		res := newResult().
+			add("seed", hexutil.Bytes(seed).String()).
+			add("curve", curve.Name()).
+			add("path", path.String()).
+			add("privateKey", hexutil.Bytes(key.Key.Bytes()).String()).
+			add("chainCode", hexutil.Bytes(key.ChainCode).String()).
+			add("publicKey", hexutil.Bytes(key.Key.Public().Bytes()).String())
+		if curve.Name() == elliptic.Secp256k1().Name() {
+			// BIP-32 serialization is only defined for secp256k1
+			accountPath := path[:min(len(path), 3)]
+			account, err := slip10.DeriveKeyFromPath(seed, curve, accountPath)
+			if err != nil {
+				return nil, fmt.Errorf("failed deriving account key: %w", err)
+			}
+			xpub, err := account.XPub()
+			if err != nil {
+				return nil, err
+			}
+			res.add("accountPath", accountPath.String()).add("accountXPub", xpub)
+		}
+		if s, ok := key.Key.(eddsa.Seed); ok {
+			public, _ := s.Ed25519Key()
+			addr, err := address.Bech32(prefix, address.AddressFromPublicKey(public))
+			if err != nil {
+				return nil, err
+			}
+			res.add("address", addr)
+		}
+		return res, nil
+	}
+}
+
+func seedPassphrases(fs *flag.FlagSet) func([]string) (*result, error) {
+	mnemonicString := fs.String("mnemonic", "", "mnemonic sentence according to BIP-39")
+	language := fs.String("language", "english", "language of the mnemonic")
+	prefixString := fs.String("prefix", address.IOTAMainnet.String(), "network prefix of the Ed25519 addresses")
+	accounts := fs.Uint("accounts", 1, "number of accounts to compare")
+	count := fs.Uint("addresses", uint(wallet.DefaultAddressGap), "number of addresses per chain and account")
+	addressList := fs.String("address", "", "comma-separated known addresses to find the passphrase of")
+	secretFlags := secret.RegisterFlags(fs)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage of %s:\n", fs.Name())
+		fmt.Fprintf(fs.Output(), "\t%s [flags] [passphrase...]\n\n", fs.Name())
+		fmt.Fprintf(fs.Output(), "Without a secret source, the passphrases are taken from the arguments. ")
+		fmt.Fprintf(fs.Output(), "Otherwise, the mnemonic is read first and then one passphrase per line until an empty line or the end of the input.\n")
+		fmt.Fprintf(fs.Output(), "The wallet without passphrase is always compared.\n\n")
+		fs.PrintDefaults()
+	}
+	return func(args []string) (*result, error) {
+		prefix, err := address.ParsePrefix(*prefixString)
+		if err != nil {
+			return nil, fmt.Errorf("invalid prefix: %w", err)
+		}
+		if *accounts == 0 || *accounts >= uint(slip10.Hardened) || *count == 0 || *count >= uint(slip10.Hardened) {
+			return nil, errors.New("invalid number of accounts or addresses")
+		}
+		if err := bip39.SetWordList(strings.ToLower(*language)); err != nil {
+			return nil, err
+		}
+		src, err := secretFlags.Source()
+		if err != nil {
+			return nil, err
+		}
+		passphrases := []string{""}
+		if src != nil {
+			if *mnemonicString, err = src.Read("mnemonic: "); err != nil {
+				return nil, err
+			}
+			for {
+				passphrase, err := src.Read("passphrase (empty to finish): ")
+				if errors.Is(err, io.EOF) || (err == nil && passphrase == "") {
+					break
+				}
+				if err != nil {
+					return nil, err
+				}
+				passphrases = append(passphrases, passphrase)
+			}
+		} else {
+			passphrases = append(passphrases, args...)
+		}
+
+		m, err := wallet.NewManager(bip39.ParseMnemonic(*mnemonicString), nil, &wallet.Options{Prefix: prefix})
+		if err != nil {
+			return nil, fmt.Errorf("invalid mnemonic: %w", err)
+		}
+		opts := &wallet.ScanOptions{Accounts: uint32(*accounts), Addresses: uint32(*count)}
+		wallets, err := m.CompareAddresses(passphrases, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		var known wallet.UsedChecker
+		if *addressList != "" {
+			if known, err = wallet.KnownAddresses(strings.Split(*addressList, ",")...); err != nil {
+				return nil, err
+			}
+		}
+		res := newResult()
+		var found bool
+		for i, p := range wallets {
+			// the passphrases themselves are never printed
+			w := &passphraseWallet{
+				Fingerprint:  hexutil.Bytes(p.Fingerprint).String(),
+				FirstAddress: p.Addresses[0].Bech32,
+			}
+			for _, addr := range p.Addresses {
+				if known == nil {
+					break
+				}
+				if ok, _ := known(context.Background(), addr); ok {
+					w.Match = addr.Path.String()
+					found = true
+					break
+				}
+			}
+			name := fmt.Sprintf("passphrase%d", i)
+			if i == 0 {
+				name = "noPassphrase"
+			}
+			res.add(name, w)
+		}
+		if known != nil && !found {
+			res.add("match", "none")
+			return res, errInvalid
+		}
+		return res, nil
+	}
+}
+
+// passphraseWallet is the summary of the wallet of a single passphrase.
+type passphraseWallet struct {
+	Fingerprint  string `json:"fingerprint"`
+	FirstAddress string `json:"firstAddress"`
+	Match        string `json:"match,omitempty"`
+}
+
+func (w *passphraseWallet) String() string {
+	s := w.Fingerprint + " " + w.FirstAddress
+	if w.Match != "" {
+		s += " match at " + w.Match
+	}
+	return s
+}
+
+// loadSeed loads the seed from the key store backend described by spec.
+// The password of encrypted keystore files is read from src or, if it is nil, from the terminal.
+func loadSeed(spec string, src *secret.Source) ([]byte, error) {
+	store, err := backend.Open(spec, func() (string, error) {
+		if src == nil {
+			src = secret.FromTerminal()
+		}
+		return src.Read("keystore password: ")
+	})
+	if err != nil {
+		return nil, err
+	}
+	seed, err := store.Seed()
+	if err != nil {
+		return nil, fmt.Errorf("failed loading seed: %w", err)
+	}
+	return seed, nil
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/iotaledger/iota-crypto-demo/internal/hexutil"
+	"github.com/iotaledger/iota-crypto-demo/internal/rand"
+	"github.com/iotaledger/iota-crypto-demo/internal/secret"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip39"
+	"github.com/iotaledger/iota-crypto-demo/pkg/qr"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip39"
+)
+
+var shamirCommand = &command{
+	name:  "shamir",
+	usage: "split and combine SLIP-39 Shamir shares",
+	actions: []*action{
+		{name: "split", usage: "split the entropy of a BIP-39 mnemonic or a master secret into group shares", flags: shamirSplit},
+		{name: "combine", usage: "interactively combine shares to recover the master secret", flags: shamirCombine},
+	},
+}
+
+func shamirSplit(fs *flag.FlagSet) func([]string) (*result, error) {
+	mnemonicString := fs.String("mnemonic", "", "BIP-39 mnemonic whose entropy is split")
+	secretString := fs.String("secret", "", "hex-encoded master secret to split instead of a mnemonic entropy")
+	passphrase := fs.String("passphrase", "", "passphrase encrypting the master secret; can be empty")
+	groupThreshold := fs.Int("group-threshold", 1, "number of groups required to recover the master secret")
+	groupsString := fs.String("groups", "2of3", "comma separated member thresholds and counts of the groups, e.g. 1of1,2of3")
+	exponent := fs.Int("iteration-exponent", 1, "iteration exponent of the encryption")
+	extendable := fs.Bool("extendable", true, "create extendable shares")
+	printQR := fs.Bool("qr", false, "also print each share as QR code")
+	secretFlags := secret.RegisterFlags(fs)
+	return func([]string) (*result, error) {
+		src, err := secretFlags.Source()
+		if err != nil {
+			return nil, err
+		}
+		if src != nil {
+			if *mnemonicString, err = src.Read("mnemonic: "); err != nil {
+				return nil, err
+			}
+			// the passphrase can be omitted at the end of the input
+			if *passphrase, err = src.Read("passphrase: "); err != nil && !errors.Is(err, io.EOF) {
+				return nil, err
+			}
+		}
+
+		var masterSecret []byte
+		switch {
+		case *mnemonicString != "" && *secretString != "":
+			return nil, errors.New("only one of -mnemonic and -secret can be used")
+		case *secretString != "":
+			if masterSecret, err = hex.DecodeString(*secretString); err != nil {
+				return nil, fmt.Errorf("invalid secret: %w", err)
+			}
+		case *mnemonicString != "":
+			if masterSecret, err = bip39.MnemonicToEntropy(bip39.ParseMnemonic(*mnemonicString)); err != nil {
+				return nil, fmt.Errorf("invalid mnemonic: %w", err)
+			}
+		default:
+			return nil, errors.New("missing mnemonic or secret")
+		}
+		groups, err := parseGroups(*groupsString)
+		if err != nil {
+			return nil, err
+		}
+
+		cfg := &slip39.Config{
+			GroupThreshold:    *groupThreshold,
+			Groups:            groups,
+			IterationExponent: *exponent,
+			Extendable:        *extendable,
+		}
+		shares, err := slip39.Split(rand.Reader, masterSecret, *passphrase, cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		res := newResult().add("groupThreshold", *groupThreshold)
+		for i, group := range shares {
+			for j, share := range group {
+				key := fmt.Sprintf("group %d share %d/%d", i+1, j+1, len(group))
+				res.add(key, share.String())
+				if !*printQR {
+					continue
+				}
+				// upper case words allow the alphanumeric mode
+				code, err := qr.Encode(strings.ToUpper(share.String()), qr.L)
+				if err != nil {
+					return nil, fmt.Errorf("failed to encode QR code: %w", err)
+				}
+				res.addBlock(key+" QR code", code.ASCII())
+			}
+		}
+		return res, nil
+	}
+}
+
+func shamirCombine(fs *flag.FlagSet) func([]string) (*result, error) {
+	secretFlags := secret.RegisterFlags(fs)
+	return func([]string) (*result, error) {
+		src, err := secretFlags.Source()
+		if err != nil {
+			return nil, err
+		}
+		if src == nil {
+			src = secret.FromTerminal()
+		}
+
+		// the passphrase is entered first, so that every new share can directly be combined with the others
+		passphrase, err := src.Read("passphrase: ")
+		if err != nil {
+			return nil, err
+		}
+		var shares []slip39.Mnemonic
+		for {
+			line, err := src.Read(fmt.Sprintf("share %d: ", len(shares)+1))
+			if err != nil {
+				return nil, err
+			}
+			share := slip39.ParseMnemonic(line)
+			if len(share) == 0 {
+				continue
+			}
+			masterSecret, err := slip39.Combine(append(shares, share), passphrase)
+			switch {
+			case err == nil:
+				res := newResult().
+					add("shares", len(shares)+1).
+					add("masterSecret", hexutil.Bytes(masterSecret).String())
+				if mnemonic, err := bip39.EntropyToMnemonic(masterSecret); err == nil {
+					res.add("mnemonic", mnemonic.String())
+				}
+				return res, nil
+			case errors.Is(err, slip39.ErrInsufficientShares):
+				shares = append(shares, share)
+			default:
+				// reject the share, but keep the ones entered so far
+				fmt.Fprintf(os.Stderr, "share rejected: %s\n", err)
+			}
+		}
+	}
+}
+
+// parseGroups parses comma separated groups of the form <threshold>of<count>.
+func parseGroups(s string) ([]slip39.Group, error) {
+	var groups []slip39.Group
+	for _, part := range strings.Split(s, ",") {
+		t, c, ok := strings.Cut(strings.TrimSpace(part), "of")
+		threshold, err1 := strconv.Atoi(t)
+		count, err2 := strconv.Atoi(c)
+		if !ok || err1 != nil || err2 != nil {
+			return nil, fmt.Errorf("invalid group: %q", part)
+		}
+		groups = append(groups, slip39.Group{Threshold: threshold, Count: count})
+	}
+	return groups, nil
+}
@@ -0,0 +1,248 @@
+package main
+
+import (
+	"crypto"
+	"crypto/sha256"
+	"encoding"
+	"encoding/binary"
+	"flag"
+	"fmt"
+
+	"github.com/iotaledger/iota-crypto-demo/internal/hexutil"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bech32/address"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip32path"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip39"
+	"github.com/iotaledger/iota-crypto-demo/pkg/merkle"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10/eddsa"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10/elliptic"
+)
+
+// vectorsVersion is the version of the generated test vectors.
+// It must be incremented whenever the generated content changes.
+const vectorsVersion = 1
+
+var vectorsCommand = &command{
+	name:  "vectors",
+	usage: "generate deterministic test vectors for other implementations",
+	actions: []*action{
+		{name: "generate", usage: "generate bip39, slip10, bech32 and merkle test vectors; use -json for the full vectors", flags: vectorsGenerate},
+	},
+}
+
+type bip39Vector struct {
+	Language   string        `json:"language"`
+	Entropy    hexutil.Bytes `json:"entropy"`
+	Mnemonic   string        `json:"mnemonic"`
+	Passphrase string        `json:"passphrase"`
+	Seed       hexutil.Bytes `json:"seed"`
+}
+
+type slip10Vector struct {
+	Curve       string        `json:"curve"`
+	Seed        hexutil.Bytes `json:"seed"`
+	Path        string        `json:"path"`
+	Fingerprint hexutil.Bytes `json:"fingerprint"`
+	ChainCode   hexutil.Bytes `json:"chainCode"`
+	PrivateKey  hexutil.Bytes `json:"privateKey"`
+	PublicKey   hexutil.Bytes `json:"publicKey"`
+	XPub        string        `json:"xpub,omitempty"`
+}
+
+type bech32Vector struct {
+	Network string        `json:"network"`
+	Version string        `json:"version"`
+	Data    hexutil.Bytes `json:"data"`
+	Address string        `json:"address"`
+}
+
+type merkleVector struct {
+	Leaves []hexutil.Bytes `json:"leaves"`
+	Root   hexutil.Bytes   `json:"root"`
+	Proofs []proofSteps    `json:"proofs"`
+}
+
+// vector slices print their size in the text output.
+type (
+	bip39Vectors  []*bip39Vector
+	slip10Vectors []*slip10Vector
+	bech32Vectors []*bech32Vector
+	merkleVectors []*merkleVector
+)
+
+func (v bip39Vectors) String() string  { return fmt.Sprintf("%d vectors", len(v)) }
+func (v slip10Vectors) String() string { return fmt.Sprintf("%d vectors", len(v)) }
+func (v bech32Vectors) String() string { return fmt.Sprintf("%d vectors", len(v)) }
+func (v merkleVectors) String() string { return fmt.Sprintf("%d vectors", len(v)) }
+
+func vectorsGenerate(*flag.FlagSet) func([]string) (*result, error) {
+	return func([]string) (*result, error) {
+		bip39Vs, err := generateBIP39Vectors()
+		if err != nil {
+			return nil, err
+		}
+		slip10Vs, err := generateSLIP10Vectors()
+		if err != nil {
+			return nil, err
+		}
+		bech32Vs, err := generateBech32Vectors()
+		if err != nil {
+			return nil, err
+		}
+		merkleVs, err := generateMerkleVectors()
+		if err != nil {
+			return nil, err
+		}
+		return newResult().
+			add("version", vectorsVersion).
+			add("bip39", bip39Vs).
+			add("slip10", slip10Vs).
+			add("bech32", bech32Vs).
+			add("merkle", merkleVs), nil
+	}
+}
+
+// vectorBytes returns n deterministic pseudo-random bytes for the given label and counter.
+func vectorBytes(label string, counter int, n int) []byte {
+	var b []byte
+	for block := uint32(0); len(b) < n; block++ {
+		h := sha256.New()
+		h.Write([]byte("iota-crypto-demo vectors " + label))
+		h.Write(binary.BigEndian.AppendUint32(nil, uint32(counter)))
+		h.Write(binary.BigEndian.AppendUint32(nil, block))
+		b = h.Sum(b)
+	}
+	return b[:n]
+}
+
+func generateBIP39Vectors() (bip39Vectors, error) {
+	// restore the default word list after generating the vectors of all languages
+	defer bip39.SetWordList("english") //nolint:errcheck
+
+	var vs bip39Vectors
+	for _, language := range []string{"english", "japanese"} {
+		if err := bip39.SetWordList(language); err != nil {
+			return nil, err
+		}
+		for bits := 128; bits <= 512; bits += 32 {
+			entropies := [][]byte{
+				make([]byte, bits/8),
+				vectorBytes("bip39", bits, bits/8),
+			}
+			for i, entropy := range entropies {
+				mnemonic, err := bip39.EntropyToMnemonic(entropy)
+				if err != nil {
+					return nil, err
+				}
+				passphrase := []string{"", "TREZOR"}[i]
+				seed, err := bip39.MnemonicToSeed(mnemonic, passphrase)
+				if err != nil {
+					return nil, err
+				}
+				vs = append(vs, &bip39Vector{language, entropy, mnemonic.String(), passphrase, seed})
+			}
+		}
+	}
+	return vs, nil
+}
+
+func generateSLIP10Vectors() (slip10Vectors, error) {
+	seeds := [][]byte{
+		vectorBytes("slip10", 0, 16),
+		vectorBytes("slip10", 1, 64),
+	}
+	// paths with hardened indices only, as supported by all curves
+	hardenedPaths := []string{"m", "m/0'", "m/2147483647'", "m/44'/4218'/0'/0'/0'", "m/44'/4219'/1'/1'/1000000000'"}
+	// paths with non-hardened indices, which are not supported by Ed25519
+	normalPaths := []string{"m/0", "m/2147483647", "m/44'/0'/0'/0/0", "m/0'/1/2'/2/1000000000"}
+
+	var vs slip10Vectors
+	for _, curve := range []slip10.Curve{eddsa.Ed25519(), elliptic.Secp256k1(), elliptic.Nist256p1()} {
+		paths := hardenedPaths
+		if curve.Name() != eddsa.Ed25519().Name() {
+			paths = append(paths[:len(paths):len(paths)], normalPaths...)
+		}
+		for _, seed := range seeds {
+			for _, s := range paths {
+				path, err := bip32path.ParsePath(s)
+				if err != nil {
+					return nil, err
+				}
+				key, err := slip10.DeriveKeyFromPath(seed, curve, path)
+				if err != nil {
+					return nil, err
+				}
+				v := &slip10Vector{
+					Curve:       curve.Name(),
+					Seed:        seed,
+					Path:        path.String(),
+					Fingerprint: key.Fingerprint(),
+					ChainCode:   key.ChainCode,
+					PrivateKey:  key.Key.Bytes(),
+					PublicKey:   key.Key.Public().Bytes(),
+				}
+				if curve.Name() == elliptic.Secp256k1().Name() {
+					if v.XPub, err = key.XPub(); err != nil {
+						return nil, err
+					}
+				}
+				vs = append(vs, v)
+			}
+		}
+	}
+	return vs, nil
+}
+
+func generateBech32Vectors() (bech32Vectors, error) {
+	var vs bech32Vectors
+	for _, prefix := range []address.Prefix{address.IOTAMainnet, address.IOTADevnet, address.ShimmerMainnet, address.ShimmerDevnet} {
+		for i := 0; i < 2; i++ {
+			var outputID [address.OutputIDLength]byte
+			copy(outputID[:], vectorBytes("bech32 output", i, address.OutputIDLength))
+			addrs := []struct {
+				data []byte
+				addr address.Address
+			}{
+				{vectorBytes("bech32 key", i, 32), address.AddressFromPublicKey(vectorBytes("bech32 key", i, 32))},
+				{outputID[:], address.AliasAddressFromOutputID(outputID)},
+				{outputID[:], address.NFTAddressFromOutputID(outputID)},
+			}
+			for _, a := range addrs {
+				s, err := address.Bech32(prefix, a.addr)
+				if err != nil {
+					return nil, err
+				}
+				vs = append(vs, &bech32Vector{prefix.String(), a.addr.Version().String(), a.data, s})
+			}
+		}
+	}
+	return vs, nil
+}
+
+func generateMerkleVectors() (merkleVectors, error) {
+	hasher := merkle.NewHasher(crypto.BLAKE2b_256)
+
+	var vs merkleVectors
+	for _, n := range []int{1, 2, 3, 4, 5, 7, 8, 13} {
+		leaves := make([]hexutil.Bytes, n)
+		data := make([]encoding.BinaryMarshaler, n)
+		for i := range leaves {
+			leaves[i] = vectorBytes("merkle", i, 32)
+			data[i] = leaf(leaves[i])
+		}
+		root, err := hasher.Hash(data)
+		if err != nil {
+			return nil, err
+		}
+		v := &merkleVector{Leaves: leaves, Root: root}
+		for i := range leaves {
+			proof, err := hasher.Proof(data, i)
+			if err != nil {
+				return nil, err
+			}
+			v.Proofs = append(v.Proofs, newProofSteps(proof))
+		}
+		vs = append(vs, v)
+	}
+	return vs, nil
+}
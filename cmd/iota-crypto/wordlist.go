@@ -0,0 +1,124 @@
+package main
+
+import (
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip39"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip39/wordlist"
+)
+
+// prefixLength is the number of leading characters that should identify a word.
+const prefixLength = 4
+
+var wordlistCommand = &command{
+	name:  "wordlist",
+	usage: "validate, print and hash BIP-39 word lists",
+	actions: []*action{
+		{name: "validate", usage: "validate a candidate word list file", flags: wordlistValidate},
+		{name: "print", usage: "print a registered word list", flags: wordlistPrint},
+		{name: "checksum", usage: "compute the SHA-256 checksum of a registered word list or a file", flags: wordlistChecksum},
+	},
+}
+
+// words prints one word per line in the text output.
+type words []string
+
+func (w words) String() string { return strings.Join(w, "\n") + "\n" }
+
+func wordlistValidate(fs *flag.FlagSet) func([]string) (*result, error) {
+	file := fs.String("file", "", "path of the word list file with one word per line")
+	return func([]string) (*result, error) {
+		f, err := os.Open(*file)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+
+		res := newResult().add("file", *file)
+		l, err := wordlist.Parse(f)
+		if err != nil {
+			res.add("valid", false).add("error", err.Error())
+			return res, errInvalid
+		}
+		res.add("valid", true).add("checksum", checksum(l))
+
+		// BIP-39 recommends that the first four letters unambiguously identify a word
+		conflicts := prefixConflicts(l)
+		res.add("uniquePrefixes", len(conflicts) == 0)
+		if len(conflicts) > 0 {
+			res.add("prefixConflicts", strings.Join(conflicts, " "))
+		}
+		return res, nil
+	}
+}
+
+func wordlistPrint(fs *flag.FlagSet) func([]string) (*result, error) {
+	language := fs.String("language", "english", "language of the registered word list: "+strings.Join(bip39.WordLists(), ", "))
+	return func([]string) (*result, error) {
+		l, err := bip39.WordList(strings.ToLower(*language))
+		if err != nil {
+			return nil, err
+		}
+		ws := make(words, wordlist.Count)
+		for i := range ws {
+			ws[i] = l.Word(i)
+		}
+		return newResult().
+			add("language", *language).
+			add("checksum", checksum(l)).
+			addBlock("words", ws), nil
+	}
+}
+
+func wordlistChecksum(fs *flag.FlagSet) func([]string) (*result, error) {
+	language := fs.String("language", "english", "language of the registered word list")
+	file := fs.String("file", "", "path of a word list file; overrides -language")
+	return func([]string) (*result, error) {
+		if *file == "" {
+			l, err := bip39.WordList(strings.ToLower(*language))
+			if err != nil {
+				return nil, err
+			}
+			return newResult().add("language", *language).add("checksum", checksum(l)), nil
+		}
+
+		f, err := os.Open(*file)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		l, err := wordlist.Parse(f)
+		if err != nil {
+			return nil, fmt.Errorf("invalid word list: %w", err)
+		}
+		return newResult().add("file", *file).add("checksum", checksum(l)), nil
+	}
+}
+
+func checksum(l wordlist.List) string {
+	sum := wordlist.Checksum(l)
+	return hex.EncodeToString(sum[:])
+}
+
+// prefixConflicts returns the words that share their first prefixLength characters with a preceding word.
+func prefixConflicts(l wordlist.List) []string {
+	var conflicts []string
+	seen := map[string]string{}
+	for i := 0; i < wordlist.Count; i++ {
+		w := l.Word(i)
+		prefix := w
+		if r := []rune(w); len(r) > prefixLength {
+			prefix = string(r[:prefixLength])
+		}
+		if other, ok := seen[prefix]; ok {
+			conflicts = append(conflicts, other+"/"+w)
+			continue
+		}
+		seen[prefix] = w
+	}
+	return conflicts
+}
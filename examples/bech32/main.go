@@ -12,6 +12,7 @@ import (
 	"github.com/iotaledger/iota-crypto-demo/pkg/bech32"
 	"github.com/iotaledger/iota-crypto-demo/pkg/bech32/address"
 	"github.com/iotaledger/iota-crypto-demo/pkg/ed25519"
+	"github.com/iotaledger/iota-crypto-demo/pkg/qr"
 )
 
 // default values
@@ -33,6 +34,7 @@ var (
 	prefixString  = encode.String("prefix", defPrefix.String(), "network prefix")
 	versionString = encode.String("version", defVersion.String(), "address version")
 	keyString     = encode.String("key", hex.EncodeToString(defPublicKey), "hex-encoded public key / output ID")
+	printQR       = encode.Bool("qr", false, "print the address as QR code")
 
 	decode        = flag.NewFlagSet("decode", flag.ExitOnError)
 	addressString = decode.String("address", defBech32, "Bech32 encoded IOTA address")
@@ -120,12 +122,19 @@ func runEncode(arguments []string) error {
 
 	fmt.Println("==> Bech32 Address Encoder")
 	fmt.Printf("  public key (%d-byte):\t%x\n", len(key), key)
-	fmt.Printf("  hash (%d-char):\t%s\n", len(addr.String()), addr.String())
+	fmt.Printf("  hash (%d-char):\t%s\n", len(addr.Hex()), addr.Hex())
 	fmt.Printf("  addr bytes (%d-byte):\t%x\n", len(addr.Bytes()), addr.Bytes())
 	fmt.Printf("  network (%d-char):\t%s\n", len(prefix.String()), prefix.String())
 	fmt.Printf("  version (1-byte):\t0x%02x (%s)\n", uint(addr.Version()), addr.Version().String())
 	fmt.Printf("  bech32 (%d-char):\t%s\n", len(s), s)
 	fmt.Printf("    checksum\t\t%s\n", strings.Repeat(" ", len(s)-6)+strings.Repeat("^", 6))
+	if *printQR {
+		code, err := qr.EncodeAddress(s)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("  QR code (version %d):\n%s", code.Version(), code.ASCII())
+	}
 	return nil
 }
 
@@ -147,7 +156,7 @@ func runDecode(arguments []string) error {
 
 	fmt.Printf("  network (%d-char):\t%s\n", len(prefix.String()), prefix.String())
 	fmt.Printf("  version (1-byte):\t0x%02x (%s)\n", uint(addr.Version()), addr.Version().String())
-	fmt.Printf("  hash (%d-char):\t%s\n", len(addr.String()), addr.String())
+	fmt.Printf("  hash (%d-char):\t%s\n", len(addr.Hex()), addr.Hex())
 	fmt.Printf("  addr bytes (%d-byte):\t%x\n", len(addr.Bytes()), addr.Bytes())
 	return nil
 }
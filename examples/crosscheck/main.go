@@ -0,0 +1,133 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/bech32/address"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip32path"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip39"
+	"github.com/iotaledger/iota-crypto-demo/pkg/crosscheck"
+)
+
+var (
+	generate       = flag.NewFlagSet("generate", flag.ExitOnError)
+	mnemonicString = generate.String(
+		"mnemonic",
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+		"mnemonic sentence according to BIP-39 the device is initialized with",
+	)
+	passphrase   = generate.String("passphrase", "", "secret passphrase of the device; can be empty")
+	curveName    = generate.String("curve", "ed25519", "SLIP-10 curve: ed25519, secp256k1 or nist256p1")
+	pathsString  = generate.String("paths", "m/44'/4218'/0'/0'/0',m/44'/4218'/0'/0'/1'", "comma-separated BIP-32 paths")
+	prefixString = generate.String("prefix", address.IOTAMainnet.String(), "network prefix used for the Ed25519 addresses")
+
+	compare      = flag.NewFlagSet("compare", flag.ExitOnError)
+	expectedFile = compare.String("expected", "expected.json", "fixture generated by this tool")
+	actualFile   = compare.String("actual", "actual.json", "fixture containing the device responses")
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		help()
+	}
+
+	switch os.Args[1] {
+	case generate.Name():
+		if err := runGenerate(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+			os.Exit(1)
+		}
+	case compare.Name():
+		if err := runCompare(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+			os.Exit(1)
+		}
+	default:
+		help()
+	}
+}
+
+func help() {
+	fmt.Printf("Usage of %s:\n", os.Args[0])
+	fmt.Printf("\t<command> [arguments]\n\n")
+	fmt.Printf("The commands are:\n")
+	fmt.Printf("\t%s\tprint the expected derivations as JSON fixture\n", generate.Name())
+	fmt.Printf("\t%s\tcompare device responses with the expected fixture\n\n", compare.Name())
+	os.Exit(2)
+}
+
+func runGenerate(arguments []string) error {
+	if err := generate.Parse(arguments); err != nil {
+		return err
+	}
+	curve, err := crosscheck.Curve(*curveName)
+	if err != nil {
+		return err
+	}
+	prefix, err := address.ParsePrefix(*prefixString)
+	if err != nil {
+		return fmt.Errorf("invalid prefix: %w", err)
+	}
+	var paths []bip32path.Path
+	for _, s := range strings.Split(*pathsString, ",") {
+		path, err := bip32path.ParsePath(strings.TrimSpace(s))
+		if err != nil {
+			return fmt.Errorf("invalid path %q: %w", s, err)
+		}
+		paths = append(paths, path)
+	}
+
+	fixture, err := crosscheck.Generate(bip39.ParseMnemonic(*mnemonicString), *passphrase, curve, prefix, paths...)
+	if err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(fixture, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(b))
+	return nil
+}
+
+func runCompare(arguments []string) error {
+	if err := compare.Parse(arguments); err != nil {
+		return err
+	}
+	expected, err := readFixture(*expectedFile)
+	if err != nil {
+		return err
+	}
+	actual, err := readFixture(*actualFile)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("==> Hardware Wallet Cross-Check")
+	fmt.Printf("  tests:\t\t%d\n", len(expected.Tests))
+	mismatches := crosscheck.Compare(expected, actual)
+	for _, m := range mismatches {
+		fmt.Printf("  mismatch:\t\t%s\n", m)
+	}
+	if len(mismatches) > 0 {
+		return errors.New("device responses do not match")
+	}
+	fmt.Println("  result:\t\tall responses match")
+	return nil
+}
+
+func readFixture(name string) (*crosscheck.Fixture, error) {
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	var f crosscheck.Fixture
+	if err := json.Unmarshal(b, &f); err != nil {
+		return nil, fmt.Errorf("invalid fixture %s: %w", name, err)
+	}
+	return &f, nil
+}
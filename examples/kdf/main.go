@@ -1,17 +1,24 @@
 package main
 
 import (
+	"bytes"
 	"crypto/rand"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
+	"github.com/iotaledger/iota-crypto-demo/internal/secret"
 	"github.com/iotaledger/iota-crypto-demo/pkg/bech32/address"
 	"github.com/iotaledger/iota-crypto-demo/pkg/bip32path"
 	"github.com/iotaledger/iota-crypto-demo/pkg/bip39"
+	"github.com/iotaledger/iota-crypto-demo/pkg/ledger"
+	"github.com/iotaledger/iota-crypto-demo/pkg/qr"
 	"github.com/iotaledger/iota-crypto-demo/pkg/slip10"
 	"github.com/iotaledger/iota-crypto-demo/pkg/slip10/eddsa"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10/elliptic"
 )
 
 var (
@@ -40,6 +47,27 @@ var (
 		address.IOTAMainnet.String(),
 		"network prefix used for the Ed25519 address",
 	)
+	seedQR = flag.Bool(
+		"seedqr",
+		false,
+		"print the mnemonic as Compact SeedQR code for air-gapped backups",
+	)
+	useLedger = flag.Bool(
+		"ledger",
+		false,
+		"cross-check the address with the IOTA app on a connected Ledger device; requires a path 44'/coin'/account'/change'/index'",
+	)
+	count = flag.Uint(
+		"count",
+		0,
+		"number of consecutive addresses to print as a table; the hardened address index is appended to path",
+	)
+	startIndex = flag.Uint(
+		"start-index",
+		0,
+		"address index of the first address in the table printed with -count",
+	)
+	secretFlags = secret.RegisterFlags(flag.CommandLine)
 )
 
 func main() {
@@ -61,6 +89,9 @@ func run() error {
 	if err := bip39.SetWordList(strings.ToLower(*language)); err != nil {
 		return err
 	}
+	if err := readSecrets(); err != nil {
+		return err
+	}
 	if len(*mnemonicString) == 0 {
 		// no mnemonic given, generate
 		entropy, err = generateEntropy(256 / 8 /* 256 bits */)
@@ -88,6 +119,13 @@ func run() error {
 	fmt.Printf(" mnemonic (%d-word):\t%s\n", len(mnemonic), mnemonic)
 	fmt.Printf(" optional passphrase:\t\"%s\"\n", *passphrase)
 	fmt.Printf(" master seed (%d-byte):\t%x\n", len(seed), seed)
+	if *seedQR {
+		code, err := qr.EncodeSeedQR(mnemonic, true)
+		if err != nil {
+			return fmt.Errorf("failed to encode SeedQR: %w", err)
+		}
+		fmt.Printf(" Compact SeedQR (%dx%d):\n%s", code.Size(), code.Size(), code.ASCII())
+	}
 
 	fmt.Println("\n==> Ed25519 Private Key Derivation")
 
@@ -113,6 +151,116 @@ func run() error {
 	fmt.Printf(" chain code (%d-byte):\t%x\n", slip10.ChainCodeSize, key.ChainCode)
 	fmt.Printf(" address (%d-char):\t%s\n", len(addr), addr)
 
+	if err := printAccountXPub(seed, path); err != nil {
+		return err
+	}
+	if *count > 0 {
+		if err := printAddresses(seed, path, hrp); err != nil {
+			return err
+		}
+	}
+	if *useLedger {
+		return crossCheckLedger(path, address.AddressFromPublicKey(public).Bytes())
+	}
+	return nil
+}
+
+// printAccountXPub prints the BIP-32 extended public key of the account level of path, i.e. its first three indices.
+// As Ed25519 does not support public child derivation, the key is derived on secp256k1.
+func printAccountXPub(seed []byte, path bip32path.Path) error {
+	accountPath := path[:min(len(path), 3)]
+	key, err := slip10.DeriveKeyFromPath(seed, elliptic.Secp256k1(), accountPath)
+	if err != nil {
+		return fmt.Errorf("failed deriving account key: %w", err)
+	}
+	xpub, err := key.XPub()
+	if err != nil {
+		return fmt.Errorf("failed to serialize account key: %w", err)
+	}
+
+	fmt.Println("\n==> BIP-32 Account Extended Public Key")
+	fmt.Printf(" account path:\t%s\n", accountPath)
+	fmt.Printf(" xpub (%d-char):\t%s\n", len(xpub), xpub)
+	return nil
+}
+
+// printAddresses prints the path, public key and address of the consecutive address indices below path.
+func printAddresses(seed []byte, path bip32path.Path, hrp address.Prefix) error {
+	const hardened = 1 << 31
+	if *startIndex >= hardened || *count > hardened-*startIndex {
+		return fmt.Errorf("invalid address range: %d addresses starting at index %d", *count, *startIndex)
+	}
+
+	fmt.Println("\n==> Ed25519 Addresses")
+	for i := uint32(0); i < uint32(*count); i++ {
+		addrPath := append(path[:len(path):len(path)], (uint32(*startIndex)+i)|hardened)
+		key, err := slip10.DeriveKeyFromPath(seed, eddsa.Ed25519(), addrPath)
+		if err != nil {
+			return fmt.Errorf("failed deriving key for %s: %w", addrPath, err)
+		}
+		public, _ := key.Key.(eddsa.Seed).Ed25519Key()
+		addr, err := address.Bech32(hrp, address.AddressFromPublicKey(public))
+		if err != nil {
+			return fmt.Errorf("failed to encode address with %s prefix: %w", hrp, err)
+		}
+		fmt.Printf(" %s\t%x\t%s\n", addrPath, []byte(public), addr)
+	}
+	return nil
+}
+
+// crossCheckLedger generates the address for path on the Ledger device and compares it with expected.
+func crossCheckLedger(path bip32path.Path, expected []byte) error {
+	const hardened = 1 << 31
+	if len(path) != 5 || path[0] != 44|hardened || path[2]&path[3]&path[4]&hardened == 0 {
+		return fmt.Errorf("invalid Ledger path %s: expected 44'/coin'/account'/change'/index'", path)
+	}
+	var mode ledger.Mode
+	switch path[1] {
+	case 4218 | hardened:
+		mode = ledger.IOTAMainnet
+	case 1 | hardened:
+		mode = ledger.IOTATestnet
+	default:
+		return fmt.Errorf("invalid Ledger path %s: unsupported coin type", path)
+	}
+
+	t, closeDevice, err := ledger.OpenHID()
+	if err != nil {
+		return fmt.Errorf("failed to open Ledger: %w", err)
+	}
+	defer closeDevice() //nolint:errcheck
+
+	fmt.Println("\n==> Ledger Address Cross-Check")
+	fmt.Println(" confirm the address on the device")
+	app := ledger.NewApp(t)
+	if err := app.SetAccount(mode, path[2]&^hardened); err != nil {
+		return fmt.Errorf("failed to set Ledger account: %w", err)
+	}
+	addrs, err := app.Addresses(path[3]&^hardened == 1, path[4]&^hardened, 1, true)
+	if err != nil {
+		return fmt.Errorf("failed to generate Ledger address: %w", err)
+	}
+	fmt.Printf(" Ledger address (%d-byte):\t%x\n", len(addrs[0]), addrs[0])
+	if !bytes.Equal(addrs[0], expected) {
+		return errors.New("address mismatch between software and Ledger derivation")
+	}
+	fmt.Println(" addresses match")
+	return nil
+}
+
+// readSecrets replaces the mnemonic and passphrase flags with the secrets read from the selected source, if any.
+func readSecrets() error {
+	src, err := secretFlags.Source()
+	if err != nil || src == nil {
+		return err
+	}
+	if *mnemonicString, err = src.Read("mnemonic: "); err != nil {
+		return err
+	}
+	// the passphrase can be omitted at the end of the input
+	if *passphrase, err = src.Read("passphrase: "); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
 	return nil
 }
 
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/iotaledger/iota-crypto-demo/internal/secret"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bech32/address"
+	"github.com/iotaledger/iota-crypto-demo/pkg/legacy"
+	"github.com/iotaledger/iota-crypto-demo/pkg/migration"
+)
+
+var (
+	seedString = flag.String(
+		"seed",
+		"ZLNM9UHJWKTTDEZOTH9CXDEIFUJQCIACDPJIXPOWBDW9LTBHC9AQRIXTIHYLIIURLZCXNSTGNIVC9ISVB",
+		"legacy 81-tryte seed",
+	)
+	security = flag.Int(
+		"security",
+		2,
+		"W-OTS security level of the legacy addresses, 1-3",
+	)
+	start = flag.Uint64(
+		"start",
+		0,
+		"index of the first legacy address",
+	)
+	count = flag.Uint64(
+		"count",
+		3,
+		"number of legacy addresses to derive",
+	)
+	balance = flag.Uint64(
+		"balance",
+		1000000,
+		"balance of each legacy address used to build the migration bundle; 0 only prints the addresses",
+	)
+	addressString = flag.String(
+		"address",
+		"iota1qp22n849vywq9aayajl9utpfawlhjaskqfdzhphevsr4g5lt74mhckpnacr",
+		"bech32 encoded Ed25519 address receiving the migrated funds",
+	)
+	secretFlags = secret.RegisterFlags(flag.CommandLine)
+)
+
+func main() {
+	flag.Parse()
+
+	if err := run(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	src, err := secretFlags.Source()
+	if err != nil {
+		return err
+	}
+	if src != nil {
+		if *seedString, err = src.Read("seed: "); err != nil {
+			return err
+		}
+	}
+
+	_, addr, err := address.ParseBech32(*addressString)
+	if err != nil {
+		return fmt.Errorf("invalid address: %w", err)
+	}
+	target, ok := addr.(address.Ed25519Address)
+	if !ok {
+		return fmt.Errorf("invalid address: unsupported version %s", addr.Version())
+	}
+
+	indices := make([]uint64, *count)
+	for i := range indices {
+		indices[i] = *start + uint64(i)
+	}
+	inputs, err := migration.Inputs(*seedString, *security, indices...)
+	if err != nil {
+		return fmt.Errorf("failed deriving legacy addresses: %w", err)
+	}
+
+	fmt.Println("==> Legacy Addresses")
+	fmt.Printf(" seed (%d-tryte):\t%s\n", len(*seedString), *seedString)
+	fmt.Printf(" security level:\t%d\n", *security)
+	for _, in := range inputs {
+		withChecksum, err := legacy.AddChecksum(in.Address)
+		if err != nil {
+			return err
+		}
+		fmt.Printf(" address %d (%d-tryte):\t%s\n", in.Index, len(withChecksum), withChecksum)
+		in.Balance = *balance
+	}
+
+	migrationAddr, err := legacy.AddChecksum(migration.Address(target))
+	if err != nil {
+		return err
+	}
+	fmt.Println("\n==> Chrysalis Migration")
+	fmt.Printf(" target address (%d-char):\t%s\n", len(*addressString), *addressString)
+	fmt.Printf(" Ed25519 address (32-byte):\t%s\n", target.Hex())
+	fmt.Printf(" migration address (%d-tryte):\t%s\n", len(migrationAddr), migrationAddr)
+	if *balance == 0 {
+		return nil
+	}
+
+	bundle, err := migration.NewBundle(target, inputs, uint64(time.Now().Unix()))
+	if err != nil {
+		return fmt.Errorf("failed creating migration bundle: %w", err)
+	}
+	fmt.Printf(" migrated balance:\t%d\n", bundle[0].Value)
+	fmt.Printf(" bundle transactions:\t%d\n", len(bundle))
+	fmt.Printf(" bundle hash (%d-tryte):\t%s\n", len(bundle[0].Bundle), bundle[0].Bundle)
+
+	if err := migration.Sign(bundle, *seedString, inputs); err != nil {
+		return fmt.Errorf("failed signing migration bundle: %w", err)
+	}
+	if !bundle.VerifySignatures() {
+		return errors.New("invalid bundle signatures")
+	}
+	fmt.Println(" W-OTS signatures:\tvalid")
+	return nil
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/bech32/address"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip32path"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip39"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10/eddsa"
+)
+
+var (
+	words = flag.Int(
+		"words",
+		24,
+		"number of words of the mnemonic to recover",
+	)
+	language = flag.String(
+		"language",
+		"english",
+		"language of the mnemonics",
+	)
+	addressString = flag.String(
+		"address",
+		"",
+		"optional bech32 encoded Ed25519 address the recovered mnemonic is checked against",
+	)
+	pathString = flag.String(
+		"path",
+		"44'/4218'/0'/0'/0'",
+		"string form of the BIP-32 path of the address given with -address",
+	)
+)
+
+// maxTypoDistance is the maximum number of edits for words suggested for a typo.
+const maxTypoDistance = 2
+
+func main() {
+	flag.Parse()
+
+	if err := run(bufio.NewReader(os.Stdin)); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(in *bufio.Reader) error {
+	if err := bip39.SetWordList(strings.ToLower(*language)); err != nil {
+		return err
+	}
+	if *words < 12 || *words > 48 || *words%3 != 0 {
+		return fmt.Errorf("invalid word count: %d", *words)
+	}
+
+	fmt.Println("==> Mnemonic Recovery")
+	fmt.Println(" enter each word or a unique prefix of it; enter < to return to the previous word")
+	mnemonic := make(bip39.Mnemonic, *words)
+	for i := 0; i < len(mnemonic); {
+		word, back, err := readWord(in, i)
+		if err != nil {
+			return err
+		}
+		if back {
+			i = max(i-1, 0)
+			continue
+		}
+		mnemonic[i] = word
+		i++
+	}
+
+	// let the user correct single words until the checksum matches
+	for {
+		_, err := bip39.MnemonicToEntropy(mnemonic)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, bip39.ErrInvalidChecksum) {
+			return err
+		}
+		fmt.Println("\n checksum invalid: at least one word is wrong or in the wrong position")
+		n, err := prompt(in, fmt.Sprintf(" number of the word to correct (1-%d): ", len(mnemonic)))
+		if err != nil {
+			return err
+		}
+		i, err := strconv.Atoi(strings.TrimSpace(n))
+		if err != nil || i < 1 || i > len(mnemonic) {
+			continue
+		}
+		word, back, err := readWord(in, i-1)
+		if err != nil {
+			return err
+		}
+		if !back {
+			mnemonic[i-1] = word
+		}
+	}
+	fmt.Println("\n checksum valid")
+	fmt.Printf(" mnemonic (%d-word):\t%s\n", len(mnemonic), mnemonic)
+
+	if *addressString == "" {
+		return nil
+	}
+	return checkAddress(in, mnemonic)
+}
+
+// readWord prompts for the word with index i until a word of the list is entered or the user returns to the previous
+// word. Unique prefixes are completed and suggestions are printed for ambiguous prefixes and typos.
+func readWord(in *bufio.Reader, i int) (string, bool, error) {
+	for {
+		input, err := prompt(in, fmt.Sprintf(" word %d/%d: ", i+1, *words))
+		if err != nil {
+			return "", false, err
+		}
+		input = strings.ToLower(strings.TrimSpace(input))
+		switch {
+		case input == "":
+			continue
+		case input == "<":
+			return "", true, nil
+		case bip39.IsWord(input):
+			return input, false, nil
+		}
+
+		if completions := bip39.Complete(input); len(completions) == 1 {
+			fmt.Printf("  -> %s\n", completions[0])
+			return completions[0], false, nil
+		} else if len(completions) > 1 {
+			fmt.Printf("  ambiguous, possible words: %s\n", strings.Join(completions, " "))
+			continue
+		}
+		if suggestions := bip39.Suggest(input, maxTypoDistance); len(suggestions) > 0 {
+			fmt.Printf("  unknown word, did you mean: %s\n", strings.Join(suggestions, " "))
+		} else {
+			fmt.Println("  unknown word")
+		}
+	}
+}
+
+// checkAddress derives the address of the recovered mnemonic and compares it with the target address.
+func checkAddress(in *bufio.Reader, mnemonic bip39.Mnemonic) error {
+	prefix, target, err := address.ParseBech32(*addressString)
+	if err != nil {
+		return fmt.Errorf("invalid address: %w", err)
+	}
+	path, err := bip32path.ParsePath(*pathString)
+	if err != nil {
+		return fmt.Errorf("invalid path: %w", err)
+	}
+	passphrase, err := prompt(in, " passphrase (empty for none): ")
+	if err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+
+	seed, _ := bip39.MnemonicToSeed(mnemonic, passphrase)
+	key, err := slip10.DeriveKeyFromPath(seed, eddsa.Ed25519(), path)
+	if err != nil {
+		return fmt.Errorf("failed deriving Ed25519 key: %w", err)
+	}
+	public, _ := key.Key.(eddsa.Seed).Ed25519Key()
+	addr, err := address.Bech32(prefix, address.AddressFromPublicKey(public))
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("\n==> Address Check")
+	fmt.Printf(" address path:\t%s\n", path)
+	fmt.Printf(" derived address (%d-char):\t%s\n", len(addr), addr)
+	if !target.Equal(address.AddressFromPublicKey(public)) {
+		return errors.New("the recovered mnemonic does not match the address")
+	}
+	fmt.Println(" target address matches")
+	return nil
+}
+
+// prompt prints the prompt and returns the next line of the input.
+func prompt(in *bufio.Reader, s string) (string, error) {
+	fmt.Print(s)
+	line, err := in.ReadString('\n')
+	if err != nil && (!errors.Is(err, io.EOF) || line == "") {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
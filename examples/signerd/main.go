@@ -0,0 +1,177 @@
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/iotaledger/iota-crypto-demo/internal/secret"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bech32/address"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip39"
+	"github.com/iotaledger/iota-crypto-demo/pkg/keystore/backend"
+	"github.com/iotaledger/iota-crypto-demo/pkg/pkcs11"
+	"github.com/iotaledger/iota-crypto-demo/pkg/signerd"
+)
+
+var (
+	listen = flag.String(
+		"listen",
+		"127.0.0.1:8443",
+		"address the service listens on",
+	)
+	certFile = flag.String(
+		"cert",
+		"server.pem",
+		"PEM encoded certificate of the service",
+	)
+	keyFile = flag.String(
+		"key",
+		"server-key.pem",
+		"PEM encoded private key of the service certificate",
+	)
+	clientCAFile = flag.String(
+		"client-ca",
+		"client-ca.pem",
+		"PEM encoded certificates of the CAs whose client certificates are accepted",
+	)
+	keyStoreSpec = flag.String(
+		"keystore",
+		"",
+		"key store backend, i.e. \"file:<name>\", \"keyring:<service>/<account>\" or \"pkcs11:<library>[:<slot>]\"; if empty the seed is derived from the mnemonic",
+	)
+	mnemonicString = flag.String(
+		"mnemonic",
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+		"mnemonic sentence according to BIP-39",
+	)
+	language = flag.String(
+		"language",
+		"english",
+		"language of the mnemonics",
+	)
+	passphrase = flag.String(
+		"passphrase",
+		"",
+		"secret passphrase to generate the master seed; can be empty",
+	)
+	prefixString = flag.String(
+		"prefix",
+		address.IOTAMainnet.String(),
+		"network prefix of the returned addresses",
+	)
+	allowPatterns []string
+	secretFlags   = secret.RegisterFlags(flag.CommandLine)
+)
+
+func init() {
+	flag.Func("allow", "path pattern the service signs for, e.g. \"m/44'/4218'/0'/0'/*'\"; can be repeated", func(s string) error {
+		allowPatterns = append(allowPatterns, s)
+		return nil
+	})
+}
+
+func main() {
+	flag.Parse()
+
+	if err := run(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	if len(allowPatterns) == 0 {
+		return errors.New("no path allowed; use -allow to add patterns")
+	}
+	policy, err := signerd.ParsePolicy(allowPatterns...)
+	if err != nil {
+		return err
+	}
+	prefix, err := address.ParsePrefix(*prefixString)
+	if err != nil {
+		return fmt.Errorf("invalid prefix: %w", err)
+	}
+	store, err := openKeyStore()
+	if err != nil {
+		return err
+	}
+
+	cert, err := tls.LoadX509KeyPair(*certFile, *keyFile)
+	if err != nil {
+		return fmt.Errorf("failed loading certificate: %w", err)
+	}
+	pem, err := os.ReadFile(*clientCAFile)
+	if err != nil {
+		return fmt.Errorf("failed loading client CAs: %w", err)
+	}
+	clientCAs := x509.NewCertPool()
+	if !clientCAs.AppendCertsFromPEM(pem) {
+		return fmt.Errorf("no certificates found in %s", *clientCAFile)
+	}
+
+	srv := &http.Server{
+		Addr:              *listen,
+		Handler:           signerd.Handler(signerd.NewSigner(store, prefix, policy)),
+		TLSConfig:         signerd.ServerTLSConfig(cert, clientCAs),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	log.Printf("signing for %s on https://%s", strings.Join(allowPatterns, ", "), *listen)
+	return srv.ListenAndServeTLS("", "")
+}
+
+// openKeyStore opens the key store backend or, if none is given, returns the BIP-39 seed of the mnemonic and
+// passphrase kept in memory.
+func openKeyStore() (backend.KeyStore, error) {
+	src, err := secretFlags.Source()
+	if err != nil {
+		return nil, err
+	}
+	if *keyStoreSpec != "" {
+		store, err := backend.Open(*keyStoreSpec, func() (string, error) {
+			if src == nil {
+				src = secret.FromTerminal()
+			}
+			return src.Read("keystore password or PIN: ")
+		})
+		if err != nil {
+			return nil, err
+		}
+		// load the seed once to fail early, e.g. on a wrong password; tokens never export it
+		seed, err := store.Seed()
+		if err != nil && !errors.Is(err, pkcs11.ErrNotExportable) {
+			return nil, fmt.Errorf("failed loading seed: %w", err)
+		}
+		clear(seed)
+		return store, nil
+	}
+
+	if err := bip39.SetWordList(strings.ToLower(*language)); err != nil {
+		return nil, err
+	}
+	if src != nil {
+		if *mnemonicString, err = src.Read("mnemonic: "); err != nil {
+			return nil, err
+		}
+		// the passphrase can be omitted at the end of the input
+		if *passphrase, err = src.Read("passphrase: "); err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+	}
+	mnemonic := bip39.ParseMnemonic(*mnemonicString)
+	if _, err := bip39.MnemonicToEntropy(mnemonic); err != nil {
+		return nil, fmt.Errorf("invalid mnemonic: %w", err)
+	}
+	seed, err := bip39.MnemonicToSeed(mnemonic, *passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return backend.NewMemory(seed), nil
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/iotaledger/iota-crypto-demo/internal/rand"
+	"github.com/iotaledger/iota-crypto-demo/internal/secret"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bech32/address"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip32path"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip39"
+	"github.com/iotaledger/iota-crypto-demo/pkg/ed25519"
+	"github.com/iotaledger/iota-crypto-demo/pkg/signedmessage"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10/eddsa"
+)
+
+// default values
+var (
+	defPrefix = address.IOTAMainnet
+	defSeed   = func() []byte {
+		_, priv, _ := ed25519.GenerateKey(rand.Reader)
+		return priv.Seed()
+	}()
+	defMessage = "I control this address."
+)
+
+var (
+	sign           = flag.NewFlagSet("sign", flag.ExitOnError)
+	prefixString   = sign.String("prefix", defPrefix.String(), "network prefix")
+	seedString     = sign.String("key", hex.EncodeToString(defSeed), "hex-encoded Ed25519 private key seed")
+	messageString  = sign.String("message", defMessage, "message to sign")
+	mnemonicString = sign.String("mnemonic", "", "BIP-39 mnemonic to derive the key from instead of using -key")
+	passphrase     = sign.String("passphrase", "", "secret passphrase of the mnemonic; can be empty")
+	pathString     = sign.String("path", "44'/4218'/0'/0'/0'", "BIP-32 path of the derived key")
+	secretFlags    = secret.RegisterFlags(sign)
+
+	verify     = flag.NewFlagSet("verify", flag.ExitOnError)
+	jsonString = verify.String("json", "", "JSON encoded signed message")
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		help()
+	}
+
+	switch os.Args[1] {
+	case sign.Name():
+		if err := runSign(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+			os.Exit(1)
+		}
+	case verify.Name():
+		if err := runVerify(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+			os.Exit(1)
+		}
+	default:
+		help()
+	}
+}
+
+func help() {
+	fmt.Printf("Usage of %s:\n", os.Args[0])
+	fmt.Printf("\t<command> [arguments]\n\n")
+	fmt.Printf("The commands are:\n")
+	fmt.Printf("\t%s\tsign a message with the key of an address\n", sign.Name())
+	fmt.Printf("\t%s\tverify a signed message\n\n", verify.Name())
+	os.Exit(2)
+}
+
+func runSign(arguments []string) error {
+	if err := sign.Parse(arguments); err != nil {
+		return err
+	}
+	prefix, err := address.ParsePrefix(*prefixString)
+	if err != nil {
+		return fmt.Errorf("invalid prefix: %w", err)
+	}
+	seed, path, err := signingKey()
+	if err != nil {
+		return err
+	}
+
+	m, err := signedmessage.Sign(ed25519.NewKeyFromSeed(seed), prefix, *messageString)
+	if err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("==> Signed Message")
+	if path != nil {
+		fmt.Printf("  key path:\t\t%s\n", path)
+	}
+	fmt.Printf("  address (%d-char):\t%s\n", len(m.Address), m.Address)
+	fmt.Printf("  message (%d-byte):\t%s\n", len(m.Message), m.Message)
+	fmt.Printf("  digest (32-byte):\t%x\n", signedmessage.Digest(m.Address, m.Message))
+	fmt.Printf("  public key (%d-byte):\t%s\n", len(m.PublicKey), m.PublicKey)
+	fmt.Printf("  signature (%d-byte):\t%s\n", len(m.Signature), m.Signature)
+	fmt.Printf("  json:\n%s\n", b)
+
+	// verify the envelope like a recipient would
+	if err := m.Verify(); err != nil {
+		return fmt.Errorf("failed to verify signed message: %w", err)
+	}
+	fmt.Println("  signature:\t\tvalid")
+	return nil
+}
+
+// signingKey returns the Ed25519 private key seed given with -key or, if a mnemonic is given, the seed derived from the
+// mnemonic and path together with the path.
+func signingKey() ([]byte, bip32path.Path, error) {
+	src, err := secretFlags.Source()
+	if err != nil {
+		return nil, nil, err
+	}
+	if src != nil {
+		if *mnemonicString, err = src.Read("mnemonic: "); err != nil {
+			return nil, nil, err
+		}
+		// the passphrase can be omitted at the end of the input
+		if *passphrase, err = src.Read("passphrase: "); err != nil && !errors.Is(err, io.EOF) {
+			return nil, nil, err
+		}
+	}
+
+	if *mnemonicString == "" {
+		seed, err := hex.DecodeString(*seedString)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid key: %w", err)
+		}
+		if len(seed) != ed25519.SeedSize {
+			return nil, nil, fmt.Errorf("invalid key: length %d", len(seed))
+		}
+		return seed, nil, nil
+	}
+
+	mnemonic := bip39.ParseMnemonic(*mnemonicString)
+	if _, err := bip39.MnemonicToEntropy(mnemonic); err != nil {
+		return nil, nil, fmt.Errorf("invalid mnemonic: %w", err)
+	}
+	path, err := bip32path.ParsePath(*pathString)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid path: %w", err)
+	}
+	masterSeed, _ := bip39.MnemonicToSeed(mnemonic, *passphrase)
+	key, err := slip10.DeriveKeyFromPath(masterSeed, eddsa.Ed25519(), path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed deriving Ed25519 key: %w", err)
+	}
+	return key.Key.Bytes(), path, nil
+}
+
+func runVerify(arguments []string) error {
+	if err := verify.Parse(arguments); err != nil {
+		return err
+	}
+	var m signedmessage.SignedMessage
+	if err := json.Unmarshal([]byte(*jsonString), &m); err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	fmt.Println("==> Signed Message Verifier")
+	fmt.Printf("  address (%d-char):\t%s\n", len(m.Address), m.Address)
+	fmt.Printf("  message (%d-byte):\t%s\n", len(m.Message), m.Message)
+	if err := m.Verify(); err != nil {
+		return err
+	}
+	fmt.Println("  signature:\t\tvalid")
+	return nil
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/iotaledger/iota-crypto-demo/internal/secret"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bech32/address"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip32path"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip39"
+	"github.com/iotaledger/iota-crypto-demo/pkg/ed25519"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10/eddsa"
+)
+
+var (
+	addressString = flag.String(
+		"address",
+		"iota1qp22n849vywq9aayajl9utpfawlhjaskqfdzhphevsr4g5lt74mhckpnacr",
+		"bech32 encoded address to verify",
+	)
+	keyString = flag.String(
+		"key",
+		"",
+		"hex-encoded Ed25519 public key; if empty the key is derived from mnemonic and path",
+	)
+	mnemonicString = flag.String(
+		"mnemonic",
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+		"mnemonic sentence according to BIP-39",
+	)
+	language = flag.String(
+		"language",
+		"english",
+		"language of the mnemonics",
+	)
+	passphrase = flag.String(
+		"passphrase",
+		"",
+		"secret passphrase to generate the master seed; can be empty",
+	)
+	pathString = flag.String(
+		"path",
+		"44'/4218'/1'/0'",
+		"string form of the BIP-32 address path to derive the Ed25519 key",
+	)
+	secretFlags = secret.RegisterFlags(flag.CommandLine)
+)
+
+// errMismatch is returned when the address does not correspond to the key.
+var errMismatch = errors.New("address does not match the key")
+
+func main() {
+	flag.Parse()
+
+	if err := run(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	prefix, addr, err := address.ParseBech32(*addressString)
+	if err != nil {
+		return fmt.Errorf("invalid address: %w", err)
+	}
+
+	fmt.Println("==> Decoded Address")
+	fmt.Printf(" address (%d-char):\t%s\n", len(*addressString), *addressString)
+	fmt.Printf(" network prefix:\t%s\n", prefix)
+	fmt.Printf(" address kind:\t%s\n", addr.Version())
+	fmt.Printf(" hash (%d-byte):\t%x\n", len(addr.Bytes())-1, addr.Bytes()[1:])
+
+	if addr.Version() != address.Ed25519 {
+		return fmt.Errorf("cannot verify %s address against a key", addr.Version())
+	}
+	public, source, err := publicKey()
+	if err != nil {
+		return err
+	}
+	expected := address.AddressFromPublicKey(public)
+
+	fmt.Println("\n==> Key Verification")
+	fmt.Printf(" key source:\t%s\n", source)
+	fmt.Printf(" public key (%d-byte):\t%x\n", len(public), []byte(public))
+	fmt.Printf(" key hash (%d-byte):\t%s\n", len(expected.Bytes())-1, expected.Hex())
+	if !addr.Equal(expected) {
+		fmt.Println(" result:\tmismatch")
+		return errMismatch
+	}
+	fmt.Println(" result:\tmatch")
+	return nil
+}
+
+// publicKey returns the Ed25519 public key given directly or derived from the mnemonic and path,
+// together with a description of its origin.
+func publicKey() (ed25519.PublicKey, string, error) {
+	if *keyString != "" {
+		key, err := hex.DecodeString(*keyString)
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid key: %w", err)
+		}
+		if len(key) != ed25519.PublicKeySize {
+			return nil, "", fmt.Errorf("invalid key: length %d", len(key))
+		}
+		return key, "public key", nil
+	}
+
+	if err := bip39.SetWordList(strings.ToLower(*language)); err != nil {
+		return nil, "", err
+	}
+	src, err := secretFlags.Source()
+	if err != nil {
+		return nil, "", err
+	}
+	if src != nil {
+		if *mnemonicString, err = src.Read("mnemonic: "); err != nil {
+			return nil, "", err
+		}
+		// the passphrase can be omitted at the end of the input
+		if *passphrase, err = src.Read("passphrase: "); err != nil && !errors.Is(err, io.EOF) {
+			return nil, "", err
+		}
+	}
+	mnemonic := bip39.ParseMnemonic(*mnemonicString)
+	if _, err := bip39.MnemonicToEntropy(mnemonic); err != nil {
+		return nil, "", fmt.Errorf("invalid mnemonic: %w", err)
+	}
+	path, err := bip32path.ParsePath(*pathString)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid path: %w", err)
+	}
+	seed, _ := bip39.MnemonicToSeed(mnemonic, *passphrase)
+	key, err := slip10.DeriveKeyFromPath(seed, eddsa.Ed25519(), path)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed deriving Ed25519 key: %w", err)
+	}
+	public, _ := key.Key.(eddsa.Seed).Ed25519Key()
+	return public, fmt.Sprintf("mnemonic at %s", path), nil
+}
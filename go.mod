@@ -6,7 +6,9 @@ require (
 	filippo.io/edwards25519 v1.1.0
 	github.com/stretchr/testify v1.9.0
 	golang.org/x/crypto v0.22.0
+	golang.org/x/term v0.19.0
 	golang.org/x/text v0.14.0
+	google.golang.org/protobuf v1.33.0
 )
 
 require (
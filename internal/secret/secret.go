@@ -0,0 +1,90 @@
+// Package secret reads mnemonics, passphrases and seeds from standard input, a file descriptor or an interactive
+// prompt, so that they do not leak through command-line flags into the shell history or the process list.
+package secret
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// Source reads one secret per line.
+type Source struct {
+	r        *bufio.Reader
+	terminal *os.File
+}
+
+// FromReader returns a Source reading newline-separated secrets from r.
+func FromReader(r io.Reader) *Source {
+	return &Source{r: bufio.NewReader(r)}
+}
+
+// FromTerminal returns a Source prompting for each secret on standard error and reading it from the terminal
+// connected to standard input with echo disabled.
+func FromTerminal() *Source {
+	return &Source{terminal: os.Stdin}
+}
+
+// Read returns the next secret without the trailing line break.
+// For interactive sources, the prompt is displayed first. At the end of the input, the returned error wraps io.EOF.
+func (s *Source) Read(prompt string) (string, error) {
+	if s.terminal != nil {
+		fmt.Fprint(os.Stderr, prompt)
+		defer fmt.Fprintln(os.Stderr)
+
+		line, err := term.ReadPassword(int(s.terminal.Fd()))
+		if err != nil {
+			return "", fmt.Errorf("failed to read secret: %w", err)
+		}
+		return string(line), nil
+	}
+	line, err := s.r.ReadString('\n')
+	if err != nil && (!errors.Is(err, io.EOF) || line == "") {
+		return "", fmt.Errorf("failed to read secret: %w", err)
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// Flags are the command-line flags selecting the Source of secrets.
+type Flags struct {
+	stdin  *bool
+	prompt *bool
+	fd     *int
+}
+
+// RegisterFlags defines the -stdin, -prompt and -fd flags in fs.
+func RegisterFlags(fs *flag.FlagSet) *Flags {
+	return &Flags{
+		stdin:  fs.Bool("stdin", false, "read the secrets line by line from standard input"),
+		prompt: fs.Bool("prompt", false, "prompt for the secrets with terminal echo disabled"),
+		fd:     fs.Int("fd", -1, "read the secrets line by line from the given file descriptor"),
+	}
+}
+
+// Source returns the Source selected by the flags or nil, if the secrets are passed as flags.
+func (f *Flags) Source() (*Source, error) {
+	n := 0
+	for _, set := range []bool{*f.stdin, *f.prompt, *f.fd >= 0} {
+		if set {
+			n++
+		}
+	}
+	switch {
+	case n > 1:
+		return nil, errors.New("at most one of -stdin, -prompt and -fd can be used")
+	case *f.stdin:
+		return FromReader(os.Stdin), nil
+	case *f.prompt:
+		return FromTerminal(), nil
+	case *f.fd >= 0:
+		return FromReader(os.NewFile(uintptr(*f.fd), fmt.Sprintf("fd%d", *f.fd))), nil
+	default:
+		return nil, nil
+	}
+}
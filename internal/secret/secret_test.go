@@ -0,0 +1,23 @@
+package secret_test
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/iotaledger/iota-crypto-demo/internal/secret"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromReader(t *testing.T) {
+	s := secret.FromReader(strings.NewReader("abandon about\r\n\nlast"))
+
+	for _, expected := range []string{"abandon about", "", "last"} {
+		line, err := s.Read("")
+		require.NoError(t, err)
+		assert.Equal(t, expected, line)
+	}
+	_, err := s.Read("")
+	assert.ErrorIs(t, err, io.EOF)
+}
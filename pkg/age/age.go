@@ -0,0 +1,389 @@
+/*
+Package age implements the minimal parts of the age file encryption format v1 to seal seed and mnemonic backups to
+one or more X25519 recipients.
+
+The encrypted files are compatible with the reference implementation at https://age-encryption.org, both in binary
+and in ASCII armored form. Ed25519 wallet keys can be used as recipients and identities via their conversion to
+X25519 keys.
+*/
+package age
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/curve25519"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/bech32"
+	"github.com/iotaledger/iota-crypto-demo/pkg/ed25519"
+	"github.com/iotaledger/iota-crypto-demo/pkg/hkdf"
+)
+
+const (
+	intro        = "age-encryption.org/v1\n"
+	footerPrefix = "---"
+	stanzaPrefix = "-> "
+	x25519Type   = "X25519"
+	x25519Label  = "age-encryption.org/v1/X25519"
+
+	recipientHRP = "age"
+	identityHRP  = "age-secret-key-"
+
+	fileKeySize = 16
+	nonceSize   = 16
+	chunkSize   = 64 * 1024
+	columns     = 64
+)
+
+var (
+	// ErrInvalidRecipient is returned when a recipient string or key is invalid.
+	ErrInvalidRecipient = errors.New("invalid recipient")
+	// ErrInvalidIdentity is returned when an identity string is invalid.
+	ErrInvalidIdentity = errors.New("invalid identity")
+	// ErrInvalidHeader is returned when the header of an age file is malformed or its MAC does not match.
+	ErrInvalidHeader = errors.New("invalid header")
+	// ErrNoIdentityMatched is returned when none of the identities can decrypt the file key.
+	ErrNoIdentityMatched = errors.New("no identity matched any of the recipients")
+	// ErrInvalidPayload is returned when the payload cannot be decrypted.
+	ErrInvalidPayload = errors.New("invalid payload")
+)
+
+var b64 = base64.RawStdEncoding.Strict()
+
+// Recipient is an X25519 public key to which files can be encrypted.
+type Recipient struct {
+	publicKey []byte
+}
+
+// ParseRecipient parses the Bech32 encoded recipient public key starting with "age1".
+func ParseRecipient(s string) (*Recipient, error) {
+	hrp, key, err := bech32.Decode(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRecipient, err)
+	}
+	if hrp != recipientHRP || len(key) != curve25519.PointSize {
+		return nil, ErrInvalidRecipient
+	}
+	return &Recipient{key}, nil
+}
+
+// RecipientFromEd25519 returns the recipient corresponding to the Ed25519 public key.
+func RecipientFromEd25519(publicKey ed25519.PublicKey) (*Recipient, error) {
+	key, err := ed25519.PublicKeyToX25519(publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRecipient, err)
+	}
+	return &Recipient{key}, nil
+}
+
+// String returns the Bech32 encoding of the recipient.
+func (r *Recipient) String() string {
+	s, _ := bech32.Encode(recipientHRP, r.publicKey)
+	return s
+}
+
+// stanza returns the X25519 recipient stanza wrapping fileKey.
+func (r *Recipient) stanza(rand io.Reader, fileKey []byte) (*stanza, error) {
+	ephemeral := make([]byte, curve25519.ScalarSize)
+	if _, err := io.ReadFull(rand, ephemeral); err != nil {
+		return nil, err
+	}
+	share, _ := curve25519.X25519(ephemeral, curve25519.Basepoint)
+	shared, err := curve25519.X25519(ephemeral, r.publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRecipient, err)
+	}
+	aead, _ := chacha20poly1305.New(wrapKey(shared, share, r.publicKey))
+	body := aead.Seal(nil, make([]byte, chacha20poly1305.NonceSize), fileKey, nil)
+	return &stanza{typ: x25519Type, args: []string{b64.EncodeToString(share)}, body: body}, nil
+}
+
+// Identity is an X25519 private key which can decrypt files encrypted to its recipient.
+type Identity struct {
+	secretKey []byte
+}
+
+// GenerateIdentity generates a new random identity using entropy from rand.
+func GenerateIdentity(rand io.Reader) (*Identity, error) {
+	key := make([]byte, curve25519.ScalarSize)
+	if _, err := io.ReadFull(rand, key); err != nil {
+		return nil, err
+	}
+	return &Identity{key}, nil
+}
+
+// ParseIdentity parses the Bech32 encoded identity starting with "AGE-SECRET-KEY-1".
+func ParseIdentity(s string) (*Identity, error) {
+	hrp, key, err := bech32.Decode(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidIdentity, err)
+	}
+	if hrp != identityHRP || len(key) != curve25519.ScalarSize {
+		return nil, ErrInvalidIdentity
+	}
+	return &Identity{key}, nil
+}
+
+// IdentityFromEd25519 returns the identity corresponding to the Ed25519 private key.
+func IdentityFromEd25519(privateKey ed25519.PrivateKey) *Identity {
+	return &Identity{ed25519.PrivateKeyToX25519(privateKey)}
+}
+
+// String returns the uppercase Bech32 encoding of the identity.
+func (i *Identity) String() string {
+	s, _ := bech32.EncodeUpper(identityHRP, i.secretKey)
+	return s
+}
+
+// Recipient returns the recipient corresponding to the identity.
+func (i *Identity) Recipient() *Recipient {
+	key, _ := curve25519.X25519(i.secretKey, curve25519.Basepoint)
+	return &Recipient{key}
+}
+
+// unwrap returns the file key if s is an X25519 stanza addressed to the identity, or nil otherwise.
+func (i *Identity) unwrap(s *stanza) ([]byte, error) {
+	if s.typ != x25519Type {
+		return nil, nil
+	}
+	if len(s.args) != 1 {
+		return nil, fmt.Errorf("%w: invalid X25519 stanza", ErrInvalidHeader)
+	}
+	share, err := b64.DecodeString(s.args[0])
+	if err != nil || len(share) != curve25519.PointSize {
+		return nil, fmt.Errorf("%w: invalid X25519 share", ErrInvalidHeader)
+	}
+	if len(s.body) != fileKeySize+chacha20poly1305.Overhead {
+		return nil, fmt.Errorf("%w: invalid X25519 body", ErrInvalidHeader)
+	}
+	shared, err := curve25519.X25519(i.secretKey, share)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidHeader, err)
+	}
+	aead, _ := chacha20poly1305.New(wrapKey(shared, share, i.Recipient().publicKey))
+	fileKey, err := aead.Open(nil, make([]byte, chacha20poly1305.NonceSize), s.body, nil)
+	if err != nil {
+		// the stanza is addressed to a different recipient
+		return nil, nil //nolint:nilerr
+	}
+	return fileKey, nil
+}
+
+// Encrypt encrypts plaintext to all the recipients and returns the binary age file.
+// The file key, ephemeral keys and payload nonce are read from rand.
+func Encrypt(rand io.Reader, plaintext []byte, recipients ...*Recipient) ([]byte, error) {
+	if len(recipients) == 0 {
+		return nil, fmt.Errorf("%w: no recipients", ErrInvalidRecipient)
+	}
+	fileKey := make([]byte, fileKeySize)
+	if _, err := io.ReadFull(rand, fileKey); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(intro)
+	for _, r := range recipients {
+		s, err := r.stanza(rand, fileKey)
+		if err != nil {
+			return nil, err
+		}
+		s.marshal(&buf)
+	}
+	buf.WriteString(footerPrefix)
+	mac := headerMAC(fileKey, buf.Bytes())
+	buf.WriteString(" " + b64.EncodeToString(mac) + "\n")
+
+	nonce := make([]byte, nonceSize)
+	if _, err := io.ReadFull(rand, nonce); err != nil {
+		return nil, err
+	}
+	buf.Write(nonce)
+	buf.Write(sealPayload(payloadKey(fileKey, nonce), plaintext))
+	return buf.Bytes(), nil
+}
+
+// Decrypt decrypts the binary age file with one of the identities.
+func Decrypt(file []byte, identities ...*Identity) ([]byte, error) {
+	stanzas, header, mac, rest, err := parseHeader(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var fileKey []byte
+	for _, s := range stanzas {
+		for _, id := range identities {
+			if fileKey, err = id.unwrap(s); err != nil {
+				return nil, err
+			}
+			if fileKey != nil {
+				break
+			}
+		}
+		if fileKey != nil {
+			break
+		}
+	}
+	if fileKey == nil {
+		return nil, ErrNoIdentityMatched
+	}
+	if !hmac.Equal(headerMAC(fileKey, header), mac) {
+		return nil, fmt.Errorf("%w: MAC mismatch", ErrInvalidHeader)
+	}
+
+	if len(rest) < nonceSize {
+		return nil, fmt.Errorf("%w: missing nonce", ErrInvalidPayload)
+	}
+	return openPayload(payloadKey(fileKey, rest[:nonceSize]), rest[nonceSize:])
+}
+
+// stanza represents a recipient stanza of the header.
+type stanza struct {
+	typ  string
+	args []string
+	body []byte
+}
+
+func (s *stanza) marshal(buf *bytes.Buffer) {
+	buf.WriteString(stanzaPrefix + strings.Join(append([]string{s.typ}, s.args...), " ") + "\n")
+	body := b64.EncodeToString(s.body)
+	// the body is wrapped at 64 columns and the last line is always shorter than that
+	for len(body) >= columns {
+		buf.WriteString(body[:columns] + "\n")
+		body = body[columns:]
+	}
+	buf.WriteString(body + "\n")
+}
+
+// parseHeader parses the header of file and returns its stanzas, the header bytes covered by the MAC, the MAC and the
+// remaining payload.
+func parseHeader(file []byte) ([]*stanza, []byte, []byte, []byte, error) {
+	if !bytes.HasPrefix(file, []byte(intro)) {
+		return nil, nil, nil, nil, fmt.Errorf("%w: invalid version line", ErrInvalidHeader)
+	}
+	pos := len(intro)
+	nextLine := func() (string, bool) {
+		i := bytes.IndexByte(file[pos:], '\n')
+		if i < 0 {
+			return "", false
+		}
+		line := string(file[pos : pos+i])
+		pos += i + 1
+		return line, true
+	}
+
+	var stanzas []*stanza
+	for {
+		start := pos
+		line, ok := nextLine()
+		if !ok {
+			return nil, nil, nil, nil, fmt.Errorf("%w: unexpected end of header", ErrInvalidHeader)
+		}
+		if strings.HasPrefix(line, footerPrefix+" ") {
+			mac, err := b64.DecodeString(strings.TrimPrefix(line, footerPrefix+" "))
+			if err != nil || len(mac) != sha256.Size {
+				return nil, nil, nil, nil, fmt.Errorf("%w: invalid MAC", ErrInvalidHeader)
+			}
+			return stanzas, file[:start+len(footerPrefix)], mac, file[pos:], nil
+		}
+		if !strings.HasPrefix(line, stanzaPrefix) {
+			return nil, nil, nil, nil, fmt.Errorf("%w: invalid stanza", ErrInvalidHeader)
+		}
+		fields := strings.Split(strings.TrimPrefix(line, stanzaPrefix), " ")
+		if fields[0] == "" {
+			return nil, nil, nil, nil, fmt.Errorf("%w: missing stanza type", ErrInvalidHeader)
+		}
+		s := &stanza{typ: fields[0], args: fields[1:]}
+		for {
+			line, ok := nextLine()
+			if !ok || len(line) > columns {
+				return nil, nil, nil, nil, fmt.Errorf("%w: invalid stanza body", ErrInvalidHeader)
+			}
+			b, err := b64.DecodeString(line)
+			if err != nil {
+				return nil, nil, nil, nil, fmt.Errorf("%w: invalid stanza body", ErrInvalidHeader)
+			}
+			s.body = append(s.body, b...)
+			if len(line) < columns {
+				break
+			}
+		}
+		stanzas = append(stanzas, s)
+	}
+}
+
+// sealPayload encrypts plaintext with the STREAM construction in chunks of 64 KiB.
+func sealPayload(key []byte, plaintext []byte) []byte {
+	aead, _ := chacha20poly1305.New(key)
+	var out []byte
+	for counter := uint64(0); ; counter++ {
+		n := min(len(plaintext), chunkSize)
+		last := n == len(plaintext)
+		out = aead.Seal(out, streamNonce(counter, last), plaintext[:n], nil)
+		plaintext = plaintext[n:]
+		if last {
+			return out
+		}
+	}
+}
+
+// openPayload decrypts the STREAM encrypted payload.
+func openPayload(key []byte, payload []byte) ([]byte, error) {
+	aead, _ := chacha20poly1305.New(key)
+	encChunkSize := chunkSize + aead.Overhead()
+	out := []byte{}
+	for counter := uint64(0); ; counter++ {
+		n := min(len(payload), encChunkSize)
+		last := n == len(payload)
+		if n < aead.Overhead() || (last && n == aead.Overhead() && counter > 0) {
+			return nil, fmt.Errorf("%w: invalid chunk size", ErrInvalidPayload)
+		}
+		var err error
+		if out, err = aead.Open(out, streamNonce(counter, last), payload[:n], nil); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidPayload, err)
+		}
+		payload = payload[n:]
+		if last {
+			return out, nil
+		}
+	}
+}
+
+// streamNonce returns the 11-byte big-endian counter followed by the last chunk flag.
+func streamNonce(counter uint64, last bool) []byte {
+	nonce := make([]byte, chacha20poly1305.NonceSize)
+	for i := 10; i >= 3; i-- {
+		nonce[i] = byte(counter)
+		counter >>= 8
+	}
+	if last {
+		nonce[11] = 1
+	}
+	return nonce
+}
+
+func wrapKey(shared, share, publicKey []byte) []byte {
+	salt := append(append([]byte{}, share...), publicKey...)
+	return hkdfKey(shared, salt, x25519Label)
+}
+
+func headerMAC(fileKey []byte, header []byte) []byte {
+	h := hmac.New(sha256.New, hkdfKey(fileKey, nil, "header"))
+	h.Write(header)
+	return h.Sum(nil)
+}
+
+func payloadKey(fileKey []byte, nonce []byte) []byte {
+	return hkdfKey(fileKey, nonce, "payload")
+}
+
+func hkdfKey(secret, salt []byte, info string) []byte {
+	key, _ := hkdf.Key(sha256.New, secret, salt, []byte(info), chacha20poly1305.KeySize)
+	return key
+}
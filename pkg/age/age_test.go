@@ -0,0 +1,119 @@
+//nolint:scopelint
+package age_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/internal/rand"
+	"github.com/iotaledger/iota-crypto-demo/pkg/age"
+	"github.com/iotaledger/iota-crypto-demo/pkg/ed25519"
+)
+
+const testMnemonic = "giant dynamic museum toddler six deny defense ostrich bomb access mercy blood explain muscle shoot shallow glad autumn author calm heavy hawk abuse rally"
+
+func TestEncryptDecrypt(t *testing.T) {
+	id1, err := age.GenerateIdentity(rand.Reader)
+	require.NoError(t, err)
+	id2, err := age.GenerateIdentity(rand.Reader)
+	require.NoError(t, err)
+
+	for _, size := range []int{0, 1, 64 * 1024, 64*1024 + 1, 200 * 1024} {
+		plaintext := make([]byte, size)
+		_, _ = rand.Reader.Read(plaintext)
+
+		file, err := age.Encrypt(rand.Reader, plaintext, id1.Recipient(), id2.Recipient())
+		require.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(file, []byte("age-encryption.org/v1\n-> X25519 ")))
+
+		for _, id := range []*age.Identity{id1, id2} {
+			res, err := age.Decrypt(file, id)
+			require.NoError(t, err)
+			assert.Equal(t, plaintext, res)
+		}
+	}
+}
+
+func TestEd25519(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	recipient, err := age.RecipientFromEd25519(pub)
+	require.NoError(t, err)
+	identity := age.IdentityFromEd25519(priv)
+	assert.Equal(t, recipient.String(), identity.Recipient().String())
+
+	file, err := age.Encrypt(rand.Reader, []byte(testMnemonic), recipient)
+	require.NoError(t, err)
+	res, err := age.Decrypt(file, identity)
+	require.NoError(t, err)
+	assert.Equal(t, testMnemonic, string(res))
+}
+
+func TestParse(t *testing.T) {
+	id, err := age.GenerateIdentity(rand.Reader)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(id.String(), "AGE-SECRET-KEY-1"))
+	assert.True(t, strings.HasPrefix(id.Recipient().String(), "age1"))
+
+	parsedID, err := age.ParseIdentity(id.String())
+	require.NoError(t, err)
+	assert.Equal(t, id.String(), parsedID.String())
+
+	parsedRecipient, err := age.ParseRecipient(id.Recipient().String())
+	require.NoError(t, err)
+	assert.Equal(t, id.Recipient().String(), parsedRecipient.String())
+
+	_, err = age.ParseRecipient(id.String())
+	assert.ErrorIs(t, err, age.ErrInvalidRecipient)
+	_, err = age.ParseIdentity(id.Recipient().String())
+	assert.ErrorIs(t, err, age.ErrInvalidIdentity)
+}
+
+func TestDecryptInvalid(t *testing.T) {
+	id, err := age.GenerateIdentity(rand.Reader)
+	require.NoError(t, err)
+	other, err := age.GenerateIdentity(rand.Reader)
+	require.NoError(t, err)
+	file, err := age.Encrypt(rand.Reader, []byte(testMnemonic), id.Recipient())
+	require.NoError(t, err)
+
+	_, err = age.Decrypt(file, other)
+	assert.ErrorIs(t, err, age.ErrNoIdentityMatched)
+
+	tampered := bytes.Clone(file)
+	tampered[len(tampered)-1] ^= 1
+	_, err = age.Decrypt(tampered, id)
+	assert.ErrorIs(t, err, age.ErrInvalidPayload)
+
+	_, err = age.Decrypt(file[:len(file)-len(testMnemonic)-10], id)
+	assert.ErrorIs(t, err, age.ErrInvalidPayload)
+
+	header := bytes.Replace(file, []byte("age-encryption.org/v1\n"), []byte("age-encryption.org/v1\n-> grease\n\n"), 1)
+	_, err = age.Decrypt(header, id)
+	assert.ErrorIs(t, err, age.ErrInvalidHeader)
+
+	_, err = age.Decrypt([]byte("age-encryption.org/v2\n"), id)
+	assert.ErrorIs(t, err, age.ErrInvalidHeader)
+}
+
+func TestArmor(t *testing.T) {
+	id, err := age.GenerateIdentity(rand.Reader)
+	require.NoError(t, err)
+	file, err := age.Encrypt(rand.Reader, []byte(testMnemonic), id.Recipient())
+	require.NoError(t, err)
+
+	armored := age.Armor(file)
+	assert.True(t, bytes.HasPrefix(armored, []byte("-----BEGIN AGE ENCRYPTED FILE-----\n")))
+	assert.True(t, bytes.HasSuffix(armored, []byte("-----END AGE ENCRYPTED FILE-----\n")))
+
+	dearmored, err := age.Dearmor(armored)
+	require.NoError(t, err)
+	assert.Equal(t, file, dearmored)
+
+	_, err = age.Dearmor(armored[1:])
+	assert.ErrorIs(t, err, age.ErrInvalidArmor)
+}
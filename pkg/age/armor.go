@@ -0,0 +1,54 @@
+package age
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"strings"
+)
+
+const (
+	armorHeader = "-----BEGIN AGE ENCRYPTED FILE-----"
+	armorFooter = "-----END AGE ENCRYPTED FILE-----"
+)
+
+// ErrInvalidArmor is returned when the ASCII armor is malformed.
+var ErrInvalidArmor = errors.New("invalid armor")
+
+// Armor returns the ASCII armored form of the binary age file, i.e. its padded Base64 encoding wrapped at 64 columns
+// between PEM-like header and footer lines.
+func Armor(file []byte) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(armorHeader + "\n")
+	s := base64.StdEncoding.EncodeToString(file)
+	for len(s) > columns {
+		buf.WriteString(s[:columns] + "\n")
+		s = s[columns:]
+	}
+	if s != "" {
+		buf.WriteString(s + "\n")
+	}
+	buf.WriteString(armorFooter + "\n")
+	return buf.Bytes()
+}
+
+// Dearmor returns the binary age file of the ASCII armored data.
+// Leading and trailing whitespace is ignored.
+func Dearmor(armored []byte) ([]byte, error) {
+	lines := strings.Split(strings.TrimSpace(strings.ReplaceAll(string(armored), "\r\n", "\n")), "\n")
+	if len(lines) < 2 || lines[0] != armorHeader || lines[len(lines)-1] != armorFooter {
+		return nil, ErrInvalidArmor
+	}
+	body := lines[1 : len(lines)-1]
+	for i, line := range body {
+		// all lines but the last must be full
+		if len(line) > columns || (i < len(body)-1 && len(line) != columns) {
+			return nil, ErrInvalidArmor
+		}
+	}
+	file, err := base64.StdEncoding.Strict().DecodeString(strings.Join(body, ""))
+	if err != nil {
+		return nil, ErrInvalidArmor
+	}
+	return file, nil
+}
@@ -0,0 +1,94 @@
+// Package base58 implements the base58 encoding using the Bitcoin alphabet.
+package base58
+
+import (
+	"errors"
+)
+
+const alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// ErrInvalidCharacter is returned when the input contains a character not in the base58 alphabet.
+var ErrInvalidCharacter = errors.New("invalid base58 character")
+
+var decodeMap = func() (m [256]byte) {
+	for i := range m {
+		m[i] = 0xFF
+	}
+	for i := 0; i < len(alphabet); i++ {
+		m[alphabet[i]] = byte(i)
+	}
+	return m
+}()
+
+// Encode returns the base58 encoding of src.
+func Encode(src []byte) string {
+	// count leading zeros, they are encoded as '1' each
+	zeros := 0
+	for zeros < len(src) && src[zeros] == 0 {
+		zeros++
+	}
+
+	// log(256) / log(58) ≈ 1.37, allocate the upper bound
+	buf := make([]byte, (len(src)-zeros)*138/100+1)
+	high := len(buf) - 1
+	for _, b := range src[zeros:] {
+		carry := int(b)
+		i := len(buf) - 1
+		for ; i > high || carry != 0; i-- {
+			carry += 256 * int(buf[i])
+			buf[i] = byte(carry % 58)
+			carry /= 58
+		}
+		high = i
+	}
+
+	// skip leading zeros in the base58 result
+	start := 0
+	for start < len(buf) && buf[start] == 0 {
+		start++
+	}
+
+	dst := make([]byte, zeros+len(buf)-start)
+	for i := 0; i < zeros; i++ {
+		dst[i] = alphabet[0]
+	}
+	for i, d := range buf[start:] {
+		dst[zeros+i] = alphabet[d]
+	}
+	return string(dst)
+}
+
+// Decode returns the bytes represented by the base58 string s.
+func Decode(s string) ([]byte, error) {
+	zeros := 0
+	for zeros < len(s) && s[zeros] == alphabet[0] {
+		zeros++
+	}
+
+	// log(58) / log(256) ≈ 0.733, allocate the upper bound
+	buf := make([]byte, (len(s)-zeros)*733/1000+1)
+	high := len(buf) - 1
+	for i := zeros; i < len(s); i++ {
+		d := decodeMap[s[i]]
+		if d == 0xFF {
+			return nil, ErrInvalidCharacter
+		}
+		carry := int(d)
+		j := len(buf) - 1
+		for ; j > high || carry != 0; j-- {
+			carry += 58 * int(buf[j])
+			buf[j] = byte(carry)
+			carry >>= 8
+		}
+		high = j
+	}
+
+	start := 0
+	for start < len(buf) && buf[start] == 0 {
+		start++
+	}
+
+	dst := make([]byte, zeros+len(buf)-start)
+	copy(dst[zeros:], buf[start:])
+	return dst, nil
+}
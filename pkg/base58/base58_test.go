@@ -0,0 +1,60 @@
+//nolint:scopelint
+package base58_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/internal/hexutil"
+	"github.com/iotaledger/iota-crypto-demo/pkg/base58"
+)
+
+func TestBase58(t *testing.T) {
+	var tests = []*struct {
+		hex string
+		s   string
+	}{
+		{"", ""},
+		{"00", "1"},
+		{"0000", "11"},
+		{"61", "2g"},
+		{"626262", "a3gV"},
+		{"636363", "aPEr"},
+		{"48656c6c6f20576f726c6421", "2NEpo7TZRRrLZSi2U"},
+		{"000000287fb4cd", "111233QC4"},
+		{"00eb15231dfceb60925886b67d065299925915aeb172c06647", "1NS17iag9jJgTHD1VXjvLCEnZuQ3rJDE9L"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			src := hexutil.MustDecodeString(tt.hex)
+			assert.Equal(t, tt.s, base58.Encode(src))
+			b, err := base58.Decode(tt.s)
+			require.NoError(t, err)
+			assert.Equal(t, src, b)
+		})
+	}
+
+	_, err := base58.Decode("0OIl")
+	assert.ErrorIs(t, err, base58.ErrInvalidCharacter)
+}
+
+func TestCheck(t *testing.T) {
+	const (
+		address = "16UwLL9Risc3QfPqBUvKofHmBQ7wMtjvM"
+		hash    = "010966776006953d5567439e5e39f86a0d273bee"
+	)
+	assert.Equal(t, address, base58.CheckEncode(0x00, hexutil.MustDecodeString(hash)))
+
+	version, payload, err := base58.CheckDecode(address)
+	require.NoError(t, err)
+	assert.EqualValues(t, 0x00, version)
+	assert.Equal(t, hexutil.MustDecodeString(hash), payload)
+
+	_, _, err = base58.CheckDecode("16UwLL9Risc3QfPqBUvKofHmBQ7wMtjvN")
+	assert.ErrorIs(t, err, base58.ErrInvalidChecksum)
+	_, _, err = base58.CheckDecode("1111")
+	assert.ErrorIs(t, err, base58.ErrInvalidFormat)
+}
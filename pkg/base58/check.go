@@ -0,0 +1,50 @@
+package base58
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+)
+
+// ChecksumSize is the size, in bytes, of the Base58Check checksum.
+const ChecksumSize = 4
+
+var (
+	// ErrInvalidChecksum is returned when the Base58Check checksum does not match.
+	ErrInvalidChecksum = errors.New("invalid checksum")
+	// ErrInvalidFormat is returned when the decoded data is too short to contain a version and checksum.
+	ErrInvalidFormat = errors.New("invalid format")
+)
+
+// Checksum returns the Base58Check checksum of data, i.e. the first four bytes of its double SHA-256 hash.
+func Checksum(data []byte) []byte {
+	h1 := sha256.Sum256(data)
+	h2 := sha256.Sum256(h1[:])
+	return h2[:ChecksumSize]
+}
+
+// CheckEncode returns the Base58Check encoding of the version byte followed by payload.
+func CheckEncode(version byte, payload []byte) string {
+	b := make([]byte, 0, 1+len(payload)+ChecksumSize)
+	b = append(b, version)
+	b = append(b, payload...)
+	b = append(b, Checksum(b)...)
+	return Encode(b)
+}
+
+// CheckDecode decodes the Base58Check encoded string s into its version byte and payload.
+// It returns an error when s is not valid base58 or the checksum does not match.
+func CheckDecode(s string) (byte, []byte, error) {
+	b, err := Decode(s)
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(b) < 1+ChecksumSize {
+		return 0, nil, ErrInvalidFormat
+	}
+	data, sum := b[:len(b)-ChecksumSize], b[len(b)-ChecksumSize:]
+	if !bytes.Equal(sum, Checksum(data)) {
+		return 0, nil, ErrInvalidChecksum
+	}
+	return data[0], data[1:], nil
+}
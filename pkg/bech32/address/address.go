@@ -5,6 +5,9 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
+	"sync"
 
 	"golang.org/x/crypto/blake2b"
 
@@ -15,8 +18,10 @@ import (
 const (
 	// OutputIDLength defines the length of an OutputID.
 	OutputIDLength = blake2b.Size256 + 2
-	// Blake2b160Length defines the size of a BLAKE2b-160 hash in bytes.
-	Blake2b160Length = 20
+	// AliasIDLength defines the length of an Alias ID.
+	AliasIDLength = blake2b.Size256
+	// NFTIDLength defines the length of an NFT ID.
+	NFTIDLength = blake2b.Size256
 )
 
 // Errors returned during address parsing.
@@ -37,15 +42,104 @@ const (
 	ShimmerDevnet
 )
 
-var hrpStrings = [...]string{"iota", "atoi", "smr", "rms"}
+// network describes a network and its human-readable prefix.
+type network struct {
+	hrp      string
+	name     string
+	versions []Version // supported address versions; nil if all versions are supported
+}
+
+var (
+	networksMu sync.RWMutex
+	networks   = []network{
+		{hrp: "iota", name: "IOTA Mainnet"},
+		{hrp: "atoi", name: "IOTA Devnet"},
+		{hrp: "smr", name: "Shimmer Mainnet"},
+		{hrp: "rms", name: "Shimmer Devnet"},
+	}
+)
+
+func lookupNetwork(p Prefix) (network, bool) {
+	networksMu.RLock()
+	defer networksMu.RUnlock()
+	if p < 0 || int(p) >= len(networks) {
+		return network{}, false
+	}
+	return networks[p], true
+}
+
+// RegisterPrefix registers a custom network with the human-readable part hrp and the given name.
+// If versions are provided, only addresses of these versions are accepted for the network; otherwise all versions are.
+// It returns the Prefix of the new network or an error if hrp is invalid or already registered.
+func RegisterPrefix(hrp string, name string, versions ...Version) (Prefix, error) {
+	if hrp != strings.ToLower(hrp) {
+		return 0, fmt.Errorf("%w: human-readable part must be lowercase", ErrInvalidPrefix)
+	}
+	if err := bech32.ValidateHRP(hrp); err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrInvalidPrefix, err)
+	}
+
+	networksMu.Lock()
+	defer networksMu.Unlock()
+	for i := range networks {
+		if networks[i].hrp == hrp {
+			return 0, fmt.Errorf("%w: %s already registered", ErrInvalidPrefix, hrp)
+		}
+	}
+	if len(versions) > 0 {
+		versions = append([]Version{}, versions...)
+	}
+	networks = append(networks, network{hrp: hrp, name: name, versions: versions})
+	return Prefix(len(networks) - 1), nil
+}
 
+// String returns the human-readable part of the network prefix.
 func (p Prefix) String() string {
-	return hrpStrings[p]
+	n, ok := lookupNetwork(p)
+	if !ok {
+		return "Prefix(" + strconv.Itoa(int(p)) + ")"
+	}
+	return n.hrp
+}
+
+// Name returns the descriptive name of the network.
+func (p Prefix) Name() string {
+	n, _ := lookupNetwork(p)
+	return n.name
 }
 
+// CoinType returns the SLIP-44 coin type used to derive the keys of the network, i.e. 4219 for Shimmer and 4218
+// otherwise.
+func (p Prefix) CoinType() uint32 {
+	if p == ShimmerMainnet || p == ShimmerDevnet {
+		return 4219
+	}
+	return 4218
+}
+
+// Supports returns whether addresses of version v are valid for the network.
+func (p Prefix) Supports(v Version) bool {
+	n, ok := lookupNetwork(p)
+	if !ok {
+		return false
+	}
+	if n.versions == nil {
+		return true
+	}
+	for _, version := range n.versions {
+		if version == v {
+			return true
+		}
+	}
+	return false
+}
+
+// ParsePrefix returns the Prefix of the registered network with human-readable part s.
 func ParsePrefix(s string) (Prefix, error) {
-	for i := range hrpStrings {
-		if s == hrpStrings[i] {
+	networksMu.RLock()
+	defer networksMu.RUnlock()
+	for i := range networks {
+		if s == networks[i].hrp {
 			return Prefix(i), nil
 		}
 	}
@@ -79,10 +173,18 @@ func ParseVersion(s string) (Version, error) {
 
 // Bech32 encodes the provided addr as a bech32 string.
 func Bech32(hrp Prefix, addr Address) (string, error) {
+	if _, ok := lookupNetwork(hrp); !ok {
+		return "", ErrInvalidPrefix
+	}
+	if !hrp.Supports(addr.Version()) {
+		return "", fmt.Errorf("%w: %s not supported by %s", ErrInvalidVersion, addr.Version(), hrp)
+	}
 	return bech32.Encode(hrp.String(), addr.Bytes())
 }
 
 // ParseBech32 decodes a bech32 encoded string.
+// The returned Address has the concrete type corresponding to its version, i.e. Ed25519Address, AliasAddress or
+// NFTAddress.
 func ParseBech32(s string) (Prefix, Address, error) {
 	hrp, addrData, err := bech32.Decode(s)
 	if err != nil {
@@ -92,73 +194,168 @@ func ParseBech32(s string) (Prefix, Address, error) {
 	if err != nil {
 		return 0, nil, fmt.Errorf("invalid human-readable prefix: %w", err)
 	}
-	if len(addrData) == 0 {
-		return 0, nil, fmt.Errorf("%w: no version", ErrInvalidVersion)
+	version, err := validateData(prefix, addrData)
+	if err != nil {
+		return 0, nil, err
 	}
-	version := Version(addrData[0])
-	addrData = addrData[1:]
+	return prefix, newAddress(version, addrData), nil
+}
+
+// ParseBytes parses the serialized address as returned by Address.Bytes.
+// The returned Address has the concrete type corresponding to its version, i.e. Ed25519Address, AliasAddress or
+// NFTAddress.
+func ParseBytes(data []byte) (Address, error) {
+	version, err := checkData(data)
+	if err != nil {
+		return nil, err
+	}
+	return newAddress(version, data), nil
+}
+
+// newAddress returns the address of the given version from the validated addrData.
+func newAddress(version Version, addrData []byte) Address {
+	var hash [blake2b.Size256]byte
+	copy(hash[:], addrData[1:])
 	switch version {
 	case Ed25519:
-		if len(addrData) != blake2b.Size256 {
-			return 0, nil, fmt.Errorf("invalid Ed25519 address: %w", ErrInvalidLength)
-		}
-		var addr Ed25519Address
-		copy(addr.hash[:], addrData)
-		return prefix, addr, nil
+		return Ed25519Address{hash}
 	case Alias:
-		if len(addrData) != Blake2b160Length {
-			return 0, nil, fmt.Errorf("invalid Alias address: %w", ErrInvalidLength)
-		}
-		var addr AliasAddress
-		copy(addr.hash[:], addrData)
-		return prefix, addr, nil
+		return AliasAddress{hash}
 	case NFT:
-		if len(addrData) != Blake2b160Length {
-			return 0, nil, fmt.Errorf("invalid NFT address: %w", ErrInvalidLength)
-		}
-		var addr NFTAddress
-		copy(addr.hash[:], addrData)
-		return prefix, addr, nil
+		return NFTAddress{hash}
 	}
-	return 0, nil, fmt.Errorf("%w: %d", ErrInvalidVersion, version)
+	panic("unreachable")
 }
 
-func blake2bSum160(b []byte) [Blake2b160Length]byte {
-	h, err := blake2b.New(Blake2b160Length, nil)
+// IsValidBech32 checks whether s is a valid bech32 encoded address of the network expected.
+// It validates the human-readable part, the charset, the checksum as well as the version and length of the payload
+// without constructing an Address. It returns nil if s is valid and the reason otherwise.
+func IsValidBech32(s string, expected Prefix) error {
+	hrp, addrData, err := bech32.Decode(s)
 	if err != nil {
-		panic(err)
+		return fmt.Errorf("invalid bech32 encoding: %w", err)
 	}
-	_, err = h.Write(b)
-	if err != nil {
-		panic(err)
+	if _, ok := lookupNetwork(expected); !ok || hrp != expected.String() {
+		return fmt.Errorf("%w: expected %s, got %s", ErrInvalidPrefix, expected, hrp)
+	}
+	_, err = validateData(expected, addrData)
+	return err
+}
+
+// validateData checks that addrData is the serialization of an address that is valid for the network prefix.
+func validateData(prefix Prefix, addrData []byte) (Version, error) {
+	if len(addrData) == 0 {
+		return 0, fmt.Errorf("%w: no version", ErrInvalidVersion)
+	}
+	version := Version(addrData[0])
+	if !prefix.Supports(version) {
+		return 0, fmt.Errorf("%w: %s not supported by %s", ErrInvalidVersion, version, prefix)
 	}
-	var sum160 [Blake2b160Length]byte
-	copy(sum160[:], h.Sum(nil))
-	return sum160
+	return checkData(addrData)
+}
+
+// checkData checks that addrData is the serialization of an address of a known version independent of the network.
+func checkData(addrData []byte) (Version, error) {
+	if len(addrData) == 0 {
+		return 0, fmt.Errorf("%w: no version", ErrInvalidVersion)
+	}
+	version := Version(addrData[0])
+	var length int
+	switch version {
+	case Ed25519:
+		length = blake2b.Size256
+	case Alias:
+		length = AliasIDLength
+	case NFT:
+		length = NFTIDLength
+	default:
+		return 0, fmt.Errorf("%w: %d", ErrInvalidVersion, version)
+	}
+	if len(addrData)-1 != length {
+		return 0, fmt.Errorf("invalid %s address: %w", version, ErrInvalidLength)
+	}
+	return version, nil
 }
 
 // Address specifies a general address of different underlying types.
 type Address interface {
+	// Version returns the version of the address.
 	Version() Version
+	// Bytes returns the serialized address, i.e. the version byte followed by the address data.
 	Bytes() []byte
+	// Hash returns the underlying 32-byte hash, i.e. the public key hash or the ID.
+	Hash() [blake2b.Size256]byte
+	// Equal reports whether the address is equal to other.
+	Equal(other Address) bool
 
+	// Hex returns the hex encoding of the address data.
+	Hex() string
+	// String returns the bech32 encoding of the address using the DefaultPrefix.
+	// If the address cannot be encoded with it, its version and the hex encoded hash are returned instead.
 	String() string
+	// MarshalText returns the bech32 encoding of the address using the DefaultPrefix.
+	MarshalText() ([]byte, error)
 }
 
+// Ed25519Address represents an address corresponding to an Ed25519 public key.
+// It consists of the BLAKE2b-256 hash of the public key.
 type Ed25519Address struct {
 	hash [blake2b.Size256]byte
 }
 
+// Version returns the Ed25519 address version.
 func (Ed25519Address) Version() Version {
 	return Ed25519
 }
+
+// Bytes returns the serialized address, i.e. the version byte followed by the public key hash.
 func (a Ed25519Address) Bytes() []byte {
 	return append([]byte{byte(Ed25519)}, a.hash[:]...)
 }
-func (a Ed25519Address) String() string {
+
+// Hash returns the public key hash.
+func (a Ed25519Address) Hash() [blake2b.Size256]byte {
+	return a.hash
+}
+
+// Equal reports whether a and other are the same Ed25519 address.
+func (a Ed25519Address) Equal(other Address) bool {
+	o, ok := other.(Ed25519Address)
+	return ok && a == o
+}
+
+// Hex returns the hex encoding of the public key hash.
+func (a Ed25519Address) Hex() string {
 	return hex.EncodeToString(a.hash[:])
 }
 
+// String returns the bech32 encoding of a using the DefaultPrefix.
+func (a Ed25519Address) String() string {
+	return bech32String(a)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (a Ed25519Address) MarshalText() ([]byte, error) {
+	return marshalText(a)
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// It accepts the bech32 encoding of an Ed25519 address with any registered prefix.
+func (a *Ed25519Address) UnmarshalText(text []byte) error {
+	return unmarshalText(text, a)
+}
+
+// MarshalCBOR implements the cbor.Marshaler interface.
+func (a Ed25519Address) MarshalCBOR() ([]byte, error) {
+	return marshalCBOR(a)
+}
+
+// UnmarshalCBOR implements the cbor.Unmarshaler interface.
+// It accepts the CBOR encoding of an Ed25519 address as returned by MarshalCBOR.
+func (a *Ed25519Address) UnmarshalCBOR(data []byte) error {
+	return unmarshalCBOR(data, a)
+}
+
 // AddressFromPublicKey creates an address from a 32-byte hash.
 //
 //nolint:revive
@@ -169,40 +366,145 @@ func AddressFromPublicKey(key ed25519.PublicKey) Ed25519Address {
 	return Ed25519Address{blake2b.Sum256(key)}
 }
 
+// AddressFromHash returns the Ed25519 address corresponding to the given public key hash.
+func AddressFromHash(hash [blake2b.Size256]byte) Ed25519Address {
+	return Ed25519Address{hash}
+}
+
+// AliasAddress represents the address of an alias output as described in TIP-18.
+// It consists of the Alias ID, i.e. the BLAKE2b-256 hash of the Output ID that created the alias.
 type AliasAddress struct {
-	hash [Blake2b160Length]byte
+	id [AliasIDLength]byte
 }
 
+// Version returns the Alias address version.
 func (AliasAddress) Version() Version {
 	return Alias
 }
+
+// Bytes returns the serialized address, i.e. the version byte followed by the Alias ID.
 func (a AliasAddress) Bytes() []byte {
-	return append([]byte{byte(Alias)}, a.hash[:]...)
+	return append([]byte{byte(Alias)}, a.id[:]...)
 }
+
+// Hash returns the Alias ID.
+func (a AliasAddress) Hash() [blake2b.Size256]byte {
+	return a.id
+}
+
+// Equal reports whether a and other are the same Alias address.
+func (a AliasAddress) Equal(other Address) bool {
+	o, ok := other.(AliasAddress)
+	return ok && a == o
+}
+
+// Hex returns the hex encoding of the Alias ID.
+func (a AliasAddress) Hex() string {
+	return hex.EncodeToString(a.id[:])
+}
+
+// String returns the bech32 encoding of a using the DefaultPrefix.
 func (a AliasAddress) String() string {
-	return hex.EncodeToString(a.hash[:])
+	return bech32String(a)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (a AliasAddress) MarshalText() ([]byte, error) {
+	return marshalText(a)
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// It accepts the bech32 encoding of an Alias address with any registered prefix.
+func (a *AliasAddress) UnmarshalText(text []byte) error {
+	return unmarshalText(text, a)
+}
+
+// MarshalCBOR implements the cbor.Marshaler interface.
+func (a AliasAddress) MarshalCBOR() ([]byte, error) {
+	return marshalCBOR(a)
+}
+
+// UnmarshalCBOR implements the cbor.Unmarshaler interface.
+// It accepts the CBOR encoding of an Alias address as returned by MarshalCBOR.
+func (a *AliasAddress) UnmarshalCBOR(data []byte) error {
+	return unmarshalCBOR(data, a)
 }
 
 // AliasAddressFromOutputID returns the alias address computed from a given OutputID.
 func AliasAddressFromOutputID(outputID [OutputIDLength]byte) AliasAddress {
-	return AliasAddress{blake2bSum160(outputID[:])}
+	return AliasAddress{blake2b.Sum256(outputID[:])}
 }
 
+// AliasAddressFromID returns the alias address corresponding to the given Alias ID.
+func AliasAddressFromID(id [AliasIDLength]byte) AliasAddress {
+	return AliasAddress{id}
+}
+
+// NFTAddress represents the address of an NFT output as described in TIP-18.
+// It consists of the NFT ID, i.e. the BLAKE2b-256 hash of the Output ID that created the NFT.
 type NFTAddress struct {
-	hash [Blake2b160Length]byte
+	id [NFTIDLength]byte
 }
 
+// Version returns the NFT address version.
 func (NFTAddress) Version() Version {
 	return NFT
 }
+
+// Bytes returns the serialized address, i.e. the version byte followed by the NFT ID.
 func (a NFTAddress) Bytes() []byte {
-	return append([]byte{byte(NFT)}, a.hash[:]...)
+	return append([]byte{byte(NFT)}, a.id[:]...)
+}
+
+// Hash returns the NFT ID.
+func (a NFTAddress) Hash() [blake2b.Size256]byte {
+	return a.id
+}
+
+// Equal reports whether a and other are the same NFT address.
+func (a NFTAddress) Equal(other Address) bool {
+	o, ok := other.(NFTAddress)
+	return ok && a == o
 }
+
+// Hex returns the hex encoding of the NFT ID.
+func (a NFTAddress) Hex() string {
+	return hex.EncodeToString(a.id[:])
+}
+
+// String returns the bech32 encoding of a using the DefaultPrefix.
 func (a NFTAddress) String() string {
-	return hex.EncodeToString(a.hash[:])
+	return bech32String(a)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (a NFTAddress) MarshalText() ([]byte, error) {
+	return marshalText(a)
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// It accepts the bech32 encoding of an NFT address with any registered prefix.
+func (a *NFTAddress) UnmarshalText(text []byte) error {
+	return unmarshalText(text, a)
+}
+
+// MarshalCBOR implements the cbor.Marshaler interface.
+func (a NFTAddress) MarshalCBOR() ([]byte, error) {
+	return marshalCBOR(a)
 }
 
-// NFTAddressFromOutputID returns the alias address computed from a given OutputID.
+// UnmarshalCBOR implements the cbor.Unmarshaler interface.
+// It accepts the CBOR encoding of an NFT address as returned by MarshalCBOR.
+func (a *NFTAddress) UnmarshalCBOR(data []byte) error {
+	return unmarshalCBOR(data, a)
+}
+
+// NFTAddressFromOutputID returns the NFT address computed from a given OutputID.
 func NFTAddressFromOutputID(outputID [OutputIDLength]byte) NFTAddress {
-	return NFTAddress{blake2bSum160(outputID[:])}
+	return NFTAddress{blake2b.Sum256(outputID[:])}
+}
+
+// NFTAddressFromID returns the NFT address corresponding to the given NFT ID.
+func NFTAddressFromID(id [NFTIDLength]byte) NFTAddress {
+	return NFTAddress{id}
 }
@@ -0,0 +1,182 @@
+//nolint:scopelint
+package address_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/internal/hexutil"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bech32"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bech32/address"
+	"github.com/iotaledger/iota-crypto-demo/pkg/ed25519"
+)
+
+var testPublicKey = ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize)).Public().(ed25519.PublicKey)
+
+func TestParsePrefix(t *testing.T) {
+	for _, p := range []address.Prefix{address.IOTAMainnet, address.IOTADevnet, address.ShimmerMainnet, address.ShimmerDevnet} {
+		parsed, err := address.ParsePrefix(p.String())
+		require.NoError(t, err)
+		assert.Equal(t, p, parsed)
+		assert.NotEmpty(t, p.Name())
+	}
+	_, err := address.ParsePrefix("unknown")
+	assert.ErrorIs(t, err, address.ErrInvalidPrefix)
+}
+
+func TestRegisterPrefix(t *testing.T) {
+	prefix, err := address.RegisterPrefix("tst", "Private Tangle", address.Ed25519)
+	require.NoError(t, err)
+	assert.Equal(t, "tst", prefix.String())
+	assert.Equal(t, "Private Tangle", prefix.Name())
+	assert.True(t, prefix.Supports(address.Ed25519))
+	assert.False(t, prefix.Supports(address.NFT))
+
+	parsed, err := address.ParsePrefix("tst")
+	require.NoError(t, err)
+	assert.Equal(t, prefix, parsed)
+
+	addr := address.AddressFromPublicKey(testPublicKey)
+	s, err := address.Bech32(prefix, addr)
+	require.NoError(t, err)
+	p, a, err := address.ParseBech32(s)
+	require.NoError(t, err)
+	assert.Equal(t, prefix, p)
+	assert.Equal(t, addr, a)
+
+	// address versions not supported by the network are rejected
+	nft := address.NFTAddressFromOutputID([address.OutputIDLength]byte{})
+	_, err = address.Bech32(prefix, nft)
+	assert.ErrorIs(t, err, address.ErrInvalidVersion)
+	s, err = bech32.Encode(prefix.String(), nft.Bytes())
+	require.NoError(t, err)
+	_, _, err = address.ParseBech32(s)
+	assert.ErrorIs(t, err, address.ErrInvalidVersion)
+
+	// invalid or duplicate prefixes
+	_, err = address.RegisterPrefix("tst", "duplicate")
+	assert.ErrorIs(t, err, address.ErrInvalidPrefix)
+	_, err = address.RegisterPrefix(address.IOTAMainnet.String(), "duplicate")
+	assert.ErrorIs(t, err, address.ErrInvalidPrefix)
+	_, err = address.RegisterPrefix("TST", "uppercase")
+	assert.ErrorIs(t, err, address.ErrInvalidPrefix)
+	_, err = address.RegisterPrefix("", "empty")
+	assert.ErrorIs(t, err, address.ErrInvalidPrefix)
+	_, err = address.RegisterPrefix("t st", "space")
+	assert.ErrorIs(t, err, address.ErrInvalidPrefix)
+}
+
+func TestUnknownPrefix(t *testing.T) {
+	_, err := address.Bech32(address.Prefix(1000), address.AddressFromPublicKey(testPublicKey))
+	assert.ErrorIs(t, err, address.ErrInvalidPrefix)
+	assert.Equal(t, "Prefix(1000)", address.Prefix(1000).String())
+}
+
+func TestParseBech32Kinds(t *testing.T) {
+	var outputID [address.OutputIDLength]byte
+	outputID[0] = 0x01
+
+	var tests = []*struct {
+		desc    string
+		addr    address.Address
+		expHRP  string
+		expSize int
+	}{
+		{"Ed25519", address.AddressFromPublicKey(testPublicKey), "smr1q", 1 + 32},
+		{"Alias", address.AliasAddressFromOutputID(outputID), "smr1p", 1 + address.AliasIDLength},
+		{"NFT", address.NFTAddressFromOutputID(outputID), "smr1z", 1 + address.NFTIDLength},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Len(t, tt.addr.Bytes(), tt.expSize)
+			s, err := address.Bech32(address.ShimmerMainnet, tt.addr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expHRP, s[:5])
+
+			prefix, addr, err := address.ParseBech32(s)
+			require.NoError(t, err)
+			assert.Equal(t, address.ShimmerMainnet, prefix)
+			assert.IsType(t, tt.addr, addr)
+			assert.Equal(t, tt.addr, addr)
+		})
+	}
+}
+
+func TestAliasNFTFromID(t *testing.T) {
+	var outputID [address.OutputIDLength]byte
+	alias := address.AliasAddressFromOutputID(outputID)
+	var aliasID [address.AliasIDLength]byte
+	copy(aliasID[:], alias.Bytes()[1:])
+	assert.Equal(t, alias, address.AliasAddressFromID(aliasID))
+
+	nft := address.NFTAddressFromOutputID(outputID)
+	var nftID [address.NFTIDLength]byte
+	copy(nftID[:], nft.Bytes()[1:])
+	assert.Equal(t, nft, address.NFTAddressFromID(nftID))
+	// the same output ID leads to the same ID but a different address
+	assert.Equal(t, alias.Hex(), nft.Hex())
+	assert.NotEqual(t, alias.Bytes(), nft.Bytes())
+}
+
+func TestParseBech32(t *testing.T) {
+	const (
+		bech32Addr = "iota1qrhacyfwlcnzkvzteumekfkrrwks98mpdm37cj4xx3drvmjvnep6xqgyzyx"
+		hash       = "efdc112efe262b304bcf379b26c31bad029f616ee3ec4aa6345a366e4c9e43a3"
+	)
+	prefix, addr, err := address.ParseBech32(bech32Addr)
+	require.NoError(t, err)
+	assert.Equal(t, address.IOTAMainnet, prefix)
+	assert.Equal(t, address.Ed25519, addr.Version())
+	assert.Equal(t, hash, addr.Hex())
+	assert.Equal(t, bech32Addr, addr.String())
+	h := addr.Hash()
+	assert.Equal(t, hexutil.MustDecodeString(hash), h[:])
+
+	s, err := address.Bech32(prefix, addr)
+	require.NoError(t, err)
+	assert.Equal(t, bech32Addr, s)
+}
+
+func TestParseBech32Invalid(t *testing.T) {
+	mustEncode := func(hrp string, data []byte) string {
+		s, err := bech32.Encode(hrp, data)
+		require.NoError(t, err)
+		return s
+	}
+	hash := make([]byte, 32)
+
+	var tests = []*struct {
+		desc   string
+		s      string
+		expErr error
+	}{
+		{"invalid checksum", "iota1qrhacyfwlcnzkvzteumekfkrrwks98mpdm37cj4xx3drvmjvnep6xqgyzyy", bech32.ErrInvalidChecksum},
+		{"unknown prefix", mustEncode("xyz", append([]byte{byte(address.Ed25519)}, hash...)), address.ErrInvalidPrefix},
+		{"no version", mustEncode("iota", nil), address.ErrInvalidVersion},
+		{"unknown version", mustEncode("iota", append([]byte{0xff}, hash...)), address.ErrInvalidVersion},
+		{"short Ed25519", mustEncode("iota", append([]byte{byte(address.Ed25519)}, hash[1:]...)), address.ErrInvalidLength},
+		{"long Alias", mustEncode("iota", append(append([]byte{byte(address.Alias)}, hash...), 0)), address.ErrInvalidLength},
+		{"short NFT", mustEncode("iota", []byte{byte(address.NFT)}), address.ErrInvalidLength},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			_, _, err := address.ParseBech32(tt.s)
+			assert.ErrorIs(t, err, tt.expErr)
+		})
+	}
+}
+
+func TestIsValidBech32(t *testing.T) {
+	const valid = "iota1qrhacyfwlcnzkvzteumekfkrrwks98mpdm37cj4xx3drvmjvnep6xqgyzyx"
+	assert.NoError(t, address.IsValidBech32(valid, address.IOTAMainnet))
+	assert.ErrorIs(t, address.IsValidBech32(valid, address.ShimmerMainnet), address.ErrInvalidPrefix)
+	assert.ErrorIs(t, address.IsValidBech32(valid, address.Prefix(-1)), address.ErrInvalidPrefix)
+	assert.ErrorIs(t, address.IsValidBech32(valid[:len(valid)-1]+"y", address.IOTAMainnet), bech32.ErrInvalidChecksum)
+
+	s, err := bech32.Encode("iota", []byte{byte(address.Ed25519), 0})
+	require.NoError(t, err)
+	assert.ErrorIs(t, address.IsValidBech32(s, address.IOTAMainnet), address.ErrInvalidLength)
+}
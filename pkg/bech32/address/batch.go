@@ -0,0 +1,93 @@
+package address
+
+import (
+	"fmt"
+	"sync"
+
+	"golang.org/x/crypto/blake2b"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/bech32"
+)
+
+// Bech32Batch encodes all the provided addrs as bech32 strings using the prefix hrp.
+// All the results share a single preallocated buffer, avoiding any per-address allocations.
+// It returns an error for the first address that cannot be encoded.
+func Bech32Batch(hrp Prefix, addrs []Address) ([]string, error) {
+	if _, ok := lookupNetwork(hrp); !ok {
+		return nil, ErrInvalidPrefix
+	}
+	prefix := hrp.String()
+
+	for i := range addrs {
+		if !hrp.Supports(addrs[i].Version()) {
+			return nil, fmt.Errorf("address %d: %w: %s not supported by %s", i, ErrInvalidVersion, addrs[i].Version(), hrp)
+		}
+	}
+
+	buf := make([]byte, 0, len(addrs)*bech32EncodedLen(prefix))
+	offsets := make([]int, len(addrs)+1)
+	var data [1 + blake2b.Size256]byte
+	for i := range addrs {
+		// serialize the address without allocating
+		data[0] = byte(addrs[i].Version())
+		hash := addrs[i].Hash()
+		copy(data[1:], hash[:])
+
+		var err error
+		if buf, err = bech32.AppendEncode(buf, prefix, data[:]); err != nil {
+			return nil, fmt.Errorf("address %d: %w", i, err)
+		}
+		offsets[i+1] = len(buf)
+	}
+
+	all := string(buf)
+	res := make([]string, len(addrs))
+	for i := range res {
+		res[i] = all[offsets[i]:offsets[i+1]]
+	}
+	return res, nil
+}
+
+// Bech32BatchParallel is like Bech32Batch, but splits addrs into chunks that are encoded concurrently by workers
+// goroutines.
+func Bech32BatchParallel(hrp Prefix, addrs []Address, workers int) ([]string, error) {
+	if workers < 2 || len(addrs) < 2*workers {
+		return Bech32Batch(hrp, addrs)
+	}
+
+	res := make([]string, len(addrs))
+	errs := make([]error, workers)
+	chunk := (len(addrs) + workers - 1) / workers
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		start, end := w*chunk, min((w+1)*chunk, len(addrs))
+		if start >= end {
+			break
+		}
+		wg.Add(1)
+		go func(w, start, end int) {
+			defer wg.Done()
+			s, err := Bech32Batch(hrp, addrs[start:end])
+			if err != nil {
+				errs[w] = fmt.Errorf("chunk starting at %d: %w", start, err)
+				return
+			}
+			copy(res[start:], s)
+		}(w, start, end)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// bech32EncodedLen returns the length of the bech32 encoding of an address with the human-readable part hrp.
+func bech32EncodedLen(hrp string) int {
+	const separatorLength, checksumLength = 1, 6
+	return len(hrp) + separatorLength + ((1+blake2b.Size256)*8+4)/5 + checksumLength
+}
@@ -0,0 +1,82 @@
+//nolint:scopelint
+package address_test
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/bech32/address"
+)
+
+func testAddresses(n int) []address.Address {
+	addrs := make([]address.Address, n)
+	for i := range addrs {
+		var outputID [address.OutputIDLength]byte
+		binary.LittleEndian.PutUint64(outputID[:], uint64(i))
+		switch i % 3 {
+		case 0:
+			addrs[i] = address.AliasAddressFromOutputID(outputID)
+		case 1:
+			addrs[i] = address.NFTAddressFromOutputID(outputID)
+		default:
+			var id [address.AliasIDLength]byte
+			copy(id[:], outputID[:])
+			addrs[i] = address.AliasAddressFromID(id)
+		}
+	}
+	return addrs
+}
+
+func TestBech32Batch(t *testing.T) {
+	addrs := testAddresses(100)
+	addrs = append(addrs, address.AddressFromPublicKey(testPublicKey))
+
+	for _, workers := range []int{0, 1, 3, 8} {
+		res, err := address.Bech32BatchParallel(address.ShimmerDevnet, addrs, workers)
+		require.NoError(t, err)
+		require.Len(t, res, len(addrs))
+		for i := range addrs {
+			exp, err := address.Bech32(address.ShimmerDevnet, addrs[i])
+			require.NoError(t, err)
+			assert.Equal(t, exp, res[i])
+		}
+	}
+
+	res, err := address.Bech32Batch(address.IOTAMainnet, nil)
+	require.NoError(t, err)
+	assert.Empty(t, res)
+}
+
+func TestBech32BatchInvalid(t *testing.T) {
+	prefix, err := address.RegisterPrefix("batch", "Batch Test", address.Ed25519)
+	require.NoError(t, err)
+
+	addrs := testAddresses(10)
+	_, err = address.Bech32Batch(prefix, addrs)
+	assert.ErrorIs(t, err, address.ErrInvalidVersion)
+	_, err = address.Bech32BatchParallel(prefix, addrs, 2)
+	assert.ErrorIs(t, err, address.ErrInvalidVersion)
+	_, err = address.Bech32Batch(address.Prefix(-1), addrs)
+	assert.ErrorIs(t, err, address.ErrInvalidPrefix)
+}
+
+func BenchmarkBech32(b *testing.B) {
+	addrs := testAddresses(1000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, addr := range addrs {
+			_, _ = address.Bech32(address.IOTAMainnet, addr)
+		}
+	}
+}
+
+func BenchmarkBech32Batch(b *testing.B) {
+	addrs := testAddresses(1000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = address.Bech32Batch(address.IOTAMainnet, addrs)
+	}
+}
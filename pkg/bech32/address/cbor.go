@@ -0,0 +1,37 @@
+package address
+
+import (
+	"github.com/iotaledger/iota-crypto-demo/pkg/cbor"
+)
+
+// ParseCBOR parses the CBOR encoding of an address, i.e. a byte string containing the serialized address.
+// It is independent of the network and the returned Address has the concrete type corresponding to its version.
+func ParseCBOR(data []byte) (Address, error) {
+	d := cbor.NewDecoder(data)
+	b, err := d.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	if err := d.Finish(); err != nil {
+		return nil, err
+	}
+	return ParseBytes(b)
+}
+
+// AppendCBOR appends the CBOR encoding of addr, i.e. the serialized address as byte string, to b.
+func AppendCBOR(b []byte, addr Address) []byte {
+	return cbor.AppendBytes(b, addr.Bytes())
+}
+
+func marshalCBOR(addr Address) ([]byte, error) {
+	return AppendCBOR(nil, addr), nil
+}
+
+// unmarshalCBOR parses the CBOR encoded address into dst, which must be a pointer to a concrete address type.
+func unmarshalCBOR(data []byte, dst Address) error {
+	addr, err := ParseCBOR(data)
+	if err != nil {
+		return err
+	}
+	return assign(addr, dst)
+}
@@ -0,0 +1,48 @@
+package address_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/bech32/address"
+	"github.com/iotaledger/iota-crypto-demo/pkg/cbor"
+)
+
+func TestMarshalCBOR(t *testing.T) {
+	ed := address.AddressFromPublicKey(testPublicKey)
+
+	b, err := ed.MarshalCBOR()
+	require.NoError(t, err)
+	// byte string of 33 bytes followed by the version and the hash
+	assert.Equal(t, "5821"+"00"+hex.EncodeToString(ed.Bytes()[1:]), hex.EncodeToString(b))
+
+	var decoded address.Ed25519Address
+	require.NoError(t, decoded.UnmarshalCBOR(b))
+	assert.Equal(t, ed, decoded)
+
+	addr, err := address.ParseCBOR(b)
+	require.NoError(t, err)
+	assert.Equal(t, ed, addr)
+
+	// an address of a different kind must be rejected
+	var outputID [address.OutputIDLength]byte
+	alias := address.AliasAddressFromOutputID(outputID)
+	var nft address.NFTAddress
+	assert.ErrorIs(t, nft.UnmarshalCBOR(address.AppendCBOR(nil, alias)), address.ErrInvalidVersion)
+}
+
+func TestParseCBORInvalid(t *testing.T) {
+	ed := address.AddressFromPublicKey(testPublicKey)
+
+	_, err := address.ParseCBOR(cbor.AppendBytes(nil, ed.Bytes()[:20]))
+	assert.ErrorIs(t, err, address.ErrInvalidLength)
+	_, err = address.ParseCBOR(cbor.AppendBytes(nil, append([]byte{0x01}, ed.Bytes()[1:]...)))
+	assert.ErrorIs(t, err, address.ErrInvalidVersion)
+	_, err = address.ParseCBOR(cbor.AppendText(nil, ed.String()))
+	assert.ErrorIs(t, err, cbor.ErrUnexpectedType)
+	_, err = address.ParseCBOR(append(address.AppendCBOR(nil, ed), 0x00))
+	assert.ErrorIs(t, err, cbor.ErrTrailingData)
+}
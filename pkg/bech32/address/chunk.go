@@ -0,0 +1,38 @@
+package address
+
+import (
+	"strings"
+	"unicode"
+)
+
+// DefaultChunkSize is the number of characters per group commonly used to display addresses.
+const DefaultChunkSize = 4
+
+// Chunk groups the address s into chunks of size characters separated by a single space.
+// This matches how hardware wallets display addresses to simplify a manual comparison.
+// If size is not positive, DefaultChunkSize is used.
+func Chunk(s string, size int) string {
+	if size < 1 {
+		size = DefaultChunkSize
+	}
+	var b strings.Builder
+	b.Grow(len(s) + len(s)/size)
+	for i := 0; i < len(s); i += size {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(s[i:min(i+size, len(s))])
+	}
+	return b.String()
+}
+
+// Unchunk removes all whitespace from s, reverting Chunk.
+// The result can then be decoded, e.g. using ParseBech32.
+func Unchunk(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, s)
+}
@@ -0,0 +1,39 @@
+//nolint:scopelint
+package address_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/bech32/address"
+)
+
+func TestChunk(t *testing.T) {
+	var tests = []*struct {
+		s    string
+		size int
+		exp  string
+	}{
+		{"", 4, ""},
+		{"iota", 4, "iota"},
+		{"iota1qrha", 4, "iota 1qrh a"},
+		{"iota1qrha", 0, "iota 1qrh a"},
+		{"iota1qrha", 3, "iot a1q rha"},
+		{"iota1qrha", 100, "iota1qrha"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.exp, func(t *testing.T) {
+			chunked := address.Chunk(tt.s, tt.size)
+			assert.Equal(t, tt.exp, chunked)
+			assert.Equal(t, tt.s, address.Unchunk(chunked))
+		})
+	}
+}
+
+func TestUnchunk(t *testing.T) {
+	const s = "iota1qrhacyfwlcnzkvzteumekfkrrwks98mpdm37cj4xx3drvmjvnep6xqgyzyx"
+	assert.Equal(t, s, address.Unchunk(" iota 1qrh\tacyf wlcn\nzkvz teumekfkrrwks98mpdm37cj4xx3drvmjvnep6xqgyzyx\n"))
+	assert.NoError(t, address.IsValidBech32(address.Unchunk(address.Chunk(s, 5)), address.IOTAMainnet))
+}
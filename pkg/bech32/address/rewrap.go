@@ -0,0 +1,57 @@
+package address
+
+import "fmt"
+
+// RewrapResult contains an address re-encoded under a different network prefix.
+type RewrapResult struct {
+	// Address is the bech32 encoding of the address with the new prefix.
+	Address string
+	// From is the prefix of the original address.
+	From Prefix
+	// To is the new prefix.
+	To Prefix
+	// Warnings describes why the re-encoded address might not be usable as expected.
+	Warnings []string
+}
+
+// Rewrap decodes the bech32 address addr and encodes the same payload using newPrefix.
+// This is useful to translate addresses between test and development networks, but the resulting address does not
+// necessarily refer to anything meaningful on the new network. Such cases are reported in the Warnings of the result.
+func Rewrap(addr string, newPrefix Prefix) (*RewrapResult, error) {
+	prefix, a, err := ParseBech32(addr)
+	if err != nil {
+		return nil, err
+	}
+	s, err := Bech32(newPrefix, a)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &RewrapResult{Address: s, From: prefix, To: newPrefix}
+	if prefix == newPrefix {
+		res.Warnings = append(res.Warnings, "prefix unchanged")
+		return res, nil
+	}
+	switch a.Version() {
+	case Ed25519:
+		if prefix.CoinType() != newPrefix.CoinType() {
+			res.Warnings = append(res.Warnings, fmt.Sprintf(
+				"%s and %s use different coin types: wallets using the default derivation path will not find the address",
+				prefix.Name(), newPrefix.Name()))
+		}
+	case Alias, NFT:
+		res.Warnings = append(res.Warnings, fmt.Sprintf(
+			"%s IDs are network specific: the output most likely does not exist on %s",
+			a.Version(), newPrefix.Name()))
+	}
+	if isMainnet(prefix) != isMainnet(newPrefix) {
+		res.Warnings = append(res.Warnings, fmt.Sprintf(
+			"%s and %s are not both mainnets: do not confuse funds on the two networks",
+			prefix.Name(), newPrefix.Name()))
+	}
+	return res, nil
+}
+
+func isMainnet(prefix Prefix) bool {
+	return prefix == IOTAMainnet || prefix == ShimmerMainnet
+}
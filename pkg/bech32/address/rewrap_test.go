@@ -0,0 +1,52 @@
+//nolint:scopelint
+package address_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/bech32/address"
+)
+
+func TestRewrap(t *testing.T) {
+	const iota = "iota1qrhacyfwlcnzkvzteumekfkrrwks98mpdm37cj4xx3drvmjvnep6xqgyzyx"
+	var outputID [address.OutputIDLength]byte
+	alias, err := address.Bech32(address.IOTAMainnet, address.AliasAddressFromOutputID(outputID))
+	require.NoError(t, err)
+
+	var tests = []*struct {
+		desc        string
+		addr        string
+		prefix      address.Prefix
+		expWarnings int
+	}{
+		{"unchanged", iota, address.IOTAMainnet, 1},
+		{"devnet", iota, address.IOTADevnet, 1},
+		{"shimmer", iota, address.ShimmerMainnet, 1},
+		{"shimmer devnet", iota, address.ShimmerDevnet, 2},
+		{"alias", alias, address.IOTADevnet, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			res, err := address.Rewrap(tt.addr, tt.prefix)
+			require.NoError(t, err)
+			assert.Len(t, res.Warnings, tt.expWarnings, res.Warnings)
+			assert.Equal(t, address.IOTAMainnet, res.From)
+			assert.Equal(t, tt.prefix, res.To)
+
+			// the payload must not change
+			prefix, addr, err := address.ParseBech32(res.Address)
+			require.NoError(t, err)
+			assert.Equal(t, tt.prefix, prefix)
+			_, orig, err := address.ParseBech32(tt.addr)
+			require.NoError(t, err)
+			assert.True(t, orig.Equal(addr))
+		})
+	}
+
+	_, err = address.Rewrap(iota, address.Prefix(-1))
+	assert.ErrorIs(t, err, address.ErrInvalidPrefix)
+}
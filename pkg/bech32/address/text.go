@@ -0,0 +1,88 @@
+package address
+
+import (
+	"bytes"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/bech32"
+)
+
+// defaultPrefix is the network prefix used when an address is formatted or marshaled without an explicit prefix.
+// Its zero value corresponds to IOTAMainnet.
+var defaultPrefix atomic.Int64
+
+// DefaultPrefix returns the network prefix used when an address is formatted or marshaled without an explicit prefix.
+func DefaultPrefix() Prefix {
+	return Prefix(defaultPrefix.Load())
+}
+
+// SetDefaultPrefix sets the network prefix used when an address is formatted or marshaled without an explicit prefix.
+// It returns ErrInvalidPrefix if p is not registered; it is safe for concurrent use with formatting addresses.
+func SetDefaultPrefix(p Prefix) error {
+	if _, ok := lookupNetwork(p); !ok {
+		return fmt.Errorf("%w: %s", ErrInvalidPrefix, p)
+	}
+	defaultPrefix.Store(int64(p))
+	return nil
+}
+
+// Compare returns an integer comparing the serializations of a and b, i.e. first by version and then by hash.
+// The result will be 0 if a == b, -1 if a < b, and +1 if a > b.
+func Compare(a, b Address) int {
+	return bytes.Compare(a.Bytes(), b.Bytes())
+}
+
+// Less reports whether a sorts before b.
+// It can be used to sort slices of addresses, e.g. with sort.Slice.
+func Less(a, b Address) bool {
+	return Compare(a, b) < 0
+}
+
+func bech32String(addr Address) string {
+	s, err := bech32.Encode(DefaultPrefix().String(), addr.Bytes())
+	if err != nil {
+		// the encoding of a long custom human-readable part can exceed the maximum length of bech32 strings
+		return addr.Version().String() + "(" + addr.Hex() + ")"
+	}
+	return s
+}
+
+func marshalText(addr Address) ([]byte, error) {
+	s, err := Bech32(DefaultPrefix(), addr)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(s), nil
+}
+
+// unmarshalText parses the bech32 encoded text into dst, which must be a pointer to a concrete address type.
+func unmarshalText(text []byte, dst Address) error {
+	_, addr, err := ParseBech32(string(text))
+	if err != nil {
+		return err
+	}
+	return assign(addr, dst)
+}
+
+// assign sets dst, which must be a pointer to a concrete address type, to addr, if both have the same type.
+func assign(addr Address, dst Address) error {
+	switch d := dst.(type) {
+	case *Ed25519Address:
+		if a, ok := addr.(Ed25519Address); ok {
+			*d = a
+			return nil
+		}
+	case *AliasAddress:
+		if a, ok := addr.(AliasAddress); ok {
+			*d = a
+			return nil
+		}
+	case *NFTAddress:
+		if a, ok := addr.(NFTAddress); ok {
+			*d = a
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: unexpected %s address", ErrInvalidVersion, addr.Version())
+}
@@ -0,0 +1,101 @@
+//nolint:scopelint
+package address_test
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/bech32/address"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	type config struct {
+		Owner address.Ed25519Address `json:"owner"`
+		Alias address.AliasAddress   `json:"alias"`
+	}
+	var outputID [address.OutputIDLength]byte
+	c := config{
+		Owner: address.AddressFromPublicKey(testPublicKey),
+		Alias: address.AliasAddressFromOutputID(outputID),
+	}
+
+	b, err := json.Marshal(c)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"owner":"`+c.Owner.String()+`","alias":"`+c.Alias.String()+`"}`, string(b))
+
+	var decoded config
+	require.NoError(t, json.Unmarshal(b, &decoded))
+	assert.Equal(t, c, decoded)
+
+	// an address of a different kind must be rejected
+	var nft address.NFTAddress
+	assert.ErrorIs(t, nft.UnmarshalText([]byte(c.Alias.String())), address.ErrInvalidVersion)
+}
+
+func TestEqualCompare(t *testing.T) {
+	var outputID [address.OutputIDLength]byte
+	ed := address.AddressFromPublicKey(testPublicKey)
+	alias := address.AliasAddressFromOutputID(outputID)
+	nft := address.NFTAddressFromOutputID(outputID)
+
+	assert.True(t, ed.Equal(address.AddressFromPublicKey(testPublicKey)))
+	assert.False(t, alias.Equal(nft))
+	assert.Zero(t, address.Compare(alias, alias))
+
+	addrs := []address.Address{nft, alias, ed}
+	sort.Slice(addrs, func(i, j int) bool { return address.Less(addrs[i], addrs[j]) })
+	assert.Equal(t, []address.Address{ed, alias, nft}, addrs)
+
+	// addresses can be used as map keys
+	m := map[address.Address]int{ed: 1, alias: 2, nft: 3}
+	assert.Equal(t, 2, m[address.AliasAddressFromOutputID(outputID)])
+}
+
+func TestDefaultPrefix(t *testing.T) {
+	addr := address.AddressFromPublicKey(testPublicKey)
+	assert.Equal(t, address.IOTAMainnet, address.DefaultPrefix())
+	assert.ErrorIs(t, address.SetDefaultPrefix(address.Prefix(1000)), address.ErrInvalidPrefix)
+	assert.Equal(t, address.IOTAMainnet, address.DefaultPrefix())
+
+	require.NoError(t, address.SetDefaultPrefix(address.ShimmerMainnet))
+	defer func() { require.NoError(t, address.SetDefaultPrefix(address.IOTAMainnet)) }()
+	s, err := address.Bech32(address.ShimmerMainnet, addr)
+	require.NoError(t, err)
+	assert.Equal(t, s, addr.String())
+
+	// formatting must be safe while the default prefix changes
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			_ = address.SetDefaultPrefix(address.Prefix(i % 2))
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			_ = addr.String()
+		}
+	}()
+	wg.Wait()
+}
+
+func TestStringFallback(t *testing.T) {
+	// the human-readable part is valid, but too long to encode an address with it
+	prefix, err := address.RegisterPrefix(strings.Repeat("x", 83), "Long")
+	require.NoError(t, err)
+	require.NoError(t, address.SetDefaultPrefix(prefix))
+	defer func() { require.NoError(t, address.SetDefaultPrefix(address.IOTAMainnet)) }()
+
+	addr := address.AddressFromPublicKey(testPublicKey)
+	assert.Equal(t, "Ed25519("+addr.Hex()+")", addr.String())
+	_, err = addr.MarshalText()
+	assert.Error(t, err)
+}
@@ -0,0 +1,176 @@
+package address
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip32path"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip39"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10/eddsa"
+)
+
+// bech32Charset contains all the characters that can appear in the data part of a bech32 string.
+const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+// vanityEntropySize is the size, in bytes, of the entropy of the generated mnemonics, i.e. 24 words.
+const vanityEntropySize = 32
+
+// ErrInvalidPattern is returned when a vanity pattern can never be matched.
+var ErrInvalidPattern = errors.New("invalid pattern")
+
+// VanityResult contains an Ed25519 address matching a vanity pattern together with how it can be derived.
+type VanityResult struct {
+	// Mnemonic is the BIP-39 mnemonic (without passphrase) of the wallet containing the address.
+	Mnemonic bip39.Mnemonic
+	// Path is the SLIP-10 derivation path of the address key.
+	Path bip32path.Path
+	// Address is the bech32 encoded address matching the pattern.
+	Address string
+	// Attempts is the total number of addresses derived by all workers.
+	Attempts uint64
+	// Duration is the time it took to find the address.
+	Duration time.Duration
+}
+
+// Rate returns the average number of attempts per second.
+func (r *VanityResult) Rate() float64 {
+	if r.Duration <= 0 {
+		return 0
+	}
+	return float64(r.Attempts) / r.Duration.Seconds()
+}
+
+// SearchVanity searches for an Ed25519 address of the network prefix, whose data part starts with pattern directly
+// after the version character.
+// Each worker generates a random BIP-39 mnemonic and then sequentially derives the address keys
+// m/44'/coin'/0'/0'/i' following SLIP-10, where coin is 4219 for Shimmer and 4218 otherwise.
+// If workers is not positive, GOMAXPROCS workers are used.
+// It blocks until a matching address is found or ctx is done.
+func SearchVanity(ctx context.Context, prefix Prefix, pattern string, workers int) (*VanityResult, error) {
+	if _, ok := lookupNetwork(prefix); !ok {
+		return nil, ErrInvalidPrefix
+	}
+	if !prefix.Supports(Ed25519) {
+		return nil, fmt.Errorf("%w: %s not supported by %s", ErrInvalidVersion, Ed25519, prefix)
+	}
+	pattern = strings.ToLower(pattern)
+	// the data part of an Ed25519 address has 53 characters plus the checksum, the first one is the fixed 'q'
+	if len(pattern) > 52 {
+		return nil, fmt.Errorf("%w: too long", ErrInvalidPattern)
+	}
+	for i := range pattern {
+		if strings.IndexByte(bech32Charset, pattern[i]) < 0 {
+			return nil, fmt.Errorf("%w: character %q not in bech32 charset", ErrInvalidPattern, pattern[i])
+		}
+	}
+	// the first data character holds the top 5 bits of the version byte, which are zero for Ed25519, i.e. it is always 'q',
+	// the pattern starts after it, with a character of the remaining 3 zero version bits and the first 2 bits of the hash,
+	// i.e. it is one of 'q', 'p', 'z' or 'r'
+	if len(pattern) > 0 && strings.IndexByte(bech32Charset[:4], pattern[0]) < 0 {
+		return nil, fmt.Errorf("%w: first character must be one of %q", ErrInvalidPattern, bech32Charset[:4])
+	}
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		attempts atomic.Uint64
+		once     sync.Once
+		result   *VanityResult
+		wg       sync.WaitGroup
+	)
+	errs := make(chan error, workers)
+	start := time.Now()
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res, err := searchVanity(ctx, prefix, pattern, &attempts)
+			if err != nil {
+				errs <- err
+				cancel()
+				return
+			}
+			if res != nil {
+				once.Do(func() { result = res })
+				cancel()
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	if result != nil {
+		result.Attempts = attempts.Load()
+		result.Duration = time.Since(start)
+		return result, nil
+	}
+	if err := <-errs; err != nil {
+		return nil, err
+	}
+	return nil, ctx.Err()
+}
+
+func searchVanity(ctx context.Context, prefix Prefix, pattern string, attempts *atomic.Uint64) (*VanityResult, error) {
+	entropy := make([]byte, vanityEntropySize)
+	if _, err := rand.Read(entropy); err != nil {
+		return nil, err
+	}
+	mnemonic, err := bip39.EntropyToMnemonic(entropy)
+	if err != nil {
+		return nil, err
+	}
+	seed, err := bip39.MnemonicToSeed(mnemonic, "")
+	if err != nil {
+		return nil, err
+	}
+	path := vanityPath(prefix)
+	key, err := slip10.DeriveKeyFromPath(seed, eddsa.Ed25519(), path)
+	if err != nil {
+		return nil, err
+	}
+
+	hrpLen := len(prefix.String())
+	for i := uint32(0); i < slip10.Hardened; i++ {
+		if ctx.Err() != nil {
+			return nil, nil
+		}
+		child, err := key.DeriveChild(i | slip10.Hardened)
+		if err != nil {
+			return nil, err
+		}
+		//nolint:forcetypeassert // the Ed25519 curve always returns a Seed
+		pub, _ := child.Key.(eddsa.Seed).Ed25519Key()
+		s, err := Bech32(prefix, AddressFromPublicKey(pub))
+		if err != nil {
+			return nil, err
+		}
+		attempts.Add(1)
+		// skip the separator and the version character
+		if strings.HasPrefix(s[hrpLen+2:], pattern) {
+			return &VanityResult{
+				Mnemonic: mnemonic,
+				Path:     append(path, i|slip10.Hardened),
+				Address:  s,
+			}, nil
+		}
+	}
+	// all indices are exhausted, start over with a new mnemonic
+	return searchVanity(ctx, prefix, pattern, attempts)
+}
+
+// vanityPath returns the BIP-44 path of the first address chain of the first account.
+func vanityPath(prefix Prefix) bip32path.Path {
+	return bip32path.Path{44 | slip10.Hardened, prefix.CoinType() | slip10.Hardened, 0 | slip10.Hardened, 0 | slip10.Hardened}
+}
@@ -0,0 +1,55 @@
+//nolint:scopelint
+package address_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/bech32/address"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip39"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10/eddsa"
+)
+
+func TestSearchVanity(t *testing.T) {
+	const pattern = "r7"
+
+	res, err := address.SearchVanity(context.Background(), address.ShimmerMainnet, pattern, 2)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(res.Address, "smr1q"+pattern), res.Address)
+	assert.NotZero(t, res.Attempts)
+	assert.Greater(t, res.Rate(), 0.)
+
+	// the address must be reproducible from the mnemonic and the path
+	seed, err := bip39.MnemonicToSeed(res.Mnemonic, "")
+	require.NoError(t, err)
+	key, err := slip10.DeriveKeyFromPath(seed, eddsa.Ed25519(), res.Path)
+	require.NoError(t, err)
+	pub, _ := key.Key.(eddsa.Seed).Ed25519Key()
+	s, err := address.Bech32(address.ShimmerMainnet, address.AddressFromPublicKey(pub))
+	require.NoError(t, err)
+	assert.Equal(t, res.Address, s)
+}
+
+func TestSearchVanityInvalid(t *testing.T) {
+	_, err := address.SearchVanity(context.Background(), address.IOTAMainnet, "b", 1)
+	assert.ErrorIs(t, err, address.ErrInvalidPattern)
+	_, err = address.SearchVanity(context.Background(), address.IOTAMainnet, "a", 1)
+	assert.ErrorIs(t, err, address.ErrInvalidPattern)
+	_, err = address.SearchVanity(context.Background(), address.IOTAMainnet, strings.Repeat("q", 53), 1)
+	assert.ErrorIs(t, err, address.ErrInvalidPattern)
+	_, err = address.SearchVanity(context.Background(), address.Prefix(-1), "q", 1)
+	assert.ErrorIs(t, err, address.ErrInvalidPrefix)
+}
+
+func TestSearchVanityCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	// the pattern is practically impossible to find
+	_, err := address.SearchVanity(ctx, address.IOTAMainnet, strings.Repeat("q", 52), 1)
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -1,18 +1,24 @@
-// Package bech32 implements bech32 encoding and decoding.
+// Package bech32 implements bech32 and bech32m encoding and decoding.
+//
+// The implementation is not constant time and reports the position of errors, which makes it unsuitable for strings
+// whose data part encodes secret material. Use the ctbech32 subpackage for those.
 package bech32
 
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/iotaledger/iota-crypto-demo/pkg/bech32/internal/base32"
 )
 
 const (
-	maxStringLength = 90
-	checksumLength  = 6
-	separator       = '1'
+	// MaxLength is the maximum length of a Bech32 string as defined in BIP-173.
+	MaxLength = 90
+
+	checksumLength = 6
+	separator      = '1'
 )
 
 var charset = newEncoding("qpzry9x8gf2tvdw0s3jn54khce6mua7l")
@@ -20,71 +26,226 @@ var charset = newEncoding("qpzry9x8gf2tvdw0s3jn54khce6mua7l")
 // Encode encodes the hrp string and the src data as a Bech32 string.
 // It returns an error when the input is invalid.
 func Encode(hrp string, src []byte) (string, error) {
-	dataLen := base32.EncodedLen(len(src))
-	if len(hrp)+dataLen+checksumLength+1 > maxStringLength {
-		return "", fmt.Errorf("%w: String length=%d, data length=%d", ErrInvalidLength, len(hrp), dataLen)
-	}
-	// validate the human-readable part
-	if len(hrp) < 1 {
-		return "", fmt.Errorf("%w: String must not be empty", ErrInvalidLength)
-	}
-	for _, c := range hrp {
-		if !isValidHRPChar(c) {
-			return "", fmt.Errorf("%w: not US-ASCII character in human-readable part", ErrInvalidCharacter)
-		}
+	return EncodeVariant(Bech32, hrp, src)
+}
+
+// EncodeVariant encodes the hrp string and the src data as a Bech32 string using the checksum variant v.
+// It returns an error when the input is invalid.
+func EncodeVariant(v Variant, hrp string, src []byte) (string, error) {
+	return EncodeLimit(v, hrp, src, MaxLength)
+}
+
+// EncodeWords encodes the hrp string and the 5-bit values in words as a Bech32 string using the checksum variant v.
+// This allows encoding data that is not a sequence of bytes, e.g. a segwit address with its witness version.
+// It returns an error when the input is invalid.
+func EncodeWords(v Variant, hrp string, words []uint8) (string, error) {
+	return encodeWords(v, hrp, words, MaxLength)
+}
+
+// EncodeLimit encodes the hrp string and the src data as a Bech32 string using the checksum variant v.
+// In contrast to EncodeVariant, the resulting string must not be longer than limit instead of MaxLength.
+// This allows longer payloads as used by some protocols, but the error detection properties are only guaranteed for
+// strings of at most MaxLength characters.
+// It returns an error when the input is invalid.
+func EncodeLimit(v Variant, hrp string, src []byte, limit int) (string, error) {
+	var buf [MaxLength]uint8
+	return encodeWords(v, hrp, toWords(buf[:], src), limit)
+}
+
+// AppendEncode appends the Bech32 encoding of the hrp string and the src data to dst and returns the extended buffer.
+// Apart from growing dst, it does not allocate.
+// It returns an error when the input is invalid.
+func AppendEncode(dst []byte, hrp string, src []byte) ([]byte, error) {
+	var buf [MaxLength]uint8
+	return appendEncodeWords(dst, Bech32, hrp, toWords(buf[:], src), MaxLength)
+}
+
+// toWords converts src into base32 digits using buf, if it is large enough.
+func toWords(buf []uint8, src []byte) []uint8 {
+	n := base32.EncodedLen(len(src))
+	if n > len(buf) {
+		buf = make([]uint8, n)
 	}
-	if err := validateCase(hrp); err != nil {
+	base32.Encode(buf, src)
+	return buf[:n]
+}
+
+func encodeWords(v Variant, hrp string, words []uint8, limit int) (string, error) {
+	res, err := appendEncodeWords(make([]byte, 0, len(hrp)+1+len(words)+checksumLength), v, hrp, words, limit)
+	if err != nil {
 		return "", err
 	}
+	return string(res), nil
+}
 
-	// convert the human-readable part to lower for the checksum
-	hrpLower := strings.ToLower(hrp)
+func appendEncodeWords(dst []byte, v Variant, hrp string, words []uint8, limit int) ([]byte, error) {
+	if v != Bech32 && v != Bech32m {
+		return dst, fmt.Errorf("invalid variant: %s", v)
+	}
+	dataLen := len(words)
+	if len(hrp)+dataLen+checksumLength+1 > limit {
+		return dst, fmt.Errorf("%w: String length=%d, data length=%d", ErrInvalidLength, len(hrp), dataLen)
+	}
+	if err := validateHRP(hrp); err != nil {
+		return dst, err
+	}
+	// validate the data part
+	for _, w := range words {
+		if w >= 32 {
+			return dst, fmt.Errorf("%w: data value %d exceeds 5 bits", ErrInvalidCharacter, w)
+		}
+	}
 
-	// convert to base32 and add the checksum
-	data := make([]uint8, base32.EncodedLen(len(src))+checksumLength)
-	base32.Encode(data, src)
-	copy(data[dataLen:], bech32CreateChecksum(hrpLower, data[:dataLen]))
+	// the checksum is always computed over the lowercase human-readable part
+	checksum := bech32CreateChecksum(v, hrp, words)
 
 	// enc the data part using the charset
-	chars := charset.encode(data)
-
-	// convert to a string using the corresponding charset
-	var res strings.Builder
-	res.WriteString(hrp)
-	res.WriteByte(separator)
-	res.WriteString(chars)
+	start := len(dst)
+	dst = append(dst, hrp...)
+	dst = append(dst, separator)
+	dst = charset.appendEncode(dst, words)
+	dst = charset.appendEncode(dst, checksum[:])
 
 	// return with the correct case
-	if hrp == hrpLower {
-		return res.String(), nil
+	if firstUpper(hrp) >= 0 {
+		for i := start + len(hrp) + 1; i < len(dst); i++ {
+			if 'a' <= dst[i] && dst[i] <= 'z' {
+				dst[i] -= 'a' - 'A'
+			}
+		}
 	}
-	return strings.ToUpper(res.String()), nil
+	return dst, nil
+}
+
+// EncodeUpper encodes the hrp string and the src data as an all-uppercase Bech32 string.
+// This form is preferred when the string is encoded in a QR code, as the alphanumeric mode leads to smaller codes.
+// It returns an error when the input is invalid.
+func EncodeUpper(hrp string, src []byte) (string, error) {
+	s, err := Encode(hrp, src)
+	if err != nil {
+		return "", err
+	}
+	return strings.ToUpper(s), nil
 }
 
 // Decode decodes the Bech32 string s into its human-readable and data part.
 // It returns an error when s does not represent a valid Bech32 encoding.
+// Only the original checksum described in BIP-173 is accepted, use DecodeVariant to also accept Bech32m.
 // An SyntaxError is returned when the error can be matched to a certain position in s.
 func Decode(s string) (string, []byte, error) {
-	if len(s) > maxStringLength {
-		return "", nil, &SyntaxError{fmt.Errorf("%w: maximum length exceeded", ErrInvalidLength), maxStringLength}
+	hrp, data, v, err := DecodeVariant(s)
+	if err != nil {
+		return "", nil, err
+	}
+	if v != Bech32 {
+		return "", nil, &SyntaxError{fmt.Errorf("%w: unexpected %s checksum", ErrInvalidChecksum, v), len(s) - checksumLength}
+	}
+	return hrp, data, nil
+}
+
+// DecodeStrict decodes the Bech32 string s like Decode, but only accepts its canonical form.
+// In addition to the checks of Decode, which already rejects non-zero padding bits (ErrNonZeroPadding), incomplete
+// bytes (ErrInvalidPadding) and the Bech32m checksum, s must be all lowercase, so that every human-readable and data
+// part has exactly one valid encoding, i.e. the one returned by Encode.
+// It returns a SyntaxError wrapping ErrNotCanonical for the first uppercase character.
+func DecodeStrict(s string) (string, []byte, error) {
+	hrp, data, err := Decode(s)
+	if err != nil {
+		return "", nil, err
+	}
+	if i := firstUpper(s); i >= 0 {
+		return "", nil, &SyntaxError{fmt.Errorf("%w: uppercase character", ErrNotCanonical), i}
+	}
+	return hrp, data, nil
+}
+
+// AppendDecode decodes the Bech32 string s like Decode, appends the data part to dst and returns the extended buffer.
+// Apart from growing dst, it does not allocate for valid lowercase input.
+func AppendDecode(dst []byte, s string) (string, []byte, error) {
+	var buf [MaxLength]uint8
+	hrp, words, v, err := decodeWords(buf[:0], s, MaxLength)
+	if err != nil {
+		return "", dst, err
+	}
+	if v != Bech32 {
+		return "", dst, &SyntaxError{fmt.Errorf("%w: unexpected %s checksum", ErrInvalidChecksum, v), len(s) - checksumLength}
+	}
+	dst, err = appendDecodeData(dst, len(hrp), words)
+	if err != nil {
+		return "", dst, err
+	}
+	return hrp, dst, nil
+}
+
+// DecodeVariant decodes the Bech32 string s into its human-readable and data part.
+// It accepts both, the Bech32 and Bech32m checksum, and returns the variant that matched.
+// It returns an error when s does not represent a valid Bech32 encoding.
+// An SyntaxError is returned when the error can be matched to a certain position in s.
+func DecodeVariant(s string) (string, []byte, Variant, error) {
+	return DecodeLimit(s, MaxLength)
+}
+
+// DecodeLimit decodes the Bech32 string s into its human-readable and data part.
+// In contrast to DecodeVariant, s must not be longer than limit instead of MaxLength.
+// It returns an error when s does not represent a valid Bech32 encoding.
+// An SyntaxError is returned when the error can be matched to a certain position in s.
+func DecodeLimit(s string, limit int) (string, []byte, Variant, error) {
+	var buf [MaxLength]uint8
+	hrp, words, v, err := decodeWords(buf[:0], s, limit)
+	if err != nil {
+		return "", nil, 0, err
+	}
+	dst, err := appendDecodeData(make([]byte, 0, base32.DecodedLen(len(words))), len(hrp), words)
+	if err != nil {
+		return "", nil, 0, err
+	}
+	return hrp, dst, v, nil
+}
+
+// appendDecodeData appends the bytes represented by the base32 digits in words to dst.
+func appendDecodeData(dst []byte, hrpLen int, words []uint8) ([]byte, error) {
+	n := base32.DecodedLen(len(words))
+	dst = slices.Grow(dst, n)
+	if _, err := base32.Decode(dst[len(dst):len(dst)+n], words); err != nil {
+		var e *base32.CorruptInputError
+		if errors.As(err, &e) {
+			return dst, &SyntaxError{e.Unwrap(), hrpLen + 1 + e.Offset}
+		}
+		return dst, err
+	}
+	return dst[:len(dst)+n], nil
+}
+
+// DecodeWords decodes the Bech32 string s into its human-readable part and the 5-bit values of its data part.
+// It accepts both, the Bech32 and Bech32m checksum, and returns the variant that matched.
+// It returns an error when s does not represent a valid Bech32 encoding.
+// An SyntaxError is returned when the error can be matched to a certain position in s.
+func DecodeWords(s string) (string, []uint8, Variant, error) {
+	return decodeWords(nil, s, MaxLength)
+}
+
+// decodeWords decodes s and appends the 5-bit values of its data part to buf.
+func decodeWords(buf []uint8, s string, limit int) (string, []uint8, Variant, error) {
+	if len(s) > limit {
+		return "", nil, 0, &SyntaxError{fmt.Errorf("%w: maximum length exceeded", ErrInvalidLength), limit}
 	}
 	// validate the separator
 	hrpLen := strings.LastIndex(s, string(separator))
 	if hrpLen == -1 {
-		return "", nil, ErrMissingSeparator
+		return "", nil, 0, ErrMissingSeparator
 	}
 	if hrpLen < 1 || hrpLen+checksumLength > len(s) {
-		return "", nil, &SyntaxError{fmt.Errorf("%w: invalid position", ErrInvalidSeparator), hrpLen}
+		return "", nil, 0, &SyntaxError{fmt.Errorf("%w: invalid position", ErrInvalidSeparator), hrpLen}
 	}
 	// validate characters in human-readable part
 	for i, c := range s[:hrpLen] {
 		if !isValidHRPChar(c) {
-			return "", nil, &SyntaxError{fmt.Errorf("%w: not US-ASCII character in human-readable part", ErrInvalidCharacter), i}
+			return "", nil, 0, &SyntaxError{fmt.Errorf("%w: not US-ASCII character in human-readable part", ErrInvalidCharacter), i}
 		}
 	}
 	// validate that the case of the entire string is consistent
 	if err := validateCase(s); err != nil {
-		return "", nil, err
+		return "", nil, 0, err
 	}
 
 	// convert everything to lower
@@ -93,27 +254,80 @@ func Decode(s string) (string, []byte, error) {
 	chars := s[hrpLen+1:]
 
 	// decode the data part
-	data, err := charset.decode(chars)
+	data, err := charset.appendDecode(buf, chars)
 	if err != nil {
-		return "", nil, &SyntaxError{fmt.Errorf("%w: non-charset character in data part", ErrInvalidCharacter), hrpLen + 1 + len(data)}
+		return "", nil, 0, &SyntaxError{fmt.Errorf("%w: non-charset character in data part", ErrInvalidCharacter), hrpLen + 1 + len(data) - len(buf)}
 	}
 
 	// validate the checksum
-	if len(data) < checksumLength || !bech32VerifyChecksum(hrp, data) {
-		return "", nil, &SyntaxError{ErrInvalidChecksum, len(s) - checksumLength}
+	if len(data) < checksumLength {
+		return "", nil, 0, &SyntaxError{ErrInvalidChecksum, len(s) - checksumLength}
+	}
+	v := bech32VerifyChecksum(hrp, data)
+	if v == 0 {
+		return "", nil, 0, newChecksumError(hrp, data)
 	}
-	data = data[:len(data)-checksumLength]
+	return hrp, data[:len(data)-checksumLength], v, nil
+}
 
-	// decode the data part
-	dst := make([]byte, base32.DecodedLen(len(data)))
-	if _, err := base32.Decode(dst, data); err != nil {
-		var e *base32.CorruptInputError
-		if errors.As(err, &e) {
-			return "", nil, &SyntaxError{e.Unwrap(), hrpLen + 1 + e.Offset}
+// newChecksumError returns a SyntaxError for an invalid checksum, locating the most likely errors for both variants.
+func newChecksumError(hrp string, data []byte) error {
+	offset := len(hrp) + 1
+	// errors can only be located reliably in strings of at most MaxLength characters
+	if offset+len(data) > MaxLength {
+		return &SyntaxError{&ChecksumError{}, offset + len(data) - checksumLength}
+	}
+	positions := bech32LocateErrors(Bech32, hrp, data)
+	if m := bech32LocateErrors(Bech32m, hrp, data); len(positions) == 0 || (len(m) > 0 && len(m) < len(positions)) {
+		positions = m
+	}
+	for i := range positions {
+		positions[i] += offset
+	}
+
+	// report the first error position or the start of the checksum if the errors could not be located
+	errOffset := offset + len(data) - checksumLength
+	if len(positions) > 0 {
+		errOffset = positions[0]
+	}
+	return &SyntaxError{&ChecksumError{positions}, errOffset}
+}
+
+// ValidateHRP checks whether hrp is a valid human-readable part of a Bech32 string.
+// It must consist of 1 to 83 US-ASCII characters in the range [33-126] and must not contain mixed case.
+func ValidateHRP(hrp string) error {
+	if len(hrp)+1+checksumLength > MaxLength {
+		return fmt.Errorf("%w: human-readable part too long", ErrInvalidLength)
+	}
+	return validateHRP(hrp)
+}
+
+// validateHRP checks the characters and case of hrp without enforcing the maximum length.
+func validateHRP(hrp string) error {
+	if len(hrp) < 1 {
+		return fmt.Errorf("%w: String must not be empty", ErrInvalidLength)
+	}
+	for _, c := range hrp {
+		if !isValidHRPChar(c) {
+			return fmt.Errorf("%w: not US-ASCII character in human-readable part", ErrInvalidCharacter)
 		}
-		return "", nil, err
 	}
-	return hrp, dst, nil
+	return validateCase(hrp)
+}
+
+// ExpandHRP returns the expansion of the human-readable part hrp that is prepended to the data for the checksum
+// computation, i.e. the high bits of each character, a zero and the low bits of each character.
+// As the checksum is defined over the lowercase human-readable part, hrp is converted to lowercase first.
+func ExpandHRP(hrp string) []byte {
+	res := make([]byte, 0, 2*len(hrp)+1)
+	for i := 0; i < len(hrp); i++ {
+		res = append(res, toLower(hrp[i])>>5)
+	}
+	res = append(res, 0)
+	for i := 0; i < len(hrp); i++ {
+		res = append(res, toLower(hrp[i])&31)
+	}
+	return res
 }
 
 func isValidHRPChar(r rune) bool {
@@ -132,20 +346,20 @@ func validateCase(s string) error {
 	return nil
 }
 
+// firstUpper returns the index of the first uppercase US-ASCII letter in s or -1 if there is none.
 func firstUpper(s string) int {
-	lower := strings.ToLower(s)
-	for i := range s {
-		if lower[i] != s[i] {
+	for i := 0; i < len(s); i++ {
+		if 'A' <= s[i] && s[i] <= 'Z' {
 			return i
 		}
 	}
 	return -1
 }
 
+// firstLower returns the index of the first lowercase US-ASCII letter in s or -1 if there is none.
 func firstLower(s string) int {
-	lower := strings.ToUpper(s)
-	for i := range s {
-		if lower[i] != s[i] {
+	for i := 0; i < len(s); i++ {
+		if 'a' <= s[i] && s[i] <= 'z' {
 			return i
 		}
 	}
@@ -5,9 +5,11 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/iotaledger/iota-crypto-demo/pkg/bech32/internal/base32"
 )
@@ -98,6 +100,40 @@ func TestEncode(t *testing.T) {
 	}
 }
 
+func TestEncodeUpper(t *testing.T) {
+	var tests = []*struct {
+		hrp    string
+		src    []byte
+		expS   string
+		expErr error
+	}{
+		{hrp: "a", src: []byte{}, expS: "A12UEL5L"},
+		{hrp: "A", src: []byte{}, expS: "A12UEL5L"},
+		{
+			hrp:  "abcdef",
+			src:  decodeHex("00443214c74254b635cf84653a56d7c675be77df"),
+			expS: "ABCDEF1QPZRY9X8GF2TVDW0S3JN54KHCE6MUA7LMQQQXW",
+		},
+		{hrp: "bC", src: []byte{}, expErr: ErrMixedCase},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.hrp, tt.src), func(t *testing.T) {
+			s, err := EncodeUpper(tt.hrp, tt.src)
+			if assert.Truef(t, errors.Is(err, tt.expErr), "unexpected error: %v", err) {
+				assert.Equal(t, tt.expS, s)
+			}
+			if err == nil {
+				// the uppercase form must decode to the lowercase human-readable part
+				hrp, data, err := Decode(s)
+				require.NoError(t, err)
+				assert.Equal(t, strings.ToLower(tt.hrp), hrp)
+				assert.Equal(t, tt.src, data)
+			}
+		})
+	}
+}
+
 func TestDecode(t *testing.T) {
 	var tests = []*struct {
 		s       string
@@ -197,6 +233,204 @@ func TestDecode(t *testing.T) {
 	}
 }
 
+func TestEncodeVariant(t *testing.T) {
+	var tests = []*struct {
+		v      Variant
+		hrp    string
+		src    []byte
+		expS   string
+		expErr error
+	}{
+		{v: Bech32m, hrp: "A", src: []byte{}, expS: "A1LQFN3A"},
+		{v: Bech32m, hrp: "a", src: []byte{}, expS: "a1lqfn3a"},
+		{v: Bech32m, hrp: "?", src: []byte{}, expS: "?1v759aa"},
+		{
+			v:    Bech32m,
+			hrp:  "split",
+			src:  decodeHex("c5f38b70305f519bf66d85fb6cf03058f3dde463ecd7918f2dc743918f2d"),
+			expS: "split1checkupstagehandshakeupstreamerranterredcaperredlc445v",
+		},
+		{
+			v:    Bech32,
+			hrp:  "split",
+			src:  decodeHex("c5f38b70305f519bf66d85fb6cf03058f3dde463ecd7918f2dc743918f2d"),
+			expS: "split1checkupstagehandshakeupstreamerranterredcaperred2y9e3w",
+		},
+		{v: Bech32m, hrp: "bC", src: []byte{}, expErr: ErrMixedCase},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.v, tt.hrp, tt.src), func(t *testing.T) {
+			s, err := EncodeVariant(tt.v, tt.hrp, tt.src)
+			if assert.Truef(t, errors.Is(err, tt.expErr), "unexpected error: %v", err) {
+				assert.Equal(t, tt.expS, s)
+			}
+		})
+	}
+}
+
+func TestDecodeVariant(t *testing.T) {
+	var tests = []*struct {
+		s       string
+		expHRP  string
+		expData []byte
+		expV    Variant
+		expErr  error
+	}{
+		// test vectors from BIP-350
+		{s: "A1LQFN3A", expHRP: "a", expData: []byte{}, expV: Bech32m},
+		{s: "a1lqfn3a", expHRP: "a", expData: []byte{}, expV: Bech32m},
+		{
+			s:       "an83characterlonghumanreadablepartthatcontainsthetheexcludedcharactersbioandnumber11sg7hg6",
+			expHRP:  "an83characterlonghumanreadablepartthatcontainsthetheexcludedcharactersbioandnumber1",
+			expData: []byte{},
+			expV:    Bech32m,
+		},
+		{
+			s:       "split1checkupstagehandshakeupstreamerranterredcaperredlc445v",
+			expHRP:  "split",
+			expData: decodeHex("c5f38b70305f519bf66d85fb6cf03058f3dde463ecd7918f2dc743918f2d"),
+			expV:    Bech32m,
+		},
+		{s: "?1v759aa", expHRP: "?", expData: []byte{}, expV: Bech32m},
+		{s: "a12uel5l", expHRP: "a", expData: []byte{}, expV: Bech32},
+		{s: "\x201xj0phk", expErr: ErrInvalidCharacter},
+		{s: "\x7F1g6xzxy", expErr: ErrInvalidCharacter},
+		{s: "\x801vctc34", expErr: ErrInvalidCharacter},
+		{s: "an84characterslonghumanreadablepartthatcontainsthetheexcludedcharactersbioandnumber11d6pts4", expErr: ErrInvalidLength},
+		{s: "qyrz8wqd2c9m", expErr: ErrMissingSeparator},
+		{s: "1qyrz8wqd2c9m", expErr: ErrInvalidSeparator},
+		{s: "y1b0jsk6g", expErr: ErrInvalidCharacter},
+		{s: "lt1igcx5c0", expErr: ErrInvalidCharacter},
+		{s: "in1muywd", expErr: ErrInvalidChecksum},
+		{s: "mm1crxm3i", expErr: ErrInvalidCharacter},
+		{s: "au1s5cgom", expErr: ErrInvalidCharacter},
+		{s: "M1VUXWEZ", expErr: ErrInvalidChecksum},
+		{s: "16plkw9", expErr: ErrInvalidSeparator},
+		{s: "1p2gdwpf", expErr: ErrInvalidSeparator},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			hrp, data, v, err := DecodeVariant(tt.s)
+			if assert.Truef(t, errors.Is(err, tt.expErr), "unexpected error: %v", err) {
+				assert.Equal(t, tt.expHRP, hrp)
+				assert.Equal(t, tt.expData, data)
+				assert.Equal(t, tt.expV, v)
+			}
+		})
+	}
+}
+
+func TestLimit(t *testing.T) {
+	src := []byte("https://service.com/api?q=3fc3645b439ce8e7f2553a69e5267081d96dcd340693afabe04be7b0ccd178df")
+	_, err := EncodeVariant(Bech32, "lnurl", src)
+	assert.ErrorIs(t, err, ErrInvalidLength)
+
+	s, err := EncodeLimit(Bech32, "lnurl", src, 1023)
+	require.NoError(t, err)
+	assert.Greater(t, len(s), MaxLength)
+
+	_, _, _, err = DecodeVariant(s)
+	assert.ErrorIs(t, err, ErrInvalidLength)
+	hrp, data, v, err := DecodeLimit(s, 1023)
+	require.NoError(t, err)
+	assert.Equal(t, "lnurl", hrp)
+	assert.Equal(t, src, data)
+	assert.Equal(t, Bech32, v)
+
+	// a stricter limit
+	_, err = EncodeLimit(Bech32, "a", []byte{0, 1, 2}, 10)
+	assert.ErrorIs(t, err, ErrInvalidLength)
+	_, _, _, err = DecodeLimit("a12uel5l", 7)
+	assert.ErrorIs(t, err, ErrInvalidLength)
+
+	// the errors are not located in long strings
+	invalid := s[:len(s)-1] + "q"
+	if invalid == s {
+		invalid = s[:len(s)-1] + "p"
+	}
+	_, _, _, err = DecodeLimit(invalid, 1023)
+	var e *ChecksumError
+	require.ErrorAs(t, err, &e)
+	assert.Empty(t, e.Positions)
+}
+
+func TestWords(t *testing.T) {
+	words := []uint8{0, 31, 1, 30}
+	s, err := EncodeWords(Bech32m, "w", words)
+	require.NoError(t, err)
+
+	hrp, decoded, v, err := DecodeWords(s)
+	require.NoError(t, err)
+	assert.Equal(t, "w", hrp)
+	assert.Equal(t, words, decoded)
+	assert.Equal(t, Bech32m, v)
+
+	_, err = EncodeWords(Bech32, "w", []uint8{32})
+	assert.ErrorIs(t, err, ErrInvalidCharacter)
+}
+
+func TestDecodeBech32m(t *testing.T) {
+	// Decode only accepts the original checksum
+	_, _, err := Decode("a1lqfn3a")
+	assert.ErrorIs(t, err, ErrInvalidChecksum)
+}
+
+func TestDecodeLocateErrors(t *testing.T) {
+	const valid = "tb1qrp33g0q5c5txsp9arysrx4k6zdkfs4nce4xj0gdcccefvpysxf3q0sl5k7"
+
+	var tests = []*struct {
+		desc      string
+		positions []int
+	}{
+		{"one error", []int{10}},
+		{"one error in checksum", []int{len(valid) - 2}},
+		{"two errors", []int{5, 40}},
+		{"two adjacent errors", []int{21, 22}},
+		{"two errors in checksum", []int{len(valid) - 6, len(valid) - 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			s := []byte(valid)
+			for _, p := range tt.positions {
+				s[p] = charset.enc[(charset.decMap[s[p]]+7)%32]
+			}
+
+			_, _, err := Decode(string(s))
+			require.ErrorIs(t, err, ErrInvalidChecksum)
+			var e *ChecksumError
+			require.True(t, errors.As(err, &e))
+			assert.Equal(t, tt.positions, e.Positions)
+
+			var syntaxErr *SyntaxError
+			require.True(t, errors.As(err, &syntaxErr))
+			assert.Equal(t, tt.positions[0], syntaxErr.Offset)
+		})
+	}
+
+	// three errors are located as the most likely pair of errors, which must not change between runs
+	s3 := []byte(valid)
+	for _, p := range []int{3, 6, 27} {
+		s3[p] = charset.enc[(charset.decMap[s3[p]]+7)%32]
+	}
+	for i := 0; i < 100; i++ {
+		_, _, err := Decode(string(s3))
+		var e *ChecksumError
+		require.True(t, errors.As(err, &e))
+		require.Equal(t, []int{24, 44}, e.Positions)
+	}
+
+	// bech32m strings can be located as well
+	s := []byte("split1checkupstagehandshakeupstreamerranterredcaperredlc445v")
+	s[12] = 'q'
+	_, _, _, err := DecodeVariant(string(s))
+	var e *ChecksumError
+	require.True(t, errors.As(err, &e))
+	assert.Equal(t, []int{12}, e.Positions)
+}
+
 func decodeHex(s string) []byte {
 	dst, err := hex.DecodeString(s)
 	if err != nil {
@@ -204,3 +438,139 @@ func decodeHex(s string) []byte {
 	}
 	return dst
 }
+
+func TestAppend(t *testing.T) {
+	src := decodeHex("00443214c74254b635cf84653a56d7c675be77df")
+	prefix := []byte("addr: ")
+
+	dst, err := AppendEncode(prefix, "abcdef", src)
+	require.NoError(t, err)
+	assert.Equal(t, "addr: abcdef1qpzry9x8gf2tvdw0s3jn54khce6mua7lmqqqxw", string(dst))
+
+	upper, err := AppendEncode(nil, "ABCDEF", src)
+	require.NoError(t, err)
+	assert.Equal(t, "ABCDEF1QPZRY9X8GF2TVDW0S3JN54KHCE6MUA7LMQQQXW", string(upper))
+
+	hrp, data, err := AppendDecode([]byte{0xff}, string(dst[len(prefix):]))
+	require.NoError(t, err)
+	assert.Equal(t, "abcdef", hrp)
+	assert.Equal(t, append([]byte{0xff}, src...), data)
+
+	_, _, err = AppendDecode(nil, "split1checkupstagehandshakeupstreamerranterredcaperredlc445v")
+	assert.ErrorIs(t, err, ErrInvalidChecksum)
+}
+
+func TestAppendAllocs(t *testing.T) {
+	src := decodeHex("00443214c74254b635cf84653a56d7c675be77df")
+	const s = "abcdef1qpzry9x8gf2tvdw0s3jn54khce6mua7lmqqqxw"
+	buf := make([]byte, 0, MaxLength)
+
+	assert.Zero(t, testing.AllocsPerRun(100, func() {
+		_, _ = AppendEncode(buf, "abcdef", src)
+	}))
+	assert.Zero(t, testing.AllocsPerRun(100, func() {
+		_, _, _ = AppendDecode(buf, s)
+	}))
+}
+
+func BenchmarkEncode(b *testing.B) {
+	src := make([]byte, 33)
+	for i := 0; i < b.N; i++ {
+		_, _ = Encode("iota", src)
+	}
+}
+
+func BenchmarkAppendEncode(b *testing.B) {
+	src := make([]byte, 33)
+	buf := make([]byte, 0, MaxLength)
+	for i := 0; i < b.N; i++ {
+		_, _ = AppendEncode(buf, "iota", src)
+	}
+}
+
+func BenchmarkAppendDecode(b *testing.B) {
+	s, _ := Encode("iota", make([]byte, 33))
+	buf := make([]byte, 0, MaxLength)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _, _ = AppendDecode(buf, s)
+	}
+}
+
+func TestValidateHRP(t *testing.T) {
+	var tests = []*struct {
+		hrp    string
+		expErr error
+	}{
+		{hrp: "a"},
+		{hrp: "A"},
+		{hrp: "?"},
+		{hrp: strings.Repeat("a", 83)},
+		{hrp: "", expErr: ErrInvalidLength},
+		{hrp: strings.Repeat("a", 84), expErr: ErrInvalidLength},
+		{hrp: " ", expErr: ErrInvalidCharacter},
+		{hrp: "\x7f", expErr: ErrInvalidCharacter},
+		{hrp: "bC", expErr: ErrMixedCase},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.hrp, func(t *testing.T) {
+			assert.ErrorIs(t, ValidateHRP(tt.hrp), tt.expErr)
+		})
+	}
+}
+
+func TestExpandHRP(t *testing.T) {
+	assert.Equal(t, []byte{3, 3, 0, 2, 3}, ExpandHRP("bc"))
+	assert.Equal(t, ExpandHRP("bc"), ExpandHRP("BC"))
+	// the polymod of the expansion must match the internal computation
+	assert.Equal(t, bech32PolymodUpdate(1, ExpandHRP("split")), bech32HrpPolymod("split"))
+}
+
+func TestDecodeStrict(t *testing.T) {
+	var tests = []*struct {
+		s      string
+		expErr error
+	}{
+		{s: "a12uel5l"},
+		{s: "abcdef1qpzry9x8gf2tvdw0s3jn54khce6mua7lmqqqxw"},
+		{s: "A12UEL5L", expErr: ErrNotCanonical},
+		{s: "tb1qrp33g0q5c5txsp9arysrx4k6zdkfs4nce4xj0gdcccefvpysxf3pjxtptv", expErr: ErrNonZeroPadding},
+		{s: "test1ls7uz56", expErr: ErrInvalidPadding},
+		{s: " a12uel5l", expErr: ErrInvalidCharacter},
+		{s: "a12uel5l ", expErr: ErrInvalidCharacter},
+		{s: "a1lqfn3a", expErr: ErrInvalidChecksum},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			hrp, data, err := DecodeStrict(tt.s)
+			if tt.expErr != nil {
+				assert.ErrorIs(t, err, tt.expErr)
+				var e *SyntaxError
+				assert.ErrorAs(t, err, &e)
+				return
+			}
+			require.NoError(t, err)
+			s, err := Encode(hrp, data)
+			require.NoError(t, err)
+			assert.Equal(t, tt.s, s)
+		})
+	}
+}
+
+func FuzzDecodeStrict(f *testing.F) {
+	f.Add("a12uel5l")
+	f.Add("A12UEL5L")
+	f.Add("abcdef1qpzry9x8gf2tvdw0s3jn54khce6mua7lmqqqxw")
+	f.Add("tb1qrp33g0q5c5txsp9arysrx4k6zdkfs4nce4xj0gdcccefvpysxf3pjxtptv")
+	f.Fuzz(func(t *testing.T, s string) {
+		hrp, data, err := DecodeStrict(s)
+		if err != nil {
+			return
+		}
+		// every accepted string must be the unique encoding of its parts
+		enc, err := Encode(hrp, data)
+		require.NoError(t, err)
+		require.Equal(t, s, enc)
+	})
+}
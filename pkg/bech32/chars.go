@@ -1,9 +1,5 @@
 package bech32
 
-import (
-	"strings"
-)
-
 type encoding struct {
 	enc    [32]byte
 	decMap [256]uint8
@@ -28,25 +24,23 @@ func newEncoding(charset string) *encoding {
 	return e
 }
 
-// encode converts the base32 digits of src into a string.
-func (e *encoding) encode(src []uint8) string {
-	var dst strings.Builder
-	dst.Grow(len(src))
+// appendEncode appends the characters corresponding to the base32 digits of src to dst.
+func (e *encoding) appendEncode(dst []byte, src []uint8) []byte {
 	for i := range src {
-		dst.WriteByte(e.enc[src[i]])
+		dst = append(dst, e.enc[src[i]])
 	}
-	return dst.String()
+	return dst
 }
 
-// decode converts the string into base32 digits.
-func (e *encoding) decode(src string) ([]uint8, error) {
-	dst := make([]uint8, len(src))
-	for i := range src {
+// appendDecode appends the base32 digits corresponding to the characters of src to dst.
+// In case of an invalid character, the digits decoded so far are returned together with the error.
+func (e *encoding) appendDecode(dst []uint8, src string) ([]uint8, error) {
+	for i := 0; i < len(src); i++ {
 		d := e.decMap[src[i]]
 		if d == 0xFF {
-			return dst[:i], ErrInvalidCharacter
+			return dst, ErrInvalidCharacter
 		}
-		dst[i] = e.decMap[src[i]]
+		dst = append(dst, d)
 	}
 	return dst, nil
 }
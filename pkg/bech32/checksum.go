@@ -1,47 +1,166 @@
 package bech32
 
+import "strconv"
+
 var gen = []int{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
 
-// For more details on the checksum calculation, please refer to BIP 173.
-func bech32CreateChecksum(hrp string, blocks []byte) []byte {
-	values := append(bech32HrpExpand(hrp), blocks...)
-	polymod := bech32Polymod(append(values, []byte{0, 0, 0, 0, 0, 0}...)) ^ 1
-	res := make([]byte, 6)
+// Variant denotes the checksum variant of a Bech32 string.
+type Variant int
+
+// Supported checksum variants.
+const (
+	// Bech32 denotes the original checksum as described in BIP-173.
+	Bech32 Variant = iota + 1
+	// Bech32m denotes the modified checksum as described in BIP-350.
+	Bech32m
+)
+
+func (v Variant) String() string {
+	switch v {
+	case Bech32:
+		return "bech32"
+	case Bech32m:
+		return "bech32m"
+	}
+	return "Variant(" + strconv.Itoa(int(v)) + ")"
+}
+
+// constant returns the value the polymod of a valid checksum must match.
+func (v Variant) constant() int {
+	switch v {
+	case Bech32:
+		return 1
+	case Bech32m:
+		return 0x2bc830a3
+	}
+	panic("bech32: invalid variant " + v.String())
+}
+
+// For more details on the checksum calculation, please refer to BIP 173 and BIP 350.
+func bech32CreateChecksum(v Variant, hrp string, blocks []byte) [checksumLength]byte {
+	chk := bech32PolymodUpdate(bech32HrpPolymod(hrp), blocks)
+	for i := 0; i < checksumLength; i++ {
+		chk = polymodStep(chk, 0)
+	}
+	polymod := chk ^ v.constant()
+	var res [checksumLength]byte
 	for i := range res {
 		res[i] = byte((polymod >> (5 * (5 - i))) & 31)
 	}
 	return res
 }
 
+// bech32PolymodUpdate continues the polymod calculation with the state chk for the given values.
 // For more details on the polymod calculation, please refer to BIP 173.
-func bech32Polymod(values []byte) int {
-	chk := 1
+func bech32PolymodUpdate(chk int, values []byte) int {
 	for _, v := range values {
-		b := chk >> 25
-		chk = (chk&0x1ffffff)<<5 ^ int(v)
-		for i := range gen {
-			if (b>>i)&1 != 0 {
-				chk ^= gen[i]
-			}
-		}
+		chk = polymodStep(chk, int(v))
 	}
 	return chk
 }
 
+// bech32HrpPolymod returns the polymod state after processing the expanded human-readable part.
+// The checksum is always computed over the lowercase human-readable part.
 // For more details on String expansion, please refer to BIP 173.
-func bech32HrpExpand(s string) []byte {
-	res := make([]byte, 0, 2*len(s)+1)
-	for _, x := range []byte(s) {
-		res = append(res, x>>5)
+func bech32HrpPolymod(hrp string) int {
+	chk := 1
+	for i := 0; i < len(hrp); i++ {
+		chk = polymodStep(chk, int(toLower(hrp[i])>>5))
 	}
-	res = append(res, 0)
-	for _, x := range []byte(s) {
-		res = append(res, x&31)
+	chk = polymodStep(chk, 0)
+	for i := 0; i < len(hrp); i++ {
+		chk = polymodStep(chk, int(toLower(hrp[i])&31))
 	}
-	return res
+	return chk
+}
+
+// bech32VerifyChecksum returns the variant of a valid checksum or 0 if the checksum is invalid.
+// For more details on the checksum verification, please refer to BIP 173 and BIP 350.
+func bech32VerifyChecksum(hrp string, data []byte) Variant {
+	switch bech32PolymodUpdate(bech32HrpPolymod(hrp), data) {
+	case Bech32.constant():
+		return Bech32
+	case Bech32m.constant():
+		return Bech32m
+	}
+	return 0
+}
+
+// bech32LocateErrors returns the ascending positions in data of the values that most likely contain an error.
+// It assumes that only data and not the human-readable part contains substitution errors.
+// The checksum is a BCH code with a minimum distance of 5 for all strings up to 89 characters, which guarantees that
+// any pattern of at most two substitution errors can be located unambiguously.
+// If no such error pattern is found, nil is returned.
+//
+// As the polymod is linear over GF(2), a substitution error e at position p changes the result by the syndrome
+// polymod₀(e || 0ⁿ⁻ᵖ⁻¹), where polymod₀ denotes the polymod with an initial value of zero. Thus, the errors can be
+// located by looking up the observed residue in the table of possible syndromes.
+func bech32LocateErrors(v Variant, hrp string, data []byte) []int {
+	residue := bech32PolymodUpdate(bech32HrpPolymod(hrp), data) ^ v.constant()
+	if residue == 0 {
+		return nil
+	}
+
+	// compute the syndromes of all single errors, both indexed by position and error and as a lookup table
+	n := len(data)
+	singles := make([]int, n<<5)
+	syndromes := make(map[int]int, n*31)
+	unit := make([]int, 5) // syndromes of the five single-bit errors at the current position
+	for b := range unit {
+		unit[b] = polymodStep(0, 1<<b)
+	}
+	for p := n - 1; p >= 0; p-- {
+		for e := 1; e < 32; e++ {
+			syn := 0
+			for b := range unit {
+				if e>>b&1 != 0 {
+					syn ^= unit[b]
+				}
+			}
+			singles[p<<5|e] = syn
+			syndromes[syn] = p<<5 | e
+		}
+		// move to the previous position by appending another zero
+		for b := range unit {
+			unit[b] = polymodStep(unit[b], 0)
+		}
+	}
+
+	// single error
+	if pe, ok := syndromes[residue]; ok {
+		return []int{pe >> 5}
+	}
+	// two errors; the candidates are tried in position order, so that the result does not depend on the map order
+	for pe, syn := range singles {
+		if pe&31 == 0 {
+			continue
+		}
+		if other, ok := syndromes[residue^syn]; ok && other>>5 != pe>>5 {
+			p1, p2 := pe>>5, other>>5
+			if p1 > p2 {
+				p1, p2 = p2, p1
+			}
+			return []int{p1, p2}
+		}
+	}
+	return nil
+}
+
+// polymodStep performs a single step of the polymod calculation.
+func polymodStep(chk int, v int) int {
+	b := chk >> 25
+	chk = (chk&0x1ffffff)<<5 ^ v
+	for i := range gen {
+		if (b>>i)&1 != 0 {
+			chk ^= gen[i]
+		}
+	}
+	return chk
 }
 
-// For more details on the checksum verification, please refer to BIP 173.
-func bech32VerifyChecksum(hrp string, data []byte) bool {
-	return bech32Polymod(append(bech32HrpExpand(hrp), data...)) == 1
+func toLower(c byte) byte {
+	if 'A' <= c && c <= 'Z' {
+		return c + 'a' - 'A'
+	}
+	return c
 }
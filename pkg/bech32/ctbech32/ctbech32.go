@@ -0,0 +1,267 @@
+/*
+Package ctbech32 implements Bech32 and Bech32m encoding and decoding in constant time with respect to the data part.
+
+The bech32 package maps characters using table lookups indexed by the data and branches on the data while computing
+the checksum and checking the padding, which can leak the encoded payload through cache and timing side channels.
+This package is meant for strings whose payload is secret, e.g. encrypted shares or keys: the time it takes only
+depends on the length of the input and the human-readable part, which are both considered public.
+
+To not leak the position of invalid characters, errors are only reported after the whole string has been processed
+and do not contain an offset. Use the bech32 package for public data like addresses, as it reports the location of
+errors and is faster.
+*/
+package ctbech32
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"strings"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/bech32"
+)
+
+const (
+	checksumLength = 6
+	separator      = '1'
+
+	// polymod constants of the checksum variants
+	bech32Const  = 1
+	bech32mConst = 0x2bc830a3
+)
+
+const charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+var gen = [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+
+// Encode encodes the hrp string and the src data as a Bech32 string without data-dependent branches or table lookups.
+// It returns an error when the input is invalid.
+func Encode(hrp string, src []byte) (string, error) {
+	return EncodeVariant(bech32.Bech32, hrp, src)
+}
+
+// EncodeVariant encodes the hrp string and the src data as a Bech32 string using the checksum variant v.
+// It returns an error when the input is invalid.
+func EncodeVariant(v bech32.Variant, hrp string, src []byte) (string, error) {
+	var constant uint32
+	switch v {
+	case bech32.Bech32:
+		constant = bech32Const
+	case bech32.Bech32m:
+		constant = bech32mConst
+	default:
+		return "", fmt.Errorf("invalid variant: %s", v)
+	}
+	if err := bech32.ValidateHRP(hrp); err != nil {
+		return "", err
+	}
+	words := toWords(src)
+	if len(hrp)+1+len(words)+checksumLength > bech32.MaxLength {
+		return "", fmt.Errorf("%w: String length=%d, data length=%d", bech32.ErrInvalidLength, len(hrp), len(words))
+	}
+	lower := strings.ToLower(hrp)
+
+	chk := hrpPolymod(lower)
+	for _, w := range words {
+		chk = polymodStep(chk, uint32(w))
+	}
+	for i := 0; i < checksumLength; i++ {
+		chk = polymodStep(chk, 0)
+	}
+	chk ^= constant
+
+	dst := make([]byte, 0, len(hrp)+1+len(words)+checksumLength)
+	dst = append(dst, lower...)
+	dst = append(dst, separator)
+	for _, w := range words {
+		dst = append(dst, encodeChar(w))
+	}
+	for i := 0; i < checksumLength; i++ {
+		dst = append(dst, encodeChar(byte(chk>>(5*(5-i))&31)))
+	}
+	if hrp != lower {
+		// the human-readable part has been validated to not contain mixed case
+		for i := range dst {
+			dst[i] -= byte(inRange(dst[i], 'a', 'z') << 5)
+		}
+	}
+	return string(dst), nil
+}
+
+// Decode decodes the Bech32 string s into its human-readable and data part without data-dependent branches or table
+// lookups. Only the original checksum described in BIP-173 is accepted, use DecodeVariant to also accept Bech32m.
+// It returns an error when s does not represent a valid Bech32 encoding.
+func Decode(s string) (string, []byte, error) {
+	hrp, data, v, err := DecodeVariant(s)
+	if err != nil {
+		return "", nil, err
+	}
+	if v != bech32.Bech32 {
+		return "", nil, fmt.Errorf("%w: unexpected %s checksum", bech32.ErrInvalidChecksum, v)
+	}
+	return hrp, data, nil
+}
+
+// DecodeVariant decodes the Bech32 string s into its human-readable and data part.
+// It accepts both, the Bech32 and Bech32m checksum, and returns the variant that matched.
+// It returns an error when s does not represent a valid Bech32 encoding.
+func DecodeVariant(s string) (string, []byte, bech32.Variant, error) {
+	if len(s) > bech32.MaxLength {
+		return "", nil, 0, fmt.Errorf("%w: maximum length exceeded", bech32.ErrInvalidLength)
+	}
+	// the separator cannot occur in the data part, so its position only depends on the public human-readable part
+	hrpLen := strings.LastIndexByte(s, separator)
+	if hrpLen == -1 {
+		return "", nil, 0, bech32.ErrMissingSeparator
+	}
+	if hrpLen < 1 || hrpLen+1+checksumLength > len(s) {
+		return "", nil, 0, fmt.Errorf("%w: invalid position", bech32.ErrInvalidSeparator)
+	}
+	hrp := s[:hrpLen]
+	if err := bech32.ValidateHRP(hrp); err != nil {
+		return "", nil, 0, err
+	}
+	chars := s[hrpLen+1:]
+
+	// decode all characters, accumulating the errors instead of returning early
+	words := make([]byte, len(chars))
+	var invalid, upper, lower int
+	for i := 0; i < len(chars); i++ {
+		c := chars[i]
+		isUpper := inRange(c, 'A', 'Z')
+		upper |= isUpper
+		lower |= inRange(c, 'a', 'z')
+		// convert uppercase letters to lowercase by setting bit 5
+		w, ok := decodeChar(c | byte(isUpper<<5))
+		words[i] = w
+		invalid |= 1 ^ ok
+	}
+	upper |= boolInt(strings.ToLower(hrp) != hrp)
+	lower |= boolInt(strings.ToUpper(hrp) != hrp)
+
+	chk := hrpPolymod(strings.ToLower(hrp))
+	for _, w := range words {
+		chk = polymodStep(chk, uint32(w))
+	}
+	data, padding := fromWords(words[:len(words)-checksumLength])
+	clear(words)
+
+	switch {
+	case invalid != 0:
+		return "", nil, 0, fmt.Errorf("%w: non-charset character in data part", bech32.ErrInvalidCharacter)
+	case upper&lower != 0:
+		return "", nil, 0, bech32.ErrMixedCase
+	}
+	var v bech32.Variant
+	switch chk {
+	case bech32Const:
+		v = bech32.Bech32
+	case bech32mConst:
+		v = bech32.Bech32m
+	default:
+		return "", nil, 0, bech32.ErrInvalidChecksum
+	}
+	if data == nil {
+		return "", nil, 0, bech32.ErrInvalidPadding
+	}
+	if padding != 0 {
+		return "", nil, 0, bech32.ErrNonZeroPadding
+	}
+	return strings.ToLower(hrp), data, v, nil
+}
+
+// toWords converts src into base32 digits. The number of iterations only depends on the length of src.
+func toWords(src []byte) []byte {
+	words := make([]byte, 0, (len(src)*8+4)/5)
+	var acc uint32
+	bits := 0
+	for _, b := range src {
+		acc = acc<<8 | uint32(b)
+		bits += 8
+		for bits >= 5 {
+			bits -= 5
+			words = append(words, byte(acc>>bits&31))
+		}
+	}
+	if bits > 0 {
+		words = append(words, byte(acc<<(5-bits)&31))
+	}
+	return words
+}
+
+// fromWords converts the base32 digits in words into bytes.
+// It returns nil, if the number of digits does not correspond to whole bytes, and the padding bits otherwise.
+func fromWords(words []byte) ([]byte, uint32) {
+	if n := len(words) % 8; n == 1 || n == 3 || n == 6 {
+		return nil, 0
+	}
+	data := make([]byte, 0, len(words)*5/8)
+	var acc uint32
+	bits := 0
+	for _, w := range words {
+		acc = acc<<5 | uint32(w)
+		bits += 5
+		if bits >= 8 {
+			bits -= 8
+			data = append(data, byte(acc>>bits))
+		}
+	}
+	return data, acc & (1<<bits - 1)
+}
+
+// encodeChar returns the character of the base32 digit w by scanning the entire charset.
+func encodeChar(w byte) byte {
+	var c byte
+	for i := 0; i < len(charset); i++ {
+		c |= byte(subtle.ConstantTimeByteEq(w, byte(i))) * charset[i]
+	}
+	return c
+}
+
+// decodeChar returns the base32 digit of the lowercase character c by scanning the entire charset.
+// The second return value is 1, if c is part of the charset, and 0 otherwise.
+func decodeChar(c byte) (byte, int) {
+	var w byte
+	found := 0
+	for i := 0; i < len(charset); i++ {
+		eq := subtle.ConstantTimeByteEq(c, charset[i])
+		w |= byte(eq * i)
+		found |= eq
+	}
+	return w, found
+}
+
+// inRange returns 1, if lo ≤ c ≤ hi, and 0 otherwise.
+func inRange(c, lo, hi byte) int {
+	// both differences are negative, if and only if c is outside the range
+	return int((uint32(c)-uint32(lo))>>31|(uint32(hi)-uint32(c))>>31) ^ 1
+}
+
+// polymodStep performs one step of the BCH checksum computation using masks instead of branches.
+func polymodStep(chk uint32, v uint32) uint32 {
+	b := chk >> 25
+	chk = (chk&0x1ffffff)<<5 ^ v
+	for i := range gen {
+		chk ^= -(b >> i & 1) & gen[i]
+	}
+	return chk
+}
+
+// hrpPolymod returns the polymod state after processing the expanded lowercase human-readable part.
+func hrpPolymod(hrp string) uint32 {
+	chk := uint32(1)
+	for i := 0; i < len(hrp); i++ {
+		chk = polymodStep(chk, uint32(hrp[i]>>5))
+	}
+	chk = polymodStep(chk, 0)
+	for i := 0; i < len(hrp); i++ {
+		chk = polymodStep(chk, uint32(hrp[i]&31))
+	}
+	return chk
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,136 @@
+//nolint:scopelint
+package ctbech32_test
+
+import (
+	"crypto/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/bech32"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bech32/ctbech32"
+)
+
+// valid test vectors of BIP-173 and BIP-350
+var validTests = []*struct {
+	s string
+	v bech32.Variant
+}{
+	{"A12UEL5L", bech32.Bech32},
+	{"a12uel5l", bech32.Bech32},
+	{"an83characterlonghumanreadablepartthatcontainsthenumber1andtheexcludedcharactersbio1tt5tgs", bech32.Bech32},
+	{"abcdef1qpzry9x8gf2tvdw0s3jn54khce6mua7lmqqqxw", bech32.Bech32},
+	{"split1checkupstagehandshakeupstreamerranterredcaperred2y9e3w", bech32.Bech32},
+	{"?1ezyfcl", bech32.Bech32},
+	{"A1LQFN3A", bech32.Bech32m},
+	{"a1lqfn3a", bech32.Bech32m},
+	{"abcdef1l7aum6echk45nj3s0wdvt2fg8x9yrzpqzd3ryx", bech32.Bech32m},
+	{"split1checkupstagehandshakeupstreamerranterredcaperredlc445v", bech32.Bech32m},
+	{"?1v759aa", bech32.Bech32m},
+}
+
+func TestDecodeVariant(t *testing.T) {
+	for _, tt := range validTests {
+		t.Run(tt.s, func(t *testing.T) {
+			hrp, data, v, err := ctbech32.DecodeVariant(tt.s)
+			require.NoError(t, err)
+			assert.Equal(t, tt.v, v)
+
+			expHRP, expData, expV, err := bech32.DecodeVariant(tt.s)
+			require.NoError(t, err)
+			assert.Equal(t, expHRP, hrp)
+			assert.Equal(t, expData, data)
+			assert.Equal(t, expV, v)
+
+			upper := strings.ToUpper(tt.s) == tt.s
+			if upper {
+				hrp = strings.ToUpper(hrp)
+			}
+			s, err := ctbech32.EncodeVariant(v, hrp, data)
+			require.NoError(t, err)
+			assert.Equal(t, tt.s, s)
+		})
+	}
+}
+
+var invalidTests = []*struct {
+	s   string
+	err error
+}{
+	{"pzry9x0s0muk", bech32.ErrMissingSeparator},
+	{"1pzry9x0s0muk", bech32.ErrInvalidSeparator},
+	{"li1dgmt3", bech32.ErrInvalidSeparator},
+	{"x1b4n0q5v", bech32.ErrInvalidCharacter},
+	{"de1lg7wt\xff", bech32.ErrInvalidCharacter},
+	{"A1G7SGD8", bech32.ErrInvalidChecksum},
+	{"a12UEL5L", bech32.ErrMixedCase},
+	{"A12uel5l", bech32.ErrMixedCase},
+	{"a12uEl5l", bech32.ErrMixedCase},
+	{"tb1qrp33g0q5c5txsp9arysrx4k6zdkfs4nce4xj0gdcccefvpysxf3pjxtptv", bech32.ErrNonZeroPadding},
+	{"test1ls7uz56", bech32.ErrInvalidPadding},
+	{"an84characterslonghumanreadablepartthatcontainsthenumber1andtheexcludedcharactersbio1569pvx", bech32.ErrInvalidLength},
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	for _, tt := range invalidTests {
+		t.Run(tt.s, func(t *testing.T) {
+			_, _, _, err := ctbech32.DecodeVariant(tt.s)
+			assert.ErrorIs(t, err, tt.err)
+			// the same input must be rejected by bech32
+			_, _, _, err = bech32.DecodeVariant(tt.s)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestDecodeChecksumVariant(t *testing.T) {
+	_, _, err := ctbech32.Decode("a1lqfn3a")
+	assert.ErrorIs(t, err, bech32.ErrInvalidChecksum)
+}
+
+func TestEncodeDecodeRandom(t *testing.T) {
+	for n := 0; n <= 40; n++ {
+		src := make([]byte, n)
+		_, err := rand.Read(src)
+		require.NoError(t, err)
+
+		s, err := ctbech32.Encode("share", src)
+		require.NoError(t, err)
+		exp, err := bech32.Encode("share", src)
+		require.NoError(t, err)
+		assert.Equal(t, exp, s)
+
+		hrp, data, err := ctbech32.Decode(s)
+		require.NoError(t, err)
+		assert.Equal(t, "share", hrp)
+		assert.Equal(t, src, data)
+
+		upper, err := ctbech32.Encode("SHARE", src)
+		require.NoError(t, err)
+		assert.Equal(t, strings.ToUpper(exp), upper)
+	}
+}
+
+func FuzzDecodeVariant(f *testing.F) {
+	for _, tt := range validTests {
+		f.Add(tt.s)
+	}
+	for _, tt := range invalidTests {
+		f.Add(tt.s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		hrp, data, v, err := ctbech32.DecodeVariant(s)
+		expHRP, expData, expV, expErr := bech32.DecodeVariant(s)
+		// both implementations must accept exactly the same strings
+		if expErr != nil {
+			require.Error(t, err)
+			return
+		}
+		require.NoError(t, err)
+		require.Equal(t, expHRP, hrp)
+		require.Equal(t, expData, data)
+		require.Equal(t, expV, v)
+	})
+}
@@ -1,6 +1,11 @@
 package bech32
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/bech32/internal/base32"
+)
 
 // Errors reported during bech32 decoding.
 var (
@@ -10,6 +15,9 @@ var (
 	ErrMixedCase        = errors.New("mixed case")
 	ErrInvalidCharacter = errors.New("invalid character")
 	ErrInvalidChecksum  = errors.New("invalid checksum")
+	ErrNonZeroPadding   = base32.ErrNonZeroPadding
+	ErrInvalidPadding   = base32.ErrInvalidLength
+	ErrNotCanonical     = errors.New("not canonical")
 )
 
 // A SyntaxError is a description of a Bech32 syntax error.
@@ -21,3 +29,20 @@ type SyntaxError struct {
 func (e *SyntaxError) Error() string { return e.err.Error() }
 
 func (e *SyntaxError) Unwrap() error { return e.err }
+
+// A ChecksumError is a description of an invalid Bech32 checksum.
+type ChecksumError struct {
+	// Positions contains the positions in the input of the characters that most likely contain an error in
+	// ascending order. Up to two incorrect characters in the data part can be located. If the errors could not be
+	// located, Positions is empty.
+	Positions []int
+}
+
+func (e *ChecksumError) Error() string {
+	if len(e.Positions) == 0 {
+		return ErrInvalidChecksum.Error()
+	}
+	return fmt.Sprintf("%s: likely error at position %v", ErrInvalidChecksum, e.Positions)
+}
+
+func (e *ChecksumError) Unwrap() error { return ErrInvalidChecksum }
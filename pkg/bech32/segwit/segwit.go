@@ -0,0 +1,94 @@
+/*
+Package segwit implements the encoding and decoding of segregated witness addresses as described in BIP-173 and
+BIP-350.
+
+A segwit address consists of a witness version and a witness program. Version 0 addresses use the original Bech32
+checksum, while all later versions use the Bech32m checksum.
+*/
+package segwit
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/bech32"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bech32/internal/base32"
+)
+
+const (
+	// MaxVersion is the largest supported witness version.
+	MaxVersion = 16
+	// MinProgramLength is the minimum length of a witness program in bytes.
+	MinProgramLength = 2
+	// MaxProgramLength is the maximum length of a witness program in bytes.
+	MaxProgramLength = 40
+)
+
+// Errors returned during segwit encoding and decoding.
+var (
+	ErrInvalidVersion = errors.New("invalid witness version")
+	ErrInvalidLength  = errors.New("invalid witness program length")
+	ErrInvalidVariant = errors.New("invalid checksum variant")
+)
+
+// Encode encodes the witness version and program as a segwit address with the human-readable part hrp.
+// It returns an error when the input is invalid.
+func Encode(hrp string, version byte, program []byte) (string, error) {
+	if err := validate(version, program); err != nil {
+		return "", err
+	}
+	words := make([]uint8, 1+base32.EncodedLen(len(program)))
+	words[0] = version
+	base32.Encode(words[1:], program)
+	return bech32.EncodeWords(variant(version), hrp, words)
+}
+
+// Decode decodes the segwit address s into its human-readable part, witness version and witness program.
+// It returns an error when s does not represent a valid segwit address.
+func Decode(s string) (string, byte, []byte, error) {
+	hrp, words, v, err := bech32.DecodeWords(s)
+	if err != nil {
+		return "", 0, nil, err
+	}
+	if len(words) < 1 {
+		return "", 0, nil, fmt.Errorf("%w: missing witness version", ErrInvalidVersion)
+	}
+	version := words[0]
+	if version > MaxVersion {
+		return "", 0, nil, fmt.Errorf("%w: %d", ErrInvalidVersion, version)
+	}
+	if v != variant(version) {
+		return "", 0, nil, fmt.Errorf("%w: %s checksum for witness version %d", ErrInvalidVariant, v, version)
+	}
+
+	program := make([]byte, base32.DecodedLen(len(words)-1))
+	if _, err := base32.Decode(program, words[1:]); err != nil {
+		return "", 0, nil, fmt.Errorf("invalid witness program: %w", err)
+	}
+	if err := validate(version, program); err != nil {
+		return "", 0, nil, err
+	}
+	return hrp, version, program, nil
+}
+
+func validate(version byte, program []byte) error {
+	if version > MaxVersion {
+		return fmt.Errorf("%w: %d", ErrInvalidVersion, version)
+	}
+	if len(program) < MinProgramLength || len(program) > MaxProgramLength {
+		return fmt.Errorf("%w: %d bytes", ErrInvalidLength, len(program))
+	}
+	// version 0 programs must either be a P2WPKH or a P2WSH
+	if version == 0 && len(program) != 20 && len(program) != 32 {
+		return fmt.Errorf("%w: %d bytes for witness version 0", ErrInvalidLength, len(program))
+	}
+	return nil
+}
+
+// variant returns the checksum variant used for the given witness version.
+func variant(version byte) bech32.Variant {
+	if version == 0 {
+		return bech32.Bech32
+	}
+	return bech32.Bech32m
+}
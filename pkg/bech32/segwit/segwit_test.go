@@ -0,0 +1,103 @@
+//nolint:scopelint
+package segwit_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/internal/hexutil"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bech32"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bech32/internal/base32"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bech32/segwit"
+)
+
+// test vectors from BIP-350.
+func TestValid(t *testing.T) {
+	var tests = []*struct {
+		s            string
+		scriptPubKey string
+	}{
+		{"BC1QW508D6QEJXTDG4Y5R3ZARVARY0C5XW7KV8F3T4", "0014751e76e8199196d454941c45d1b3a323f1433bd6"},
+		{"tb1qrp33g0q5c5txsp9arysrx4k6zdkfs4nce4xj0gdcccefvpysxf3q0sl5k7", "00201863143c14c5166804bd19203356da136c985678cd4d27a1b8c6329604903262"},
+		{"bc1pw508d6qejxtdg4y5r3zarvary0c5xw7kw508d6qejxtdg4y5r3zarvary0c5xw7kt5nd6y", "5128751e76e8199196d454941c45d1b3a323f1433bd6751e76e8199196d454941c45d1b3a323f1433bd6"},
+		{"BC1SW50QGDZ25J", "6002751e"},
+		{"bc1zw508d6qejxtdg4y5r3zarvaryvaxxpcs", "5210751e76e8199196d454941c45d1b3a323"},
+		{"tb1qqqqqp399et2xygdj5xreqhjjvcmzhxw4aywxecjdzew6hylgvsesrxh6hy", "0020000000c4a5cad46221b2a187905e5266362b99d5e91c6ce24d165dab93e86433"},
+		{"tb1pqqqqp399et2xygdj5xreqhjjvcmzhxw4aywxecjdzew6hylgvsesf3hn0c", "5120000000c4a5cad46221b2a187905e5266362b99d5e91c6ce24d165dab93e86433"},
+		{"bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqzk5jj0", "512079be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			hrp, version, program, err := segwit.Decode(tt.s)
+			require.NoError(t, err)
+			assert.Equal(t, hexutil.MustDecodeString(tt.scriptPubKey), scriptPubKey(version, program))
+
+			s, err := segwit.Encode(hrp, version, program)
+			require.NoError(t, err)
+			assert.Equal(t, strings.ToLower(tt.s), s)
+		})
+	}
+}
+
+// test vectors from BIP-350.
+func TestInvalid(t *testing.T) {
+	var tests = []*struct {
+		s      string
+		expErr error
+	}{
+		{"bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqh2y7hd", segwit.ErrInvalidVariant},
+		{"tb1z0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqglt7rf", segwit.ErrInvalidVariant},
+		{"BC1S0XLXVLHEMJA6C4DQV22UAPCTQUPFHLXM9H8Z3K2E72Q4K9HCZ7VQ54WELL", segwit.ErrInvalidVariant},
+		{"bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kemeawh", segwit.ErrInvalidVariant},
+		{"tb1q0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vq24jc47", segwit.ErrInvalidVariant},
+		{"bc1p38j9r5y49hruaue7wxjce0updqjuyyx0kh56v8s25huc6995vvpql3jow4", bech32.ErrInvalidCharacter},
+		{"BC130XLXVLHEMJA6C4DQV22UAPCTQUPFHLXM9H8Z3K2E72Q4K9HCZ7VQ7ZWS8R", segwit.ErrInvalidVersion},
+		{"bc1pw5dgrnzv", segwit.ErrInvalidLength},
+		{"bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7v8n0nx0muaewav253zgeav", segwit.ErrInvalidLength},
+		{"BC1QR508D6QEJXTDG4Y5R3ZARVARYV98GJ9P", segwit.ErrInvalidLength},
+		{"tb1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vq47Zagq", bech32.ErrMixedCase},
+		{"bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7v07qwwzcrf", base32.ErrInvalidLength},
+		{"tb1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vpggkg4j", base32.ErrNonZeroPadding},
+		{"bc1gmk9yu", segwit.ErrInvalidVersion},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			_, _, _, err := segwit.Decode(tt.s)
+			assert.ErrorIs(t, err, tt.expErr)
+		})
+	}
+}
+
+func TestEncodeInvalid(t *testing.T) {
+	var tests = []*struct {
+		desc    string
+		version byte
+		program []byte
+		expErr  error
+	}{
+		{"version too large", 17, make([]byte, 20), segwit.ErrInvalidVersion},
+		{"program too short", 1, make([]byte, 1), segwit.ErrInvalidLength},
+		{"program too long", 1, make([]byte, 41), segwit.ErrInvalidLength},
+		{"invalid version 0 length", 0, make([]byte, 16), segwit.ErrInvalidLength},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			_, err := segwit.Encode("bc", tt.version, tt.program)
+			assert.ErrorIs(t, err, tt.expErr)
+		})
+	}
+}
+
+func scriptPubKey(version byte, program []byte) []byte {
+	op := version
+	if version > 0 {
+		op += 0x50
+	}
+	return append([]byte{op, byte(len(program))}, program...)
+}
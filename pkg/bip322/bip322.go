@@ -0,0 +1,156 @@
+/*
+Package bip322 implements the signing and verification of messages with Bitcoin
+addresses as described in BIP-0322 as well as the legacy "Bitcoin Signed
+Message" format.
+
+This allows proving the ownership of secp256k1 keys derived using the slip10
+package. BIP-322 signatures are supported in the simple format for native
+segwit P2WPKH and P2TR key path addresses, legacy signatures for P2PKH
+addresses.
+
+This package is tested against the test vectors provided in the official
+BIP-0322 specification.
+*/
+package bip322
+
+import (
+	"bytes"
+	"encoding/asn1"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip340"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10/elliptic"
+)
+
+var (
+	// ErrUnsupportedAddress is returned when the address is invalid or of an unsupported type.
+	ErrUnsupportedAddress = errors.New("unsupported address")
+	// ErrInvalidKey is returned when the private key does not correspond to the address.
+	ErrInvalidKey = errors.New("invalid key")
+	// ErrInvalidSignature is returned when the signature is malformed or does not match.
+	ErrInvalidSignature = errors.New("invalid signature")
+)
+
+const (
+	tagMessage    = "BIP0322-signed-message"
+	tagTapSighash = "TapSighash"
+)
+
+// MessageHash returns the tagged hash of the message committed to in BIP-322 signatures.
+func MessageHash(message []byte) []byte {
+	return taggedHash(tagMessage, message)
+}
+
+// Sign signs the message with the secp256k1 private key for the P2WPKH or P2TR address and returns the
+// base64-encoded signature in the simple format.
+// P2TR addresses must commit to the key without a script tree.
+func Sign(key *elliptic.PrivateKey, addr string, message []byte) (string, error) {
+	if key.Curve.Params().Name != curve.Params().Name {
+		return "", fmt.Errorf("%w: not a secp256k1 key", ErrInvalidKey)
+	}
+	a, err := parseAddress(addr)
+	if err != nil {
+		return "", err
+	}
+	signTx := toSign(toSpend(a.scriptPubKey(), message))
+
+	var witness [][]byte
+	switch a.typ {
+	case p2wpkh:
+		x, y := curve.ScalarBaseMult(key.K.Bytes())
+		pub := serializePublicKey(x, y, true)
+		if !bytes.Equal(hash160(pub), a.program) {
+			return "", fmt.Errorf("%w: key does not match address", ErrInvalidKey)
+		}
+		r, s, err := signECDSA(key, signTx.sighashV0(p2pkhScript(a.program)))
+		if err != nil {
+			return "", err
+		}
+		der, err := asn1.Marshal(struct{ R, S *big.Int }{r, s})
+		if err != nil {
+			return "", err
+		}
+		witness = [][]byte{append(der, sighashAll), pub}
+	case p2tr:
+		tweaked, err := bip340.TweakPrivateKey(key, nil)
+		if err != nil {
+			return "", err
+		}
+		outputKey, err := bip340.XOnlyPublicKey(tweaked.Public().(*elliptic.PublicKey)) //nolint:forcetypeassert
+		if err != nil {
+			return "", err
+		}
+		if !bytes.Equal(outputKey, a.program) {
+			return "", fmt.Errorf("%w: key does not match address", ErrInvalidKey)
+		}
+		sig, err := bip340.Sign(nil, tweaked, signTx.sighashTaproot(a.scriptPubKey(), sighashDefault))
+		if err != nil {
+			return "", err
+		}
+		witness = [][]byte{sig}
+	default:
+		return "", fmt.Errorf("%w: use SignLegacy for P2PKH addresses", ErrUnsupportedAddress)
+	}
+	return base64.StdEncoding.EncodeToString(encodeWitness(witness)), nil
+}
+
+// Verify checks that signature is a valid BIP-322 signature in the simple format of message for the P2WPKH or
+// P2TR address.
+func Verify(addr string, message []byte, signature string) error {
+	a, err := parseAddress(addr)
+	if err != nil {
+		return err
+	}
+	raw, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+	}
+	witness, ok := decodeWitness(raw)
+	if !ok {
+		return fmt.Errorf("%w: malformed witness", ErrInvalidSignature)
+	}
+	signTx := toSign(toSpend(a.scriptPubKey(), message))
+
+	switch a.typ {
+	case p2wpkh:
+		if len(witness) != 2 || len(witness[0]) < 1 || witness[0][len(witness[0])-1] != sighashAll {
+			return fmt.Errorf("%w: invalid witness for P2WPKH", ErrInvalidSignature)
+		}
+		if !bytes.Equal(hash160(witness[1]), a.program) {
+			return fmt.Errorf("%w: public key does not match address", ErrInvalidSignature)
+		}
+		x, y, ok := parseCompressedPublicKey(witness[1])
+		if !ok {
+			return fmt.Errorf("%w: invalid public key", ErrInvalidSignature)
+		}
+		r, s, ok := parseDER(witness[0][:len(witness[0])-1])
+		if !ok || !verifyECDSA(x, y, signTx.sighashV0(p2pkhScript(a.program)), r, s) {
+			return ErrInvalidSignature
+		}
+	case p2tr:
+		if len(witness) != 1 {
+			return fmt.Errorf("%w: invalid witness for P2TR", ErrInvalidSignature)
+		}
+		sig, hashType := witness[0], byte(sighashDefault)
+		switch {
+		case len(sig) == bip340.SignatureSize+1 && sig[bip340.SignatureSize] == sighashAll:
+			sig, hashType = sig[:bip340.SignatureSize], sighashAll
+		case len(sig) != bip340.SignatureSize:
+			return fmt.Errorf("%w: invalid Schnorr signature", ErrInvalidSignature)
+		}
+		if !bip340.Verify(a.program, signTx.sighashTaproot(a.scriptPubKey(), hashType), sig) {
+			return ErrInvalidSignature
+		}
+	default:
+		return fmt.Errorf("%w: use VerifyLegacy for P2PKH addresses", ErrUnsupportedAddress)
+	}
+	return nil
+}
+
+// p2pkhScript returns the P2PKH script of the public key hash, which is the script code of P2WPKH.
+func p2pkhScript(pubKeyHash []byte) []byte {
+	return (&address{p2pkh, pubKeyHash}).scriptPubKey()
+}
@@ -0,0 +1,88 @@
+package bip322_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/internal/hexutil"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip322"
+	"github.com/iotaledger/iota-crypto-demo/pkg/wif"
+)
+
+// test key and addresses from the BIP-0322 specification
+const (
+	testWIF     = "L3VFeEujGtevx9w18HD1fhRbCH67Az2dpCymeRE1SoPK6XQtaN2k"
+	testP2WPKH  = "bc1q9vza2e8x573nczrlzms0wvx3gsqjx7vavgkx0l"
+	testP2TR    = "bc1ppv609nr0vr25u07u95waq5lucwfm6tde4nydujnu8npg4q75mr5sxq8lt3"
+	testP2PKH   = "14vV3aCHBeStb5bkenkNHbe2YAFinYdXgc"
+	helloWorld  = "Hello World"
+	otherP2WPKH = "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4"
+)
+
+func TestMessageHash(t *testing.T) {
+	assert.Equal(t, hexutil.MustDecodeString("c90c269c4f8fcbe6880f72a721ddfbf1914268a794cbb21cfafee13770ae19f1"), bip322.MessageHash(nil))
+}
+
+func TestVerify(t *testing.T) {
+	var tests = []*struct {
+		address   string
+		message   string
+		signature string
+	}{
+		{testP2WPKH, "", "AkcwRAIgM2gBAQqvZX15ZiysmKmQpDrG83avLIT492QBzLnQIxYCIBaTpOaD20qRlEylyxFSeEA2ba9YOixpX8z46TSDtS40ASECx/EgAxlkQpQ9hYjgGu6EBCPMVPwVIVJqO4XCsMvViHI="},
+		{testP2WPKH, helloWorld, "AkcwRAIgZRfIY3p7/DoVTty6YZbWS71bc5Vct9p9Fia83eRmw2QCICK/ENGfwLtptFluMGs2KsqoNSk89pO7F29zJLUx9a/sASECx/EgAxlkQpQ9hYjgGu6EBCPMVPwVIVJqO4XCsMvViHI="},
+		{testP2TR, helloWorld, "AUHd69PrJQEv+oKTfZ8l+WROBHuy9HKrbFCJu7U1iK2iiEy1vMU5EfMtjc+VSHM7aU0SDbak5IUZRVno2P5mjSafAQ=="},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.address+"/"+tt.message, func(t *testing.T) {
+			assert.NoError(t, bip322.Verify(tt.address, []byte(tt.message), tt.signature))
+			assert.ErrorIs(t, bip322.Verify(tt.address, []byte(tt.message+"!"), tt.signature), bip322.ErrInvalidSignature)
+		})
+	}
+}
+
+func TestSign(t *testing.T) {
+	key, err := wif.Decode(testWIF)
+	require.NoError(t, err)
+
+	sig, err := bip322.Sign(key.PrivateKey, testP2WPKH, []byte(helloWorld))
+	require.NoError(t, err)
+	assert.NoError(t, bip322.Verify(testP2WPKH, []byte(helloWorld), sig))
+
+	sig, err = bip322.Sign(key.PrivateKey, testP2TR, []byte(helloWorld))
+	require.NoError(t, err)
+	assert.NoError(t, bip322.Verify(testP2TR, []byte(helloWorld), sig))
+
+	_, err = bip322.Sign(key.PrivateKey, otherP2WPKH, []byte(helloWorld))
+	assert.ErrorIs(t, err, bip322.ErrInvalidKey)
+	_, err = bip322.Sign(key.PrivateKey, testP2PKH, []byte(helloWorld))
+	assert.ErrorIs(t, err, bip322.ErrUnsupportedAddress)
+}
+
+func TestVerifyWrongAddress(t *testing.T) {
+	sig := "AkcwRAIgZRfIY3p7/DoVTty6YZbWS71bc5Vct9p9Fia83eRmw2QCICK/ENGfwLtptFluMGs2KsqoNSk89pO7F29zJLUx9a/sASECx/EgAxlkQpQ9hYjgGu6EBCPMVPwVIVJqO4XCsMvViHI="
+	assert.ErrorIs(t, bip322.Verify(otherP2WPKH, []byte(helloWorld), sig), bip322.ErrInvalidSignature)
+	assert.ErrorIs(t, bip322.Verify(testP2TR, []byte(helloWorld), sig), bip322.ErrInvalidSignature)
+	assert.ErrorIs(t, bip322.Verify("bc1zw508d6qejxtdg4y5r3zarvaryvaxxpcs", []byte(helloWorld), sig), bip322.ErrUnsupportedAddress)
+	assert.ErrorIs(t, bip322.Verify(testP2WPKH, []byte(helloWorld), "invalid"), bip322.ErrInvalidSignature)
+}
+
+func TestLegacy(t *testing.T) {
+	key, err := wif.Decode(testWIF)
+	require.NoError(t, err)
+
+	sig, err := bip322.SignLegacy(key.PrivateKey, []byte(helloWorld), key.Compressed)
+	require.NoError(t, err)
+	assert.NoError(t, bip322.VerifyLegacy(testP2PKH, []byte(helloWorld), sig))
+	assert.ErrorIs(t, bip322.VerifyLegacy(testP2PKH, []byte(helloWorld+"!"), sig), bip322.ErrInvalidSignature)
+
+	// the signature of the uncompressed key corresponds to a different address
+	sig, err = bip322.SignLegacy(key.PrivateKey, []byte(helloWorld), false)
+	require.NoError(t, err)
+	assert.ErrorIs(t, bip322.VerifyLegacy(testP2PKH, []byte(helloWorld), sig), bip322.ErrInvalidSignature)
+
+	assert.ErrorIs(t, bip322.VerifyLegacy(testP2WPKH, []byte(helloWorld), sig), bip322.ErrUnsupportedAddress)
+}
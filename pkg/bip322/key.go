@@ -0,0 +1,181 @@
+package bip322
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+
+	"golang.org/x/crypto/ripemd160" //nolint:staticcheck
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/base58"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bech32/segwit"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10/btccurve"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10/elliptic"
+)
+
+// Version bytes of P2PKH addresses.
+const (
+	p2pkhMainnet = 0x00
+	p2pkhTestnet = 0x6F
+)
+
+var curve = btccurve.Secp256k1()
+
+// addressType denotes the supported types of addresses.
+type addressType int
+
+const (
+	p2pkh addressType = iota
+	p2wpkh
+	p2tr
+)
+
+// address is a decoded Bitcoin address.
+type address struct {
+	typ     addressType
+	program []byte // public key hash or output key
+}
+
+// parseAddress decodes a P2PKH, P2WPKH or P2TR address.
+func parseAddress(s string) (*address, error) {
+	if version, payload, err := base58.CheckDecode(s); err == nil {
+		if (version != p2pkhMainnet && version != p2pkhTestnet) || len(payload) != ripemd160.Size {
+			return nil, fmt.Errorf("%w: unknown base58 address", ErrUnsupportedAddress)
+		}
+		return &address{p2pkh, payload}, nil
+	}
+	_, version, program, err := segwit.Decode(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUnsupportedAddress, err)
+	}
+	switch {
+	case version == 0 && len(program) == ripemd160.Size:
+		return &address{p2wpkh, program}, nil
+	case version == 1 && len(program) == 32:
+		return &address{p2tr, program}, nil
+	}
+	return nil, fmt.Errorf("%w: witness version %d with %d-byte program", ErrUnsupportedAddress, version, len(program))
+}
+
+// scriptPubKey returns the locking script of the address.
+func (a *address) scriptPubKey() []byte {
+	switch a.typ {
+	case p2wpkh:
+		return append([]byte{0x00, 0x14}, a.program...)
+	case p2tr:
+		return append([]byte{0x51, 0x20}, a.program...)
+	}
+	// OP_DUP OP_HASH160 PUSH20[hash] OP_EQUALVERIFY OP_CHECKSIG
+	return append(append([]byte{0x76, 0xA9, 0x14}, a.program...), 0x88, 0xAC)
+}
+
+func hash160(b []byte) []byte {
+	h := sha256.Sum256(b)
+	r := ripemd160.New()
+	r.Write(h[:])
+	return r.Sum(nil)
+}
+
+// serializePublicKey returns the compressed or uncompressed SEC 1 encoding of the point.
+func serializePublicKey(x, y *big.Int, compressed bool) []byte {
+	if !compressed {
+		return append(append([]byte{0x04}, x.FillBytes(make([]byte, 32))...), y.FillBytes(make([]byte, 32))...)
+	}
+	return append([]byte{0x02 | byte(y.Bit(0))}, x.FillBytes(make([]byte, 32))...)
+}
+
+// liftX returns the point with the x-coordinate x and the given parity of the y-coordinate.
+func liftX(x *big.Int, odd uint) (*big.Int, bool) {
+	params := curve.Params()
+	if x.Cmp(params.P) >= 0 {
+		return nil, false
+	}
+	c := new(big.Int).Mul(x, x)
+	c.Mul(c, x).Add(c, params.B).Mod(c, params.P)
+	e := new(big.Int).Add(params.P, big.NewInt(1))
+	y := new(big.Int).Exp(c, e.Rsh(e, 2), params.P)
+	if new(big.Int).Exp(y, big.NewInt(2), params.P).Cmp(c) != 0 {
+		return nil, false
+	}
+	if y.Bit(0) != odd {
+		y.Sub(params.P, y)
+	}
+	return y, true
+}
+
+// parseCompressedPublicKey decodes a 33-byte compressed secp256k1 public key.
+func parseCompressedPublicKey(b []byte) (x, y *big.Int, ok bool) {
+	if len(b) != 33 || (b[0] != 0x02 && b[0] != 0x03) {
+		return nil, nil, false
+	}
+	x = new(big.Int).SetBytes(b[1:])
+	y, ok = liftX(x, uint(b[0]&1))
+	return x, y, ok
+}
+
+// addPoints adds two points, where the point at infinity is represented by nil coordinates as returned by
+// curve.ScalarMult for a zero scalar. Unlike curve.Add, it handles the addition of equal and opposite points.
+func addPoints(x1, y1, x2, y2 *big.Int) (x, y *big.Int) {
+	switch {
+	case x1 == nil:
+		return x2, y2
+	case x2 == nil:
+		return x1, y1
+	case x1.Cmp(x2) == 0:
+		if y1.Cmp(y2) == 0 {
+			return curve.Double(x1, y1)
+		}
+		return nil, nil
+	}
+	return curve.Add(x1, y1, x2, y2)
+}
+
+// signECDSA returns the deterministic low-S ECDSA signature (r, s) of the digest.
+func signECDSA(key *elliptic.PrivateKey, digest []byte) (r, s *big.Int, err error) {
+	return elliptic.SignRFC6979(key, crypto.SHA256, digest, true)
+}
+
+// recoverPublicKey recovers the public key from the ECDSA signature (r, s) of digest and the recovery id.
+func recoverPublicKey(digest []byte, r, s *big.Int, recID byte) (x, y *big.Int, ok bool) {
+	n := curve.Params().N
+	if r.Sign() <= 0 || r.Cmp(n) >= 0 || s.Sign() <= 0 || s.Cmp(n) >= 0 {
+		return nil, nil, false
+	}
+	// R = (r + j⋅n, y) where the parity of y is given by the recovery id
+	rx := new(big.Int).Set(r)
+	if recID&2 != 0 {
+		rx.Add(rx, n)
+	}
+	ry, ok := liftX(rx, uint(recID&1))
+	if !ok {
+		return nil, nil, false
+	}
+
+	// Q = r⁻¹(s⋅R - e⋅G)
+	rInv := new(big.Int).ModInverse(r, n)
+	e := new(big.Int).SetBytes(digest)
+	u1 := e.Mul(e, rInv).Neg(e).Mod(e, n)
+	u2 := new(big.Int).Mul(s, rInv)
+	u2.Mod(u2, n)
+	x1, y1 := curve.ScalarBaseMult(u1.Bytes())
+	x2, y2 := curve.ScalarMult(rx, ry, u2.Bytes())
+	x, y = addPoints(x1, y1, x2, y2)
+	return x, y, x != nil
+}
+
+// parseDER parses a DER encoded ECDSA signature.
+func parseDER(sig []byte) (r, s *big.Int, ok bool) {
+	var v struct{ R, S *big.Int }
+	rest, err := asn1.Unmarshal(sig, &v)
+	if err != nil || len(rest) > 0 {
+		return nil, nil, false
+	}
+	return v.R, v.S, true
+}
+
+func verifyECDSA(x, y *big.Int, digest []byte, r, s *big.Int) bool {
+	return ecdsa.Verify(&ecdsa.PublicKey{Curve: curve, X: x, Y: y}, digest, r, s)
+}
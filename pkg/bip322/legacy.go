@@ -0,0 +1,93 @@
+package bip322
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10/elliptic"
+)
+
+// legacyMagic is the prefix of messages signed in the legacy format.
+const legacyMagic = "Bitcoin Signed Message:\n"
+
+const (
+	// legacySignatureSize is the size, in bytes, of compact recoverable signatures.
+	legacySignatureSize = 65
+	// legacyHeaderBase is the header byte of a signature with recovery id zero for an uncompressed key.
+	legacyHeaderBase = 27
+	// legacyHeaderCompressed is added to the header byte for compressed keys.
+	legacyHeaderCompressed = 4
+)
+
+// legacyHash returns the double SHA-256 hash of the message in the legacy format.
+func legacyHash(message []byte) []byte {
+	b := appendVarBytes(nil, []byte(legacyMagic))
+	b = appendVarBytes(b, message)
+	h := doubleSHA256(b)
+	return h[:]
+}
+
+// SignLegacy signs the message with the secp256k1 private key in the legacy "Bitcoin Signed Message" format and
+// returns the base64-encoded compact signature. The compressed flag must match the encoding of the public key
+// used for the P2PKH address.
+func SignLegacy(key *elliptic.PrivateKey, message []byte, compressed bool) (string, error) {
+	if key.Curve.Params().Name != curve.Params().Name {
+		return "", fmt.Errorf("%w: not a secp256k1 key", ErrInvalidKey)
+	}
+	digest := legacyHash(message)
+	r, s, err := signECDSA(key, digest)
+	if err != nil {
+		return "", err
+	}
+
+	// find the recovery id yielding the public key
+	x, y := curve.ScalarBaseMult(key.K.Bytes())
+	for recID := byte(0); recID < 4; recID++ {
+		qx, qy, ok := recoverPublicKey(digest, r, s, recID)
+		if !ok || qx.Cmp(x) != 0 || qy.Cmp(y) != 0 {
+			continue
+		}
+		header := legacyHeaderBase + recID
+		if compressed {
+			header += legacyHeaderCompressed
+		}
+		sig := make([]byte, 1, legacySignatureSize)
+		sig[0] = header
+		sig = append(sig, r.FillBytes(make([]byte, 32))...)
+		sig = append(sig, s.FillBytes(make([]byte, 32))...)
+		return base64.StdEncoding.EncodeToString(sig), nil
+	}
+	panic("bip322: failed to compute recovery id")
+}
+
+// VerifyLegacy checks that signature is a valid legacy "Bitcoin Signed Message" signature of message for the
+// P2PKH address.
+func VerifyLegacy(addr string, message []byte, signature string) error {
+	a, err := parseAddress(addr)
+	if err != nil {
+		return err
+	}
+	if a.typ != p2pkh {
+		return fmt.Errorf("%w: legacy signatures require a P2PKH address", ErrUnsupportedAddress)
+	}
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+	}
+	if len(sig) != legacySignatureSize || sig[0] < legacyHeaderBase || sig[0] >= legacyHeaderBase+2*legacyHeaderCompressed {
+		return fmt.Errorf("%w: invalid compact signature", ErrInvalidSignature)
+	}
+	header := sig[0] - legacyHeaderBase
+	r := new(big.Int).SetBytes(sig[1:33])
+	s := new(big.Int).SetBytes(sig[33:])
+	x, y, ok := recoverPublicKey(legacyHash(message), r, s, header&3)
+	if !ok {
+		return ErrInvalidSignature
+	}
+	if !bytes.Equal(hash160(serializePublicKey(x, y, header >= legacyHeaderCompressed)), a.program) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
@@ -0,0 +1,213 @@
+package bip322
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+)
+
+// Hash types of the signature hash algorithms.
+const (
+	sighashDefault = 0x00
+	sighashAll     = 0x01
+)
+
+// tx is the subset of a Bitcoin transaction needed to construct the virtual BIP-322 transactions.
+type tx struct {
+	version  uint32
+	inputs   []txIn
+	outputs  []txOut
+	lockTime uint32
+}
+
+type txIn struct {
+	prevHash  [sha256.Size]byte
+	prevIndex uint32
+	script    []byte
+	sequence  uint32
+}
+
+type txOut struct {
+	value  uint64
+	script []byte
+}
+
+// toSpend returns the virtual transaction whose only output is locked by the message challenge.
+func toSpend(messageChallenge, message []byte) *tx {
+	// scriptSig = OP_0 PUSH32[message_hash]
+	scriptSig := append([]byte{0x00, 0x20}, MessageHash(message)...)
+	return &tx{
+		inputs:  []txIn{{prevIndex: 0xFFFFFFFF, script: scriptSig}},
+		outputs: []txOut{{script: messageChallenge}},
+	}
+}
+
+// toSign returns the virtual transaction spending the output of toSpend. Its witness is the message signature.
+func toSign(spend *tx) *tx {
+	return &tx{
+		inputs: []txIn{{prevHash: spend.txid(), prevIndex: 0}},
+		// scriptPubKey = OP_RETURN
+		outputs: []txOut{{script: []byte{0x6a}}},
+	}
+}
+
+// txid returns the hash of the transaction serialized without witness.
+func (t *tx) txid() [sha256.Size]byte {
+	b := binary.LittleEndian.AppendUint32(nil, t.version)
+	b = appendVarInt(b, uint64(len(t.inputs)))
+	for _, in := range t.inputs {
+		b = append(b, in.prevHash[:]...)
+		b = binary.LittleEndian.AppendUint32(b, in.prevIndex)
+		b = appendVarBytes(b, in.script)
+		b = binary.LittleEndian.AppendUint32(b, in.sequence)
+	}
+	b = appendVarInt(b, uint64(len(t.outputs)))
+	b = appendOutputs(b, t.outputs)
+	b = binary.LittleEndian.AppendUint32(b, t.lockTime)
+	return doubleSHA256(b)
+}
+
+func (t *tx) prevouts() []byte {
+	var b []byte
+	for _, in := range t.inputs {
+		b = append(b, in.prevHash[:]...)
+		b = binary.LittleEndian.AppendUint32(b, in.prevIndex)
+	}
+	return b
+}
+
+func (t *tx) sequences() []byte {
+	var b []byte
+	for _, in := range t.inputs {
+		b = binary.LittleEndian.AppendUint32(b, in.sequence)
+	}
+	return b
+}
+
+// sighashV0 computes the BIP-143 signature hash with SIGHASH_ALL of the first input spending a zero-value output
+// with the given script code.
+func (t *tx) sighashV0(scriptCode []byte) []byte {
+	in := t.inputs[0]
+	hashPrevouts := doubleSHA256(t.prevouts())
+	hashSequence := doubleSHA256(t.sequences())
+	hashOutputs := doubleSHA256(appendOutputs(nil, t.outputs))
+
+	b := binary.LittleEndian.AppendUint32(nil, t.version)
+	b = append(b, hashPrevouts[:]...)
+	b = append(b, hashSequence[:]...)
+	b = append(b, in.prevHash[:]...)
+	b = binary.LittleEndian.AppendUint32(b, in.prevIndex)
+	b = appendVarBytes(b, scriptCode)
+	b = binary.LittleEndian.AppendUint64(b, 0) // amount
+	b = binary.LittleEndian.AppendUint32(b, in.sequence)
+	b = append(b, hashOutputs[:]...)
+	b = binary.LittleEndian.AppendUint32(b, t.lockTime)
+	b = binary.LittleEndian.AppendUint32(b, sighashAll)
+	h := doubleSHA256(b)
+	return h[:]
+}
+
+// sighashTaproot computes the BIP-341 key path signature hash of the first input spending a zero-value output
+// locked by spentScript. The hash type must be SIGHASH_DEFAULT or SIGHASH_ALL.
+func (t *tx) sighashTaproot(spentScript []byte, hashType byte) []byte {
+	shaPrevouts := sha256.Sum256(t.prevouts())
+	shaAmounts := sha256.Sum256(binary.LittleEndian.AppendUint64(nil, 0))
+	shaScriptPubKeys := sha256.Sum256(appendVarBytes(nil, spentScript))
+	shaSequences := sha256.Sum256(t.sequences())
+	shaOutputs := sha256.Sum256(appendOutputs(nil, t.outputs))
+
+	b := []byte{0x00, hashType} // epoch and hash type
+	b = binary.LittleEndian.AppendUint32(b, t.version)
+	b = binary.LittleEndian.AppendUint32(b, t.lockTime)
+	b = append(b, shaPrevouts[:]...)
+	b = append(b, shaAmounts[:]...)
+	b = append(b, shaScriptPubKeys[:]...)
+	b = append(b, shaSequences[:]...)
+	b = append(b, shaOutputs[:]...)
+	b = append(b, 0x00)                        // spend type: key path without annex
+	b = binary.LittleEndian.AppendUint32(b, 0) // input index
+	return taggedHash(tagTapSighash, b)
+}
+
+func appendOutputs(b []byte, outputs []txOut) []byte {
+	for _, out := range outputs {
+		b = binary.LittleEndian.AppendUint64(b, out.value)
+		b = appendVarBytes(b, out.script)
+	}
+	return b
+}
+
+// appendVarInt appends the Bitcoin CompactSize encoding of n.
+func appendVarInt(b []byte, n uint64) []byte {
+	switch {
+	case n < 0xFD:
+		return append(b, byte(n))
+	case n <= 0xFFFF:
+		return binary.LittleEndian.AppendUint16(append(b, 0xFD), uint16(n))
+	case n <= 0xFFFFFFFF:
+		return binary.LittleEndian.AppendUint32(append(b, 0xFE), uint32(n))
+	}
+	return binary.LittleEndian.AppendUint64(append(b, 0xFF), n)
+}
+
+func appendVarBytes(b []byte, data []byte) []byte {
+	return append(appendVarInt(b, uint64(len(data))), data...)
+}
+
+// readVarInt decodes a CompactSize integer and returns the remaining bytes.
+func readVarInt(b []byte) (uint64, []byte, bool) {
+	if len(b) < 1 {
+		return 0, nil, false
+	}
+	switch prefix := b[0]; {
+	case prefix < 0xFD:
+		return uint64(prefix), b[1:], true
+	case prefix == 0xFD && len(b) >= 3:
+		return uint64(binary.LittleEndian.Uint16(b[1:])), b[3:], true
+	case prefix == 0xFE && len(b) >= 5:
+		return uint64(binary.LittleEndian.Uint32(b[1:])), b[5:], true
+	case prefix == 0xFF && len(b) >= 9:
+		return binary.LittleEndian.Uint64(b[1:]), b[9:], true
+	}
+	return 0, nil, false
+}
+
+// encodeWitness serializes the witness stack as used by the simple signature format.
+func encodeWitness(items [][]byte) []byte {
+	b := appendVarInt(nil, uint64(len(items)))
+	for _, item := range items {
+		b = appendVarBytes(b, item)
+	}
+	return b
+}
+
+// decodeWitness parses a serialized witness stack. The input must not contain trailing data.
+func decodeWitness(b []byte) ([][]byte, bool) {
+	n, b, ok := readVarInt(b)
+	if !ok || n > uint64(len(b)) {
+		return nil, false
+	}
+	items := make([][]byte, n)
+	for i := range items {
+		var l uint64
+		if l, b, ok = readVarInt(b); !ok || l > uint64(len(b)) {
+			return nil, false
+		}
+		items[i], b = b[:l], b[l:]
+	}
+	return items, len(b) == 0
+}
+
+func doubleSHA256(b []byte) [sha256.Size]byte {
+	h := sha256.Sum256(b)
+	return sha256.Sum256(h[:])
+}
+
+// taggedHash computes SHA256(SHA256(tag) ‖ SHA256(tag) ‖ x) as defined in BIP-340.
+func taggedHash(tag string, x []byte) []byte {
+	tagHash := sha256.Sum256([]byte(tag))
+	h := sha256.New()
+	h.Write(tagHash[:])
+	h.Write(tagHash[:])
+	h.Write(x)
+	return h.Sum(nil)
+}
@@ -14,14 +14,20 @@ package bip32path
 import (
 	"errors"
 	"fmt"
+	"math"
 	"regexp"
 	"strconv"
 	"strings"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/cbor"
 )
 
 // ErrInvalidPathFormat is returned when a path string could not be parsed due to a different general structure.
 var ErrInvalidPathFormat = errors.New("invalid path format")
 
+// ErrNotCanonical is returned by ParsePathStrict when a path string is valid but not in its canonical form.
+var ErrNotCanonical = errors.New("not canonical")
+
 // hardened denotes the first hardened index.
 const hardened uint32 = 1 << 31
 
@@ -63,6 +69,42 @@ func ParsePath(s string) (Path, error) {
 	return path, nil
 }
 
+// ParsePathStrict parses s as a BIP-32 path like ParsePath, but only accepts the canonical form returned by String.
+// The "m" prefix is mandatory, hardened keys must be marked with an apostrophe and indices must be decimal without
+// leading zeros. It returns ErrNotCanonical for otherwise valid paths in a different notation and ErrInvalidPathFormat
+// for everything else.
+func ParsePathStrict(s string) (Path, error) {
+	keys := strings.Split(s, "/")
+	if keys[0] != "m" {
+		if _, err := ParsePath(s); err == nil {
+			return nil, fmt.Errorf("%w: missing master prefix", ErrNotCanonical)
+		}
+		return nil, fmt.Errorf("%w: missing master prefix", ErrInvalidPathFormat)
+	}
+	path := make(Path, 0, len(keys)-1)
+	for i, key := range keys[1:] {
+		digits, isHardened := strings.CutSuffix(key, "'")
+		if next, ok := strings.CutSuffix(key, "H"); ok {
+			digits, isHardened = next, true
+		}
+		if digits == "" || strings.Trim(digits, "0123456789") != "" {
+			return nil, fmt.Errorf("invalid key %d: %w", i, ErrInvalidPathFormat)
+		}
+		v, err := strconv.ParseUint(digits, 10, 31)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key %d: %w: index out of range", i, ErrInvalidPathFormat)
+		}
+		if strings.HasSuffix(key, "H") || (len(digits) > 1 && digits[0] == '0') {
+			return nil, fmt.Errorf("invalid key %d: %w: expected %d", i, ErrNotCanonical, v)
+		}
+		if isHardened {
+			v |= uint64(hardened)
+		}
+		path = append(path, uint32(v))
+	}
+	return path, nil
+}
+
 // String returns the string form of the BIP-32 path.
 // It returns:
 // - "m" for an empty path
@@ -98,6 +140,42 @@ func (p *Path) UnmarshalText(text []byte) (err error) {
 	return nil
 }
 
+// MarshalCBOR implements the cbor.Marshaler interface.
+// The path is encoded as array of its child indices, where hardened indices include the 2^31 offset.
+func (p Path) MarshalCBOR() ([]byte, error) {
+	b := cbor.AppendArray(nil, len(p))
+	for _, idx := range p {
+		b = cbor.AppendUint(b, uint64(idx))
+	}
+	return b, nil
+}
+
+// UnmarshalCBOR implements the cbor.Unmarshaler interface.
+// The BIP-32 path is expected in the canonical form returned by MarshalCBOR.
+func (p *Path) UnmarshalCBOR(data []byte) error {
+	d := cbor.NewDecoder(data)
+	n, err := d.Array()
+	if err != nil {
+		return err
+	}
+	x := make(Path, n)
+	for i := range x {
+		v, err := d.Uint()
+		if err != nil {
+			return fmt.Errorf("invalid key %d: %w", i, err)
+		}
+		if v > math.MaxUint32 {
+			return fmt.Errorf("invalid key %d: %w", i, ErrInvalidPathFormat)
+		}
+		x[i] = uint32(v)
+	}
+	if err := d.Finish(); err != nil {
+		return err
+	}
+	*p = x
+	return nil
+}
+
 func parseUint31(s string) (uint32, error) {
 	n, err := strconv.ParseUint(s, 0, 31)
 	if err != nil {
@@ -2,6 +2,7 @@
 package bip32path
 
 import (
+	"encoding/hex"
 	"errors"
 	"strconv"
 	"strings"
@@ -9,6 +10,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/cbor"
 )
 
 var parsePathTests = []*struct {
@@ -107,3 +110,106 @@ func TestBIPPathMarshalText(t *testing.T) {
 		})
 	}
 }
+
+var bipPathCBORTests = []*struct {
+	path Path
+	cbor string
+}{
+	{Path{}, "80"},
+	{Path{0, 1, 23, 24}, "840001171818"},
+	{Path{hardened + 44, hardened + 4218}, "821a8000002c1a8000107a"},
+	{Path{255, 256, 65535, 65536}, "8418ff19010019ffff1a00010000"},
+}
+
+func TestBIPPathCBOR(t *testing.T) {
+	for _, tt := range bipPathCBORTests {
+		t.Run(tt.path.String(), func(t *testing.T) {
+			b, err := tt.path.MarshalCBOR()
+			require.NoError(t, err)
+			assert.Equal(t, tt.cbor, hex.EncodeToString(b))
+
+			var path Path
+			require.NoError(t, path.UnmarshalCBOR(b))
+			assert.Equal(t, tt.path, path)
+		})
+	}
+}
+
+func TestBIPPathUnmarshalCBORInvalid(t *testing.T) {
+	var path Path
+	// uint64 index
+	assert.ErrorIs(t, path.UnmarshalCBOR(mustDecodeHex("811b0000000100000000")), ErrInvalidPathFormat)
+	// non-shortest argument
+	assert.ErrorIs(t, path.UnmarshalCBOR(mustDecodeHex("811800")), cbor.ErrNotCanonical)
+	// missing element
+	assert.ErrorIs(t, path.UnmarshalCBOR(mustDecodeHex("8201")), cbor.ErrUnexpectedEnd)
+	// trailing data
+	assert.ErrorIs(t, path.UnmarshalCBOR(mustDecodeHex("800a")), cbor.ErrTrailingData)
+}
+
+func mustDecodeHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+var parsePathStrictTests = []*struct {
+	s    string
+	path Path
+	err  error
+}{
+	{"m", Path{}, nil},
+	{"m/0'", Path{hardened + 0}, nil},
+	{"m/44'/4218'/0'/0'/0'", Path{hardened + 44, hardened + 4218, hardened, hardened, hardened}, nil},
+	{"m/0'/1/2147483647", Path{hardened + 0, 1, 2147483647}, nil},
+	{"", nil, ErrNotCanonical},
+	{"0'/1", nil, ErrNotCanonical},
+	{"m/0H", nil, ErrNotCanonical},
+	{"m/0H/1'", nil, ErrNotCanonical},
+	{"m/01", nil, ErrNotCanonical},
+	{"m/00'", nil, ErrNotCanonical},
+	{"m/", nil, ErrInvalidPathFormat},
+	{"m/1/", nil, ErrInvalidPathFormat},
+	{"m//1", nil, ErrInvalidPathFormat},
+	{"m/1 ", nil, ErrInvalidPathFormat},
+	{" m/1", nil, ErrInvalidPathFormat},
+	{"m/+1", nil, ErrInvalidPathFormat},
+	{"m/0x1", nil, ErrInvalidPathFormat},
+	{"m/1''", nil, ErrInvalidPathFormat},
+	{"m/2147483648", nil, ErrInvalidPathFormat},
+	{"M/1", nil, ErrInvalidPathFormat},
+}
+
+func TestParsePathStrict(t *testing.T) {
+	for _, tt := range parsePathStrictTests {
+		t.Run(strings.ReplaceAll(tt.s, "/", "|"), func(t *testing.T) {
+			path, err := ParsePathStrict(tt.s)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.path, path)
+		})
+	}
+}
+
+func FuzzParsePathStrict(f *testing.F) {
+	for _, tt := range parsePathStrictTests {
+		f.Add(tt.s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		path, err := ParsePathStrict(s)
+		if err != nil {
+			require.True(t, errors.Is(err, ErrNotCanonical) || errors.Is(err, ErrInvalidPathFormat), err)
+			return
+		}
+		// every accepted path must be in canonical form
+		require.Equal(t, s, path.String())
+		lax, err := ParsePath(s)
+		require.NoError(t, err)
+		require.Equal(t, path, lax)
+	})
+}
@@ -0,0 +1,206 @@
+/*
+Package bip340 implements the BIP-0340 Schnorr signatures for secp256k1.
+
+Public keys are x-only, i.e. they only encode the x-coordinate of the point
+and implicitly refer to the point with an even y-coordinate. This allows keys
+derived using the slip10 package to be used with Taproot. The tweaking of
+internal keys into Taproot output keys follows BIP-0341.
+
+This package is tested against the test vectors provided in the official
+BIP-0340 and BIP-0341 specifications.
+*/
+package bip340
+
+import (
+	cryptorand "crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"io"
+	"math/big"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10/btccurve"
+	slip10elliptic "github.com/iotaledger/iota-crypto-demo/pkg/slip10/elliptic"
+)
+
+const (
+	// PublicKeySize is the size, in bytes, of x-only public keys.
+	PublicKeySize = 32
+	// SignatureSize is the size, in bytes, of signatures generated and verified by this package.
+	SignatureSize = 64
+	// AuxSize is the size, in bytes, of the auxiliary random data used during signing.
+	AuxSize = 32
+)
+
+var (
+	// ErrInvalidCurve is returned when a key is not a secp256k1 key.
+	ErrInvalidCurve = errors.New("key is not a secp256k1 key")
+	// ErrInvalidKey is returned when a public key is not a valid x-only public key.
+	ErrInvalidKey = errors.New("invalid key")
+
+	errInvalidNonce = errors.New("invalid nonce")
+)
+
+// Tags of the tagged hashes.
+const (
+	tagAux       = "BIP0340/aux"
+	tagNonce     = "BIP0340/nonce"
+	tagChallenge = "BIP0340/challenge"
+)
+
+var curve = btccurve.Secp256k1()
+
+// PublicKey is the type of x-only public keys.
+type PublicKey []byte
+
+// XOnlyPublicKey returns the x-only public key corresponding to the secp256k1 public key.
+func XOnlyPublicKey(key *slip10elliptic.PublicKey) (PublicKey, error) {
+	if key.Curve.Params().Name != curve.Params().Name {
+		return nil, ErrInvalidCurve
+	}
+	return key.X.FillBytes(make([]byte, PublicKeySize)), nil
+}
+
+// ParsePublicKey returns the point with an even y-coordinate corresponding to the x-only public key.
+func ParsePublicKey(pub PublicKey) (*slip10elliptic.PublicKey, error) {
+	x, y, err := liftX(pub)
+	if err != nil {
+		return nil, err
+	}
+	return &slip10elliptic.PublicKey{X: x, Y: y, Curve: curve}, nil
+}
+
+// Sign signs the message with the secp256k1 private key and returns a signature.
+// The auxiliary random data is read from rand. If rand is nil, crypto/rand.Reader will be used.
+func Sign(rand io.Reader, key *slip10elliptic.PrivateKey, message []byte) ([]byte, error) {
+	if key.Curve.Params().Name != curve.Params().Name {
+		return nil, ErrInvalidCurve
+	}
+	if rand == nil {
+		rand = cryptorand.Reader
+	}
+	aux := make([]byte, AuxSize)
+	if _, err := io.ReadFull(rand, aux); err != nil {
+		return nil, err
+	}
+
+	n := curve.Params().N
+	px, py := curve.ScalarBaseMult(key.K.Bytes())
+	d := new(big.Int).Set(key.K)
+	if py.Bit(0) == 1 {
+		d.Sub(n, d)
+	}
+	pBytes := bytes32(px)
+
+	// t = bytes(d) ⊕ hash_aux(a)
+	t := bytes32(d)
+	for i, b := range taggedHash(tagAux, aux) {
+		t[i] ^= b
+	}
+	k := new(big.Int).SetBytes(taggedHash(tagNonce, t, pBytes, message))
+	k.Mod(k, n)
+	if k.Sign() == 0 {
+		// happens with negligible probability
+		return nil, errInvalidNonce
+	}
+	rx, ry := curve.ScalarBaseMult(k.Bytes())
+	if ry.Bit(0) == 1 {
+		k.Sub(n, k)
+	}
+	rBytes := bytes32(rx)
+
+	// s = k + e⋅d mod n
+	s := challenge(rBytes, pBytes, message)
+	s.Mul(s, d).Add(s, k).Mod(s, n)
+
+	return append(rBytes, bytes32(s)...), nil
+}
+
+// Verify reports whether sig is a valid signature of message by the x-only public key.
+func Verify(publicKey PublicKey, message, sig []byte) bool {
+	if len(sig) != SignatureSize {
+		return false
+	}
+	px, py, err := liftX(publicKey)
+	if err != nil {
+		return false
+	}
+	params := curve.Params()
+	r := new(big.Int).SetBytes(sig[:32])
+	s := new(big.Int).SetBytes(sig[32:])
+	if r.Cmp(params.P) >= 0 || s.Cmp(params.N) >= 0 {
+		return false
+	}
+	e := challenge(sig[:32], publicKey, message)
+
+	// R = s⋅G - e⋅P
+	sx, sy := curve.ScalarBaseMult(s.Bytes())
+	ex, ey := curve.ScalarMult(px, new(big.Int).Sub(params.P, py), e.Bytes())
+	rx, ry := addPoints(sx, sy, ex, ey)
+	if rx == nil || ry.Bit(0) == 1 {
+		return false
+	}
+	return rx.Cmp(r) == 0
+}
+
+// challenge returns the challenge e = int(hash_challenge(bytes(R) ‖ bytes(P) ‖ m)) mod n.
+func challenge(r, p, message []byte) *big.Int {
+	e := new(big.Int).SetBytes(taggedHash(tagChallenge, r, p, message))
+	return e.Mod(e, curve.Params().N)
+}
+
+// liftX returns the point with the x-coordinate pub and an even y-coordinate.
+func liftX(pub []byte) (x, y *big.Int, err error) {
+	if len(pub) != PublicKeySize {
+		return nil, nil, ErrInvalidKey
+	}
+	params := curve.Params()
+	x = new(big.Int).SetBytes(pub)
+	if x.Cmp(params.P) >= 0 {
+		return nil, nil, ErrInvalidKey
+	}
+	// y = c^((p+1)/4) with c = x³ + 7
+	c := new(big.Int).Mul(x, x)
+	c.Mul(c, x).Add(c, params.B).Mod(c, params.P)
+	e := new(big.Int).Add(params.P, big.NewInt(1))
+	y = new(big.Int).Exp(c, e.Rsh(e, 2), params.P)
+	if new(big.Int).Exp(y, big.NewInt(2), params.P).Cmp(c) != 0 {
+		return nil, nil, ErrInvalidKey
+	}
+	if y.Bit(0) == 1 {
+		y.Sub(params.P, y)
+	}
+	return x, y, nil
+}
+
+// taggedHash computes SHA256(SHA256(tag) ‖ SHA256(tag) ‖ x).
+func taggedHash(tag string, data ...[]byte) []byte {
+	tagHash := sha256.Sum256([]byte(tag))
+	h := sha256.New()
+	h.Write(tagHash[:])
+	h.Write(tagHash[:])
+	for _, d := range data {
+		h.Write(d)
+	}
+	return h.Sum(nil)
+}
+
+func bytes32(x *big.Int) []byte {
+	return x.FillBytes(make([]byte, 32))
+}
+
+// addPoints adds two points, where the point at infinity is represented by nil coordinates as returned by
+// curve.ScalarMult for a zero scalar. Unlike curve.Add, it handles the addition of equal and opposite points.
+func addPoints(x1, y1, x2, y2 *big.Int) (x, y *big.Int) {
+	switch {
+	case x1 == nil:
+		return x2, y2
+	case x2 == nil:
+		return x1, y1
+	case x1.Cmp(x2) == 0:
+		if y1.Cmp(y2) == 0 {
+			return curve.Double(x1, y1)
+		}
+		return nil, nil
+	}
+	return curve.Add(x1, y1, x2, y2)
+}
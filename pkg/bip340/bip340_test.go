@@ -0,0 +1,150 @@
+package bip340_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/internal/hexutil"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip340"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10/elliptic"
+)
+
+func TestSign(t *testing.T) {
+	// test vectors from the BIP-0340 specification
+	var tests = []*struct {
+		secretKey []byte
+		publicKey []byte
+		aux       []byte
+		message   []byte
+		signature []byte
+	}{
+		{
+			hexutil.MustDecodeString("0000000000000000000000000000000000000000000000000000000000000003"),
+			hexutil.MustDecodeString("F9308A019258C31049344F85F89D5229B531C845836F99B08601F113BCE036F9"),
+			hexutil.MustDecodeString("0000000000000000000000000000000000000000000000000000000000000000"),
+			hexutil.MustDecodeString("0000000000000000000000000000000000000000000000000000000000000000"),
+			hexutil.MustDecodeString("E907831F80848D1069A5371B402410364BDF1C5F8307B0084C55F1CE2DCA821525F66A4A85EA8B71E482A74F382D2CE5EBEEE8FDB2172F477DF4900D310536C0"),
+		},
+		{
+			hexutil.MustDecodeString("B7E151628AED2A6ABF7158809CF4F3C762E7160F38B4DA56A784D9045190CFEF"),
+			hexutil.MustDecodeString("DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659"),
+			hexutil.MustDecodeString("0000000000000000000000000000000000000000000000000000000000000001"),
+			hexutil.MustDecodeString("243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89"),
+			hexutil.MustDecodeString("6896BD60EEAE296DB48A229FF71DFE071BDE413E6D43F917DC8DCF8C78DE33418906D11AC976ABCCB20B091292BFF4EA897EFCB639EA871CFA95F6DE339E4B0A"),
+		},
+		{
+			hexutil.MustDecodeString("C90FDAA22168C234C4C6628B80DC1CD129024E088A67CC74020BBEA63B14E5C9"),
+			hexutil.MustDecodeString("DD308AFEC5777E13121FA72B9CC1B7CC0139715309B086C960E18FD969774EB8"),
+			hexutil.MustDecodeString("C87AA53824B4D7AE2EB035A2B5BBBCCC080E76CDC6D1692C4B0B62D798E6D906"),
+			hexutil.MustDecodeString("7E2D58D8B3BCDF1ABADEC7829054F90DDA9805AAB56C77333024B9D0A508B75C"),
+			hexutil.MustDecodeString("5831AAEED7B44BB74E5EAB94BA9D4294C49BCF2A60728D8B4C200F50DD313C1BAB745879A5AD954A72C45A91C3A51D3C7ADEA98D82F8481E0E1E03674A6F3FB7"),
+		},
+		{
+			hexutil.MustDecodeString("0B432B2677937381AEF05BB02A66ECD012773062CF3FA2549E44F58ED2401710"),
+			hexutil.MustDecodeString("25D1DFF95105F5253C4022F628A996AD3A0D95FBF21D468A1B33F8C160D8F517"),
+			hexutil.MustDecodeString("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF"),
+			hexutil.MustDecodeString("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF"),
+			hexutil.MustDecodeString("7EB0509757E246F19449885651611CB965ECC1A187DD51B64FDA1EDC9637D5EC97582B9CB13DB3933705B32BA982AF5AF25FD78881EBB32771FC5922EFC66EA3"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(hexutil.Bytes(tt.publicKey).String(), func(t *testing.T) {
+			key, err := elliptic.Secp256k1().NewPrivateKey(tt.secretKey)
+			require.NoError(t, err)
+			pub, err := bip340.XOnlyPublicKey(key.Public().(*elliptic.PublicKey)) //nolint:forcetypeassert
+			require.NoError(t, err)
+			assert.EqualValues(t, tt.publicKey, pub)
+
+			sig, err := bip340.Sign(bytes.NewReader(tt.aux), key.(*elliptic.PrivateKey), tt.message) //nolint:forcetypeassert
+			require.NoError(t, err)
+			assert.Equal(t, tt.signature, sig)
+			assert.True(t, bip340.Verify(pub, tt.message, sig))
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	pub := hexutil.MustDecodeString("DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659")
+	msg := hexutil.MustDecodeString("243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89")
+	sig := hexutil.MustDecodeString("6896BD60EEAE296DB48A229FF71DFE071BDE413E6D43F917DC8DCF8C78DE33418906D11AC976ABCCB20B091292BFF4EA897EFCB639EA871CFA95F6DE339E4B0A")
+	require.True(t, bip340.Verify(pub, msg, sig))
+
+	var tests = []*struct {
+		desc      string
+		publicKey []byte
+		message   []byte
+		signature []byte
+	}{
+		{
+			"public key not on the curve",
+			hexutil.MustDecodeString("EEFDEA4CDB677750A420FEE807EACF21EB9898AE79B9768766E4FAA04A2D4A34"),
+			msg,
+			sig,
+		},
+		{
+			"public key exceeds field size",
+			hexutil.MustDecodeString("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEFFFFFC30"),
+			msg,
+			sig,
+		},
+		{
+			"wrong message",
+			pub,
+			append([]byte{0x00}, msg[1:]...),
+			sig,
+		},
+		{
+			"sig[0:32] is equal to field size",
+			pub,
+			msg,
+			append(hexutil.MustDecodeString("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEFFFFFC2F"), sig[32:]...),
+		},
+		{
+			"sig[32:64] is equal to curve order",
+			pub,
+			msg,
+			append(append([]byte{}, sig[:32]...), hexutil.MustDecodeString("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEBAAEDCE6AF48A03BBFD25E8CD0364141")...),
+		},
+		{
+			"zero s",
+			pub,
+			msg,
+			append(append([]byte{}, sig[:32]...), make([]byte, 32)...),
+		},
+		{
+			"invalid signature length",
+			pub,
+			msg,
+			sig[:63],
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.False(t, bip340.Verify(tt.publicKey, tt.message, tt.signature))
+		})
+	}
+}
+
+func TestParsePublicKey(t *testing.T) {
+	seed := hexutil.MustDecodeString("000102030405060708090a0b0c0d0e0f")
+	key, err := slip10.DeriveKeyFromPath(seed, elliptic.Secp256k1(), []uint32{slip10.Hardened + 86, slip10.Hardened, slip10.Hardened, 0, 0})
+	require.NoError(t, err)
+
+	pub, err := bip340.XOnlyPublicKey(key.Key.Public().(*elliptic.PublicKey)) //nolint:forcetypeassert
+	require.NoError(t, err)
+	point, err := bip340.ParsePublicKey(pub)
+	require.NoError(t, err)
+	assert.Equal(t, key.Key.Public().(*elliptic.PublicKey).X, point.X) //nolint:forcetypeassert
+	assert.Zero(t, point.Y.Bit(0))
+
+	msg := []byte("message")
+	sig, err := bip340.Sign(nil, key.Key.(*elliptic.PrivateKey), msg) //nolint:forcetypeassert
+	require.NoError(t, err)
+	assert.True(t, bip340.Verify(pub, msg, sig))
+}
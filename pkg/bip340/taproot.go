@@ -0,0 +1,73 @@
+package bip340
+
+import (
+	"errors"
+	"math/big"
+
+	slip10elliptic "github.com/iotaledger/iota-crypto-demo/pkg/slip10/elliptic"
+)
+
+// MerkleRootSize is the size, in bytes, of the Merkle root of a Taproot script tree.
+const MerkleRootSize = 32
+
+// ErrInvalidTweak is returned when the Taproot tweak leads to an invalid key.
+var ErrInvalidTweak = errors.New("invalid tweak")
+
+const tagTapTweak = "TapTweak"
+
+// tapTweak returns the tweak t = hash_TapTweak(bytes(P) ‖ merkleRoot) as an integer.
+func tapTweak(internalKey, merkleRoot []byte) (*big.Int, error) {
+	if merkleRoot != nil && len(merkleRoot) != MerkleRootSize {
+		return nil, ErrInvalidTweak
+	}
+	t := new(big.Int).SetBytes(taggedHash(tagTapTweak, internalKey, merkleRoot))
+	if t.Cmp(curve.Params().N) >= 0 {
+		return nil, ErrInvalidTweak
+	}
+	return t, nil
+}
+
+// TweakPublicKey computes the Taproot output key Q = P + t⋅G of the x-only internal key as described in BIP-0341.
+// The merkleRoot of the script tree must be nil for outputs that can only be spent using the key path.
+// It returns the x-only output key together with the parity of the y-coordinate of Q, which is needed for
+// script path spending.
+func TweakPublicKey(internalKey PublicKey, merkleRoot []byte) (PublicKey, uint, error) {
+	px, py, err := liftX(internalKey)
+	if err != nil {
+		return nil, 0, err
+	}
+	t, err := tapTweak(internalKey, merkleRoot)
+	if err != nil {
+		return nil, 0, err
+	}
+	tx, ty := curve.ScalarBaseMult(t.Bytes())
+	qx, qy := addPoints(px, py, tx, ty)
+	if qx == nil {
+		return nil, 0, ErrInvalidTweak
+	}
+	return bytes32(qx), qy.Bit(0), nil
+}
+
+// TweakPrivateKey returns the private key corresponding to the Taproot output key of the given private key.
+// The merkleRoot of the script tree must be nil for outputs that can only be spent using the key path.
+// Signatures created with the tweaked key using Sign are valid for the output key returned by TweakPublicKey.
+func TweakPrivateKey(key *slip10elliptic.PrivateKey, merkleRoot []byte) (*slip10elliptic.PrivateKey, error) {
+	if key.Curve.Params().Name != curve.Params().Name {
+		return nil, ErrInvalidCurve
+	}
+	n := curve.Params().N
+	px, py := curve.ScalarBaseMult(key.K.Bytes())
+	d := new(big.Int).Set(key.K)
+	if py.Bit(0) == 1 {
+		d.Sub(n, d)
+	}
+	t, err := tapTweak(bytes32(px), merkleRoot)
+	if err != nil {
+		return nil, err
+	}
+	d.Add(d, t).Mod(d, n)
+	if d.Sign() == 0 {
+		return nil, ErrInvalidTweak
+	}
+	return &slip10elliptic.PrivateKey{K: d, Curve: key.Curve}, nil
+}
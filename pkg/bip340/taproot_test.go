@@ -0,0 +1,69 @@
+package bip340_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/internal/hexutil"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip340"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10/elliptic"
+)
+
+func TestTweakPublicKey(t *testing.T) {
+	// test vectors from the BIP-0341 wallet test vectors
+	var tests = []*struct {
+		internalKey []byte
+		merkleRoot  []byte
+		outputKey   []byte
+	}{
+		{
+			hexutil.MustDecodeString("d6889cb081036e0faefa3a35157ad71086b123b2b144b649798b494c300a961d"),
+			nil,
+			hexutil.MustDecodeString("53a1f6e454df1aa2776a2814a721372d6258050de330b3c6d10ee8f4e0dda343"),
+		},
+		{
+			hexutil.MustDecodeString("187791b6f712a8ea41c8ecdd0ee77fab3e85263b37e1ec18a3651926b3a6cf27"),
+			hexutil.MustDecodeString("5b75adecf53548f3ec6ad7d78383bf84cc57b55a3127c72b9a2481752dd88b21"),
+			hexutil.MustDecodeString("147c9c57132f6e7ecddba9800bb0c4449251c92a1e60371ee77557b6620f3ea3"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(hexutil.Bytes(tt.internalKey).String(), func(t *testing.T) {
+			outputKey, _, err := bip340.TweakPublicKey(tt.internalKey, tt.merkleRoot)
+			require.NoError(t, err)
+			assert.EqualValues(t, tt.outputKey, outputKey)
+		})
+	}
+}
+
+func TestTweakPrivateKey(t *testing.T) {
+	// test vector from the BIP-0341 wallet test vectors
+	key, err := elliptic.Secp256k1().NewPrivateKey(hexutil.MustDecodeString("6b973d88838f27366ed61c9ad6367663045cb456e28335c109e30717ae0c6baa"))
+	require.NoError(t, err)
+	tweaked, err := bip340.TweakPrivateKey(key.(*elliptic.PrivateKey), nil) //nolint:forcetypeassert
+	require.NoError(t, err)
+	assert.Equal(t, hexutil.MustDecodeString("2405b971772ad26915c8dcdf10f238753a9b837e5f8e6a86fd7c0cce5b7296d9"), tweaked.Bytes())
+
+	internalKey, err := bip340.XOnlyPublicKey(key.Public().(*elliptic.PublicKey)) //nolint:forcetypeassert
+	require.NoError(t, err)
+	outputKey, parity, err := bip340.TweakPublicKey(internalKey, nil)
+	require.NoError(t, err)
+	tweakedPub := tweaked.Public().(*elliptic.PublicKey) //nolint:forcetypeassert
+	tweakedKey, err := bip340.XOnlyPublicKey(tweakedPub)
+	require.NoError(t, err)
+	assert.Equal(t, outputKey, tweakedKey)
+	assert.EqualValues(t, parity, tweakedPub.Y.Bit(0))
+
+	msg := []byte("message")
+	sig, err := bip340.Sign(nil, tweaked, msg)
+	require.NoError(t, err)
+	assert.True(t, bip340.Verify(outputKey, msg, sig))
+}
+
+func TestTweakInvalidMerkleRoot(t *testing.T) {
+	_, _, err := bip340.TweakPublicKey(hexutil.MustDecodeString("d6889cb081036e0faefa3a35157ad71086b123b2b144b649798b494c300a961d"), make([]byte, 31))
+	assert.ErrorIs(t, err, bip340.ErrInvalidTweak)
+}
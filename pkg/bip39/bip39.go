@@ -36,6 +36,8 @@ var (
 	ErrInvalidMnemonic = errors.New("invalid mnemonic")
 	// ErrInvalidChecksum is returned when checksum does not match.
 	ErrInvalidChecksum = errors.New("invalid checksum")
+	// ErrNotCanonical is returned by ParseMnemonicStrict when a mnemonic sentence is not in its canonical form.
+	ErrNotCanonical = errors.New("not canonical")
 )
 
 const (
@@ -62,8 +64,14 @@ var wordList wordlist.List
 // MnemonicToSeed creates a hashed seed output given a provided string and password.
 // No checking is performed to validate that the string provided is a valid mnemonic.
 func MnemonicToSeed(mnemonic Mnemonic, passphrase string) ([]byte, error) {
+	return MnemonicToSeedWithList(mnemonic, passphrase, wordList)
+}
+
+// MnemonicToSeedWithList is like MnemonicToSeed, but validates the mnemonic using list instead of the current word
+// list. In contrast to SetWordList, it can be used concurrently with different word lists.
+func MnemonicToSeedWithList(mnemonic Mnemonic, passphrase string, list wordlist.List) ([]byte, error) {
 	// validate mnemonic
-	if _, err := MnemonicToEntropy(mnemonic); err != nil {
+	if _, err := MnemonicToEntropyWithList(mnemonic, list); err != nil {
 		return nil, err
 	}
 	// UTF-8 NFKD
@@ -74,6 +82,11 @@ func MnemonicToSeed(mnemonic Mnemonic, passphrase string) ([]byte, error) {
 
 // EntropyToMnemonic generates a BIP-39 mnemonic sentence that satisfies the given entropy length.
 func EntropyToMnemonic(entropy []byte) (Mnemonic, error) {
+	return EntropyToMnemonicWithList(entropy, wordList)
+}
+
+// EntropyToMnemonicWithList is like EntropyToMnemonic, but uses the words of list instead of the current word list.
+func EntropyToMnemonicWithList(entropy []byte, list wordlist.List) (Mnemonic, error) {
 	if err := validateEntropy(entropy); err != nil {
 		return nil, err
 	}
@@ -99,7 +112,7 @@ func EntropyToMnemonic(entropy []byte) (Mnemonic, error) {
 		// get least significant 11 bits
 		wordIndex.And(bigEntropy, wordIndexMask)
 		// convert the 11 bits to index
-		words[i] = wordList.Word(int(wordIndex.Int64()))
+		words[i] = list.Word(int(wordIndex.Int64()))
 
 		// shift out least significant 11 bits
 		bigEntropy.Rsh(bigEntropy, wordlist.IndexBits)
@@ -111,7 +124,13 @@ func EntropyToMnemonic(entropy []byte) (Mnemonic, error) {
 // MnemonicToEntropy takes a BIP-39 mnemonic sentence and returns the initial
 // entropy used. If the sentence is invalid, an error is returned.
 func MnemonicToEntropy(mnemonic Mnemonic) ([]byte, error) {
-	if err := validateMnemonic(mnemonic); err != nil {
+	return MnemonicToEntropyWithList(mnemonic, wordList)
+}
+
+// MnemonicToEntropyWithList is like MnemonicToEntropy, but decodes the words using list instead of the current word
+// list.
+func MnemonicToEntropyWithList(mnemonic Mnemonic, list wordlist.List) ([]byte, error) {
+	if err := validateMnemonic(mnemonic, list); err != nil {
 		return nil, err
 	}
 
@@ -122,7 +141,7 @@ func MnemonicToEntropy(mnemonic Mnemonic) ([]byte, error) {
 	// use a big.Int to decode words for easier bitwise operations
 	decoder := big.NewInt(0)
 	for _, word := range mnemonic {
-		wordIndex := wordList.Index(word)
+		wordIndex := list.Index(word)
 		if wordIndex < 0 || wordIndex >= wordlist.Count {
 			panic("invalid word index")
 		}
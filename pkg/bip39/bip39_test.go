@@ -121,6 +121,39 @@ func TestMnemonicToSeed(t *testing.T) {
 	}
 }
 
+func TestWithList(t *testing.T) {
+	require.NoError(t, SetWordList(defaultLanguage))
+	b, err := os.ReadFile(filepath.Join("testdata", "TestBIP39.json"))
+	require.NoError(t, err)
+	var tvs []TestVector
+	require.NoError(t, json.Unmarshal(b, &tvs))
+
+	// the languages are used concurrently without changing the current word list
+	t.Run("group", func(t *testing.T) {
+		for _, tv := range tvs {
+			t.Run(tv.Language, func(t *testing.T) {
+				t.Parallel()
+				list, err := WordList(strings.ToLower(tv.Language))
+				require.NoError(t, err)
+				for _, tt := range tv.Tests {
+					ms, err := EntropyToMnemonicWithList(tt.Entropy, list)
+					assert.NoError(t, err)
+					assert.Equal(t, tt.Mnemonic, ms)
+
+					ent, err := MnemonicToEntropyWithList(tt.Mnemonic, list)
+					assert.NoError(t, err)
+					assert.EqualValues(t, tt.Entropy, ent)
+
+					seed, err := MnemonicToSeedWithList(tt.Mnemonic, tt.Passphrase, list)
+					assert.NoError(t, err)
+					assert.EqualValues(t, tt.Seed, seed)
+				}
+			})
+		}
+	})
+	assert.Equal(t, "abandon", wordList.Word(0))
+}
+
 func readJSONTests(t *testing.T) []TestVector {
 	b, err := os.ReadFile(filepath.Join("testdata", t.Name()+".json"))
 	require.NoError(t, err)
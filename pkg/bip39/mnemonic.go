@@ -1,6 +1,7 @@
 package bip39
 
 import (
+	"fmt"
 	"strings"
 
 	"golang.org/x/text/unicode/norm"
@@ -15,6 +16,26 @@ func ParseMnemonic(s string) Mnemonic {
 	return strings.Fields(normalized)
 }
 
+// ParseMnemonicStrict parses s as mnemonic sentence, but only accepts a valid mnemonic in its canonical form.
+// The canonical form is the one returned by String, i.e. the NFKD normalized words of the current word list separated
+// by single ASCII spaces without leading or trailing white space. Ideographic spaces are not canonical either, as
+// they are normalized to ASCII spaces before the seed derivation.
+// It returns ErrNotCanonical for a different form and otherwise the error of MnemonicToEntropy, e.g. ErrInvalidMnemonic
+// or ErrInvalidChecksum.
+func ParseMnemonicStrict(s string) (Mnemonic, error) {
+	if !norm.NFKD.IsNormalString(s) {
+		return nil, fmt.Errorf("%w: not NFKD normalized", ErrNotCanonical)
+	}
+	mnemonic := Mnemonic(strings.Fields(s))
+	if mnemonic.String() != s {
+		return nil, fmt.Errorf("%w: words must be separated by single spaces", ErrNotCanonical)
+	}
+	if _, err := MnemonicToEntropy(mnemonic); err != nil {
+		return nil, err
+	}
+	return mnemonic, nil
+}
+
 // String will return all the words composing the mnemonic sentence as a single string of space separated words.
 func (ms Mnemonic) String() string {
 	return strings.Join(ms, " ")
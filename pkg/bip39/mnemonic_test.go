@@ -80,3 +80,56 @@ func TestMnemonichMarshalText(t *testing.T) {
 		})
 	}
 }
+
+const testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+var parseMnemonicStrictTests = []*struct {
+	s   string
+	err error
+}{
+	{testMnemonic, nil},
+	{"legal winner thank year wave sausage worth useful legal winner thank yellow", nil},
+	{"", ErrInvalidMnemonic},
+	{" " + testMnemonic, ErrNotCanonical},
+	{testMnemonic + "\n", ErrNotCanonical},
+	{strings.Replace(testMnemonic, " ", "  ", 1), ErrNotCanonical},
+	{strings.Replace(testMnemonic, " ", "\t", 1), ErrNotCanonical},
+	{strings.Replace(testMnemonic, " ", "　", 1), ErrNotCanonical},
+	{"Süßölgefäß", ErrNotCanonical},
+	{strings.Replace(testMnemonic, "about", "abandon", 1), ErrInvalidChecksum},
+	{strings.Replace(testMnemonic, "about", "About", 1), ErrInvalidMnemonic},
+	{strings.Replace(testMnemonic, "about", "abou", 1), ErrInvalidMnemonic},
+}
+
+func TestParseMnemonicStrict(t *testing.T) {
+	for _, tt := range parseMnemonicStrictTests {
+		t.Run(tt.s, func(t *testing.T) {
+			mnemonic, err := ParseMnemonicStrict(tt.s)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.s, mnemonic.String())
+		})
+	}
+}
+
+func FuzzParseMnemonicStrict(f *testing.F) {
+	for _, tt := range parseMnemonicStrictTests {
+		f.Add(tt.s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		mnemonic, err := ParseMnemonicStrict(s)
+		if err != nil {
+			return
+		}
+		// every accepted mnemonic must be the unique encoding of its entropy
+		require.Equal(t, s, mnemonic.String())
+		entropy, err := MnemonicToEntropy(mnemonic)
+		require.NoError(t, err)
+		again, err := EntropyToMnemonic(entropy)
+		require.NoError(t, err)
+		require.Equal(t, mnemonic, again)
+	})
+}
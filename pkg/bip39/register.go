@@ -2,6 +2,7 @@ package bip39
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/iotaledger/iota-crypto-demo/pkg/bip39/wordlist"
 )
@@ -24,3 +25,22 @@ func SetWordList(language string) error {
 func RegisterWordList(language string, init func() wordlist.List) {
 	wordLists[language] = init
 }
+
+// WordLists returns the sorted languages of all registered word lists.
+func WordLists() []string {
+	languages := make([]string, 0, len(wordLists))
+	for language := range wordLists {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages)
+	return languages
+}
+
+// WordList returns a new instance of the registered word list for language.
+func WordList(language string) (wordlist.List, error) {
+	init, ok := wordLists[language]
+	if !ok {
+		return nil, fmt.Errorf("word list '%s' is unavailable", language)
+	}
+	return init(), nil
+}
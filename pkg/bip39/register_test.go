@@ -0,0 +1,21 @@
+package bip39_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip39"
+)
+
+func TestWordLists(t *testing.T) {
+	assert.Equal(t, []string{"english", "japanese"}, bip39.WordLists())
+
+	l, err := bip39.WordList("english")
+	require.NoError(t, err)
+	assert.Equal(t, "abandon", l.Word(0))
+
+	_, err = bip39.WordList("klingon")
+	assert.Error(t, err)
+}
@@ -0,0 +1,75 @@
+package bip39
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip39/wordlist"
+)
+
+// IsWord reports whether word is contained in the current word list.
+func IsWord(word string) bool {
+	return wordList.Contains(word)
+}
+
+// Complete returns all words of the current word list starting with prefix in the order of the list.
+// For the English word list, the first four letters of a word uniquely identify it.
+func Complete(prefix string) []string {
+	var words []string
+	for i := 0; i < wordlist.Count; i++ {
+		if w := wordList.Word(i); strings.HasPrefix(w, prefix) {
+			words = append(words, w)
+		}
+	}
+	return words
+}
+
+// Suggest returns the words of the current word list that are at most maxDistance edits away from word, to correct
+// typos. An edit is the insertion, deletion or substitution of a single character or the transposition of two adjacent
+// characters. The words are ordered by their distance and then by their order in the list.
+func Suggest(word string, maxDistance int) []string {
+	type candidate struct {
+		word     string
+		distance int
+	}
+	var candidates []candidate
+	for i := 0; i < wordlist.Count; i++ {
+		w := wordList.Word(i)
+		if d := editDistance([]rune(word), []rune(w)); d <= maxDistance {
+			candidates = append(candidates, candidate{w, d})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].distance < candidates[j].distance })
+
+	words := make([]string, len(candidates))
+	for i := range candidates {
+		words[i] = candidates[i].word
+	}
+	return words
+}
+
+// editDistance returns the optimal string alignment distance between a and b.
+func editDistance(a, b []rune) int {
+	// d[i][j] is the distance between the first i runes of a and the first j runes of b
+	d := make([][]int, len(a)+1)
+	for i := range d {
+		d[i] = make([]int, len(b)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(a)][len(b)]
+}
@@ -0,0 +1,53 @@
+//nolint:scopelint
+package bip39_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip39"
+)
+
+func TestComplete(t *testing.T) {
+	var tests = []*struct {
+		prefix string
+		words  []string
+	}{
+		{"abando", []string{"abandon"}},
+		{"zoo", []string{"zoo"}},
+		{"aba", []string{"abandon"}},
+		{"ab", []string{"abandon", "ability", "able", "about", "above", "absent", "absorb", "abstract", "absurd", "abuse"}},
+		{"xyz", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.prefix, func(t *testing.T) {
+			assert.Equal(t, tt.words, bip39.Complete(tt.prefix))
+		})
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	var tests = []*struct {
+		word        string
+		maxDistance int
+		words       []string
+	}{
+		{"abandon", 0, []string{"abandon"}},
+		{"abadnon", 1, []string{"abandon"}},  // transposition
+		{"abandn", 1, []string{"abandon"}},   // deletion
+		{"abanxdon", 1, []string{"abandon"}}, // insertion
+		{"zoa", 1, []string{"zoo"}},
+		{"qqqqqqqq", 2, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			assert.Equal(t, tt.words, bip39.Suggest(tt.word, tt.maxDistance))
+		})
+	}
+}
+
+func TestIsWord(t *testing.T) {
+	assert.True(t, bip39.IsWord("abandon"))
+	assert.False(t, bip39.IsWord("abando"))
+}
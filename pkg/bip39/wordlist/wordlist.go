@@ -1,6 +1,16 @@
 // Package wordlist defines the requirements for a word list used for the bip39 package.
 package wordlist
 
+import (
+	"bufio"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+)
+
 const (
 	// IndexBits is the number of bits used to represent a word index.
 	IndexBits = 11
@@ -8,6 +18,15 @@ const (
 	Count = 1 << IndexBits
 )
 
+var (
+	// ErrInvalidWordCount is returned when a word list does not contain exactly Count words.
+	ErrInvalidWordCount = errors.New("invalid word count")
+	// ErrInvalidWord is returned when a word is empty or contains white space.
+	ErrInvalidWord = errors.New("invalid word")
+	// ErrDuplicateWord is returned when a word is contained more than once.
+	ErrDuplicateWord = errors.New("duplicate word")
+)
+
 // List represents a collection of valid BIP-39 mnemonic words.
 type List interface {
 	// Contains returns whether the given word is contained in the wordList w.
@@ -20,3 +39,76 @@ type List interface {
 	// It panics when the word is not contained in the list.
 	Index(word string) int
 }
+
+type wordList struct {
+	indexes map[string]int
+	words   [Count]string
+}
+
+// New creates a List from the given words in the order of their indices.
+// It returns an error if the number of words is not Count, or if a word is invalid or contained more than once.
+func New(words []string) (List, error) {
+	if l := len(words); l != Count {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidWordCount, l)
+	}
+
+	indexMap := make(map[string]int, Count)
+	for i, word := range words {
+		if word == "" || strings.IndexFunc(word, unicode.IsSpace) >= 0 {
+			return nil, fmt.Errorf("%w: %q at index %d", ErrInvalidWord, word, i)
+		}
+		if _, contains := indexMap[word]; contains {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateWord, word)
+		}
+		indexMap[word] = i
+	}
+
+	w := &wordList{indexes: indexMap}
+	copy(w.words[:], words)
+	return w, nil
+}
+
+// Parse reads a List from r in the format of the BIP-39 word list files, i.e. one word per line.
+// Empty lines are ignored.
+func Parse(r io.Reader) (List, error) {
+	var words []string
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		if line := strings.TrimRight(s.Text(), "\r"); line != "" {
+			words = append(words, line)
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return New(words)
+}
+
+// Checksum returns the SHA-256 hash of the canonical file of l, i.e. each word followed by a newline.
+// It matches the hash of the corresponding word list file of the BIP-39 specification.
+func Checksum(l List) [sha256.Size]byte {
+	h := sha256.New()
+	for i := 0; i < Count; i++ {
+		io.WriteString(h, l.Word(i)+"\n") //nolint:errcheck
+	}
+	var sum [sha256.Size]byte
+	h.Sum(sum[:0])
+	return sum
+}
+
+func (w *wordList) Contains(word string) bool {
+	_, ok := w.indexes[word]
+	return ok
+}
+
+func (w *wordList) Word(i int) string {
+	return w.words[i]
+}
+
+func (w *wordList) Index(word string) int {
+	index, ok := w.indexes[word]
+	if !ok {
+		panic("unknown word")
+	}
+	return index
+}
@@ -0,0 +1,65 @@
+//nolint:scopelint
+package wordlist_test
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip39/internal/wordlists"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip39/wordlist"
+)
+
+func TestChecksum(t *testing.T) {
+	sum := wordlist.Checksum(wordlists.English())
+	// SHA-256 of english.txt of the BIP-39 specification
+	assert.Equal(t, "2f5eed53a4727b4bf8880d8f3f199efc90e58503646d9ff8eff3a2ed3b24dbda", hex.EncodeToString(sum[:]))
+}
+
+func TestParse(t *testing.T) {
+	english := wordlists.English()
+	var b strings.Builder
+	for i := 0; i < wordlist.Count; i++ {
+		b.WriteString(english.Word(i) + "\r\n")
+	}
+
+	l, err := wordlist.Parse(strings.NewReader(b.String()))
+	require.NoError(t, err)
+	assert.Equal(t, wordlist.Checksum(english), wordlist.Checksum(l))
+	assert.True(t, l.Contains("zoo"))
+	assert.Equal(t, 2047, l.Index("zoo"))
+}
+
+func TestNewInvalid(t *testing.T) {
+	words := func(modify func([]string) []string) []string {
+		w := make([]string, wordlist.Count)
+		for i := range w {
+			w[i] = fmt.Sprintf("word%d", i)
+		}
+		return modify(w)
+	}
+	var tests = []*struct {
+		desc  string
+		words []string
+		err   error
+	}{
+		{"too few", words(func(w []string) []string { return w[1:] }), wordlist.ErrInvalidWordCount},
+		{"too many", words(func(w []string) []string { return append(w, "extra") }), wordlist.ErrInvalidWordCount},
+		{"empty", words(func(w []string) []string { w[5] = ""; return w }), wordlist.ErrInvalidWord},
+		{"space", words(func(w []string) []string { w[5] = "two words"; return w }), wordlist.ErrInvalidWord},
+		{"duplicate", words(func(w []string) []string { w[5] = w[4]; return w }), wordlist.ErrDuplicateWord},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			_, err := wordlist.New(tt.words)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+
+	_, err := wordlist.New(words(func(w []string) []string { return w }))
+	assert.NoError(t, err)
+}
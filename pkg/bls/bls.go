@@ -0,0 +1,254 @@
+/*
+Package bls implements BLS signatures on the BLS12-381 curve as specified in the
+IETF draft draft-irtf-cfrg-bls-signature.
+
+It uses the minimal-pubkey-size variant, i.e. public keys are points in G1 and
+signatures are points in G2, together with the proof-of-possession scheme
+protecting aggregated signatures against rogue key attacks. This corresponds to
+the ciphersuite BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_ used by Ethereum.
+Messages are hashed to G2 following RFC 9380.
+
+Private keys are 32-byte big-endian scalars as derived by the eip2333 package.
+
+This package favors simplicity over performance and makes no effort to run in
+constant time. It must not be used to protect actual assets.
+*/
+package bls
+
+import (
+	"bytes"
+	cryptorand "crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+)
+
+const (
+	// PrivateKeySize is the size, in bytes, of private keys as used in this package.
+	PrivateKeySize = 32
+	// PublicKeySize is the size, in bytes, of compressed public keys as used in this package.
+	PublicKeySize = fpSize
+	// SignatureSize is the size, in bytes, of compressed signatures generated and verified by this package.
+	SignatureSize = 2 * fpSize
+)
+
+var (
+	// ErrInvalidKey is returned when a private or public key is invalid.
+	ErrInvalidKey = errors.New("invalid key")
+	// ErrInvalidEncoding is returned when a point cannot be decoded.
+	ErrInvalidEncoding = errors.New("invalid encoding")
+	// ErrInvalidSignature is returned when a signature is invalid.
+	ErrInvalidSignature = errors.New("invalid signature")
+)
+
+var (
+	// dstSignature is the domain separation tag for hashing messages to be signed.
+	dstSignature = []byte("BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_")
+	// dstPOP is the domain separation tag for hashing public keys in proofs of possession.
+	dstPOP = []byte("BLS_POP_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_")
+)
+
+// PrivateKey is the type of BLS private keys.
+type PrivateKey []byte
+
+// PublicKey is the type of BLS public keys.
+type PublicKey []byte
+
+// GenerateKey generates a private key using entropy from rand.
+// If rand is nil, crypto/rand.Reader will be used.
+func GenerateKey(rand io.Reader) (PrivateKey, error) {
+	if rand == nil {
+		rand = cryptorand.Reader
+	}
+	b := make([]byte, PrivateKeySize)
+	for {
+		if _, err := io.ReadFull(rand, b); err != nil {
+			return nil, err
+		}
+		// r has 255 bits, so clearing the top bit rejects less than half of the samples
+		b[0] &= 0x7f
+		if priv, err := NewPrivateKey(b); err == nil {
+			return priv, nil
+		}
+	}
+}
+
+// NewPrivateKey returns the private key corresponding to the big-endian scalar sk.
+// It returns ErrInvalidKey if sk is zero or not smaller than the group order.
+func NewPrivateKey(sk []byte) (PrivateKey, error) {
+	if len(sk) != PrivateKeySize {
+		return nil, fmt.Errorf("%w: invalid length", ErrInvalidKey)
+	}
+	if n := new(big.Int).SetBytes(sk); n.Sign() == 0 || n.Cmp(r) >= 0 {
+		return nil, fmt.Errorf("%w: scalar out of range", ErrInvalidKey)
+	}
+	return append(PrivateKey{}, sk...), nil
+}
+
+func (priv PrivateKey) scalar() *big.Int {
+	if l := len(priv); l != PrivateKeySize {
+		panic("bls: bad private key length: " + strconv.Itoa(l))
+	}
+	return new(big.Int).SetBytes(priv)
+}
+
+// Public returns the PublicKey corresponding to priv.
+func (priv PrivateKey) Public() PublicKey {
+	return encodeG1(g1Generator.mul(priv.scalar()))
+}
+
+// Equal reports whether priv and x have the same value.
+func (priv PrivateKey) Equal(x PrivateKey) bool {
+	return subtle.ConstantTimeCompare(priv, x) == 1
+}
+
+// Equal reports whether pub and x have the same value.
+func (pub PublicKey) Equal(x PublicKey) bool {
+	return bytes.Equal(pub, x)
+}
+
+// ValidatePublicKey checks that pub encodes a point of G1 other than the identity.
+func ValidatePublicKey(pub PublicKey) error {
+	_, err := decodePublicKey(pub)
+	return err
+}
+
+func decodePublicKey(pub PublicKey) (g1Point, error) {
+	a, err := decodeG1(pub)
+	if err != nil {
+		return g1Point{}, fmt.Errorf("%w: %w", ErrInvalidKey, err)
+	}
+	if a.inf || !a.inSubgroup() {
+		return g1Point{}, fmt.Errorf("%w: not in G1", ErrInvalidKey)
+	}
+	return a, nil
+}
+
+func decodeSignature(sig []byte) (g2Point, error) {
+	a, err := decodeG2(sig)
+	if err != nil {
+		return g2Point{}, fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+	}
+	if !a.inSubgroup() {
+		return g2Point{}, fmt.Errorf("%w: not in G2", ErrInvalidSignature)
+	}
+	return a, nil
+}
+
+// Sign signs the message with privateKey and returns a signature.
+// It will panic if len(privateKey) is not PrivateKeySize.
+func Sign(privateKey PrivateKey, message []byte) []byte {
+	return encodeG2(hashToG2(message, dstSignature).mul(privateKey.scalar()))
+}
+
+// Verify reports whether sig is a valid signature of message by publicKey.
+func Verify(publicKey PublicKey, message, sig []byte) bool {
+	a, err := decodePublicKey(publicKey)
+	if err != nil {
+		return false
+	}
+	return verifyPoint(a, hashToG2(message, dstSignature), sig)
+}
+
+// AggregateSignatures combines the signatures into a single signature.
+func AggregateSignatures(sigs [][]byte) ([]byte, error) {
+	if len(sigs) == 0 {
+		return nil, fmt.Errorf("%w: no signatures", ErrInvalidSignature)
+	}
+	res := g2Point{inf: true}
+	for _, sig := range sigs {
+		a, err := decodeSignature(sig)
+		if err != nil {
+			return nil, err
+		}
+		res = res.add(a)
+	}
+	return encodeG2(res), nil
+}
+
+// AggregatePublicKeys combines the public keys into a single public key.
+// The public keys must be accompanied by a valid proof of possession, as checked by VerifyPossession.
+func AggregatePublicKeys(publicKeys []PublicKey) (PublicKey, error) {
+	res, err := aggregatePublicKeys(publicKeys)
+	if err != nil {
+		return nil, err
+	}
+	return encodeG1(res), nil
+}
+
+func aggregatePublicKeys(publicKeys []PublicKey) (g1Point, error) {
+	if len(publicKeys) == 0 {
+		return g1Point{}, fmt.Errorf("%w: no public keys", ErrInvalidKey)
+	}
+	res := g1Point{inf: true}
+	for _, pub := range publicKeys {
+		a, err := decodePublicKey(pub)
+		if err != nil {
+			return g1Point{}, err
+		}
+		res = res.add(a)
+	}
+	return res, nil
+}
+
+// AggregateVerify reports whether the aggregate signature sig is valid for the messages signed by the
+// corresponding public keys.
+func AggregateVerify(publicKeys []PublicKey, messages [][]byte, sig []byte) bool {
+	if len(publicKeys) == 0 || len(publicKeys) != len(messages) {
+		return false
+	}
+	s, err := decodeSignature(sig)
+	if err != nil {
+		return false
+	}
+	ps := make([]g1Point, 0, len(publicKeys)+1)
+	qs := make([]g2Point, 0, len(publicKeys)+1)
+	for i := range publicKeys {
+		a, err := decodePublicKey(publicKeys[i])
+		if err != nil {
+			return false
+		}
+		ps = append(ps, a)
+		qs = append(qs, hashToG2(messages[i], dstSignature))
+	}
+	// e(pk₁, H(m₁))⋯e(pkₙ, H(mₙ)) = e(g1, sig)
+	ps = append(ps, g1Generator.neg())
+	qs = append(qs, s)
+	return pairingCheck(ps, qs)
+}
+
+// FastAggregateVerify reports whether the aggregate signature sig is valid for message signed by all public keys.
+// The public keys must be accompanied by a valid proof of possession, as checked by VerifyPossession.
+func FastAggregateVerify(publicKeys []PublicKey, message, sig []byte) bool {
+	agg, err := aggregatePublicKeys(publicKeys)
+	if err != nil {
+		return false
+	}
+	return verifyPoint(agg, hashToG2(message, dstSignature), sig)
+}
+
+// ProvePossession returns a proof that the signer possesses the private key of its public key.
+func ProvePossession(privateKey PrivateKey) []byte {
+	return encodeG2(hashToG2(privateKey.Public(), dstPOP).mul(privateKey.scalar()))
+}
+
+// VerifyPossession reports whether proof is a valid proof of possession for publicKey.
+func VerifyPossession(publicKey PublicKey, proof []byte) bool {
+	a, err := decodePublicKey(publicKey)
+	if err != nil {
+		return false
+	}
+	return verifyPoint(a, hashToG2(encodeG1(a), dstPOP), proof)
+}
+
+// verifyPoint reports whether sig is a valid signature of the hashed message h by the public key a.
+func verifyPoint(a g1Point, h g2Point, sig []byte) bool {
+	s, err := decodeSignature(sig)
+	if err != nil {
+		return false
+	}
+	return pairingCheck([]g1Point{a, g1Generator.neg()}, []g2Point{h, s})
+}
@@ -0,0 +1,122 @@
+package bls_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/internal/hexutil"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bls"
+	"github.com/iotaledger/iota-crypto-demo/pkg/eip2333"
+)
+
+func TestSign(t *testing.T) {
+	// test vector from the Ethereum consensus specification tests
+	priv, err := bls.NewPrivateKey(hexutil.MustDecodeString("263dbd792f5b1be47ed85f8938c0f29586af0d3ac7b977f21c278fe1462040e3"))
+	require.NoError(t, err)
+	pub := priv.Public()
+	assert.Equal(t, hexutil.MustDecodeString("a491d1b0ecd9bb917989f0e74f0dea0422eac4a873e5e2644f368dffb9a6e20fd6e10c1b77654d067c0618f6e5a7f79a"), []byte(pub))
+
+	msg := make([]byte, 32)
+	sig := bls.Sign(priv, msg)
+	assert.Equal(t, hexutil.MustDecodeString("b6ed936746e01f8ecf281f020953fbf1f01debd5657c4a383940b020b26507f6076334f91e2366c96e9ab279fb5158090352ea1c5b0c9274504f4f0e7053af24802e51e4568d164fe986834f41e55c8e850ce1f98458c0cfc9ab380b55285a55"), sig)
+
+	assert.True(t, bls.Verify(pub, msg, sig))
+	assert.False(t, bls.Verify(pub, []byte("wrong message"), sig))
+	other, err := bls.GenerateKey(nil)
+	require.NoError(t, err)
+	assert.False(t, bls.Verify(other.Public(), msg, sig))
+}
+
+func TestNewPrivateKey(t *testing.T) {
+	var tests = []*struct {
+		sk     []byte
+		expErr error
+	}{
+		{make([]byte, bls.PrivateKeySize), bls.ErrInvalidKey},
+		{make([]byte, bls.PrivateKeySize-1), bls.ErrInvalidKey},
+		{hexutil.MustDecodeString("73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001"), bls.ErrInvalidKey},
+		{hexutil.MustDecodeString("73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000000"), nil},
+	}
+	for _, tt := range tests {
+		_, err := bls.NewPrivateKey(tt.sk)
+		assert.ErrorIs(t, err, tt.expErr)
+	}
+}
+
+func TestValidatePublicKey(t *testing.T) {
+	var tests = []*struct {
+		desc   string
+		pub    []byte
+		expErr error
+	}{
+		{"generator", hexutil.MustDecodeString("97f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb"), nil},
+		{"identity", append([]byte{0xc0}, make([]byte, bls.PublicKeySize-1)...), bls.ErrInvalidKey},
+		{"uncompressed flag", hexutil.MustDecodeString("17f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb"), bls.ErrInvalidEncoding},
+		{"invalid length", make([]byte, bls.PublicKeySize-1), bls.ErrInvalidEncoding},
+		{"not on curve", append(append([]byte{0x80}, make([]byte, bls.PublicKeySize-2)...), 0x01), bls.ErrInvalidEncoding},
+		{"not in G1", append([]byte{0x80}, make([]byte, bls.PublicKeySize-1)...), bls.ErrInvalidKey},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.ErrorIs(t, bls.ValidatePublicKey(tt.pub), tt.expErr)
+		})
+	}
+}
+
+func TestAggregate(t *testing.T) {
+	const n = 3
+	msg := []byte("message")
+
+	pubs := make([]bls.PublicKey, n)
+	msgs := make([][]byte, n)
+	sameMsgSigs := make([][]byte, n)
+	distinctMsgSigs := make([][]byte, n)
+	for i := range pubs {
+		priv, err := bls.GenerateKey(nil)
+		require.NoError(t, err)
+		pubs[i] = priv.Public()
+		msgs[i] = []byte{byte(i)}
+		sameMsgSigs[i] = bls.Sign(priv, msg)
+		distinctMsgSigs[i] = bls.Sign(priv, msgs[i])
+	}
+
+	sig, err := bls.AggregateSignatures(sameMsgSigs)
+	require.NoError(t, err)
+	assert.True(t, bls.FastAggregateVerify(pubs, msg, sig))
+	assert.False(t, bls.FastAggregateVerify(pubs[1:], msg, sig))
+
+	agg, err := bls.AggregatePublicKeys(pubs)
+	require.NoError(t, err)
+	assert.True(t, bls.Verify(agg, msg, sig))
+
+	sig, err = bls.AggregateSignatures(distinctMsgSigs)
+	require.NoError(t, err)
+	assert.True(t, bls.AggregateVerify(pubs, msgs, sig))
+	assert.False(t, bls.AggregateVerify(pubs, append([][]byte{msg}, msgs[1:]...), sig))
+}
+
+func TestProvePossession(t *testing.T) {
+	priv, err := bls.GenerateKey(nil)
+	require.NoError(t, err)
+	other, err := bls.GenerateKey(nil)
+	require.NoError(t, err)
+
+	proof := bls.ProvePossession(priv)
+	assert.True(t, bls.VerifyPossession(priv.Public(), proof))
+	assert.False(t, bls.VerifyPossession(other.Public(), proof))
+	// a proof of possession is not a signature of the public key
+	assert.False(t, bls.VerifyPossession(priv.Public(), bls.Sign(priv, priv.Public())))
+}
+
+func TestDerivedKey(t *testing.T) {
+	seed := hexutil.MustDecodeString("c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04")
+	sk, err := eip2333.DeriveKeyFromPath(seed, eip2333.SigningKeyPath(0))
+	require.NoError(t, err)
+	priv, err := bls.NewPrivateKey(sk)
+	require.NoError(t, err)
+
+	msg := []byte("message")
+	assert.True(t, bls.Verify(priv.Public(), msg, bls.Sign(priv, msg)))
+}
@@ -0,0 +1,127 @@
+package bls
+
+import "math/big"
+
+// r is the prime order of the subgroups G1 and G2.
+var r = mustBigInt("73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001")
+
+// element is the constraint of the fields Fp and Fp2 over which the curves are defined.
+type element[T any] interface {
+	add(T) T
+	sub(T) T
+	mul(T) T
+	square() T
+	neg() T
+	inv() T
+	isZero() bool
+	equal(T) bool
+}
+
+// point is a point in affine coordinates on a short Weierstrass curve y² = x³ + b.
+type point[T element[T]] struct {
+	x, y T
+	inf  bool
+}
+
+type (
+	g1Point = point[fe]
+	g2Point = point[fe2]
+)
+
+var (
+	// b1 is the constant of the curve E: y² = x³ + 4 over Fp.
+	b1 = feFromInt64(4)
+	// b2 is the constant of the twist E': y² = x³ + 4(1+u) over Fp2.
+	b2 = fe2{feFromInt64(4), feFromInt64(4)}
+
+	g1Generator = g1Point{
+		x: mustFe("17f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb"),
+		y: mustFe("08b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e1"),
+	}
+	g2Generator = g2Point{
+		x: fe2{
+			mustFe("024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb8"),
+			mustFe("13e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e"),
+		},
+		y: fe2{
+			mustFe("0ce5d527727d6e118cc9cdc6da2e351aadfd9baa8cbdd3a76d429a695160d12c923ac9cc3baca289e193548608b82801"),
+			mustFe("0606c4a02ea734cc32acd2b02bc28b99cb3e287e85a763af267492ab572e99ab3f370d275cec1da1aaa9075ff05f79be"),
+		},
+	}
+)
+
+func (a point[T]) neg() point[T] {
+	if a.inf {
+		return a
+	}
+	return point[T]{x: a.x, y: a.y.neg()}
+}
+
+func (a point[T]) equal(b point[T]) bool {
+	if a.inf || b.inf {
+		return a.inf == b.inf
+	}
+	return a.x.equal(b.x) && a.y.equal(b.y)
+}
+
+// tangent returns the slope of the tangent at a.
+func (a point[T]) tangent() T {
+	x2 := a.x.square()
+	return x2.add(x2).add(x2).mul(a.y.add(a.y).inv())
+}
+
+// chord returns the slope of the line through a and b.
+func (a point[T]) chord(b point[T]) T {
+	return b.y.sub(a.y).mul(b.x.sub(a.x).inv())
+}
+
+func (a point[T]) double() point[T] {
+	if a.inf || a.y.isZero() {
+		return point[T]{inf: true}
+	}
+	return a.lineThrough(a, a.tangent())
+}
+
+func (a point[T]) add(b point[T]) point[T] {
+	switch {
+	case a.inf:
+		return b
+	case b.inf:
+		return a
+	case a.x.equal(b.x):
+		if a.y.equal(b.y) {
+			return a.double()
+		}
+		return point[T]{inf: true}
+	}
+	return a.lineThrough(b, a.chord(b))
+}
+
+// lineThrough returns the third intersection of the line with slope l through a and b, negated.
+func (a point[T]) lineThrough(b point[T], l T) point[T] {
+	x := l.square().sub(a.x).sub(b.x)
+	y := l.mul(a.x.sub(x)).sub(a.y)
+	return point[T]{x: x, y: y}
+}
+
+// mul returns k·a.
+func (a point[T]) mul(k *big.Int) point[T] {
+	res := point[T]{inf: true}
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		res = res.double()
+		if k.Bit(i) == 1 {
+			res = res.add(a)
+		}
+	}
+	return res
+}
+
+// isOnCurve reports whether a lies on the curve y² = x³ + b.
+func (a point[T]) isOnCurve(b T) bool {
+	return a.inf || a.y.square().equal(a.x.square().mul(a.x).add(b))
+}
+
+// inSubgroup reports whether a is in the subgroup of order r.
+func (a point[T]) inSubgroup() bool {
+	return a.mul(r).inf
+}
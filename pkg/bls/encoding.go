@@ -0,0 +1,124 @@
+package bls
+
+import (
+	"fmt"
+	"math/big"
+)
+
+const (
+	// fpSize is the size, in bytes, of an encoded element of Fp.
+	fpSize = 48
+
+	flagCompressed = 0x80
+	flagInfinity   = 0x40
+	flagLargest    = 0x20
+	flagMask       = flagCompressed | flagInfinity | flagLargest
+)
+
+// encodeFlags returns the flag bits of the compressed encoding of a point.
+func encodeFlags(inf, largest bool) byte {
+	switch {
+	case inf:
+		return flagCompressed | flagInfinity
+	case largest:
+		return flagCompressed | flagLargest
+	}
+	return flagCompressed
+}
+
+// decodeFlags validates the flag bits of a compressed encoding and returns the encoding without them.
+func decodeFlags(b []byte) (inf, largest bool, data []byte, err error) {
+	flags := b[0] & flagMask
+	if flags&flagCompressed == 0 {
+		return false, false, nil, fmt.Errorf("%w: uncompressed encoding", ErrInvalidEncoding)
+	}
+	data = append([]byte{}, b...)
+	data[0] &^= flagMask
+	if flags&flagInfinity != 0 {
+		if flags&flagLargest != 0 || new(big.Int).SetBytes(data).Sign() != 0 {
+			return false, false, nil, fmt.Errorf("%w: non-canonical point at infinity", ErrInvalidEncoding)
+		}
+		return true, false, nil, nil
+	}
+	return false, flags&flagLargest != 0, data, nil
+}
+
+func decodeFe(b []byte) (fe, error) {
+	n := new(big.Int).SetBytes(b)
+	if n.Cmp(p) >= 0 {
+		return fe{}, fmt.Errorf("%w: non-canonical field element", ErrInvalidEncoding)
+	}
+	return fe{n}, nil
+}
+
+// encodeG1 returns the 48-byte compressed encoding of a.
+func encodeG1(a g1Point) []byte {
+	b := make([]byte, fpSize)
+	if !a.inf {
+		a.x.n.FillBytes(b)
+	}
+	b[0] |= encodeFlags(a.inf, !a.inf && a.y.isLargest())
+	return b
+}
+
+// decodeG1 decodes a compressed point on E. It does not check subgroup membership.
+func decodeG1(b []byte) (g1Point, error) {
+	if len(b) != fpSize {
+		return g1Point{}, fmt.Errorf("%w: invalid length", ErrInvalidEncoding)
+	}
+	inf, largest, data, err := decodeFlags(b)
+	if err != nil || inf {
+		return g1Point{inf: inf}, err
+	}
+	x, err := decodeFe(data)
+	if err != nil {
+		return g1Point{}, err
+	}
+	y, ok := x.square().mul(x).add(b1).sqrt()
+	if !ok {
+		return g1Point{}, fmt.Errorf("%w: not on curve", ErrInvalidEncoding)
+	}
+	if y.isLargest() != largest {
+		y = y.neg()
+	}
+	return g1Point{x: x, y: y}, nil
+}
+
+// encodeG2 returns the 96-byte compressed encoding of a, where the coefficient c1 precedes c0.
+func encodeG2(a g2Point) []byte {
+	b := make([]byte, 2*fpSize)
+	if !a.inf {
+		a.x.c1.n.FillBytes(b[:fpSize])
+		a.x.c0.n.FillBytes(b[fpSize:])
+	}
+	b[0] |= encodeFlags(a.inf, !a.inf && a.y.isLargest())
+	return b
+}
+
+// decodeG2 decodes a compressed point on E'. It does not check subgroup membership.
+func decodeG2(b []byte) (g2Point, error) {
+	if len(b) != 2*fpSize {
+		return g2Point{}, fmt.Errorf("%w: invalid length", ErrInvalidEncoding)
+	}
+	inf, largest, data, err := decodeFlags(b)
+	if err != nil || inf {
+		return g2Point{inf: inf}, err
+	}
+	c1, err := decodeFe(data[:fpSize])
+	if err != nil {
+		return g2Point{}, err
+	}
+	c0, err := decodeFe(data[fpSize:])
+	if err != nil {
+		return g2Point{}, err
+	}
+	x := fe2{c0, c1}
+	y, ok := x.square().mul(x).add(b2).sqrt()
+	if !ok {
+		return g2Point{}, fmt.Errorf("%w: not on curve", ErrInvalidEncoding)
+	}
+	if y.isLargest() != largest {
+		y = y.neg()
+	}
+	return g2Point{x: x, y: y}, nil
+}
@@ -0,0 +1,207 @@
+package bls
+
+import (
+	"math/big"
+)
+
+// p is the characteristic of the BLS12-381 base field.
+var p = mustBigInt("1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaaab")
+
+var (
+	// pMinus1Half is (p-1)/2, the largest element that is not lexicographically largest.
+	pMinus1Half = new(big.Int).Rsh(p, 1)
+	// pPlus1Quarter is the exponent computing square roots in Fp, as p ≡ 3 mod 4.
+	pPlus1Quarter = new(big.Int).Rsh(new(big.Int).Add(p, big.NewInt(1)), 2)
+)
+
+func mustBigInt(s string) *big.Int {
+	n, ok := new(big.Int).SetString(s, 16)
+	if !ok {
+		panic("bls: invalid constant " + s)
+	}
+	return n
+}
+
+// fe is an element of Fp. Operations never modify their operands.
+type fe struct{ n *big.Int }
+
+func feFromInt64(x int64) fe {
+	return fe{new(big.Int).Mod(big.NewInt(x), p)}
+}
+
+func mustFe(s string) fe {
+	return fe{mustBigInt(s)}
+}
+
+func (a fe) add(b fe) fe {
+	r := new(big.Int).Add(a.n, b.n)
+	if r.Cmp(p) >= 0 {
+		r.Sub(r, p)
+	}
+	return fe{r}
+}
+
+func (a fe) sub(b fe) fe {
+	r := new(big.Int).Sub(a.n, b.n)
+	if r.Sign() < 0 {
+		r.Add(r, p)
+	}
+	return fe{r}
+}
+
+func (a fe) mul(b fe) fe {
+	r := new(big.Int).Mul(a.n, b.n)
+	return fe{r.Mod(r, p)}
+}
+
+func (a fe) square() fe {
+	return a.mul(a)
+}
+
+func (a fe) neg() fe {
+	if a.isZero() {
+		return a
+	}
+	return fe{new(big.Int).Sub(p, a.n)}
+}
+
+// inv returns the inverse of a, or zero if a is zero.
+func (a fe) inv() fe {
+	if a.isZero() {
+		return a
+	}
+	return fe{new(big.Int).ModInverse(a.n, p)}
+}
+
+func (a fe) isZero() bool {
+	return a.n.Sign() == 0
+}
+
+func (a fe) equal(b fe) bool {
+	return a.n.Cmp(b.n) == 0
+}
+
+// sqrt returns a square root of a and whether a is a square.
+func (a fe) sqrt() (fe, bool) {
+	r := fe{new(big.Int).Exp(a.n, pPlus1Quarter, p)}
+	return r, r.square().equal(a)
+}
+
+// isLargest reports whether a is the lexicographically largest of a and -a.
+func (a fe) isLargest() bool {
+	return a.n.Cmp(pMinus1Half) > 0
+}
+
+func (a fe) sgn0() uint {
+	return a.n.Bit(0)
+}
+
+// fe2 is an element c0 + c1·u of Fp2 = Fp[u]/(u²+1).
+type fe2 struct{ c0, c1 fe }
+
+var (
+	zero2 = fe2{feFromInt64(0), feFromInt64(0)}
+	one2  = fe2{feFromInt64(1), feFromInt64(0)}
+	inv2  = feFromInt64(2).inv()
+)
+
+func (a fe2) add(b fe2) fe2 {
+	return fe2{a.c0.add(b.c0), a.c1.add(b.c1)}
+}
+
+func (a fe2) sub(b fe2) fe2 {
+	return fe2{a.c0.sub(b.c0), a.c1.sub(b.c1)}
+}
+
+func (a fe2) mul(b fe2) fe2 {
+	t0 := a.c0.mul(b.c0)
+	t1 := a.c1.mul(b.c1)
+	t2 := a.c0.add(a.c1).mul(b.c0.add(b.c1))
+	return fe2{t0.sub(t1), t2.sub(t0).sub(t1)}
+}
+
+func (a fe2) mulFp(b fe) fe2 {
+	return fe2{a.c0.mul(b), a.c1.mul(b)}
+}
+
+// mulXi multiplies a by the non-residue ξ = 1 + u.
+func (a fe2) mulXi() fe2 {
+	return fe2{a.c0.sub(a.c1), a.c0.add(a.c1)}
+}
+
+func (a fe2) square() fe2 {
+	return a.mul(a)
+}
+
+func (a fe2) neg() fe2 {
+	return fe2{a.c0.neg(), a.c1.neg()}
+}
+
+func (a fe2) conj() fe2 {
+	return fe2{a.c0, a.c1.neg()}
+}
+
+// inv returns the inverse of a, or zero if a is zero.
+func (a fe2) inv() fe2 {
+	t := a.c0.square().add(a.c1.square()).inv()
+	return fe2{a.c0.mul(t), a.c1.neg().mul(t)}
+}
+
+func (a fe2) isZero() bool {
+	return a.c0.isZero() && a.c1.isZero()
+}
+
+func (a fe2) equal(b fe2) bool {
+	return a.c0.equal(b.c0) && a.c1.equal(b.c1)
+}
+
+func (a fe2) exp(e *big.Int) fe2 {
+	r := one2
+	for i := e.BitLen() - 1; i >= 0; i-- {
+		r = r.square()
+		if e.Bit(i) == 1 {
+			r = r.mul(a)
+		}
+	}
+	return r
+}
+
+// sqrt returns a square root of a and whether a is a square.
+func (a fe2) sqrt() (fe2, bool) {
+	if a.c1.isZero() {
+		if r, ok := a.c0.sqrt(); ok {
+			return fe2{r, a.c1}, true
+		}
+		// -1 is not a square in Fp, so -a0 is a square and (√-a0·u)² = a0
+		r, ok := a.c0.neg().sqrt()
+		return fe2{a.c1, r}, ok
+	}
+	alpha, ok := a.c0.square().add(a.c1.square()).sqrt()
+	if !ok {
+		return fe2{}, false
+	}
+	x0, ok := a.c0.add(alpha).mul(inv2).sqrt()
+	if !ok {
+		if x0, ok = a.c0.sub(alpha).mul(inv2).sqrt(); !ok {
+			return fe2{}, false
+		}
+	}
+	x1 := a.c1.mul(x0.add(x0).inv())
+	return fe2{x0, x1}, true
+}
+
+// isLargest reports whether a is the lexicographically largest of a and -a, comparing c1 first.
+func (a fe2) isLargest() bool {
+	if !a.c1.isZero() {
+		return a.c1.isLargest()
+	}
+	return a.c0.isLargest()
+}
+
+// sgn0 returns the sign of a as defined in RFC 9380.
+func (a fe2) sgn0() uint {
+	if a.c0.isZero() {
+		return a.c1.sgn0()
+	}
+	return a.c0.sgn0()
+}
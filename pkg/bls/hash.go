@@ -0,0 +1,159 @@
+package bls
+
+import (
+	"crypto/sha256"
+	"math/big"
+)
+
+// mustFe2 parses the hexadecimal coefficients of c0 + c1·u.
+func mustFe2(c0, c1 string) fe2 {
+	return fe2{mustFe(c0), mustFe(c1)}
+}
+
+var (
+	// constants of the curve E2': y² = x³ + A'x + B' 3-isogenous to E'
+	sswuA = fe2{feFromInt64(0), feFromInt64(240)}
+	sswuB = fe2{feFromInt64(1012), feFromInt64(1012)}
+	sswuZ = fe2{feFromInt64(-2), feFromInt64(-1)}
+
+	// coefficients of the 3-isogeny map from E2' to E' as specified in RFC 9380, Appendix E.3
+	isoXNum = []fe2{
+		mustFe2("5c759507e8e333ebb5b7a9a47d7ed8532c52d39fd3a042a88b58423c50ae15d5c2638e343d9c71c6238aaaaaaaa97d6", "5c759507e8e333ebb5b7a9a47d7ed8532c52d39fd3a042a88b58423c50ae15d5c2638e343d9c71c6238aaaaaaaa97d6"),
+		mustFe2("0", "11560bf17baa99bc32126fced787c88f984f87adf7ae0c7f9a208c6b4f20a4181472aaa9cb8d555526a9ffffffffc71a"),
+		mustFe2("11560bf17baa99bc32126fced787c88f984f87adf7ae0c7f9a208c6b4f20a4181472aaa9cb8d555526a9ffffffffc71e", "8ab05f8bdd54cde190937e76bc3e447cc27c3d6fbd7063fcd104635a790520c0a395554e5c6aaaa9354ffffffffe38d"),
+		mustFe2("171d6541fa38ccfaed6dea691f5fb614cb14b4e7f4e810aa22d6108f142b85757098e38d0f671c7188e2aaaaaaaa5ed1", "0"),
+	}
+	isoXDen = []fe2{
+		mustFe2("0", "1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaa63"),
+		mustFe2("c", "1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaa9f"),
+		one2,
+	}
+	isoYNum = []fe2{
+		mustFe2("1530477c7ab4113b59a4c18b076d11930f7da5d4a07f649bf54439d87d27e500fc8c25ebf8c92f6812cfc71c71c6d706", "1530477c7ab4113b59a4c18b076d11930f7da5d4a07f649bf54439d87d27e500fc8c25ebf8c92f6812cfc71c71c6d706"),
+		mustFe2("0", "5c759507e8e333ebb5b7a9a47d7ed8532c52d39fd3a042a88b58423c50ae15d5c2638e343d9c71c6238aaaaaaaa97be"),
+		mustFe2("11560bf17baa99bc32126fced787c88f984f87adf7ae0c7f9a208c6b4f20a4181472aaa9cb8d555526a9ffffffffc71c", "8ab05f8bdd54cde190937e76bc3e447cc27c3d6fbd7063fcd104635a790520c0a395554e5c6aaaa9354ffffffffe38f"),
+		mustFe2("124c9ad43b6cf79bfbf7043de3811ad0761b0f37a1e26286b0e977c69aa274524e79097a56dc4bd9e1b371c71c718b10", "0"),
+	}
+	isoYDen = []fe2{
+		mustFe2("1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffa8fb", "1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffa8fb"),
+		mustFe2("0", "1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffa9d3"),
+		mustFe2("12", "1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaa99"),
+		one2,
+	}
+
+	// hEff is the scalar clearing the cofactor of G2 as specified in RFC 9380, Section 8.8.2.
+	hEff = mustBigInt("bc69f08f2ee75b3584c6a0ea91b352888e2a8e9145ad7689986ff031508ffe1329c2f178731db956d82bf015d1212b02ec0ec69d7477c1ae954cbc06689f6a359894c0adebbf6b4e8020005aaa95551")
+)
+
+const (
+	// hashBlockSize is the input block size, in bytes, of SHA-256.
+	hashBlockSize = 64
+	// fieldElementLength is the number of bytes L hashed to one element of Fp.
+	fieldElementLength = 64
+)
+
+// expandMessageXMD implements expand_message_xmd of RFC 9380 with SHA-256.
+func expandMessageXMD(msg, dst []byte, length int) []byte {
+	if len(dst) > 255 {
+		h := sha256.Sum256(append([]byte("H2C-OVERSIZE-DST-"), dst...))
+		dst = h[:]
+	}
+	dstPrime := append(append([]byte{}, dst...), byte(len(dst)))
+	ell := (length + sha256.Size - 1) / sha256.Size
+	if ell > 255 {
+		panic("bls: requested length too large")
+	}
+
+	h := sha256.New()
+	h.Write(make([]byte, hashBlockSize))
+	h.Write(msg)
+	h.Write([]byte{byte(length >> 8), byte(length), 0})
+	h.Write(dstPrime)
+	b0 := h.Sum(nil)
+
+	res := make([]byte, 0, ell*sha256.Size)
+	bi := make([]byte, sha256.Size)
+	for i := 1; i <= ell; i++ {
+		for j := range bi {
+			bi[j] ^= b0[j]
+		}
+		h.Reset()
+		h.Write(bi)
+		h.Write([]byte{byte(i)})
+		h.Write(dstPrime)
+		bi = h.Sum(bi[:0])
+		res = append(res, bi...)
+	}
+	return res[:length]
+}
+
+// hashToField hashes msg to count elements of Fp2.
+func hashToField(msg, dst []byte, count int) []fe2 {
+	uniform := expandMessageXMD(msg, dst, count*2*fieldElementLength)
+	res := make([]fe2, count)
+	for i := range res {
+		var c [2]fe
+		for j := range c {
+			offset := (2*i + j) * fieldElementLength
+			n := new(big.Int).SetBytes(uniform[offset : offset+fieldElementLength])
+			c[j] = fe{n.Mod(n, p)}
+		}
+		res[i] = fe2{c[0], c[1]}
+	}
+	return res
+}
+
+// mapToCurveSSWU maps u to a point on E2' using the simplified Shallue-van de Woestijne-Ulas method.
+func mapToCurveSSWU(u fe2) g2Point {
+	g := func(x fe2) fe2 { return x.square().add(sswuA).mul(x).add(sswuB) }
+
+	zu2 := sswuZ.mul(u.square())
+	tv1 := zu2.square().add(zu2).inv()
+	var x1 fe2
+	if tv1.isZero() {
+		x1 = sswuB.mul(sswuZ.mul(sswuA).inv())
+	} else {
+		x1 = sswuB.neg().mul(sswuA.inv()).mul(one2.add(tv1))
+	}
+	x, y := x1, fe2{}
+	if y1, ok := g(x1).sqrt(); ok {
+		y = y1
+	} else {
+		x = zu2.mul(x1)
+		y, _ = g(x).sqrt()
+	}
+	if u.sgn0() != y.sgn0() {
+		y = y.neg()
+	}
+	return g2Point{x: x, y: y}
+}
+
+// evalPolynomial evaluates the polynomial with the coefficients in ascending order at x.
+func evalPolynomial(coeffs []fe2, x fe2) fe2 {
+	res := coeffs[len(coeffs)-1]
+	for i := len(coeffs) - 2; i >= 0; i-- {
+		res = res.mul(x).add(coeffs[i])
+	}
+	return res
+}
+
+// isoMap maps a point on E2' to E' using the 3-isogeny.
+func isoMap(a g2Point) g2Point {
+	xDen := evalPolynomial(isoXDen, a.x)
+	yDen := evalPolynomial(isoYDen, a.x)
+	if a.inf || xDen.isZero() || yDen.isZero() {
+		return g2Point{inf: true}
+	}
+	return g2Point{
+		x: evalPolynomial(isoXNum, a.x).mul(xDen.inv()),
+		y: a.y.mul(evalPolynomial(isoYNum, a.x)).mul(yDen.inv()),
+	}
+}
+
+// hashToG2 hashes msg to a point in G2 following hash_to_curve of the suite BLS12381G2_XMD:SHA-256_SSWU_RO_.
+func hashToG2(msg, dst []byte) g2Point {
+	u := hashToField(msg, dst, 2)
+	q0 := isoMap(mapToCurveSSWU(u[0]))
+	q1 := isoMap(mapToCurveSSWU(u[1]))
+	return q0.add(q1).mul(hEff)
+}
@@ -0,0 +1,59 @@
+package bls
+
+import "math/big"
+
+var (
+	// blsX is the absolute value of the negative curve parameter x = -0xd201000000010000.
+	blsX = mustBigInt("d201000000010000")
+	// hardExponent is the hard part (p⁴-p²+1)/r of the final exponentiation.
+	hardExponent = func() *big.Int {
+		p2 := new(big.Int).Mul(p, p)
+		e := new(big.Int).Mul(p2, p2)
+		e.Sub(e, p2).Add(e, big.NewInt(1))
+		return e.Div(e, r)
+	}()
+)
+
+// line evaluates the line with slope l through t ∈ E' at p ∈ E after untwisting.
+// The result is scaled by w³, which lies in a proper subfield and vanishes in the final exponentiation.
+func line(t g2Point, l fe2, p g1Point) fe12 {
+	return fe12{
+		fe6{l.mul(t.x).sub(t.y), l.mulFp(p.x).neg(), zero2},
+		fe6{zero2, fe2{p.y, feFromInt64(0)}, zero2},
+	}
+}
+
+// millerLoop computes the Miller loop of the optimal ate pairing of p and q.
+func millerLoop(p g1Point, q g2Point) fe12 {
+	if p.inf || q.inf {
+		return one12
+	}
+	f, t := one12, q
+	for i := blsX.BitLen() - 2; i >= 0; i-- {
+		f = f.square().mul(line(t, t.tangent(), p))
+		t = t.double()
+		if blsX.Bit(i) == 1 {
+			f = f.mul(line(t, t.chord(q), p))
+			t = t.add(q)
+		}
+	}
+	// x is negative
+	return f.conj()
+}
+
+// finalExponentiation raises f to the power (p¹²-1)/r.
+func finalExponentiation(f fe12) fe12 {
+	// easy part: f^((p⁶-1)(p²+1))
+	f = f.conj().mul(f.inv())
+	f = f.frobenius().frobenius().mul(f)
+	return f.exp(hardExponent)
+}
+
+// pairingCheck reports whether the product of the pairings e(pᵢ, qᵢ) is one.
+func pairingCheck(ps []g1Point, qs []g2Point) bool {
+	f := one12
+	for i := range ps {
+		f = f.mul(millerLoop(ps[i], qs[i]))
+	}
+	return finalExponentiation(f).equal(one12)
+}
@@ -0,0 +1,57 @@
+package bls
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/internal/hexutil"
+)
+
+func TestGenerators(t *testing.T) {
+	require.True(t, g1Generator.isOnCurve(b1))
+	require.True(t, g2Generator.isOnCurve(b2))
+	assert.True(t, g1Generator.inSubgroup())
+	assert.True(t, g2Generator.inSubgroup())
+}
+
+func TestPairingBilinearity(t *testing.T) {
+	a, b := big.NewInt(0xdeadbeef), big.NewInt(0x1337)
+	e := finalExponentiation(millerLoop(g1Generator, g2Generator))
+	require.False(t, e.equal(one12))
+	assert.True(t, e.exp(r).equal(one12))
+
+	ab := new(big.Int).Mul(a, b)
+	assert.True(t, finalExponentiation(millerLoop(g1Generator.mul(a), g2Generator.mul(b))).equal(e.exp(ab)))
+	assert.True(t, pairingCheck([]g1Point{g1Generator.mul(ab), g1Generator.neg()}, []g2Point{g2Generator, g2Generator.mul(ab)}))
+}
+
+func TestHashToG2(t *testing.T) {
+	for _, msg := range []string{"", "abc", "abcdef0123456789"} {
+		u := hashToField([]byte(msg), []byte("QUUX-V01-CS02-with-BLS12381G2_XMD:SHA-256_SSWU_RO_"), 2)
+		for _, e := range u {
+			q := mapToCurveSSWU(e)
+			require.True(t, q.isOnCurve(sswuB.add(sswuA.mul(q.x))))
+			require.True(t, isoMap(q).isOnCurve(b2))
+		}
+		h := hashToG2([]byte(msg), []byte("QUUX-V01-CS02-with-BLS12381G2_XMD:SHA-256_SSWU_RO_"))
+		require.True(t, h.isOnCurve(b2))
+		assert.True(t, h.inSubgroup())
+	}
+}
+
+func TestHashToG2Vectors(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-BLS12381G2_XMD:SHA-256_SSWU_RO_")
+	// test vectors from RFC 9380, Appendix J.10.1
+	h := hashToG2(nil, dst)
+	assert.Equal(t, "141ebfbdca40eb85b87142e130ab689c673cf60f1a3e98d69335266f30d9b8d4ac44c1038e9dcdd5393faf5c41fb78a", h.x.c0.n.Text(16))
+	assert.Equal(t, "5cb8437535e20ecffaef7752baddf98034139c38452458baeefab379ba13dff5bf5dd71b72418717047f5b0f37da03d", h.x.c1.n.Text(16))
+}
+
+func TestExpandMessageXMD(t *testing.T) {
+	// test vector from RFC 9380, Appendix K.1
+	uniform := expandMessageXMD(nil, []byte("QUUX-V01-CS02-with-expander-SHA256-128"), 0x20)
+	assert.Equal(t, hexutil.MustDecodeString("68a985b87eb6b46952128911f2a4412bbc302a9d759667f87f7a21d803f07235"), uniform)
+}
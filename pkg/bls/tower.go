@@ -0,0 +1,109 @@
+package bls
+
+import "math/big"
+
+// fe6 is an element c0 + c1·v + c2·v² of Fp6 = Fp2[v]/(v³-ξ).
+type fe6 struct{ c0, c1, c2 fe2 }
+
+var zero6 = fe6{zero2, zero2, zero2}
+
+func (a fe6) add(b fe6) fe6 {
+	return fe6{a.c0.add(b.c0), a.c1.add(b.c1), a.c2.add(b.c2)}
+}
+
+func (a fe6) sub(b fe6) fe6 {
+	return fe6{a.c0.sub(b.c0), a.c1.sub(b.c1), a.c2.sub(b.c2)}
+}
+
+func (a fe6) neg() fe6 {
+	return fe6{a.c0.neg(), a.c1.neg(), a.c2.neg()}
+}
+
+func (a fe6) mul(b fe6) fe6 {
+	t0 := a.c0.mul(b.c0)
+	t1 := a.c1.mul(b.c1)
+	t2 := a.c2.mul(b.c2)
+	c0 := a.c1.add(a.c2).mul(b.c1.add(b.c2)).sub(t1).sub(t2).mulXi().add(t0)
+	c1 := a.c0.add(a.c1).mul(b.c0.add(b.c1)).sub(t0).sub(t1).add(t2.mulXi())
+	c2 := a.c0.add(a.c2).mul(b.c0.add(b.c2)).sub(t0).sub(t2).add(t1)
+	return fe6{c0, c1, c2}
+}
+
+// mulV multiplies a by v.
+func (a fe6) mulV() fe6 {
+	return fe6{a.c2.mulXi(), a.c0, a.c1}
+}
+
+func (a fe6) inv() fe6 {
+	t0 := a.c0.square().sub(a.c1.mul(a.c2).mulXi())
+	t1 := a.c2.square().mulXi().sub(a.c0.mul(a.c1))
+	t2 := a.c1.square().sub(a.c0.mul(a.c2))
+	d := a.c0.mul(t0).add(a.c2.mul(t1).add(a.c1.mul(t2)).mulXi()).inv()
+	return fe6{t0.mul(d), t1.mul(d), t2.mul(d)}
+}
+
+func (a fe6) equal(b fe6) bool {
+	return a.c0.equal(b.c0) && a.c1.equal(b.c1) && a.c2.equal(b.c2)
+}
+
+// fe12 is an element c0 + c1·w of Fp12 = Fp6[w]/(w²-v).
+type fe12 struct{ c0, c1 fe6 }
+
+var one12 = fe12{fe6{one2, zero2, zero2}, zero6}
+
+// frobeniusCoeffs contains γₖ = ξ^(k(p-1)/6), so that (wᵏ)ᵖ = γₖ·wᵏ.
+var frobeniusCoeffs = func() (res [6]fe2) {
+	e := new(big.Int).Sub(p, big.NewInt(1))
+	e.Div(e, big.NewInt(6))
+	gamma := one2.mulXi().exp(e)
+	res[0] = one2
+	for k := 1; k < len(res); k++ {
+		res[k] = res[k-1].mul(gamma)
+	}
+	return res
+}()
+
+func (a fe12) mul(b fe12) fe12 {
+	t0 := a.c0.mul(b.c0)
+	t1 := a.c1.mul(b.c1)
+	c1 := a.c0.add(a.c1).mul(b.c0.add(b.c1)).sub(t0).sub(t1)
+	return fe12{t0.add(t1.mulV()), c1}
+}
+
+func (a fe12) square() fe12 {
+	return a.mul(a)
+}
+
+// conj returns the conjugate a^(p⁶) of a.
+func (a fe12) conj() fe12 {
+	return fe12{a.c0, a.c1.neg()}
+}
+
+func (a fe12) inv() fe12 {
+	t := a.c0.mul(a.c0).sub(a.c1.mul(a.c1).mulV()).inv()
+	return fe12{a.c0.mul(t), a.c1.mul(t).neg()}
+}
+
+// frobenius returns a^p.
+func (a fe12) frobenius() fe12 {
+	// the coefficient of vⁱ·wʲ belongs to w^(2i+j)
+	return fe12{
+		fe6{a.c0.c0.conj(), a.c0.c1.conj().mul(frobeniusCoeffs[2]), a.c0.c2.conj().mul(frobeniusCoeffs[4])},
+		fe6{a.c1.c0.conj().mul(frobeniusCoeffs[1]), a.c1.c1.conj().mul(frobeniusCoeffs[3]), a.c1.c2.conj().mul(frobeniusCoeffs[5])},
+	}
+}
+
+func (a fe12) exp(e *big.Int) fe12 {
+	r := one12
+	for i := e.BitLen() - 1; i >= 0; i-- {
+		r = r.square()
+		if e.Bit(i) == 1 {
+			r = r.mul(a)
+		}
+	}
+	return r
+}
+
+func (a fe12) equal(b fe12) bool {
+	return a.c0.equal(b.c0) && a.c1.equal(b.c1)
+}
@@ -0,0 +1,339 @@
+/*
+Package cbor implements the subset of CBOR (RFC 8949) needed to exchange keys, paths, addresses and signed messages
+with protocols using CBOR instead of JSON.
+
+Encoding always produces the core deterministic encoding of RFC 8949, section 4.2.1: all arguments use their shortest
+form, only definite lengths are used and map keys are sorted by the bytewise lexicographic order of their encodings.
+The Decoder only accepts this canonical form, so that every value has exactly one valid encoding.
+
+Only unsigned integers, byte strings, text strings, arrays, maps and the simple values false and true are supported.
+*/
+package cbor
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+	"unicode/utf8"
+)
+
+// Major types of CBOR data items.
+const (
+	majorUnsigned byte = 0
+	majorNegative byte = 1
+	majorBytes    byte = 2
+	majorText     byte = 3
+	majorArray    byte = 4
+	majorMap      byte = 5
+	majorTag      byte = 6
+	majorSimple   byte = 7
+)
+
+// Simple values false and true.
+const (
+	simpleFalse = 20
+	simpleTrue  = 21
+)
+
+// maxNesting limits the depth of nested arrays and maps when skipping data items.
+const maxNesting = 32
+
+var (
+	// ErrUnexpectedEnd is returned when the data ends in the middle of a data item.
+	ErrUnexpectedEnd = errors.New("unexpected end of data")
+	// ErrUnexpectedType is returned when a data item does not have the expected major type.
+	ErrUnexpectedType = errors.New("unexpected type")
+	// ErrNotCanonical is returned when the data does not use the core deterministic encoding.
+	ErrNotCanonical = errors.New("not canonical")
+	// ErrTrailingData is returned when data remains after the last expected data item.
+	ErrTrailingData = errors.New("trailing data")
+	// ErrInvalidUTF8 is returned when a text string is not valid UTF-8.
+	ErrInvalidUTF8 = errors.New("invalid UTF-8")
+	// ErrUnsupported is returned for valid CBOR that is not supported by this package.
+	ErrUnsupported = errors.New("unsupported")
+)
+
+// Marshaler is implemented by types that can marshal themselves into canonical CBOR.
+type Marshaler interface {
+	MarshalCBOR() ([]byte, error)
+}
+
+// Unmarshaler is implemented by types that can unmarshal a canonical CBOR encoding of themselves.
+type Unmarshaler interface {
+	UnmarshalCBOR([]byte) error
+}
+
+// appendHead appends the head of a data item, i.e. the major type and its argument in the shortest form.
+func appendHead(b []byte, major byte, arg uint64) []byte {
+	major <<= 5
+	switch {
+	case arg < 24:
+		return append(b, major|byte(arg))
+	case arg <= 0xff:
+		return append(b, major|24, byte(arg))
+	case arg <= 0xffff:
+		return binary.BigEndian.AppendUint16(append(b, major|25), uint16(arg))
+	case arg <= 0xffffffff:
+		return binary.BigEndian.AppendUint32(append(b, major|26), uint32(arg))
+	default:
+		return binary.BigEndian.AppendUint64(append(b, major|27), arg)
+	}
+}
+
+// AppendUint appends the encoding of the unsigned integer v to b.
+func AppendUint(b []byte, v uint64) []byte {
+	return appendHead(b, majorUnsigned, v)
+}
+
+// AppendBytes appends the encoding of the byte string v to b.
+func AppendBytes(b []byte, v []byte) []byte {
+	return append(appendHead(b, majorBytes, uint64(len(v))), v...)
+}
+
+// AppendText appends the encoding of the text string s to b.
+func AppendText(b []byte, s string) []byte {
+	return append(appendHead(b, majorText, uint64(len(s))), s...)
+}
+
+// AppendBool appends the encoding of the simple value false or true to b.
+func AppendBool(b []byte, v bool) []byte {
+	if v {
+		return appendHead(b, majorSimple, simpleTrue)
+	}
+	return appendHead(b, majorSimple, simpleFalse)
+}
+
+// AppendArray appends the head of an array with n elements to b, which must be followed by the encoded elements.
+func AppendArray(b []byte, n int) []byte {
+	return appendHead(b, majorArray, uint64(n))
+}
+
+// Entry is a map entry consisting of the encoded key and the encoded value.
+type Entry struct {
+	Key, Value []byte
+}
+
+// AppendMap appends the encoding of the map with the given entries to b.
+// The entries are sorted by their keys, which must be unique.
+func AppendMap(b []byte, entries ...Entry) []byte {
+	sorted := append([]Entry{}, entries...)
+	sort.Slice(sorted, func(i, j int) bool { return bytes.Compare(sorted[i].Key, sorted[j].Key) < 0 })
+	b = appendHead(b, majorMap, uint64(len(sorted)))
+	for i, e := range sorted {
+		if i > 0 && bytes.Equal(sorted[i-1].Key, e.Key) {
+			panic("cbor: duplicate map key")
+		}
+		b = append(b, e.Key...)
+		b = append(b, e.Value...)
+	}
+	return b
+}
+
+// UintKey returns the encoding of v for use as map key.
+func UintKey(v uint64) []byte {
+	return AppendUint(nil, v)
+}
+
+// TextKey returns the encoding of s for use as map key.
+func TextKey(s string) []byte {
+	return AppendText(nil, s)
+}
+
+// Decoder reads canonical CBOR data items one after the other.
+type Decoder struct {
+	data []byte
+	off  int
+}
+
+// NewDecoder returns a Decoder reading from data.
+func NewDecoder(data []byte) *Decoder {
+	return &Decoder{data: data}
+}
+
+// Finish returns ErrTrailingData if not all the data has been read.
+func (d *Decoder) Finish() error {
+	if d.off != len(d.data) {
+		return fmt.Errorf("%w: %d bytes", ErrTrailingData, len(d.data)-d.off)
+	}
+	return nil
+}
+
+// head reads the head of the next data item and checks that its argument uses the shortest form.
+func (d *Decoder) head() (byte, uint64, error) {
+	if d.off >= len(d.data) {
+		return 0, 0, ErrUnexpectedEnd
+	}
+	major, info := d.data[d.off]>>5, d.data[d.off]&0x1f
+	d.off++
+	if info < 24 {
+		return major, uint64(info), nil
+	}
+	if info > 27 {
+		return 0, 0, fmt.Errorf("%w: indefinite length or reserved value", ErrUnsupported)
+	}
+	n := 1 << (info - 24)
+	if len(d.data)-d.off < n {
+		return 0, 0, ErrUnexpectedEnd
+	}
+	var arg, limit uint64
+	switch n {
+	case 1:
+		arg, limit = uint64(d.data[d.off]), 24
+	case 2:
+		arg, limit = uint64(binary.BigEndian.Uint16(d.data[d.off:])), 0xff
+	case 4:
+		arg, limit = uint64(binary.BigEndian.Uint32(d.data[d.off:])), 0xffff
+	default:
+		arg, limit = binary.BigEndian.Uint64(d.data[d.off:]), 0xffffffff
+	}
+	d.off += n
+	// the one byte argument is only valid for values of at least 24, all others must exceed the shorter form
+	if (n == 1 && arg < limit) || (n > 1 && arg <= limit) {
+		return 0, 0, fmt.Errorf("%w: argument %d not in shortest form", ErrNotCanonical, arg)
+	}
+	return major, arg, nil
+}
+
+func (d *Decoder) expect(major byte) (uint64, error) {
+	m, arg, err := d.head()
+	if err != nil {
+		return 0, err
+	}
+	if m != major {
+		return 0, fmt.Errorf("%w: major type %d instead of %d", ErrUnexpectedType, m, major)
+	}
+	return arg, nil
+}
+
+// Uint reads an unsigned integer.
+func (d *Decoder) Uint() (uint64, error) {
+	return d.expect(majorUnsigned)
+}
+
+func (d *Decoder) content(major byte) ([]byte, error) {
+	n, err := d.expect(major)
+	if err != nil {
+		return nil, err
+	}
+	if n > uint64(len(d.data)-d.off) {
+		return nil, ErrUnexpectedEnd
+	}
+	v := d.data[d.off : d.off+int(n)]
+	d.off += int(n)
+	return v, nil
+}
+
+// Bytes reads a byte string. The returned slice is a copy of the data.
+func (d *Decoder) Bytes() ([]byte, error) {
+	v, err := d.content(majorBytes)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{}, v...), nil
+}
+
+// Text reads a text string.
+func (d *Decoder) Text() (string, error) {
+	v, err := d.content(majorText)
+	if err != nil {
+		return "", err
+	}
+	if !utf8.Valid(v) {
+		return "", ErrInvalidUTF8
+	}
+	return string(v), nil
+}
+
+// Bool reads one of the simple values false or true.
+func (d *Decoder) Bool() (bool, error) {
+	v, err := d.expect(majorSimple)
+	if err != nil {
+		return false, err
+	}
+	switch v {
+	case simpleFalse:
+		return false, nil
+	case simpleTrue:
+		return true, nil
+	default:
+		return false, fmt.Errorf("%w: simple value %d", ErrUnsupported, v)
+	}
+}
+
+// Array reads the head of an array and returns the number of its elements, which must be read next.
+func (d *Decoder) Array() (int, error) {
+	n, err := d.expect(majorArray)
+	if err != nil {
+		return 0, err
+	}
+	// every element takes at least one byte
+	if n > uint64(len(d.data)-d.off) {
+		return 0, ErrUnexpectedEnd
+	}
+	return int(n), nil
+}
+
+// Map reads a complete map and returns its entries in their encoded form.
+// It checks that the keys are unique and sorted as required by the core deterministic encoding.
+func (d *Decoder) Map() ([]Entry, error) {
+	n, err := d.expect(majorMap)
+	if err != nil {
+		return nil, err
+	}
+	// every entry takes at least two bytes
+	if n > uint64(len(d.data)-d.off)/2 {
+		return nil, ErrUnexpectedEnd
+	}
+	entries := make([]Entry, n)
+	for i := range entries {
+		if entries[i].Key, err = d.raw(0); err != nil {
+			return nil, err
+		}
+		if i > 0 && bytes.Compare(entries[i-1].Key, entries[i].Key) >= 0 {
+			return nil, fmt.Errorf("%w: map keys not sorted or not unique", ErrNotCanonical)
+		}
+		if entries[i].Value, err = d.raw(0); err != nil {
+			return nil, err
+		}
+	}
+	return entries, nil
+}
+
+// raw reads the next complete data item and returns its encoding.
+func (d *Decoder) raw(depth int) ([]byte, error) {
+	if depth > maxNesting {
+		return nil, fmt.Errorf("%w: nesting too deep", ErrUnsupported)
+	}
+	start := d.off
+	major, arg, err := d.head()
+	if err != nil {
+		return nil, err
+	}
+	switch major {
+	case majorUnsigned, majorNegative, majorSimple:
+	case majorBytes, majorText:
+		if arg > uint64(len(d.data)-d.off) {
+			return nil, ErrUnexpectedEnd
+		}
+		d.off += int(arg)
+	case majorArray, majorMap:
+		if major == majorMap {
+			arg *= 2
+		}
+		if arg > uint64(len(d.data)-d.off) {
+			return nil, ErrUnexpectedEnd
+		}
+		for i := uint64(0); i < arg; i++ {
+			if _, err := d.raw(depth + 1); err != nil {
+				return nil, err
+			}
+		}
+	case majorTag:
+		if _, err := d.raw(depth + 1); err != nil {
+			return nil, err
+		}
+	}
+	return d.data[start:d.off], nil
+}
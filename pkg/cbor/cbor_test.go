@@ -0,0 +1,192 @@
+//nolint:scopelint // from tests
+package cbor_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/cbor"
+)
+
+// examples of RFC 8949, appendix A
+var uintTests = []*struct {
+	v   uint64
+	hex string
+}{
+	{0, "00"},
+	{1, "01"},
+	{10, "0a"},
+	{23, "17"},
+	{24, "1818"},
+	{25, "1819"},
+	{100, "1864"},
+	{1000, "1903e8"},
+	{1000000, "1a000f4240"},
+	{1000000000000, "1b000000e8d4a51000"},
+	{18446744073709551615, "1bffffffffffffffff"},
+}
+
+func TestUint(t *testing.T) {
+	for _, tt := range uintTests {
+		t.Run(tt.hex, func(t *testing.T) {
+			b := cbor.AppendUint(nil, tt.v)
+			assert.Equal(t, tt.hex, hex.EncodeToString(b))
+
+			d := cbor.NewDecoder(b)
+			v, err := d.Uint()
+			require.NoError(t, err)
+			assert.Equal(t, tt.v, v)
+			assert.NoError(t, d.Finish())
+		})
+	}
+}
+
+var stringTests = []*struct {
+	text bool
+	v    string
+	hex  string
+}{
+	{false, "", "40"},
+	{false, "\x01\x02\x03\x04", "4401020304"},
+	{true, "", "60"},
+	{true, "a", "6161"},
+	{true, "IETF", "6449455446"},
+	{true, "\"\\", "62225c"},
+	{true, "ü", "62c3bc"},
+	{true, "水", "63e6b0b4"},
+	{true, "\U00010151", "64f0908591"},
+}
+
+func TestString(t *testing.T) {
+	for _, tt := range stringTests {
+		t.Run(tt.hex, func(t *testing.T) {
+			var b []byte
+			if tt.text {
+				b = cbor.AppendText(nil, tt.v)
+			} else {
+				b = cbor.AppendBytes(nil, []byte(tt.v))
+			}
+			assert.Equal(t, tt.hex, hex.EncodeToString(b))
+
+			d := cbor.NewDecoder(b)
+			if tt.text {
+				v, err := d.Text()
+				require.NoError(t, err)
+				assert.Equal(t, tt.v, v)
+			} else {
+				v, err := d.Bytes()
+				require.NoError(t, err)
+				assert.Equal(t, []byte(tt.v), v)
+			}
+			assert.NoError(t, d.Finish())
+		})
+	}
+}
+
+func TestArray(t *testing.T) {
+	// [1, [2, 3], [4, 5]]
+	b := cbor.AppendArray(nil, 3)
+	b = cbor.AppendUint(b, 1)
+	b = cbor.AppendUint(cbor.AppendUint(cbor.AppendArray(b, 2), 2), 3)
+	b = cbor.AppendUint(cbor.AppendUint(cbor.AppendArray(b, 2), 4), 5)
+	assert.Equal(t, "8301820203820405", hex.EncodeToString(b))
+
+	d := cbor.NewDecoder(b)
+	n, err := d.Array()
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+	v, err := d.Uint()
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, v)
+}
+
+func TestBool(t *testing.T) {
+	assert.Equal(t, []byte{0xf4}, cbor.AppendBool(nil, false))
+	assert.Equal(t, []byte{0xf5}, cbor.AppendBool(nil, true))
+
+	v, err := cbor.NewDecoder([]byte{0xf5}).Bool()
+	require.NoError(t, err)
+	assert.True(t, v)
+	// null is not supported
+	_, err = cbor.NewDecoder([]byte{0xf6}).Bool()
+	assert.ErrorIs(t, err, cbor.ErrUnsupported)
+}
+
+func TestMap(t *testing.T) {
+	// {"a": 1, "b": [2, 3]} with the entries given in reverse order
+	b := cbor.AppendMap(nil,
+		cbor.Entry{Key: cbor.TextKey("b"), Value: cbor.AppendUint(cbor.AppendUint(cbor.AppendArray(nil, 2), 2), 3)},
+		cbor.Entry{Key: cbor.TextKey("a"), Value: cbor.AppendUint(nil, 1)},
+	)
+	assert.Equal(t, "a26161016162820203", hex.EncodeToString(b))
+
+	entries, err := cbor.NewDecoder(b).Map()
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, cbor.TextKey("a"), entries[0].Key)
+	assert.Equal(t, []byte{0x82, 0x02, 0x03}, entries[1].Value)
+
+	// integer keys sort before text keys and shorter encodings before longer ones
+	b = cbor.AppendMap(nil,
+		cbor.Entry{Key: cbor.TextKey("a"), Value: cbor.AppendUint(nil, 0)},
+		cbor.Entry{Key: cbor.UintKey(100), Value: cbor.AppendUint(nil, 0)},
+		cbor.Entry{Key: cbor.UintKey(10), Value: cbor.AppendUint(nil, 0)},
+	)
+	assert.Equal(t, "a30a00186400616100", hex.EncodeToString(b))
+}
+
+var invalidTests = []*struct {
+	hex string
+	err error
+}{
+	{"", cbor.ErrUnexpectedEnd},
+	{"19", cbor.ErrUnexpectedEnd},
+	{"1817", cbor.ErrNotCanonical},
+	{"1900ff", cbor.ErrNotCanonical},
+	{"1a0000ffff", cbor.ErrNotCanonical},
+	{"1b00000000ffffffff", cbor.ErrNotCanonical},
+	{"1c", cbor.ErrUnsupported},
+	{"1f", cbor.ErrUnsupported},
+	{"0000", cbor.ErrTrailingData},
+	{"20", cbor.ErrUnexpectedType},
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	for _, tt := range invalidTests {
+		t.Run(tt.hex, func(t *testing.T) {
+			b, err := hex.DecodeString(tt.hex)
+			require.NoError(t, err)
+
+			d := cbor.NewDecoder(b)
+			_, err = d.Uint()
+			if err == nil {
+				err = d.Finish()
+			}
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestDecodeInvalidContainers(t *testing.T) {
+	// indefinite length byte string
+	_, err := cbor.NewDecoder([]byte{0x5f, 0x41, 0x00, 0xff}).Bytes()
+	assert.ErrorIs(t, err, cbor.ErrUnsupported)
+	// truncated byte string
+	_, err = cbor.NewDecoder([]byte{0x44, 0x01, 0x02}).Bytes()
+	assert.ErrorIs(t, err, cbor.ErrUnexpectedEnd)
+	// invalid UTF-8
+	_, err = cbor.NewDecoder([]byte{0x62, 0xc3, 0x28}).Text()
+	assert.ErrorIs(t, err, cbor.ErrInvalidUTF8)
+	// unsorted map keys
+	_, err = cbor.NewDecoder([]byte{0xa2, 0x02, 0x00, 0x01, 0x00}).Map()
+	assert.ErrorIs(t, err, cbor.ErrNotCanonical)
+	// duplicate map keys
+	_, err = cbor.NewDecoder([]byte{0xa2, 0x01, 0x00, 0x01, 0x00}).Map()
+	assert.ErrorIs(t, err, cbor.ErrNotCanonical)
+	// array longer than the data
+	_, err = cbor.NewDecoder([]byte{0x9a, 0xff, 0xff, 0xff, 0xff}).Array()
+	assert.ErrorIs(t, err, cbor.ErrUnexpectedEnd)
+}
@@ -0,0 +1,167 @@
+/*
+Package certificate creates deterministic self-signed X.509 certificates and
+certificate signing requests from SLIP-10 derived Ed25519 keys.
+
+All fields of the certificate that are usually chosen at random or depend on the
+current time are derived from the public key and the derivation path instead.
+As such, the same seed and path always result in the exact same certificate,
+which allows to restore the identity of e.g. a node from its mnemonic backup.
+*/
+package certificate
+
+import (
+	stded25519 "crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"time"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip32path"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10/eddsa"
+)
+
+// serialSize is the size, in bytes, of the derived serial number.
+const serialSize = 16
+
+// serialDomain is the domain separation tag for the serial number derivation.
+const serialDomain = "iota-crypto-demo/certificate/serial"
+
+var (
+	// NotBefore is the fixed start of the validity period of all created certificates.
+	NotBefore = time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	// NotAfter is the fixed end of the validity period of all created certificates.
+	// It corresponds to the GeneralizedTime value 99991231235959Z, i.e. no well-defined expiration as per RFC 5280.
+	NotAfter = time.Date(9999, time.December, 31, 23, 59, 59, 0, time.UTC)
+)
+
+// ErrNotHardened is returned when the path contains non-hardened indices that are not supported by Ed25519.
+var ErrNotHardened = errors.New("path must only contain hardened indices")
+
+// Template contains the caller-defined fields of the created certificates.
+type Template struct {
+	// Subject is the subject as well as the issuer of the certificate.
+	// If the CommonName is empty, the derivation path is used instead.
+	Subject pkix.Name
+	// DNSNames contains the DNS subject alternative names.
+	DNSNames []string
+	// IPAddresses contains the IP address subject alternative names.
+	IPAddresses []net.IP
+}
+
+// PrivateKey derives the Ed25519 private key for the given seed and path.
+func PrivateKey(seed []byte, path bip32path.Path) (stded25519.PrivateKey, error) {
+	for _, index := range path {
+		if index < slip10.Hardened {
+			return nil, ErrNotHardened
+		}
+	}
+	key, err := slip10.DeriveKeyFromPath(seed, eddsa.Ed25519(), path)
+	if err != nil {
+		return nil, err
+	}
+	//nolint:forcetypeassert // the Ed25519 curve always returns an eddsa.Seed
+	return stded25519.NewKeyFromSeed(key.Key.(eddsa.Seed)), nil
+}
+
+// CreateCertificate creates a self-signed X.509 certificate for the key derived from seed and path.
+// It returns the certificate in DER encoding.
+func CreateCertificate(seed []byte, path bip32path.Path, tmpl *Template) ([]byte, error) {
+	priv, err := PrivateKey(seed, path)
+	if err != nil {
+		return nil, err
+	}
+	//nolint:forcetypeassert // Public of an ed25519.PrivateKey always returns an ed25519.PublicKey
+	pub := priv.Public().(stded25519.PublicKey)
+
+	cert := &x509.Certificate{
+		SerialNumber:          SerialNumber(pub, path),
+		Subject:               subject(tmpl, path),
+		NotBefore:             NotBefore,
+		NotAfter:              NotAfter,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		SubjectKeyId:          subjectKeyID(pub),
+	}
+	if tmpl != nil {
+		cert.DNSNames = tmpl.DNSNames
+		cert.IPAddresses = tmpl.IPAddresses
+	}
+
+	// Ed25519 signatures are deterministic and do not consume any randomness
+	der, err := x509.CreateCertificate(zeroReader{}, cert, cert, pub, priv)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create certificate: %w", err)
+	}
+	return der, nil
+}
+
+// CreateCertificateRequest creates an X.509 certificate signing request for the key derived from seed and path.
+// It returns the request in DER encoding.
+func CreateCertificateRequest(seed []byte, path bip32path.Path, tmpl *Template) ([]byte, error) {
+	priv, err := PrivateKey(seed, path)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &x509.CertificateRequest{
+		Subject: subject(tmpl, path),
+	}
+	if tmpl != nil {
+		req.DNSNames = tmpl.DNSNames
+		req.IPAddresses = tmpl.IPAddresses
+	}
+
+	der, err := x509.CreateCertificateRequest(zeroReader{}, req, priv)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create certificate request: %w", err)
+	}
+	return der, nil
+}
+
+// SerialNumber returns the deterministic serial number for the public key and path.
+// It is a positive 128-bit integer derived from the SHA-256 hash of the public key and the path.
+func SerialNumber(pub stded25519.PublicKey, path bip32path.Path) *big.Int {
+	h := sha256.New()
+	h.Write([]byte(serialDomain))
+	h.Write(pub)
+	h.Write([]byte(path.String()))
+	sum := h.Sum(nil)
+
+	// clear the most significant bit to assure a positive number with a DER encoding of at most 16 bytes
+	sum[0] &= 0x7F
+	return new(big.Int).SetBytes(sum[:serialSize])
+}
+
+func subject(tmpl *Template, path bip32path.Path) pkix.Name {
+	var name pkix.Name
+	if tmpl != nil {
+		name = tmpl.Subject
+	}
+	if name.CommonName == "" {
+		name.CommonName = path.String()
+	}
+	return name
+}
+
+// subjectKeyID computes the key identifier as described in RFC 7093, section 2, method 1.
+func subjectKeyID(pub stded25519.PublicKey) []byte {
+	sum := sha256.Sum256(pub)
+	return sum[:20]
+}
+
+// zeroReader is an io.Reader that always returns zeros.
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 0
+	}
+	return len(p), nil
+}
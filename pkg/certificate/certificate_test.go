@@ -0,0 +1,75 @@
+package certificate_test
+
+import (
+	stded25519 "crypto/ed25519"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip32path"
+	"github.com/iotaledger/iota-crypto-demo/pkg/certificate"
+)
+
+var seed, _ = hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+
+func TestCreateCertificate(t *testing.T) {
+	path, err := bip32path.ParsePath("m/44'/4218'/0'/0'")
+	require.NoError(t, err)
+	tmpl := &certificate.Template{
+		Subject:     pkix.Name{Organization: []string{"IOTA"}},
+		DNSNames:    []string{"node.example.com"},
+		IPAddresses: []net.IP{net.IPv4(127, 0, 0, 1)},
+	}
+
+	der, err := certificate.CreateCertificate(seed, path, tmpl)
+	require.NoError(t, err)
+
+	// creating the certificate again must lead to the exact same result
+	again, err := certificate.CreateCertificate(seed, path, tmpl)
+	require.NoError(t, err)
+	assert.Equal(t, der, again)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	require.NoError(t, cert.CheckSignatureFrom(cert))
+
+	priv, err := certificate.PrivateKey(seed, path)
+	require.NoError(t, err)
+	assert.True(t, priv.Public().(stded25519.PublicKey).Equal(cert.PublicKey))
+	assert.Equal(t, path.String(), cert.Subject.CommonName)
+	assert.Equal(t, []string{"IOTA"}, cert.Subject.Organization)
+	assert.Equal(t, tmpl.DNSNames, cert.DNSNames)
+	assert.Equal(t, certificate.NotBefore, cert.NotBefore)
+	assert.Equal(t, certificate.NotAfter, cert.NotAfter)
+	assert.Equal(t, 0, certificate.SerialNumber(priv.Public().(stded25519.PublicKey), path).Cmp(cert.SerialNumber))
+
+	// a different path must lead to a different certificate
+	other, err := certificate.CreateCertificate(seed, append(path[:len(path):len(path)], path[0]), tmpl)
+	require.NoError(t, err)
+	otherCert, err := x509.ParseCertificate(other)
+	require.NoError(t, err)
+	assert.NotEqual(t, cert.SerialNumber, otherCert.SerialNumber)
+	assert.NotEqual(t, cert.PublicKey, otherCert.PublicKey)
+}
+
+func TestCreateCertificateRequest(t *testing.T) {
+	path, err := bip32path.ParsePath("m/0'")
+	require.NoError(t, err)
+
+	der, err := certificate.CreateCertificateRequest(seed, path, nil)
+	require.NoError(t, err)
+	req, err := x509.ParseCertificateRequest(der)
+	require.NoError(t, err)
+	require.NoError(t, req.CheckSignature())
+	assert.Equal(t, "m/0'", req.Subject.CommonName)
+}
+
+func TestNotHardened(t *testing.T) {
+	_, err := certificate.CreateCertificate(seed, bip32path.Path{0}, nil)
+	assert.ErrorIs(t, err, certificate.ErrNotHardened)
+}
@@ -0,0 +1,143 @@
+/*
+Package crosscheck generates derivation expectations for hardware wallets and compares them with device responses.
+
+Fixtures use the layout of the JSON test fixtures consumed by the Trezor python tooling: a setup section with the
+mnemonic and passphrase of the device followed by a list of tests, each with its parameters and expected result.
+Devices or their tooling can dump their responses in the same format, so that Compare reports every path for which
+the device disagrees with the SLIP-10 derivation of this repository.
+*/
+package crosscheck
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/iotaledger/iota-crypto-demo/internal/hexutil"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bech32/address"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip32path"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip39"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10/eddsa"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10/elliptic"
+)
+
+// ErrUnsupportedCurve is returned when a curve name is unknown.
+var ErrUnsupportedCurve = errors.New("unsupported curve")
+
+// Fixture contains the setup of the device and the tests to run against it.
+type Fixture struct {
+	Setup Setup   `json:"setup"`
+	Tests []*Test `json:"tests"`
+}
+
+// Setup describes how the device is initialized.
+type Setup struct {
+	Mnemonic   string `json:"mnemonic"`
+	Passphrase string `json:"passphrase"`
+}
+
+// Test is a single derivation with its parameters and result.
+type Test struct {
+	Description string     `json:"description"`
+	Parameters  Parameters `json:"parameters"`
+	Result      Result     `json:"result"`
+}
+
+// Parameters identify the derived key.
+type Parameters struct {
+	Curve string         `json:"curve"`
+	Path  bip32path.Path `json:"path"`
+}
+
+// Result contains the derived public node and, for Ed25519, the corresponding IOTA address.
+type Result struct {
+	PublicKey hexutil.Bytes `json:"public_key"`
+	ChainCode hexutil.Bytes `json:"chain_code"`
+	Address   string        `json:"address,omitempty"`
+}
+
+// Mismatch describes a result field for which the actual fixture differs from the expected one.
+type Mismatch struct {
+	Description string
+	Field       string
+	Expected    string
+	Actual      string
+}
+
+func (m *Mismatch) String() string {
+	return fmt.Sprintf("%s: %s expected %q, got %q", m.Description, m.Field, m.Expected, m.Actual)
+}
+
+// Curve returns the SLIP-10 curve with the given name, i.e. "ed25519", "secp256k1" or "nist256p1".
+func Curve(name string) (slip10.Curve, error) {
+	for _, c := range []slip10.Curve{eddsa.Ed25519(), elliptic.Secp256k1(), elliptic.Nist256p1()} {
+		if c.Name() == name {
+			return c, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnsupportedCurve, name)
+}
+
+// Generate derives the expected results for each path on the curve from the mnemonic and passphrase.
+// Ed25519 results include the Bech32 address with the given prefix.
+func Generate(mnemonic bip39.Mnemonic, passphrase string, curve slip10.Curve, prefix address.Prefix, paths ...bip32path.Path) (*Fixture, error) {
+	seed, err := bip39.MnemonicToSeed(mnemonic, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	f := &Fixture{Setup: Setup{Mnemonic: mnemonic.String(), Passphrase: passphrase}}
+	for _, path := range paths {
+		key, err := slip10.DeriveKeyFromPath(seed, curve, path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to derive %s: %w", path, err)
+		}
+		res := Result{PublicKey: key.Key.Public().Bytes(), ChainCode: key.ChainCode}
+		if seed, ok := key.Key.(eddsa.Seed); ok {
+			public, _ := seed.Ed25519Key()
+			if res.Address, err = address.Bech32(prefix, address.AddressFromPublicKey(public)); err != nil {
+				return nil, err
+			}
+		}
+		f.Tests = append(f.Tests, &Test{
+			Description: fmt.Sprintf("%s %s", curve.Name(), path),
+			Parameters:  Parameters{Curve: curve.Name(), Path: path},
+			Result:      res,
+		})
+	}
+	return f, nil
+}
+
+// Compare compares the actual device responses with the expected fixture.
+// Tests are matched by their parameters and all differing or missing results are returned.
+// Empty fields of an actual result, e.g. the chain code when only the address was queried, are not compared.
+func Compare(expected, actual *Fixture) []*Mismatch {
+	var mismatches []*Mismatch
+	if expected.Setup != actual.Setup {
+		mismatches = append(mismatches, &Mismatch{"setup", "mnemonic and passphrase", expected.Setup.Mnemonic, actual.Setup.Mnemonic})
+	}
+	for _, e := range expected.Tests {
+		a := findTest(actual, &e.Parameters)
+		if a == nil {
+			mismatches = append(mismatches, &Mismatch{e.Description, "result", "present", "missing"})
+			continue
+		}
+		compare := func(field string, expected, actual string) {
+			if actual != "" && actual != expected {
+				mismatches = append(mismatches, &Mismatch{e.Description, field, expected, actual})
+			}
+		}
+		compare("public_key", e.Result.PublicKey.String(), a.Result.PublicKey.String())
+		compare("chain_code", e.Result.ChainCode.String(), a.Result.ChainCode.String())
+		compare("address", e.Result.Address, a.Result.Address)
+	}
+	return mismatches
+}
+
+func findTest(f *Fixture, p *Parameters) *Test {
+	for _, t := range f.Tests {
+		if t.Parameters.Curve == p.Curve && t.Parameters.Path.String() == p.Path.String() {
+			return t
+		}
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package crosscheck_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/bech32/address"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip32path"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip39"
+	"github.com/iotaledger/iota-crypto-demo/pkg/crosscheck"
+)
+
+const testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+func generate(t *testing.T, curveName string, paths ...string) *crosscheck.Fixture {
+	curve, err := crosscheck.Curve(curveName)
+	require.NoError(t, err)
+	var ps []bip32path.Path
+	for _, s := range paths {
+		p, err := bip32path.ParsePath(s)
+		require.NoError(t, err)
+		ps = append(ps, p)
+	}
+	f, err := crosscheck.Generate(bip39.ParseMnemonic(testMnemonic), "", curve, address.IOTAMainnet, ps...)
+	require.NoError(t, err)
+	return f
+}
+
+func TestGenerate(t *testing.T) {
+	f := generate(t, "ed25519", "m/44'/4218'/1'/0'")
+	require.Len(t, f.Tests, 1)
+	res := f.Tests[0].Result
+	assert.Equal(t, "974c2e2c01f8d2a9eabbb805f9222716056bea5ac91353599c190c9f1dae243f", res.ChainCode.String())
+	assert.Equal(t, "iota1qp22n849vywq9aayajl9utpfawlhjaskqfdzhphevsr4g5lt74mhckpnacr", res.Address)
+	assert.Len(t, res.PublicKey, 33)
+
+	f = generate(t, "secp256k1", "m/44'/0'/0'/0/0")
+	require.Len(t, f.Tests, 1)
+	assert.Empty(t, f.Tests[0].Result.Address)
+	assert.Len(t, f.Tests[0].Result.PublicKey, 33)
+
+	_, err := crosscheck.Curve("curve448")
+	assert.ErrorIs(t, err, crosscheck.ErrUnsupportedCurve)
+}
+
+func TestCompare(t *testing.T) {
+	expected := generate(t, "ed25519", "m/44'/4218'/0'/0'/0'", "m/44'/4218'/0'/0'/1'", "m/44'/4218'/0'/0'/2'")
+
+	// round trip through the JSON fixture format
+	b, err := json.Marshal(expected)
+	require.NoError(t, err)
+	var actual crosscheck.Fixture
+	require.NoError(t, json.Unmarshal(b, &actual))
+	assert.Empty(t, crosscheck.Compare(expected, &actual))
+
+	// devices only reporting the address are accepted
+	actual.Tests[0].Result.ChainCode = nil
+	actual.Tests[0].Result.PublicKey = nil
+	assert.Empty(t, crosscheck.Compare(expected, &actual))
+
+	actual.Tests[1].Result.Address = expected.Tests[0].Result.Address
+	actual.Tests = actual.Tests[:2]
+	mismatches := crosscheck.Compare(expected, &actual)
+	require.Len(t, mismatches, 2)
+	assert.Equal(t, "address", mismatches[0].Field)
+	assert.Equal(t, expected.Tests[1].Description, mismatches[0].Description)
+	assert.Equal(t, "result", mismatches[1].Field)
+	assert.Equal(t, expected.Tests[2].Description, mismatches[1].Description)
+}
@@ -0,0 +1,117 @@
+/*
+Package cryptopb provides the Protocol Buffers wire schema of derivation paths, public keys, addresses and signatures,
+so that services like a gRPC signer exchange them in a stable format.
+
+The messages are generated from proto/iota/crypto/v1/crypto.proto, this file adds the conversions from and to the
+types of the other packages. The conversions to Go types validate the decoded messages, as these usually come from
+untrusted peers.
+*/
+package cryptopb
+
+//go:generate protoc -I ../../proto --go_out=../.. --go_opt=module=github.com/iotaledger/iota-crypto-demo iota/crypto/v1/crypto.proto
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/bech32/address"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip32path"
+	"github.com/iotaledger/iota-crypto-demo/pkg/ed25519"
+)
+
+var (
+	// ErrMissingField is returned when a required message or field is not set.
+	ErrMissingField = errors.New("missing field")
+	// ErrUnexpectedCurve is returned when a key does not belong to the expected curve.
+	ErrUnexpectedCurve = errors.New("unexpected curve")
+	// ErrInvalidLength is returned when a key or signature has an invalid length.
+	ErrInvalidLength = errors.New("invalid length")
+)
+
+// NewPath returns the message of the BIP-32 path p.
+func NewPath(p bip32path.Path) *Path {
+	return &Path{Indices: append([]uint32{}, p...)}
+}
+
+// ToPath returns the BIP-32 path of x. A nil message corresponds to the master key.
+func (x *Path) ToPath() bip32path.Path {
+	return append(bip32path.Path{}, x.GetIndices()...)
+}
+
+// NewEd25519PublicKey returns the message of the Ed25519 public key.
+func NewEd25519PublicKey(publicKey ed25519.PublicKey) *PublicKey {
+	return &PublicKey{Curve: Curve_CURVE_ED25519, Key: append([]byte{}, publicKey...)}
+}
+
+// Ed25519 returns the Ed25519 public key of x.
+func (x *PublicKey) Ed25519() (ed25519.PublicKey, error) {
+	if x == nil {
+		return nil, fmt.Errorf("%w: public key", ErrMissingField)
+	}
+	if x.Curve != Curve_CURVE_ED25519 {
+		return nil, fmt.Errorf("%w: %s", ErrUnexpectedCurve, x.Curve)
+	}
+	if len(x.Key) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("%w: public key of %d bytes", ErrInvalidLength, len(x.Key))
+	}
+	return append(ed25519.PublicKey{}, x.Key...), nil
+}
+
+// NewAddress returns the message of addr on the network with the given prefix.
+func NewAddress(prefix address.Prefix, addr address.Address) *Address {
+	hash := addr.Hash()
+	return &Address{Version: uint32(addr.Version()), Hash: hash[:], Hrp: prefix.String()}
+}
+
+// ToAddress returns the address of x.
+func (x *Address) ToAddress() (address.Address, error) {
+	if x == nil {
+		return nil, fmt.Errorf("%w: address", ErrMissingField)
+	}
+	if x.Version > 0xff {
+		return nil, fmt.Errorf("%w: %d", address.ErrInvalidVersion, x.Version)
+	}
+	return address.ParseBytes(append([]byte{byte(x.Version)}, x.Hash...))
+}
+
+// Bech32 returns the Bech32 encoding of x using the network of its human-readable part.
+func (x *Address) Bech32() (string, error) {
+	addr, err := x.ToAddress()
+	if err != nil {
+		return "", err
+	}
+	if x.Hrp == "" {
+		return "", fmt.Errorf("%w: hrp", ErrMissingField)
+	}
+	prefix, err := address.ParsePrefix(x.Hrp)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", err, x.Hrp)
+	}
+	return address.Bech32(prefix, addr)
+}
+
+// NewEd25519Signature returns the message of the Ed25519 signature sig of publicKey.
+func NewEd25519Signature(publicKey ed25519.PublicKey, sig []byte) *Signature {
+	return &Signature{PublicKey: NewEd25519PublicKey(publicKey), Signature: append([]byte{}, sig...)}
+}
+
+// Ed25519 returns the Ed25519 public key and signature of x.
+func (x *Signature) Ed25519() (ed25519.PublicKey, []byte, error) {
+	if x == nil {
+		return nil, nil, fmt.Errorf("%w: signature", ErrMissingField)
+	}
+	publicKey, err := x.PublicKey.Ed25519()
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(x.Signature) != ed25519.SignatureSize {
+		return nil, nil, fmt.Errorf("%w: signature of %d bytes", ErrInvalidLength, len(x.Signature))
+	}
+	return publicKey, append([]byte{}, x.Signature...), nil
+}
+
+// VerifyEd25519 reports whether x is a valid Ed25519 signature of message.
+func (x *Signature) VerifyEd25519(message []byte) bool {
+	publicKey, sig, err := x.Ed25519()
+	return err == nil && ed25519.Verify(publicKey, message, sig)
+}
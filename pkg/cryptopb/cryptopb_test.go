@@ -0,0 +1,117 @@
+//nolint:scopelint // from tests
+package cryptopb_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/bech32/address"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip32path"
+	"github.com/iotaledger/iota-crypto-demo/pkg/cryptopb"
+	"github.com/iotaledger/iota-crypto-demo/pkg/ed25519"
+)
+
+var privateKey = ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize))
+
+func publicKey() ed25519.PublicKey {
+	return privateKey.Public().(ed25519.PublicKey) //nolint:forcetypeassert
+}
+
+// marshal encodes m and decodes it into a new message of the same type.
+func marshal[T proto.Message](t *testing.T, m T, decoded T) ([]byte, T) {
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(m)
+	require.NoError(t, err)
+	require.NoError(t, proto.Unmarshal(data, decoded))
+	return data, decoded
+}
+
+func TestPath(t *testing.T) {
+	path, err := bip32path.ParsePath("m/44'/4218'/0'/0'/1")
+	require.NoError(t, err)
+	data, decoded := marshal(t, cryptopb.NewPath(path), &cryptopb.Path{})
+	// the indices are encoded as packed varints in field 1
+	assert.Equal(t, "0a15ac80808008faa08080088080808008808080800801", hex.EncodeToString(data))
+	assert.Equal(t, path, decoded.ToPath())
+
+	assert.Empty(t, (*cryptopb.Path)(nil).ToPath())
+}
+
+func TestPublicKey(t *testing.T) {
+	_, decoded := marshal(t, cryptopb.NewEd25519PublicKey(publicKey()), &cryptopb.PublicKey{})
+	key, err := decoded.Ed25519()
+	require.NoError(t, err)
+	assert.Equal(t, publicKey(), key)
+
+	var tests = []*struct {
+		name string
+		msg  *cryptopb.PublicKey
+		err  error
+	}{
+		{"nil", nil, cryptopb.ErrMissingField},
+		{"curve", &cryptopb.PublicKey{Curve: cryptopb.Curve_CURVE_SECP256K1, Key: publicKey()}, cryptopb.ErrUnexpectedCurve},
+		{"unspecified", &cryptopb.PublicKey{Key: publicKey()}, cryptopb.ErrUnexpectedCurve},
+		{"length", &cryptopb.PublicKey{Curve: cryptopb.Curve_CURVE_ED25519, Key: publicKey()[1:]}, cryptopb.ErrInvalidLength},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.msg.Ed25519()
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestAddress(t *testing.T) {
+	addr := address.AddressFromPublicKey(publicKey())
+	_, decoded := marshal(t, cryptopb.NewAddress(address.ShimmerMainnet, addr), &cryptopb.Address{})
+	assert.Equal(t, "smr", decoded.Hrp)
+	parsed, err := decoded.ToAddress()
+	require.NoError(t, err)
+	assert.True(t, addr.Equal(parsed))
+	s, err := decoded.Bech32()
+	require.NoError(t, err)
+	expected, err := address.Bech32(address.ShimmerMainnet, addr)
+	require.NoError(t, err)
+	assert.Equal(t, expected, s)
+
+	var tests = []*struct {
+		name string
+		msg  *cryptopb.Address
+		err  error
+	}{
+		{"nil", nil, cryptopb.ErrMissingField},
+		{"version", &cryptopb.Address{Version: 0x100, Hash: decoded.Hash}, address.ErrInvalidVersion},
+		{"unknown version", &cryptopb.Address{Version: 1, Hash: decoded.Hash}, address.ErrInvalidVersion},
+		{"length", &cryptopb.Address{Hash: decoded.Hash[1:]}, address.ErrInvalidLength},
+		{"hrp", &cryptopb.Address{Hash: decoded.Hash, Hrp: "unknown"}, address.ErrInvalidPrefix},
+		{"no hrp", &cryptopb.Address{Hash: decoded.Hash}, cryptopb.ErrMissingField},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.msg.Bech32()
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestSignature(t *testing.T) {
+	message := []byte("message")
+	sig := ed25519.Sign(privateKey, message)
+	_, decoded := marshal(t, cryptopb.NewEd25519Signature(publicKey(), sig), &cryptopb.Signature{})
+	key, decodedSig, err := decoded.Ed25519()
+	require.NoError(t, err)
+	assert.Equal(t, publicKey(), key)
+	assert.Equal(t, sig, decodedSig)
+	assert.True(t, decoded.VerifyEd25519(message))
+	assert.False(t, decoded.VerifyEd25519([]byte("other message")))
+
+	_, _, err = (&cryptopb.Signature{Signature: sig}).Ed25519()
+	assert.ErrorIs(t, err, cryptopb.ErrMissingField)
+	decoded.Signature = sig[:32]
+	_, _, err = decoded.Ed25519()
+	assert.ErrorIs(t, err, cryptopb.ErrInvalidLength)
+	assert.False(t, decoded.VerifyEd25519(message))
+}
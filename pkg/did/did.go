@@ -0,0 +1,145 @@
+/*
+Package did generates decentralized identifiers and DID documents for Ed25519 keys.
+
+It supports the did:key method, where the identifier is the multibase encoded multicodec public key, as well as the
+did:iota method, where the identifier is the Alias ID of the alias output controlling the DID document. Both documents
+describe the Ed25519 key as an Ed25519VerificationKey2020 verification method, so that keys derived with SLIP-10 can be
+reused for identity experiments.
+*/
+package did
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/iotaledger/iota-crypto-demo/internal/hexutil"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bech32/address"
+	"github.com/iotaledger/iota-crypto-demo/pkg/ed25519"
+	"github.com/iotaledger/iota-crypto-demo/pkg/multikey"
+)
+
+const (
+	// KeyMethod is the prefix of did:key identifiers.
+	KeyMethod = "did:key:"
+	// IOTAMethod is the prefix of did:iota identifiers.
+	IOTAMethod = "did:iota:"
+)
+
+const (
+	contextDID     = "https://www.w3.org/ns/did/v1"
+	contextEd25519 = "https://w3id.org/security/suites/ed25519-2020/v1"
+	contextX25519  = "https://w3id.org/security/suites/x25519-2020/v1"
+)
+
+// ErrInvalidDID is returned when a DID string does not have the expected format.
+var ErrInvalidDID = errors.New("invalid DID")
+
+// Document represents a DID document.
+type Document struct {
+	Context              []string              `json:"@context"`
+	ID                   string                `json:"id"`
+	VerificationMethod   []*VerificationMethod `json:"verificationMethod"`
+	Authentication       []string              `json:"authentication,omitempty"`
+	AssertionMethod      []string              `json:"assertionMethod,omitempty"`
+	CapabilityDelegation []string              `json:"capabilityDelegation,omitempty"`
+	CapabilityInvocation []string              `json:"capabilityInvocation,omitempty"`
+	KeyAgreement         []string              `json:"keyAgreement,omitempty"`
+}
+
+// VerificationMethod represents a public key of a DID document.
+type VerificationMethod struct {
+	ID                 string `json:"id"`
+	Type               string `json:"type"`
+	Controller         string `json:"controller"`
+	PublicKeyMultibase string `json:"publicKeyMultibase"`
+}
+
+// Key returns the did:key identifier of the Ed25519 public key.
+func Key(publicKey ed25519.PublicKey) string {
+	return KeyMethod + multibase(multikey.Ed25519, publicKey)
+}
+
+// ParseKey returns the Ed25519 public key of the did:key identifier s.
+func ParseKey(s string) (ed25519.PublicKey, error) {
+	encoded, ok := strings.CutPrefix(s, KeyMethod)
+	if !ok || len(encoded) == 0 || multikey.Base(encoded[0]) != multikey.Base58BTC {
+		return nil, fmt.Errorf("%w: not a base58 did:key", ErrInvalidDID)
+	}
+	codec, key, err := multikey.Decode(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidDID, err)
+	}
+	if codec != multikey.Ed25519 {
+		return nil, fmt.Errorf("%w: unsupported key type %s", ErrInvalidDID, codec)
+	}
+	return ed25519.PublicKey(key), nil
+}
+
+// KeyDocument returns the DID document of the did:key identifier of the Ed25519 public key.
+// As described by the did:key method, it contains the Ed25519 key for all verification relationships and the
+// corresponding X25519 key for key agreement.
+func KeyDocument(publicKey ed25519.PublicKey) (*Document, error) {
+	x25519Key, err := ed25519.PublicKeyToX25519(publicKey)
+	if err != nil {
+		return nil, err
+	}
+	id := Key(publicKey)
+	encoded := strings.TrimPrefix(id, KeyMethod)
+	doc := newDocument(id, id+"#"+encoded, publicKey)
+
+	agreement := &VerificationMethod{
+		ID:                 id + "#" + multibase(multikey.X25519, x25519Key),
+		Type:               "X25519KeyAgreementKey2020",
+		Controller:         id,
+		PublicKeyMultibase: multibase(multikey.X25519, x25519Key),
+	}
+	doc.Context = append(doc.Context, contextX25519)
+	doc.VerificationMethod = append(doc.VerificationMethod, agreement)
+	doc.KeyAgreement = []string{agreement.ID}
+	return doc, nil
+}
+
+// IOTA returns the did:iota identifier of the alias on the network with the given prefix.
+// The network name is omitted for the IOTA mainnet.
+func IOTA(prefix address.Prefix, alias address.AliasAddress) string {
+	id := alias.Hash()
+	if prefix == address.IOTAMainnet {
+		return IOTAMethod + "0x" + hexutil.Bytes(id[:]).String()
+	}
+	return IOTAMethod + prefix.String() + ":0x" + hexutil.Bytes(id[:]).String()
+}
+
+// IOTADocument returns the DID document of the did:iota identifier with the Ed25519 public key as the verification
+// method with the given fragment, e.g. "key-1".
+func IOTADocument(prefix address.Prefix, alias address.AliasAddress, publicKey ed25519.PublicKey, fragment string) *Document {
+	id := IOTA(prefix, alias)
+	return newDocument(id, id+"#"+fragment, publicKey)
+}
+
+func newDocument(id string, methodID string, publicKey ed25519.PublicKey) *Document {
+	method := &VerificationMethod{
+		ID:                 methodID,
+		Type:               "Ed25519VerificationKey2020",
+		Controller:         id,
+		PublicKeyMultibase: multibase(multikey.Ed25519, publicKey),
+	}
+	return &Document{
+		Context:              []string{contextDID, contextEd25519},
+		ID:                   id,
+		VerificationMethod:   []*VerificationMethod{method},
+		Authentication:       []string{method.ID},
+		AssertionMethod:      []string{method.ID},
+		CapabilityDelegation: []string{method.ID},
+		CapabilityInvocation: []string{method.ID},
+	}
+}
+
+// multibase returns the base58 multibase encoding of the key prefixed with its multicodec identifier.
+func multibase(codec multikey.Codec, key []byte) string {
+	s, err := multikey.Encode(codec, key, multikey.Base58BTC)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
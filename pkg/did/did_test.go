@@ -0,0 +1,60 @@
+package did_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/internal/hexutil"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bech32/address"
+	"github.com/iotaledger/iota-crypto-demo/pkg/did"
+	"github.com/iotaledger/iota-crypto-demo/pkg/ed25519"
+)
+
+// test vector from the did:key test suite for the all-zero Ed25519 seed
+const testKeyDID = "did:key:z6MkiTBz1ymuepAQ4HEHYSF1H8quG5GLVVQR3djdX3mDooWp"
+
+var testPublicKey = ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize)).Public().(ed25519.PublicKey) //nolint:forcetypeassert
+
+func TestKey(t *testing.T) {
+	assert.Equal(t, testKeyDID, did.Key(testPublicKey))
+
+	pub, err := did.ParseKey(testKeyDID)
+	require.NoError(t, err)
+	assert.Equal(t, testPublicKey, pub)
+
+	for _, s := range []string{"did:web:example.com", "did:key:f00", "did:key:z6LShs9GGnqk85isEBzzshkuVWrVKsRp24GnDuHk8QWkARMW", "did:key:z6MkiTBz1ymuepAQ4HEHYSF1H8quG5GLVVQR3djdX3mDoo"} {
+		_, err := did.ParseKey(s)
+		assert.ErrorIs(t, err, did.ErrInvalidDID, s)
+	}
+}
+
+func TestKeyDocument(t *testing.T) {
+	doc, err := did.KeyDocument(testPublicKey)
+	require.NoError(t, err)
+	assert.Equal(t, testKeyDID, doc.ID)
+	require.Len(t, doc.VerificationMethod, 2)
+	assert.Equal(t, testKeyDID+"#z6MkiTBz1ymuepAQ4HEHYSF1H8quG5GLVVQR3djdX3mDooWp", doc.VerificationMethod[0].ID)
+	// X25519 public keys start with "z6LS" in their multibase encoding
+	agreement := doc.VerificationMethod[1]
+	assert.Equal(t, "X25519KeyAgreementKey2020", agreement.Type)
+	assert.Equal(t, "z6LS", agreement.PublicKeyMultibase[:4])
+	assert.Equal(t, []string{doc.VerificationMethod[0].ID}, doc.Authentication)
+	assert.Equal(t, []string{testKeyDID + "#" + agreement.PublicKeyMultibase}, doc.KeyAgreement)
+
+	_, err = json.Marshal(doc)
+	require.NoError(t, err)
+}
+
+func TestIOTA(t *testing.T) {
+	alias := address.AliasAddressFromID([address.AliasIDLength]byte(hexutil.MustDecodeString("e9fb6db8679ed1e66417d0b59eb4cd2efe0ac4e78b7c3e9b5d4ecfe76df33c3d")))
+	assert.Equal(t, "did:iota:0xe9fb6db8679ed1e66417d0b59eb4cd2efe0ac4e78b7c3e9b5d4ecfe76df33c3d", did.IOTA(address.IOTAMainnet, alias))
+	assert.Equal(t, "did:iota:smr:0xe9fb6db8679ed1e66417d0b59eb4cd2efe0ac4e78b7c3e9b5d4ecfe76df33c3d", did.IOTA(address.ShimmerMainnet, alias))
+
+	doc := did.IOTADocument(address.ShimmerMainnet, alias, testPublicKey, "key-1")
+	assert.Equal(t, "did:iota:smr:0xe9fb6db8679ed1e66417d0b59eb4cd2efe0ac4e78b7c3e9b5d4ecfe76df33c3d#key-1", doc.VerificationMethod[0].ID)
+	assert.Equal(t, "z6MkiTBz1ymuepAQ4HEHYSF1H8quG5GLVVQR3djdX3mDooWp", doc.VerificationMethod[0].PublicKeyMultibase)
+	assert.Empty(t, doc.KeyAgreement)
+}
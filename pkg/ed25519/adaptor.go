@@ -0,0 +1,168 @@
+package ed25519
+
+import (
+	cryptorand "crypto/rand"
+	"crypto/sha512"
+	"errors"
+	"io"
+	"strconv"
+
+	"filippo.io/edwards25519"
+)
+
+// AdaptorSize is the size, in bytes, of adaptor secrets and adaptor points.
+const AdaptorSize = 32
+
+// ErrInvalidAdaptor is returned when an adaptor secret, adaptor point or pre-signature is invalid.
+var ErrInvalidAdaptor = errors.New("ed25519: invalid adaptor")
+
+// GenerateAdaptor generates a random adaptor secret t together with the corresponding adaptor point T = [t]B.
+// If rand is nil, crypto/rand.Reader will be used.
+func GenerateAdaptor(rand io.Reader) (secret, point []byte, err error) {
+	if rand == nil {
+		rand = cryptorand.Reader
+	}
+	var b [64]byte
+	if _, err := io.ReadFull(rand, b[:]); err != nil {
+		return nil, nil, err
+	}
+	t, err := edwards25519.NewScalar().SetUniformBytes(b[:])
+	if err != nil {
+		panic("ed25519: internal error: setting scalar failed")
+	}
+	return t.Bytes(), new(edwards25519.Point).ScalarBaseMult(t).Bytes(), nil
+}
+
+// AdaptorSign creates a pre-signature of message with privateKey that is encrypted to the adaptor point.
+// The pre-signature can be checked with VerifyAdaptor, turned into a valid signature with AdaptSignature by anyone
+// knowing the adaptor secret, and once that signature is published, the secret can be recovered with ExtractSecret.
+// It will panic if len(privateKey) is not PrivateKeySize.
+func AdaptorSign(privateKey PrivateKey, message, adaptor []byte) ([]byte, error) {
+	if l := len(privateKey); l != PrivateKeySize {
+		panic("ed25519: bad private key length: " + strconv.Itoa(l))
+	}
+	T, err := decodeAdaptorPoint(adaptor)
+	if err != nil {
+		return nil, err
+	}
+
+	h := sha512.Sum512(privateKey[:SeedSize])
+	s, err := edwards25519.NewScalar().SetBytesWithClamping(h[:32])
+	if err != nil {
+		panic("ed25519: internal error: setting scalar failed")
+	}
+
+	// the nonce must also depend on the adaptor point, as the same r must never be used with different points
+	mh := sha512.New()
+	mh.Write(h[32:])
+	mh.Write(adaptor)
+	mh.Write(message)
+	r, err := edwards25519.NewScalar().SetUniformBytes(mh.Sum(make([]byte, 0, sha512.Size)))
+	if err != nil {
+		panic("ed25519: internal error: setting scalar failed")
+	}
+
+	// R' = [r]B + T, s' = r + k⋅s
+	R := new(edwards25519.Point).ScalarBaseMult(r)
+	R.Add(R, T)
+	k := challenge(R.Bytes(), privateKey[SeedSize:], message)
+	S := edwards25519.NewScalar().MultiplyAdd(k, s, r)
+
+	preSig := make([]byte, SignatureSize)
+	copy(preSig[:32], R.Bytes())
+	copy(preSig[32:], S.Bytes())
+	return preSig, nil
+}
+
+// VerifyAdaptor reports whether preSig is a valid pre-signature of message by publicKey encrypted to the adaptor
+// point, i.e. whether adapting it with the corresponding secret yields a valid signature.
+// It will panic if len(publicKey) is not PublicKeySize.
+func VerifyAdaptor(publicKey PublicKey, message, preSig, adaptor []byte) bool {
+	if l := len(publicKey); l != PublicKeySize {
+		panic("ed25519: bad public key length: " + strconv.Itoa(l))
+	}
+	T, err := decodeAdaptorPoint(adaptor)
+	if err != nil || len(preSig) != SignatureSize {
+		return false
+	}
+	A, err := new(edwards25519.Point).SetBytes(publicKey)
+	if err != nil {
+		return false
+	}
+	R, err := checkPoint(preSig[:32])
+	if err != nil {
+		return false
+	}
+	S, err := edwards25519.NewScalar().SetCanonicalBytes(preSig[32:])
+	if err != nil {
+		return false
+	}
+
+	// check [8][s']B == [8](R' - T) + [8][k]A
+	k := challenge(preSig[:32], publicKey, message)
+	p := new(edwards25519.Point).VarTimeDoubleScalarBaseMult(k, new(edwards25519.Point).Negate(A), S)
+	p.Subtract(p, R)
+	p.Add(p, T)
+	p.MultByCofactor(p)
+	return p.Equal(identity) == 1
+}
+
+// AdaptSignature completes the pre-signature preSig using the adaptor secret and returns the resulting signature.
+func AdaptSignature(preSig, secret []byte) ([]byte, error) {
+	if len(preSig) != SignatureSize {
+		return nil, ErrInvalidAdaptor
+	}
+	S, err := edwards25519.NewScalar().SetCanonicalBytes(preSig[32:])
+	if err != nil {
+		return nil, ErrInvalidAdaptor
+	}
+	t, err := edwards25519.NewScalar().SetCanonicalBytes(secret)
+	if err != nil {
+		return nil, ErrInvalidAdaptor
+	}
+
+	sig := make([]byte, SignatureSize)
+	copy(sig[:32], preSig[:32])
+	copy(sig[32:], S.Add(S, t).Bytes())
+	return sig, nil
+}
+
+// ExtractSecret recovers the adaptor secret from the pre-signature preSig and the signature sig adapted from it.
+func ExtractSecret(preSig, sig []byte) ([]byte, error) {
+	if len(preSig) != SignatureSize || len(sig) != SignatureSize || [32]byte(preSig[:32]) != [32]byte(sig[:32]) {
+		return nil, ErrInvalidAdaptor
+	}
+	preS, err := edwards25519.NewScalar().SetCanonicalBytes(preSig[32:])
+	if err != nil {
+		return nil, ErrInvalidAdaptor
+	}
+	S, err := edwards25519.NewScalar().SetCanonicalBytes(sig[32:])
+	if err != nil {
+		return nil, ErrInvalidAdaptor
+	}
+	return S.Subtract(S, preS).Bytes(), nil
+}
+
+func decodeAdaptorPoint(adaptor []byte) (*edwards25519.Point, error) {
+	if len(adaptor) != AdaptorSize {
+		return nil, ErrInvalidAdaptor
+	}
+	T, err := checkPoint(adaptor)
+	if err != nil {
+		return nil, ErrInvalidAdaptor
+	}
+	return T, nil
+}
+
+// challenge computes the scalar k = SHA-512(R || A || M) of pure Ed25519.
+func challenge(r, publicKey, message []byte) *edwards25519.Scalar {
+	kh := sha512.New()
+	kh.Write(r)
+	kh.Write(publicKey)
+	kh.Write(message)
+	k, err := edwards25519.NewScalar().SetUniformBytes(kh.Sum(make([]byte, 0, sha512.Size)))
+	if err != nil {
+		panic("ed25519: internal error: setting scalar failed")
+	}
+	return k
+}
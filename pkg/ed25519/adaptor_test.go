@@ -0,0 +1,54 @@
+package ed25519_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/ed25519"
+)
+
+func TestAdaptor(t *testing.T) {
+	publicKey, privateKey, _ := ed25519.GenerateKey(bytes.NewReader(nullSeed))
+	message := []byte("test message")
+
+	secret, point, err := ed25519.GenerateAdaptor(nil)
+	require.NoError(t, err)
+
+	preSig, err := ed25519.AdaptorSign(privateKey, message, point)
+	require.NoError(t, err)
+	assert.True(t, ed25519.VerifyAdaptor(publicKey, message, preSig, point))
+	// the pre-signature itself must not be a valid signature
+	assert.False(t, ed25519.Verify(publicKey, message, preSig))
+
+	sig, err := ed25519.AdaptSignature(preSig, secret)
+	require.NoError(t, err)
+	assert.True(t, ed25519.Verify(publicKey, message, sig))
+
+	extracted, err := ed25519.ExtractSecret(preSig, sig)
+	require.NoError(t, err)
+	assert.Equal(t, secret, extracted)
+}
+
+func TestAdaptorInvalid(t *testing.T) {
+	publicKey, privateKey, _ := ed25519.GenerateKey(bytes.NewReader(nullSeed))
+	message := []byte("test message")
+	secret, point, _ := ed25519.GenerateAdaptor(nil)
+	_, otherPoint, _ := ed25519.GenerateAdaptor(nil)
+
+	preSig, err := ed25519.AdaptorSign(privateKey, message, point)
+	require.NoError(t, err)
+	assert.False(t, ed25519.VerifyAdaptor(publicKey, message, preSig, otherPoint))
+	assert.False(t, ed25519.VerifyAdaptor(publicKey, []byte("wrong message"), preSig, point))
+
+	_, err = ed25519.AdaptorSign(privateKey, message, point[:ed25519.AdaptorSize-1])
+	assert.ErrorIs(t, err, ed25519.ErrInvalidAdaptor)
+
+	sig, _ := ed25519.AdaptSignature(preSig, secret)
+	_, err = ed25519.ExtractSecret(preSig, ed25519.Sign(privateKey, message))
+	assert.ErrorIs(t, err, ed25519.ErrInvalidAdaptor)
+	_, err = ed25519.ExtractSecret(preSig[:ed25519.SignatureSize-1], sig)
+	assert.ErrorIs(t, err, ed25519.ErrInvalidAdaptor)
+}
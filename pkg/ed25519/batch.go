@@ -0,0 +1,107 @@
+package ed25519
+
+import (
+	cryptorand "crypto/rand"
+	"crypto/sha512"
+	"strconv"
+
+	"filippo.io/edwards25519"
+)
+
+// VerifyBatch reports whether all sigs are valid signatures of the corresponding messages by the publicKeys.
+// In addition, it returns the validity of each individual signature.
+// It will panic if the slices do not have the same length or if any public key is not of length PublicKeySize.
+//
+// The signatures are first checked together using a random linear combination of the cofactored verification
+// equations, which is considerably faster than verifying them one after another. Only if this combined check fails,
+// each signature is verified individually to identify the invalid ones. The same validation criteria (ZIP 215) as in
+// Verify are used, so that the results of VerifyBatch and Verify always agree.
+func VerifyBatch(publicKeys []PublicKey, messages, sigs [][]byte) (bool, []bool) {
+	if len(publicKeys) != len(messages) || len(publicKeys) != len(sigs) {
+		panic("ed25519: mismatched batch lengths: " +
+			strconv.Itoa(len(publicKeys)) + ", " + strconv.Itoa(len(messages)) + ", " + strconv.Itoa(len(sigs)))
+	}
+	for _, publicKey := range publicKeys {
+		if l := len(publicKey); l != PublicKeySize {
+			panic("ed25519: bad public key length: " + strconv.Itoa(l))
+		}
+	}
+
+	valid := make([]bool, len(sigs))
+	if verifyBatch(publicKeys, messages, sigs) {
+		for i := range valid {
+			valid[i] = true
+		}
+		return true, valid
+	}
+
+	// fall back to individual verification
+	allValid := true
+	for i := range sigs {
+		valid[i] = Verify(publicKeys[i], messages[i], sigs[i])
+		allValid = allValid && valid[i]
+	}
+	return allValid, valid
+}
+
+// verifyBatch checks [8](∑zᵢRᵢ + ∑(zᵢkᵢ)Aᵢ - (∑zᵢSᵢ)B) == 0 for random 128-bit scalars zᵢ.
+func verifyBatch(publicKeys []PublicKey, messages, sigs [][]byte) bool {
+	n := len(sigs)
+	scalars := make([]*edwards25519.Scalar, 0, 2*n+1)
+	points := make([]*edwards25519.Point, 0, 2*n+1)
+
+	// coefficient of the base point
+	b := edwards25519.NewScalar()
+	scalars = append(scalars, b)
+	points = append(points, edwards25519.NewGeneratorPoint())
+
+	var zBytes [32]byte
+	for i := range sigs {
+		sig := sigs[i]
+		if len(sig) != SignatureSize || sig[63]&224 != 0 {
+			return false
+		}
+
+		// ZIP215: this works because SetBytes does not check that encodings are canonical
+		A, err := new(edwards25519.Point).SetBytes(publicKeys[i])
+		if err != nil {
+			return false
+		}
+		R, err := new(edwards25519.Point).SetBytes(sig[:32])
+		if err != nil {
+			return false
+		}
+		S, err := edwards25519.NewScalar().SetCanonicalBytes(sig[32:])
+		if err != nil {
+			return false
+		}
+
+		kh := sha512.New()
+		kh.Write(sig[:32])
+		kh.Write(publicKeys[i])
+		kh.Write(messages[i])
+		hramDigest := make([]byte, 0, sha512.Size)
+		hramDigest = kh.Sum(hramDigest)
+		k, err := edwards25519.NewScalar().SetUniformBytes(hramDigest)
+		if err != nil {
+			panic("ed25519: internal error: setting scalar failed")
+		}
+
+		// the upper half of zBytes stays zero, so that z is always canonical
+		if _, err := cryptorand.Read(zBytes[:16]); err != nil {
+			panic("ed25519: failed to read random bytes: " + err.Error())
+		}
+		z, err := edwards25519.NewScalar().SetCanonicalBytes(zBytes[:])
+		if err != nil {
+			panic("ed25519: internal error: setting scalar failed")
+		}
+
+		b.Subtract(b, edwards25519.NewScalar().Multiply(z, S))
+		scalars = append(scalars, z, edwards25519.NewScalar().Multiply(z, k))
+		points = append(points, R, A)
+	}
+
+	p := new(edwards25519.Point).VarTimeMultiScalarMult(scalars, points)
+	p.MultByCofactor(p)
+	return p.Equal(identity) == 1
+}
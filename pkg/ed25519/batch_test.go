@@ -0,0 +1,91 @@
+package ed25519_test
+
+import (
+	"encoding/hex"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/ed25519"
+)
+
+func newBatch(t testing.TB, n int) ([]ed25519.PublicKey, [][]byte, [][]byte) {
+	publicKeys := make([]ed25519.PublicKey, n)
+	messages := make([][]byte, n)
+	sigs := make([][]byte, n)
+	for i := 0; i < n; i++ {
+		publicKey, privateKey, err := ed25519.GenerateKey(nil)
+		require.NoError(t, err)
+		publicKeys[i] = publicKey
+		messages[i] = []byte("message " + strconv.Itoa(i))
+		sigs[i] = ed25519.Sign(privateKey, messages[i])
+	}
+	return publicKeys, messages, sigs
+}
+
+func TestVerifyBatch(t *testing.T) {
+	publicKeys, messages, sigs := newBatch(t, 16)
+
+	ok, valid := ed25519.VerifyBatch(publicKeys, messages, sigs)
+	assert.True(t, ok)
+	assert.Len(t, valid, len(sigs))
+	assert.NotContains(t, valid, false)
+
+	// invalidate some of the signatures
+	messages[3] = []byte("wrong message")
+	sigs[7] = sigs[8]
+	sigs[11] = sigs[11][:ed25519.SignatureSize-1]
+	ok, valid = ed25519.VerifyBatch(publicKeys, messages, sigs)
+	assert.False(t, ok)
+	for i := range valid {
+		assert.Equalf(t, i != 3 && i != 7 && i != 11, valid[i], "signature %d", i)
+	}
+}
+
+func TestVerifyBatchEmpty(t *testing.T) {
+	ok, valid := ed25519.VerifyBatch(nil, nil, nil)
+	assert.True(t, ok)
+	assert.Empty(t, valid)
+}
+
+func TestVerifyBatchPanics(t *testing.T) {
+	publicKeys, messages, sigs := newBatch(t, 2)
+	assert.Panics(t, func() { ed25519.VerifyBatch(publicKeys, messages[:1], sigs) })
+	publicKeys[1] = publicKeys[1][:ed25519.PublicKeySize-1]
+	assert.Panics(t, func() { ed25519.VerifyBatch(publicKeys, messages, sigs) })
+}
+
+func TestVerifyBatchZIP215(t *testing.T) {
+	publicKeys := make([]ed25519.PublicKey, len(tests))
+	messages := make([][]byte, len(tests))
+	sigs := make([][]byte, len(tests))
+	for i, tt := range tests {
+		publicKeys[i], _ = hex.DecodeString(tt.pk)
+		messages[i] = message
+		sigs[i], _ = hex.DecodeString(tt.s)
+	}
+
+	ok, valid := ed25519.VerifyBatch(publicKeys, messages, sigs)
+	assert.True(t, ok)
+	assert.NotContains(t, valid, false)
+}
+
+func BenchmarkVerifyIndividual(b *testing.B) {
+	publicKeys, messages, sigs := newBatch(b, 64)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j := range sigs {
+			ed25519.Verify(publicKeys[j], messages[j], sigs[j])
+		}
+	}
+}
+
+func BenchmarkVerifyBatch(b *testing.B) {
+	publicKeys, messages, sigs := newBatch(b, 64)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ed25519.VerifyBatch(publicKeys, messages, sigs)
+	}
+}
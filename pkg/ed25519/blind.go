@@ -0,0 +1,91 @@
+package ed25519
+
+import (
+	"crypto/sha512"
+	"fmt"
+	"strconv"
+
+	"filippo.io/edwards25519"
+)
+
+// domain separation strings of the key blinding
+const (
+	blindFactorString = "ed25519 key blinding factor"
+	blindPrefixString = "ed25519 key blinding prefix"
+)
+
+// BlindedPrivateKey is a private key blinded with a tweak using BlindPrivateKey.
+// Since the blinded secret scalar does not correspond to any seed, it cannot be represented as a PrivateKey.
+type BlindedPrivateKey struct {
+	s         *edwards25519.Scalar
+	prefix    [32]byte
+	publicKey PublicKey
+}
+
+// blindingFactor derives the non-zero blinding scalar for publicKey and tweak.
+func blindingFactor(publicKey PublicKey, tweak []byte) *edwards25519.Scalar {
+	h := sha512.New()
+	h.Write([]byte(blindFactorString))
+	h.Write(publicKey)
+	h.Write(tweak)
+	f, err := edwards25519.NewScalar().SetUniformBytes(h.Sum(make([]byte, 0, sha512.Size)))
+	if err != nil {
+		panic("ed25519: internal error: setting scalar failed")
+	}
+	return f
+}
+
+// BlindPublicKey derives the blinded public key A' = [h]A from publicKey A and the tweak, where h is derived from A
+// and tweak. Without knowing A and tweak, blinded keys for different tweaks cannot be linked to each other or to A,
+// which allows one-time addresses to be derived from published public keys, as for Tor onion services.
+// It returns an error if publicKey does not pass CheckPublicKey.
+func BlindPublicKey(publicKey PublicKey, tweak []byte) (PublicKey, error) {
+	if err := CheckPublicKey(publicKey); err != nil {
+		return nil, fmt.Errorf("ed25519: invalid public key for blinding: %w", err)
+	}
+	A, _ := new(edwards25519.Point).SetBytes(publicKey)
+	A.ScalarMult(blindingFactor(publicKey, tweak), A)
+	return A.Bytes(), nil
+}
+
+// BlindPrivateKey derives the blinded private key corresponding to BlindPublicKey(privateKey.Public(), tweak).
+// It will panic if len(privateKey) is not PrivateKeySize.
+func BlindPrivateKey(privateKey PrivateKey, tweak []byte) *BlindedPrivateKey {
+	if l := len(privateKey); l != PrivateKeySize {
+		panic("ed25519: bad private key length: " + strconv.Itoa(l))
+	}
+	publicKey := PublicKey(privateKey[SeedSize:])
+
+	h := sha512.Sum512(privateKey[:SeedSize])
+	s, err := edwards25519.NewScalar().SetBytesWithClamping(h[:32])
+	if err != nil {
+		panic("ed25519: internal error: setting scalar failed")
+	}
+	f := blindingFactor(publicKey, tweak)
+	s.Multiply(s, f)
+
+	// derive a new nonce prefix, so that nonces of the blinded and the unblinded key are independent
+	ph := sha512.New()
+	ph.Write([]byte(blindPrefixString))
+	ph.Write(h[32:])
+	ph.Write(f.Bytes())
+
+	key := &BlindedPrivateKey{s: s, publicKey: new(edwards25519.Point).ScalarBaseMult(s).Bytes()}
+	copy(key.prefix[:], ph.Sum(nil))
+	return key
+}
+
+// Public returns the blinded public key corresponding to key.
+func (key *BlindedPrivateKey) Public() PublicKey {
+	publicKey := make([]byte, PublicKeySize)
+	copy(publicKey, key.publicKey)
+	return publicKey
+}
+
+// Sign signs the message with the blinded key and returns a signature, which can be verified with Verify against
+// the blinded public key.
+func (key *BlindedPrivateKey) Sign(message []byte) []byte {
+	signature := make([]byte, SignatureSize)
+	signExpanded(signature, key.s, key.prefix[:], key.publicKey, message, nil, domPrefixPure, "")
+	return signature
+}
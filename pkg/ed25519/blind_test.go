@@ -0,0 +1,40 @@
+package ed25519_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/internal/hexutil"
+	"github.com/iotaledger/iota-crypto-demo/pkg/ed25519"
+)
+
+func TestBlind(t *testing.T) {
+	publicKey, privateKey, _ := ed25519.GenerateKey(bytes.NewReader(nullSeed))
+	message := []byte("test message")
+
+	blindedPublic, err := ed25519.BlindPublicKey(publicKey, []byte("tweak"))
+	require.NoError(t, err)
+	blindedPrivate := ed25519.BlindPrivateKey(privateKey, []byte("tweak"))
+	assert.Equal(t, blindedPublic, blindedPrivate.Public())
+	assert.NotEqual(t, publicKey, blindedPublic)
+	assert.NoError(t, ed25519.CheckPublicKey(blindedPublic))
+
+	sig := blindedPrivate.Sign(message)
+	assert.True(t, ed25519.Verify(blindedPublic, message, sig))
+	assert.False(t, ed25519.Verify(publicKey, message, sig))
+	assert.Equal(t, sig, blindedPrivate.Sign(message))
+
+	// different tweaks must lead to unrelated keys
+	other, err := ed25519.BlindPublicKey(publicKey, []byte("other tweak"))
+	require.NoError(t, err)
+	assert.NotEqual(t, blindedPublic, other)
+	assert.False(t, ed25519.Verify(other, message, sig))
+}
+
+func TestBlindPublicKeyInvalid(t *testing.T) {
+	_, err := ed25519.BlindPublicKey(hexutil.MustDecodeString("0100000000000000000000000000000000000000000000000000000000000000"), nil)
+	assert.ErrorIs(t, err, ed25519.ErrSmallOrder)
+}
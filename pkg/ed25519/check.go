@@ -0,0 +1,73 @@
+package ed25519
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"filippo.io/edwards25519"
+)
+
+var (
+	// ErrInvalidLength is returned when a public key or signature has the wrong length.
+	ErrInvalidLength = errors.New("ed25519: invalid length")
+	// ErrNotOnCurve is returned when an encoded point is not on the curve.
+	ErrNotOnCurve = errors.New("ed25519: point not on curve")
+	// ErrNonCanonical is returned when a point or scalar does not have its canonical encoding.
+	ErrNonCanonical = errors.New("ed25519: non-canonical encoding")
+	// ErrSmallOrder is returned when a point has small order, i.e. lies in the torsion subgroup.
+	ErrSmallOrder = errors.New("ed25519: small order point")
+)
+
+// CheckPublicKey reports why publicKey is pathological, i.e. not of length PublicKeySize, not on the curve, not
+// canonically encoded or of small order. It returns nil if publicKey passes all checks.
+// Verify accepts some of these keys as required by ZIP 215; CheckPublicKey allows rejecting them beforehand.
+func CheckPublicKey(publicKey PublicKey) error {
+	if l := len(publicKey); l != PublicKeySize {
+		return fmt.Errorf("%w: public key has length %d", ErrInvalidLength, l)
+	}
+	A, err := checkPoint(publicKey)
+	if err != nil {
+		return fmt.Errorf("public key: %w", err)
+	}
+	if new(edwards25519.Point).MultByCofactor(A).Equal(identity) == 1 {
+		return fmt.Errorf("public key: %w", ErrSmallOrder)
+	}
+	return nil
+}
+
+// CheckSignature reports why sig is malformed, i.e. not of length SignatureSize, its R not a canonically encoded
+// point on the curve or its S not canonical. It returns nil if sig passes all checks.
+// The checks only concern the encoding; whether sig is valid for a message must still be checked using Verify.
+func CheckSignature(sig []byte) error {
+	if l := len(sig); l != SignatureSize {
+		return fmt.Errorf("%w: signature has length %d", ErrInvalidLength, l)
+	}
+	if _, err := checkPoint(sig[:32]); err != nil {
+		return fmt.Errorf("signature R: %w", err)
+	}
+	if _, err := edwards25519.NewScalar().SetCanonicalBytes(sig[32:]); err != nil {
+		return fmt.Errorf("signature S: %w", ErrNonCanonical)
+	}
+	return nil
+}
+
+// IsCanonical reports whether sig is canonically encoded, i.e. whether both its R and its S have their canonical
+// encodings. Verify always rejects non-canonical S, but, as required by ZIP 215, accepts non-canonical encodings of
+// R, so that the same signature can have several accepted encodings. To reject those, verify with the
+// CanonicalZIP215 semantics.
+func IsCanonical(sig []byte) bool {
+	return CheckSignature(sig) == nil
+}
+
+// checkPoint decodes b and checks that it is the canonical encoding of a point on the curve.
+func checkPoint(b []byte) (*edwards25519.Point, error) {
+	p, err := new(edwards25519.Point).SetBytes(b)
+	if err != nil {
+		return nil, ErrNotOnCurve
+	}
+	if !bytes.Equal(p.Bytes(), b) {
+		return nil, ErrNonCanonical
+	}
+	return p, nil
+}
@@ -0,0 +1,60 @@
+package ed25519_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/iotaledger/iota-crypto-demo/internal/hexutil"
+	"github.com/iotaledger/iota-crypto-demo/pkg/ed25519"
+)
+
+func TestCheckPublicKey(t *testing.T) {
+	publicKey, _, _ := ed25519.GenerateKey(bytes.NewReader(nullSeed))
+
+	var tests = []*struct {
+		name      string
+		publicKey []byte
+		expErr    error
+	}{
+		{"valid", publicKey, nil},
+		{"short", publicKey[:ed25519.PublicKeySize-1], ed25519.ErrInvalidLength},
+		{"not on curve", hexutil.MustDecodeString("0200000000000000000000000000000000000000000000000000000000000000"), ed25519.ErrNotOnCurve},
+		{"non-canonical", hexutil.MustDecodeString("eeffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f"), ed25519.ErrNonCanonical},
+		{"non-canonical sign", hexutil.MustDecodeString("0100000000000000000000000000000000000000000000000000000000000080"), ed25519.ErrNonCanonical},
+		{"identity", hexutil.MustDecodeString("0100000000000000000000000000000000000000000000000000000000000000"), ed25519.ErrSmallOrder},
+		{"order 2", hexutil.MustDecodeString("ecffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f"), ed25519.ErrSmallOrder},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, ed25519.CheckPublicKey(tt.publicKey), tt.expErr)
+		})
+	}
+}
+
+func TestCheckSignature(t *testing.T) {
+	_, privateKey, _ := ed25519.GenerateKey(bytes.NewReader(nullSeed))
+	sig := ed25519.Sign(privateKey, []byte("test message"))
+
+	// S = ℓ is the non-canonical encoding of zero
+	nonCanonicalS := hexutil.MustDecodeString("0000000000000000000000000000000000000000000000000000000000000000edd3f55c1a631258d69cf7a2def9de1400000000000000000000000000000010")
+
+	var tests = []*struct {
+		name   string
+		sig    []byte
+		expErr error
+	}{
+		{"valid", sig, nil},
+		{"short", sig[:ed25519.SignatureSize-1], ed25519.ErrInvalidLength},
+		{"R not on curve", append(hexutil.MustDecodeString("0200000000000000000000000000000000000000000000000000000000000000"), sig[32:]...), ed25519.ErrNotOnCurve},
+		{"R non-canonical", append(hexutil.MustDecodeString("eeffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f"), sig[32:]...), ed25519.ErrNonCanonical},
+		{"S non-canonical", append(sig[:32:32], nonCanonicalS[32:]...), ed25519.ErrNonCanonical},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, ed25519.CheckSignature(tt.sig), tt.expErr)
+			assert.Equal(t, tt.expErr == nil, ed25519.IsCanonical(tt.sig))
+		})
+	}
+}
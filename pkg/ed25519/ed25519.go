@@ -11,6 +11,7 @@ import (
 	cryptorand "crypto/rand"
 	"crypto/sha512"
 	"errors"
+	"hash"
 	"io"
 	"strconv"
 
@@ -71,17 +72,102 @@ func (priv PrivateKey) Seed() []byte {
 	return seed
 }
 
-// Sign signs the given message with priv.
-// Ed25519 performs two passes over messages to be signed and therefore cannot
-// handle pre-hashed messages. Thus opts.HashFunc() must return zero to
-// indicate the message hasn't been hashed. This can be achieved by passing
-// crypto.Hash(0) as the value for opts.
+// Sign signs the given message with priv. rand is ignored.
+//
+// If opts.HashFunc() is crypto.SHA512, the pre-hashed variant Ed25519ph is used
+// and message is expected to be a SHA-512 hash, otherwise opts.HashFunc() must
+// be crypto.Hash(0) and the message must not be hashed, as Ed25519 performs two
+// passes over messages to be signed.
+//
+// A value of type Options can be used as opts, or crypto.Hash(0) or
+// crypto.SHA512 directly to select plain Ed25519 or Ed25519ph, respectively.
 func (priv PrivateKey) Sign(_ io.Reader, message []byte, opts crypto.SignerOpts) (signature []byte, err error) {
-	if opts.HashFunc() != crypto.Hash(0) {
-		return nil, errors.New("ed25519: cannot sign hashed message")
+	if l := len(priv); l != PrivateKeySize {
+		panic("ed25519: bad private key length: " + strconv.Itoa(l))
 	}
+	h := sha512.Sum512(priv[:SeedSize])
+	s, err := edwards25519.NewScalar().SetBytesWithClamping(h[:32])
+	if err != nil {
+		panic("ed25519: internal error: setting scalar failed")
+	}
+	return signWithOptions(s, h[32:], priv[SeedSize:], message, opts)
+}
+
+// signWithOptions signs message using the secret scalar s and the nonce prefix with the variant selected by opts.
+func signWithOptions(s *edwards25519.Scalar, prefix, publicKey, message []byte, opts crypto.SignerOpts) ([]byte, error) {
+	hash := opts.HashFunc()
+	context := ""
+	if opts, ok := opts.(*Options); ok {
+		context = opts.Context
+	}
+	signature := make([]byte, SignatureSize)
+	switch {
+	case hash == crypto.SHA512: // Ed25519ph
+		if l := len(message); l != sha512.Size {
+			return nil, errors.New("ed25519: bad Ed25519ph message hash length: " + strconv.Itoa(l))
+		}
+		if l := len(context); l > 255 {
+			return nil, errors.New("ed25519: bad Ed25519ph context length: " + strconv.Itoa(l))
+		}
+		signExpanded(signature, s, prefix, publicKey, message, nil, domPrefixPh, context)
+	case hash == crypto.Hash(0) && context != "": // Ed25519ctx
+		if l := len(context); l > 255 {
+			return nil, errors.New("ed25519: bad Ed25519ctx context length: " + strconv.Itoa(l))
+		}
+		signExpanded(signature, s, prefix, publicKey, message, nil, domPrefixCtx, context)
+	case hash == crypto.Hash(0): // Ed25519
+		signExpanded(signature, s, prefix, publicKey, message, nil, domPrefixPure, "")
+	default:
+		return nil, errors.New("ed25519: expected opts.HashFunc() zero (unhashed message, for standard Ed25519) or SHA-512 (for Ed25519ph)")
+	}
+	return signature, nil
+}
+
+// Options can be used with PrivateKey.Sign or VerifyWithOptions
+// to select Ed25519 variants.
+type Options struct {
+	// Hash can be zero for regular Ed25519, or crypto.SHA512 for Ed25519ph.
+	Hash crypto.Hash
+
+	// Context, if not empty, selects Ed25519ctx or provides the context string
+	// for Ed25519ph. It can be at most 255 bytes in length.
+	Context string
+
+	// Semantics selects the validation criteria used by VerifyWithOptions.
+	// It is ignored by PrivateKey.Sign.
+	Semantics Semantics
+}
+
+// Semantics denotes the validation criteria applied when verifying a signature.
+type Semantics int
+
+// Supported verification semantics.
+const (
+	// ZIP215 denotes the cofactored verification equation [8][S]B = [8]R + [8][k]A, which accepts non-canonical
+	// encodings of A and R. This is the default and matches the rules of IOTA protocol RFC-0028.
+	ZIP215 Semantics = iota
+	// Cofactorless denotes the cofactorless verification equation [S]B = R + [k]A, which also accepts non-canonical
+	// encodings of A and R.
+	Cofactorless
+	// Strict denotes the cofactorless verification equation, additionally requiring canonical encodings of A and R.
+	Strict
+	// CanonicalZIP215 denotes the cofactored verification equation of ZIP215, additionally requiring the canonical
+	// encoding of R. Together with the canonical S required by all semantics, this rejects every signature for which
+	// IsCanonical returns false, so that each accepted signature has a unique encoding.
+	CanonicalZIP215
+)
 
-	return Sign(priv, message), nil
+// cofactored reports whether the cofactored verification equation is used.
+func (s Semantics) cofactored() bool { return s == ZIP215 || s == CanonicalZIP215 }
+
+// HashFunc returns o.Hash.
+func (o *Options) HashFunc() crypto.Hash { return o.Hash }
+
+// NewPrehash returns a new hash.Hash computing the SHA-512 digest of a message for Ed25519ph.
+// This allows signing and verifying large messages without buffering them in memory: the message is streamed into
+// the returned hash and its Sum is passed as the message together with Options{Hash: crypto.SHA512}.
+func NewPrehash() hash.Hash {
+	return sha512.New()
 }
 
 // GenerateKey generates a public/private key pair using entropy from rand.
@@ -136,11 +222,31 @@ func newKeyFromSeed(privateKey, seed []byte) {
 func Sign(privateKey PrivateKey, message []byte) []byte {
 	// when Sign is inlined, the returned signature can be stack-allocated
 	signature := make([]byte, SignatureSize)
-	sign(signature, privateKey, message)
+	sign(signature, privateKey, message, nil, domPrefixPure, "")
 	return signature
 }
 
-func sign(signature, privateKey, message []byte) {
+// Domain separation prefixes used to disambiguate Ed25519 and its variants, see RFC 8032, Section 5.1.
+const (
+	// domPrefixPure is empty for pure Ed25519.
+	domPrefixPure = ""
+	// domPrefixPh is dom2(phflag=1) for Ed25519ph. It must be followed by the context length and context.
+	domPrefixPh = "SigEd25519 no Ed25519 collisions\x01"
+	// domPrefixCtx is dom2(phflag=0) for Ed25519ctx. It must be followed by the context length and context.
+	domPrefixCtx = "SigEd25519 no Ed25519 collisions\x00"
+)
+
+// writeDom writes the domain separation prefix followed by the context, unless the prefix is empty.
+func writeDom(h hash.Hash, domPrefix, context string) {
+	if domPrefix != domPrefixPure {
+		h.Write([]byte(domPrefix))
+		h.Write([]byte{byte(len(context))})
+		h.Write([]byte(context))
+	}
+}
+
+// sign computes the signature of message. If noise is not empty, it is mixed into the nonce derivation.
+func sign(signature, privateKey, message, noise []byte, domPrefix, context string) {
 	if l := len(privateKey); l != PrivateKeySize {
 		panic("ed25519: bad private key length: " + strconv.Itoa(l))
 	}
@@ -151,10 +257,15 @@ func sign(signature, privateKey, message []byte) {
 	if err != nil {
 		panic("ed25519: internal error: setting scalar failed")
 	}
-	prefix := h[32:]
+	signExpanded(signature, s, h[32:], publicKey, message, noise, domPrefix, context)
+}
 
+// signExpanded computes the signature of message using the secret scalar s and the nonce prefix directly.
+func signExpanded(signature []byte, s *edwards25519.Scalar, prefix, publicKey, message, noise []byte, domPrefix, context string) {
 	mh := sha512.New()
+	writeDom(mh, domPrefix, context)
 	mh.Write(prefix)
+	mh.Write(noise)
 	mh.Write(message)
 	messageDigest := make([]byte, 0, sha512.Size)
 	messageDigest = mh.Sum(messageDigest)
@@ -166,6 +277,7 @@ func sign(signature, privateKey, message []byte) {
 	R := (&edwards25519.Point{}).ScalarBaseMult(r)
 
 	kh := sha512.New()
+	writeDom(kh, domPrefix, context)
 	kh.Write(R.Bytes())
 	kh.Write(publicKey)
 	kh.Write(message)
@@ -187,7 +299,56 @@ var identity = edwards25519.NewIdentityPoint()
 
 // Verify reports whether sig is a valid signature of message by publicKey.
 // It uses precisely-specified validation criteria (ZIP 215) suitable for use in consensus-critical contexts.
+// It will panic if len(publicKey) is not PublicKeySize.
 func Verify(publicKey PublicKey, message, sig []byte) bool {
+	return verify(publicKey, message, sig, domPrefixPure, "", ZIP215)
+}
+
+// VerifyWithOptions reports whether sig is a valid signature of message by
+// publicKey. A valid signature is indicated by returning a nil error. It will
+// panic if len(publicKey) is not PublicKeySize.
+//
+// If opts.Hash is crypto.SHA512, the pre-hashed variant Ed25519ph is used and
+// message is expected to be a SHA-512 hash, otherwise opts.Hash must be
+// crypto.Hash(0) and the message must not be hashed, as Ed25519 performs two
+// passes over messages to be signed.
+// The validation criteria are selected by opts.Semantics, which defaults to the
+// same criteria (ZIP 215) as in Verify.
+func VerifyWithOptions(publicKey PublicKey, message, sig []byte, opts *Options) error {
+	if opts.Semantics < ZIP215 || opts.Semantics > CanonicalZIP215 {
+		return errors.New("ed25519: unknown verification semantics: " + strconv.Itoa(int(opts.Semantics)))
+	}
+	switch {
+	case opts.Hash == crypto.SHA512: // Ed25519ph
+		if l := len(message); l != sha512.Size {
+			return errors.New("ed25519: bad Ed25519ph message hash length: " + strconv.Itoa(l))
+		}
+		if l := len(opts.Context); l > 255 {
+			return errors.New("ed25519: bad Ed25519ph context length: " + strconv.Itoa(l))
+		}
+		if !verify(publicKey, message, sig, domPrefixPh, opts.Context, opts.Semantics) {
+			return errors.New("ed25519: invalid signature")
+		}
+		return nil
+	case opts.Hash == crypto.Hash(0) && opts.Context != "": // Ed25519ctx
+		if l := len(opts.Context); l > 255 {
+			return errors.New("ed25519: bad Ed25519ctx context length: " + strconv.Itoa(l))
+		}
+		if !verify(publicKey, message, sig, domPrefixCtx, opts.Context, opts.Semantics) {
+			return errors.New("ed25519: invalid signature")
+		}
+		return nil
+	case opts.Hash == crypto.Hash(0): // Ed25519
+		if !verify(publicKey, message, sig, domPrefixPure, "", opts.Semantics) {
+			return errors.New("ed25519: invalid signature")
+		}
+		return nil
+	default:
+		return errors.New("ed25519: expected opts.Hash zero (unhashed message, for standard Ed25519) or SHA-512 (for Ed25519ph)")
+	}
+}
+
+func verify(publicKey PublicKey, message, sig []byte, domPrefix, context string, semantics Semantics) bool {
 	if l := len(publicKey); l != PublicKeySize {
 		panic("ed25519: bad public key length: " + strconv.Itoa(l))
 	}
@@ -200,9 +361,13 @@ func Verify(publicKey PublicKey, message, sig []byte) bool {
 	if err != nil {
 		return false
 	}
+	if semantics == Strict && !bytes.Equal(A.Bytes(), publicKey) {
+		return false
+	}
 	A.Negate(A)
 
 	kh := sha512.New()
+	writeDom(kh, domPrefix, context)
 	kh.Write(sig[:32])
 	kh.Write(publicKey)
 	kh.Write(message)
@@ -218,6 +383,9 @@ func Verify(publicKey PublicKey, message, sig []byte) bool {
 	if err != nil {
 		return false
 	}
+	if (semantics == Strict || semantics == CanonicalZIP215) && !bytes.Equal(checkR.Bytes(), sig[:32]) {
+		return false
+	}
 
 	// https://tools.ietf.org/html/rfc8032#section-5.1.7 requires that s be in
 	// the range [0, order) in order to prevent signature malleability
@@ -227,6 +395,9 @@ func Verify(publicKey PublicKey, message, sig []byte) bool {
 	}
 
 	R := (&edwards25519.Point{}).VarTimeDoubleScalarBaseMult(k, A, S)
+	if !semantics.cofactored() {
+		return R.Equal(checkR) == 1
+	}
 
 	// ZIP215: We want to check [8](R - checkR) == 0
 	p := new(edwards25519.Point).Subtract(R, checkR) // p = R - checkR
@@ -0,0 +1,71 @@
+package ed25519
+
+import (
+	"crypto"
+	"crypto/sha512"
+	"io"
+	"strconv"
+
+	"filippo.io/edwards25519"
+)
+
+// ExpandedPrivateKeySize is the size, in bytes, of expanded private keys.
+const ExpandedPrivateKeySize = 64
+
+// ExpandedPrivateKey is an Ed25519 private key given by its secret scalar instead of a seed.
+// It consists of the 32-byte little-endian scalar, which is neither required to be clamped nor to be reduced modulo
+// the group order, followed by the 32-byte nonce prefix. Such keys are produced, for example, by BIP32-Ed25519 soft derivation or by threshold
+// schemes and cannot be represented as a PrivateKey. It implements crypto.Signer.
+type ExpandedPrivateKey []byte
+
+// Expand returns the expanded form of priv, i.e. the clamped scalar and the prefix derived from its seed.
+// Signatures created with the result are identical to those created with priv.
+func (priv PrivateKey) Expand() ExpandedPrivateKey {
+	if l := len(priv); l != PrivateKeySize {
+		panic("ed25519: bad private key length: " + strconv.Itoa(l))
+	}
+	h := sha512.Sum512(priv[:SeedSize])
+	h[0] &= 248
+	h[31] &= 127
+	h[31] |= 64
+	return ExpandedPrivateKey(h[:])
+}
+
+// scalar returns the secret scalar of k reduced modulo the group order.
+func (k ExpandedPrivateKey) scalar() *edwards25519.Scalar {
+	if l := len(k); l != ExpandedPrivateKeySize {
+		panic("ed25519: bad expanded private key length: " + strconv.Itoa(l))
+	}
+	var wide [64]byte
+	copy(wide[:], k[:32])
+	s, err := edwards25519.NewScalar().SetUniformBytes(wide[:])
+	if err != nil {
+		panic("ed25519: internal error: setting scalar failed")
+	}
+	return s
+}
+
+// Public returns the PublicKey corresponding to k. It will panic if len(k) is not ExpandedPrivateKeySize.
+func (k ExpandedPrivateKey) Public() crypto.PublicKey {
+	return PublicKey(new(edwards25519.Point).ScalarBaseMult(k.scalar()).Bytes())
+}
+
+// Sign signs the given message with k. rand is ignored. The options are the same as for PrivateKey.Sign.
+// It will panic if len(k) is not ExpandedPrivateKeySize.
+func (k ExpandedPrivateKey) Sign(_ io.Reader, message []byte, opts crypto.SignerOpts) ([]byte, error) {
+	s := k.scalar()
+	publicKey := new(edwards25519.Point).ScalarBaseMult(s).Bytes()
+	return signWithOptions(s, k[32:], publicKey, message, opts)
+}
+
+// SignExpanded signs the message with the expanded private key and returns a signature. It will panic if
+// len(privateKey) is not ExpandedPrivateKeySize.
+// As the public key is not part of an expanded key, it is computed on every call; use the crypto.Signer interface
+// of a SigningKey for repeated signing with seed-based keys.
+func SignExpanded(privateKey ExpandedPrivateKey, message []byte) []byte {
+	s := privateKey.scalar()
+	publicKey := new(edwards25519.Point).ScalarBaseMult(s).Bytes()
+	signature := make([]byte, SignatureSize)
+	signExpanded(signature, s, privateKey[32:], publicKey, message, nil, domPrefixPure, "")
+	return signature
+}
@@ -0,0 +1,45 @@
+package ed25519_test
+
+import (
+	"bytes"
+	"crypto"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/internal/hexutil"
+	"github.com/iotaledger/iota-crypto-demo/pkg/ed25519"
+)
+
+func TestExpand(t *testing.T) {
+	publicKey, privateKey, _ := ed25519.GenerateKey(bytes.NewReader(nullSeed))
+	message := []byte("test message")
+
+	expanded := privateKey.Expand()
+	assert.Len(t, expanded, ed25519.ExpandedPrivateKeySize)
+	assert.Equal(t, publicKey, expanded.Public())
+	assert.Equal(t, ed25519.Sign(privateKey, message), ed25519.SignExpanded(expanded, message))
+
+	opts := &ed25519.Options{Hash: crypto.SHA512}
+	digest := make([]byte, 64)
+	sig, err := expanded.Sign(nil, digest, opts)
+	require.NoError(t, err)
+	expected, err := privateKey.Sign(nil, digest, opts)
+	require.NoError(t, err)
+	assert.Equal(t, expected, sig)
+}
+
+func TestSignExpanded(t *testing.T) {
+	// an unclamped scalar larger than the group order, as produced by BIP32-Ed25519 derivation
+	expanded := ed25519.ExpandedPrivateKey(hexutil.MustDecodeString(
+		"ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff" +
+			"0000000000000000000000000000000000000000000000000000000000000000"))
+	message := []byte("test message")
+
+	//nolint:forcetypeassert
+	publicKey := expanded.Public().(ed25519.PublicKey)
+	sig := ed25519.SignExpanded(expanded, message)
+	assert.True(t, ed25519.Verify(publicKey, message, sig))
+	assert.Panics(t, func() { ed25519.SignExpanded(expanded[:32], message) })
+}
@@ -0,0 +1,53 @@
+package frost
+
+import (
+	cryptorand "crypto/rand"
+	"crypto/sha512"
+	"io"
+
+	"filippo.io/edwards25519"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/ed25519"
+)
+
+// Split splits the existing Ed25519 privateKey into key shares for the participants 1 to n, of which any threshold
+// can sign for the corresponding public key. If rand is nil, crypto/rand.Reader will be used.
+// Unlike with the DKG, the dealer knows the full key and must be trusted to erase privateKey and the shares.
+func Split(rand io.Reader, privateKey ed25519.PrivateKey, threshold, n int) ([]*KeyShare, error) {
+	if rand == nil {
+		rand = cryptorand.Reader
+	}
+	if err := validateThreshold(threshold, n); err != nil {
+		return nil, err
+	}
+
+	// the signing scalar of the private key is the constant term of the polynomial
+	h := sha512.Sum512(privateKey.Seed())
+	s, err := edwards25519.NewScalar().SetBytesWithClamping(h[:32])
+	if err != nil {
+		panic("frost: internal error: setting scalar failed")
+	}
+	coefficients := []*edwards25519.Scalar{s}
+	for i := 1; i < threshold; i++ {
+		a, err := randomScalar(rand)
+		if err != nil {
+			return nil, err
+		}
+		coefficients = append(coefficients, a)
+	}
+
+	//nolint:forcetypeassert
+	pkg := &PublicKeyPackage{
+		Threshold:          threshold,
+		PublicKey:          privateKey.Public().(ed25519.PublicKey),
+		VerificationShares: make(map[Identifier][]byte, n),
+	}
+	shares := make([]*KeyShare, n)
+	for i := range shares {
+		id := Identifier(i + 1)
+		secret := evalPolynomial(coefficients, id.scalar())
+		pkg.VerificationShares[id] = new(edwards25519.Point).ScalarBaseMult(secret).Bytes()
+		shares[i] = &KeyShare{ID: id, Secret: secret.Bytes(), PublicKeyPackage: pkg}
+	}
+	return shares, nil
+}
@@ -0,0 +1,211 @@
+package frost
+
+import (
+	cryptorand "crypto/rand"
+	"fmt"
+	"io"
+
+	"filippo.io/edwards25519"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/ed25519"
+)
+
+// Round1Package is broadcast by each participant to all other participants in the first round of the DKG.
+type Round1Package struct {
+	// ID is the identifier of the sender.
+	ID Identifier
+	// Commitment contains the serialized commitments to the coefficients of the secret polynomial of the sender.
+	Commitment [][]byte
+	// Proof is the Schnorr proof of knowledge of the constant term of the secret polynomial.
+	Proof []byte
+}
+
+// Round2Package is sent privately from one participant to another in the second round of the DKG.
+type Round2Package struct {
+	// From is the identifier of the sender.
+	From Identifier
+	// To is the identifier of the recipient.
+	To Identifier
+	// Share is the serialized secret share of the recipient. It must be sent over a confidential channel.
+	Share []byte
+}
+
+// DKG holds the state of a participant in the distributed key generation.
+type DKG struct {
+	id           Identifier
+	threshold    int
+	participants int
+	coefficients []*edwards25519.Scalar
+	commitments  map[Identifier][]*edwards25519.Point
+}
+
+// NewDKG starts the distributed key generation for the participant id of a threshold-of-participants group and
+// returns the package to broadcast to all other participants. If rand is nil, crypto/rand.Reader will be used.
+func NewDKG(rand io.Reader, id Identifier, threshold, participants int) (*DKG, *Round1Package, error) {
+	if rand == nil {
+		rand = cryptorand.Reader
+	}
+	if err := validateThreshold(threshold, participants); err != nil {
+		return nil, nil, err
+	}
+	if id == 0 {
+		return nil, nil, fmt.Errorf("%w: %d", ErrInvalidIdentifier, id)
+	}
+
+	d := &DKG{
+		id:           id,
+		threshold:    threshold,
+		participants: participants,
+		coefficients: make([]*edwards25519.Scalar, threshold),
+		commitments:  make(map[Identifier][]*edwards25519.Point, participants),
+	}
+	pkg := &Round1Package{ID: id, Commitment: make([][]byte, threshold)}
+	commitment := make([]*edwards25519.Point, threshold)
+	for i := range d.coefficients {
+		a, err := randomScalar(rand)
+		if err != nil {
+			return nil, nil, err
+		}
+		d.coefficients[i] = a
+		commitment[i] = new(edwards25519.Point).ScalarBaseMult(a)
+		pkg.Commitment[i] = commitment[i].Bytes()
+	}
+	d.commitments[id] = commitment
+
+	// prove knowledge of the constant term to prevent rogue-key attacks
+	k, err := randomScalar(rand)
+	if err != nil {
+		return nil, nil, err
+	}
+	r := new(edwards25519.Point).ScalarBaseMult(k)
+	c := proofChallenge(id, commitment[0], r)
+	mu := edwards25519.NewScalar().MultiplyAdd(d.coefficients[0], c, k)
+	pkg.Proof = append(r.Bytes(), mu.Bytes()...)
+
+	return d, pkg, nil
+}
+
+// proofChallenge computes the challenge of the proof of knowledge.
+func proofChallenge(id Identifier, c0, r *edwards25519.Point) *edwards25519.Scalar {
+	return hashToScalar("dkg", id.scalar().Bytes(), c0.Bytes(), r.Bytes())
+}
+
+// Round2 verifies the packages received from all other participants in the first round and returns the secret
+// shares to send to each of them.
+func (d *DKG) Round2(packages []*Round1Package) ([]*Round2Package, error) {
+	if len(d.commitments) != 1 {
+		return nil, fmt.Errorf("%w: round 2 already completed", ErrInvalidPackages)
+	}
+	if len(packages) != d.participants-1 {
+		return nil, fmt.Errorf("%w: expected %d packages, got %d", ErrInvalidPackages, d.participants-1, len(packages))
+	}
+	received := make(map[Identifier][]*edwards25519.Point, len(packages))
+	for _, pkg := range packages {
+		if pkg.ID == 0 || pkg.ID == d.id || received[pkg.ID] != nil {
+			return nil, fmt.Errorf("%w: %d", ErrInvalidIdentifier, pkg.ID)
+		}
+		if len(pkg.Commitment) != d.threshold {
+			return nil, fmt.Errorf("%w: commitment of participant %d has length %d", ErrInvalidProof, pkg.ID, len(pkg.Commitment))
+		}
+		commitment := make([]*edwards25519.Point, d.threshold)
+		for i, b := range pkg.Commitment {
+			p, err := decodeElement(b)
+			if err != nil {
+				return nil, fmt.Errorf("commitment of participant %d: %w", pkg.ID, err)
+			}
+			commitment[i] = p
+		}
+		if err := verifyProof(pkg.ID, commitment[0], pkg.Proof); err != nil {
+			return nil, fmt.Errorf("participant %d: %w", pkg.ID, err)
+		}
+		received[pkg.ID] = commitment
+	}
+	for id, commitment := range received {
+		d.commitments[id] = commitment
+	}
+
+	res := make([]*Round2Package, 0, len(packages))
+	for _, pkg := range packages {
+		share := evalPolynomial(d.coefficients, pkg.ID.scalar())
+		res = append(res, &Round2Package{From: d.id, To: pkg.ID, Share: share.Bytes()})
+	}
+	return res, nil
+}
+
+func verifyProof(id Identifier, c0 *edwards25519.Point, proof []byte) error {
+	if len(proof) != ElementSize+ScalarSize {
+		return ErrInvalidProof
+	}
+	r, err := decodeElement(proof[:ElementSize])
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidProof, err)
+	}
+	mu, err := decodeScalar(proof[ElementSize:])
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidProof, err)
+	}
+	// check [μ]B == R + [c]C₀
+	c := proofChallenge(id, c0, r)
+	expected := new(edwards25519.Point).ScalarMult(c, c0)
+	expected.Add(expected, r)
+	if new(edwards25519.Point).ScalarBaseMult(mu).Equal(expected) != 1 {
+		return ErrInvalidProof
+	}
+	return nil
+}
+
+// Finalize verifies the secret shares received from all other participants in the second round and returns the
+// resulting key share. The DKG must not be used afterwards.
+func (d *DKG) Finalize(packages []*Round2Package) (*KeyShare, error) {
+	if len(d.commitments) != d.participants || d.coefficients == nil {
+		return nil, fmt.Errorf("%w: round 2 not completed or already finalized", ErrInvalidPackages)
+	}
+	if len(packages) != d.participants-1 {
+		return nil, fmt.Errorf("%w: expected %d packages, got %d", ErrInvalidPackages, d.participants-1, len(packages))
+	}
+
+	x := d.id.scalar()
+	secret := evalPolynomial(d.coefficients, x)
+	seen := make(map[Identifier]bool, len(packages))
+	for _, pkg := range packages {
+		commitment := d.commitments[pkg.From]
+		if pkg.To != d.id || pkg.From == d.id || commitment == nil || seen[pkg.From] {
+			return nil, fmt.Errorf("%w: package from %d to %d", ErrInvalidIdentifier, pkg.From, pkg.To)
+		}
+		seen[pkg.From] = true
+
+		share, err := decodeScalar(pkg.Share)
+		if err != nil {
+			return nil, fmt.Errorf("share of participant %d: %w", pkg.From, err)
+		}
+		if new(edwards25519.Point).ScalarBaseMult(share).Equal(evalCommitment(commitment, x)) != 1 {
+			return nil, fmt.Errorf("%w: share of participant %d", ErrInvalidShare, pkg.From)
+		}
+		secret.Add(secret, share)
+	}
+
+	groupKey := edwards25519.NewIdentityPoint()
+	for _, commitment := range d.commitments {
+		groupKey.Add(groupKey, commitment[0])
+	}
+	pkg := &PublicKeyPackage{
+		Threshold:          d.threshold,
+		PublicKey:          ed25519.PublicKey(groupKey.Bytes()),
+		VerificationShares: make(map[Identifier][]byte, d.participants),
+	}
+	for _, id := range sortedIdentifiers(d.commitments) {
+		y := edwards25519.NewIdentityPoint()
+		for _, commitment := range d.commitments {
+			y.Add(y, evalCommitment(commitment, id.scalar()))
+		}
+		pkg.VerificationShares[id] = y.Bytes()
+	}
+
+	// erase the secret polynomial
+	for _, a := range d.coefficients {
+		a.Set(edwards25519.NewScalar())
+	}
+	d.coefficients = nil
+
+	return &KeyShare{ID: d.id, Secret: secret.Bytes(), PublicKeyPackage: pkg}, nil
+}
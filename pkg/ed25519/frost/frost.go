@@ -0,0 +1,208 @@
+/*
+Package frost implements FROST threshold signing for Ed25519 as specified in RFC 9591 using the
+FROST(Ed25519, SHA-512) ciphersuite.
+
+Keys are either generated jointly by all participants using a distributed key generation with proofs of knowledge
+(see NewDKG), so that the group secret never exists in one place, or an existing Ed25519 key, e.g. one derived via
+SLIP-10, is split by a trusted dealer (see Split). Any threshold of participants can then produce a signature in two
+rounds (see Commit, Sign and Aggregate), which is a standard Ed25519 signature that can be verified with
+ed25519.Verify against the group public key.
+*/
+package frost
+
+import (
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"filippo.io/edwards25519"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/ed25519"
+)
+
+// contextString is the context string used for domain separation of all hash functions of the ciphersuite.
+const contextString = "FROST-ED25519-SHA512-v1"
+
+// ScalarSize is the size, in bytes, of serialized scalars, i.e. secret shares and signature shares.
+const ScalarSize = 32
+
+// ElementSize is the size, in bytes, of serialized group elements, i.e. commitments and public keys.
+const ElementSize = 32
+
+var (
+	// ErrInvalidThreshold is returned when the threshold or the number of participants is invalid.
+	ErrInvalidThreshold = errors.New("invalid threshold")
+	// ErrInvalidIdentifier is returned when an identifier is zero, duplicated or unknown.
+	ErrInvalidIdentifier = errors.New("invalid identifier")
+	// ErrInvalidEncoding is returned when a scalar or group element cannot be decoded.
+	ErrInvalidEncoding = errors.New("invalid encoding")
+	// ErrInvalidProof is returned when the proof of knowledge of a DKG participant is invalid.
+	ErrInvalidProof = errors.New("invalid proof of knowledge")
+	// ErrInvalidShare is returned when a secret share or signature share does not match its commitment.
+	ErrInvalidShare = errors.New("invalid share")
+	// ErrInvalidPackages is returned when the DKG packages are incomplete or received in the wrong state.
+	ErrInvalidPackages = errors.New("invalid packages")
+	// ErrInvalidCommitments is returned when the list of signing commitments is invalid.
+	ErrInvalidCommitments = errors.New("invalid commitments")
+	// ErrInvalidSignature is returned when the aggregated signature does not verify under the group public key, i.e.
+	// the public key package is inconsistent.
+	ErrInvalidSignature = errors.New("invalid signature")
+)
+
+// Identifier identifies a participant. It must not be zero.
+type Identifier uint16
+
+func (id Identifier) scalar() *edwards25519.Scalar {
+	var b [ScalarSize]byte
+	binary.LittleEndian.PutUint16(b[:], uint16(id))
+	s, err := edwards25519.NewScalar().SetCanonicalBytes(b[:])
+	if err != nil {
+		panic("frost: internal error: setting scalar failed")
+	}
+	return s
+}
+
+// PublicKeyPackage contains the public information of a key shared among the participants.
+type PublicKeyPackage struct {
+	// Threshold is the minimum number of participants required to sign.
+	Threshold int
+	// PublicKey is the Ed25519 public key of the group.
+	PublicKey ed25519.PublicKey
+	// VerificationShares contains the public verification share of each participant.
+	VerificationShares map[Identifier][]byte
+}
+
+// KeyShare is the long-lived secret share of a participant.
+type KeyShare struct {
+	// ID is the identifier of the participant.
+	ID Identifier
+	// Secret is the serialized secret signing share of the participant.
+	Secret []byte
+	*PublicKeyPackage
+}
+
+// hash computes SHA-512 over the context string, the tag and all of msgs.
+func hash(tag string, msgs ...[]byte) []byte {
+	h := sha512.New()
+	h.Write([]byte(contextString))
+	h.Write([]byte(tag))
+	for _, m := range msgs {
+		h.Write(m)
+	}
+	return h.Sum(make([]byte, 0, sha512.Size))
+}
+
+// hashToScalar computes a uniformly distributed scalar from hash.
+func hashToScalar(tag string, msgs ...[]byte) *edwards25519.Scalar {
+	s, err := edwards25519.NewScalar().SetUniformBytes(hash(tag, msgs...))
+	if err != nil {
+		panic("frost: internal error: setting scalar failed")
+	}
+	return s
+}
+
+// challenge computes the Ed25519 challenge H2 of the ciphersuite, which is not domain separated.
+func challenge(r *edwards25519.Point, publicKey []byte, message []byte) *edwards25519.Scalar {
+	h := sha512.New()
+	h.Write(r.Bytes())
+	h.Write(publicKey)
+	h.Write(message)
+	s, err := edwards25519.NewScalar().SetUniformBytes(h.Sum(make([]byte, 0, sha512.Size)))
+	if err != nil {
+		panic("frost: internal error: setting scalar failed")
+	}
+	return s
+}
+
+// randomScalar returns a uniformly distributed random scalar read from rand.
+func randomScalar(rand io.Reader) (*edwards25519.Scalar, error) {
+	var b [64]byte
+	if _, err := io.ReadFull(rand, b[:]); err != nil {
+		return nil, err
+	}
+	s, err := edwards25519.NewScalar().SetUniformBytes(b[:])
+	if err != nil {
+		panic("frost: internal error: setting scalar failed")
+	}
+	return s, nil
+}
+
+// decodeScalar decodes the canonical scalar encoding b.
+func decodeScalar(b []byte) (*edwards25519.Scalar, error) {
+	s, err := edwards25519.NewScalar().SetCanonicalBytes(b)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidEncoding, err)
+	}
+	return s, nil
+}
+
+// decodeElement decodes the group element b, which must not be the identity.
+func decodeElement(b []byte) (*edwards25519.Point, error) {
+	p, err := new(edwards25519.Point).SetBytes(b)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidEncoding, err)
+	}
+	if p.Equal(edwards25519.NewIdentityPoint()) == 1 {
+		return nil, fmt.Errorf("%w: identity element", ErrInvalidEncoding)
+	}
+	return p, nil
+}
+
+// evalPolynomial evaluates the polynomial with the given coefficients, the constant term first, at x.
+func evalPolynomial(coefficients []*edwards25519.Scalar, x *edwards25519.Scalar) *edwards25519.Scalar {
+	res := edwards25519.NewScalar()
+	for i := len(coefficients) - 1; i >= 0; i-- {
+		res.MultiplyAdd(res, x, coefficients[i])
+	}
+	return res
+}
+
+// evalCommitment evaluates the commitment to a polynomial at x in the exponent.
+func evalCommitment(commitment []*edwards25519.Point, x *edwards25519.Scalar) *edwards25519.Point {
+	res := edwards25519.NewIdentityPoint()
+	for i := len(commitment) - 1; i >= 0; i-- {
+		res.ScalarMult(x, res)
+		res.Add(res, commitment[i])
+	}
+	return res
+}
+
+// lagrangeCoefficient returns the Lagrange coefficient of id for the interpolation at zero over ids.
+func lagrangeCoefficient(id Identifier, ids []Identifier) *edwards25519.Scalar {
+	x := id.scalar()
+	num, den := scalarOne(), scalarOne()
+	for _, other := range ids {
+		if other == id {
+			continue
+		}
+		xj := other.scalar()
+		num.Multiply(num, xj)
+		den.Multiply(den, edwards25519.NewScalar().Subtract(xj, x))
+	}
+	return num.Multiply(num, den.Invert(den))
+}
+
+func scalarOne() *edwards25519.Scalar {
+	return Identifier(1).scalar()
+}
+
+// validateThreshold checks that threshold and participants form a valid t-of-n configuration.
+func validateThreshold(threshold, participants int) error {
+	if threshold < 1 || threshold > participants || participants > 1<<16-1 {
+		return fmt.Errorf("%w: %d-of-%d", ErrInvalidThreshold, threshold, participants)
+	}
+	return nil
+}
+
+// sortedIdentifiers returns the identifiers of m in increasing order.
+func sortedIdentifiers[V any](m map[Identifier]V) []Identifier {
+	ids := make([]Identifier, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
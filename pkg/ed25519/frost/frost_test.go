@@ -0,0 +1,306 @@
+package frost_test
+
+import (
+	"bytes"
+	std "crypto/ed25519"
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"filippo.io/edwards25519"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/ed25519"
+	"github.com/iotaledger/iota-crypto-demo/pkg/ed25519/frost"
+)
+
+var message = []byte("test message")
+
+// runDKG runs the distributed key generation for all participants.
+func runDKG(t *testing.T, threshold, n int) []*frost.KeyShare {
+	dkgs := make([]*frost.DKG, n)
+	round1 := make([]*frost.Round1Package, n)
+	for i := range dkgs {
+		var err error
+		dkgs[i], round1[i], err = frost.NewDKG(nil, frost.Identifier(i+1), threshold, n)
+		require.NoError(t, err)
+	}
+
+	round2 := make(map[frost.Identifier][]*frost.Round2Package)
+	for i, d := range dkgs {
+		packages, err := d.Round2(others(round1, i))
+		require.NoError(t, err)
+		for _, pkg := range packages {
+			round2[pkg.To] = append(round2[pkg.To], pkg)
+		}
+	}
+
+	shares := make([]*frost.KeyShare, n)
+	for i, d := range dkgs {
+		var err error
+		shares[i], err = d.Finalize(round2[frost.Identifier(i+1)])
+		require.NoError(t, err)
+	}
+	return shares
+}
+
+func others(packages []*frost.Round1Package, i int) []*frost.Round1Package {
+	res := append([]*frost.Round1Package{}, packages[:i]...)
+	return append(res, packages[i+1:]...)
+}
+
+// sign runs both signing rounds for the given signers.
+func sign(t *testing.T, signers []*frost.KeyShare, message []byte) ([]byte, error) {
+	nonces := make([]*frost.Nonces, len(signers))
+	commitments := make([]*frost.Commitment, len(signers))
+	for i, share := range signers {
+		var err error
+		nonces[i], commitments[i], err = frost.Commit(nil, share)
+		require.NoError(t, err)
+	}
+	sigShares := make([]*frost.SignatureShare, len(signers))
+	for i, share := range signers {
+		var err error
+		sigShares[i], err = frost.Sign(share, nonces[i], message, commitments)
+		require.NoError(t, err)
+	}
+	return frost.Aggregate(signers[0].PublicKeyPackage, message, commitments, sigShares)
+}
+
+func TestDKG(t *testing.T) {
+	var tests = []*struct {
+		threshold, n int
+	}{
+		{1, 1},
+		{1, 3},
+		{2, 3},
+		{3, 5},
+		{5, 5},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%d-of-%d", tt.threshold, tt.n), func(t *testing.T) {
+			shares := runDKG(t, tt.threshold, tt.n)
+			for _, share := range shares {
+				assert.Equal(t, shares[0].PublicKeyPackage, share.PublicKeyPackage)
+			}
+
+			// every threshold-sized window of signers must produce a valid signature
+			for i := 0; i+tt.threshold <= tt.n; i++ {
+				sig, err := sign(t, shares[i:i+tt.threshold], message)
+				require.NoError(t, err)
+				assert.True(t, ed25519.Verify(shares[0].PublicKey, message, sig))
+				assert.True(t, std.Verify(std.PublicKey(shares[0].PublicKey), message, sig))
+			}
+		})
+	}
+}
+
+func TestSplit(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	shares, err := frost.Split(nil, privateKey, 2, 3)
+	require.NoError(t, err)
+	for _, share := range shares {
+		assert.Equal(t, publicKey, share.PublicKey)
+	}
+
+	for _, signers := range [][]*frost.KeyShare{{shares[0], shares[1]}, {shares[2], shares[0]}, shares} {
+		sig, err := sign(t, signers, message)
+		require.NoError(t, err)
+		assert.True(t, ed25519.Verify(publicKey, message, sig))
+	}
+}
+
+func TestInvalidThreshold(t *testing.T) {
+	_, privateKey, _ := ed25519.GenerateKey(nil)
+	for _, tt := range [][2]int{{0, 3}, {4, 3}, {1, 0}} {
+		_, _, err := frost.NewDKG(nil, 1, tt[0], tt[1])
+		assert.ErrorIs(t, err, frost.ErrInvalidThreshold)
+		_, err = frost.Split(nil, privateKey, tt[0], tt[1])
+		assert.ErrorIs(t, err, frost.ErrInvalidThreshold)
+	}
+	_, _, err := frost.NewDKG(nil, 0, 2, 3)
+	assert.ErrorIs(t, err, frost.ErrInvalidIdentifier)
+}
+
+func TestDKGInvalidProof(t *testing.T) {
+	d, _, err := frost.NewDKG(nil, 1, 2, 2)
+	require.NoError(t, err)
+	_, pkg, err := frost.NewDKG(nil, 2, 2, 2)
+	require.NoError(t, err)
+
+	// a proof for a different identifier must be rejected
+	pkg.ID = 3
+	_, err = d.Round2([]*frost.Round1Package{pkg})
+	assert.ErrorIs(t, err, frost.ErrInvalidProof)
+}
+
+func TestDKGInvalidShare(t *testing.T) {
+	d1, pkg1, err := frost.NewDKG(nil, 1, 2, 2)
+	require.NoError(t, err)
+	d2, pkg2, err := frost.NewDKG(nil, 2, 2, 2)
+	require.NoError(t, err)
+
+	_, err = d1.Round2([]*frost.Round1Package{pkg2})
+	require.NoError(t, err)
+	packages, err := d2.Round2([]*frost.Round1Package{pkg1})
+	require.NoError(t, err)
+
+	packages[0].Share[0] ^= 1
+	_, err = d1.Finalize(packages)
+	assert.ErrorIs(t, err, frost.ErrInvalidShare)
+}
+
+func TestAggregateInvalidShare(t *testing.T) {
+	shares := runDKG(t, 2, 3)
+	signers := shares[:2]
+
+	nonces := make([]*frost.Nonces, len(signers))
+	commitments := make([]*frost.Commitment, len(signers))
+	for i, share := range signers {
+		var err error
+		nonces[i], commitments[i], err = frost.Commit(nil, share)
+		require.NoError(t, err)
+	}
+	sigShares := make([]*frost.SignatureShare, len(signers))
+	for i, share := range signers {
+		var err error
+		sigShares[i], err = frost.Sign(share, nonces[i], message, commitments)
+		require.NoError(t, err)
+	}
+
+	// signature shares for a different message must be detected
+	_, err := frost.Aggregate(signers[0].PublicKeyPackage, []byte("wrong message"), commitments, sigShares)
+	assert.ErrorIs(t, err, frost.ErrInvalidShare)
+
+	// nonces must not be reused
+	_, err = frost.Sign(signers[0], nonces[0], message, commitments)
+	assert.ErrorIs(t, err, frost.ErrInvalidCommitments)
+
+	// too few commitments
+	_, err = frost.Aggregate(signers[0].PublicKeyPackage, message, commitments[:1], sigShares[:1])
+	assert.ErrorIs(t, err, frost.ErrInvalidCommitments)
+}
+
+func mustDecodeHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// RFC 9591, Appendix E.1: FROST(Ed25519, SHA-512)
+var rfcVector = struct {
+	groupPublicKey string
+	message        string
+	shares         map[frost.Identifier]string
+	// round one inputs and outputs of the participants 1 and 3
+	hidingRandomness, bindingRandomness map[frost.Identifier]string
+	hidingCommitment, bindingCommitment map[frost.Identifier]string
+	// round two outputs
+	sigShares map[frost.Identifier]string
+	sig       string
+}{
+	groupPublicKey: "15d21ccd7ee42959562fc8aa63224c8851fb3ec85a3faf66040d380fb9738673",
+	message:        "74657374",
+	shares: map[frost.Identifier]string{
+		1: "929dcc590407aae7d388761cddb0c0db6f5627aea8e217f4a033f2ec83d93509",
+		2: "a91e66e012e4364ac9aaa405fcafd370402d9859f7b6685c07eed76bf409e80d",
+		3: "d3cb090a075eb154e82fdb4b3cb507f110040905468bb9c46da8bdea643a9a02",
+	},
+	hidingRandomness: map[frost.Identifier]string{
+		1: "0fd2e39e111cdc266f6c0f4d0fd45c947761f1f5d3cb583dfcb9bbaf8d4c9fec",
+		3: "86d64a260059e495d0fb4fcc17ea3da7452391baa494d4b00321098ed2a0062f",
+	},
+	bindingRandomness: map[frost.Identifier]string{
+		1: "69cd85f631d5f7f2721ed5e40519b1366f340a87c2f6856363dbdcda348a7501",
+		3: "13e6b25afb2eba51716a9a7d44130c0dbae0004a9ef8d7b5550c8a0e07c61775",
+	},
+	hidingCommitment: map[frost.Identifier]string{
+		1: "b5aa8ab305882a6fc69cbee9327e5a45e54c08af61ae77cb8207be3d2ce13de3",
+		3: "cfbdb165bd8aad6eb79deb8d287bcc0ab6658ae57fdcc98ed12c0669e90aec91",
+	},
+	bindingCommitment: map[frost.Identifier]string{
+		1: "67e98ab55aa310c3120418e5050c9cf76cf387cb20ac9e4b6fdb6f82a469f932",
+		3: "7487bc41a6e712eea2f2af24681b58b1cf1da278ea11fe4e8b78398965f13552",
+	},
+	sigShares: map[frost.Identifier]string{
+		1: "001719ab5a53ee1a12095cd088fd149702c0720ce5fd2f29dbecf24b7281b603",
+		3: "bd86125de990acc5e1f13781d8e32c03a9bbd4c53539bbc106058bfd14326007",
+	},
+	sig: "36282629c383bb820a88b71cae937d41f2f2adfcc3d02e55507e2fb9e2dd3cbe" +
+		"bd9d2b0844e49ae0f3fa935161e1419aab7b47d21a37ebeae1f17d4987b3160b",
+}
+
+// rfcPublicKeyPackage returns the 2-of-3 public key package of the RFC vector.
+func rfcPublicKeyPackage() *frost.PublicKeyPackage {
+	pkg := &frost.PublicKeyPackage{
+		Threshold:          2,
+		PublicKey:          mustDecodeHex(rfcVector.groupPublicKey),
+		VerificationShares: map[frost.Identifier][]byte{},
+	}
+	for id, share := range rfcVector.shares {
+		s, err := edwards25519.NewScalar().SetCanonicalBytes(mustDecodeHex(share))
+		if err != nil {
+			panic(err)
+		}
+		pkg.VerificationShares[id] = new(edwards25519.Point).ScalarBaseMult(s).Bytes()
+	}
+	return pkg
+}
+
+func TestRFCVector(t *testing.T) {
+	pkg := rfcPublicKeyPackage()
+	message := mustDecodeHex(rfcVector.message)
+	ids := []frost.Identifier{1, 3}
+
+	shares := make([]*frost.KeyShare, len(ids))
+	nonces := make([]*frost.Nonces, len(ids))
+	commitments := make([]*frost.Commitment, len(ids))
+	for i, id := range ids {
+		shares[i] = &frost.KeyShare{ID: id, Secret: mustDecodeHex(rfcVector.shares[id]), PublicKeyPackage: pkg}
+		rand := bytes.NewReader(mustDecodeHex(rfcVector.hidingRandomness[id] + rfcVector.bindingRandomness[id]))
+		var err error
+		nonces[i], commitments[i], err = frost.Commit(rand, shares[i])
+		require.NoError(t, err)
+		assert.Equal(t, rfcVector.hidingCommitment[id], hex.EncodeToString(commitments[i].Hiding))
+		assert.Equal(t, rfcVector.bindingCommitment[id], hex.EncodeToString(commitments[i].Binding))
+	}
+
+	sigShares := make([]*frost.SignatureShare, len(ids))
+	for i, id := range ids {
+		var err error
+		sigShares[i], err = frost.Sign(shares[i], nonces[i], message, commitments)
+		require.NoError(t, err)
+		assert.Equal(t, rfcVector.sigShares[id], hex.EncodeToString(sigShares[i].Share))
+	}
+
+	sig, err := frost.Aggregate(pkg, message, commitments, sigShares)
+	require.NoError(t, err)
+	assert.Equal(t, rfcVector.sig, hex.EncodeToString(sig))
+	assert.True(t, std.Verify(std.PublicKey(pkg.PublicKey), message, sig))
+}
+
+func TestAggregateInconsistentPackage(t *testing.T) {
+	shares := runDKG(t, 2, 3)
+	_, other, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	// valid signature shares for a package, whose public key does not match its verification shares
+	pkg := *shares[0].PublicKeyPackage
+	pkg.PublicKey = other.Public().(ed25519.PublicKey) //nolint:forcetypeassert
+	signers := make([]*frost.KeyShare, 2)
+	for i := range signers {
+		signers[i] = &frost.KeyShare{ID: shares[i].ID, Secret: shares[i].Secret, PublicKeyPackage: &pkg}
+	}
+	_, err = sign(t, signers, message)
+	assert.ErrorIs(t, err, frost.ErrInvalidSignature)
+
+	pkg.PublicKey = pkg.PublicKey[:16]
+	_, err = sign(t, signers, message)
+	assert.ErrorIs(t, err, frost.ErrInvalidSignature)
+}
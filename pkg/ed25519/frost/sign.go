@@ -0,0 +1,219 @@
+package frost
+
+import (
+	"bytes"
+	cryptorand "crypto/rand"
+	"fmt"
+	"io"
+	"sort"
+
+	"filippo.io/edwards25519"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/ed25519"
+)
+
+// Commitment is the public commitment to the nonces of a participant, which is sent to the coordinator in the
+// first round of signing.
+type Commitment struct {
+	// ID is the identifier of the participant.
+	ID Identifier
+	// Hiding is the serialized hiding nonce commitment.
+	Hiding []byte
+	// Binding is the serialized binding nonce commitment.
+	Binding []byte
+}
+
+// Nonces are the secret single-use nonces of a participant corresponding to a Commitment.
+type Nonces struct {
+	hiding, binding *edwards25519.Scalar
+	commitment      *Commitment
+}
+
+// SignatureShare is the share of the signature produced by a participant in the second round of signing.
+type SignatureShare struct {
+	// ID is the identifier of the participant.
+	ID Identifier
+	// Share is the serialized signature share.
+	Share []byte
+}
+
+// Commit generates the nonces of the participant holding share for a single signing operation together with the
+// commitment to send to the coordinator. If rand is nil, crypto/rand.Reader will be used.
+func Commit(rand io.Reader, share *KeyShare) (*Nonces, *Commitment, error) {
+	if rand == nil {
+		rand = cryptorand.Reader
+	}
+	hiding, err := generateNonce(rand, share.Secret)
+	if err != nil {
+		return nil, nil, err
+	}
+	binding, err := generateNonce(rand, share.Secret)
+	if err != nil {
+		return nil, nil, err
+	}
+	commitment := &Commitment{
+		ID:      share.ID,
+		Hiding:  new(edwards25519.Point).ScalarBaseMult(hiding).Bytes(),
+		Binding: new(edwards25519.Point).ScalarBaseMult(binding).Bytes(),
+	}
+	return &Nonces{hiding: hiding, binding: binding, commitment: commitment}, commitment, nil
+}
+
+// generateNonce derives a nonce from fresh randomness and the secret, so that a weak rand alone does not reveal it.
+func generateNonce(rand io.Reader, secret []byte) (*edwards25519.Scalar, error) {
+	var b [32]byte
+	if _, err := io.ReadFull(rand, b[:]); err != nil {
+		return nil, err
+	}
+	return hashToScalar("nonce", b[:], secret), nil
+}
+
+// Sign computes the signature share of message for the participant holding share using its nonces from Commit.
+// The commitments must contain the commitments of all participating signers including this one. The nonces are
+// erased and cannot be used again.
+func Sign(share *KeyShare, nonces *Nonces, message []byte, commitments []*Commitment) (*SignatureShare, error) {
+	if nonces.hiding == nil {
+		return nil, fmt.Errorf("%w: nonces already used", ErrInvalidCommitments)
+	}
+	ctx, err := newSigningContext(share.PublicKeyPackage, message, commitments)
+	if err != nil {
+		return nil, err
+	}
+	own, ok := ctx.commitments[share.ID]
+	if !ok || !bytes.Equal(own.Hiding, nonces.commitment.Hiding) || !bytes.Equal(own.Binding, nonces.commitment.Binding) {
+		return nil, fmt.Errorf("%w: missing commitment of participant %d", ErrInvalidCommitments, share.ID)
+	}
+	secret, err := decodeScalar(share.Secret)
+	if err != nil {
+		return nil, fmt.Errorf("secret share: %w", err)
+	}
+
+	// z = d + e⋅ρ + λ⋅s⋅c
+	z := edwards25519.NewScalar().Multiply(ctx.lambda(share.ID), secret)
+	z.Multiply(z, ctx.challenge)
+	z.MultiplyAdd(nonces.binding, ctx.bindingFactors[share.ID], z)
+	z.Add(z, nonces.hiding)
+
+	// erase the nonces to prevent their reuse
+	nonces.hiding.Set(edwards25519.NewScalar())
+	nonces.binding.Set(edwards25519.NewScalar())
+	nonces.hiding, nonces.binding = nil, nil
+
+	return &SignatureShare{ID: share.ID, Share: z.Bytes()}, nil
+}
+
+// Aggregate verifies the signature shares of all participants in commitments and combines them into a standard
+// Ed25519 signature of message under pkg.PublicKey.
+// If a share is invalid, an error wrapping ErrInvalidShare identifies the misbehaving participant.
+func Aggregate(pkg *PublicKeyPackage, message []byte, commitments []*Commitment, shares []*SignatureShare) ([]byte, error) {
+	ctx, err := newSigningContext(pkg, message, commitments)
+	if err != nil {
+		return nil, err
+	}
+	if len(shares) != len(ctx.ids) {
+		return nil, fmt.Errorf("%w: expected %d signature shares, got %d", ErrInvalidShare, len(ctx.ids), len(shares))
+	}
+
+	z := edwards25519.NewScalar()
+	seen := make(map[Identifier]bool, len(shares))
+	for _, share := range shares {
+		c, ok := ctx.commitments[share.ID]
+		if !ok || seen[share.ID] {
+			return nil, fmt.Errorf("%w: %d", ErrInvalidIdentifier, share.ID)
+		}
+		seen[share.ID] = true
+
+		zi, err := decodeScalar(share.Share)
+		if err != nil {
+			return nil, fmt.Errorf("signature share of participant %d: %w", share.ID, err)
+		}
+		// check [zᵢ]B == Dᵢ + [ρᵢ]Eᵢ + [λᵢ⋅c]Yᵢ
+		y, err := decodeElement(pkg.VerificationShares[share.ID])
+		if err != nil {
+			return nil, fmt.Errorf("verification share of participant %d: %w", share.ID, err)
+		}
+		hiding, _ := decodeElement(c.Hiding)
+		binding, _ := decodeElement(c.Binding)
+		expected := new(edwards25519.Point).ScalarMult(edwards25519.NewScalar().Multiply(ctx.lambda(share.ID), ctx.challenge), y)
+		expected.Add(expected, new(edwards25519.Point).ScalarMult(ctx.bindingFactors[share.ID], binding))
+		expected.Add(expected, hiding)
+		if new(edwards25519.Point).ScalarBaseMult(zi).Equal(expected) != 1 {
+			return nil, fmt.Errorf("%w: signature share of participant %d", ErrInvalidShare, share.ID)
+		}
+		z.Add(z, zi)
+	}
+
+	sig := append(ctx.groupCommitment.Bytes(), z.Bytes()...)
+	if len(pkg.PublicKey) != ed25519.PublicKeySize || !ed25519.Verify(pkg.PublicKey, message, sig) {
+		return nil, fmt.Errorf("%w: public key does not match the verification shares", ErrInvalidSignature)
+	}
+	return sig, nil
+}
+
+// signingContext contains the values common to all participants of one signing operation.
+type signingContext struct {
+	ids             []Identifier
+	commitments     map[Identifier]*Commitment
+	bindingFactors  map[Identifier]*edwards25519.Scalar
+	groupCommitment *edwards25519.Point
+	challenge       *edwards25519.Scalar
+}
+
+func newSigningContext(pkg *PublicKeyPackage, message []byte, commitments []*Commitment) (*signingContext, error) {
+	if len(commitments) < pkg.Threshold {
+		return nil, fmt.Errorf("%w: %d commitments for threshold %d", ErrInvalidCommitments, len(commitments), pkg.Threshold)
+	}
+	ctx := &signingContext{
+		commitments:    make(map[Identifier]*Commitment, len(commitments)),
+		bindingFactors: make(map[Identifier]*edwards25519.Scalar, len(commitments)),
+	}
+
+	// the commitment list must be sorted by the identifiers
+	sorted := append([]*Commitment{}, commitments...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+
+	var encoded []byte
+	hiding := make([]*edwards25519.Point, len(sorted))
+	binding := make([]*edwards25519.Point, len(sorted))
+	for i, c := range sorted {
+		if _, ok := pkg.VerificationShares[c.ID]; !ok || ctx.commitments[c.ID] != nil {
+			return nil, fmt.Errorf("%w: %d", ErrInvalidIdentifier, c.ID)
+		}
+		ctx.ids = append(ctx.ids, c.ID)
+		ctx.commitments[c.ID] = c
+
+		var err error
+		if hiding[i], err = decodeElement(c.Hiding); err != nil {
+			return nil, fmt.Errorf("%w: hiding commitment of participant %d: %w", ErrInvalidCommitments, c.ID, err)
+		}
+		if binding[i], err = decodeElement(c.Binding); err != nil {
+			return nil, fmt.Errorf("%w: binding commitment of participant %d: %w", ErrInvalidCommitments, c.ID, err)
+		}
+		encoded = append(encoded, c.ID.scalar().Bytes()...)
+		encoded = append(encoded, c.Hiding...)
+		encoded = append(encoded, c.Binding...)
+	}
+
+	// binding factors ρᵢ = H1(PK || H4(msg) || H5(commitments) || i)
+	prefix := append([]byte{}, pkg.PublicKey...)
+	prefix = append(prefix, hash("msg", message)...)
+	prefix = append(prefix, hash("com", encoded)...)
+	for _, id := range ctx.ids {
+		ctx.bindingFactors[id] = hashToScalar("rho", prefix, id.scalar().Bytes())
+	}
+
+	// group commitment R = ∑(Dᵢ + [ρᵢ]Eᵢ)
+	r := edwards25519.NewIdentityPoint()
+	for i, id := range ctx.ids {
+		r.Add(r, hiding[i])
+		r.Add(r, new(edwards25519.Point).ScalarMult(ctx.bindingFactors[id], binding[i]))
+	}
+	ctx.groupCommitment = r
+	ctx.challenge = challenge(r, pkg.PublicKey, message)
+	return ctx, nil
+}
+
+// lambda returns the Lagrange coefficient of id among the signers.
+func (ctx *signingContext) lambda(id Identifier) *edwards25519.Scalar {
+	return lagrangeCoefficient(id, ctx.ids)
+}
@@ -0,0 +1,31 @@
+package ed25519
+
+import (
+	cryptorand "crypto/rand"
+	"io"
+)
+
+// NoiseSize is the size, in bytes, of the random noise mixed into the nonce by SignHedged.
+const NoiseSize = 32
+
+// SignHedged signs the message with privateKey using a hedged nonce and returns a signature. It will panic if
+// len(privateKey) is not PrivateKeySize.
+// In contrast to the deterministic Sign of RFC 8032, NoiseSize random bytes read from rand are mixed into the nonce
+// derivation, i.e. the nonce is computed as SHA-512(prefix || Z || M). This protects signing devices against fault
+// attacks that exploit two signatures sharing the same nonce, while a broken rand still yields a secure
+// deterministic nonce. If rand is nil, crypto/rand.Reader will be used.
+// The resulting signature is verified like any other Ed25519 signature, but is not reproducible.
+func SignHedged(rand io.Reader, privateKey PrivateKey, message []byte) ([]byte, error) {
+	if rand == nil {
+		rand = cryptorand.Reader
+	}
+
+	var noise [NoiseSize]byte
+	if _, err := io.ReadFull(rand, noise[:]); err != nil {
+		return nil, err
+	}
+
+	signature := make([]byte, SignatureSize)
+	sign(signature, privateKey, message, noise[:], domPrefixPure, "")
+	return signature, nil
+}
@@ -0,0 +1,42 @@
+package ed25519_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/ed25519"
+)
+
+func TestSignHedged(t *testing.T) {
+	publicKey, privateKey, _ := ed25519.GenerateKey(bytes.NewReader(nullSeed))
+	message := []byte("test message")
+
+	sig1, err := ed25519.SignHedged(nil, privateKey, message)
+	require.NoError(t, err)
+	sig2, err := ed25519.SignHedged(nil, privateKey, message)
+	require.NoError(t, err)
+
+	assert.True(t, ed25519.Verify(publicKey, message, sig1))
+	assert.True(t, ed25519.Verify(publicKey, message, sig2))
+	// different noise must lead to different nonces
+	assert.NotEqual(t, sig1, sig2)
+	assert.NotEqual(t, ed25519.Sign(privateKey, message), sig1)
+}
+
+func TestSignHedgedDeterministicNoise(t *testing.T) {
+	_, privateKey, _ := ed25519.GenerateKey(bytes.NewReader(nullSeed))
+	message := []byte("test message")
+
+	noise := make([]byte, ed25519.NoiseSize)
+	sig1, err := ed25519.SignHedged(bytes.NewReader(noise), privateKey, message)
+	require.NoError(t, err)
+	sig2, err := ed25519.SignHedged(bytes.NewReader(noise), privateKey, message)
+	require.NoError(t, err)
+	assert.Equal(t, sig1, sig2)
+
+	_, err = ed25519.SignHedged(new(bytes.Reader), privateKey, message)
+	assert.Error(t, err)
+}
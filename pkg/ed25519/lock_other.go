@@ -0,0 +1,11 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd)
+
+package ed25519
+
+// allocLocked returns a buffer of size n. Memory locking is not supported on this platform.
+func allocLocked(n int) ([]byte, bool) {
+	return make([]byte, n), false
+}
+
+// freeLocked releases a buffer returned by allocLocked.
+func freeLocked([]byte, bool) {}
@@ -0,0 +1,27 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+
+package ed25519
+
+import "syscall"
+
+// allocLocked returns a buffer of size n outside of the Go heap and tries to lock it into memory.
+// It reports whether the buffer is locked.
+func allocLocked(n int) ([]byte, bool) {
+	buf, err := syscall.Mmap(-1, 0, n, syscall.PROT_READ|syscall.PROT_WRITE, syscall.MAP_ANON|syscall.MAP_PRIVATE)
+	if err != nil {
+		return make([]byte, n), false
+	}
+	if err := syscall.Mlock(buf); err != nil {
+		_ = syscall.Munmap(buf)
+		return make([]byte, n), false
+	}
+	return buf, true
+}
+
+// freeLocked releases a buffer returned by allocLocked.
+func freeLocked(buf []byte, locked bool) {
+	if locked {
+		_ = syscall.Munlock(buf)
+		_ = syscall.Munmap(buf)
+	}
+}
@@ -0,0 +1,210 @@
+/*
+Package musig implements n-of-n MuSig2 multi-signatures for Ed25519.
+
+The public keys of all signers are aggregated into a single Ed25519 public key (see AggregateKeys), which can be used
+like any other key, e.g. to derive an address. Signing takes two rounds: each signer starts a Session and broadcasts
+its PublicNonce; once all nonces have been received, each signer computes a PartialSignature, which are combined
+into a standard Ed25519 signature using Aggregate. All protocol messages can be serialized for transport using
+encoding.BinaryMarshaler.
+*/
+package musig
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"sort"
+
+	"filippo.io/edwards25519"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/ed25519"
+)
+
+const (
+	// PublicNonceSize is the size, in bytes, of a serialized PublicNonce.
+	PublicNonceSize = 64
+	// PartialSignatureSize is the size, in bytes, of a serialized PartialSignature.
+	PartialSignatureSize = 32
+)
+
+var (
+	// ErrInvalidPublicKey is returned when a public key is invalid, duplicated or not part of the aggregate key.
+	ErrInvalidPublicKey = errors.New("invalid public key")
+	// ErrInvalidNonce is returned when public nonces are invalid or missing.
+	ErrInvalidNonce = errors.New("invalid nonce")
+	// ErrInvalidPartialSignature is returned when a partial signature is invalid or missing.
+	ErrInvalidPartialSignature = errors.New("invalid partial signature")
+)
+
+// AggregateKey is the aggregation of the public keys of all signers.
+type AggregateKey struct {
+	publicKey    ed25519.PublicKey
+	keys         []ed25519.PublicKey
+	points       []*edwards25519.Point
+	coefficients []*edwards25519.Scalar
+}
+
+// AggregateKeys aggregates the public keys of all signers. The order of publicKeys does not matter.
+func AggregateKeys(publicKeys []ed25519.PublicKey) (*AggregateKey, error) {
+	if len(publicKeys) == 0 {
+		return nil, fmt.Errorf("%w: no public keys", ErrInvalidPublicKey)
+	}
+	keys := append([]ed25519.PublicKey{}, publicKeys...)
+	sort.Slice(keys, func(i, j int) bool { return bytes.Compare(keys[i], keys[j]) < 0 })
+
+	// L = H(X₁ || … || Xₙ)
+	var list []byte
+	for i, key := range keys {
+		if err := ed25519.CheckPublicKey(key); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidPublicKey, err)
+		}
+		if i > 0 && bytes.Equal(key, keys[i-1]) {
+			return nil, fmt.Errorf("%w: duplicate key %x", ErrInvalidPublicKey, []byte(key))
+		}
+		list = append(list, key...)
+	}
+	l := hash("keys", list)
+
+	// X = ∑aᵢXᵢ with aᵢ = H(L || Xᵢ)
+	agg := &AggregateKey{keys: keys}
+	x := edwards25519.NewIdentityPoint()
+	for _, key := range keys {
+		p, _ := new(edwards25519.Point).SetBytes(key)
+		a := hashToScalar("coefficient", l, key)
+		agg.points = append(agg.points, p)
+		agg.coefficients = append(agg.coefficients, a)
+		x.Add(x, new(edwards25519.Point).ScalarMult(a, p))
+	}
+	agg.publicKey = x.Bytes()
+	return agg, nil
+}
+
+// PublicKey returns the aggregated Ed25519 public key.
+func (agg *AggregateKey) PublicKey() ed25519.PublicKey {
+	return append(ed25519.PublicKey{}, agg.publicKey...)
+}
+
+// Keys returns the public keys of all signers in the canonical order, in which their public nonces and partial
+// signatures must be provided.
+func (agg *AggregateKey) Keys() []ed25519.PublicKey {
+	return append([]ed25519.PublicKey{}, agg.keys...)
+}
+
+// index returns the position of publicKey in the sorted keys.
+func (agg *AggregateKey) index(publicKey ed25519.PublicKey) (int, error) {
+	i := sort.Search(len(agg.keys), func(i int) bool { return bytes.Compare(agg.keys[i], publicKey) >= 0 })
+	if i == len(agg.keys) || !bytes.Equal(agg.keys[i], publicKey) {
+		return 0, fmt.Errorf("%w: %x not aggregated", ErrInvalidPublicKey, []byte(publicKey))
+	}
+	return i, nil
+}
+
+// PublicNonce is the commitment to the two secret nonces of a signer, which is broadcast in the first round.
+type PublicNonce struct {
+	r1, r2 *edwards25519.Point
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (n *PublicNonce) MarshalBinary() ([]byte, error) {
+	return append(n.r1.Bytes(), n.r2.Bytes()...), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (n *PublicNonce) UnmarshalBinary(data []byte) error {
+	if len(data) != PublicNonceSize {
+		return fmt.Errorf("%w: invalid length %d", ErrInvalidNonce, len(data))
+	}
+	r1, err := new(edwards25519.Point).SetBytes(data[:32])
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidNonce, err)
+	}
+	r2, err := new(edwards25519.Point).SetBytes(data[32:])
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidNonce, err)
+	}
+	n.r1, n.r2 = r1, r2
+	return nil
+}
+
+// PartialSignature is the signature share of a signer, which is sent to the aggregator in the second round.
+type PartialSignature struct {
+	s *edwards25519.Scalar
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (p *PartialSignature) MarshalBinary() ([]byte, error) {
+	return p.s.Bytes(), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (p *PartialSignature) UnmarshalBinary(data []byte) error {
+	s, err := edwards25519.NewScalar().SetCanonicalBytes(data)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidPartialSignature, err)
+	}
+	p.s = s
+	return nil
+}
+
+// signingContext contains the values common to all signers of one message.
+type signingContext struct {
+	r *edwards25519.Point
+	b *edwards25519.Scalar
+	c *edwards25519.Scalar
+}
+
+// newSigningContext aggregates the public nonces of all signers, which must be in the order of agg.Keys().
+func newSigningContext(agg *AggregateKey, message []byte, nonces []*PublicNonce) *signingContext {
+	r1, r2 := edwards25519.NewIdentityPoint(), edwards25519.NewIdentityPoint()
+	for _, n := range nonces {
+		r1.Add(r1, n.r1)
+		r2.Add(r2, n.r2)
+	}
+	// b = H(X || R₁ || R₂ || m) and R = R₁ + [b]R₂
+	b := hashToScalar("nonce coefficient", agg.publicKey, r1.Bytes(), r2.Bytes(), message)
+	r := new(edwards25519.Point).ScalarMult(b, r2)
+	r.Add(r, r1)
+
+	// c = SHA-512(R || X || m) as in Ed25519
+	h := sha512.New()
+	h.Write(r.Bytes())
+	h.Write(agg.publicKey)
+	h.Write(message)
+	c, err := edwards25519.NewScalar().SetUniformBytes(h.Sum(nil))
+	if err != nil {
+		panic("musig: internal error: setting scalar failed")
+	}
+	return &signingContext{r: r, b: b, c: c}
+}
+
+// checkNonces checks that there is one valid nonce for each of the keys of agg.
+func checkNonces(agg *AggregateKey, nonces []*PublicNonce) error {
+	if len(nonces) != len(agg.keys) {
+		return fmt.Errorf("%w: expected %d nonces, got %d", ErrInvalidNonce, len(agg.keys), len(nonces))
+	}
+	for i, n := range nonces {
+		if n == nil || n.r1 == nil {
+			return fmt.Errorf("%w: missing nonce of %x", ErrInvalidNonce, []byte(agg.keys[i]))
+		}
+	}
+	return nil
+}
+
+func hash(tag string, msgs ...[]byte) []byte {
+	h := sha512.New()
+	h.Write([]byte("MuSig2/Ed25519/"))
+	h.Write([]byte(tag))
+	for _, m := range msgs {
+		h.Write(m)
+	}
+	return h.Sum(nil)
+}
+
+func hashToScalar(tag string, msgs ...[]byte) *edwards25519.Scalar {
+	s, err := edwards25519.NewScalar().SetUniformBytes(hash(tag, msgs...))
+	if err != nil {
+		panic("musig: internal error: setting scalar failed")
+	}
+	return s
+}
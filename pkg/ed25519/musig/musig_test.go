@@ -0,0 +1,116 @@
+package musig_test
+
+import (
+	std "crypto/ed25519"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/ed25519"
+	"github.com/iotaledger/iota-crypto-demo/pkg/ed25519/musig"
+)
+
+var message = []byte("test message")
+
+func generateKeys(t *testing.T, n int) ([]ed25519.PublicKey, map[string]ed25519.PrivateKey) {
+	publicKeys := make([]ed25519.PublicKey, n)
+	privateKeys := make(map[string]ed25519.PrivateKey, n)
+	for i := range publicKeys {
+		publicKey, privateKey, err := ed25519.GenerateKey(nil)
+		require.NoError(t, err)
+		publicKeys[i] = publicKey
+		privateKeys[string(publicKey)] = privateKey
+	}
+	return publicKeys, privateKeys
+}
+
+// transmit simulates sending m over the network.
+func transmit[T any, PT interface {
+	*T
+	MarshalBinary() ([]byte, error)
+	UnmarshalBinary([]byte) error
+}](t *testing.T, m PT) PT {
+	data, err := m.MarshalBinary()
+	require.NoError(t, err)
+	res := PT(new(T))
+	require.NoError(t, res.UnmarshalBinary(data))
+	return res
+}
+
+func TestMuSig(t *testing.T) {
+	for _, n := range []int{1, 2, 5} {
+		publicKeys, privateKeys := generateKeys(t, n)
+		agg, err := musig.AggregateKeys(publicKeys)
+		require.NoError(t, err)
+
+		keys := agg.Keys()
+		sessions := make([]*musig.Session, n)
+		nonces := make([]*musig.PublicNonce, n)
+		for i, key := range keys {
+			sessions[i], err = musig.NewSession(nil, agg, privateKeys[string(key)], message)
+			require.NoError(t, err)
+			nonces[i] = transmit(t, sessions[i].PublicNonce())
+		}
+		partials := make([]*musig.PartialSignature, n)
+		for i, s := range sessions {
+			p, err := s.Sign(nonces)
+			require.NoError(t, err)
+			partials[i] = transmit(t, p)
+		}
+
+		sig, err := musig.Aggregate(agg, message, nonces, partials)
+		require.NoError(t, err)
+		assert.True(t, ed25519.Verify(agg.PublicKey(), message, sig))
+		assert.True(t, std.Verify(std.PublicKey(agg.PublicKey()), message, sig))
+
+		// sessions must not be reused
+		_, err = sessions[0].Sign(nonces)
+		assert.ErrorIs(t, err, musig.ErrInvalidNonce)
+	}
+}
+
+func TestAggregateKeys(t *testing.T) {
+	publicKeys, _ := generateKeys(t, 3)
+	agg1, err := musig.AggregateKeys(publicKeys)
+	require.NoError(t, err)
+	agg2, err := musig.AggregateKeys([]ed25519.PublicKey{publicKeys[2], publicKeys[0], publicKeys[1]})
+	require.NoError(t, err)
+	assert.Equal(t, agg1.PublicKey(), agg2.PublicKey())
+	assert.NoError(t, ed25519.CheckPublicKey(agg1.PublicKey()))
+
+	_, err = musig.AggregateKeys(nil)
+	assert.ErrorIs(t, err, musig.ErrInvalidPublicKey)
+	_, err = musig.AggregateKeys([]ed25519.PublicKey{publicKeys[0], publicKeys[0]})
+	assert.ErrorIs(t, err, musig.ErrInvalidPublicKey)
+}
+
+func TestInvalidPartialSignature(t *testing.T) {
+	publicKeys, privateKeys := generateKeys(t, 2)
+	agg, err := musig.AggregateKeys(publicKeys)
+	require.NoError(t, err)
+	keys := agg.Keys()
+
+	s0, err := musig.NewSession(nil, agg, privateKeys[string(keys[0])], message)
+	require.NoError(t, err)
+	// the second signer signs a different message
+	s1, err := musig.NewSession(nil, agg, privateKeys[string(keys[1])], []byte("wrong message"))
+	require.NoError(t, err)
+	nonces := []*musig.PublicNonce{s0.PublicNonce(), s1.PublicNonce()}
+
+	p0, err := s0.Sign(nonces)
+	require.NoError(t, err)
+	p1, err := s1.Sign(nonces)
+	require.NoError(t, err)
+	_, err = musig.Aggregate(agg, message, nonces, []*musig.PartialSignature{p0, p1})
+	assert.ErrorIs(t, err, musig.ErrInvalidPartialSignature)
+
+	// signers outside the aggregate key are rejected
+	_, other, _ := ed25519.GenerateKey(nil)
+	_, err = musig.NewSession(nil, agg, other, message)
+	assert.ErrorIs(t, err, musig.ErrInvalidPublicKey)
+
+	// nonces must be complete
+	_, err = musig.Aggregate(agg, message, nonces[:1], []*musig.PartialSignature{p0})
+	assert.ErrorIs(t, err, musig.ErrInvalidNonce)
+}
@@ -0,0 +1,131 @@
+package musig
+
+import (
+	cryptorand "crypto/rand"
+	"crypto/sha512"
+	"fmt"
+	"io"
+
+	"filippo.io/edwards25519"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/ed25519"
+)
+
+// Session holds the secret state of a signer for signing a single message.
+type Session struct {
+	agg     *AggregateKey
+	index   int
+	message []byte
+	x       *edwards25519.Scalar
+	k1, k2  *edwards25519.Scalar
+	nonce   *PublicNonce
+}
+
+// NewSession starts signing message with privateKey, whose public key must be part of agg, and generates fresh
+// secret nonces. If rand is nil, crypto/rand.Reader will be used.
+func NewSession(rand io.Reader, agg *AggregateKey, privateKey ed25519.PrivateKey, message []byte) (*Session, error) {
+	if rand == nil {
+		rand = cryptorand.Reader
+	}
+	//nolint:forcetypeassert
+	index, err := agg.index(privateKey.Public().(ed25519.PublicKey))
+	if err != nil {
+		return nil, err
+	}
+
+	h := sha512.Sum512(privateKey.Seed())
+	x, err := edwards25519.NewScalar().SetBytesWithClamping(h[:32])
+	if err != nil {
+		panic("musig: internal error: setting scalar failed")
+	}
+
+	// derive the nonces from fresh randomness, the key and the message, so that a weak rand alone does not leak them
+	var k [2]*edwards25519.Scalar
+	for i := range k {
+		var b [32]byte
+		if _, err := io.ReadFull(rand, b[:]); err != nil {
+			return nil, err
+		}
+		k[i] = hashToScalar("nonce", b[:], h[32:], agg.publicKey, message, []byte{byte(i)})
+	}
+
+	return &Session{
+		agg:     agg,
+		index:   index,
+		message: append([]byte{}, message...),
+		x:       x,
+		k1:      k[0],
+		k2:      k[1],
+		nonce: &PublicNonce{
+			r1: new(edwards25519.Point).ScalarBaseMult(k[0]),
+			r2: new(edwards25519.Point).ScalarBaseMult(k[1]),
+		},
+	}, nil
+}
+
+// PublicNonce returns the public nonce of the signer to broadcast to all other signers.
+func (s *Session) PublicNonce() *PublicNonce {
+	return s.nonce
+}
+
+// Sign computes the partial signature of the signer from the public nonces of all signers in the order of
+// agg.Keys(). The secret nonces are erased, so Sign can only be called once per session.
+func (s *Session) Sign(nonces []*PublicNonce) (*PartialSignature, error) {
+	if s.k1 == nil {
+		return nil, fmt.Errorf("%w: session already used", ErrInvalidNonce)
+	}
+	if err := checkNonces(s.agg, nonces); err != nil {
+		return nil, err
+	}
+	if own := nonces[s.index]; own.r1.Equal(s.nonce.r1) != 1 || own.r2.Equal(s.nonce.r2) != 1 {
+		return nil, fmt.Errorf("%w: own nonce does not match", ErrInvalidNonce)
+	}
+	ctx := newSigningContext(s.agg, s.message, nonces)
+
+	// sᵢ = k₁ + b⋅k₂ + c⋅aᵢ⋅xᵢ
+	z := edwards25519.NewScalar().Multiply(ctx.c, s.agg.coefficients[s.index])
+	z.Multiply(z, s.x)
+	z.MultiplyAdd(ctx.b, s.k2, z)
+	z.Add(z, s.k1)
+
+	// erase the secret nonces to prevent their reuse
+	s.k1.Set(edwards25519.NewScalar())
+	s.k2.Set(edwards25519.NewScalar())
+	s.k1, s.k2 = nil, nil
+
+	return &PartialSignature{s: z}, nil
+}
+
+// Aggregate verifies the partial signatures of all signers and combines them into a standard Ed25519 signature of
+// message under agg.PublicKey(). Both nonces and partials must be in the order of agg.Keys().
+// If a partial signature is invalid, the error wrapping ErrInvalidPartialSignature identifies the signer.
+func Aggregate(agg *AggregateKey, message []byte, nonces []*PublicNonce, partials []*PartialSignature) ([]byte, error) {
+	if err := checkNonces(agg, nonces); err != nil {
+		return nil, err
+	}
+	if len(partials) != len(agg.keys) {
+		return nil, fmt.Errorf("%w: expected %d partial signatures, got %d", ErrInvalidPartialSignature, len(agg.keys), len(partials))
+	}
+	ctx := newSigningContext(agg, message, nonces)
+
+	sum := edwards25519.NewScalar()
+	for i, p := range partials {
+		if p == nil || p.s == nil {
+			return nil, fmt.Errorf("%w: missing partial signature of %x", ErrInvalidPartialSignature, []byte(agg.keys[i]))
+		}
+		// check [sᵢ]B == R₁ᵢ + [b]R₂ᵢ + [c⋅aᵢ]Xᵢ
+		expected := new(edwards25519.Point).ScalarMult(edwards25519.NewScalar().Multiply(ctx.c, agg.coefficients[i]), agg.points[i])
+		expected.Add(expected, new(edwards25519.Point).ScalarMult(ctx.b, nonces[i].r2))
+		expected.Add(expected, nonces[i].r1)
+		if new(edwards25519.Point).ScalarBaseMult(p.s).Equal(expected) != 1 {
+			return nil, fmt.Errorf("%w: signer %x", ErrInvalidPartialSignature, []byte(agg.keys[i]))
+		}
+		sum.Add(sum, p.s)
+	}
+
+	sig := append(ctx.r.Bytes(), sum.Bytes()...)
+	if !ed25519.Verify(agg.publicKey, message, sig) {
+		panic("musig: internal error: invalid signature")
+	}
+	return sig, nil
+}
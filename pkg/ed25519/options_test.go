@@ -0,0 +1,121 @@
+package ed25519_test
+
+import (
+	"bytes"
+	"crypto"
+	std "crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha512"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/internal/hexutil"
+	"github.com/iotaledger/iota-crypto-demo/pkg/ed25519"
+)
+
+func TestEd25519ph(t *testing.T) {
+	// test vector from RFC 8032, Section 7.3
+	privateKey := ed25519.NewKeyFromSeed(hexutil.MustDecodeString("833fe62409237b9d62ec77587520911e9a759cec1d19755b7da901b96dca3d42"))
+	//nolint:forcetypeassert
+	publicKey := privateKey.Public().(ed25519.PublicKey)
+	assert.EqualValues(t, hexutil.MustDecodeString("ec172b93ad5e563bf4932c70e1245034c35467ef2efd4d64ebf819683467e2bf"), publicKey)
+
+	digest := sha512.Sum512([]byte("abc"))
+	opts := &ed25519.Options{Hash: crypto.SHA512}
+	sig, err := privateKey.Sign(nil, digest[:], opts)
+	require.NoError(t, err)
+	assert.Equal(t, hexutil.MustDecodeString("98a70222f0b8121aa9d30f813d683f809e462b469c7ff87639499bb94e6dae4131f85042463c2a355a2003d062adf5aaa10b8c61e636062aaad11c2a26083406"), sig)
+	assert.NoError(t, ed25519.VerifyWithOptions(publicKey, digest[:], sig, opts))
+
+	// the signature is not valid for plain Ed25519 or a different context
+	assert.False(t, ed25519.Verify(publicKey, digest[:], sig))
+	assert.Error(t, ed25519.VerifyWithOptions(publicKey, digest[:], sig, &ed25519.Options{Hash: crypto.SHA512, Context: "foo"}))
+}
+
+func TestEd25519ctx(t *testing.T) {
+	publicKey, privateKey, _ := ed25519.GenerateKey(bytes.NewReader(nullSeed))
+	message := []byte("test message")
+
+	opts := &ed25519.Options{Context: "foo"}
+	sig, err := privateKey.Sign(nil, message, opts)
+	require.NoError(t, err)
+	assert.NoError(t, ed25519.VerifyWithOptions(publicKey, message, sig, opts))
+
+	assert.False(t, ed25519.Verify(publicKey, message, sig))
+	assert.Error(t, ed25519.VerifyWithOptions(publicKey, message, sig, &ed25519.Options{Context: "bar"}))
+	assert.Error(t, ed25519.VerifyWithOptions(publicKey, []byte("wrong message"), sig, opts))
+}
+
+func TestNewPrehash(t *testing.T) {
+	publicKey, privateKey, _ := ed25519.GenerateKey(bytes.NewReader(nullSeed))
+	message := strings.Repeat("large message ", 1000)
+
+	// stream the message in chunks
+	h := ed25519.NewPrehash()
+	_, err := io.Copy(h, strings.NewReader(message))
+	require.NoError(t, err)
+	opts := &ed25519.Options{Hash: crypto.SHA512, Context: "stream"}
+	sig, err := privateKey.Sign(nil, h.Sum(nil), opts)
+	require.NoError(t, err)
+
+	digest := sha512.Sum512([]byte(message))
+	assert.NoError(t, ed25519.VerifyWithOptions(publicKey, digest[:], sig, opts))
+}
+
+func TestOptionsInvalid(t *testing.T) {
+	publicKey, privateKey, _ := ed25519.GenerateKey(bytes.NewReader(nullSeed))
+	message := []byte("test message")
+	sig := ed25519.Sign(privateKey, message)
+
+	var tests = []*struct {
+		name    string
+		message []byte
+		opts    *ed25519.Options
+	}{
+		{"unsupported hash", message, &ed25519.Options{Hash: crypto.SHA256}},
+		{"bad hash length", message, &ed25519.Options{Hash: crypto.SHA512}},
+		{"ctx too long", message, &ed25519.Options{Context: strings.Repeat("x", 256)}},
+		{"ph ctx too long", make([]byte, sha512.Size), &ed25519.Options{Hash: crypto.SHA512, Context: strings.Repeat("x", 256)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := privateKey.Sign(nil, tt.message, tt.opts)
+			assert.Error(t, err)
+			assert.Error(t, ed25519.VerifyWithOptions(publicKey, tt.message, sig, tt.opts))
+		})
+	}
+}
+
+func TestOptionsSTDLib(t *testing.T) {
+	seed := make([]byte, ed25519.SeedSize)
+	for i := 0; i < 100; i++ {
+		_, err := rand.Read(seed)
+		assert.NoError(t, err)
+
+		pub, priv, _ := ed25519.GenerateKey(bytes.NewReader(seed))
+		_, privExpected, _ := std.GenerateKey(bytes.NewReader(seed))
+
+		message := []byte("test message")
+		digest := sha512.Sum512(message)
+		for _, opts := range []*ed25519.Options{
+			{Context: "test"},
+			{Hash: crypto.SHA512},
+			{Hash: crypto.SHA512, Context: "test"},
+		} {
+			m := message
+			if opts.Hash == crypto.SHA512 {
+				m = digest[:]
+			}
+			sig, err := priv.Sign(nil, m, opts)
+			require.NoError(t, err)
+			sigExpected, err := privExpected.Sign(nil, m, &std.Options{Hash: opts.Hash, Context: opts.Context})
+			require.NoError(t, err)
+			assert.Equalf(t, sigExpected, sig, "different signature")
+			assert.NoError(t, ed25519.VerifyWithOptions(pub, m, sig, opts))
+		}
+	}
+}
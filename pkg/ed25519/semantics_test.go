@@ -0,0 +1,59 @@
+package ed25519_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"filippo.io/edwards25519"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/ed25519"
+)
+
+func isCanonical(t *testing.T, b []byte) bool {
+	p, err := new(edwards25519.Point).SetBytes(b)
+	assert.NoError(t, err)
+	return bytes.Equal(p.Bytes(), b)
+}
+
+func TestSemantics(t *testing.T) {
+	publicKey, privateKey, _ := ed25519.GenerateKey(bytes.NewReader(nullSeed))
+	message := []byte("test message")
+	sig := ed25519.Sign(privateKey, message)
+
+	for _, semantics := range []ed25519.Semantics{ed25519.ZIP215, ed25519.Cofactorless, ed25519.Strict, ed25519.CanonicalZIP215} {
+		opts := &ed25519.Options{Semantics: semantics}
+		assert.NoErrorf(t, ed25519.VerifyWithOptions(publicKey, message, sig, opts), "semantics %d", semantics)
+		assert.Errorf(t, ed25519.VerifyWithOptions(publicKey, []byte("wrong message"), sig, opts), "semantics %d", semantics)
+	}
+	assert.Error(t, ed25519.VerifyWithOptions(publicKey, message, sig, &ed25519.Options{Semantics: ed25519.CanonicalZIP215 + 1}))
+}
+
+func TestSemanticsZIP215(t *testing.T) {
+	verify := func(publicKey, sig []byte, semantics ed25519.Semantics) bool {
+		return ed25519.VerifyWithOptions(publicKey, message, sig, &ed25519.Options{Semantics: semantics}) == nil
+	}
+
+	var cofactorless, strict int
+	for i, tt := range tests {
+		publicKey, _ := hex.DecodeString(tt.pk)
+		sig, _ := hex.DecodeString(tt.s)
+
+		assert.Truef(t, verify(publicKey, sig, ed25519.ZIP215), "test %d failed to verify", i)
+		// all vectors use small-order points, so only some of them satisfy the cofactorless equation
+		if verify(publicKey, sig, ed25519.Cofactorless) {
+			cofactorless++
+		}
+		// strict verification must additionally reject all non-canonical encodings
+		canonical := isCanonical(t, publicKey) && isCanonical(t, sig[:32])
+		assert.Equalf(t, verify(publicKey, sig, ed25519.Cofactorless) && canonical, verify(publicKey, sig, ed25519.Strict), "test %d", i)
+		// the canonical ZIP215 semantics must only reject non-canonical signatures
+		assert.Equalf(t, ed25519.IsCanonical(sig), verify(publicKey, sig, ed25519.CanonicalZIP215), "test %d", i)
+		if verify(publicKey, sig, ed25519.Strict) {
+			strict++
+		}
+	}
+	assert.Less(t, cofactorless, len(tests))
+	assert.Less(t, strict, cofactorless)
+}
@@ -0,0 +1,141 @@
+package ed25519
+
+import (
+	"crypto"
+	stded25519 "crypto/ed25519"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"filippo.io/edwards25519"
+)
+
+var (
+	// ErrWiped is returned when using a SigningKey after Wipe has been called.
+	ErrWiped = errors.New("ed25519: signing key has been wiped")
+	// ErrSerialization is returned when trying to serialize a SigningKey.
+	ErrSerialization = errors.New("ed25519: signing key must not be serialized")
+)
+
+// layout of the secret buffer of a SigningKey
+const (
+	skSeed   = 0
+	skScalar = skSeed + SeedSize
+	skPrefix = skScalar + 32
+	skSize   = skPrefix + 32
+)
+
+// SigningKey is an Ed25519 private key that keeps its seed and expanded secret scalar in a dedicated buffer.
+// Where supported, the buffer is locked into memory, so that it is never written to swap, and it can be erased using
+// Wipe. As a locked buffer is not managed by the garbage collector, Wipe must be called once the key is no longer
+// needed. To prevent accidental leaks, a SigningKey is redacted when formatted and refuses to be marshaled.
+// It implements crypto.Signer.
+type SigningKey struct {
+	buf       []byte
+	locked    bool
+	publicKey PublicKey
+}
+
+// NewSigningKey creates a SigningKey from privateKey. The caller should erase privateKey afterwards.
+// It will panic if len(privateKey) is not PrivateKeySize.
+func NewSigningKey(privateKey PrivateKey) *SigningKey {
+	if l := len(privateKey); l != PrivateKeySize {
+		panic("ed25519: bad private key length: " + strconv.Itoa(l))
+	}
+	k := &SigningKey{publicKey: append(PublicKey{}, privateKey[SeedSize:]...)}
+	k.buf, k.locked = allocLocked(skSize)
+	copy(k.buf[skSeed:], privateKey[:SeedSize])
+
+	h := sha512.Sum512(privateKey[:SeedSize])
+	s, err := edwards25519.NewScalar().SetBytesWithClamping(h[:32])
+	if err != nil {
+		panic("ed25519: internal error: setting scalar failed")
+	}
+	copy(k.buf[skScalar:], s.Bytes())
+	copy(k.buf[skPrefix:], h[32:])
+	clear(h[:])
+	return k
+}
+
+// NewSigningKeyFromStdlib creates a SigningKey from a private key of the standard library "crypto/ed25519".
+func NewSigningKeyFromStdlib(privateKey stded25519.PrivateKey) *SigningKey {
+	return NewSigningKey(PrivateKey(privateKey))
+}
+
+// Public returns the PublicKey corresponding to k.
+func (k *SigningKey) Public() crypto.PublicKey {
+	return append(PublicKey{}, k.publicKey...)
+}
+
+// Locked reports whether the secret buffer of k is locked into memory.
+func (k *SigningKey) Locked() bool {
+	return k.locked
+}
+
+// PrivateKey returns a copy of k as a PrivateKey, which is not protected anymore.
+func (k *SigningKey) PrivateKey() (PrivateKey, error) {
+	if k.buf == nil {
+		return nil, ErrWiped
+	}
+	return append(append(PrivateKey{}, k.buf[skSeed:skScalar]...), k.publicKey...), nil
+}
+
+// Stdlib returns a copy of k as a private key of the standard library "crypto/ed25519".
+func (k *SigningKey) Stdlib() (stded25519.PrivateKey, error) {
+	priv, err := k.PrivateKey()
+	return stded25519.PrivateKey(priv), err
+}
+
+// Sign signs the message with k. rand is ignored. The options are the same as for PrivateKey.Sign.
+func (k *SigningKey) Sign(_ io.Reader, message []byte, opts crypto.SignerOpts) ([]byte, error) {
+	if k.buf == nil {
+		return nil, ErrWiped
+	}
+	s, err := edwards25519.NewScalar().SetCanonicalBytes(k.buf[skScalar:skPrefix])
+	if err != nil {
+		panic("ed25519: internal error: setting scalar failed")
+	}
+	return signWithOptions(s, k.buf[skPrefix:], k.publicKey, message, opts)
+}
+
+// Wipe erases the secret key material of k and releases its buffer. Afterwards, k can no longer be used for signing.
+func (k *SigningKey) Wipe() {
+	if k.buf == nil {
+		return
+	}
+	clear(k.buf)
+	freeLocked(k.buf, k.locked)
+	k.buf, k.locked = nil, false
+}
+
+// String returns a redacted representation of k, which only contains the public key.
+func (k *SigningKey) String() string {
+	return fmt.Sprintf("ed25519.SigningKey{public: %x}", []byte(k.publicKey))
+}
+
+// GoString implements fmt.GoStringer and returns the same redacted representation as String.
+func (k *SigningKey) GoString() string {
+	return k.String()
+}
+
+// Format implements fmt.Formatter, so that all verbs, including %x and %v, print the redacted representation.
+func (k *SigningKey) Format(f fmt.State, _ rune) {
+	_, _ = io.WriteString(f, k.String())
+}
+
+// MarshalJSON implements json.Marshaler and always fails to prevent accidental serialization.
+func (k *SigningKey) MarshalJSON() ([]byte, error) {
+	return nil, ErrSerialization
+}
+
+// MarshalText implements encoding.TextMarshaler and always fails to prevent accidental serialization.
+func (k *SigningKey) MarshalText() ([]byte, error) {
+	return nil, ErrSerialization
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler and always fails to prevent accidental serialization.
+func (k *SigningKey) MarshalBinary() ([]byte, error) {
+	return nil, ErrSerialization
+}
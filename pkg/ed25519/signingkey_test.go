@@ -0,0 +1,87 @@
+package ed25519_test
+
+import (
+	"bytes"
+	"crypto"
+	std "crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/ed25519"
+)
+
+func TestSigningKey(t *testing.T) {
+	publicKey, privateKey, _ := ed25519.GenerateKey(bytes.NewReader(nullSeed))
+	message := []byte("test message")
+
+	k := ed25519.NewSigningKey(privateKey)
+	defer k.Wipe()
+	assert.Equal(t, publicKey, k.Public())
+
+	sig, err := k.Sign(nil, message, crypto.Hash(0))
+	require.NoError(t, err)
+	assert.Equal(t, ed25519.Sign(privateKey, message), sig)
+
+	opts := &ed25519.Options{Context: "test"}
+	sig, err = k.Sign(nil, message, opts)
+	require.NoError(t, err)
+	assert.NoError(t, ed25519.VerifyWithOptions(publicKey, message, sig, opts))
+
+	priv, err := k.PrivateKey()
+	require.NoError(t, err)
+	assert.Equal(t, privateKey, priv)
+}
+
+func TestSigningKeyStdlib(t *testing.T) {
+	_, stdPrivateKey, err := std.GenerateKey(nil)
+	require.NoError(t, err)
+	message := []byte("test message")
+
+	k := ed25519.NewSigningKeyFromStdlib(stdPrivateKey)
+	defer k.Wipe()
+	sig, err := k.Sign(nil, message, crypto.Hash(0))
+	require.NoError(t, err)
+	assert.Equal(t, std.Sign(stdPrivateKey, message), sig)
+
+	converted, err := k.Stdlib()
+	require.NoError(t, err)
+	assert.True(t, stdPrivateKey.Equal(converted))
+}
+
+func TestSigningKeyWipe(t *testing.T) {
+	_, privateKey, _ := ed25519.GenerateKey(bytes.NewReader(nullSeed))
+	k := ed25519.NewSigningKey(privateKey)
+	k.Wipe()
+	k.Wipe() // wiping twice is allowed
+
+	_, err := k.Sign(nil, []byte("test message"), crypto.Hash(0))
+	assert.ErrorIs(t, err, ed25519.ErrWiped)
+	_, err = k.PrivateKey()
+	assert.ErrorIs(t, err, ed25519.ErrWiped)
+	_, err = k.Stdlib()
+	assert.ErrorIs(t, err, ed25519.ErrWiped)
+}
+
+func TestSigningKeyRedacted(t *testing.T) {
+	_, privateKey, _ := ed25519.GenerateKey(bytes.NewReader(nullSeed))
+	k := ed25519.NewSigningKey(privateKey)
+	defer k.Wipe()
+
+	seed := hex.EncodeToString(privateKey.Seed())
+	for _, format := range []string{"%v", "%+v", "%#v", "%s", "%x", "%X"} {
+		s := fmt.Sprintf(format, k)
+		assert.NotContainsf(t, s, seed, "format %s", format)
+		assert.Equalf(t, k.String(), s, "format %s", format)
+	}
+	assert.NotContains(t, fmt.Sprint(struct{ Key *ed25519.SigningKey }{k}), seed)
+
+	_, err := json.Marshal(k)
+	assert.ErrorIs(t, err, ed25519.ErrSerialization)
+	_, err = json.Marshal(struct{ Key *ed25519.SigningKey }{k})
+	assert.ErrorIs(t, err, ed25519.ErrSerialization)
+}
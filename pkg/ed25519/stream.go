@@ -0,0 +1,28 @@
+package ed25519
+
+import (
+	"crypto"
+	"io"
+)
+
+// SignReader signs the message read from r until EOF with privateKey using Ed25519ph.
+// As the message is streamed through the prehash, it never has to be held in memory completely, which makes this
+// suitable for large payloads like firmware images or snapshots. The signature must be verified with VerifyReader
+// or with VerifyWithOptions using crypto.SHA512, as it is not a valid plain Ed25519 signature of the message.
+func SignReader(privateKey PrivateKey, r io.Reader) ([]byte, error) {
+	h := NewPrehash()
+	if _, err := io.Copy(h, r); err != nil {
+		return nil, err
+	}
+	return privateKey.Sign(nil, h.Sum(nil), crypto.SHA512)
+}
+
+// VerifyReader verifies that sig is a valid Ed25519ph signature by publicKey of the message read from r until EOF.
+// A valid signature is indicated by returning a nil error. It will panic if len(publicKey) is not PublicKeySize.
+func VerifyReader(publicKey PublicKey, r io.Reader, sig []byte) error {
+	h := NewPrehash()
+	if _, err := io.Copy(h, r); err != nil {
+		return err
+	}
+	return VerifyWithOptions(publicKey, h.Sum(nil), sig, &Options{Hash: crypto.SHA512})
+}
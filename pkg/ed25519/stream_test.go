@@ -0,0 +1,47 @@
+package ed25519_test
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/sha512"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/ed25519"
+)
+
+func TestSignReader(t *testing.T) {
+	publicKey, privateKey, _ := ed25519.GenerateKey(bytes.NewReader(nullSeed))
+	// a message larger than typical read buffers
+	message := bytes.Repeat([]byte("firmware image "), 10000)
+
+	sig, err := ed25519.SignReader(privateKey, bytes.NewReader(message))
+	require.NoError(t, err)
+	assert.NoError(t, ed25519.VerifyReader(publicKey, bytes.NewReader(message), sig))
+
+	// the signature must be the Ed25519ph signature of the message
+	digest := sha512.Sum512(message)
+	assert.NoError(t, ed25519.VerifyWithOptions(publicKey, digest[:], sig, &ed25519.Options{Hash: crypto.SHA512}))
+	assert.False(t, ed25519.Verify(publicKey, message, sig))
+
+	message[0] ^= 1
+	assert.Error(t, ed25519.VerifyReader(publicKey, bytes.NewReader(message), sig))
+}
+
+func TestSignReaderError(t *testing.T) {
+	publicKey, privateKey, _ := ed25519.GenerateKey(bytes.NewReader(nullSeed))
+	errRead := errors.New("read failed")
+	r := io.MultiReader(bytes.NewReader([]byte("partial")), &errReader{errRead})
+
+	_, err := ed25519.SignReader(privateKey, r)
+	assert.ErrorIs(t, err, errRead)
+	assert.ErrorIs(t, ed25519.VerifyReader(publicKey, &errReader{errRead}, make([]byte, ed25519.SignatureSize)), errRead)
+}
+
+type errReader struct{ err error }
+
+func (r *errReader) Read([]byte) (int, error) { return 0, r.err }
@@ -0,0 +1,45 @@
+package ed25519
+
+import (
+	"crypto/sha512"
+	"strconv"
+
+	"filippo.io/edwards25519"
+)
+
+// X25519Size is the size, in bytes, of X25519 public and private keys.
+const X25519Size = 32
+
+// PublicKeyToX25519 converts the Ed25519 public key to the corresponding X25519 public key, i.e. the u-coordinate
+// of the birationally-equivalent point on Curve25519 as specified in RFC 7748.
+// It returns an error if publicKey is not a valid point or if it has small order, as such keys would lead to a
+// predictable shared secret. It will panic if len(publicKey) is not PublicKeySize.
+func PublicKeyToX25519(publicKey PublicKey) ([]byte, error) {
+	if l := len(publicKey); l != PublicKeySize {
+		panic("ed25519: bad public key length: " + strconv.Itoa(l))
+	}
+	A, err := new(edwards25519.Point).SetBytes(publicKey)
+	if err != nil {
+		return nil, ErrNotOnCurve
+	}
+	if new(edwards25519.Point).MultByCofactor(A).Equal(identity) == 1 {
+		return nil, ErrSmallOrder
+	}
+	return A.BytesMontgomery(), nil
+}
+
+// PrivateKeyToX25519 converts the Ed25519 private key to the corresponding X25519 private key, i.e. the clamped
+// signing scalar derived from the seed. The resulting key matches the public key returned by PublicKeyToX25519.
+// It will panic if len(privateKey) is not PrivateKeySize.
+func PrivateKeyToX25519(privateKey PrivateKey) []byte {
+	if l := len(privateKey); l != PrivateKeySize {
+		panic("ed25519: bad private key length: " + strconv.Itoa(l))
+	}
+	h := sha512.Sum512(privateKey[:SeedSize])
+	h[0] &= 248
+	h[31] &= 127
+	h[31] |= 64
+	key := make([]byte, X25519Size)
+	copy(key, h[:X25519Size])
+	return key
+}
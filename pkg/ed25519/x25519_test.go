@@ -0,0 +1,61 @@
+package ed25519_test
+
+import (
+	"crypto/ecdh"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/internal/hexutil"
+	"github.com/iotaledger/iota-crypto-demo/pkg/ed25519"
+)
+
+func TestX25519(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		public, private, err := ed25519.GenerateKey(nil)
+		require.NoError(t, err)
+
+		xPublic, err := ed25519.PublicKeyToX25519(public)
+		require.NoError(t, err)
+		xPrivate, err := ecdh.X25519().NewPrivateKey(ed25519.PrivateKeyToX25519(private))
+		require.NoError(t, err)
+		assert.Equal(t, xPrivate.PublicKey().Bytes(), xPublic)
+	}
+}
+
+func TestX25519SharedSecret(t *testing.T) {
+	alicePublic, alicePrivate, _ := ed25519.GenerateKey(nil)
+	bobPublic, bobPrivate, _ := ed25519.GenerateKey(nil)
+
+	sharedSecret := func(private ed25519.PrivateKey, public ed25519.PublicKey) []byte {
+		priv, err := ecdh.X25519().NewPrivateKey(ed25519.PrivateKeyToX25519(private))
+		require.NoError(t, err)
+		b, err := ed25519.PublicKeyToX25519(public)
+		require.NoError(t, err)
+		pub, err := ecdh.X25519().NewPublicKey(b)
+		require.NoError(t, err)
+		secret, err := priv.ECDH(pub)
+		require.NoError(t, err)
+		return secret
+	}
+	assert.Equal(t, sharedSecret(alicePrivate, bobPublic), sharedSecret(bobPrivate, alicePublic))
+}
+
+func TestPublicKeyToX25519Invalid(t *testing.T) {
+	var tests = []*struct {
+		name      string
+		publicKey ed25519.PublicKey
+	}{
+		{"identity", hexutil.MustDecodeString("0100000000000000000000000000000000000000000000000000000000000000")},
+		{"small order", hexutil.MustDecodeString("ecffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f")},
+		{"not on curve", hexutil.MustDecodeString("0200000000000000000000000000000000000000000000000000000000000000")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ed25519.PublicKeyToX25519(tt.publicKey)
+			assert.Error(t, err)
+		})
+	}
+	assert.Panics(t, func() { _, _ = ed25519.PublicKeyToX25519(make([]byte, ed25519.PublicKeySize-1)) })
+}
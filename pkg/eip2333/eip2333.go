@@ -0,0 +1,133 @@
+/*
+Package eip2333 implements the EIP-2333 key derivation of BLS12-381 secret keys
+together with the EIP-2334 derivation paths used for Ethereum validator keys.
+
+In contrast to SLIP-10, every child derivation is hardened: the child key is
+derived from a Lamport public key computed from the parent secret key, so that
+it remains secure even against quantum adversaries knowing the parent public key.
+
+This package is tested against the test vectors provided in the official
+EIP-2333 specification.
+*/
+package eip2333
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"math/big"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip32path"
+	"github.com/iotaledger/iota-crypto-demo/pkg/hkdf"
+)
+
+const (
+	// SecretKeySize is the size, in bytes, of a BLS12-381 secret key.
+	SecretKeySize = 32
+	// MinSeedSize is the minimal size, in bytes, of the seed.
+	MinSeedSize = 32
+
+	// Purpose is the purpose index of EIP-2334 paths.
+	Purpose = 12381
+	// CoinType is the coin type index of Ethereum in EIP-2334 paths.
+	CoinType = 3600
+)
+
+// ErrInvalidSeed is returned when the seed is too short.
+var ErrInvalidSeed = errors.New("invalid seed")
+
+const (
+	// keygenSalt is the initial salt of HKDF_mod_r as specified in the BLS signature draft.
+	keygenSalt = "BLS-SIG-KEYGEN-SALT-"
+	// okmLength is the number of bytes of the HKDF output reduced modulo r.
+	okmLength = 48
+	// lamportChunks is the number of 32-byte chunks of a Lamport secret key half.
+	lamportChunks = 255
+)
+
+// curveOrder is the order r of the BLS12-381 subgroups.
+var curveOrder, _ = new(big.Int).SetString("73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001", 16)
+
+// DeriveMasterSK derives the master secret key from seed.
+func DeriveMasterSK(seed []byte) ([]byte, error) {
+	if len(seed) < MinSeedSize {
+		return nil, ErrInvalidSeed
+	}
+	return hkdfModR(seed, nil), nil
+}
+
+// DeriveChildSK derives the child secret key with the given index from the parent secret key.
+func DeriveChildSK(parentSK []byte, index uint32) []byte {
+	return hkdfModR(parentSKToLamportPK(parentSK, index), nil)
+}
+
+// DeriveKeyFromPath derives the secret key from seed and path as outlined by EIP-2333.
+// All indices of path are used as is, there is no distinction between hardened and non-hardened indices.
+func DeriveKeyFromPath(seed []byte, path bip32path.Path) ([]byte, error) {
+	sk, err := DeriveMasterSK(seed)
+	if err != nil {
+		return nil, err
+	}
+	for _, index := range path {
+		sk = DeriveChildSK(sk, index)
+	}
+	return sk, nil
+}
+
+// WithdrawalKeyPath returns the EIP-2334 path of the withdrawal key of the given validator account.
+func WithdrawalKeyPath(account uint32) bip32path.Path {
+	return bip32path.Path{Purpose, CoinType, account, 0}
+}
+
+// SigningKeyPath returns the EIP-2334 path of the signing key of the given validator account.
+func SigningKeyPath(account uint32) bip32path.Path {
+	return append(WithdrawalKeyPath(account), 0)
+}
+
+// hkdfModR computes the HKDF_mod_r function producing a non-zero secret key from ikm.
+func hkdfModR(ikm, keyInfo []byte) []byte {
+	salt := []byte(keygenSalt)
+	ikm = append(append([]byte{}, ikm...), 0) // IKM || I2OSP(0, 1)
+	info := binary.BigEndian.AppendUint16(append([]byte{}, keyInfo...), okmLength)
+
+	sk := new(big.Int)
+	for sk.Sign() == 0 {
+		h := sha256.Sum256(salt)
+		salt = h[:]
+		okm, err := hkdf.Key(sha256.New, ikm, salt, info, okmLength)
+		if err != nil {
+			panic(err)
+		}
+		sk.SetBytes(okm).Mod(sk, curveOrder)
+	}
+	return sk.FillBytes(make([]byte, SecretKeySize))
+}
+
+// parentSKToLamportPK returns the compressed Lamport public key derived from the parent secret key and index.
+func parentSKToLamportPK(parentSK []byte, index uint32) []byte {
+	salt := binary.BigEndian.AppendUint32(nil, index)
+	ikm := new(big.Int).SetBytes(parentSK).FillBytes(make([]byte, SecretKeySize))
+	notIKM := make([]byte, len(ikm))
+	for i := range ikm {
+		notIKM[i] = ^ikm[i]
+	}
+
+	h := sha256.New()
+	for _, k := range [][]byte{ikm, notIKM} {
+		lamportSK := ikmToLamportSK(k, salt)
+		for i := 0; i < lamportChunks; i++ {
+			chunk := sha256.Sum256(lamportSK[i*sha256.Size : (i+1)*sha256.Size])
+			h.Write(chunk[:])
+		}
+	}
+	return h.Sum(nil)
+}
+
+// ikmToLamportSK returns the concatenated chunks of one half of a Lamport secret key.
+func ikmToLamportSK(ikm, salt []byte) []byte {
+	okm, err := hkdf.Key(sha256.New, ikm, salt, nil, lamportChunks*sha256.Size)
+	if err != nil {
+		panic(err)
+	}
+	return okm
+}
@@ -0,0 +1,61 @@
+package eip2333_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/internal/hexutil"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip32path"
+	"github.com/iotaledger/iota-crypto-demo/pkg/eip2333"
+)
+
+func TestDeriveChildSK(t *testing.T) {
+	var tests = []*struct {
+		seed       []byte
+		masterSK   string
+		childIndex uint32
+		childSK    string
+	}{
+		{
+			hexutil.MustDecodeString("c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04"),
+			"6083874454709270928345386274498605044986640685124978867557563392430687146096",
+			0,
+			"20397789859736650942317412262472558107875392172444076792671091975210932703118",
+		},
+		{
+			hexutil.MustDecodeString("3141592653589793238462643383279502884197169399375105820974944592"),
+			"29757020647961307431480504535336562678282505419141012933316116377660817309383",
+			3141592653,
+			"25457201688850691947727629385191704516744796114925897962676248250929345014287",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.masterSK, func(t *testing.T) {
+			masterSK, err := eip2333.DeriveMasterSK(tt.seed)
+			require.NoError(t, err)
+			require.Len(t, masterSK, eip2333.SecretKeySize)
+			assert.Equal(t, tt.masterSK, new(big.Int).SetBytes(masterSK).String())
+
+			childSK := eip2333.DeriveChildSK(masterSK, tt.childIndex)
+			assert.Equal(t, tt.childSK, new(big.Int).SetBytes(childSK).String())
+
+			pathSK, err := eip2333.DeriveKeyFromPath(tt.seed, bip32path.Path{tt.childIndex})
+			require.NoError(t, err)
+			assert.Equal(t, childSK, pathSK)
+		})
+	}
+}
+
+func TestDeriveMasterSKInvalidSeed(t *testing.T) {
+	_, err := eip2333.DeriveMasterSK(make([]byte, eip2333.MinSeedSize-1))
+	assert.ErrorIs(t, err, eip2333.ErrInvalidSeed)
+}
+
+func TestKeyPaths(t *testing.T) {
+	assert.Equal(t, "m/12381/3600/5/0", eip2333.WithdrawalKeyPath(5).String())
+	assert.Equal(t, "m/12381/3600/5/0/0", eip2333.SigningKeyPath(5).String())
+}
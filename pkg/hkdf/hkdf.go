@@ -0,0 +1,81 @@
+/*
+Package hkdf implements the HMAC-based extract-and-expand key derivation function HKDF as specified in RFC 5869.
+
+Besides the plain Extract and Expand steps, it provides ExpandLabel, which binds the derived key to a label, a
+context and the output length, so that keys for different purposes derived from the same secret are independent.
+
+This package is tested against the test vectors provided in RFC 5869.
+*/
+package hkdf
+
+import (
+	"crypto/hmac"
+	"encoding/binary"
+	"errors"
+	"hash"
+)
+
+// maxLabelSize is the maximum size, in bytes, of the label and the context.
+const maxLabelSize = 255
+
+var (
+	// ErrInvalidLength is returned when the requested output is longer than 255 times the hash size.
+	ErrInvalidLength = errors.New("invalid length")
+	// ErrInvalidLabel is returned when the label or the context is longer than 255 bytes.
+	ErrInvalidLabel = errors.New("invalid label")
+)
+
+// Extract returns the pseudorandom key for the input keying material secret and the optional salt.
+// If salt is empty, a string of hash size zeros is used.
+func Extract(h func() hash.Hash, secret, salt []byte) []byte {
+	if len(salt) == 0 {
+		salt = make([]byte, h().Size())
+	}
+	mac := hmac.New(h, salt)
+	mac.Write(secret)
+	return mac.Sum(nil)
+}
+
+// Expand expands the pseudorandom key prk into length bytes of output keying material bound to info.
+func Expand(h func() hash.Hash, prk, info []byte, length int) ([]byte, error) {
+	mac := hmac.New(h, prk)
+	if length < 0 || length > 255*mac.Size() {
+		return nil, ErrInvalidLength
+	}
+
+	okm := make([]byte, 0, length+mac.Size())
+	var t []byte
+	for counter := byte(1); len(okm) < length; counter++ {
+		mac.Reset()
+		mac.Write(t)
+		mac.Write(info)
+		mac.Write([]byte{counter})
+		t = mac.Sum(t[:0])
+		okm = append(okm, t...)
+	}
+	return okm[:length], nil
+}
+
+// Key derives length bytes of output keying material from secret, salt and info, i.e. it performs Extract followed
+// by Expand.
+func Key(h func() hash.Hash, secret, salt, info []byte, length int) ([]byte, error) {
+	return Expand(h, Extract(h, secret, salt), info, length)
+}
+
+// ExpandLabel expands prk like Expand using the info encoding of the HKDF-Expand-Label function in RFC 8446: the
+// 2-byte big-endian output length followed by the length-prefixed label and context.
+// Unlike in TLS 1.3, no prefix is added to the label.
+func ExpandLabel(h func() hash.Hash, prk []byte, label string, context []byte, length int) ([]byte, error) {
+	if len(label) > maxLabelSize || len(context) > maxLabelSize {
+		return nil, ErrInvalidLabel
+	}
+	if length < 0 || length > 0xFFFF {
+		return nil, ErrInvalidLength
+	}
+	info := binary.BigEndian.AppendUint16(nil, uint16(length))
+	info = append(info, byte(len(label)))
+	info = append(info, label...)
+	info = append(info, byte(len(context)))
+	info = append(info, context...)
+	return Expand(h, prk, info, length)
+}
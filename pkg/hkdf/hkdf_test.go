@@ -0,0 +1,119 @@
+//nolint:scopelint
+package hkdf_test
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"hash"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/internal/hexutil"
+	"github.com/iotaledger/iota-crypto-demo/pkg/hkdf"
+)
+
+// test vectors from RFC 5869, Appendix A
+var rfc5869Tests = []*struct {
+	name   string
+	hash   func() hash.Hash
+	secret string
+	salt   string
+	info   string
+	length int
+	prk    string
+	okm    string
+}{
+	{
+		"case 1", sha256.New,
+		"0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b",
+		"000102030405060708090a0b0c",
+		"f0f1f2f3f4f5f6f7f8f9",
+		42,
+		"077709362c2e32df0ddc3f0dc47bba6390b6c73bb50f9c3122ec844ad7c2b3e5",
+		"3cb25f25faacd57a90434f64d0362f2a2d2d0a90cf1a5a4c5db02d56ecc4c5bf34007208d5b887185865",
+	},
+	{
+		"case 3", sha256.New,
+		"0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b",
+		"",
+		"",
+		42,
+		"19ef24a32c717b167f33a91d6f648bdf96596776afdb6377ac434c1c293ccb04",
+		"8da4e775a563c18f715f802a063c5a31b8a11f5c5ee1879ec3454e5f3c738d2d9d201395faa4b61a96c8",
+	},
+	{
+		"case 4", sha1.New,
+		"0b0b0b0b0b0b0b0b0b0b0b",
+		"000102030405060708090a0b0c",
+		"f0f1f2f3f4f5f6f7f8f9",
+		42,
+		"9b6c18c432a7bf8f0e71c8eb88f4b30baa2ba243",
+		"085a01ea1b10f36933068b56efa5ad81a4f14b822f5b091568a9cdd4f155fda2c22e422478d305f3f896",
+	},
+	{
+		"case 7", sha1.New,
+		"0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c",
+		"",
+		"",
+		42,
+		"2adccada18779e7c2077ad2eb19d3f3e731385dd",
+		"2c91117204d745f3500d636a62f64f0ab3bae548aa53d423b0d1f27ebba6f5e5673a081d70cce7acfc48",
+	},
+}
+
+func TestRFC5869(t *testing.T) {
+	for _, tt := range rfc5869Tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secret := hexutil.MustDecodeString(tt.secret)
+			salt := hexutil.MustDecodeString(tt.salt)
+			info := hexutil.MustDecodeString(tt.info)
+
+			prk := hkdf.Extract(tt.hash, secret, salt)
+			assert.Equal(t, tt.prk, hexutil.Bytes(prk).String())
+
+			okm, err := hkdf.Expand(tt.hash, prk, info, tt.length)
+			require.NoError(t, err)
+			assert.Equal(t, tt.okm, hexutil.Bytes(okm).String())
+
+			key, err := hkdf.Key(tt.hash, secret, salt, info, tt.length)
+			require.NoError(t, err)
+			assert.Equal(t, okm, key)
+		})
+	}
+}
+
+func TestExpandLength(t *testing.T) {
+	prk := make([]byte, sha256.Size)
+	okm, err := hkdf.Expand(sha256.New, prk, nil, 255*sha256.Size)
+	require.NoError(t, err)
+	assert.Len(t, okm, 255*sha256.Size)
+
+	_, err = hkdf.Expand(sha256.New, prk, nil, 255*sha256.Size+1)
+	assert.ErrorIs(t, err, hkdf.ErrInvalidLength)
+}
+
+func TestExpandLabel(t *testing.T) {
+	prk := make([]byte, sha256.Size)
+	a, err := hkdf.ExpandLabel(sha256.New, prk, "a", []byte("context"), 32)
+	require.NoError(t, err)
+	b, err := hkdf.ExpandLabel(sha256.New, prk, "b", []byte("context"), 32)
+	require.NoError(t, err)
+	assert.NotEqual(t, a, b)
+
+	// the output length is part of the info, so a shorter key is not a prefix
+	short, err := hkdf.ExpandLabel(sha256.New, prk, "a", []byte("context"), 16)
+	require.NoError(t, err)
+	assert.NotEqual(t, a[:16], short)
+
+	// the label is equivalent to the corresponding info for Expand
+	info := append([]byte{0x00, 0x20, 0x01, 'a', 0x07}, "context"...)
+	expected, err := hkdf.Expand(sha256.New, prk, info, 32)
+	require.NoError(t, err)
+	assert.Equal(t, expected, a)
+
+	_, err = hkdf.ExpandLabel(sha256.New, prk, strings.Repeat("a", 256), nil, 32)
+	assert.ErrorIs(t, err, hkdf.ErrInvalidLabel)
+}
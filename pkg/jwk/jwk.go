@@ -0,0 +1,306 @@
+/*
+Package jwk implements the JSON Web Key (JWK) representation of keys derived
+using the slip10 package.
+
+It supports Ed25519 keys as Octet Key Pairs according to RFC 8037 as well as
+NIST P-256 and secp256k1 keys as Elliptic Curve keys according to RFC 7518 and
+RFC 8812.
+The key thumbprints are computed as described in RFC 7638.
+*/
+package jwk
+
+import (
+	stdelliptic "crypto/elliptic"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/ed25519"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10/eddsa"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10/elliptic"
+)
+
+// Key types as defined in RFC 7518 and RFC 8037.
+const (
+	KeyTypeEC  = "EC"
+	KeyTypeOKP = "OKP"
+)
+
+// Curve names as registered in the JSON Web Key Elliptic Curve registry.
+const (
+	CurveEd25519   = "Ed25519"
+	CurveP256      = "P-256"
+	CurveSecp256k1 = "secp256k1"
+)
+
+// coordinateSize is the size, in bytes, of a single coordinate or private scalar of the supported curves.
+const coordinateSize = 32
+
+var (
+	// ErrUnsupportedKey is returned when a key type or curve is not supported.
+	ErrUnsupportedKey = errors.New("unsupported key")
+	// ErrInvalidKey is returned when the JWK parameters do not describe a valid key.
+	ErrInvalidKey = errors.New("invalid key")
+)
+
+// Key represents a JSON Web Key.
+type Key struct {
+	// KeyType corresponds to the "kty" parameter.
+	KeyType string
+	// Curve corresponds to the "crv" parameter.
+	Curve string
+	// X corresponds to the "x" parameter, i.e. the public key or its x-coordinate.
+	X []byte
+	// Y corresponds to the "y" parameter, i.e. the y-coordinate of the public key; only used for EC keys.
+	Y []byte
+	// D corresponds to the "d" parameter, i.e. the private key; nil for public keys.
+	D []byte
+	// KeyID corresponds to the optional "kid" parameter.
+	KeyID string
+}
+
+// FromKey returns the JWK representation of the provided slip10.Key.
+func FromKey(key slip10.Key) (*Key, error) {
+	switch k := key.(type) {
+	case eddsa.Seed:
+		if len(k) != ed25519.SeedSize {
+			return nil, fmt.Errorf("%w: invalid Ed25519 seed length", ErrInvalidKey)
+		}
+		pub, _ := k.Ed25519Key()
+		return &Key{
+			KeyType: KeyTypeOKP,
+			Curve:   CurveEd25519,
+			X:       pub,
+			D:       append([]byte{}, k...),
+		}, nil
+	case eddsa.PublicKey:
+		if len(k) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("%w: invalid Ed25519 public key length", ErrInvalidKey)
+		}
+		return &Key{
+			KeyType: KeyTypeOKP,
+			Curve:   CurveEd25519,
+			X:       append([]byte{}, k...),
+		}, nil
+	case *elliptic.PrivateKey:
+		crv, err := curveName(k.Curve.Params().Name)
+		if err != nil {
+			return nil, err
+		}
+		//nolint:forcetypeassert // Public of a PrivateKey always returns a PublicKey
+		pub := k.Public().(*elliptic.PublicKey)
+		return &Key{
+			KeyType: KeyTypeEC,
+			Curve:   crv,
+			X:       pub.X.FillBytes(make([]byte, coordinateSize)),
+			Y:       pub.Y.FillBytes(make([]byte, coordinateSize)),
+			D:       k.K.FillBytes(make([]byte, coordinateSize)),
+		}, nil
+	case *elliptic.PublicKey:
+		crv, err := curveName(k.Curve.Params().Name)
+		if err != nil {
+			return nil, err
+		}
+		return &Key{
+			KeyType: KeyTypeEC,
+			Curve:   crv,
+			X:       k.X.FillBytes(make([]byte, coordinateSize)),
+			Y:       k.Y.FillBytes(make([]byte, coordinateSize)),
+		}, nil
+	}
+	return nil, fmt.Errorf("%w: %T", ErrUnsupportedKey, key)
+}
+
+// IsPrivate returns whether the JWK contains a private key.
+func (k *Key) IsPrivate() bool {
+	return len(k.D) > 0
+}
+
+// Public returns the JWK of the corresponding public key.
+func (k *Key) Public() *Key {
+	return &Key{
+		KeyType: k.KeyType,
+		Curve:   k.Curve,
+		X:       k.X,
+		Y:       k.Y,
+		KeyID:   k.KeyID,
+	}
+}
+
+// Key returns the slip10.Key corresponding to the JWK.
+// It returns an error if the parameters are inconsistent or do not describe a valid key.
+func (k *Key) Key() (slip10.Key, error) {
+	switch k.KeyType {
+	case KeyTypeOKP:
+		return k.okpKey()
+	case KeyTypeEC:
+		return k.ecKey()
+	}
+	return nil, fmt.Errorf("%w: key type %q", ErrUnsupportedKey, k.KeyType)
+}
+
+func (k *Key) okpKey() (slip10.Key, error) {
+	if k.Curve != CurveEd25519 {
+		return nil, fmt.Errorf("%w: curve %q", ErrUnsupportedKey, k.Curve)
+	}
+	if len(k.X) != ed25519.PublicKeySize || len(k.Y) > 0 {
+		return nil, fmt.Errorf("%w: invalid public key", ErrInvalidKey)
+	}
+	if !k.IsPrivate() {
+		return eddsa.PublicKey(append([]byte{}, k.X...)), nil
+	}
+	if len(k.D) != ed25519.SeedSize {
+		return nil, fmt.Errorf("%w: invalid private key", ErrInvalidKey)
+	}
+	seed := eddsa.Seed(append([]byte{}, k.D...))
+	if pub, _ := seed.Ed25519Key(); !pub.Equal(ed25519.PublicKey(k.X)) {
+		return nil, fmt.Errorf("%w: public key does not match private key", ErrInvalidKey)
+	}
+	return seed, nil
+}
+
+func (k *Key) ecKey() (slip10.Key, error) {
+	var curve slip10.Curve
+	switch k.Curve {
+	case CurveP256:
+		curve = elliptic.Nist256p1()
+	case CurveSecp256k1:
+		curve = elliptic.Secp256k1()
+	default:
+		return nil, fmt.Errorf("%w: curve %q", ErrUnsupportedKey, k.Curve)
+	}
+	if len(k.X) != coordinateSize || len(k.Y) != coordinateSize {
+		return nil, fmt.Errorf("%w: invalid public key", ErrInvalidKey)
+	}
+
+	//nolint:forcetypeassert // all curves of the elliptic package implement elliptic.Curve
+	c := curve.(stdelliptic.Curve)
+	x, y := new(big.Int).SetBytes(k.X), new(big.Int).SetBytes(k.Y)
+	if !c.IsOnCurve(x, y) {
+		return nil, fmt.Errorf("%w: point not on curve", ErrInvalidKey)
+	}
+	if !k.IsPrivate() {
+		return &elliptic.PublicKey{X: x, Y: y, Curve: c}, nil
+	}
+	if len(k.D) != coordinateSize {
+		return nil, fmt.Errorf("%w: invalid private key", ErrInvalidKey)
+	}
+	priv, err := curve.NewPrivateKey(k.D)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid private key", ErrInvalidKey)
+	}
+	//nolint:forcetypeassert // Public of a PrivateKey always returns a PublicKey
+	pub := priv.Public().(*elliptic.PublicKey)
+	if pub.X.Cmp(x) != 0 || pub.Y.Cmp(y) != 0 {
+		return nil, fmt.Errorf("%w: public key does not match private key", ErrInvalidKey)
+	}
+	return priv, nil
+}
+
+// Thumbprint computes the SHA-256 JWK thumbprint of the key as described in RFC 7638.
+// The thumbprint only depends on the public key parameters.
+func (k *Key) Thumbprint() ([]byte, error) {
+	var (
+		b   []byte
+		err error
+	)
+	// the required members must be ordered lexicographically
+	switch k.KeyType {
+	case KeyTypeOKP:
+		b, err = json.Marshal(struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+		}{k.Curve, k.KeyType, encode(k.X)})
+	case KeyTypeEC:
+		b, err = json.Marshal(struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+			Y   string `json:"y"`
+		}{k.Curve, k.KeyType, encode(k.X), encode(k.Y)})
+	default:
+		return nil, fmt.Errorf("%w: key type %q", ErrUnsupportedKey, k.KeyType)
+	}
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(b)
+	return sum[:], nil
+}
+
+// jsonKey is the JSON serialization of a Key.
+type jsonKey struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y,omitempty"`
+	D   string `json:"d,omitempty"`
+	Kid string `json:"kid,omitempty"`
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (k *Key) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&jsonKey{
+		Kty: k.KeyType,
+		Crv: k.Curve,
+		X:   encode(k.X),
+		Y:   encode(k.Y),
+		D:   encode(k.D),
+		Kid: k.KeyID,
+	})
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (k *Key) UnmarshalJSON(data []byte) error {
+	var j jsonKey
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	x, err := decode(j.X)
+	if err != nil {
+		return fmt.Errorf("invalid parameter x: %w", err)
+	}
+	y, err := decode(j.Y)
+	if err != nil {
+		return fmt.Errorf("invalid parameter y: %w", err)
+	}
+	d, err := decode(j.D)
+	if err != nil {
+		return fmt.Errorf("invalid parameter d: %w", err)
+	}
+	*k = Key{
+		KeyType: j.Kty,
+		Curve:   j.Crv,
+		X:       x,
+		Y:       y,
+		D:       d,
+		KeyID:   j.Kid,
+	}
+	return nil
+}
+
+func curveName(name string) (string, error) {
+	switch name {
+	case elliptic.Nist256p1().Name():
+		return CurveP256, nil
+	case elliptic.Secp256k1().Name():
+		return CurveSecp256k1, nil
+	}
+	return "", fmt.Errorf("%w: curve %q", ErrUnsupportedKey, name)
+}
+
+func encode(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decode(s string) ([]byte, error) {
+	if s == "" {
+		return nil, nil
+	}
+	return base64.RawURLEncoding.DecodeString(s)
+}
@@ -0,0 +1,126 @@
+//nolint:scopelint
+package jwk_test
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/jwk"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10/eddsa"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10/elliptic"
+)
+
+// test vector from RFC 8037, Appendix A.
+const (
+	rfc8037Key        = `{"kty":"OKP","crv":"Ed25519","x":"11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo","d":"nWGxne_9WmC6hEr0kuwsxERJxWl7MmkZcDusAxyuf2A"}`
+	rfc8037Thumbprint = "kPrK_qmxVWaYVA9wwBF6Iuo3vVzz7TxHCTwXBygrS4k"
+)
+
+func TestRFC8037(t *testing.T) {
+	var key jwk.Key
+	require.NoError(t, json.Unmarshal([]byte(rfc8037Key), &key))
+	assert.True(t, key.IsPrivate())
+
+	thumbprint, err := key.Thumbprint()
+	require.NoError(t, err)
+	assert.Equal(t, rfc8037Thumbprint, base64.RawURLEncoding.EncodeToString(thumbprint))
+
+	k, err := key.Key()
+	require.NoError(t, err)
+	assert.Equal(t, "9d61b19deffd5a60ba844af492ec2cc44449c5697b326919703bac031cae7f60", hex.EncodeToString(k.Bytes()))
+
+	b, err := json.Marshal(&key)
+	require.NoError(t, err)
+	assert.JSONEq(t, rfc8037Key, string(b))
+}
+
+func TestRoundTrip(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	path := []uint32{44 | slip10.Hardened, 4218 | slip10.Hardened, 0 | slip10.Hardened}
+
+	for _, curve := range []slip10.Curve{eddsa.Ed25519(), elliptic.Nist256p1(), elliptic.Secp256k1()} {
+		t.Run(curve.Name(), func(t *testing.T) {
+			extended, err := slip10.DeriveKeyFromPath(seed, curve, path)
+			require.NoError(t, err)
+
+			for _, key := range []slip10.Key{extended.Key, extended.Key.Public()} {
+				j, err := jwk.FromKey(key)
+				require.NoError(t, err)
+				assert.Equal(t, key.IsPrivate(), j.IsPrivate())
+
+				b, err := json.Marshal(j)
+				require.NoError(t, err)
+
+				var decoded jwk.Key
+				require.NoError(t, json.Unmarshal(b, &decoded))
+				assert.Equal(t, j, &decoded)
+
+				k, err := decoded.Key()
+				require.NoError(t, err)
+				assert.Equal(t, key.Bytes(), k.Bytes())
+				assert.Equal(t, key.IsPrivate(), k.IsPrivate())
+			}
+
+			// the thumbprint must not depend on the private key
+			priv, err := jwk.FromKey(extended.Key)
+			require.NoError(t, err)
+			pub, err := jwk.FromKey(extended.Key.Public())
+			require.NoError(t, err)
+			privThumbprint, err := priv.Thumbprint()
+			require.NoError(t, err)
+			pubThumbprint, err := pub.Thumbprint()
+			require.NoError(t, err)
+			assert.Equal(t, pubThumbprint, privThumbprint)
+			assert.Equal(t, pub, priv.Public())
+		})
+	}
+}
+
+func TestInvalid(t *testing.T) {
+	var tests = []*struct {
+		desc   string
+		json   string
+		expErr error
+	}{
+		{
+			desc:   "unknown key type",
+			json:   `{"kty":"RSA","crv":"Ed25519","x":"11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"}`,
+			expErr: jwk.ErrUnsupportedKey,
+		},
+		{
+			desc:   "unknown curve",
+			json:   `{"kty":"OKP","crv":"X25519","x":"11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"}`,
+			expErr: jwk.ErrUnsupportedKey,
+		},
+		{
+			desc:   "invalid public key",
+			json:   `{"kty":"OKP","crv":"Ed25519","x":"11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcH"}`,
+			expErr: jwk.ErrInvalidKey,
+		},
+		{
+			desc:   "mismatching private key",
+			json:   `{"kty":"OKP","crv":"Ed25519","x":"11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo","d":"AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA"}`,
+			expErr: jwk.ErrInvalidKey,
+		},
+		{
+			desc:   "point not on curve",
+			json:   `{"kty":"EC","crv":"P-256","x":"AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA","y":"AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA"}`,
+			expErr: jwk.ErrInvalidKey,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var key jwk.Key
+			require.NoError(t, json.Unmarshal([]byte(tt.json), &key))
+			_, err := key.Key()
+			assert.ErrorIs(t, err, tt.expErr)
+		})
+	}
+}
@@ -0,0 +1,326 @@
+/*
+Package keylog implements an audit trail of the keys derived from a seed.
+
+A KeyLog is an append-only list of entries, each recording the derivation path, public key, address, creation time and
+purpose of a derived key. Every entry is signed by the master identity of the seed, i.e. the Ed25519 key of the
+SLIP-10 master node, and commits to the digest of its predecessor, so that entries can neither be forged, modified,
+removed nor reordered without invalidating the log. Key rotations are recorded as entries replacing the public key of
+an earlier entry.
+
+The signed digest of an entry is the BLAKE2b-256 hash of a domain separation tag followed by its length-prefixed fields.
+As only the identity can sign entries, the log does not protect against the removal of its most recent entries; the
+length or the digest of the last entry must be anchored elsewhere, if this is required.
+*/
+package keylog
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/blake2b"
+
+	"github.com/iotaledger/iota-crypto-demo/internal/hexutil"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bech32/address"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip32path"
+	"github.com/iotaledger/iota-crypto-demo/pkg/ed25519"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10/eddsa"
+)
+
+// domain is the domain separation tag prepended to the signed data.
+const domain = "IOTA Key Log v1"
+
+var (
+	// ErrInvalidEntry is returned when an entry is inconsistent with its predecessors.
+	ErrInvalidEntry = errors.New("invalid entry")
+	// ErrInvalidSignature is returned when the signature of an entry does not match the identity.
+	ErrInvalidSignature = errors.New("invalid signature")
+	// ErrIdentityMismatch is returned when a log does not belong to the master identity of a seed.
+	ErrIdentityMismatch = errors.New("identity mismatch")
+	// ErrKeyNotFound is returned when a public key has not been recorded in the log.
+	ErrKeyNotFound = errors.New("key not found")
+)
+
+// Entry records a single derived key.
+type Entry struct {
+	// Sequence is the position of the entry in the log starting from zero.
+	Sequence uint64 `json:"sequence"`
+	// Path is the SLIP-10 derivation path of the key.
+	Path bip32path.Path `json:"path"`
+	// PublicKey is the derived Ed25519 public key.
+	PublicKey hexutil.Bytes `json:"publicKey"`
+	// Address is the Bech32 encoded address of the public key.
+	Address string `json:"address"`
+	// CreatedAt is the time the key has been derived.
+	CreatedAt time.Time `json:"createdAt"`
+	// Purpose describes what the key is used for.
+	Purpose string `json:"purpose"`
+	// Replaces is the public key of an earlier entry replaced by this key, or empty.
+	Replaces hexutil.Bytes `json:"replaces,omitempty"`
+	// Previous is the digest of the previous entry, or empty for the first entry.
+	Previous hexutil.Bytes `json:"previous,omitempty"`
+	// Signature is the signature of the digest by the master identity.
+	Signature hexutil.Bytes `json:"signature"`
+}
+
+// Digest returns the BLAKE2b-256 hash of the domain separated fields of the entry, which is signed by the identity.
+func (e *Entry) Digest() []byte {
+	h, _ := blake2b.New256(nil)
+	h.Write([]byte(domain))
+	h.Write(binary.BigEndian.AppendUint64(nil, e.Sequence))
+	for _, field := range [][]byte{
+		[]byte(e.Path.String()),
+		e.PublicKey,
+		[]byte(e.Address),
+		[]byte(e.CreatedAt.UTC().Format(time.RFC3339Nano)),
+		[]byte(e.Purpose),
+		e.Replaces,
+		e.Previous,
+	} {
+		h.Write(binary.BigEndian.AppendUint32(nil, uint32(len(field))))
+		h.Write(field)
+	}
+	return h.Sum(nil)
+}
+
+// KeyLog is the audit trail of the keys derived from a seed.
+type KeyLog struct {
+	// Identity is the public key of the master identity signing the entries.
+	Identity hexutil.Bytes `json:"identity"`
+	// Entries contains the recorded entries in order.
+	Entries []Entry `json:"entries"`
+}
+
+// Verify checks the signature of every entry, that the entries form a chain and that each address matches its public
+// key. It returns nil, if the log is valid.
+func (l *KeyLog) Verify() error {
+	if len(l.Identity) != ed25519.PublicKeySize {
+		return fmt.Errorf("%w: invalid identity length %d", ErrInvalidSignature, len(l.Identity))
+	}
+	var previous []byte
+	recorded := map[string]bool{}
+	for i := range l.Entries {
+		e := &l.Entries[i]
+		if err := verifyEntry(e, uint64(i), previous, recorded); err != nil {
+			return fmt.Errorf("entry %d: %w", i, err)
+		}
+		digest := e.Digest()
+		if !ed25519.Verify(ed25519.PublicKey(l.Identity), digest, e.Signature) {
+			return fmt.Errorf("entry %d: %w", i, ErrInvalidSignature)
+		}
+		previous = digest
+		recorded[string(e.PublicKey)] = true
+	}
+	return nil
+}
+
+func verifyEntry(e *Entry, sequence uint64, previous []byte, recorded map[string]bool) error {
+	if e.Sequence != sequence {
+		return fmt.Errorf("%w: unexpected sequence %d", ErrInvalidEntry, e.Sequence)
+	}
+	if !bytes.Equal(e.Previous, previous) {
+		return fmt.Errorf("%w: previous digest does not match", ErrInvalidEntry)
+	}
+	if len(e.PublicKey) != ed25519.PublicKeySize {
+		return fmt.Errorf("%w: invalid public key length %d", ErrInvalidEntry, len(e.PublicKey))
+	}
+	_, addr, err := address.ParseBech32(e.Address)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidEntry, err)
+	}
+	if !addr.Equal(address.AddressFromPublicKey(ed25519.PublicKey(e.PublicKey))) {
+		return fmt.Errorf("%w: address does not match public key", ErrInvalidEntry)
+	}
+	if len(e.Replaces) > 0 && !recorded[string(e.Replaces)] {
+		return fmt.Errorf("%w: replaced key not recorded", ErrInvalidEntry)
+	}
+	return nil
+}
+
+// Lookup returns the entry of publicKey.
+func (l *KeyLog) Lookup(publicKey ed25519.PublicKey) (*Entry, error) {
+	for i := range l.Entries {
+		if bytes.Equal(l.Entries[i].PublicKey, publicKey) {
+			return &l.Entries[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %x", ErrKeyNotFound, []byte(publicKey))
+}
+
+// Current returns the entries, whose keys have not been replaced by a later entry.
+func (l *KeyLog) Current() []Entry {
+	replaced := map[string]bool{}
+	for i := range l.Entries {
+		replaced[string(l.Entries[i].Replaces)] = true
+	}
+	var res []Entry
+	for i := range l.Entries {
+		if !replaced[string(l.Entries[i].PublicKey)] {
+			res = append(res, l.Entries[i])
+		}
+	}
+	return res
+}
+
+// VerifyDerivations re-derives every key of the log from seed and checks that it belongs to the identity of the
+// seed. It returns nil, if all recorded keys have been derived from seed. The signatures are not checked, use Verify.
+func (l *KeyLog) VerifyDerivations(seed []byte) error {
+	if !bytes.Equal(l.Identity, Identity(seed)) {
+		return ErrIdentityMismatch
+	}
+	for i := range l.Entries {
+		e := &l.Entries[i]
+		public, err := derive(seed, e.Path)
+		if err != nil {
+			return fmt.Errorf("entry %d: %w", i, err)
+		}
+		if !bytes.Equal(public, e.PublicKey) {
+			return fmt.Errorf("entry %d: %w: public key not derived from seed at %s", i, ErrInvalidEntry, e.Path)
+		}
+	}
+	return nil
+}
+
+// Identity returns the public key of the master identity of seed.
+func Identity(seed []byte) ed25519.PublicKey {
+	n := eddsa.NewMasterNode(seed)
+	defer n.Clear()
+	public, _ := n.Seed().Ed25519Key()
+	return public
+}
+
+// Options specifies how a Recorder creates entries.
+type Options struct {
+	// Prefix is the network of the recorded addresses. The zero value corresponds to address.IOTAMainnet.
+	Prefix address.Prefix
+	// Now returns the creation time of entries. If nil, time.Now is used.
+	Now func() time.Time
+}
+
+// Recorder derives keys from a seed and records them in a KeyLog.
+// It is safe for concurrent use.
+type Recorder struct {
+	prefix address.Prefix
+	now    func() time.Time
+
+	mu       sync.Mutex
+	seed     []byte
+	identity ed25519.PrivateKey
+	log      KeyLog
+}
+
+// NewRecorder returns a Recorder for seed appending to log. If log is nil, a new log is started.
+// An existing log must be valid and belong to the identity of seed.
+func NewRecorder(seed []byte, log *KeyLog, opts *Options) (*Recorder, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+	n := eddsa.NewMasterNode(seed)
+	public, private := n.Seed().Ed25519Key()
+	n.Clear()
+
+	r := &Recorder{
+		prefix:   opts.Prefix,
+		now:      opts.Now,
+		seed:     append([]byte{}, seed...),
+		identity: private,
+		log:      KeyLog{Identity: hexutil.Bytes(public)},
+	}
+	if r.now == nil {
+		r.now = time.Now
+	}
+	if log != nil {
+		if !bytes.Equal(log.Identity, public) {
+			return nil, ErrIdentityMismatch
+		}
+		if err := log.Verify(); err != nil {
+			return nil, err
+		}
+		r.log.Entries = append([]Entry{}, log.Entries...)
+	}
+	return r, nil
+}
+
+// Record derives the key of path and appends it with the given purpose to the log.
+// It returns the new entry.
+func (r *Recorder) Record(path bip32path.Path, purpose string) (*Entry, error) {
+	return r.record(path, purpose, nil)
+}
+
+// Rotate derives the key of path and appends it to the log as replacement of the recorded key old.
+// It returns the new entry.
+func (r *Recorder) Rotate(old ed25519.PublicKey, path bip32path.Path, purpose string) (*Entry, error) {
+	if len(old) == 0 {
+		return nil, fmt.Errorf("%w: empty public key", ErrKeyNotFound)
+	}
+	return r.record(path, purpose, old)
+}
+
+func (r *Recorder) record(path bip32path.Path, purpose string, replaces ed25519.PublicKey) (*Entry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if replaces != nil {
+		if _, err := r.log.Lookup(replaces); err != nil {
+			return nil, err
+		}
+	}
+	public, err := derive(r.seed, path)
+	if err != nil {
+		return nil, err
+	}
+	addr, err := address.Bech32(r.prefix, address.AddressFromPublicKey(public))
+	if err != nil {
+		return nil, err
+	}
+	e := Entry{
+		Sequence:  uint64(len(r.log.Entries)),
+		Path:      append(bip32path.Path{}, path...),
+		PublicKey: hexutil.Bytes(public),
+		Address:   addr,
+		CreatedAt: r.now().UTC(),
+		Purpose:   purpose,
+		Replaces:  hexutil.Bytes(append([]byte(nil), replaces...)),
+	}
+	if last := len(r.log.Entries) - 1; last >= 0 {
+		e.Previous = r.log.Entries[last].Digest()
+	}
+	e.Signature = ed25519.Sign(r.identity, e.Digest())
+	r.log.Entries = append(r.log.Entries, e)
+	return &e, nil
+}
+
+// Log returns a copy of the current log.
+func (r *Recorder) Log() *KeyLog {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return &KeyLog{
+		Identity: append(hexutil.Bytes{}, r.log.Identity...),
+		Entries:  append([]Entry{}, r.log.Entries...),
+	}
+}
+
+// Clear overwrites the seed and the identity key with zeros.
+// The Recorder must not be used afterwards.
+func (r *Recorder) Clear() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	clear(r.seed)
+	clear(r.identity)
+}
+
+// derive returns the Ed25519 public key derived from seed at path.
+func derive(seed []byte, path bip32path.Path) (ed25519.PublicKey, error) {
+	n, err := eddsa.DeriveNode(seed, path)
+	if err != nil {
+		return nil, err
+	}
+	defer n.Clear()
+	public, _ := n.Seed().Ed25519Key()
+	return public, nil
+}
@@ -0,0 +1,153 @@
+//nolint:scopelint // from tests
+package keylog_test
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/bech32/address"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip32path"
+	"github.com/iotaledger/iota-crypto-demo/pkg/keylog"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10/eddsa"
+)
+
+var seed, _ = hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+
+// slip10 test vector 1 for Ed25519 at m
+const expectedIdentity = "a4b2856bfec510abab89753fac1ac0e1112364e7d250545963f135f2a33188ed"
+
+func mustParsePath(s string) bip32path.Path {
+	p, err := bip32path.ParsePath(s)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+func newLog(t *testing.T) (*keylog.Recorder, *keylog.KeyLog) {
+	clock := time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC)
+	r, err := keylog.NewRecorder(seed, nil, &keylog.Options{
+		Prefix: address.ShimmerMainnet,
+		Now: func() time.Time {
+			clock = clock.Add(time.Hour)
+			return clock
+		},
+	})
+	require.NoError(t, err)
+	_, err = r.Record(mustParsePath("m/44'/4219'/0'/0'/0'"), "treasury")
+	require.NoError(t, err)
+	_, err = r.Record(mustParsePath("m/44'/4219'/1'/0'/0'"), "payroll")
+	require.NoError(t, err)
+	return r, r.Log()
+}
+
+func TestRecord(t *testing.T) {
+	r, l := newLog(t)
+	assert.Equal(t, expectedIdentity, hex.EncodeToString(l.Identity))
+	assert.Equal(t, []byte(keylog.Identity(seed)), l.Identity.Bytes())
+	require.Len(t, l.Entries, 2)
+
+	e := l.Entries[1]
+	assert.EqualValues(t, 1, e.Sequence)
+	assert.Equal(t, "m/44'/4219'/1'/0'/0'", e.Path.String())
+	assert.Equal(t, "payroll", e.Purpose)
+	assert.Equal(t, time.Date(2024, 1, 2, 5, 4, 5, 6, time.UTC), e.CreatedAt)
+	assert.Equal(t, l.Entries[0].Digest(), e.Previous.Bytes())
+	assert.Empty(t, l.Entries[0].Previous)
+
+	key, err := slip10.DeriveKeyFromPath(seed, eddsa.Ed25519(), e.Path)
+	require.NoError(t, err)
+	public, _ := key.Key.(eddsa.Seed).Ed25519Key()
+	assert.Equal(t, []byte(public), e.PublicKey.Bytes())
+	expectedAddress, err := address.Bech32(address.ShimmerMainnet, address.AddressFromPublicKey(public))
+	require.NoError(t, err)
+	assert.Equal(t, expectedAddress, e.Address)
+
+	require.NoError(t, l.Verify())
+	require.NoError(t, l.VerifyDerivations(seed))
+
+	found, err := l.Lookup(public)
+	require.NoError(t, err)
+	assert.Equal(t, e, *found)
+
+	_, err = r.Record(mustParsePath("m/44'/4219'/1'/0'/0"), "")
+	assert.ErrorIs(t, err, eddsa.ErrNotHardened)
+}
+
+func TestRotate(t *testing.T) {
+	r, l := newLog(t)
+	old := l.Entries[0].PublicKey
+
+	e, err := r.Rotate(old.Bytes(), mustParsePath("m/44'/4219'/0'/0'/1'"), "treasury")
+	require.NoError(t, err)
+	assert.Equal(t, old, e.Replaces)
+	_, err = r.Rotate(make([]byte, 32), mustParsePath("m/44'/4219'/0'/0'/2'"), "treasury")
+	assert.ErrorIs(t, err, keylog.ErrKeyNotFound)
+
+	l = r.Log()
+	require.NoError(t, l.Verify())
+	current := l.Current()
+	require.Len(t, current, 2)
+	assert.Equal(t, "payroll", current[0].Purpose)
+	assert.Equal(t, *e, current[1])
+}
+
+func TestKeyLogJSON(t *testing.T) {
+	_, l := newLog(t)
+	data, err := json.Marshal(l)
+	require.NoError(t, err)
+
+	var decoded keylog.KeyLog
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.NoError(t, decoded.Verify())
+
+	// a recorder continues an existing log
+	r, err := keylog.NewRecorder(seed, &decoded, nil)
+	require.NoError(t, err)
+	e, err := r.Record(mustParsePath("m/44'/4218'/0'/0'/0'"), "")
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, e.Sequence)
+	assert.Equal(t, decoded.Entries[1].Digest(), e.Previous.Bytes())
+	require.NoError(t, r.Log().Verify())
+
+	_, err = keylog.NewRecorder([]byte("another seed"), &decoded, nil)
+	assert.ErrorIs(t, err, keylog.ErrIdentityMismatch)
+}
+
+func TestVerifyInvalid(t *testing.T) {
+	var tests = []*struct {
+		name   string
+		modify func(*keylog.KeyLog)
+		err    error
+	}{
+		{"purpose", func(l *keylog.KeyLog) { l.Entries[0].Purpose = "other" }, keylog.ErrInvalidSignature},
+		{"time", func(l *keylog.KeyLog) { l.Entries[1].CreatedAt = time.Now() }, keylog.ErrInvalidSignature},
+		{"removed", func(l *keylog.KeyLog) { l.Entries = l.Entries[1:] }, keylog.ErrInvalidEntry},
+		{"reordered", func(l *keylog.KeyLog) { l.Entries[0], l.Entries[1] = l.Entries[1], l.Entries[0] }, keylog.ErrInvalidEntry},
+		{"address", func(l *keylog.KeyLog) { l.Entries[0].Address = l.Entries[1].Address }, keylog.ErrInvalidEntry},
+		{"replaces", func(l *keylog.KeyLog) { l.Entries[0].Replaces = l.Entries[1].PublicKey }, keylog.ErrInvalidEntry},
+		{"identity", func(l *keylog.KeyLog) { l.Identity = l.Entries[0].PublicKey }, keylog.ErrInvalidSignature},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, l := newLog(t)
+			tt.modify(l)
+			assert.ErrorIs(t, l.Verify(), tt.err)
+		})
+	}
+}
+
+func TestVerifyDerivations(t *testing.T) {
+	_, l := newLog(t)
+	assert.ErrorIs(t, l.VerifyDerivations([]byte("another seed")), keylog.ErrIdentityMismatch)
+
+	// an entry signed by the identity, whose key has not been derived at the recorded path
+	l.Entries[0].Path = mustParsePath("m/44'/4219'/2'/0'/0'")
+	assert.ErrorIs(t, l.VerifyDerivations(seed), keylog.ErrInvalidEntry)
+}
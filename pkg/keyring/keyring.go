@@ -0,0 +1,79 @@
+/*
+Package keyring provides access to the secret storage of the operating system, so that seeds can be persisted without
+writing them to plaintext files.
+
+The Keyring returned by System uses the macOS Keychain, the Secret Service of the Linux desktop via secret-tool or,
+on Windows, files in the user configuration directory protected with DPAPI. Memory provides an in-process
+implementation for tests and for platforms without system support.
+*/
+package keyring
+
+import (
+	"errors"
+	"sync"
+)
+
+var (
+	// ErrNotFound is returned when no secret is stored for the service and account.
+	ErrNotFound = errors.New("secret not found")
+	// ErrUnsupported is returned when the platform does not provide a supported secret storage.
+	ErrUnsupported = errors.New("keyring not supported on this platform")
+)
+
+// Keyring stores secrets identified by a service and an account name.
+type Keyring interface {
+	// Set stores secret for the service and account, replacing any existing secret.
+	Set(service, account string, secret []byte) error
+	// Get returns the secret stored for the service and account or ErrNotFound.
+	Get(service, account string) ([]byte, error)
+	// Delete removes the secret stored for the service and account or returns ErrNotFound.
+	Delete(service, account string) error
+}
+
+// System returns the keyring of the operating system.
+// It returns ErrUnsupported, if the platform or its required tools are not available.
+func System() (Keyring, error) {
+	return system()
+}
+
+// Memory is a Keyring keeping the secrets in memory. The zero value is an empty keyring ready to use.
+type Memory struct {
+	mu      sync.Mutex
+	secrets map[[2]string][]byte
+}
+
+// Set implements Keyring.
+func (m *Memory) Set(service, account string, secret []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.secrets == nil {
+		m.secrets = map[[2]string][]byte{}
+	}
+	m.secrets[[2]string{service, account}] = append([]byte{}, secret...)
+	return nil
+}
+
+// Get implements Keyring.
+func (m *Memory) Get(service, account string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	secret, ok := m.secrets[[2]string{service, account}]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return append([]byte{}, secret...), nil
+}
+
+// Delete implements Keyring.
+func (m *Memory) Delete(service, account string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := [2]string{service, account}
+	secret, ok := m.secrets[key]
+	if !ok {
+		return ErrNotFound
+	}
+	clear(secret)
+	delete(m.secrets, key)
+	return nil
+}
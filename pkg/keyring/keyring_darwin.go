@@ -0,0 +1,65 @@
+package keyring
+
+import (
+	"encoding/hex"
+	"errors"
+	"os/exec"
+	"strings"
+)
+
+// exit status of the security tool when the item could not be found
+const errSecItemNotFound = 44
+
+// keychain stores secrets as generic passwords in the default macOS Keychain using the security tool.
+// The secrets are stored hex encoded, as the tool only handles text.
+type keychain struct {
+	path string
+}
+
+func system() (Keyring, error) {
+	path, err := exec.LookPath("security")
+	if err != nil {
+		return nil, ErrUnsupported
+	}
+	return &keychain{path}, nil
+}
+
+func (k *keychain) Set(service, account string, secret []byte) error {
+	return run(k.setCommand(service, account, secret))
+}
+
+// setCommand returns the command storing secret. The secret is passed via stdin, so that it does not appear in the
+// process list; the tool only reads it from there, if -w is the last argument.
+func (k *keychain) setCommand(service, account string, secret []byte) *exec.Cmd {
+	// -U updates an existing item instead of failing
+	cmd := exec.Command(k.path, "add-generic-password", "-U", "-s", service, "-a", account, "-w")
+	// the tool asks for the password and then for its confirmation
+	password := hex.EncodeToString(secret)
+	cmd.Stdin = strings.NewReader(password + "\n" + password + "\n")
+	return cmd
+}
+
+func (k *keychain) Get(service, account string) ([]byte, error) {
+	var out strings.Builder
+	cmd := exec.Command(k.path, "find-generic-password", "-s", service, "-a", account, "-w")
+	cmd.Stdout = &out
+	if err := run(cmd); err != nil {
+		return nil, err
+	}
+	return hex.DecodeString(strings.TrimSpace(out.String()))
+}
+
+func (k *keychain) Delete(service, account string) error {
+	return run(exec.Command(k.path, "delete-generic-password", "-s", service, "-a", account))
+}
+
+func run(cmd *exec.Cmd) error {
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == errSecItemNotFound {
+			return ErrNotFound
+		}
+		return err
+	}
+	return nil
+}
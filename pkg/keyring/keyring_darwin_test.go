@@ -0,0 +1,27 @@
+package keyring
+
+import (
+	"encoding/hex"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeychainSetCommand(t *testing.T) {
+	secret := []byte{0xde, 0xad, 0xbe, 0xef}
+	k := &keychain{"/usr/bin/security"}
+	cmd := k.setCommand("service", "account", secret)
+
+	for _, arg := range cmd.Args {
+		assert.NotContains(t, arg, hex.EncodeToString(secret))
+	}
+	assert.Equal(t, "-w", cmd.Args[len(cmd.Args)-1])
+
+	require.NotNil(t, cmd.Stdin)
+	stdin, err := io.ReadAll(cmd.Stdin)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"deadbeef", "deadbeef", ""}, strings.Split(string(stdin), "\n"))
+}
@@ -0,0 +1,49 @@
+package keyring
+
+import (
+	"encoding/hex"
+	"errors"
+	"os/exec"
+	"strings"
+)
+
+// secretService stores secrets in the Secret Service of the desktop session, e.g. GNOME Keyring or KWallet, using the
+// secret-tool of libsecret. The secrets are stored hex encoded, as the tool only handles text.
+type secretService struct {
+	path string
+}
+
+func system() (Keyring, error) {
+	path, err := exec.LookPath("secret-tool")
+	if err != nil {
+		return nil, ErrUnsupported
+	}
+	return &secretService{path}, nil
+}
+
+func (s *secretService) Set(service, account string, secret []byte) error {
+	cmd := exec.Command(s.path, "store", "--label="+service+" "+account, "service", service, "account", account)
+	// the secret is passed via stdin, so that it does not appear in the process list
+	cmd.Stdin = strings.NewReader(hex.EncodeToString(secret))
+	return cmd.Run()
+}
+
+func (s *secretService) Get(service, account string) ([]byte, error) {
+	out, err := exec.Command(s.path, "lookup", "service", service, "account", account).Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		// secret-tool exits with status 1 and no output, if no matching item exists
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 && len(out) == 0 {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return hex.DecodeString(strings.TrimSpace(string(out)))
+}
+
+func (s *secretService) Delete(service, account string) error {
+	if _, err := s.Get(service, account); err != nil {
+		return err
+	}
+	return exec.Command(s.path, "clear", "service", service, "account", account).Run()
+}
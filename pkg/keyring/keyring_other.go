@@ -0,0 +1,7 @@
+//go:build !(darwin || linux || windows)
+
+package keyring
+
+func system() (Keyring, error) {
+	return nil, ErrUnsupported
+}
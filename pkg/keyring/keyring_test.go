@@ -0,0 +1,38 @@
+package keyring_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/keyring"
+)
+
+func TestMemory(t *testing.T) {
+	var k keyring.Keyring = &keyring.Memory{}
+
+	_, err := k.Get("service", "account")
+	assert.ErrorIs(t, err, keyring.ErrNotFound)
+
+	secret := []byte{1, 2, 3}
+	require.NoError(t, k.Set("service", "account", secret))
+	secret[0] = 0
+
+	res, err := k.Get("service", "account")
+	require.NoError(t, err)
+	assert.Equal(t, []byte{1, 2, 3}, res)
+
+	_, err = k.Get("service", "other")
+	assert.ErrorIs(t, err, keyring.ErrNotFound)
+
+	require.NoError(t, k.Set("service", "account", []byte{4}))
+	res, err = k.Get("service", "account")
+	require.NoError(t, err)
+	assert.Equal(t, []byte{4}, res)
+
+	require.NoError(t, k.Delete("service", "account"))
+	_, err = k.Get("service", "account")
+	assert.ErrorIs(t, err, keyring.ErrNotFound)
+	assert.ErrorIs(t, k.Delete("service", "account"), keyring.ErrNotFound)
+}
@@ -0,0 +1,117 @@
+package keyring
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"syscall"
+	"unsafe"
+)
+
+var (
+	crypt32                = syscall.NewLazyDLL("crypt32.dll")
+	procCryptProtectData   = crypt32.NewProc("CryptProtectData")
+	procCryptUnprotectData = crypt32.NewProc("CryptUnprotectData")
+	procLocalFree          = syscall.NewLazyDLL("kernel32.dll").NewProc("LocalFree")
+)
+
+// cryptprotectUIForbidden fails the call instead of showing a user interface.
+const cryptprotectUIForbidden = 0x1
+
+type dataBlob struct {
+	size uint32
+	data *byte
+}
+
+func newBlob(b []byte) *dataBlob {
+	if len(b) == 0 {
+		return &dataBlob{}
+	}
+	return &dataBlob{size: uint32(len(b)), data: &b[0]}
+}
+
+func (b *dataBlob) bytes() []byte {
+	out := make([]byte, b.size)
+	copy(out, unsafe.Slice(b.data, b.size))
+	return out
+}
+
+// dpapi stores secrets in files encrypted with the Data Protection API, so that only the current user can decrypt them.
+type dpapi struct {
+	dir string
+}
+
+func system() (Keyring, error) {
+	if err := crypt32.Load(); err != nil {
+		return nil, ErrUnsupported
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return nil, ErrUnsupported
+	}
+	return &dpapi{filepath.Join(dir, "iota-crypto-demo", "keyring")}, nil
+}
+
+func (d *dpapi) path(service, account string) string {
+	return filepath.Join(d.dir, escapeName(service), escapeName(account)+".dpapi")
+}
+
+func (d *dpapi) Set(service, account string, secret []byte) error {
+	var out dataBlob
+	r, _, err := procCryptProtectData.Call(uintptr(unsafe.Pointer(newBlob(secret))), 0, 0, 0, 0,
+		cryptprotectUIForbidden, uintptr(unsafe.Pointer(&out)))
+	if r == 0 {
+		return err
+	}
+	defer procLocalFree.Call(uintptr(unsafe.Pointer(out.data))) //nolint:errcheck
+
+	path := d.path(service, account)
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, out.bytes(), 0o600)
+}
+
+func (d *dpapi) Get(service, account string) ([]byte, error) {
+	encrypted, err := os.ReadFile(d.path(service, account))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	var out dataBlob
+	r, _, err := procCryptUnprotectData.Call(uintptr(unsafe.Pointer(newBlob(encrypted))), 0, 0, 0, 0,
+		cryptprotectUIForbidden, uintptr(unsafe.Pointer(&out)))
+	if r == 0 {
+		return nil, err
+	}
+	defer procLocalFree.Call(uintptr(unsafe.Pointer(out.data))) //nolint:errcheck
+	return out.bytes(), nil
+}
+
+func (d *dpapi) Delete(service, account string) error {
+	err := os.Remove(d.path(service, account))
+	if errors.Is(err, fs.ErrNotExist) {
+		return ErrNotFound
+	}
+	return err
+}
+
+// escapeName escapes s, so that it can be safely used as a file name.
+// Uppercase letters are escaped as well, as file names are case-insensitive and names differing only in case must not
+// map to the same file.
+func escapeName(s string) string {
+	const hex = "0123456789abcdef"
+	var b []byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '-' || c == '_' || c == '.' && i > 0 {
+			b = append(b, c)
+			continue
+		}
+		b = append(b, '%', hex[c>>4], hex[c&0xF])
+	}
+	return string(b)
+}
@@ -0,0 +1,24 @@
+package keyring
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEscapeName(t *testing.T) {
+	assert.Equal(t, "service-1_a.b", escapeName("service-1_a.b"))
+	assert.Equal(t, "%2eservice%2f%5c", escapeName(".service/\\"))
+
+	// names differing only in case must not collide on a case-insensitive file system
+	for _, names := range [][2]string{{"service", "Service"}, {"ACCOUNT", "account"}, {"aB", "Ab"}} {
+		assert.False(t, strings.EqualFold(escapeName(names[0]), escapeName(names[1])), names)
+	}
+}
+
+func TestPathCaseCollision(t *testing.T) {
+	d := &dpapi{dir: t.TempDir()}
+	assert.False(t, strings.EqualFold(d.path("service", "account"), d.path("Service", "account")))
+	assert.False(t, strings.EqualFold(d.path("service", "account"), d.path("service", "Account")))
+}
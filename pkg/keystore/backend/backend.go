@@ -0,0 +1,247 @@
+/*
+Package backend defines the KeyStore interface through which seeds are loaded and used for key derivation and
+signing, so that tools can switch between the storage backends without code changes.
+
+The implementations keep the seed in memory, decrypt it from an encrypted JSON keystore file or fetch it from the
+secret storage of the operating system, while keys held by PKCS#11 tokens are provided by the pkcs11 package. Open
+selects the backend from a single specification string like "file:wallet.json" or "keyring:service/account", which
+can directly be passed as command-line flag.
+*/
+package backend
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/iotaledger/iota-crypto-demo/internal/rand"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip32path"
+	"github.com/iotaledger/iota-crypto-demo/pkg/ed25519"
+	"github.com/iotaledger/iota-crypto-demo/pkg/keyring"
+	"github.com/iotaledger/iota-crypto-demo/pkg/keystore"
+	"github.com/iotaledger/iota-crypto-demo/pkg/pkcs11"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10/eddsa"
+)
+
+var (
+	// ErrInvalidSpec is returned when a backend specification cannot be parsed.
+	ErrInvalidSpec = errors.New("invalid backend specification")
+	// ErrNotHardened is returned when a path contains non-hardened indices that are not supported by Ed25519.
+	ErrNotHardened = errors.New("path must only contain hardened indices")
+)
+
+// KeyStore provides access to a seed and the Ed25519 keys derived from it using SLIP-10.
+type KeyStore interface {
+	// Seed loads the seed.
+	Seed() ([]byte, error)
+	// PublicKey returns the public key derived for path.
+	PublicKey(path bip32path.Path) (ed25519.PublicKey, error)
+	// Sign signs message with the key derived for path.
+	Sign(path bip32path.Path, message []byte) ([]byte, error)
+}
+
+// deriver implements the derivation and signing of a KeyStore on top of a function loading the seed.
+type deriver struct {
+	load func() ([]byte, error)
+}
+
+// Seed implements KeyStore.
+func (d deriver) Seed() ([]byte, error) {
+	return d.load()
+}
+
+// PublicKey implements KeyStore.
+func (d deriver) PublicKey(path bip32path.Path) (ed25519.PublicKey, error) {
+	key, err := d.privateKey(path)
+	if err != nil {
+		return nil, err
+	}
+	//nolint:forcetypeassert // ed25519.PrivateKey always returns an ed25519.PublicKey
+	return key.Public().(ed25519.PublicKey), nil
+}
+
+// Sign implements KeyStore.
+func (d deriver) Sign(path bip32path.Path, message []byte) ([]byte, error) {
+	key, err := d.privateKey(path)
+	if err != nil {
+		return nil, err
+	}
+	return ed25519.Sign(key, message), nil
+}
+
+func (d deriver) privateKey(path bip32path.Path) (ed25519.PrivateKey, error) {
+	for _, index := range path {
+		if index < slip10.Hardened {
+			return nil, fmt.Errorf("%w: %s", ErrNotHardened, path)
+		}
+	}
+	seed, err := d.load()
+	if err != nil {
+		return nil, err
+	}
+	defer clear(seed)
+	key, err := slip10.DeriveKeyFromPath(seed, eddsa.Ed25519(), path)
+	if err != nil {
+		return nil, err
+	}
+	//nolint:forcetypeassert // the Ed25519 curve always returns an eddsa.Seed
+	_, private := key.Key.(eddsa.Seed).Ed25519Key()
+	return private, nil
+}
+
+// Memory is a KeyStore keeping the seed in memory.
+type Memory struct {
+	deriver
+	seed []byte
+}
+
+// NewMemory returns a KeyStore for a copy of seed.
+func NewMemory(seed []byte) *Memory {
+	m := &Memory{seed: append([]byte{}, seed...)}
+	m.load = func() ([]byte, error) { return append([]byte{}, m.seed...), nil }
+	return m
+}
+
+// File is a KeyStore for a seed encrypted in a JSON keystore file.
+// The keystore is decrypted on first use and the seed is kept in memory afterwards.
+type File struct {
+	deriver
+	name     string
+	password func() (string, error)
+
+	once sync.Once
+	seed []byte
+	err  error
+}
+
+// NewFile returns a KeyStore for the keystore file name, which calls password to obtain the decryption password.
+func NewFile(name string, password func() (string, error)) *File {
+	f := &File{name: name, password: password}
+	f.load = f.decrypt
+	return f
+}
+
+func (f *File) decrypt() ([]byte, error) {
+	f.once.Do(func() {
+		var data []byte
+		if data, f.err = os.ReadFile(f.name); f.err != nil {
+			return
+		}
+		k := &keystore.Keystore{}
+		if f.err = json.Unmarshal(data, k); f.err != nil {
+			f.err = fmt.Errorf("invalid keystore %s: %w", f.name, f.err)
+			return
+		}
+		var password string
+		if password, f.err = f.password(); f.err != nil {
+			return
+		}
+		f.seed, f.err = k.Decrypt(password)
+	})
+	if f.err != nil {
+		return nil, f.err
+	}
+	return append([]byte{}, f.seed...), nil
+}
+
+// CreateFile encrypts seed with password and writes the resulting keystore to the file name.
+func CreateFile(name string, seed []byte, password string, opts *keystore.Options) error {
+	k, err := keystore.Encrypt(rand.Reader, seed, password, opts)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(k, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(name, data, 0o600)
+}
+
+// Keyring is a KeyStore for a seed stored in a keyring.
+// The seed is fetched from the keyring for every operation and not kept in memory.
+type Keyring struct {
+	deriver
+	keyring          keyring.Keyring
+	service, account string
+}
+
+// NewKeyring returns a KeyStore for the seed stored in k for the service and account.
+func NewKeyring(k keyring.Keyring, service, account string) *Keyring {
+	r := &Keyring{keyring: k, service: service, account: account}
+	r.load = func() ([]byte, error) { return r.keyring.Get(r.service, r.account) }
+	return r
+}
+
+// Open returns the KeyStore described by spec, which has one of the following forms:
+//   - "hex:<seed>" for a hex-encoded seed kept in memory,
+//   - "file:<name>" for a JSON keystore file decrypted with the password returned by password,
+//   - "keyring:<service>/<account>" for a seed stored in the keyring of the operating system.
+func Open(spec string, password func() (string, error)) (KeyStore, error) {
+	scheme, value, ok := strings.Cut(spec, ":")
+	if !ok || value == "" {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidSpec, spec)
+	}
+	switch scheme {
+	case "hex":
+		seed, err := hex.DecodeString(value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidSpec, err)
+		}
+		return NewMemory(seed), nil
+	case "file":
+		return NewFile(value, password), nil
+	case "keyring":
+		service, account, ok := strings.Cut(value, "/")
+		if !ok || service == "" || account == "" {
+			return nil, fmt.Errorf("%w: keyring requires service/account", ErrInvalidSpec)
+		}
+		k, err := keyring.System()
+		if err != nil {
+			return nil, err
+		}
+		return NewKeyring(k, service, account), nil
+	case "pkcs11":
+		return openToken(value, password)
+	default:
+		return nil, fmt.Errorf("%w: unknown backend %q", ErrInvalidSpec, scheme)
+	}
+}
+
+// openToken opens a session with the token of the PKCS#11 module described by "<library>[:<slot>]".
+func openToken(value string, pin func() (string, error)) (*pkcs11.Token, error) {
+	library, slotString, hasSlot := strings.Cut(value, ":")
+	m, err := pkcs11.Open(library)
+	if err != nil {
+		return nil, err
+	}
+	slots, err := m.Slots()
+	if err != nil {
+		return nil, errors.Join(err, m.Close())
+	}
+	if len(slots) == 0 {
+		return nil, errors.Join(fmt.Errorf("no token present in %s", library), m.Close())
+	}
+	slot := slots[0]
+	if hasSlot {
+		s, err := strconv.ParseUint(slotString, 10, 0)
+		if err != nil {
+			return nil, errors.Join(fmt.Errorf("%w: invalid slot %q", ErrInvalidSpec, slotString), m.Close())
+		}
+		slot = uint(s)
+	}
+	p, err := pin()
+	if err != nil {
+		return nil, errors.Join(err, m.Close())
+	}
+	session, err := m.OpenSession(slot, p)
+	if err != nil {
+		return nil, errors.Join(err, m.Close())
+	}
+	return pkcs11.NewToken(session), nil
+}
@@ -0,0 +1,98 @@
+//nolint:scopelint // from tests
+package backend_test
+
+import (
+	"encoding/hex"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip32path"
+	"github.com/iotaledger/iota-crypto-demo/pkg/ed25519"
+	"github.com/iotaledger/iota-crypto-demo/pkg/keyring"
+	"github.com/iotaledger/iota-crypto-demo/pkg/keystore"
+	"github.com/iotaledger/iota-crypto-demo/pkg/keystore/backend"
+)
+
+var seed, _ = hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+
+// slip10 test vector 1 for Ed25519 at m/0'/1'
+const expectedPublicKey = "1932a5270f335bed617d5b935c80aedb1a35bd9fc1e31acafd5372c30f5c1187"
+
+func TestKeyStores(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "keystore.json")
+	require.NoError(t, backend.CreateFile(name, seed, "password", &keystore.Options{KDF: keystore.PBKDF2, Cost: 1 << 10}))
+
+	var kr keyring.Memory
+	require.NoError(t, kr.Set("service", "account", seed))
+
+	var tests = []*struct {
+		name  string
+		store backend.KeyStore
+	}{
+		{"Memory", backend.NewMemory(seed)},
+		{"File", backend.NewFile(name, func() (string, error) { return "password", nil })},
+		{"Keyring", backend.NewKeyring(&kr, "service", "account")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := tt.store.Seed()
+			require.NoError(t, err)
+			assert.Equal(t, seed, s)
+
+			path, _ := bip32path.ParsePath("m/0'/1'")
+			public, err := tt.store.PublicKey(path)
+			require.NoError(t, err)
+			assert.Equal(t, expectedPublicKey, hex.EncodeToString(public))
+
+			sig, err := tt.store.Sign(path, []byte("message"))
+			require.NoError(t, err)
+			assert.True(t, ed25519.Verify(public, []byte("message"), sig))
+
+			soft, _ := bip32path.ParsePath("m/0'/1")
+			_, err = tt.store.Sign(soft, []byte("message"))
+			assert.ErrorIs(t, err, backend.ErrNotHardened)
+
+			// the seed must still be available after signing
+			s, err = tt.store.Seed()
+			require.NoError(t, err)
+			assert.Equal(t, seed, s)
+		})
+	}
+}
+
+func TestFileInvalidPassword(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "keystore.json")
+	require.NoError(t, backend.CreateFile(name, seed, "password", &keystore.Options{KDF: keystore.PBKDF2, Cost: 1 << 10}))
+
+	_, err := backend.NewFile(name, func() (string, error) { return "wrong", nil }).Seed()
+	assert.ErrorIs(t, err, keystore.ErrInvalidPassword)
+}
+
+func TestKeyringNotFound(t *testing.T) {
+	_, err := backend.NewKeyring(&keyring.Memory{}, "service", "account").Seed()
+	assert.ErrorIs(t, err, keyring.ErrNotFound)
+}
+
+func TestOpen(t *testing.T) {
+	store, err := backend.Open("hex:"+hex.EncodeToString(seed), nil)
+	require.NoError(t, err)
+	s, err := store.Seed()
+	require.NoError(t, err)
+	assert.Equal(t, seed, s)
+
+	store, err = backend.Open("file:keystore.json", nil)
+	require.NoError(t, err)
+	assert.IsType(t, &backend.File{}, store)
+
+	_, err = backend.Open("pkcs11:/nonexistent/libpkcs11.so", nil)
+	assert.Error(t, err)
+
+	for _, spec := range []string{"", "hex:", "hex:xyz", "keyring:service", "unknown:value"} {
+		_, err := backend.Open(spec, nil)
+		assert.ErrorIs(t, err, backend.ErrInvalidSpec, spec)
+	}
+}
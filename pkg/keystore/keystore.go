@@ -0,0 +1,374 @@
+/*
+Package keystore implements encrypted JSON keystores for seeds and private keys based on the format specified in
+EIP-2335.
+
+The secret is encrypted with a key derived from a password using scrypt, PBKDF2 or Argon2id. Besides the AES-128-CTR
+cipher and SHA-256 checksum of EIP-2335, the authenticated AES-128-GCM cipher is supported as an extension.
+Keystores using scrypt or PBKDF2 with AES-128-CTR are compatible with EIP-2335 and are tested against its test
+vectors.
+*/
+package keystore
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/iotaledger/iota-crypto-demo/internal/hexutil"
+)
+
+// Version is the version of the keystore format.
+const Version = 4
+
+// Supported key derivation functions.
+const (
+	// Scrypt denotes the scrypt key derivation function.
+	Scrypt = "scrypt"
+	// PBKDF2 denotes the PBKDF2 key derivation function with HMAC-SHA256.
+	PBKDF2 = "pbkdf2"
+	// Argon2id denotes the Argon2id key derivation function.
+	Argon2id = "argon2id"
+)
+
+// Supported ciphers.
+const (
+	// AES128CTR denotes AES-128 in counter mode.
+	AES128CTR = "aes-128-ctr"
+	// AES128GCM denotes AES-128 in Galois/counter mode.
+	AES128GCM = "aes-128-gcm"
+)
+
+// default and maximum parameters of the key derivation functions
+const (
+	defaultScryptN          = 1 << 18
+	defaultPBKDF2Iterations = 1 << 18
+	defaultArgon2Memory     = 1 << 16 // in KiB
+	defaultArgon2Time       = 3
+
+	maxDKLen            = 1 << 10
+	maxScryptRP         = 1 << 8
+	maxScryptWork       = 1 << 32 // 128⋅N⋅r⋅p, the bytes processed by the mixing function
+	maxPBKDF2Iterations = 1 << 24
+	maxArgon2Time       = 1 << 4
+	maxArgon2Memory     = 1 << 22 // 4 GiB in KiB
+	maxArgon2Work       = 1 << 24 // time⋅memory in KiB
+)
+
+const (
+	saltSize   = 32
+	keySize    = 32
+	checksumFn = "sha256"
+	pbkdf2PRF  = "hmac-sha256"
+)
+
+var (
+	// ErrUnsupportedVersion is returned when a keystore has an unknown version.
+	ErrUnsupportedVersion = errors.New("unsupported version")
+	// ErrUnsupportedFunction is returned when a keystore uses an unknown KDF, checksum or cipher.
+	ErrUnsupportedFunction = errors.New("unsupported function")
+	// ErrInvalidParams is returned when the parameters of a function are invalid.
+	ErrInvalidParams = errors.New("invalid parameters")
+	// ErrInvalidPassword is returned when the checksum does not match, i.e. the password is wrong.
+	ErrInvalidPassword = errors.New("invalid password")
+)
+
+// Keystore represents an encrypted secret.
+type Keystore struct {
+	// Crypto contains the modules used to encrypt the secret.
+	Crypto Crypto `json:"crypto"`
+	// Description is an optional description of the keystore.
+	Description string `json:"description,omitempty"`
+	// PublicKey is the optional public key corresponding to the secret.
+	PublicKey hexutil.Bytes `json:"pubkey"`
+	// Path is the derivation path of the secret or empty, if it was not derived.
+	Path string `json:"path"`
+	// UUID is a random identifier of the keystore.
+	UUID string `json:"uuid"`
+	// Version is the version of the keystore format.
+	Version int `json:"version"`
+}
+
+// Crypto contains the KDF, checksum and cipher modules of a keystore.
+type Crypto struct {
+	KDF      Module `json:"kdf"`
+	Checksum Module `json:"checksum"`
+	Cipher   Module `json:"cipher"`
+}
+
+// Module describes a function, its parameters and its output.
+type Module struct {
+	Function string          `json:"function"`
+	Params   json.RawMessage `json:"params"`
+	Message  hexutil.Bytes   `json:"message"`
+}
+
+type scryptParams struct {
+	DKLen int           `json:"dklen"`
+	N     int           `json:"n"`
+	P     int           `json:"p"`
+	R     int           `json:"r"`
+	Salt  hexutil.Bytes `json:"salt"`
+}
+
+type pbkdf2Params struct {
+	DKLen int           `json:"dklen"`
+	C     int           `json:"c"`
+	PRF   string        `json:"prf"`
+	Salt  hexutil.Bytes `json:"salt"`
+}
+
+type argon2Params struct {
+	DKLen       int           `json:"dklen"`
+	Memory      uint32        `json:"memory"`
+	Iterations  uint32        `json:"iterations"`
+	Parallelism uint8         `json:"parallelism"`
+	Salt        hexutil.Bytes `json:"salt"`
+}
+
+type cipherParams struct {
+	IV hexutil.Bytes `json:"iv"`
+}
+
+// Options specifies how a secret is encrypted.
+// Zero values select the defaults, i.e. scrypt with the parameters recommended by EIP-2335 and AES-128-CTR.
+type Options struct {
+	// KDF is the key derivation function.
+	KDF string
+	// Cipher is the cipher used to encrypt the secret.
+	Cipher string
+	// Cost is the scrypt N, the PBKDF2 iteration count or the Argon2id memory in KiB.
+	Cost int
+	// Description, PublicKey and Path are stored unencrypted in the keystore.
+	Description string
+	PublicKey   []byte
+	Path        string
+}
+
+// Encrypt encrypts secret with password and returns the resulting keystore.
+// The salt, IV and UUID are read from rand.
+func Encrypt(rand io.Reader, secret []byte, password string, opts *Options) (*Keystore, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+	salt := make([]byte, saltSize)
+	if _, err := io.ReadFull(rand, salt); err != nil {
+		return nil, err
+	}
+
+	var kdf Module
+	switch opts.KDF {
+	case "", Scrypt:
+		kdf = newModule(Scrypt, &scryptParams{DKLen: keySize, N: orDefault(opts.Cost, defaultScryptN), P: 1, R: 8, Salt: salt})
+	case PBKDF2:
+		kdf = newModule(PBKDF2, &pbkdf2Params{DKLen: keySize, C: orDefault(opts.Cost, defaultPBKDF2Iterations), PRF: pbkdf2PRF, Salt: salt})
+	case Argon2id:
+		kdf = newModule(Argon2id, &argon2Params{DKLen: keySize, Memory: uint32(orDefault(opts.Cost, defaultArgon2Memory)), Iterations: defaultArgon2Time, Parallelism: 1, Salt: salt})
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedFunction, opts.KDF)
+	}
+	key, err := deriveKey(&kdf, password)
+	if err != nil {
+		return nil, err
+	}
+
+	cipherFn := opts.Cipher
+	if cipherFn == "" {
+		cipherFn = AES128CTR
+	}
+	var iv []byte
+	switch cipherFn {
+	case AES128CTR:
+		iv = make([]byte, aes.BlockSize)
+	case AES128GCM:
+		iv = make([]byte, 12)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedFunction, cipherFn)
+	}
+	if _, err := io.ReadFull(rand, iv); err != nil {
+		return nil, err
+	}
+	c := newModule(cipherFn, &cipherParams{IV: iv})
+	if c.Message, err = crypt(&c, key, secret, true); err != nil {
+		return nil, err
+	}
+
+	uuid, err := newUUID(rand)
+	if err != nil {
+		return nil, err
+	}
+	return &Keystore{
+		Crypto: Crypto{
+			KDF:      kdf,
+			Checksum: Module{Function: checksumFn, Params: json.RawMessage("{}"), Message: checksum(key, c.Message)},
+			Cipher:   c,
+		},
+		Description: opts.Description,
+		PublicKey:   opts.PublicKey,
+		Path:        opts.Path,
+		UUID:        uuid,
+		Version:     Version,
+	}, nil
+}
+
+// Decrypt decrypts the secret of the keystore with password.
+func (k *Keystore) Decrypt(password string) ([]byte, error) {
+	if k.Version != Version {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, k.Version)
+	}
+	if k.Crypto.Checksum.Function != checksumFn {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedFunction, k.Crypto.Checksum.Function)
+	}
+	key, err := deriveKey(&k.Crypto.KDF, password)
+	if err != nil {
+		return nil, err
+	}
+	if subtle.ConstantTimeCompare(checksum(key, k.Crypto.Cipher.Message), k.Crypto.Checksum.Message) != 1 {
+		return nil, ErrInvalidPassword
+	}
+	return crypt(&k.Crypto.Cipher, key, k.Crypto.Cipher.Message, false)
+}
+
+// deriveKey derives the decryption key from password using the KDF module. The memory and the total work of the key
+// derivation are bounded, so that decrypting a malicious keystore cannot exhaust the resources.
+func deriveKey(kdf *Module, password string) ([]byte, error) {
+	pw := processPassword(password)
+	switch kdf.Function {
+	case Scrypt:
+		var p scryptParams
+		if err := unmarshalParams(kdf.Params, &p); err != nil {
+			return nil, err
+		}
+		if p.DKLen < keySize || p.DKLen > maxDKLen {
+			return nil, fmt.Errorf("%w: dklen %d", ErrInvalidParams, p.DKLen)
+		}
+		if p.N < 1 || p.N > maxScryptWork/128 || p.R < 1 || p.P < 1 || uint64(p.R)*uint64(p.P) > maxScryptRP ||
+			128*uint64(p.N)*uint64(p.R)*uint64(p.P) > maxScryptWork {
+			return nil, fmt.Errorf("%w: scrypt n=%d, r=%d, p=%d", ErrInvalidParams, p.N, p.R, p.P)
+		}
+		key, err := scrypt.Key(pw, p.Salt, p.N, p.R, p.P, p.DKLen)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidParams, err)
+		}
+		return key, nil
+	case PBKDF2:
+		var p pbkdf2Params
+		if err := unmarshalParams(kdf.Params, &p); err != nil {
+			return nil, err
+		}
+		if p.PRF != pbkdf2PRF {
+			return nil, fmt.Errorf("%w: %s", ErrUnsupportedFunction, p.PRF)
+		}
+		if p.DKLen < keySize || p.DKLen > maxDKLen || p.C < 1 || p.C > maxPBKDF2Iterations {
+			return nil, fmt.Errorf("%w: pbkdf2 dklen=%d, c=%d", ErrInvalidParams, p.DKLen, p.C)
+		}
+		return pbkdf2.Key(pw, p.Salt, p.C, p.DKLen, sha256.New), nil
+	case Argon2id:
+		var p argon2Params
+		if err := unmarshalParams(kdf.Params, &p); err != nil {
+			return nil, err
+		}
+		if p.DKLen < keySize || p.DKLen > maxDKLen || p.Iterations < 1 || p.Iterations > maxArgon2Time ||
+			p.Parallelism < 1 || p.Memory > maxArgon2Memory || uint64(p.Iterations)*uint64(p.Memory) > maxArgon2Work {
+			return nil, fmt.Errorf("%w: argon2id dklen=%d, t=%d, m=%d, p=%d",
+				ErrInvalidParams, p.DKLen, p.Iterations, p.Memory, p.Parallelism)
+		}
+		return argon2.IDKey(pw, p.Salt, p.Iterations, p.Memory, p.Parallelism, uint32(p.DKLen)), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedFunction, kdf.Function)
+	}
+}
+
+// crypt encrypts or decrypts data with the first half of key using the cipher module.
+func crypt(c *Module, key []byte, data []byte, encrypt bool) ([]byte, error) {
+	var p cipherParams
+	if err := unmarshalParams(c.Params, &p); err != nil {
+		return nil, err
+	}
+	block, _ := aes.NewCipher(key[:16])
+
+	switch c.Function {
+	case AES128CTR:
+		if len(p.IV) != aes.BlockSize {
+			return nil, fmt.Errorf("%w: iv length %d", ErrInvalidParams, len(p.IV))
+		}
+		out := make([]byte, len(data))
+		cipher.NewCTR(block, p.IV).XORKeyStream(out, data)
+		return out, nil
+	case AES128GCM:
+		aead, _ := cipher.NewGCM(block)
+		if len(p.IV) != aead.NonceSize() {
+			return nil, fmt.Errorf("%w: iv length %d", ErrInvalidParams, len(p.IV))
+		}
+		if encrypt {
+			return aead.Seal(nil, p.IV, data, nil), nil
+		}
+		out, err := aead.Open(nil, p.IV, data, nil)
+		if err != nil {
+			return nil, ErrInvalidPassword
+		}
+		return out, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedFunction, c.Function)
+	}
+}
+
+// checksum computes the SHA-256 checksum of the second half of key and the cipher message.
+func checksum(key []byte, cipherMessage []byte) []byte {
+	h := sha256.New()
+	h.Write(key[16:32])
+	h.Write(cipherMessage)
+	return h.Sum(nil)
+}
+
+// processPassword normalizes the password with NFKD and strips the C0, C1 and Delete control codes.
+func processPassword(password string) []byte {
+	var b bytes.Buffer
+	for _, r := range norm.NFKD.String(password) {
+		if r < 0x20 || (r >= 0x7F && r <= 0x9F) {
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.Bytes()
+}
+
+func newModule(function string, params any) Module {
+	b, _ := json.Marshal(params)
+	return Module{Function: function, Params: b, Message: hexutil.Bytes{}}
+}
+
+func unmarshalParams(params json.RawMessage, v any) error {
+	if err := json.Unmarshal(params, v); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidParams, err)
+	}
+	return nil
+}
+
+// newUUID returns a random version 4 UUID.
+func newUUID(rand io.Reader) (string, error) {
+	var u [16]byte
+	if _, err := io.ReadFull(rand, u[:]); err != nil {
+		return "", err
+	}
+	u[6] = u[6]&0x0F | 0x40
+	u[8] = u[8]&0x3F | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", u[0:4], u[4:6], u[6:8], u[8:10], u[10:]), nil
+}
+
+func orDefault(v, def int) int {
+	if v > 0 {
+		return v
+	}
+	return def
+}
@@ -0,0 +1,200 @@
+//nolint:scopelint
+package keystore_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/internal/hexutil"
+	"github.com/iotaledger/iota-crypto-demo/internal/rand"
+	"github.com/iotaledger/iota-crypto-demo/pkg/keystore"
+)
+
+// test vectors from EIP-2335
+const (
+	testPassword = "𝔱𝔢𝔰𝔱𝔭𝔞𝔰𝔰𝔴𝔬𝔯𝔡🔑"
+	testSecret   = "000000000019d6689c085ae165831e934ff763ae46a2a6c172b3f1b60a8ce26f"
+)
+
+var eip2335Tests = []*struct {
+	name     string
+	keystore string
+}{
+	{
+		"scrypt",
+		`{
+			"crypto": {
+				"kdf": {
+					"function": "scrypt",
+					"params": {
+						"dklen": 32,
+						"n": 262144,
+						"p": 1,
+						"r": 8,
+						"salt": "d4e56740f876aef8c010b86a40d5f56745a118d0906a34e69aec8c0db1cb8fa3"
+					},
+					"message": ""
+				},
+				"checksum": {
+					"function": "sha256",
+					"params": {},
+					"message": "d2217fe5f3e9a1e34581ef8a78f7c9928e436d36dacc5e846690a5581e8ea484"
+				},
+				"cipher": {
+					"function": "aes-128-ctr",
+					"params": {
+						"iv": "264daa3f303d7259501c93d997d84fe6"
+					},
+					"message": "06ae90d55fe0a6e9c5c3bc5b170827b2e5cce3929ed3f116c2811e6366dfe20f"
+				}
+			},
+			"description": "This is a test keystore that uses scrypt to secure the secret.",
+			"pubkey": "9612d7a727c9d0a22e185a1c768478dfe919cada9266988cb32359c11f2b7b27f4ae4040902382ae2910c15e2b420d07",
+			"path": "m/12381/60/3141592653/589793238",
+			"uuid": "1d85ae20-35c5-4611-98e8-aa14a633906f",
+			"version": 4
+		}`,
+	},
+	{
+		"pbkdf2",
+		`{
+			"crypto": {
+				"kdf": {
+					"function": "pbkdf2",
+					"params": {
+						"dklen": 32,
+						"c": 262144,
+						"prf": "hmac-sha256",
+						"salt": "d4e56740f876aef8c010b86a40d5f56745a118d0906a34e69aec8c0db1cb8fa3"
+					},
+					"message": ""
+				},
+				"checksum": {
+					"function": "sha256",
+					"params": {},
+					"message": "8a9f5d9912ed7e75ea794bc5a89bca5f193721d30868ade6f73043c6ea6febf1"
+				},
+				"cipher": {
+					"function": "aes-128-ctr",
+					"params": {
+						"iv": "264daa3f303d7259501c93d997d84fe6"
+					},
+					"message": "cee03fde2af33149775b7223e7845e4fb2c8ae1792e5f99fe9ecf474cc8c16ad"
+				}
+			},
+			"description": "This is a test keystore that uses PBKDF2 to secure the secret.",
+			"pubkey": "9612d7a727c9d0a22e185a1c768478dfe919cada9266988cb32359c11f2b7b27f4ae4040902382ae2910c15e2b420d07",
+			"path": "m/12381/60/0/0",
+			"uuid": "64625def-3331-4eea-ab6f-782f3ed16a83",
+			"version": 4
+		}`,
+	},
+}
+
+func TestEIP2335(t *testing.T) {
+	for _, tt := range eip2335Tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ks keystore.Keystore
+			require.NoError(t, json.Unmarshal([]byte(tt.keystore), &ks))
+
+			secret, err := ks.Decrypt(testPassword)
+			require.NoError(t, err)
+			assert.Equal(t, hexutil.MustDecodeString(testSecret), secret)
+
+			_, err = ks.Decrypt("testpassword")
+			assert.ErrorIs(t, err, keystore.ErrInvalidPassword)
+
+			b, err := json.Marshal(&ks)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.keystore, string(b))
+		})
+	}
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	var tests = []*struct {
+		name string
+		opts *keystore.Options
+	}{
+		{"scrypt", &keystore.Options{KDF: keystore.Scrypt, Cost: 1 << 10}},
+		{"pbkdf2", &keystore.Options{KDF: keystore.PBKDF2, Cost: 1 << 10}},
+		{"argon2id", &keystore.Options{KDF: keystore.Argon2id, Cost: 1 << 10}},
+		{"gcm", &keystore.Options{KDF: keystore.Scrypt, Cipher: keystore.AES128GCM, Cost: 1 << 10}},
+		{"metadata", &keystore.Options{Cost: 1 << 10, Description: "test", PublicKey: []byte{1, 2, 3}, Path: "m/44'/4218'/0'/0'/0'"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secret := hexutil.MustDecodeString(testSecret)
+			ks, err := keystore.Encrypt(rand.Reader, secret, testPassword, tt.opts)
+			require.NoError(t, err)
+			assert.Equal(t, keystore.Version, ks.Version)
+			assert.Len(t, ks.UUID, 36)
+			assert.Equal(t, tt.opts.Path, ks.Path)
+
+			b, err := json.Marshal(ks)
+			require.NoError(t, err)
+			var decoded keystore.Keystore
+			require.NoError(t, json.Unmarshal(b, &decoded))
+
+			decrypted, err := decoded.Decrypt(testPassword)
+			require.NoError(t, err)
+			assert.Equal(t, secret, decrypted)
+
+			_, err = decoded.Decrypt("wrong")
+			assert.ErrorIs(t, err, keystore.ErrInvalidPassword)
+		})
+	}
+}
+
+func TestPasswordControlCodes(t *testing.T) {
+	ks, err := keystore.Encrypt(rand.Reader, []byte{1}, "pass\x7fword", &keystore.Options{Cost: 1 << 10})
+	require.NoError(t, err)
+	secret, err := ks.Decrypt("password\n")
+	require.NoError(t, err)
+	assert.Equal(t, []byte{1}, secret)
+}
+
+func TestUnsupported(t *testing.T) {
+	_, err := keystore.Encrypt(rand.Reader, []byte{1}, "", &keystore.Options{KDF: "bcrypt"})
+	assert.ErrorIs(t, err, keystore.ErrUnsupportedFunction)
+	_, err = keystore.Encrypt(rand.Reader, []byte{1}, "", &keystore.Options{Cipher: "aes-256-cbc", Cost: 1 << 10})
+	assert.ErrorIs(t, err, keystore.ErrUnsupportedFunction)
+
+	ks, err := keystore.Encrypt(rand.Reader, []byte{1}, "", &keystore.Options{Cost: 1 << 10})
+	require.NoError(t, err)
+	ks.Version = 3
+	_, err = ks.Decrypt("")
+	assert.ErrorIs(t, err, keystore.ErrUnsupportedVersion)
+}
+
+func TestDecryptExcessiveParams(t *testing.T) {
+	var tests = []*struct {
+		name   string
+		kdf    string
+		params string
+	}{
+		{"scrypt n", keystore.Scrypt, `{"dklen": 32, "n": 33554432, "p": 1, "r": 8}`},
+		{"scrypt r", keystore.Scrypt, `{"dklen": 32, "n": 2, "p": 1, "r": 512}`},
+		{"scrypt p", keystore.Scrypt, `{"dklen": 32, "n": 2, "p": 4611686018427387904, "r": 8}`},
+		{"scrypt n overflow", keystore.Scrypt, `{"dklen": 32, "n": 4611686018427387904, "p": 1, "r": 1}`},
+		{"scrypt dklen", keystore.Scrypt, `{"dklen": 1073741824, "n": 2, "p": 1, "r": 1}`},
+		{"pbkdf2 c", keystore.PBKDF2, `{"dklen": 32, "c": 1073741824, "prf": "hmac-sha256"}`},
+		{"pbkdf2 dklen", keystore.PBKDF2, `{"dklen": 1073741824, "c": 1, "prf": "hmac-sha256"}`},
+		{"argon2id memory", keystore.Argon2id, `{"dklen": 32, "memory": 4294967295, "iterations": 1, "parallelism": 1}`},
+		{"argon2id iterations", keystore.Argon2id, `{"dklen": 32, "memory": 8, "iterations": 4294967295, "parallelism": 1}`},
+		{"argon2id work", keystore.Argon2id, `{"dklen": 32, "memory": 4194304, "iterations": 8, "parallelism": 1}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ks, err := keystore.Encrypt(rand.Reader, []byte{1}, "", &keystore.Options{KDF: tt.kdf, Cost: 1 << 10})
+			require.NoError(t, err)
+			ks.Crypto.KDF.Params = json.RawMessage(tt.params)
+
+			_, err = ks.Decrypt("")
+			assert.ErrorIs(t, err, keystore.ErrInvalidParams)
+		})
+	}
+}
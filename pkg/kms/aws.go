@@ -0,0 +1,147 @@
+package kms
+
+import (
+	"bytes"
+	"crypto"
+	stded25519 "crypto/ed25519"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// AWS KMS key spec and signing algorithm of Ed25519 keys.
+const (
+	awsKeySpecEd25519   = "ECC_NIST_EDWARDS25519"
+	awsSigningAlgorithm = "ED25519_SHA_512"
+)
+
+// AWSConfig configures the access to AWS KMS.
+type AWSConfig struct {
+	// Region is the AWS region of the keys, e.g. "eu-central-1".
+	Region string
+	// AccessKeyID, SecretAccessKey and the optional SessionToken are the credentials used to sign the requests.
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+	// Endpoint overrides the regional endpoint "https://kms.<region>.amazonaws.com".
+	Endpoint string
+	// HTTPClient is the client used for the requests; http.DefaultClient if nil.
+	HTTPClient *http.Client
+}
+
+// AWSSigner is a crypto.Signer for an Ed25519 key held by AWS KMS.
+type AWSSigner struct {
+	cfg    *AWSConfig
+	keyID  string
+	public stded25519.PublicKey
+	now    func() time.Time
+}
+
+// NewAWSSigner returns a signer for the KMS key with the given key ID, key ARN or alias and fetches its public key.
+func NewAWSSigner(cfg *AWSConfig, keyID string) (*AWSSigner, error) {
+	s := &AWSSigner{cfg: cfg, keyID: keyID, now: time.Now}
+	var res struct {
+		KeySpec           string
+		KeyUsage          string
+		PublicKey         []byte
+		SigningAlgorithms []string
+	}
+	if err := s.call("GetPublicKey", map[string]any{"KeyId": keyID}, &res); err != nil {
+		return nil, err
+	}
+	if res.KeySpec != awsKeySpecEd25519 || res.KeyUsage != "SIGN_VERIFY" {
+		return nil, fmt.Errorf("%w: %s key for %s", ErrUnsupportedKey, res.KeySpec, res.KeyUsage)
+	}
+	public, err := parsePublicKey(res.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	s.public = public
+	return s, nil
+}
+
+// Public returns the stded25519.PublicKey of the KMS key.
+func (s *AWSSigner) Public() crypto.PublicKey {
+	return s.public
+}
+
+// Sign signs the message with the KMS key. As for ed25519.PrivateKey, opts.HashFunc() must return crypto.Hash(0).
+// The random source is ignored.
+func (s *AWSSigner) Sign(_ io.Reader, message []byte, opts crypto.SignerOpts) ([]byte, error) {
+	if err := checkOptions(opts); err != nil {
+		return nil, err
+	}
+	var res struct {
+		Signature []byte
+	}
+	if err := s.call("Sign", map[string]any{
+		"KeyId":            s.keyID,
+		"Message":          message,
+		"MessageType":      "RAW",
+		"SigningAlgorithm": awsSigningAlgorithm,
+	}, &res); err != nil {
+		return nil, err
+	}
+	if err := verify(s.public, message, res.Signature); err != nil {
+		return nil, err
+	}
+	return res.Signature, nil
+}
+
+// call performs the action of the AWS JSON 1.1 protocol of KMS.
+func (s *AWSSigner) call(action string, req any, res any) error {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	endpoint := s.cfg.Endpoint
+	if endpoint == "" {
+		endpoint = "https://kms." + s.cfg.Region + ".amazonaws.com"
+	}
+	r, err := http.NewRequest(http.MethodPost, strings.TrimSuffix(endpoint, "/")+"/", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	r.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	r.Header.Set("X-Amz-Target", "TrentService."+action)
+	signer := &sigV4{
+		accessKeyID:     s.cfg.AccessKeyID,
+		secretAccessKey: s.cfg.SecretAccessKey,
+		sessionToken:    s.cfg.SessionToken,
+		region:          s.cfg.Region,
+		service:         "kms",
+	}
+	signer.sign(r, body, s.now())
+
+	resp, err := httpClient(s.cfg.HTTPClient).Do(r)
+	if err != nil {
+		return err
+	}
+	data, err := readBody(resp)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		var e struct {
+			Type    string `json:"__type"`
+			Message string `json:"message"`
+		}
+		_ = json.Unmarshal(data, &e)
+		// the type may be prefixed with the namespace, e.g. "com.amazonaws.kms#NotFoundException"
+		return &APIError{StatusCode: resp.StatusCode, Type: e.Type[strings.LastIndex(e.Type, "#")+1:], Message: e.Message}
+	}
+	if err := json.Unmarshal(data, res); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidResponse, err)
+	}
+	return nil
+}
+
+func httpClient(c *http.Client) *http.Client {
+	if c == nil {
+		return http.DefaultClient
+	}
+	return c
+}
@@ -0,0 +1,134 @@
+package kms
+
+import (
+	"bytes"
+	"crypto"
+	stded25519 "crypto/ed25519"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// gcpAlgorithm is the Cloud KMS algorithm of Ed25519 signing keys.
+const gcpAlgorithm = "EC_SIGN_ED25519"
+
+// GCPConfig configures the access to Google Cloud KMS.
+type GCPConfig struct {
+	// Token returns the OAuth 2.0 access token, e.g. from the metadata server or "gcloud auth print-access-token".
+	Token func() (string, error)
+	// Endpoint overrides the default endpoint "https://cloudkms.googleapis.com".
+	Endpoint string
+	// HTTPClient is the client used for the requests; http.DefaultClient if nil.
+	HTTPClient *http.Client
+}
+
+// GCPSigner is a crypto.Signer for an Ed25519 key version held by Google Cloud KMS.
+type GCPSigner struct {
+	cfg    *GCPConfig
+	name   string
+	public stded25519.PublicKey
+}
+
+// NewGCPSigner returns a signer for the key version with the resource name
+// "projects/<project>/locations/<location>/keyRings/<ring>/cryptoKeys/<key>/cryptoKeyVersions/<version>"
+// and fetches its public key.
+func NewGCPSigner(cfg *GCPConfig, name string) (*GCPSigner, error) {
+	s := &GCPSigner{cfg: cfg, name: name}
+	var res struct {
+		PEM       string `json:"pem"`
+		Algorithm string `json:"algorithm"`
+	}
+	if err := s.call(http.MethodGet, "/publicKey", nil, &res); err != nil {
+		return nil, err
+	}
+	if res.Algorithm != gcpAlgorithm {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedKey, res.Algorithm)
+	}
+	block, _ := pem.Decode([]byte(res.PEM))
+	if block == nil || block.Type != "PUBLIC KEY" {
+		return nil, fmt.Errorf("%w: missing PEM public key", ErrInvalidResponse)
+	}
+	public, err := parsePublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	s.public = public
+	return s, nil
+}
+
+// Public returns the stded25519.PublicKey of the key version.
+func (s *GCPSigner) Public() crypto.PublicKey {
+	return s.public
+}
+
+// Sign signs the message with the key version. As for ed25519.PrivateKey, opts.HashFunc() must return
+// crypto.Hash(0). The random source is ignored.
+func (s *GCPSigner) Sign(_ io.Reader, message []byte, opts crypto.SignerOpts) ([]byte, error) {
+	if err := checkOptions(opts); err != nil {
+		return nil, err
+	}
+	var res struct {
+		Signature []byte `json:"signature"`
+	}
+	if err := s.call(http.MethodPost, ":asymmetricSign", map[string]any{"data": message}, &res); err != nil {
+		return nil, err
+	}
+	if err := verify(s.public, message, res.Signature); err != nil {
+		return nil, err
+	}
+	return res.Signature, nil
+}
+
+// call performs the REST method on the key version, where suffix is appended to its resource name.
+func (s *GCPSigner) call(method string, suffix string, req any, res any) error {
+	var body io.Reader
+	if req != nil {
+		data, err := json.Marshal(req)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+	endpoint := s.cfg.Endpoint
+	if endpoint == "" {
+		endpoint = "https://cloudkms.googleapis.com"
+	}
+	r, err := http.NewRequest(method, strings.TrimSuffix(endpoint, "/")+"/v1/"+s.name+suffix, body)
+	if err != nil {
+		return err
+	}
+	token, err := s.cfg.Token()
+	if err != nil {
+		return fmt.Errorf("failed obtaining access token: %w", err)
+	}
+	r.Header.Set("Authorization", "Bearer "+token)
+	if req != nil {
+		r.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := httpClient(s.cfg.HTTPClient).Do(r)
+	if err != nil {
+		return err
+	}
+	data, err := readBody(resp)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		var e struct {
+			Error struct {
+				Status  string `json:"status"`
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		_ = json.Unmarshal(data, &e)
+		return &APIError{StatusCode: resp.StatusCode, Type: e.Error.Status, Message: e.Error.Message}
+	}
+	if err := json.Unmarshal(data, res); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidResponse, err)
+	}
+	return nil
+}
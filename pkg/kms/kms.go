@@ -0,0 +1,107 @@
+/*
+Package kms implements crypto.Signer adapters for Ed25519 keys held by cloud key management services, i.e. AWS KMS and
+Google Cloud KMS, so that hybrid setups can combine KMS-held keys with the address layer of this repository.
+
+The adapters talk to the JSON APIs of the services directly using net/http: requests to AWS are authenticated with
+Signature Version 4, requests to Google Cloud with an OAuth 2.0 access token. The public key is fetched once when
+creating a signer, so that Address can be computed without further requests.
+*/
+package kms
+
+import (
+	"crypto"
+	stded25519 "crypto/ed25519"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/bech32/address"
+	"github.com/iotaledger/iota-crypto-demo/pkg/ed25519"
+)
+
+// maxResponseSize is the maximum size, in bytes, of a response body.
+const maxResponseSize = 1 << 20
+
+var (
+	// ErrUnsupportedKey is returned when the KMS key is not an Ed25519 signing key.
+	ErrUnsupportedKey = errors.New("unsupported key")
+	// ErrInvalidResponse is returned when the service returns a malformed public key or signature.
+	ErrInvalidResponse = errors.New("invalid response")
+	// ErrUnsupportedOptions is returned when signing with a pre-hashed digest is requested.
+	ErrUnsupportedOptions = errors.New("unsupported signer options")
+)
+
+// APIError is returned when the service responds with an error.
+type APIError struct {
+	StatusCode int
+	Type       string
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("kms: %s (%d): %s", e.Type, e.StatusCode, e.Message)
+}
+
+// Address returns the Bech32 encoded Ed25519 address of the public key of signer on the network with prefix.
+func Address(signer crypto.Signer, prefix address.Prefix) (string, error) {
+	public, ok := signer.Public().(stded25519.PublicKey)
+	if !ok {
+		return "", fmt.Errorf("%w: %T", ErrUnsupportedKey, signer.Public())
+	}
+	return address.Bech32(prefix, address.AddressFromPublicKey(ed25519.PublicKey(public)))
+}
+
+// parsePublicKey parses a DER encoded SubjectPublicKeyInfo containing an Ed25519 key.
+func parsePublicKey(der []byte) (stded25519.PublicKey, error) {
+	key, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidResponse, err)
+	}
+	public, ok := key.(stded25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T", ErrUnsupportedKey, key)
+	}
+	return public, nil
+}
+
+// checkOptions ensures that the message is signed as is, which is the only mode of Ed25519 supported by the services.
+// Only nil, crypto.Hash(0) and the Ed25519 options of both packages without a context are accepted, as unknown
+// options could select a variant that the services would silently ignore.
+func checkOptions(opts crypto.SignerOpts) error {
+	var context string
+	switch o := opts.(type) {
+	case nil:
+		return nil
+	case crypto.Hash:
+	case *stded25519.Options:
+		context = o.Context
+	case *ed25519.Options:
+		context = o.Context
+	default:
+		return fmt.Errorf("%w: %T", ErrUnsupportedOptions, opts)
+	}
+	if opts.HashFunc() != crypto.Hash(0) {
+		return fmt.Errorf("%w: Ed25519 requires crypto.Hash(0)", ErrUnsupportedOptions)
+	}
+	if context != "" {
+		return fmt.Errorf("%w: Ed25519 contexts are not supported", ErrUnsupportedOptions)
+	}
+	return nil
+}
+
+// verify checks the signature returned by the service against the public key, so that a misconfigured key is
+// detected before the signature is used.
+func verify(public stded25519.PublicKey, message, sig []byte) error {
+	if len(sig) != stded25519.SignatureSize || !stded25519.Verify(public, message, sig) {
+		return fmt.Errorf("%w: invalid signature", ErrInvalidResponse)
+	}
+	return nil
+}
+
+// readBody reads the body of resp up to maxResponseSize.
+func readBody(resp *http.Response) ([]byte, error) {
+	defer resp.Body.Close()
+	return io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+}
@@ -0,0 +1,185 @@
+package kms_test
+
+import (
+	"crypto"
+	stded25519 "crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/bech32/address"
+	"github.com/iotaledger/iota-crypto-demo/pkg/ed25519"
+	"github.com/iotaledger/iota-crypto-demo/pkg/kms"
+)
+
+var testKey = stded25519.NewKeyFromSeed(make([]byte, stded25519.SeedSize))
+
+func spki(t *testing.T) []byte {
+	der, err := x509.MarshalPKIXPublicKey(testKey.Public())
+	require.NoError(t, err)
+	return der
+}
+
+func expectedAddress(t *testing.T) string {
+	addr, err := address.Bech32(address.IOTAMainnet, address.AddressFromPublicKey(ed25519.PublicKey(testKey.Public().(stded25519.PublicKey))))
+	require.NoError(t, err)
+	return addr
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// awsServer simulates the GetPublicKey and Sign actions of AWS KMS for testKey.
+func awsServer(t *testing.T, signature func(message []byte) []byte) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKID/"))
+		assert.Contains(t, r.Header.Get("Authorization"), "/eu-central-1/kms/aws4_request")
+		assert.Equal(t, "application/x-amz-json-1.1", r.Header.Get("Content-Type"))
+
+		var req struct {
+			KeyId            string //nolint:revive,stylecheck // AWS field name
+			Message          []byte
+			SigningAlgorithm string
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		if req.KeyId != "alias/test" {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"__type": "com.amazonaws.kms#NotFoundException", "message": "key not found"})
+			return
+		}
+		switch r.Header.Get("X-Amz-Target") {
+		case "TrentService.GetPublicKey":
+			writeJSON(w, http.StatusOK, map[string]any{"KeySpec": "ECC_NIST_EDWARDS25519", "KeyUsage": "SIGN_VERIFY", "PublicKey": spki(t)})
+		case "TrentService.Sign":
+			assert.Equal(t, "ED25519_SHA_512", req.SigningAlgorithm)
+			writeJSON(w, http.StatusOK, map[string]any{"Signature": signature(req.Message)})
+		default:
+			writeJSON(w, http.StatusBadRequest, map[string]string{"__type": "UnknownOperationException"})
+		}
+	}))
+}
+
+func TestAWSSigner(t *testing.T) {
+	srv := awsServer(t, func(message []byte) []byte { return stded25519.Sign(testKey, message) })
+	defer srv.Close()
+	cfg := &kms.AWSConfig{Region: "eu-central-1", AccessKeyID: "AKID", SecretAccessKey: "secret", Endpoint: srv.URL}
+
+	var signer crypto.Signer
+	signer, err := kms.NewAWSSigner(cfg, "alias/test")
+	require.NoError(t, err)
+	assert.Equal(t, testKey.Public(), signer.Public())
+
+	addr, err := kms.Address(signer, address.IOTAMainnet)
+	require.NoError(t, err)
+	assert.Equal(t, expectedAddress(t), addr)
+
+	sig, err := signer.Sign(nil, []byte("message"), crypto.Hash(0))
+	require.NoError(t, err)
+	assert.True(t, stded25519.Verify(testKey.Public().(stded25519.PublicKey), []byte("message"), sig))
+
+	digest := sha256.Sum256([]byte("message"))
+	_, err = signer.Sign(nil, digest[:], crypto.SHA256)
+	assert.ErrorIs(t, err, kms.ErrUnsupportedOptions)
+
+	_, err = kms.NewAWSSigner(cfg, "alias/other")
+	var apiErr *kms.APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "NotFoundException", apiErr.Type)
+}
+
+func TestSignOptions(t *testing.T) {
+	srv := awsServer(t, func(message []byte) []byte { return stded25519.Sign(testKey, message) })
+	defer srv.Close()
+	signer, err := kms.NewAWSSigner(&kms.AWSConfig{Region: "eu-central-1", AccessKeyID: "AKID", Endpoint: srv.URL}, "alias/test")
+	require.NoError(t, err)
+
+	var tests = []*struct {
+		name  string
+		opts  crypto.SignerOpts
+		valid bool
+	}{
+		{"nil", nil, true},
+		{"hash", crypto.Hash(0), true},
+		{"sha512", crypto.SHA512, false},
+		{"crypto/ed25519", &stded25519.Options{}, true},
+		{"crypto/ed25519 ph", &stded25519.Options{Hash: crypto.SHA512}, false},
+		{"crypto/ed25519 ctx", &stded25519.Options{Context: "context"}, false},
+		{"ed25519", &ed25519.Options{}, true},
+		{"ed25519 ph", &ed25519.Options{Hash: crypto.SHA512}, false},
+		{"ed25519 ctx", &ed25519.Options{Context: "context"}, false},
+		{"unknown", &rsa.PSSOptions{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sig, err := signer.Sign(nil, []byte("message"), tt.opts)
+			if !tt.valid {
+				assert.ErrorIs(t, err, kms.ErrUnsupportedOptions)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, stded25519.Sign(testKey, []byte("message")), sig)
+		})
+	}
+}
+
+func TestAWSSignerInvalidSignature(t *testing.T) {
+	srv := awsServer(t, func([]byte) []byte { return make([]byte, stded25519.SignatureSize) })
+	defer srv.Close()
+
+	signer, err := kms.NewAWSSigner(&kms.AWSConfig{Region: "eu-central-1", AccessKeyID: "AKID", Endpoint: srv.URL}, "alias/test")
+	require.NoError(t, err)
+	_, err = signer.Sign(nil, []byte("message"), crypto.Hash(0))
+	assert.ErrorIs(t, err, kms.ErrInvalidResponse)
+}
+
+func TestGCPSigner(t *testing.T) {
+	const name = "projects/p/locations/global/keyRings/r/cryptoKeys/k/cryptoKeyVersions/1"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			writeJSON(w, http.StatusUnauthorized, map[string]any{"error": map[string]string{"status": "UNAUTHENTICATED", "message": "invalid token"}})
+			return
+		}
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/"+name+"/publicKey":
+			pemKey := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: spki(t)})
+			writeJSON(w, http.StatusOK, map[string]string{"pem": string(pemKey), "algorithm": "EC_SIGN_ED25519"})
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/"+name+":asymmetricSign":
+			var req struct {
+				Data []byte `json:"data"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			writeJSON(w, http.StatusOK, map[string]any{"signature": stded25519.Sign(testKey, req.Data)})
+		default:
+			writeJSON(w, http.StatusNotFound, map[string]any{"error": map[string]string{"status": "NOT_FOUND"}})
+		}
+	}))
+	defer srv.Close()
+
+	cfg := &kms.GCPConfig{Token: func() (string, error) { return "token", nil }, Endpoint: srv.URL}
+	signer, err := kms.NewGCPSigner(cfg, name)
+	require.NoError(t, err)
+
+	addr, err := kms.Address(signer, address.IOTAMainnet)
+	require.NoError(t, err)
+	assert.Equal(t, expectedAddress(t), addr)
+
+	sig, err := signer.Sign(nil, []byte("message"), crypto.Hash(0))
+	require.NoError(t, err)
+	assert.Equal(t, stded25519.Sign(testKey, []byte("message")), sig)
+
+	_, err = kms.NewGCPSigner(&kms.GCPConfig{Token: func() (string, error) { return "wrong", nil }, Endpoint: srv.URL}, name)
+	var apiErr *kms.APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusUnauthorized, apiErr.StatusCode)
+	assert.Equal(t, "UNAUTHENTICATED", apiErr.Type)
+}
@@ -0,0 +1,79 @@
+/*
+Package ledger implements the communication with Ledger hardware wallets and the IOTA Ledger app.
+
+Commands are exchanged as ISO 7816-4 APDUs over a Transport. HIDTransport implements the framing of the Ledger HID
+protocol on top of any 64-byte report based device, and OpenHID opens the first connected Ledger device on platforms
+with raw HID support.
+
+The App client covers the APDUs of the IOTA app required to audit software derived addresses, i.e. reading the app
+configuration, selecting the account and generating addresses with optional confirmation on the device.
+*/
+package ledger
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// StatusOK is the status word of a successful command.
+const StatusOK = 0x9000
+
+var (
+	// ErrInvalidResponse is returned when the device sends a malformed response.
+	ErrInvalidResponse = errors.New("invalid response")
+	// ErrUnsupported is returned when raw HID access is not supported on this platform.
+	ErrUnsupported = errors.New("HID not supported on this platform")
+	// ErrNoDevice is returned when no Ledger device is connected.
+	ErrNoDevice = errors.New("no Ledger device found")
+)
+
+// StatusError is returned when the device responds with a status word other than StatusOK.
+type StatusError uint16
+
+func (e StatusError) Error() string {
+	switch e {
+	case 0x6985:
+		return "ledger: denied by the user"
+	case 0x6D00:
+		return "ledger: instruction not supported"
+	case 0x6E00:
+		return "ledger: app not open"
+	}
+	return fmt.Sprintf("ledger: status word %04x", uint16(e))
+}
+
+// Transport exchanges raw APDUs with a device.
+type Transport interface {
+	// Exchange sends the command APDU and returns the response APDU including the status word.
+	Exchange(apdu []byte) ([]byte, error)
+}
+
+// command is an APDU sent to the device.
+type command struct {
+	cla, ins, p1, p2 byte
+	data             []byte
+}
+
+func (c *command) bytes() []byte {
+	if len(c.data) > 255 {
+		panic("ledger: command data too long")
+	}
+	return append([]byte{c.cla, c.ins, c.p1, c.p2, byte(len(c.data))}, c.data...)
+}
+
+// exchange sends the command over t and returns the response data, if the status word signals success.
+func exchange(t Transport, c *command) ([]byte, error) {
+	resp, err := t.Exchange(c.bytes())
+	if err != nil {
+		return nil, err
+	}
+	if len(resp) < 2 {
+		return nil, fmt.Errorf("%w: missing status word", ErrInvalidResponse)
+	}
+	data, sw := resp[:len(resp)-2], binary.BigEndian.Uint16(resp[len(resp)-2:])
+	if sw != StatusOK {
+		return nil, StatusError(sw)
+	}
+	return data, nil
+}
@@ -0,0 +1,77 @@
+package ledger
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// HID framing constants of the Ledger protocol
+const (
+	hidReportSize = 64
+	hidChannel    = 0x0101
+	hidTagAPDU    = 0x05
+	hidHeaderSize = 5 // channel, tag and sequence index
+)
+
+// HIDTransport implements Transport over a device exchanging 64-byte HID reports.
+type HIDTransport struct {
+	rw io.ReadWriter
+}
+
+// NewHIDTransport returns a Transport framing the APDUs into HID reports which are written to and read from rw.
+// Each Write and Read must correspond to a single report.
+func NewHIDTransport(rw io.ReadWriter) *HIDTransport {
+	return &HIDTransport{rw}
+}
+
+// Exchange implements Transport.
+func (t *HIDTransport) Exchange(apdu []byte) ([]byte, error) {
+	for _, report := range wrapHID(apdu) {
+		if _, err := t.rw.Write(report); err != nil {
+			return nil, err
+		}
+	}
+
+	var resp []byte
+	length := -1
+	report := make([]byte, hidReportSize)
+	for seq := 0; length < 0 || len(resp) < length; seq++ {
+		n, err := t.rw.Read(report)
+		if err != nil {
+			return nil, err
+		}
+		if n < hidHeaderSize || binary.BigEndian.Uint16(report) != hidChannel || report[2] != hidTagAPDU ||
+			int(binary.BigEndian.Uint16(report[3:])) != seq {
+			return nil, fmt.Errorf("%w: invalid HID frame", ErrInvalidResponse)
+		}
+		data := report[hidHeaderSize:n]
+		if seq == 0 {
+			if len(data) < 2 {
+				return nil, fmt.Errorf("%w: missing length", ErrInvalidResponse)
+			}
+			length = int(binary.BigEndian.Uint16(data))
+			data = data[2:]
+		}
+		resp = append(resp, data...)
+	}
+	return resp[:length], nil
+}
+
+// wrapHID splits the APDU into zero-padded HID reports. The first report contains the total length of the APDU.
+func wrapHID(apdu []byte) [][]byte {
+	data := binary.BigEndian.AppendUint16(nil, uint16(len(apdu)))
+	data = append(data, apdu...)
+
+	var reports [][]byte
+	for seq := 0; len(data) > 0 || seq == 0; seq++ {
+		report := make([]byte, hidReportSize)
+		binary.BigEndian.PutUint16(report, hidChannel)
+		report[2] = hidTagAPDU
+		binary.BigEndian.PutUint16(report[3:], uint16(seq))
+		n := copy(report[hidHeaderSize:], data)
+		data = data[n:]
+		reports = append(reports, report)
+	}
+	return reports
+}
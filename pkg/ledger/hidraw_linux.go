@@ -0,0 +1,62 @@
+package ledger
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ledgerVendorID is the USB vendor ID of Ledger devices.
+const ledgerVendorID = "00002C97"
+
+// hidraw is a Linux raw HID device. Writes are prefixed with the report ID 0, as the Ledger does not use numbered reports.
+type hidraw struct {
+	*os.File
+}
+
+func (h hidraw) Write(report []byte) (int, error) {
+	n, err := h.File.Write(append([]byte{0}, report...))
+	return max(n-1, 0), err
+}
+
+// OpenHID opens the APDU interface of the first connected Ledger device.
+// The returned closer releases the device.
+func OpenHID() (*HIDTransport, func() error, error) {
+	devices, _ := filepath.Glob("/sys/class/hidraw/hidraw*")
+	for _, dev := range devices {
+		if !isLedgerAPDU(filepath.Join(dev, "device", "uevent")) {
+			continue
+		}
+		f, err := os.OpenFile(filepath.Join("/dev", filepath.Base(dev)), os.O_RDWR, 0)
+		if err != nil {
+			return nil, nil, err
+		}
+		return NewHIDTransport(hidraw{f}), f.Close, nil
+	}
+	return nil, nil, ErrNoDevice
+}
+
+// isLedgerAPDU reports whether the uevent file describes interface 0 of a Ledger device, which is used for APDUs.
+func isLedgerAPDU(uevent string) bool {
+	f, err := os.Open(uevent)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	var vendor, apdu bool
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		key, value, _ := strings.Cut(s.Text(), "=")
+		switch key {
+		case "HID_ID":
+			// bus:vendor:product
+			parts := strings.Split(value, ":")
+			vendor = len(parts) == 3 && strings.EqualFold(parts[1], ledgerVendorID)
+		case "HID_PHYS":
+			apdu = strings.HasSuffix(value, "input0")
+		}
+	}
+	return vendor && apdu
+}
@@ -0,0 +1,9 @@
+//go:build !linux
+
+package ledger
+
+// OpenHID opens the APDU interface of the first connected Ledger device.
+// Raw HID access is only supported on Linux.
+func OpenHID() (*HIDTransport, func() error, error) {
+	return nil, nil, ErrUnsupported
+}
@@ -0,0 +1,153 @@
+package ledger
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// APDU class and instructions of the IOTA app
+const (
+	iotaCLA = 0x7B
+
+	insGetAppConfig       = 0x10
+	insSetAccount         = 0x11
+	insGetDataBufferState = 0x80
+	insReadDataBlock      = 0x82
+	insGenerateAddress    = 0xA1
+	insReset              = 0xFF
+)
+
+// AddressSize is the size, in bytes, of a serialized Ed25519 address returned by the device.
+const AddressSize = 33
+
+const hardened = 1 << 31
+
+// Mode selects the network and coin type of the IOTA app.
+type Mode byte
+
+// Supported app modes.
+const (
+	// IOTAMainnet derives the keys of coin type 4218.
+	IOTAMainnet Mode = 0x00
+	// IOTATestnet derives the keys of coin type 1.
+	IOTATestnet Mode = 0x80
+)
+
+// AppConfig describes the IOTA app running on the device.
+type AppConfig struct {
+	// Version is the major, minor and patch version of the app.
+	Version [3]byte
+	// Flags contains the app flags, e.g. whether the device is locked.
+	Flags byte
+	// Device identifies the Ledger model.
+	Device byte
+	// Debug reports whether the app is a debug build.
+	Debug bool
+}
+
+// App communicates with the IOTA app on a Ledger device.
+type App struct {
+	t Transport
+}
+
+// NewApp returns a client of the IOTA app reachable via t.
+func NewApp(t Transport) *App {
+	return &App{t}
+}
+
+// Config returns the configuration of the running app.
+func (a *App) Config() (*AppConfig, error) {
+	data, err := exchange(a.t, &command{cla: iotaCLA, ins: insGetAppConfig})
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < 6 {
+		return nil, fmt.Errorf("%w: app config too short", ErrInvalidResponse)
+	}
+	return &AppConfig{
+		Version: [3]byte{data[0], data[1], data[2]},
+		Flags:   data[3],
+		Device:  data[4],
+		Debug:   data[5] != 0,
+	}, nil
+}
+
+// SetAccount selects the app mode and the hardened account index used for all following commands.
+func (a *App) SetAccount(mode Mode, account uint32) error {
+	_, err := exchange(a.t, &command{
+		cla:  iotaCLA,
+		ins:  insSetAccount,
+		p1:   byte(mode),
+		data: binary.LittleEndian.AppendUint32(nil, account|hardened),
+	})
+	return err
+}
+
+// Addresses generates count addresses starting at the hardened index of the path m/44'/coin'/account'/change'/index'
+// and returns them in their serialized form, i.e. the version byte followed by the public key hash.
+// If show is set, the device displays the first address and waits for the confirmation by the user.
+func (a *App) Addresses(change bool, index uint32, count uint32, show bool) ([][]byte, error) {
+	data := binary.LittleEndian.AppendUint32(nil, index|hardened)
+	changeIndex := uint32(hardened)
+	if change {
+		changeIndex |= 1
+	}
+	data = binary.LittleEndian.AppendUint32(data, changeIndex)
+	data = binary.LittleEndian.AppendUint32(data, count)
+
+	var p1 byte
+	if show {
+		p1 = 0x01
+	}
+	if _, err := exchange(a.t, &command{cla: iotaCLA, ins: insGenerateAddress, p1: p1, data: data}); err != nil {
+		return nil, err
+	}
+
+	buf, err := a.readDataBuffer()
+	if err != nil {
+		return nil, err
+	}
+	if len(buf) != int(count)*AddressSize {
+		return nil, fmt.Errorf("%w: unexpected address data length %d", ErrInvalidResponse, len(buf))
+	}
+	addrs := make([][]byte, count)
+	for i := range addrs {
+		addrs[i] = buf[i*AddressSize : (i+1)*AddressSize]
+	}
+	return addrs, nil
+}
+
+// Reset resets the state of the app, e.g. the selected account and the data buffer.
+func (a *App) Reset() error {
+	_, err := exchange(a.t, &command{cla: iotaCLA, ins: insReset})
+	return err
+}
+
+// readDataBuffer reads the content of the data buffer of the app block by block.
+func (a *App) readDataBuffer() ([]byte, error) {
+	state, err := exchange(a.t, &command{cla: iotaCLA, ins: insGetDataBufferState})
+	if err != nil {
+		return nil, err
+	}
+	if len(state) < 5 {
+		return nil, fmt.Errorf("%w: data buffer state too short", ErrInvalidResponse)
+	}
+	length := int(binary.LittleEndian.Uint16(state))
+	blockSize, blockCount := int(state[3]), int(state[4])
+	if length > blockSize*blockCount {
+		return nil, fmt.Errorf("%w: invalid data buffer state", ErrInvalidResponse)
+	}
+
+	var buf []byte
+	for i := 0; len(buf) < length; i++ {
+		block, err := exchange(a.t, &command{cla: iotaCLA, ins: insReadDataBlock, p1: byte(i)})
+		if err != nil {
+			return nil, err
+		}
+		if len(block) != blockSize {
+			return nil, fmt.Errorf("%w: unexpected block size %d", ErrInvalidResponse, len(block))
+		}
+		buf = append(buf, block...)
+	}
+	return buf[:length], nil
+}
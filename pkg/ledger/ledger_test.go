@@ -0,0 +1,148 @@
+package ledger_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/bech32/address"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip32path"
+	"github.com/iotaledger/iota-crypto-demo/pkg/ledger"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10/eddsa"
+)
+
+// hidDevice simulates a HID device by answering each complete command with the response produced by handle.
+type hidDevice struct {
+	handle  func(apdu []byte) []byte
+	request []byte
+	reports [][]byte
+}
+
+func (d *hidDevice) Write(report []byte) (int, error) {
+	if len(report) != 64 || binary.BigEndian.Uint16(report) != 0x0101 || report[2] != 0x05 {
+		panic("invalid report")
+	}
+	data := report[5:]
+	if binary.BigEndian.Uint16(report[3:]) == 0 {
+		d.request = make([]byte, 0, binary.BigEndian.Uint16(data))
+		data = data[2:]
+	}
+	d.request = append(d.request, data[:min(len(data), cap(d.request)-len(d.request))]...)
+	if len(d.request) == cap(d.request) {
+		// answer with the same framing
+		resp := d.handle(d.request)
+		data := binary.BigEndian.AppendUint16(nil, uint16(len(resp)))
+		data = append(data, resp...)
+		for seq := 0; len(data) > 0; seq++ {
+			r := make([]byte, 64)
+			binary.BigEndian.PutUint16(r, 0x0101)
+			r[2] = 0x05
+			binary.BigEndian.PutUint16(r[3:], uint16(seq))
+			data = data[copy(r[5:], data):]
+			d.reports = append(d.reports, r)
+		}
+	}
+	return len(report), nil
+}
+
+func (d *hidDevice) Read(report []byte) (int, error) {
+	n := copy(report, d.reports[0])
+	d.reports = d.reports[1:]
+	return n, nil
+}
+
+func TestHIDTransport(t *testing.T) {
+	for _, size := range []int{0, 1, 57, 58, 59, 200} {
+		apdu := bytes.Repeat([]byte{0xAB}, size)
+		dev := &hidDevice{handle: func(req []byte) []byte {
+			// echo the request
+			return append([]byte{}, req...)
+		}}
+		resp, err := ledger.NewHIDTransport(dev).Exchange(apdu)
+		require.NoError(t, err)
+		assert.Equal(t, apdu, resp)
+	}
+}
+
+// iotaApp simulates the IOTA app for the given seed.
+type iotaApp struct {
+	seed    []byte
+	account uint32
+	buffer  []byte
+	shown   bool
+}
+
+const blockSize = 128
+
+func (a *iotaApp) Exchange(apdu []byte) ([]byte, error) {
+	if apdu[0] != 0x7B || int(apdu[4]) != len(apdu)-5 {
+		return []byte{0x6E, 0x00}, nil
+	}
+	data := apdu[5:]
+	ok := []byte{0x90, 0x00}
+	switch apdu[1] {
+	case 0x10:
+		return append([]byte{1, 2, 3, 0, 0x31, 0}, ok...), nil
+	case 0x11:
+		a.account = binary.LittleEndian.Uint32(data)
+		return ok, nil
+	case 0xA1:
+		index := binary.LittleEndian.Uint32(data)
+		change := binary.LittleEndian.Uint32(data[4:])
+		count := binary.LittleEndian.Uint32(data[8:])
+		a.shown = apdu[2] == 0x01
+		a.buffer = nil
+		for i := uint32(0); i < count; i++ {
+			path := bip32path.Path{44 | 1<<31, 4218 | 1<<31, a.account, change, index + i}
+			key, err := slip10.DeriveKeyFromPath(a.seed, eddsa.Ed25519(), path)
+			if err != nil {
+				panic(err)
+			}
+			pub, _ := key.Key.(eddsa.Seed).Ed25519Key() //nolint:forcetypeassert
+			a.buffer = append(a.buffer, address.AddressFromPublicKey(pub).Bytes()...)
+		}
+		return ok, nil
+	case 0x80:
+		state := binary.LittleEndian.AppendUint16(nil, uint16(len(a.buffer)))
+		return append(append(state, 2, blockSize, 4), ok...), nil
+	case 0x82:
+		block := make([]byte, blockSize)
+		copy(block, a.buffer[min(int(apdu[2])*blockSize, len(a.buffer)):])
+		return append(block, ok...), nil
+	default:
+		return []byte{0x6D, 0x00}, nil
+	}
+}
+
+func TestApp(t *testing.T) {
+	seed := bytes.Repeat([]byte{0x42}, 64)
+	dev := &iotaApp{seed: seed}
+	app := ledger.NewApp(dev)
+
+	config, err := app.Config()
+	require.NoError(t, err)
+	assert.Equal(t, [3]byte{1, 2, 3}, config.Version)
+
+	require.NoError(t, app.SetAccount(ledger.IOTAMainnet, 1))
+	addrs, err := app.Addresses(false, 5, 6, true)
+	require.NoError(t, err)
+	require.Len(t, addrs, 6)
+	assert.True(t, dev.shown)
+
+	for i, addr := range addrs {
+		path, err := bip32path.ParsePath("44'/4218'/1'/0'")
+		require.NoError(t, err)
+		path = append(path, uint32(5+i)|1<<31)
+		key, err := slip10.DeriveKeyFromPath(seed, eddsa.Ed25519(), path)
+		require.NoError(t, err)
+		pub, _ := key.Key.(eddsa.Seed).Ed25519Key() //nolint:forcetypeassert
+		assert.Equal(t, address.AddressFromPublicKey(pub).Bytes(), addr)
+	}
+
+	err = app.Reset()
+	assert.ErrorIs(t, err, ledger.StatusError(0x6D00))
+}
@@ -0,0 +1,260 @@
+package legacy
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/trinary"
+)
+
+// sizes, in trytes, of the fields of a legacy transaction
+const (
+	SignatureMessageFragmentTrytesSize = KeyFragmentSize / trinary.TritsPerTryte
+	ValueTrytesSize                    = 27
+	TagTrytesSize                      = 27
+	TimestampTrytesSize                = 9
+	IndexTrytesSize                    = 9
+	NonceTrytesSize                    = 27
+	TransactionTrytesSize              = SignatureMessageFragmentTrytesSize + 4*HashTrytesSize + 2*TagTrytesSize +
+		ValueTrytesSize + 3*IndexTrytesSize + 3*TimestampTrytesSize + NonceTrytesSize
+)
+
+// ErrInvalidBundle is returned when a bundle does not contain the transactions of an input.
+var ErrInvalidBundle = errors.New("invalid bundle")
+
+// Transaction is a legacy value transaction.
+type Transaction struct {
+	SignatureMessageFragment string
+	Address                  string
+	Value                    int64
+	ObsoleteTag              string
+	Timestamp                uint64
+	CurrentIndex             uint64
+	LastIndex                uint64
+	Bundle                   string
+	TrunkTransaction         string
+	BranchTransaction        string
+	Tag                      string
+	AttachmentTimestamp      uint64
+	AttachmentTimestampLower uint64
+	AttachmentTimestampUpper uint64
+	Nonce                    string
+}
+
+// NewTransaction returns a transaction of address with the given value, where all other fields are empty.
+func NewTransaction(address string, value int64, timestamp uint64) *Transaction {
+	return &Transaction{
+		SignatureMessageFragment: strings.Repeat("9", SignatureMessageFragmentTrytesSize),
+		Address:                  address,
+		Value:                    value,
+		ObsoleteTag:              strings.Repeat("9", TagTrytesSize),
+		Timestamp:                timestamp,
+		Bundle:                   strings.Repeat("9", HashTrytesSize),
+		TrunkTransaction:         strings.Repeat("9", HashTrytesSize),
+		BranchTransaction:        strings.Repeat("9", HashTrytesSize),
+		Tag:                      strings.Repeat("9", TagTrytesSize),
+		Nonce:                    strings.Repeat("9", NonceTrytesSize),
+	}
+}
+
+// Trytes returns the serialized transaction.
+func (t *Transaction) Trytes() string {
+	var b strings.Builder
+	b.Grow(TransactionTrytesSize)
+	b.WriteString(t.SignatureMessageFragment)
+	b.WriteString(t.Address)
+	b.WriteString(intToTrytes(t.Value, ValueTrytesSize))
+	b.WriteString(t.ObsoleteTag)
+	b.WriteString(uintToTrytes(t.Timestamp, TimestampTrytesSize))
+	b.WriteString(uintToTrytes(t.CurrentIndex, IndexTrytesSize))
+	b.WriteString(uintToTrytes(t.LastIndex, IndexTrytesSize))
+	b.WriteString(t.Bundle)
+	b.WriteString(t.TrunkTransaction)
+	b.WriteString(t.BranchTransaction)
+	b.WriteString(t.Tag)
+	b.WriteString(uintToTrytes(t.AttachmentTimestamp, TimestampTrytesSize))
+	b.WriteString(uintToTrytes(t.AttachmentTimestampLower, TimestampTrytesSize))
+	b.WriteString(uintToTrytes(t.AttachmentTimestampUpper, TimestampTrytesSize))
+	b.WriteString(t.Nonce)
+	return b.String()
+}
+
+// essence returns the trits of the transaction fields contributing to the bundle hash.
+func (t *Transaction) essence() trinary.Trits {
+	essence := t.Address +
+		intToTrytes(t.Value, ValueTrytesSize) +
+		t.ObsoleteTag +
+		uintToTrytes(t.Timestamp, TimestampTrytesSize) +
+		uintToTrytes(t.CurrentIndex, IndexTrytesSize) +
+		uintToTrytes(t.LastIndex, IndexTrytesSize)
+	return trinary.MustTrytesToTrits(essence)
+}
+
+// Bundle is an ordered list of transactions.
+type Bundle []*Transaction
+
+// Finalize sets the indices and the bundle hash of all transactions.
+// The obsolete tag of the first transaction is incremented until the normalized bundle hash does not contain the
+// maximal tryte value, as such a hash would reveal the last private key chunk of a W-OTS fragment.
+func (b Bundle) Finalize() error {
+	if len(b) == 0 {
+		return fmt.Errorf("%w: empty bundle", ErrInvalidLength)
+	}
+	for i, t := range b {
+		t.CurrentIndex = uint64(i)
+		t.LastIndex = uint64(len(b) - 1)
+	}
+	for {
+		hash, err := b.hash()
+		if err != nil {
+			return err
+		}
+		if !containsMaxTryte(NormalizeHash(hash)) {
+			for _, t := range b {
+				t.Bundle = hash
+			}
+			return nil
+		}
+		tag, err := trinary.TrytesToTrits(b[0].ObsoleteTag)
+		if err != nil {
+			return err
+		}
+		b[0].ObsoleteTag = trinary.MustTritsToTrytes(trinary.AddTrits(tag, trinary.Trits{1}))
+	}
+}
+
+// SignInput signs the finalized bundle with the W-OTS key of the input address. The signature fragments are stored
+// in the input transaction and the following zero-value transactions of the same address, one per security level.
+func (b Bundle) SignInput(address string, key trinary.Trits) error {
+	for i, t := range b {
+		if t.Address != address || t.Value >= 0 {
+			continue
+		}
+		fragments, err := SignatureFragments(key, t.Bundle)
+		if err != nil {
+			return err
+		}
+		if i+len(fragments) > len(b) {
+			return fmt.Errorf("%w: missing transactions for input %s", ErrInvalidBundle, address)
+		}
+		for j, fragment := range fragments {
+			if tx := b[i+j]; tx.Address != address || (j > 0 && tx.Value != 0) {
+				return fmt.Errorf("%w: missing transactions for input %s", ErrInvalidBundle, address)
+			}
+			b[i+j].SignatureMessageFragment = trinary.MustTritsToTrytes(fragment)
+		}
+		return nil
+	}
+	return fmt.Errorf("%w: no input %s", ErrInvalidBundle, address)
+}
+
+// VerifySignatures reports whether the bundle hash is valid and all inputs are signed by the keys of their addresses.
+// The signature of an input consists of the fragments of its transaction and all following zero-value transactions
+// of the same address.
+func (b Bundle) VerifySignatures() bool {
+	if len(b) == 0 {
+		return false
+	}
+	hash, err := b.hash()
+	if err != nil {
+		return false
+	}
+	for _, t := range b {
+		if t.Bundle != hash {
+			return false
+		}
+	}
+	for i, t := range b {
+		if t.Value >= 0 {
+			continue
+		}
+		var fragments []trinary.Trits
+		for j := i; j < len(b) && b[j].Address == t.Address && (j == i || b[j].Value == 0); j++ {
+			fragment, err := trinary.TrytesToTrits(b[j].SignatureMessageFragment)
+			if err != nil {
+				return false
+			}
+			fragments = append(fragments, fragment)
+		}
+		if !VerifySignature(t.Address, hash, fragments) {
+			return false
+		}
+	}
+	return true
+}
+
+// hash computes the bundle hash from the essences of all transactions.
+func (b Bundle) hash() (string, error) {
+	k := NewKerl()
+	for _, t := range b {
+		// each essence consists of exactly two chunks
+		if err := k.Absorb(t.essence()); err != nil {
+			return "", err
+		}
+	}
+	h, err := k.Squeeze(HashTrinarySize)
+	if err != nil {
+		return "", err
+	}
+	return trinary.MustTritsToTrytes(h), nil
+}
+
+// NormalizeHash returns the tryte values of the normalized hash, in which each third of the hash sums up to zero.
+// The normalized values determine how often each W-OTS private key chunk is hashed when signing.
+func NormalizeHash(hash string) []int {
+	values := make([]int, 0, HashTrytesSize)
+	for i := 0; i < len(hash); i++ {
+		values = append(values, int(trinary.TritsToInt(trinary.MustTrytesToTrits(hash[i:i+1]))))
+	}
+	const partSize = HashTrytesSize / MaxSecurityLevel
+	for part := 0; part < MaxSecurityLevel; part++ {
+		p := values[part*partSize : (part+1)*partSize]
+		sum := 0
+		for _, v := range p {
+			sum += v
+		}
+		for ; sum > 0; sum-- {
+			for i := range p {
+				if p[i] > trinary.MinTryteValue {
+					p[i]--
+					break
+				}
+			}
+		}
+		for ; sum < 0; sum++ {
+			for i := range p {
+				if p[i] < trinary.MaxTryteValue {
+					p[i]++
+					break
+				}
+			}
+		}
+	}
+	return values
+}
+
+func containsMaxTryte(values []int) bool {
+	for _, v := range values {
+		if v == trinary.MaxTryteValue {
+			return true
+		}
+	}
+	return false
+}
+
+// intToTrytes returns the trytes of v padded to the given number of trytes.
+func intToTrytes(v int64, size int) string {
+	return padTrytes(trinary.IntToTrits(v), size)
+}
+
+// uintToTrytes returns the trytes of v padded to the given number of trytes.
+func uintToTrytes(v uint64, size int) string {
+	return padTrytes(uintToTrits(v), size)
+}
+
+func padTrytes(trits trinary.Trits, size int) string {
+	padded := make(trinary.Trits, size*trinary.TritsPerTryte)
+	copy(padded, trits)
+	return trinary.MustTritsToTrytes(padded)
+}
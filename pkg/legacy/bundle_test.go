@@ -0,0 +1,49 @@
+package legacy_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/legacy"
+	"github.com/iotaledger/iota-crypto-demo/pkg/trinary"
+)
+
+func TestNormalizeHash(t *testing.T) {
+	values := legacy.NormalizeHash(strings.Repeat("M", legacy.HashTrytesSize))
+	for part := 0; part < legacy.MaxSecurityLevel; part++ {
+		sum := 0
+		for _, v := range values[part*27 : (part+1)*27] {
+			assert.GreaterOrEqual(t, v, trinary.MinTryteValue)
+			assert.LessOrEqual(t, v, trinary.MaxTryteValue)
+			sum += v
+		}
+		assert.Zero(t, sum)
+	}
+}
+
+func TestFinalize(t *testing.T) {
+	addr := addressTests[0].address
+	bundle := legacy.Bundle{
+		legacy.NewTransaction(addr, 100, 1600000000),
+		legacy.NewTransaction(addr, -100, 1600000000),
+	}
+	require.NoError(t, bundle.Finalize())
+
+	hash := bundle[0].Bundle
+	assert.Len(t, hash, legacy.HashTrytesSize)
+	assert.NotContains(t, legacy.NormalizeHash(hash), trinary.MaxTryteValue)
+	for i, tx := range bundle {
+		assert.EqualValues(t, i, tx.CurrentIndex)
+		assert.EqualValues(t, 1, tx.LastIndex)
+		assert.Equal(t, hash, tx.Bundle)
+
+		trytes := tx.Trytes()
+		assert.Len(t, trytes, legacy.TransactionTrytesSize)
+		assert.NoError(t, trinary.ValidTrytes(trytes))
+	}
+
+	assert.Error(t, legacy.Bundle{}.Finalize())
+}
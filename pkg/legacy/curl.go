@@ -0,0 +1,79 @@
+package legacy
+
+import (
+	"github.com/iotaledger/iota-crypto-demo/pkg/trinary"
+)
+
+const (
+	// CurlStateSize is the size, in trits, of the Curl state.
+	CurlStateSize = 3 * HashTrinarySize
+	// CurlP81Rounds is the number of rounds of Curl-P-81.
+	CurlP81Rounds = 81
+)
+
+// truthTable is the S-box of Curl indexed by a + 4·b + 5.
+var truthTable = [11]int8{1, 0, -1, 2, 1, -1, 0, 2, -1, 1, 0}
+
+// Curl implements the Curl-P ternary sponge.
+type Curl struct {
+	state  [CurlStateSize]int8
+	rounds int
+}
+
+// NewCurlP81 returns a new Curl-P-81 sponge.
+func NewCurlP81() Sponge {
+	return &Curl{rounds: CurlP81Rounds}
+}
+
+// Absorb absorbs trits, whose length must be a multiple of HashTrinarySize, into the state.
+func (c *Curl) Absorb(trits trinary.Trits) error {
+	if err := checkLength(len(trits)); err != nil {
+		return err
+	}
+	if err := trinary.ValidTrits(trits); err != nil {
+		return err
+	}
+	for len(trits) > 0 {
+		copy(c.state[:HashTrinarySize], trits)
+		c.transform()
+		trits = trits[HashTrinarySize:]
+	}
+	return nil
+}
+
+// Squeeze squeezes length trits, which must be a multiple of HashTrinarySize, out of the state.
+func (c *Curl) Squeeze(length int) (trinary.Trits, error) {
+	if err := checkLength(length); err != nil {
+		return nil, err
+	}
+	out := make(trinary.Trits, 0, length)
+	for len(out) < length {
+		out = append(out, c.state[:HashTrinarySize]...)
+		c.transform()
+	}
+	return out, nil
+}
+
+// Reset resets the sponge to its initial state.
+func (c *Curl) Reset() {
+	c.state = [CurlStateSize]int8{}
+}
+
+// transform applies the Curl permutation to the state.
+func (c *Curl) transform() {
+	var scratch [CurlStateSize]int8
+	for r := 0; r < c.rounds; r++ {
+		scratch = c.state
+		index := 0
+		for i := 0; i < CurlStateSize; i++ {
+			a := scratch[index]
+			// advance by 364 modulo the state size
+			if index < 365 {
+				index += 364
+			} else {
+				index -= 365
+			}
+			c.state[i] = truthTable[a+scratch[index]<<2+5]
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package legacy
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// keccak is the original Keccak sponge with the 0x01 padding, as x/crypto/sha3 does not provide Keccak-384.
+type keccak struct {
+	a         [25]uint64
+	buf       []byte
+	rate      int
+	outputLen int
+}
+
+// newLegacyKeccak returns a new Keccak hash with the given output size and a capacity of twice that size.
+func newLegacyKeccak(outputLen int) *keccak {
+	return &keccak{rate: 200 - 2*outputLen, outputLen: outputLen}
+}
+
+func newLegacyKeccak384() *keccak {
+	return newLegacyKeccak(HashByteSize)
+}
+
+func (k *keccak) Size() int      { return k.outputLen }
+func (k *keccak) BlockSize() int { return k.rate }
+
+func (k *keccak) Reset() {
+	k.a = [25]uint64{}
+	k.buf = k.buf[:0]
+}
+
+func (k *keccak) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		m := min(k.rate-len(k.buf), len(p))
+		k.buf = append(k.buf, p[:m]...)
+		p = p[m:]
+		if len(k.buf) == k.rate {
+			k.absorbBlock(k.buf)
+			k.buf = k.buf[:0]
+		}
+	}
+	return n, nil
+}
+
+// Sum appends the digest to b without changing the state.
+func (k *keccak) Sum(b []byte) []byte {
+	d := *k
+	block := make([]byte, k.rate)
+	copy(block, k.buf)
+	block[len(k.buf)] ^= 0x01
+	block[k.rate-1] ^= 0x80
+	d.absorbBlock(block)
+
+	var out []byte
+	for i := 0; len(out) < k.outputLen; i++ {
+		out = binary.LittleEndian.AppendUint64(out, d.a[i])
+	}
+	return append(b, out[:k.outputLen]...)
+}
+
+func (k *keccak) absorbBlock(block []byte) {
+	for i := 0; i < k.rate/8; i++ {
+		k.a[i] ^= binary.LittleEndian.Uint64(block[8*i:])
+	}
+	keccakF1600(&k.a)
+}
+
+var roundConstants = [24]uint64{
+	0x0000000000000001, 0x0000000000008082, 0x800000000000808A, 0x8000000080008000,
+	0x000000000000808B, 0x0000000080000001, 0x8000000080008081, 0x8000000000008009,
+	0x000000000000008A, 0x0000000000000088, 0x0000000080008009, 0x000000008000000A,
+	0x000000008000808B, 0x800000000000008B, 0x8000000000008089, 0x8000000000008003,
+	0x8000000000008002, 0x8000000000000080, 0x000000000000800A, 0x800000008000000A,
+	0x8000000080008081, 0x8000000000008080, 0x0000000080000001, 0x8000000080008008,
+}
+
+// rotations[x][y] is the rotation offset of the lane A[x+5y].
+var rotations = [5][5]int{
+	{0, 36, 3, 41, 18},
+	{1, 44, 10, 45, 2},
+	{62, 6, 43, 15, 61},
+	{28, 55, 25, 21, 56},
+	{27, 20, 39, 8, 14},
+}
+
+// keccakF1600 applies the Keccak-f[1600] permutation.
+func keccakF1600(a *[25]uint64) {
+	var b [25]uint64
+	var c, d [5]uint64
+	for _, rc := range roundConstants {
+		// θ
+		for x := 0; x < 5; x++ {
+			c[x] = a[x] ^ a[x+5] ^ a[x+10] ^ a[x+15] ^ a[x+20]
+		}
+		for x := 0; x < 5; x++ {
+			d[x] = c[(x+4)%5] ^ bits.RotateLeft64(c[(x+1)%5], 1)
+		}
+		// ρ and π
+		for x := 0; x < 5; x++ {
+			for y := 0; y < 5; y++ {
+				b[y+5*((2*x+3*y)%5)] = bits.RotateLeft64(a[x+5*y]^d[x], rotations[x][y])
+			}
+		}
+		// χ
+		for y := 0; y < 5; y++ {
+			for x := 0; x < 5; x++ {
+				a[x+5*y] = b[x+5*y] ^ (^b[(x+1)%5+5*y] & b[(x+2)%5+5*y])
+			}
+		}
+		// ι
+		a[0] ^= rc
+	}
+}
@@ -0,0 +1,25 @@
+package legacy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/sha3"
+)
+
+func TestKeccak(t *testing.T) {
+	// compare the sponge construction with a Keccak variant provided by x/crypto
+	for _, n := range []int{0, 1, 135, 136, 137, 1000} {
+		data := make([]byte, n)
+		for i := range data {
+			data[i] = byte(i)
+		}
+		expected := sha3.NewLegacyKeccak256()
+		expected.Write(data)
+
+		k := newLegacyKeccak(32)
+		k.Write(data[:n/2])
+		k.Write(data[n/2:])
+		assert.Equal(t, expected.Sum(nil), k.Sum(nil), "length %d", n)
+	}
+}
@@ -0,0 +1,102 @@
+package legacy
+
+import (
+	"hash"
+	"math/big"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/trinary"
+)
+
+// HashByteSize is the size, in bytes, of the Keccak-384 digest used by Kerl.
+const HashByteSize = 48
+
+// Kerl implements the Kerl ternary sponge based on Keccak-384.
+type Kerl struct {
+	h hash.Hash
+}
+
+// NewKerl returns a new Kerl sponge.
+func NewKerl() Sponge {
+	return &Kerl{h: newLegacyKeccak384()}
+}
+
+// Absorb absorbs trits, whose length must be a multiple of HashTrinarySize, into the state.
+func (k *Kerl) Absorb(trits trinary.Trits) error {
+	if err := checkLength(len(trits)); err != nil {
+		return err
+	}
+	if err := trinary.ValidTrits(trits); err != nil {
+		return err
+	}
+	for ; len(trits) > 0; trits = trits[HashTrinarySize:] {
+		k.h.Write(tritsToBytes(trits[:HashTrinarySize]))
+	}
+	return nil
+}
+
+// Squeeze squeezes length trits, which must be a multiple of HashTrinarySize, out of the state.
+func (k *Kerl) Squeeze(length int) (trinary.Trits, error) {
+	if err := checkLength(length); err != nil {
+		return nil, err
+	}
+	out := make(trinary.Trits, 0, length)
+	for len(out) < length {
+		digest := k.h.Sum(nil)
+		out = append(out, bytesToTrits(digest)...)
+
+		// the next state is derived from the bitwise complement of the digest
+		for i := range digest {
+			digest[i] = ^digest[i]
+		}
+		k.h.Reset()
+		k.h.Write(digest)
+	}
+	return out, nil
+}
+
+// Reset resets the sponge to its initial state.
+func (k *Kerl) Reset() {
+	k.h.Reset()
+}
+
+var (
+	three = big.NewInt(3)
+	// twoTo384 is used to represent negative integers in two's complement.
+	twoTo384 = new(big.Int).Lsh(big.NewInt(1), 8*HashByteSize)
+)
+
+// tritsToBytes converts the first 242 trits into the 384-bit two's complement big-endian representation.
+// The last trit is ignored, as it is always treated as zero by Kerl.
+func tritsToBytes(trits trinary.Trits) []byte {
+	v := new(big.Int)
+	for i := HashTrinarySize - 2; i >= 0; i-- {
+		v.Mul(v, three)
+		v.Add(v, big.NewInt(int64(trits[i])))
+	}
+	if v.Sign() < 0 {
+		v.Add(v, twoTo384)
+	}
+	return v.FillBytes(make([]byte, HashByteSize))
+}
+
+// bytesToTrits converts the 384-bit two's complement big-endian integer into 243 trits with the last trit zero.
+// Integers exceeding the range of 242 trits are reduced to it.
+func bytesToTrits(b []byte) trinary.Trits {
+	v := new(big.Int).SetBytes(b)
+	if b[0]&0x80 != 0 {
+		v.Sub(v, twoTo384)
+	}
+
+	trits := make(trinary.Trits, HashTrinarySize)
+	r := new(big.Int)
+	for i := 0; i < HashTrinarySize-1; i++ {
+		v.DivMod(v, three, r) // 0 ≤ r < 3
+		t := r.Int64()
+		if t == 2 {
+			t = -1
+			v.Add(v, big.NewInt(1))
+		}
+		trits[i] = int8(t)
+	}
+	return trits
+}
@@ -0,0 +1,58 @@
+/*
+Package legacy implements the ternary hash functions of the legacy IOTA protocol for migration tooling.
+
+Curl-P-81 is the ternary sponge used for legacy transaction hashes, while Kerl wraps Keccak-384 with a conversion
+between 243 trits and 48 bytes and is used for addresses, bundle hashes and W-OTS signatures.
+*/
+package legacy
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/trinary"
+)
+
+const (
+	// HashTrinarySize is the size, in trits, of a hash and of the rate of the sponges.
+	HashTrinarySize = 243
+	// HashTrytesSize is the size, in trytes, of a hash.
+	HashTrytesSize = HashTrinarySize / trinary.TritsPerTryte
+)
+
+// ErrInvalidLength is returned when the number of trits is not a multiple of HashTrinarySize.
+var ErrInvalidLength = errors.New("invalid length")
+
+// Sponge is a ternary sponge hash function.
+type Sponge interface {
+	// Absorb absorbs trits, whose length must be a multiple of HashTrinarySize, into the state.
+	Absorb(trits trinary.Trits) error
+	// Squeeze squeezes length trits, which must be a multiple of HashTrinarySize, out of the state.
+	Squeeze(length int) (trinary.Trits, error)
+	// Reset resets the sponge to its initial state.
+	Reset()
+}
+
+// HashTrytes returns the hash of trytes with a single squeeze of the sponge created by newSponge.
+func HashTrytes(newSponge func() Sponge, trytes string) (string, error) {
+	trits, err := trinary.TrytesToTrits(trytes)
+	if err != nil {
+		return "", err
+	}
+	s := newSponge()
+	if err := s.Absorb(trits); err != nil {
+		return "", err
+	}
+	hash, err := s.Squeeze(HashTrinarySize)
+	if err != nil {
+		return "", err
+	}
+	return trinary.MustTritsToTrytes(hash), nil
+}
+
+func checkLength(length int) error {
+	if length == 0 || length%HashTrinarySize != 0 {
+		return fmt.Errorf("%w: %d trits", ErrInvalidLength, length)
+	}
+	return nil
+}
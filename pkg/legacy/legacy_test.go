@@ -0,0 +1,66 @@
+//nolint:scopelint
+package legacy_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/legacy"
+	"github.com/iotaledger/iota-crypto-demo/pkg/trinary"
+)
+
+var kerlTests = []*struct {
+	in  string
+	out string
+}{
+	{
+		"EMIDYNHBWMBCXVDEFOFWINXTERALUKYYPPHKP9JJFGJEIUY9MUDVNFZHMMWZUYUSWAIOWEVTHNWMHANBH",
+		"EJEAOOZYSAWFPZQESYDHZCGYNSTWXUMVJOVDWUNZJXDGWCLUFGIMZRMGCAZGKNPLBRLGUNYWKLJTYEAQX",
+	},
+	{
+		"9MIDYNHBWMBCXVDEFOFWINXTERALUKYYPPHKP9JJFGJEIUY9MUDVNFZHMMWZUYUSWAIOWEVTHNWMHANBH",
+		"G9JYBOMPUXHYHKSNRNMMSSZCSHOFYOYNZRSZMAAYWDYEIMVVOGKPJBVBM9TDPULSFUNMTVXRKFIDOHUXXVYDLFSZYZTWQYTE9SPYYWYTXJYQ9IFGYOLZXWZBKWZN9QOOTBQMWMUBLEWUEEASRHRTNIQWJQNDWRYLCA",
+	},
+}
+
+func TestKerl(t *testing.T) {
+	for _, tt := range kerlTests {
+		t.Run(tt.in[:9], func(t *testing.T) {
+			k := legacy.NewKerl()
+			require.NoError(t, k.Absorb(trinary.MustTrytesToTrits(tt.in)))
+			out, err := k.Squeeze(len(tt.out) * trinary.TritsPerTryte)
+			require.NoError(t, err)
+			assert.Equal(t, tt.out, trinary.MustTritsToTrytes(out))
+		})
+	}
+}
+
+func TestCurlP81(t *testing.T) {
+	// known answer of the iota.go test suite, the short input is padded with zero trits
+	hash, err := legacy.HashTrytes(legacy.NewCurlP81, "A"+strings.Repeat("9", legacy.HashTrytesSize-1))
+	require.NoError(t, err)
+	assert.Equal(t, "TJVKPMTAMIZVBVHIVQUPTKEMPROEKV9SB9COEDQYRHYPTYSKQIAN9PQKMZHCPO9TS9BHCORFKW9CQXZEE", hash)
+
+	// the all-zero state is a fixed point of the permutation, as its uniform lanes cycle with a period of three rounds
+	zero := strings.Repeat("9", legacy.HashTrytesSize)
+	hash, err = legacy.HashTrytes(legacy.NewCurlP81, zero)
+	require.NoError(t, err)
+	assert.Equal(t, zero, hash)
+
+	other, err := legacy.HashTrytes(legacy.NewCurlP81, "B"+zero[1:])
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, other)
+}
+
+func TestInvalidLength(t *testing.T) {
+	for _, newSponge := range []func() legacy.Sponge{legacy.NewCurlP81, legacy.NewKerl} {
+		s := newSponge()
+		assert.ErrorIs(t, s.Absorb(make(trinary.Trits, 242)), legacy.ErrInvalidLength)
+		_, err := s.Squeeze(0)
+		assert.ErrorIs(t, err, legacy.ErrInvalidLength)
+		assert.NoError(t, s.Absorb(make(trinary.Trits, 243)))
+	}
+}
@@ -0,0 +1,276 @@
+package legacy
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/trinary"
+)
+
+const (
+	// SeedTrytesSize is the size, in trytes, of a legacy seed.
+	SeedTrytesSize = HashTrytesSize
+	// ChecksumTrytesSize is the size, in trytes, of an address checksum.
+	ChecksumTrytesSize = 9
+	// FragmentChunks is the number of hash chunks of a key fragment.
+	FragmentChunks = 27
+	// KeyFragmentSize is the size, in trits, of a key fragment, i.e. one fragment per security level.
+	KeyFragmentSize = FragmentChunks * HashTrinarySize
+
+	// MinSecurityLevel is the lowest W-OTS security level.
+	MinSecurityLevel = 1
+	// MaxSecurityLevel is the highest W-OTS security level.
+	MaxSecurityLevel = 3
+
+	// chainLength is the number of hashes of a W-OTS chain, i.e. the number of tryte values minus one.
+	chainLength = trinary.MaxTryteValue - trinary.MinTryteValue
+)
+
+var (
+	// ErrInvalidSeed is returned when a seed does not consist of exactly SeedTrytesSize trytes.
+	ErrInvalidSeed = errors.New("invalid seed")
+	// ErrInvalidSecurityLevel is returned when the security level is not between MinSecurityLevel and MaxSecurityLevel.
+	ErrInvalidSecurityLevel = errors.New("invalid security level")
+	// ErrInvalidChecksum is returned when the checksum of an address does not match.
+	ErrInvalidChecksum = errors.New("invalid checksum")
+)
+
+// Subseed returns the subseed of the address with the given index, i.e. the Kerl hash of the seed plus the index.
+func Subseed(seed string, index uint64) (trinary.Trits, error) {
+	if len(seed) != SeedTrytesSize {
+		return nil, fmt.Errorf("%w: length %d", ErrInvalidSeed, len(seed))
+	}
+	seedTrits, err := trinary.TrytesToTrits(seed)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSeed, err)
+	}
+	incremented := trinary.AddTrits(seedTrits, uintToTrits(index))
+
+	k := NewKerl()
+	if err := k.Absorb(incremented); err != nil {
+		return nil, err
+	}
+	return k.Squeeze(HashTrinarySize)
+}
+
+// Key returns the W-OTS private key of the subseed consisting of one key fragment per security level.
+func Key(subseed trinary.Trits, security int) (trinary.Trits, error) {
+	if err := checkSecurityLevel(security); err != nil {
+		return nil, err
+	}
+	k := NewKerl()
+	if err := k.Absorb(subseed); err != nil {
+		return nil, err
+	}
+	return k.Squeeze(security * KeyFragmentSize)
+}
+
+// Digests returns the digests of all fragments of the private key by hashing each chunk to the end of its chain.
+func Digests(key trinary.Trits) (trinary.Trits, error) {
+	if len(key) == 0 || len(key)%KeyFragmentSize != 0 {
+		return nil, fmt.Errorf("%w: %d trits", ErrInvalidLength, len(key))
+	}
+	var digests trinary.Trits
+	for fragment := key; len(fragment) > 0; fragment = fragment[KeyFragmentSize:] {
+		chains := make(trinary.Trits, 0, KeyFragmentSize)
+		for i := 0; i < FragmentChunks; i++ {
+			chunk, err := hashChain(fragment[i*HashTrinarySize:(i+1)*HashTrinarySize], chainLength)
+			if err != nil {
+				return nil, err
+			}
+			chains = append(chains, chunk...)
+		}
+		digest, err := kerlHash(chains)
+		if err != nil {
+			return nil, err
+		}
+		digests = append(digests, digest...)
+	}
+	return digests, nil
+}
+
+// AddressFromDigests returns the address corresponding to the key digests.
+func AddressFromDigests(digests trinary.Trits) (trinary.Trits, error) {
+	return kerlHash(digests)
+}
+
+// Address derives the address with the given index and security level from seed.
+// The returned address consists of HashTrytesSize trytes without checksum.
+func Address(seed string, index uint64, security int) (string, error) {
+	subseed, err := Subseed(seed, index)
+	if err != nil {
+		return "", err
+	}
+	key, err := Key(subseed, security)
+	if err != nil {
+		return "", err
+	}
+	digests, err := Digests(key)
+	if err != nil {
+		return "", err
+	}
+	addr, err := AddressFromDigests(digests)
+	if err != nil {
+		return "", err
+	}
+	return trinary.MustTritsToTrytes(addr), nil
+}
+
+// SignatureFragments signs hash with the W-OTS private key and returns one signature fragment per security level.
+// Each key chunk is hashed MaxTryteValue minus the corresponding value of the normalized hash times.
+// As signing reveals parts of the key, each key must only be used for a single signature.
+func SignatureFragments(key trinary.Trits, hash string) ([]trinary.Trits, error) {
+	if len(key) == 0 || len(key)%KeyFragmentSize != 0 || len(key) > MaxSecurityLevel*KeyFragmentSize {
+		return nil, fmt.Errorf("%w: %d trits", ErrInvalidLength, len(key))
+	}
+	normalized, err := normalizeHash(hash)
+	if err != nil {
+		return nil, err
+	}
+	fragments := make([]trinary.Trits, len(key)/KeyFragmentSize)
+	for i := range fragments {
+		fragment := make(trinary.Trits, 0, KeyFragmentSize)
+		for j := 0; j < FragmentChunks; j++ {
+			offset := i*KeyFragmentSize + j*HashTrinarySize
+			chunk, err := hashChain(key[offset:offset+HashTrinarySize], trinary.MaxTryteValue-normalized[i*FragmentChunks+j])
+			if err != nil {
+				return nil, err
+			}
+			fragment = append(fragment, chunk...)
+		}
+		fragments[i] = fragment
+	}
+	return fragments, nil
+}
+
+// AddressFromSignature returns the address of the key that produced the signature fragments of hash.
+// Each signature chunk is hashed to the end of its chain, i.e. the corresponding normalized value minus
+// MinTryteValue times, resulting in the key digests.
+func AddressFromSignature(hash string, fragments []trinary.Trits) (string, error) {
+	if len(fragments) < MinSecurityLevel || len(fragments) > MaxSecurityLevel {
+		return "", fmt.Errorf("%w: %d fragments", ErrInvalidSecurityLevel, len(fragments))
+	}
+	normalized, err := normalizeHash(hash)
+	if err != nil {
+		return "", err
+	}
+	var digests trinary.Trits
+	for i, fragment := range fragments {
+		if len(fragment) != KeyFragmentSize {
+			return "", fmt.Errorf("%w: fragment of %d trits", ErrInvalidLength, len(fragment))
+		}
+		chains := make(trinary.Trits, 0, KeyFragmentSize)
+		for j := 0; j < FragmentChunks; j++ {
+			chunk, err := hashChain(fragment[j*HashTrinarySize:(j+1)*HashTrinarySize], normalized[i*FragmentChunks+j]-trinary.MinTryteValue)
+			if err != nil {
+				return "", err
+			}
+			chains = append(chains, chunk...)
+		}
+		digest, err := kerlHash(chains)
+		if err != nil {
+			return "", err
+		}
+		digests = append(digests, digest...)
+	}
+	addr, err := AddressFromDigests(digests)
+	if err != nil {
+		return "", err
+	}
+	return trinary.MustTritsToTrytes(addr), nil
+}
+
+// VerifySignature reports whether the signature fragments of hash were produced by the key of address.
+func VerifySignature(address string, hash string, fragments []trinary.Trits) bool {
+	addr, err := AddressFromSignature(hash, fragments)
+	return err == nil && addr == address
+}
+
+// normalizeHash validates hash and returns its normalized values.
+func normalizeHash(hash string) ([]int, error) {
+	if len(hash) != HashTrytesSize {
+		return nil, fmt.Errorf("%w: hash length %d", ErrInvalidLength, len(hash))
+	}
+	if err := trinary.ValidTrytes(hash); err != nil {
+		return nil, err
+	}
+	return NormalizeHash(hash), nil
+}
+
+// Checksum returns the checksum of the address, i.e. the last ChecksumTrytesSize trytes of its Kerl hash.
+func Checksum(address string) (string, error) {
+	if len(address) != HashTrytesSize {
+		return "", fmt.Errorf("%w: address length %d", ErrInvalidLength, len(address))
+	}
+	h, err := HashTrytes(NewKerl, address)
+	if err != nil {
+		return "", err
+	}
+	return h[HashTrytesSize-ChecksumTrytesSize:], nil
+}
+
+// AddChecksum returns the address followed by its checksum.
+func AddChecksum(address string) (string, error) {
+	checksum, err := Checksum(address)
+	if err != nil {
+		return "", err
+	}
+	return address + checksum, nil
+}
+
+// RemoveChecksum validates the checksum of the address and returns the address without it.
+func RemoveChecksum(address string) (string, error) {
+	if len(address) != HashTrytesSize+ChecksumTrytesSize {
+		return "", fmt.Errorf("%w: address length %d", ErrInvalidLength, len(address))
+	}
+	checksum, err := Checksum(address[:HashTrytesSize])
+	if err != nil {
+		return "", err
+	}
+	if checksum != address[HashTrytesSize:] {
+		return "", ErrInvalidChecksum
+	}
+	return address[:HashTrytesSize], nil
+}
+
+// uintToTrits returns the balanced ternary representation of the unsigned integer v.
+func uintToTrits(v uint64) trinary.Trits {
+	var trits trinary.Trits
+	for v != 0 {
+		t := int8(v % 3)
+		v /= 3
+		if t == 2 {
+			t = -1
+			v++
+		}
+		trits = append(trits, t)
+	}
+	return trits
+}
+
+// hashChain hashes the chunk n times with Kerl.
+func hashChain(chunk trinary.Trits, n int) (trinary.Trits, error) {
+	var err error
+	for i := 0; i < n; i++ {
+		if chunk, err = kerlHash(chunk); err != nil {
+			return nil, err
+		}
+	}
+	return chunk, nil
+}
+
+// kerlHash returns the Kerl hash of trits.
+func kerlHash(trits trinary.Trits) (trinary.Trits, error) {
+	k := NewKerl()
+	if err := k.Absorb(trits); err != nil {
+		return nil, err
+	}
+	return k.Squeeze(HashTrinarySize)
+}
+
+func checkSecurityLevel(security int) error {
+	if security < MinSecurityLevel || security > MaxSecurityLevel {
+		return fmt.Errorf("%w: %d", ErrInvalidSecurityLevel, security)
+	}
+	return nil
+}
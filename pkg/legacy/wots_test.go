@@ -0,0 +1,87 @@
+//nolint:scopelint
+package legacy_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/legacy"
+)
+
+const testSeed = "ZLNM9UHJWKTTDEZOTH9CXDEIFUJQCIACDPJIXPOWBDW9LTBHC9AQRIXTIHYLIIURLZCXNSTGNIVC9ISVB"
+
+var addressTests = []*struct {
+	index    uint64
+	security int
+	address  string
+}{
+	{0, 1, "TKHWAWYZNDMPBEAVJWMZGOFKRCPWBBSDYQ9LYSSUBKNTDAJFSMMPOUUXYGNKDRLCRITQLLTWIJHTKZTMW"},
+	{0, 2, "CLAAFXEY9AHHCSZCXNKDRZEJHIAFVKYORWNOZAGFPAZYNTSLCXUAG9WBSXBRXYEDPVPLXYVDCBCEKRUBD"},
+	{0, 3, "U9EIHTGTIKLIFC9HCPMUHQFQBZDHPQPYKKMOIAYAGSWXVWJCX9XLXPPPSLOLEKHLHYXSWEJHEXLJNEJXY"},
+}
+
+func TestAddress(t *testing.T) {
+	for _, tt := range addressTests {
+		t.Run(fmt.Sprintf("%d/%d", tt.index, tt.security), func(t *testing.T) {
+			addr, err := legacy.Address(testSeed, tt.index, tt.security)
+			require.NoError(t, err)
+			assert.Equal(t, tt.address, addr)
+
+			withChecksum, err := legacy.AddChecksum(addr)
+			require.NoError(t, err)
+			withoutChecksum, err := legacy.RemoveChecksum(withChecksum)
+			require.NoError(t, err)
+			assert.Equal(t, addr, withoutChecksum)
+		})
+	}
+}
+
+func TestAddressIndex(t *testing.T) {
+	first, err := legacy.Address(testSeed, 0, 1)
+	require.NoError(t, err)
+	second, err := legacy.Address(testSeed, 1, 1)
+	require.NoError(t, err)
+	assert.NotEqual(t, first, second)
+}
+
+func TestAddressInvalid(t *testing.T) {
+	_, err := legacy.Address(testSeed[1:], 0, 2)
+	assert.ErrorIs(t, err, legacy.ErrInvalidSeed)
+	_, err = legacy.Address(testSeed, 0, 4)
+	assert.ErrorIs(t, err, legacy.ErrInvalidSecurityLevel)
+
+	withChecksum, err := legacy.AddChecksum(addressTests[0].address)
+	require.NoError(t, err)
+	_, err = legacy.RemoveChecksum(withChecksum[:legacy.HashTrytesSize] + "999999999")
+	assert.ErrorIs(t, err, legacy.ErrInvalidChecksum)
+}
+
+func TestSignatureFragments(t *testing.T) {
+	hash, err := legacy.HashTrytes(legacy.NewKerl, testSeed)
+	require.NoError(t, err)
+	other, err := legacy.HashTrytes(legacy.NewKerl, hash)
+	require.NoError(t, err)
+
+	for security := legacy.MinSecurityLevel; security <= legacy.MaxSecurityLevel; security++ {
+		t.Run(fmt.Sprint(security), func(t *testing.T) {
+			subseed, err := legacy.Subseed(testSeed, 0)
+			require.NoError(t, err)
+			key, err := legacy.Key(subseed, security)
+			require.NoError(t, err)
+
+			fragments, err := legacy.SignatureFragments(key, hash)
+			require.NoError(t, err)
+			require.Len(t, fragments, security)
+			assert.True(t, legacy.VerifySignature(addressTests[security-1].address, hash, fragments))
+
+			assert.False(t, legacy.VerifySignature(addressTests[security-1].address, other, fragments))
+
+			if security > 1 {
+				assert.False(t, legacy.VerifySignature(addressTests[security-1].address, hash, fragments[:security-1]))
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package merkle
+
+import "encoding"
+
+// Builder computes the Merkle tree hash incrementally from leaves added one at a time.
+// It only stores the roots of the O(log n) perfect subtrees and produces the same hash as Hasher.Hash.
+type Builder struct {
+	hasher *Hasher
+	size   int
+	// stack contains the roots of the perfect subtrees in decreasing size, heights[i] is the height of stack[i].
+	stack   [][]byte
+	heights []int
+}
+
+// NewBuilder creates a new Builder using the hash function of the Hasher.
+func (t *Hasher) NewBuilder() *Builder {
+	return &Builder{hasher: t}
+}
+
+// Add adds the data encoding as the next leaf.
+func (b *Builder) Add(data encoding.BinaryMarshaler) error {
+	h, err := b.hasher.hashLeaf(data)
+	if err != nil {
+		return err
+	}
+	b.push(h)
+	return nil
+}
+
+// Write adds p as the next leaf. It always returns len(p) and a nil error.
+func (b *Builder) Write(p []byte) (int, error) {
+	h := b.hasher.newHash()
+	h.Write([]byte{LeafHashPrefix})
+	h.Write(p)
+	b.push(h.Sum(nil))
+	return len(p), nil
+}
+
+// Size returns the number of leaves added so far.
+func (b *Builder) Size() int {
+	return b.size
+}
+
+// Root returns the Merkle tree hash of all leaves added so far.
+func (b *Builder) Root() []byte {
+	if len(b.stack) == 0 {
+		return b.hasher.EmptyRoot()
+	}
+	// the left subtrees are always perfect, so the remaining roots are combined from right to left
+	root := b.stack[len(b.stack)-1]
+	for i := len(b.stack) - 2; i >= 0; i-- {
+		root = b.hasher.hashNode(b.stack[i], root)
+	}
+	return root
+}
+
+// push adds the leaf hash h and merges the perfect subtrees of equal height.
+func (b *Builder) push(h []byte) {
+	b.size++
+	height := 0
+	for n := len(b.stack); n > 0 && b.heights[n-1] == height; n-- {
+		h = b.hasher.hashNode(b.stack[n-1], h)
+		height++
+		b.stack, b.heights = b.stack[:n-1], b.heights[:n-1]
+	}
+	b.stack = append(b.stack, h)
+	b.heights = append(b.heights, height)
+}
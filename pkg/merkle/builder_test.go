@@ -0,0 +1,32 @@
+package merkle
+
+import (
+	"crypto"
+	"encoding"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuilder(t *testing.T) {
+	hasher := NewHasher(crypto.BLAKE2b_256)
+	builder := hasher.NewBuilder()
+
+	var data []encoding.BinaryMarshaler
+	for i := 0; i < 33; i++ {
+		root, err := hasher.Hash(data)
+		require.NoError(t, err)
+		assert.Equal(t, root, builder.Root(), "size %d", i)
+		assert.Equal(t, i, builder.Size())
+
+		b := []byte{byte(i), byte(i >> 8)}
+		data = append(data, marshalerFunc(func() ([]byte, error) { return b, nil }))
+		if i%2 == 0 {
+			require.NoError(t, builder.Add(data[i]))
+		} else {
+			_, err := builder.Write(b)
+			require.NoError(t, err)
+		}
+	}
+}
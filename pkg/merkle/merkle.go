@@ -1,11 +1,12 @@
 /*
-Package merkle implements the Merkle tree hash computation.
+Package merkle implements the Merkle tree hash computation and inclusion proofs.
 */
 package merkle
 
 import (
 	"crypto"
 	"encoding"
+	"hash"
 	"math/bits"
 )
 
@@ -17,23 +18,31 @@ const (
 
 // Hasher implements the hashing algorithm described in the IOTA protocol RFC-12.
 type Hasher struct {
-	hash crypto.Hash
+	newHash func() hash.Hash
+	size    int
 }
 
 // NewHasher creates a new Hasher using the provided hash function.
 func NewHasher(h crypto.Hash) *Hasher {
-	return &Hasher{hash: h}
+	return NewHasherFunc(h.New)
+}
+
+// NewHasherFunc creates a new Hasher using the hash function returned by the provided constructor,
+// e.g. sha512.New or sha3.NewLegacyKeccak256. This allows to match the trees of other implementations,
+// while the domain separation prefixes are preserved.
+func NewHasherFunc(newHash func() hash.Hash) *Hasher {
+	return &Hasher{newHash: newHash, size: newHash().Size()}
 }
 
 // Size returns the length, in bytes, of a digest resulting from the given hash function.
 func (t *Hasher) Size() int {
-	return t.hash.Size()
+	return t.size
 }
 
 // EmptyRoot returns a special case for an empty tree.
 // This is equivalent to Hash(nil).
 func (t *Hasher) EmptyRoot() []byte {
-	return t.hash.New().Sum(nil)
+	return t.newHash().Sum(nil)
 }
 
 // Hash computes the Merkle tree hash of the provided data encodings.
@@ -63,7 +72,7 @@ func (t *Hasher) hashLeaf(data encoding.BinaryMarshaler) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
-	h := t.hash.New()
+	h := t.newHash()
 	h.Write([]byte{LeafHashPrefix})
 	h.Write(b)
 	return h.Sum(nil), nil
@@ -71,7 +80,7 @@ func (t *Hasher) hashLeaf(data encoding.BinaryMarshaler) ([]byte, error) {
 
 // hashNode returns the inner Merkle tree node hash of the two child nodes l and r.
 func (t *Hasher) hashNode(l, r []byte) []byte {
-	h := t.hash.New()
+	h := t.newHash()
 	h.Write([]byte{NodeHashPrefix})
 	h.Write(l)
 	h.Write(r)
@@ -3,8 +3,11 @@ package merkle
 
 import (
 	"crypto"
+	"crypto/sha256"
+	"crypto/sha512"
 	"encoding"
 	"encoding/hex"
+	"hash"
 	"math"
 	"testing"
 
@@ -60,6 +63,40 @@ func TestHash(t *testing.T) {
 	}
 }
 
+func TestNewHasherFunc(t *testing.T) {
+	ids := []encoding.BinaryMarshaler{
+		marshalerFunc(func() ([]byte, error) { return hex.DecodeString("0001020304050607") }),
+		marshalerFunc(func() ([]byte, error) { return hex.DecodeString("08090a0b0c0d0e0f") }),
+	}
+
+	var tests = []*struct {
+		desc      string
+		newHash   func() hash.Hash
+		expString string
+	}{
+		{
+			desc:      "SHA-256",
+			newHash:   sha256.New,
+			expString: "da755e2df013ea7fb7f25d69fdbaa23944aee2693ef562df59196dcb4a1159ea",
+		},
+		{
+			desc:      "SHA-512",
+			newHash:   sha512.New,
+			expString: "8fbe8f6a6d987dd3f440961ff5437aa36c002ff10265ee435c5cc72110fa3196b8dfdd8a86e94ccec8c51e87c3863da1689f914ef05ded1a2badbe6e88552451",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			hasher := NewHasherFunc(tt.newHash)
+			assert.Equal(t, tt.newHash().Size(), hasher.Size())
+			bytes, err := hasher.Hash(ids)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expString, hex.EncodeToString(bytes))
+		})
+	}
+}
+
 func TestLargestPowerOfTwo(t *testing.T) {
 	// panics for x < 2
 	assert.Panics(t, func() { largestPowerOfTwo(0) })
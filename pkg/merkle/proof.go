@@ -0,0 +1,69 @@
+package merkle
+
+import (
+	"bytes"
+	"encoding"
+	"errors"
+)
+
+// ErrIndexOutOfRange is returned when the leaf index of a proof is outside the tree.
+var ErrIndexOutOfRange = errors.New("leaf index out of range")
+
+// ProofStep is a single sibling node of the audit path of an inclusion proof.
+type ProofStep struct {
+	// Hash is the Merkle tree hash of the sibling node.
+	Hash []byte
+	// Left indicates whether the sibling node is the left child of their common parent.
+	Left bool
+}
+
+// Proof is the audit path from a leaf to the root, starting with the sibling of the leaf.
+type Proof []ProofStep
+
+// Proof computes the inclusion proof of the leaf with the given index in the Merkle tree of data.
+func (t *Hasher) Proof(data []encoding.BinaryMarshaler, leafIndex int) (Proof, error) {
+	if leafIndex < 0 || leafIndex >= len(data) {
+		return nil, ErrIndexOutOfRange
+	}
+	if len(data) == 1 {
+		return Proof{}, nil
+	}
+
+	k := int(largestPowerOfTwo(len(data)))
+	if leafIndex < k {
+		proof, err := t.Proof(data[:k], leafIndex)
+		if err != nil {
+			return nil, err
+		}
+		r, err := t.Hash(data[k:])
+		if err != nil {
+			return nil, err
+		}
+		return append(proof, ProofStep{Hash: r, Left: false}), nil
+	}
+	proof, err := t.Proof(data[k:], leafIndex-k)
+	if err != nil {
+		return nil, err
+	}
+	l, err := t.Hash(data[:k])
+	if err != nil {
+		return nil, err
+	}
+	return append(proof, ProofStep{Hash: l, Left: true}), nil
+}
+
+// VerifyProof checks whether proof proves the inclusion of leaf in the Merkle tree with the given root.
+func (t *Hasher) VerifyProof(root []byte, proof Proof, leaf encoding.BinaryMarshaler) (bool, error) {
+	h, err := t.hashLeaf(leaf)
+	if err != nil {
+		return false, err
+	}
+	for _, step := range proof {
+		if step.Left {
+			h = t.hashNode(step.Hash, h)
+		} else {
+			h = t.hashNode(h, step.Hash)
+		}
+	}
+	return bytes.Equal(h, root), nil
+}
@@ -0,0 +1,52 @@
+//nolint:scopelint
+package merkle
+
+import (
+	"crypto"
+	"encoding"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProof(t *testing.T) {
+	hasher := NewHasher(crypto.BLAKE2b_256)
+
+	for _, size := range []int{1, 2, 3, 5, 8, 13} {
+		data := make([]encoding.BinaryMarshaler, size)
+		for i := range data {
+			b := []byte{byte(i)}
+			data[i] = marshalerFunc(func() ([]byte, error) { return b, nil })
+		}
+		root, err := hasher.Hash(data)
+		require.NoError(t, err)
+
+		for i := range data {
+			proof, err := hasher.Proof(data, i)
+			require.NoError(t, err)
+
+			ok, err := hasher.VerifyProof(root, proof, data[i])
+			require.NoError(t, err)
+			assert.True(t, ok, "size %d, index %d", size, i)
+
+			// the proof must not be valid for any other leaf
+			other := data[(i+1)%size]
+			if size > 1 {
+				ok, err = hasher.VerifyProof(root, proof, other)
+				require.NoError(t, err)
+				assert.False(t, ok, "size %d, index %d", size, i)
+			}
+		}
+	}
+}
+
+func TestProofIndexOutOfRange(t *testing.T) {
+	hasher := NewHasher(crypto.BLAKE2b_256)
+	data := []encoding.BinaryMarshaler{marshalerFunc(func() ([]byte, error) { return nil, nil })}
+
+	_, err := hasher.Proof(data, 1)
+	assert.ErrorIs(t, err, ErrIndexOutOfRange)
+	_, err = hasher.Proof(nil, 0)
+	assert.ErrorIs(t, err, ErrIndexOutOfRange)
+}
@@ -0,0 +1,142 @@
+/*
+Package migration implements the migration of legacy IOTA funds to Ed25519 addresses as specified for Chrysalis.
+
+The legacy funds are transferred with a regular legacy bundle to a special migration address, which encodes the
+receiving Ed25519 address in trytes. The migration address consists of the prefix "TRANSFER", the b1t6 encoding of
+the Ed25519 address, the b1t6 encoding of the first four bytes of its BLAKE2b-256 hash as checksum and a padding "9".
+*/
+package migration
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/bech32/address"
+	"github.com/iotaledger/iota-crypto-demo/pkg/legacy"
+	"github.com/iotaledger/iota-crypto-demo/pkg/trinary"
+	"golang.org/x/crypto/blake2b"
+)
+
+const (
+	// AddressPrefix is the prefix of every migration address.
+	AddressPrefix = "TRANSFER"
+	// checksumSize is the number of bytes of the address hash used as checksum.
+	checksumSize = 4
+)
+
+var (
+	// ErrInvalidAddress is returned when trytes are not a valid migration address.
+	ErrInvalidAddress = errors.New("invalid migration address")
+	// ErrNoInputs is returned when a bundle without any inputs with balance is requested.
+	ErrNoInputs = errors.New("no inputs")
+)
+
+// Address returns the legacy migration address, without checksum, of the Ed25519 address.
+func Address(addr address.Ed25519Address) string {
+	hash := addr.Hash()
+	checksum := blake2b.Sum256(hash[:])
+	return AddressPrefix + trinary.BytesToTrytes(hash[:]) + trinary.BytesToTrytes(checksum[:checksumSize]) + "9"
+}
+
+// ParseAddress decodes the Ed25519 address from the legacy migration address with or without legacy checksum.
+func ParseAddress(trytes string) (address.Ed25519Address, error) {
+	if len(trytes) == legacy.HashTrytesSize+legacy.ChecksumTrytesSize {
+		var err error
+		if trytes, err = legacy.RemoveChecksum(trytes); err != nil {
+			return address.Ed25519Address{}, fmt.Errorf("%w: %w", ErrInvalidAddress, err)
+		}
+	}
+	if len(trytes) != legacy.HashTrytesSize || !strings.HasPrefix(trytes, AddressPrefix) || !strings.HasSuffix(trytes, "9") {
+		return address.Ed25519Address{}, ErrInvalidAddress
+	}
+	b, err := trinary.TrytesToBytes(trytes[len(AddressPrefix) : legacy.HashTrytesSize-1])
+	if err != nil {
+		return address.Ed25519Address{}, fmt.Errorf("%w: %w", ErrInvalidAddress, err)
+	}
+
+	var hash [blake2b.Size256]byte
+	copy(hash[:], b)
+	checksum := blake2b.Sum256(hash[:])
+	if !bytes.Equal(checksum[:checksumSize], b[blake2b.Size256:]) {
+		return address.Ed25519Address{}, fmt.Errorf("%w: checksum mismatch", ErrInvalidAddress)
+	}
+	return address.AddressFromHash(hash), nil
+}
+
+// Input is a legacy address holding funds to be migrated.
+type Input struct {
+	// Address is the legacy address without checksum.
+	Address string
+	// Index is the key index of the address.
+	Index uint64
+	// Security is the W-OTS security level of the address.
+	Security int
+	// Balance is the balance of the address.
+	Balance uint64
+}
+
+// Inputs derives the inputs with the given indices and security level from the legacy seed.
+// The balances of the inputs are left empty and must be set from the ledger state.
+func Inputs(seed string, security int, indices ...uint64) ([]*Input, error) {
+	inputs := make([]*Input, len(indices))
+	for i, index := range indices {
+		addr, err := legacy.Address(seed, index, security)
+		if err != nil {
+			return nil, err
+		}
+		inputs[i] = &Input{Address: addr, Index: index, Security: security}
+	}
+	return inputs, nil
+}
+
+// NewBundle creates the finalized, but unsigned, legacy bundle transferring the balances of all inputs to the
+// migration address of target. Inputs without balance are skipped.
+// The first transaction is the output, followed by one transaction per security level of each input.
+func NewBundle(target address.Ed25519Address, inputs []*Input, timestamp uint64) (legacy.Bundle, error) {
+	output := legacy.NewTransaction(Address(target), 0, timestamp)
+	bundle := legacy.Bundle{output}
+	for _, in := range inputs {
+		if in.Balance == 0 {
+			continue
+		}
+		if in.Security < legacy.MinSecurityLevel || in.Security > legacy.MaxSecurityLevel {
+			return nil, fmt.Errorf("%w: %d", legacy.ErrInvalidSecurityLevel, in.Security)
+		}
+		output.Value += int64(in.Balance)
+		bundle = append(bundle, legacy.NewTransaction(in.Address, -int64(in.Balance), timestamp))
+		// the remaining signature fragments of the input are stored in zero-value transactions
+		for i := 1; i < in.Security; i++ {
+			bundle = append(bundle, legacy.NewTransaction(in.Address, 0, timestamp))
+		}
+	}
+	if len(bundle) == 1 {
+		return nil, ErrNoInputs
+	}
+	if err := bundle.Finalize(); err != nil {
+		return nil, err
+	}
+	return bundle, nil
+}
+
+// Sign signs the inputs of the migration bundle with the W-OTS keys derived from the legacy seed.
+func Sign(bundle legacy.Bundle, seed string, inputs []*Input) error {
+	for _, in := range inputs {
+		if in.Balance == 0 {
+			continue
+		}
+		subseed, err := legacy.Subseed(seed, in.Index)
+		if err != nil {
+			return err
+		}
+		key, err := legacy.Key(subseed, in.Security)
+		if err != nil {
+			return err
+		}
+		if err := bundle.SignInput(in.Address, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
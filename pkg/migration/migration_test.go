@@ -0,0 +1,94 @@
+package migration_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/internal/hexutil"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bech32/address"
+	"github.com/iotaledger/iota-crypto-demo/pkg/legacy"
+	"github.com/iotaledger/iota-crypto-demo/pkg/migration"
+)
+
+// test vector from the Chrysalis migration specification
+const (
+	testEd25519Address   = "6f9e8510b88b0ea4fbc684df90ba310540370a0403067b22cef4971fec3e8bb8"
+	testMigrationAddress = "TRANSFERCDJWLVPAIXRWNAPXV9WYKVUZWWKXVBE9JBABJ9D9C9F9OEGADYO9CWDAGZHBRWIXLXG9MAJV9RJEOLXSJW"
+)
+
+func testAddress() address.Ed25519Address {
+	var hash [32]byte
+	copy(hash[:], hexutil.MustDecodeString(testEd25519Address))
+	return address.AddressFromHash(hash)
+}
+
+func TestAddress(t *testing.T) {
+	addr := migration.Address(testAddress())
+	withChecksum, err := legacy.AddChecksum(addr)
+	require.NoError(t, err)
+	assert.Equal(t, testMigrationAddress, withChecksum)
+}
+
+func TestParseAddress(t *testing.T) {
+	for _, s := range []string{testMigrationAddress, testMigrationAddress[:legacy.HashTrytesSize]} {
+		addr, err := migration.ParseAddress(s)
+		require.NoError(t, err)
+		assert.Equal(t, testAddress(), addr)
+	}
+
+	invalid := []byte(testMigrationAddress[:legacy.HashTrytesSize])
+	invalid[10] = 'A'
+	_, err := migration.ParseAddress(string(invalid))
+	assert.ErrorIs(t, err, migration.ErrInvalidAddress)
+
+	_, err = migration.ParseAddress(testMigrationAddress[:legacy.HashTrytesSize-1] + "X")
+	assert.ErrorIs(t, err, migration.ErrInvalidAddress)
+}
+
+func TestNewBundle(t *testing.T) {
+	const seed = "ZLNM9UHJWKTTDEZOTH9CXDEIFUJQCIACDPJIXPOWBDW9LTBHC9AQRIXTIHYLIIURLZCXNSTGNIVC9ISVB"
+	inputs, err := migration.Inputs(seed, 2, 0, 1, 2)
+	require.NoError(t, err)
+	inputs[0].Balance = 1000000
+	inputs[2].Balance = 2000000
+
+	bundle, err := migration.NewBundle(testAddress(), inputs, 1600000000)
+	require.NoError(t, err)
+	require.Len(t, bundle, 1+2*2)
+
+	assert.Equal(t, migration.Address(testAddress()), bundle[0].Address)
+	assert.EqualValues(t, 3000000, bundle[0].Value)
+	var sum int64
+	for _, tx := range bundle {
+		sum += tx.Value
+	}
+	assert.Zero(t, sum)
+	assert.Equal(t, inputs[0].Address, bundle[1].Address)
+	assert.Equal(t, inputs[2].Address, bundle[3].Address)
+
+	_, err = migration.NewBundle(testAddress(), inputs[1:2], 1600000000)
+	assert.ErrorIs(t, err, migration.ErrNoInputs)
+}
+
+func TestSign(t *testing.T) {
+	const seed = "ZLNM9UHJWKTTDEZOTH9CXDEIFUJQCIACDPJIXPOWBDW9LTBHC9AQRIXTIHYLIIURLZCXNSTGNIVC9ISVB"
+	inputs, err := migration.Inputs(seed, 2, 0, 1)
+	require.NoError(t, err)
+	inputs[0].Balance = 1000000
+	inputs[1].Balance = 5
+
+	bundle, err := migration.NewBundle(testAddress(), inputs, 1600000000)
+	require.NoError(t, err)
+	assert.False(t, bundle.VerifySignatures())
+
+	require.NoError(t, migration.Sign(bundle, seed, inputs))
+	assert.True(t, bundle.VerifySignatures())
+
+	// signing an input with the key of a different seed must not produce a valid signature
+	other := "A" + seed[1:]
+	otherInputs := []*migration.Input{{Address: inputs[1].Address, Index: 1, Security: 2, Balance: 5}}
+	require.NoError(t, migration.Sign(bundle, other, otherInputs))
+	assert.False(t, bundle.VerifySignatures())
+}
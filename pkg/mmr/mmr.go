@@ -0,0 +1,185 @@
+/*
+Package mmr implements an append-only Merkle Mountain Range with inclusion proofs.
+
+A Merkle Mountain Range is a list of perfect Merkle trees, the peaks, of strictly decreasing height; appending a leaf
+merges the peaks of equal height. The peaks are bagged from right to left into a single hash, which is committed
+together with the number of leaves to the compact root.
+
+The leaf and node hashes use the same domain separation as the merkle package, the root commitment an additional
+prefix, so that the different hash types can never collide.
+*/
+package mmr
+
+import (
+	"bytes"
+	"crypto"
+	"encoding/binary"
+	"errors"
+	"math/bits"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/merkle"
+)
+
+// RootHashPrefix is the domain separation prefix of the root commitment.
+const RootHashPrefix = 2
+
+// ErrIndexOutOfRange is returned when the leaf index of a proof is outside the range.
+var ErrIndexOutOfRange = errors.New("leaf index out of range")
+
+// MMR is an append-only Merkle Mountain Range.
+type MMR struct {
+	hash crypto.Hash
+	size uint64
+	// levels[h][i] is the root of the i-th perfect subtree of height h.
+	levels [][][]byte
+}
+
+// Proof is the inclusion proof of a leaf in an MMR of a given size.
+type Proof struct {
+	// LeafIndex is the index of the proven leaf.
+	LeafIndex uint64
+	// Size is the number of leaves of the MMR.
+	Size uint64
+	// Path is the audit path from the leaf to its peak.
+	Path merkle.Proof
+	// Peaks contains all peaks of the MMR in decreasing height.
+	Peaks [][]byte
+}
+
+// New creates a new empty MMR using the provided hash function.
+func New(h crypto.Hash) *MMR {
+	return &MMR{hash: h}
+}
+
+// Size returns the number of leaves.
+func (m *MMR) Size() uint64 {
+	return m.size
+}
+
+// Append appends data as the next leaf and returns its index.
+func (m *MMR) Append(data []byte) uint64 {
+	h := hashLeaf(m.hash, data)
+	for height := 0; ; height++ {
+		if height == len(m.levels) {
+			m.levels = append(m.levels, nil)
+		}
+		m.levels[height] = append(m.levels[height], h)
+		// a new node is complete whenever the level contains an even number of nodes
+		n := len(m.levels[height])
+		if n%2 == 1 {
+			break
+		}
+		h = hashNode(m.hash, m.levels[height][n-2], h)
+	}
+	m.size++
+	return m.size - 1
+}
+
+// Peaks returns the peaks in decreasing height.
+func (m *MMR) Peaks() [][]byte {
+	var peaks [][]byte
+	var offset uint64
+	for height := len(m.levels) - 1; height >= 0; height-- {
+		if m.size&(1<<height) != 0 {
+			peaks = append(peaks, m.levels[height][offset>>height])
+			offset += 1 << height
+		}
+	}
+	return peaks
+}
+
+// Root returns the compact root commitment to all leaves.
+func (m *MMR) Root() []byte {
+	return rootOf(m.hash, m.size, m.Peaks())
+}
+
+// Proof returns the inclusion proof of the leaf with the given index.
+func (m *MMR) Proof(leafIndex uint64) (*Proof, error) {
+	if leafIndex >= m.size {
+		return nil, ErrIndexOutOfRange
+	}
+	height, _ := peakOf(m.size, leafIndex)
+	var path merkle.Proof
+	for level := 0; level < height; level++ {
+		i := leafIndex >> level
+		sibling := i ^ 1
+		path = append(path, merkle.ProofStep{Hash: m.levels[level][sibling], Left: sibling < i})
+	}
+	return &Proof{LeafIndex: leafIndex, Size: m.size, Path: path, Peaks: m.Peaks()}, nil
+}
+
+// VerifyProof checks whether proof proves the inclusion of data in the MMR with the given root using hash function h.
+func VerifyProof(h crypto.Hash, root []byte, proof *Proof, data []byte) bool {
+	if proof.LeafIndex >= proof.Size || len(proof.Peaks) != bits.OnesCount64(proof.Size) {
+		return false
+	}
+	height, peakIndex := peakOf(proof.Size, proof.LeafIndex)
+	if len(proof.Path) != height {
+		return false
+	}
+
+	node := hashLeaf(h, data)
+	for level, step := range proof.Path {
+		// the position of the node within its level determines the side of the sibling
+		if step.Left != ((proof.LeafIndex>>level)&1 == 1) {
+			return false
+		}
+		if step.Left {
+			node = hashNode(h, step.Hash, node)
+		} else {
+			node = hashNode(h, node, step.Hash)
+		}
+	}
+	if !bytes.Equal(node, proof.Peaks[peakIndex]) {
+		return false
+	}
+	return bytes.Equal(root, rootOf(h, proof.Size, proof.Peaks))
+}
+
+// peakOf returns the height and the index of the peak containing the leaf with the given index.
+func peakOf(size, leafIndex uint64) (int, int) {
+	var offset uint64
+	peakIndex := 0
+	for height := bits.Len64(size) - 1; height >= 0; height-- {
+		if size&(1<<height) == 0 {
+			continue
+		}
+		if leafIndex < offset+1<<height {
+			return height, peakIndex
+		}
+		offset += 1 << height
+		peakIndex++
+	}
+	panic("mmr: leaf index out of range")
+}
+
+// rootOf bags the peaks from right to left and commits the result together with the size.
+func rootOf(h crypto.Hash, size uint64, peaks [][]byte) []byte {
+	var bag []byte
+	if len(peaks) > 0 {
+		bag = peaks[len(peaks)-1]
+		for i := len(peaks) - 2; i >= 0; i-- {
+			bag = hashNode(h, peaks[i], bag)
+		}
+	}
+	d := h.New()
+	d.Write([]byte{RootHashPrefix})
+	_ = binary.Write(d, binary.BigEndian, size)
+	d.Write(bag)
+	return d.Sum(nil)
+}
+
+func hashLeaf(h crypto.Hash, data []byte) []byte {
+	d := h.New()
+	d.Write([]byte{merkle.LeafHashPrefix})
+	d.Write(data)
+	return d.Sum(nil)
+}
+
+func hashNode(h crypto.Hash, l, r []byte) []byte {
+	d := h.New()
+	d.Write([]byte{merkle.NodeHashPrefix})
+	d.Write(l)
+	d.Write(r)
+	return d.Sum(nil)
+}
@@ -0,0 +1,74 @@
+package mmr_test
+
+import (
+	"crypto"
+	"encoding"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/merkle"
+	"github.com/iotaledger/iota-crypto-demo/pkg/mmr"
+	_ "golang.org/x/crypto/blake2b" // BLAKE2b_256 is the default hashing algorithm
+)
+
+type leaf []byte
+
+func (l leaf) MarshalBinary() ([]byte, error) { return l, nil }
+
+func TestPeaks(t *testing.T) {
+	m := mmr.New(crypto.BLAKE2b_256)
+	hasher := merkle.NewHasher(crypto.BLAKE2b_256)
+
+	var leaves []encoding.BinaryMarshaler
+	for i := 0; i < 16; i++ {
+		leaves = append(leaves, leaf{byte(i)})
+		m.Append([]byte{byte(i)})
+	}
+	// with a power of two number of leaves, the single peak is the plain Merkle tree hash
+	peaks := m.Peaks()
+	require.Len(t, peaks, 1)
+	expected, err := hasher.Hash(leaves)
+	require.NoError(t, err)
+	assert.Equal(t, expected, peaks[0])
+
+	// the peaks of 13 leaves are the Merkle trees of 8, 4 and 1 leaves
+	m = mmr.New(crypto.BLAKE2b_256)
+	for i := 0; i < 13; i++ {
+		m.Append([]byte{byte(i)})
+	}
+	peaks = m.Peaks()
+	require.Len(t, peaks, 3)
+	for i, r := range [][2]int{{0, 8}, {8, 12}, {12, 13}} {
+		expected, err := hasher.Hash(leaves[r[0]:r[1]])
+		require.NoError(t, err)
+		assert.Equal(t, expected, peaks[i])
+	}
+}
+
+func TestProof(t *testing.T) {
+	m := mmr.New(crypto.BLAKE2b_256)
+	var roots [][]byte
+	for size := uint64(1); size <= 20; size++ {
+		assert.Equal(t, size-1, m.Append([]byte{byte(size - 1)}))
+		root := m.Root()
+		for _, r := range roots {
+			assert.NotEqual(t, r, root)
+		}
+		roots = append(roots, root)
+
+		for i := uint64(0); i < size; i++ {
+			proof, err := m.Proof(i)
+			require.NoError(t, err)
+			assert.True(t, mmr.VerifyProof(crypto.BLAKE2b_256, root, proof, []byte{byte(i)}), "size %d, index %d", size, i)
+			assert.False(t, mmr.VerifyProof(crypto.BLAKE2b_256, root, proof, []byte{0xff}), "size %d, index %d", size, i)
+
+			proof.Size++
+			assert.False(t, mmr.VerifyProof(crypto.BLAKE2b_256, root, proof, []byte{byte(i)}), "size %d, index %d", size, i)
+		}
+	}
+
+	_, err := m.Proof(m.Size())
+	assert.ErrorIs(t, err, mmr.ErrIndexOutOfRange)
+}
@@ -0,0 +1,358 @@
+/*
+Package mnemonicfile implements a small, versioned file format storing a BIP-39 mnemonic encrypted with a password.
+
+In contrast to the keystore package, which stores raw seeds and keys, the file contains the mnemonic itself, so that it
+can be restored with any wallet and combined with different passphrases. A file consists of the following fields,
+where all integers are big-endian:
+
+	magic       8 bytes  "IOTAMNEM"
+	version     1 byte   1
+	kdf         1 byte   1 for scrypt, 2 for Argon2id
+	params     12 bytes  three uint32: log2(N), r and p for scrypt; time, memory in KiB and threads for Argon2id
+	salt       16 bytes
+	nonce      24 bytes
+	ciphertext           XChaCha20-Poly1305 encryption of the mnemonic, authenticating all preceding fields
+	checksum    4 bytes  first bytes of the SHA-256 hash of all preceding fields
+
+The checksum detects corrupted files without the password and before the expensive key derivation, while the
+authentication tag of the ciphertext detects wrong passwords and any manipulation. Files are usually stored ASCII
+armored, i.e. Base64 encoded between header and footer lines.
+*/
+package mnemonicfile
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/iotaledger/iota-crypto-demo/internal/rand"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip39"
+)
+
+// Version is the version of the file format.
+const Version = 1
+
+// KDF is a key derivation function deriving the encryption key from the password.
+type KDF byte
+
+// Supported key derivation functions.
+const (
+	// Scrypt denotes the scrypt key derivation function.
+	Scrypt KDF = 1
+	// Argon2id denotes the Argon2id key derivation function.
+	Argon2id KDF = 2
+)
+
+func (k KDF) String() string {
+	switch k {
+	case Scrypt:
+		return "scrypt"
+	case Argon2id:
+		return "argon2id"
+	default:
+		return fmt.Sprintf("KDF(%d)", byte(k))
+	}
+}
+
+const (
+	magic        = "IOTAMNEM"
+	saltSize     = 16
+	checksumSize = 4
+	headerSize   = len(magic) + 1 + 1 + 12 + saltSize + chacha20poly1305.NonceSizeX
+
+	armorHeader = "-----BEGIN IOTA ENCRYPTED MNEMONIC-----"
+	armorFooter = "-----END IOTA ENCRYPTED MNEMONIC-----"
+	columns     = 64
+)
+
+// default and maximum parameters of the key derivation functions
+const (
+	defaultScryptLogN   = 18
+	defaultArgon2Memory = 1 << 16 // in KiB
+	defaultArgon2Time   = 3
+
+	maxScryptLogN   = 30
+	maxScryptRP     = 1 << 8
+	maxScryptWork   = 1 << 32 // 128⋅N⋅r⋅p, the bytes processed by the mixing function
+	maxArgon2Time   = 1 << 4
+	maxArgon2Memory = 1 << 22 // 4 GiB in KiB
+	maxArgon2Work   = 1 << 24 // time⋅memory in KiB
+)
+
+var (
+	// ErrInvalidFormat is returned when the data is not an encrypted mnemonic file.
+	ErrInvalidFormat = errors.New("invalid format")
+	// ErrUnsupportedVersion is returned when a file has an unknown version.
+	ErrUnsupportedVersion = errors.New("unsupported version")
+	// ErrInvalidParams is returned when the KDF parameters are unknown or out of range.
+	ErrInvalidParams = errors.New("invalid parameters")
+	// ErrInvalidChecksum is returned when the checksum does not match, i.e. the file is corrupted.
+	ErrInvalidChecksum = errors.New("invalid checksum")
+	// ErrInvalidPassword is returned when the ciphertext cannot be authenticated, i.e. the password is wrong.
+	ErrInvalidPassword = errors.New("invalid password")
+)
+
+// Options specifies how a mnemonic is encrypted.
+// Zero values select the defaults, i.e. scrypt with N = 2¹⁸, r = 8 and p = 1.
+type Options struct {
+	// KDF is the key derivation function.
+	KDF KDF
+	// Cost is log2 of the scrypt N or the Argon2id memory in KiB.
+	Cost uint32
+}
+
+// Header contains the unencrypted parameters of a file.
+type Header struct {
+	Version int
+	KDF     KDF
+	// Params are log2(N), r and p for scrypt or time, memory in KiB and threads for Argon2id.
+	Params [3]uint32
+	Salt   []byte
+	Nonce  []byte
+}
+
+// Encrypt encrypts mnemonic with password and returns the binary file.
+// The mnemonic must be valid for the current word list. The salt and nonce are read from rand.
+func Encrypt(rand io.Reader, mnemonic bip39.Mnemonic, password string, opts *Options) ([]byte, error) {
+	if _, err := bip39.MnemonicToEntropy(mnemonic); err != nil {
+		return nil, err
+	}
+	if opts == nil {
+		opts = &Options{}
+	}
+	h := &Header{Version: Version, KDF: opts.KDF}
+	switch opts.KDF {
+	case 0, Scrypt:
+		h.KDF = Scrypt
+		h.Params = [3]uint32{orDefault(opts.Cost, defaultScryptLogN), 8, 1}
+	case Argon2id:
+		h.Params = [3]uint32{defaultArgon2Time, orDefault(opts.Cost, defaultArgon2Memory), 1}
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrInvalidParams, opts.KDF)
+	}
+	if err := h.check(); err != nil {
+		return nil, err
+	}
+	h.Salt = make([]byte, saltSize)
+	h.Nonce = make([]byte, chacha20poly1305.NonceSizeX)
+	if _, err := io.ReadFull(rand, h.Salt); err != nil {
+		return nil, err
+	}
+	if _, err := io.ReadFull(rand, h.Nonce); err != nil {
+		return nil, err
+	}
+
+	key, err := h.deriveKey(password)
+	if err != nil {
+		return nil, err
+	}
+	defer clear(key)
+	aead, _ := chacha20poly1305.NewX(key)
+	plaintext := []byte(mnemonic.String())
+	defer clear(plaintext)
+
+	data := h.append(make([]byte, 0, headerSize+len(plaintext)+aead.Overhead()+checksumSize))
+	data = aead.Seal(data, h.Nonce, plaintext, data)
+	return append(data, checksum(data)...), nil
+}
+
+// Decrypt decrypts the mnemonic of the binary file data with password.
+func Decrypt(data []byte, password string) (bip39.Mnemonic, error) {
+	h, err := ParseHeader(data)
+	if err != nil {
+		return nil, err
+	}
+	key, err := h.deriveKey(password)
+	if err != nil {
+		return nil, err
+	}
+	defer clear(key)
+	aead, _ := chacha20poly1305.NewX(key)
+	plaintext, err := aead.Open(nil, h.Nonce, data[headerSize:len(data)-checksumSize], data[:headerSize])
+	if err != nil {
+		return nil, ErrInvalidPassword
+	}
+	defer clear(plaintext)
+	return bip39.ParseMnemonic(string(plaintext)), nil
+}
+
+// ParseHeader verifies the checksum of the binary file data and returns its unencrypted header.
+func ParseHeader(data []byte) (*Header, error) {
+	if len(data) < headerSize+chacha20poly1305.Overhead+checksumSize || string(data[:len(magic)]) != magic {
+		return nil, ErrInvalidFormat
+	}
+	body, sum := data[:len(data)-checksumSize], data[len(data)-checksumSize:]
+	if !bytes.Equal(sum, checksum(body)) {
+		return nil, ErrInvalidChecksum
+	}
+	b := data[len(magic):]
+	if b[0] != Version {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, b[0])
+	}
+	h := &Header{Version: int(b[0]), KDF: KDF(b[1])}
+	b = b[2:]
+	for i := range h.Params {
+		h.Params[i] = binary.BigEndian.Uint32(b[4*i:])
+	}
+	b = b[12:]
+	h.Salt = append([]byte{}, b[:saltSize]...)
+	h.Nonce = append([]byte{}, b[saltSize:saltSize+chacha20poly1305.NonceSizeX]...)
+	if err := h.check(); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// Armor returns the ASCII armored form of the binary file, i.e. its padded Base64 encoding wrapped at 64 columns
+// between header and footer lines.
+func Armor(data []byte) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(armorHeader + "\n")
+	s := base64.StdEncoding.EncodeToString(data)
+	for len(s) > columns {
+		buf.WriteString(s[:columns] + "\n")
+		s = s[columns:]
+	}
+	if s != "" {
+		buf.WriteString(s + "\n")
+	}
+	buf.WriteString(armorFooter + "\n")
+	return buf.Bytes()
+}
+
+// Dearmor returns the binary file of the ASCII armored data.
+// Leading and trailing whitespace is ignored.
+func Dearmor(armored []byte) ([]byte, error) {
+	lines := strings.Split(strings.TrimSpace(strings.ReplaceAll(string(armored), "\r\n", "\n")), "\n")
+	if len(lines) < 2 || lines[0] != armorHeader || lines[len(lines)-1] != armorFooter {
+		return nil, ErrInvalidFormat
+	}
+	body := lines[1 : len(lines)-1]
+	for i, line := range body {
+		// all lines but the last must be full
+		if len(line) > columns || (i < len(body)-1 && len(line) != columns) {
+			return nil, ErrInvalidFormat
+		}
+	}
+	data, err := base64.StdEncoding.Strict().DecodeString(strings.Join(body, ""))
+	if err != nil {
+		return nil, ErrInvalidFormat
+	}
+	return data, nil
+}
+
+// Write encrypts mnemonic with password and writes the armored file to w.
+func Write(w io.Writer, mnemonic bip39.Mnemonic, password string, opts *Options) error {
+	data, err := Encrypt(rand.Reader, mnemonic, password, opts)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(Armor(data))
+	return err
+}
+
+// Read reads an armored file from r and decrypts its mnemonic with password.
+func Read(r io.Reader, password string) (bip39.Mnemonic, error) {
+	armored, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	data, err := Dearmor(armored)
+	if err != nil {
+		return nil, err
+	}
+	return Decrypt(data, password)
+}
+
+// WriteFile encrypts mnemonic with password and writes the armored file to the file name.
+func WriteFile(name string, mnemonic bip39.Mnemonic, password string, opts *Options) error {
+	var buf bytes.Buffer
+	if err := Write(&buf, mnemonic, password, opts); err != nil {
+		return err
+	}
+	return os.WriteFile(name, buf.Bytes(), 0o600)
+}
+
+// ReadFile reads the armored file name and decrypts its mnemonic with password.
+func ReadFile(name, password string) (bip39.Mnemonic, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Read(f, password)
+}
+
+// check validates the KDF parameters. Both the memory and the total work of the key derivation are bounded, so that
+// decrypting a malicious file cannot exhaust the resources.
+func (h *Header) check() error {
+	switch h.KDF {
+	case Scrypt:
+		logN, r, p := h.Params[0], h.Params[1], h.Params[2]
+		if logN < 1 || logN > maxScryptLogN || r < 1 || p < 1 || uint64(r)*uint64(p) > maxScryptRP ||
+			128*uint64(r)*uint64(p)<<logN > maxScryptWork {
+			return fmt.Errorf("%w: scrypt N=2^%d, r=%d, p=%d", ErrInvalidParams, logN, r, p)
+		}
+	case Argon2id:
+		t, memory, threads := h.Params[0], h.Params[1], h.Params[2]
+		if t < 1 || t > maxArgon2Time || threads < 1 || threads > 0xff || memory < 8*threads ||
+			memory > maxArgon2Memory || uint64(t)*uint64(memory) > maxArgon2Work {
+			return fmt.Errorf("%w: argon2id t=%d, m=%d, p=%d", ErrInvalidParams, t, memory, threads)
+		}
+	default:
+		return fmt.Errorf("%w: %s", ErrInvalidParams, h.KDF)
+	}
+	return nil
+}
+
+// deriveKey derives the encryption key from the NFKD normalized password.
+func (h *Header) deriveKey(password string) ([]byte, error) {
+	pw := []byte(norm.NFKD.String(password))
+	defer clear(pw)
+	switch h.KDF {
+	case Scrypt:
+		key, err := scrypt.Key(pw, h.Salt, 1<<h.Params[0], int(h.Params[1]), int(h.Params[2]), chacha20poly1305.KeySize)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidParams, err)
+		}
+		return key, nil
+	case Argon2id:
+		return argon2.IDKey(pw, h.Salt, h.Params[0], h.Params[1], uint8(h.Params[2]), chacha20poly1305.KeySize), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrInvalidParams, h.KDF)
+	}
+}
+
+// append appends the encoded header to b.
+func (h *Header) append(b []byte) []byte {
+	b = append(b, magic...)
+	b = append(b, byte(h.Version), byte(h.KDF))
+	for _, p := range h.Params {
+		b = binary.BigEndian.AppendUint32(b, p)
+	}
+	b = append(b, h.Salt...)
+	return append(b, h.Nonce...)
+}
+
+func checksum(data []byte) []byte {
+	sum := sha256.Sum256(data)
+	return sum[:checksumSize]
+}
+
+func orDefault(v, def uint32) uint32 {
+	if v > 0 {
+		return v
+	}
+	return def
+}
@@ -0,0 +1,167 @@
+//nolint:scopelint // from tests
+package mnemonicfile_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/internal/rand"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip39"
+	"github.com/iotaledger/iota-crypto-demo/pkg/mnemonicfile"
+)
+
+var mnemonic = bip39.ParseMnemonic("legal winner thank year wave sausage worth useful legal winner thank yellow")
+
+// options with low cost parameters to speed up the tests
+var tests = []*struct {
+	name string
+	opts *mnemonicfile.Options
+}{
+	{"scrypt", &mnemonicfile.Options{KDF: mnemonicfile.Scrypt, Cost: 10}},
+	{"argon2id", &mnemonicfile.Options{KDF: mnemonicfile.Argon2id, Cost: 64}},
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := mnemonicfile.Encrypt(rand.Reader, mnemonic, "password", tt.opts)
+			require.NoError(t, err)
+			assert.True(t, bytes.HasPrefix(data, []byte("IOTAMNEM\x01")))
+
+			h, err := mnemonicfile.ParseHeader(data)
+			require.NoError(t, err)
+			assert.Equal(t, mnemonicfile.Version, h.Version)
+			assert.Equal(t, tt.opts.KDF, h.KDF)
+
+			decrypted, err := mnemonicfile.Decrypt(data, "password")
+			require.NoError(t, err)
+			assert.Equal(t, mnemonic, decrypted)
+
+			_, err = mnemonicfile.Decrypt(data, "wrong")
+			assert.ErrorIs(t, err, mnemonicfile.ErrInvalidPassword)
+		})
+	}
+}
+
+func TestPasswordNormalization(t *testing.T) {
+	data, err := mnemonicfile.Encrypt(rand.Reader, mnemonic, "é", tests[0].opts)
+	require.NoError(t, err)
+	// the decomposed form is accepted as well
+	decrypted, err := mnemonicfile.Decrypt(data, "é")
+	require.NoError(t, err)
+	assert.Equal(t, mnemonic, decrypted)
+}
+
+func TestReadWrite(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, mnemonicfile.Write(&buf, mnemonic, "password", tests[0].opts))
+	armored := buf.String()
+	assert.True(t, strings.HasPrefix(armored, "-----BEGIN IOTA ENCRYPTED MNEMONIC-----\n"))
+	assert.True(t, strings.HasSuffix(armored, "-----END IOTA ENCRYPTED MNEMONIC-----\n"))
+
+	decrypted, err := mnemonicfile.Read(strings.NewReader("\r\n"+strings.ReplaceAll(armored, "\n", "\r\n")), "password")
+	require.NoError(t, err)
+	assert.Equal(t, mnemonic, decrypted)
+
+	name := filepath.Join(t.TempDir(), "mnemonic.txt")
+	require.NoError(t, mnemonicfile.WriteFile(name, mnemonic, "password", tests[1].opts))
+	decrypted, err = mnemonicfile.ReadFile(name, "password")
+	require.NoError(t, err)
+	assert.Equal(t, mnemonic, decrypted)
+}
+
+func TestInvalid(t *testing.T) {
+	data, err := mnemonicfile.Encrypt(rand.Reader, mnemonic, "password", tests[0].opts)
+	require.NoError(t, err)
+
+	_, err = mnemonicfile.Encrypt(rand.Reader, bip39.ParseMnemonic("legal winner"), "password", nil)
+	assert.Error(t, err)
+	_, err = mnemonicfile.Encrypt(rand.Reader, mnemonic, "password", &mnemonicfile.Options{KDF: 3})
+	assert.ErrorIs(t, err, mnemonicfile.ErrInvalidParams)
+	_, err = mnemonicfile.Encrypt(rand.Reader, mnemonic, "password", &mnemonicfile.Options{Cost: 31})
+	assert.ErrorIs(t, err, mnemonicfile.ErrInvalidParams)
+
+	var tests = []*struct {
+		name string
+		data []byte
+		err  error
+	}{
+		{"empty", nil, mnemonicfile.ErrInvalidFormat},
+		{"magic", modify(data, 0, 'X'), mnemonicfile.ErrInvalidFormat},
+		{"checksum", modify(data, len(data)-1, data[len(data)-1]^1), mnemonicfile.ErrInvalidChecksum},
+		{"corrupted", modify(data, 60, data[60]^1), mnemonicfile.ErrInvalidChecksum},
+		{"version", withChecksum(modify(data, 8, 2)), mnemonicfile.ErrUnsupportedVersion},
+		{"kdf", withChecksum(modify(data, 9, 3)), mnemonicfile.ErrInvalidParams},
+		{"cost", withChecksum(modify(data, 13, 31)), mnemonicfile.ErrInvalidParams},
+		// a valid checksum does not protect against manipulation
+		{"salt", withChecksum(modify(data, 30, data[30]^1)), mnemonicfile.ErrInvalidPassword},
+		{"ciphertext", withChecksum(modify(data, 70, data[70]^1)), mnemonicfile.ErrInvalidPassword},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := mnemonicfile.Decrypt(tt.data, "password")
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+
+	_, err = mnemonicfile.Dearmor([]byte("-----BEGIN IOTA ENCRYPTED MNEMONIC-----\n!!\n-----END IOTA ENCRYPTED MNEMONIC-----"))
+	assert.ErrorIs(t, err, mnemonicfile.ErrInvalidFormat)
+	_, err = mnemonicfile.Dearmor(mnemonicfile.Armor(data)[1:])
+	assert.ErrorIs(t, err, mnemonicfile.ErrInvalidFormat)
+}
+
+func TestDecryptExcessiveParams(t *testing.T) {
+	scrypt, argon2id := tests[0].opts, tests[1].opts
+	var tests = []*struct {
+		name   string
+		opts   *mnemonicfile.Options
+		params [3]uint32
+	}{
+		{"argon2id time", argon2id, [3]uint32{1<<32 - 1, 8, 1}},
+		{"argon2id work", argon2id, [3]uint32{16, 1 << 22, 1}},
+		{"argon2id memory", argon2id, [3]uint32{1, 1<<22 + 1, 1}},
+		{"argon2id threads", argon2id, [3]uint32{1, 1 << 20, 256}},
+		{"scrypt p", scrypt, [3]uint32{1, 1, 1 << 29}},
+		{"scrypt r", scrypt, [3]uint32{1, 1 << 29, 1}},
+		{"scrypt work", scrypt, [3]uint32{20, 16, 16}},
+		{"scrypt N", scrypt, [3]uint32{31, 1, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := mnemonicfile.Encrypt(rand.Reader, mnemonic, "password", tt.opts)
+			require.NoError(t, err)
+			data = withChecksum(withParams(data, tt.params))
+
+			_, err = mnemonicfile.Decrypt(data, "password")
+			assert.ErrorIs(t, err, mnemonicfile.ErrInvalidParams)
+		})
+	}
+}
+
+// withParams replaces the KDF parameters of data.
+func withParams(data []byte, params [3]uint32) []byte {
+	res := append([]byte{}, data...)
+	for i, p := range params {
+		binary.BigEndian.PutUint32(res[10+4*i:], p)
+	}
+	return res
+}
+
+func modify(data []byte, i int, b byte) []byte {
+	res := append([]byte{}, data...)
+	res[i] = b
+	return res
+}
+
+// withChecksum recomputes the checksum of data, so that the decoding continues.
+func withChecksum(data []byte) []byte {
+	sum := sha256.Sum256(data[:len(data)-4])
+	return append(data[:len(data)-4], sum[:4]...)
+}
@@ -0,0 +1,130 @@
+/*
+Package multikey implements the encoding of public keys as multicodec prefixed values in multibase, as used by DIDs
+and IPFS-adjacent tooling.
+
+A key is prefixed with the unsigned varint of its multicodec identifier and encoded using a multibase, i.e. a single
+character identifying the base followed by the encoded data. Supported bases are base58btc and lowercase unpadded
+base32. Decode detects both the base and the key type.
+*/
+package multikey
+
+import (
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/base58"
+)
+
+// Codec is the multicodec identifier of a public key type.
+type Codec uint64
+
+// Supported public key types.
+const (
+	Ed25519   Codec = 0xED
+	X25519    Codec = 0xEC
+	Secp256k1 Codec = 0xE7   // compressed
+	P256      Codec = 0x1200 // compressed
+	BLS12381  Codec = 0xEB   // G2 public key
+)
+
+var codecs = map[Codec]struct {
+	name string
+	size int
+}{
+	Ed25519:   {"ed25519-pub", 32},
+	X25519:    {"x25519-pub", 32},
+	Secp256k1: {"secp256k1-pub", 33},
+	P256:      {"p256-pub", 33},
+	BLS12381:  {"bls12_381-g2-pub", 96},
+}
+
+// String returns the multicodec name of c.
+func (c Codec) String() string {
+	if info, ok := codecs[c]; ok {
+		return info.name
+	}
+	return fmt.Sprintf("Codec(0x%x)", uint64(c))
+}
+
+// KeySize returns the size, in bytes, of public keys of type c, or 0 if c is unsupported.
+func (c Codec) KeySize() int {
+	return codecs[c].size
+}
+
+// Base identifies a multibase encoding by its prefix character.
+type Base byte
+
+// Supported multibase encodings.
+const (
+	Base58BTC Base = 'z'
+	Base32    Base = 'b'
+)
+
+var base32Encoding = base32.NewEncoding("abcdefghijklmnopqrstuvwxyz234567").WithPadding(base32.NoPadding)
+
+var (
+	// ErrUnsupportedCodec is returned when the key type is not supported.
+	ErrUnsupportedCodec = errors.New("unsupported codec")
+	// ErrUnsupportedBase is returned when the multibase encoding is not supported.
+	ErrUnsupportedBase = errors.New("unsupported base")
+	// ErrInvalidKey is returned when the key length does not match its type.
+	ErrInvalidKey = errors.New("invalid key")
+)
+
+// Encode returns the multibase encoding of key prefixed with codec.
+func Encode(codec Codec, key []byte, base Base) (string, error) {
+	size := codec.KeySize()
+	if size == 0 {
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedCodec, codec)
+	}
+	if len(key) != size {
+		return "", fmt.Errorf("%w: invalid %s length %d", ErrInvalidKey, codec, len(key))
+	}
+	b := append(binary.AppendUvarint(nil, uint64(codec)), key...)
+	switch base {
+	case Base58BTC:
+		return string(base) + base58.Encode(b), nil
+	case Base32:
+		return string(base) + base32Encoding.EncodeToString(b), nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnsupportedBase, base)
+	}
+}
+
+// Decode decodes the multibase encoded key s and returns its type and the raw key.
+func Decode(s string) (Codec, []byte, error) {
+	if len(s) == 0 {
+		return 0, nil, fmt.Errorf("%w: empty string", ErrUnsupportedBase)
+	}
+	var (
+		b   []byte
+		err error
+	)
+	switch Base(s[0]) {
+	case Base58BTC:
+		b, err = base58.Decode(s[1:])
+	case Base32:
+		b, err = base32Encoding.DecodeString(s[1:])
+	default:
+		return 0, nil, fmt.Errorf("%w: %q", ErrUnsupportedBase, s[0])
+	}
+	if err != nil {
+		return 0, nil, fmt.Errorf("%w: %w", ErrInvalidKey, err)
+	}
+
+	c, n := binary.Uvarint(b)
+	if n <= 0 {
+		return 0, nil, fmt.Errorf("%w: invalid multicodec prefix", ErrInvalidKey)
+	}
+	codec := Codec(c)
+	size := codec.KeySize()
+	if size == 0 {
+		return 0, nil, fmt.Errorf("%w: %s", ErrUnsupportedCodec, codec)
+	}
+	if len(b)-n != size {
+		return 0, nil, fmt.Errorf("%w: invalid %s length %d", ErrInvalidKey, codec, len(b)-n)
+	}
+	return codec, b[n:], nil
+}
@@ -0,0 +1,77 @@
+//nolint:scopelint
+package multikey_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/internal/hexutil"
+	"github.com/iotaledger/iota-crypto-demo/pkg/base58"
+	"github.com/iotaledger/iota-crypto-demo/pkg/multikey"
+)
+
+func TestEncode(t *testing.T) {
+	var tests = []*struct {
+		codec  multikey.Codec
+		key    []byte
+		prefix string
+	}{
+		// the did:key test vector of the all-zero Ed25519 seed
+		{multikey.Ed25519, hexutil.MustDecodeString("3b6a27bcceb6a42d62a3a8d02a6f0d73653215771de243a63ac048a18b59da29"), "z6MkiTBz1ymuepAQ4HEHYSF1H8quG5GLVVQR3djdX3mDooWp"},
+		// characteristic prefixes of the other key types
+		{multikey.X25519, bytes.Repeat([]byte{0x42}, 32), "z6LS"},
+		{multikey.Secp256k1, append([]byte{0x02}, bytes.Repeat([]byte{0x42}, 32)...), "zQ3s"},
+		{multikey.P256, append([]byte{0x03}, bytes.Repeat([]byte{0x42}, 32)...), "zDn"},
+		{multikey.BLS12381, bytes.Repeat([]byte{0x42}, 96), "zUC"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.codec.String(), func(t *testing.T) {
+			for _, base := range []multikey.Base{multikey.Base58BTC, multikey.Base32} {
+				s, err := multikey.Encode(tt.codec, tt.key, base)
+				require.NoError(t, err)
+				assert.Equal(t, byte(base), s[0])
+				if base == multikey.Base58BTC {
+					assert.Contains(t, s, tt.prefix)
+					assert.Equal(t, tt.prefix, s[:len(tt.prefix)])
+				}
+
+				codec, key, err := multikey.Decode(s)
+				require.NoError(t, err)
+				assert.Equal(t, tt.codec, codec)
+				assert.Equal(t, tt.key, key)
+			}
+		})
+	}
+}
+
+func TestEncodeInvalid(t *testing.T) {
+	_, err := multikey.Encode(multikey.Ed25519, make([]byte, 31), multikey.Base58BTC)
+	assert.ErrorIs(t, err, multikey.ErrInvalidKey)
+	_, err = multikey.Encode(0x55, make([]byte, 32), multikey.Base58BTC)
+	assert.ErrorIs(t, err, multikey.ErrUnsupportedCodec)
+	_, err = multikey.Encode(multikey.Ed25519, make([]byte, 32), 'f')
+	assert.ErrorIs(t, err, multikey.ErrUnsupportedBase)
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	var tests = []*struct {
+		s   string
+		err error
+	}{
+		{"", multikey.ErrUnsupportedBase},
+		{"f00", multikey.ErrUnsupportedBase},
+		{"z0OIl", multikey.ErrInvalidKey},
+		{"b1", multikey.ErrInvalidKey},
+		{"z" + base58.Encode(append([]byte{0xED, 0x01}, make([]byte, 31)...)), multikey.ErrInvalidKey},
+		{"z2", multikey.ErrUnsupportedCodec},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			_, _, err := multikey.Decode(tt.s)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
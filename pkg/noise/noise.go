@@ -0,0 +1,324 @@
+/*
+Package noise derives static X25519 keys for the Noise Protocol Framework from a wallet seed and implements the XX and
+IK handshakes with the Noise_25519_ChaChaPoly_SHA256 cipher suite.
+
+The static keys are derived from labeled SLIP-0021 nodes below the "Noise static key" label, so that any number of
+independent peer-to-peer identities can be recovered from the same seed. After the handshake is complete, the
+resulting cipher states encrypt the transport messages of the channel.
+*/
+package noise
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip21"
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/curve25519"
+)
+
+const (
+	// KeySize is the size, in bytes, of X25519 public and private keys.
+	KeySize = curve25519.PointSize
+	// MaxMessageSize is the maximum size, in bytes, of a Noise message.
+	MaxMessageSize = 65535
+
+	// StaticKeyLabel is the SLIP-21 label of the node below which all static keys are derived.
+	StaticKeyLabel = "Noise static key"
+)
+
+var (
+	// ErrInvalidConfig is returned when the handshake configuration is incomplete for the pattern.
+	ErrInvalidConfig = errors.New("invalid config")
+	// ErrInvalidMessage is returned when a handshake message is malformed.
+	ErrInvalidMessage = errors.New("invalid message")
+	// ErrUnexpectedMessage is returned when a handshake message is written or read out of turn.
+	ErrUnexpectedMessage = errors.New("unexpected message")
+	// ErrDecryptionFailed is returned when a message could not be authenticated.
+	ErrDecryptionFailed = errors.New("decryption failed")
+	// ErrNonceExhausted is returned when all nonces of a cipher state have been used.
+	ErrNonceExhausted = errors.New("nonce exhausted")
+)
+
+// Pattern is a Noise handshake pattern.
+type Pattern int
+
+const (
+	// XX is the handshake pattern in which both static keys are transmitted during the handshake.
+	XX Pattern = iota
+	// IK is the handshake pattern in which the static key of the responder is known to the initiator in advance.
+	IK
+)
+
+// String returns the name of the pattern.
+func (p Pattern) String() string {
+	switch p {
+	case XX:
+		return "XX"
+	case IK:
+		return "IK"
+	default:
+		return fmt.Sprintf("Pattern(%d)", int(p))
+	}
+}
+
+// messages returns the tokens of the handshake messages of the pattern.
+func (p Pattern) messages() [][]string {
+	switch p {
+	case XX:
+		return [][]string{{"e"}, {"e", "ee", "s", "es"}, {"s", "se"}}
+	case IK:
+		return [][]string{{"e", "es", "s", "ss"}, {"e", "ee", "se"}}
+	default:
+		return nil
+	}
+}
+
+// KeyPair is an X25519 key pair.
+type KeyPair struct {
+	Private []byte
+	Public  []byte
+}
+
+// NewKeyPair returns the X25519 key pair of the private key.
+func NewKeyPair(private []byte) (*KeyPair, error) {
+	public, err := curve25519.X25519(private, curve25519.Basepoint)
+	if err != nil {
+		return nil, err
+	}
+	return &KeyPair{Private: append([]byte{}, private...), Public: public}, nil
+}
+
+// GenerateKeyPair generates a random X25519 key pair.
+func GenerateKeyPair(rand io.Reader) (*KeyPair, error) {
+	private := make([]byte, KeySize)
+	if _, err := io.ReadFull(rand, private); err != nil {
+		return nil, err
+	}
+	return NewKeyPair(private)
+}
+
+// StaticKey derives the static key pair from seed for the SLIP-21 path StaticKeyLabel/labels.
+func StaticKey(seed []byte, labels ...string) (*KeyPair, error) {
+	node := slip21.DeriveNodeFromPath(seed, append([]string{StaticKeyLabel}, labels...)...)
+	return NewKeyPair(node.Key())
+}
+
+// Config configures a handshake.
+type Config struct {
+	// Pattern is the handshake pattern.
+	Pattern Pattern
+	// Initiator marks the party sending the first handshake message.
+	Initiator bool
+	// Prologue is arbitrary data both parties must agree on.
+	Prologue []byte
+	// StaticKey is the local static key pair.
+	StaticKey *KeyPair
+	// RemoteStatic is the static public key of the responder, required for the initiator of IK.
+	RemoteStatic []byte
+	// Rand is the source of randomness for the ephemeral keys.
+	Rand io.Reader
+}
+
+// Handshake is the state of a Noise handshake.
+type Handshake struct {
+	ss        *symmetricState
+	initiator bool
+	messages  [][]string
+	index     int
+	rand      io.Reader
+
+	s, e   *KeyPair
+	rs, re []byte
+}
+
+// NewHandshake initializes a handshake with the given configuration.
+func NewHandshake(cfg *Config) (*Handshake, error) {
+	messages := cfg.Pattern.messages()
+	if messages == nil {
+		return nil, fmt.Errorf("%w: unsupported pattern %s", ErrInvalidConfig, cfg.Pattern)
+	}
+	if cfg.StaticKey == nil {
+		return nil, fmt.Errorf("%w: missing static key", ErrInvalidConfig)
+	}
+	if cfg.Rand == nil {
+		return nil, fmt.Errorf("%w: missing randomness", ErrInvalidConfig)
+	}
+
+	hs := &Handshake{
+		ss:        newSymmetricState("Noise_" + cfg.Pattern.String() + "_25519_ChaChaPoly_SHA256"),
+		initiator: cfg.Initiator,
+		messages:  messages,
+		rand:      cfg.Rand,
+		s:         cfg.StaticKey,
+	}
+	hs.ss.mixHash(cfg.Prologue)
+
+	// the pre-message of IK contains the static key of the responder
+	if cfg.Pattern == IK {
+		if cfg.Initiator {
+			if len(cfg.RemoteStatic) != KeySize {
+				return nil, fmt.Errorf("%w: missing remote static key", ErrInvalidConfig)
+			}
+			hs.rs = append([]byte{}, cfg.RemoteStatic...)
+			hs.ss.mixHash(hs.rs)
+		} else {
+			hs.ss.mixHash(hs.s.Public)
+		}
+	}
+	return hs, nil
+}
+
+// WriteMessage returns the next handshake message containing the encrypted payload.
+func (hs *Handshake) WriteMessage(payload []byte) ([]byte, error) {
+	tokens, err := hs.next(true)
+	if err != nil {
+		return nil, err
+	}
+
+	var msg []byte
+	for _, token := range tokens {
+		switch token {
+		case "e":
+			if hs.e, err = GenerateKeyPair(hs.rand); err != nil {
+				return nil, err
+			}
+			msg = append(msg, hs.e.Public...)
+			hs.ss.mixHash(hs.e.Public)
+		case "s":
+			c, err := hs.ss.encryptAndHash(hs.s.Public)
+			if err != nil {
+				return nil, err
+			}
+			msg = append(msg, c...)
+		default:
+			if err := hs.mixDH(token); err != nil {
+				return nil, err
+			}
+		}
+	}
+	c, err := hs.ss.encryptAndHash(payload)
+	if err != nil {
+		return nil, err
+	}
+	msg = append(msg, c...)
+	if len(msg) > MaxMessageSize {
+		return nil, fmt.Errorf("%w: message too long", ErrInvalidMessage)
+	}
+	return msg, nil
+}
+
+// ReadMessage processes the next handshake message and returns its decrypted payload.
+func (hs *Handshake) ReadMessage(msg []byte) ([]byte, error) {
+	if len(msg) > MaxMessageSize {
+		return nil, fmt.Errorf("%w: message too long", ErrInvalidMessage)
+	}
+	tokens, err := hs.next(false)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, token := range tokens {
+		switch token {
+		case "e":
+			if len(msg) < KeySize {
+				return nil, fmt.Errorf("%w: missing ephemeral key", ErrInvalidMessage)
+			}
+			hs.re = append([]byte{}, msg[:KeySize]...)
+			msg = msg[KeySize:]
+			hs.ss.mixHash(hs.re)
+		case "s":
+			n := KeySize
+			if hs.ss.cs != nil {
+				n += chacha20poly1305.Overhead
+			}
+			if len(msg) < n {
+				return nil, fmt.Errorf("%w: missing static key", ErrInvalidMessage)
+			}
+			if hs.rs, err = hs.ss.decryptAndHash(msg[:n]); err != nil {
+				return nil, err
+			}
+			msg = msg[n:]
+		default:
+			if err := hs.mixDH(token); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return hs.ss.decryptAndHash(msg)
+}
+
+// Complete reports whether all handshake messages have been processed.
+func (hs *Handshake) Complete() bool {
+	return hs.index == len(hs.messages)
+}
+
+// RemoteStatic returns the static public key of the remote party, or nil if it has not been received yet.
+func (hs *Handshake) RemoteStatic() []byte {
+	return hs.rs
+}
+
+// HandshakeHash returns the handshake hash binding the channel, e.g. for channel binding in higher level protocols.
+func (hs *Handshake) HandshakeHash() []byte {
+	return append([]byte{}, hs.ss.h...)
+}
+
+// Split returns the cipher states for sending and receiving transport messages after the handshake is complete.
+func (hs *Handshake) Split() (send, recv *CipherState, err error) {
+	if !hs.Complete() {
+		return nil, nil, fmt.Errorf("%w: handshake not complete", ErrUnexpectedMessage)
+	}
+	c1, c2 := hs.ss.split()
+	if hs.initiator {
+		return c1, c2, nil
+	}
+	return c2, c1, nil
+}
+
+// next returns the tokens of the next message if it is the turn of the party to write or read.
+func (hs *Handshake) next(write bool) ([]string, error) {
+	if hs.Complete() {
+		return nil, fmt.Errorf("%w: handshake already complete", ErrUnexpectedMessage)
+	}
+	// the initiator writes the messages with even index, the responder those with odd index
+	if (hs.index%2 == 0) != (hs.initiator == write) {
+		return nil, fmt.Errorf("%w: not our turn", ErrUnexpectedMessage)
+	}
+	tokens := hs.messages[hs.index]
+	hs.index++
+	return tokens, nil
+}
+
+// mixDH performs the Diffie-Hellman operation of the token and mixes the result into the chaining key.
+func (hs *Handshake) mixDH(token string) error {
+	var local *KeyPair
+	var remote []byte
+	switch token {
+	case "ee":
+		local, remote = hs.e, hs.re
+	case "es":
+		if hs.initiator {
+			local, remote = hs.e, hs.rs
+		} else {
+			local, remote = hs.s, hs.re
+		}
+	case "se":
+		if hs.initiator {
+			local, remote = hs.s, hs.re
+		} else {
+			local, remote = hs.e, hs.rs
+		}
+	case "ss":
+		local, remote = hs.s, hs.rs
+	default:
+		panic("noise: invalid token " + token)
+	}
+	shared, err := curve25519.X25519(local.Private, remote)
+	if err != nil || subtle.ConstantTimeCompare(shared, make([]byte, KeySize)) == 1 {
+		return fmt.Errorf("%w: invalid public key", ErrInvalidMessage)
+	}
+	hs.ss.mixKey(shared)
+	return nil
+}
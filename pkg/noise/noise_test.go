@@ -0,0 +1,146 @@
+//nolint:scopelint
+package noise_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/internal/hexutil"
+	"github.com/iotaledger/iota-crypto-demo/internal/rand"
+	"github.com/iotaledger/iota-crypto-demo/pkg/noise"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip21"
+)
+
+var testSeed = hexutil.MustDecodeString("000102030405060708090a0b0c0d0e0f")
+
+func TestStaticKey(t *testing.T) {
+	alice, err := noise.StaticKey(testSeed, "alice")
+	require.NoError(t, err)
+	bob, err := noise.StaticKey(testSeed, "bob")
+	require.NoError(t, err)
+	assert.NotEqual(t, alice.Public, bob.Public)
+
+	again, err := noise.StaticKey(testSeed, "alice")
+	require.NoError(t, err)
+	assert.Equal(t, alice, again)
+
+	node := slip21.DeriveNodeFromPath(testSeed, noise.StaticKeyLabel, "alice")
+	assert.Equal(t, node.Key(), alice.Private)
+}
+
+var handshakeTests = []*struct {
+	pattern noise.Pattern
+}{
+	{noise.XX},
+	{noise.IK},
+}
+
+func TestHandshake(t *testing.T) {
+	for _, tt := range handshakeTests {
+		t.Run(tt.pattern.String(), func(t *testing.T) {
+			initiator, responder := newHandshakes(t, tt.pattern)
+
+			sender, receiver := initiator, responder
+			for i := 0; !initiator.Complete(); i++ {
+				payload := []byte{byte(i)}
+				msg, err := sender.WriteMessage(payload)
+				require.NoError(t, err)
+				received, err := receiver.ReadMessage(msg)
+				require.NoError(t, err)
+				assert.Equal(t, payload, received)
+				sender, receiver = receiver, sender
+			}
+			require.True(t, responder.Complete())
+
+			assert.Equal(t, initiator.HandshakeHash(), responder.HandshakeHash())
+			iKey, _ := noise.StaticKey(testSeed, "initiator")
+			rKey, _ := noise.StaticKey(testSeed, "responder")
+			assert.Equal(t, rKey.Public, initiator.RemoteStatic())
+			assert.Equal(t, iKey.Public, responder.RemoteStatic())
+
+			iSend, iRecv, err := initiator.Split()
+			require.NoError(t, err)
+			rSend, rRecv, err := responder.Split()
+			require.NoError(t, err)
+			for _, msg := range []string{"ping", "pong"} {
+				c, err := iSend.Encrypt(nil, []byte(msg))
+				require.NoError(t, err)
+				p, err := rRecv.Decrypt(nil, c)
+				require.NoError(t, err)
+				assert.EqualValues(t, msg, p)
+
+				c, err = rSend.Encrypt(nil, []byte(msg))
+				require.NoError(t, err)
+				p, err = iRecv.Decrypt(nil, c)
+				require.NoError(t, err)
+				assert.EqualValues(t, msg, p)
+			}
+		})
+	}
+}
+
+func TestHandshakeTampered(t *testing.T) {
+	initiator, responder := newHandshakes(t, noise.XX)
+	msg, err := initiator.WriteMessage(nil)
+	require.NoError(t, err)
+	_, err = responder.ReadMessage(msg)
+	require.NoError(t, err)
+
+	msg, err = responder.WriteMessage(nil)
+	require.NoError(t, err)
+	msg[len(msg)-1] ^= 1
+	_, err = initiator.ReadMessage(msg)
+	assert.ErrorIs(t, err, noise.ErrDecryptionFailed)
+}
+
+func TestHandshakeOutOfTurn(t *testing.T) {
+	initiator, responder := newHandshakes(t, noise.XX)
+	_, err := responder.WriteMessage(nil)
+	assert.ErrorIs(t, err, noise.ErrUnexpectedMessage)
+	_, _, err = initiator.Split()
+	assert.ErrorIs(t, err, noise.ErrUnexpectedMessage)
+}
+
+func TestIKWrongResponder(t *testing.T) {
+	iKey, _ := noise.StaticKey(testSeed, "initiator")
+	rKey, _ := noise.StaticKey(testSeed, "responder")
+	other, _ := noise.StaticKey(testSeed, "other")
+
+	initiator, err := noise.NewHandshake(&noise.Config{Pattern: noise.IK, Initiator: true, StaticKey: iKey, RemoteStatic: rKey.Public, Rand: rand.Reader})
+	require.NoError(t, err)
+	responder, err := noise.NewHandshake(&noise.Config{Pattern: noise.IK, StaticKey: other, Rand: rand.Reader})
+	require.NoError(t, err)
+
+	msg, err := initiator.WriteMessage(nil)
+	require.NoError(t, err)
+	_, err = responder.ReadMessage(msg)
+	assert.ErrorIs(t, err, noise.ErrDecryptionFailed)
+}
+
+func newHandshakes(t *testing.T, pattern noise.Pattern) (*noise.Handshake, *noise.Handshake) {
+	iKey, err := noise.StaticKey(testSeed, "initiator")
+	require.NoError(t, err)
+	rKey, err := noise.StaticKey(testSeed, "responder")
+	require.NoError(t, err)
+	prologue := []byte("test")
+
+	initiator, err := noise.NewHandshake(&noise.Config{
+		Pattern:      pattern,
+		Initiator:    true,
+		Prologue:     prologue,
+		StaticKey:    iKey,
+		RemoteStatic: rKey.Public,
+		Rand:         rand.Reader,
+	})
+	require.NoError(t, err)
+	responder, err := noise.NewHandshake(&noise.Config{
+		Pattern:   pattern,
+		Prologue:  prologue,
+		StaticKey: rKey,
+		Rand:      rand.Reader,
+	})
+	require.NoError(t, err)
+	return initiator, responder
+}
@@ -0,0 +1,127 @@
+package noise
+
+import (
+	"crypto/cipher"
+	"crypto/sha256"
+	"encoding/binary"
+	"math"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/hkdf"
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+// CipherState encrypts and decrypts transport messages in one direction of a Noise session.
+type CipherState struct {
+	aead  cipher.AEAD
+	nonce uint64
+}
+
+func newCipherState(key []byte) *CipherState {
+	aead, err := chacha20poly1305.New(key)
+	if err != nil {
+		panic(err)
+	}
+	return &CipherState{aead: aead}
+}
+
+// Encrypt encrypts and authenticates plaintext together with the associated data ad.
+func (c *CipherState) Encrypt(ad, plaintext []byte) ([]byte, error) {
+	if c.nonce == math.MaxUint64 {
+		return nil, ErrNonceExhausted
+	}
+	ciphertext := c.aead.Seal(nil, c.nonceBytes(), plaintext, ad)
+	c.nonce++
+	return ciphertext, nil
+}
+
+// Decrypt authenticates and decrypts ciphertext together with the associated data ad.
+func (c *CipherState) Decrypt(ad, ciphertext []byte) ([]byte, error) {
+	if c.nonce == math.MaxUint64 {
+		return nil, ErrNonceExhausted
+	}
+	plaintext, err := c.aead.Open(nil, c.nonceBytes(), ciphertext, ad)
+	if err != nil {
+		return nil, ErrDecryptionFailed
+	}
+	c.nonce++
+	return plaintext, nil
+}
+
+// nonceBytes returns the 96-bit nonce, i.e. 32 zero bits followed by the little-endian counter.
+func (c *CipherState) nonceBytes() []byte {
+	nonce := make([]byte, chacha20poly1305.NonceSize)
+	binary.LittleEndian.PutUint64(nonce[4:], c.nonce)
+	return nonce
+}
+
+// symmetricState holds the chaining key and the handshake hash of the handshake.
+type symmetricState struct {
+	cs *CipherState
+	ck []byte
+	h  []byte
+}
+
+func newSymmetricState(protocolName string) *symmetricState {
+	h := make([]byte, sha256.Size)
+	if len(protocolName) <= sha256.Size {
+		copy(h, protocolName)
+	} else {
+		sum := sha256.Sum256([]byte(protocolName))
+		copy(h, sum[:])
+	}
+	return &symmetricState{ck: append([]byte{}, h...), h: h}
+}
+
+func (s *symmetricState) mixKey(ikm []byte) {
+	ck, k := s.hkdf(ikm)
+	s.ck = ck
+	s.cs = newCipherState(k)
+}
+
+func (s *symmetricState) mixHash(data []byte) {
+	h := sha256.New()
+	h.Write(s.h)
+	h.Write(data)
+	s.h = h.Sum(nil)
+}
+
+func (s *symmetricState) encryptAndHash(plaintext []byte) ([]byte, error) {
+	if s.cs == nil {
+		s.mixHash(plaintext)
+		return plaintext, nil
+	}
+	ciphertext, err := s.cs.Encrypt(s.h, plaintext)
+	if err != nil {
+		return nil, err
+	}
+	s.mixHash(ciphertext)
+	return ciphertext, nil
+}
+
+func (s *symmetricState) decryptAndHash(ciphertext []byte) ([]byte, error) {
+	if s.cs == nil {
+		s.mixHash(ciphertext)
+		return ciphertext, nil
+	}
+	plaintext, err := s.cs.Decrypt(s.h, ciphertext)
+	if err != nil {
+		return nil, err
+	}
+	s.mixHash(ciphertext)
+	return plaintext, nil
+}
+
+// split returns the cipher states for the initiator to responder and the responder to initiator direction.
+func (s *symmetricState) split() (*CipherState, *CipherState) {
+	k1, k2 := s.hkdf(nil)
+	return newCipherState(k1), newCipherState(k2)
+}
+
+// hkdf returns the two outputs of the Noise HKDF function keyed with the chaining key.
+func (s *symmetricState) hkdf(ikm []byte) ([]byte, []byte) {
+	okm, err := hkdf.Key(sha256.New, ikm, s.ck, nil, 2*sha256.Size)
+	if err != nil {
+		panic(err)
+	}
+	return okm[:sha256.Size], okm[sha256.Size:]
+}
@@ -0,0 +1,48 @@
+package pgp
+
+import (
+	"encoding/base64"
+	"strings"
+)
+
+const (
+	publicKeyBlock  = "PGP PUBLIC KEY BLOCK"
+	privateKeyBlock = "PGP PRIVATE KEY BLOCK"
+)
+
+const armorColumns = 64
+
+// armor returns the ASCII armor of data with the block type, including the CRC-24 checksum.
+func armor(blockType string, data []byte) string {
+	var b strings.Builder
+	b.WriteString("-----BEGIN " + blockType + "-----\n\n")
+	s := base64.StdEncoding.EncodeToString(data)
+	for len(s) > armorColumns {
+		b.WriteString(s[:armorColumns] + "\n")
+		s = s[armorColumns:]
+	}
+	b.WriteString(s + "\n")
+	crc := crc24(data)
+	b.WriteString("=" + base64.StdEncoding.EncodeToString([]byte{byte(crc >> 16), byte(crc >> 8), byte(crc)}) + "\n")
+	b.WriteString("-----END " + blockType + "-----\n")
+	return b.String()
+}
+
+// crc24 computes the CRC-24 checksum of the armor as specified in RFC 4880, Section 6.1.
+func crc24(data []byte) uint32 {
+	const (
+		init = 0xB704CE
+		poly = 0x1864CFB
+	)
+	crc := uint32(init)
+	for _, b := range data {
+		crc ^= uint32(b) << 16
+		for i := 0; i < 8; i++ {
+			crc <<= 1
+			if crc&0x1000000 != 0 {
+				crc ^= poly
+			}
+		}
+	}
+	return crc & 0xFFFFFF
+}
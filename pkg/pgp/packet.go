@@ -0,0 +1,144 @@
+package pgp
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"math/bits"
+	"time"
+)
+
+// packet tags
+const (
+	tagSignature    = 2
+	tagSecretKey    = 5
+	tagPublicKey    = 6
+	tagSecretSubkey = 7
+	tagUserID       = 13
+	tagPublicSubkey = 14
+)
+
+// public key algorithms
+const (
+	algoECDH  = 18
+	algoEdDSA = 22
+)
+
+// signature types
+const (
+	sigPositiveCertification = 0x13
+	sigSubkeyBinding         = 0x18
+)
+
+// signature subpacket types
+const (
+	subCreationTime       = 2
+	subPreferredSymmetric = 11
+	subIssuer             = 16
+	subPreferredHash      = 21
+	subKeyFlags           = 27
+	subFeatures           = 30
+	subIssuerFingerprint  = 33
+)
+
+// key flags
+const (
+	flagCertify        = 0x01
+	flagSign           = 0x02
+	flagEncryptComms   = 0x04
+	flagEncryptStorage = 0x08
+)
+
+const (
+	hashSHA256   = 8
+	hashSHA512   = 10
+	cipherAES128 = 7
+	cipherAES256 = 9
+)
+
+var (
+	// OID 1.3.6.1.4.1.11591.15.1
+	oidEd25519 = []byte{0x2B, 0x06, 0x01, 0x04, 0x01, 0xDA, 0x47, 0x0F, 0x01}
+	// OID 1.3.6.1.4.1.3029.1.5.1
+	oidCurve25519 = []byte{0x2B, 0x06, 0x01, 0x04, 0x01, 0x97, 0x55, 0x01, 0x05, 0x01}
+)
+
+// appendPacket appends a packet with the given tag and body using the new packet format.
+func appendPacket(dst []byte, tag byte, body []byte) []byte {
+	dst = append(dst, 0xC0|tag)
+	return append(appendLength(dst, len(body)), body...)
+}
+
+// appendLength appends the new format length encoding of n, which is also used for subpackets.
+func appendLength(dst []byte, n int) []byte {
+	switch {
+	case n < 192:
+		return append(dst, byte(n))
+	case n < 8384:
+		n -= 192
+		return append(dst, byte(n>>8)+192, byte(n))
+	default:
+		return binary.BigEndian.AppendUint32(append(dst, 0xFF), uint32(n))
+	}
+}
+
+// appendMPI appends b as multiprecision integer, i.e. its bit length followed by the big-endian bytes without leading
+// zeros.
+func appendMPI(dst []byte, b []byte) []byte {
+	for len(b) > 0 && b[0] == 0 {
+		b = b[1:]
+	}
+	n := 0
+	if len(b) > 0 {
+		n = (len(b)-1)*8 + bits.Len8(b[0])
+	}
+	return append(binary.BigEndian.AppendUint16(dst, uint16(n)), b...)
+}
+
+func appendSubpacket(dst []byte, typ byte, data ...byte) []byte {
+	dst = appendLength(dst, len(data)+1)
+	return append(append(dst, typ), data...)
+}
+
+// publicKeyBody returns the body of a version 4 public key packet.
+func publicKeyBody(created time.Time, algo byte, oid []byte, point []byte, params []byte) []byte {
+	body := []byte{4}
+	body = binary.BigEndian.AppendUint32(body, uint32(created.Unix()))
+	body = append(body, algo, byte(len(oid)))
+	body = append(body, oid...)
+	body = appendMPI(body, append([]byte{0x40}, point...))
+	return append(body, params...)
+}
+
+// secretKeyBody returns the body of an unencrypted secret key packet for the public key body and the secret MPI.
+func secretKeyBody(publicBody []byte, secret []byte) []byte {
+	body := append(append([]byte{}, publicBody...), 0) // no S2K encryption
+	mpi := appendMPI(nil, secret)
+	body = append(body, mpi...)
+	var checksum uint16
+	for _, b := range mpi {
+		checksum += uint16(b)
+	}
+	return binary.BigEndian.AppendUint16(body, checksum)
+}
+
+// fingerprint returns the version 4 fingerprint of the public key body.
+func fingerprint(publicBody []byte) []byte {
+	return sha1Sum(keyHashPrefix(publicBody), publicBody)
+}
+
+// keyHashPrefix returns the prefix of a key when it is hashed for fingerprints and signatures.
+func keyHashPrefix(publicBody []byte) []byte {
+	return binary.BigEndian.AppendUint16([]byte{0x99}, uint16(len(publicBody)))
+}
+
+// signatureDigest returns the SHA-256 digest over data and the hashed part of the signature including the trailer.
+func signatureDigest(hashedPart []byte, data ...[]byte) []byte {
+	h := sha256.New()
+	for _, d := range data {
+		h.Write(d)
+	}
+	h.Write(hashedPart)
+	h.Write([]byte{4, 0xFF})
+	h.Write(binary.BigEndian.AppendUint32(nil, uint32(len(hashedPart))))
+	return h.Sum(nil)
+}
@@ -0,0 +1,186 @@
+/*
+Package pgp implements the deterministic generation of OpenPGP keys from derived Ed25519 seeds.
+
+A key consists of an EdDSA primary key for certification and signing, a single user ID and a Curve25519 ECDH subkey
+for encryption, using the algorithms supported by current OpenPGP implementations such as GnuPG. As both the key
+material and the creation time are inputs, the same seed always results in the same fingerprint, so that an OpenPGP
+identity can be restored from a mnemonic.
+*/
+package pgp
+
+import (
+	"crypto/sha1" //nolint:gosec // required by the version 4 fingerprint
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"slices"
+	"time"
+
+	"golang.org/x/crypto/curve25519"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip32path"
+	"github.com/iotaledger/iota-crypto-demo/pkg/ed25519"
+	"github.com/iotaledger/iota-crypto-demo/pkg/hkdf"
+)
+
+// SeedSize is the size, in bytes, of the seed of a key.
+const SeedSize = ed25519.SeedSize
+
+// epoch is the earliest creation time returned by CreationTime, 2020-01-01 00:00:00 UTC.
+const epoch = 1577836800
+
+// subkeyInfo is the HKDF info used to derive the encryption subkey from the seed.
+const subkeyInfo = "OpenPGP Curve25519 subkey"
+
+// ErrInvalidSeed is returned when the seed has an invalid length.
+var ErrInvalidSeed = errors.New("invalid seed")
+
+// Key is an OpenPGP key consisting of an EdDSA primary key, a user ID and an ECDH encryption subkey.
+type Key struct {
+	primary ed25519.PrivateKey
+	subkey  []byte // X25519 scalar
+	userID  string
+	created time.Time
+
+	primaryBody []byte
+	subkeyBody  []byte
+}
+
+// CreationTime returns a fixed creation time derived from the path, so that keys for the same path are identical.
+// The time is in the two years following 2020-01-01, so that it is never in the future.
+func CreationTime(path bip32path.Path) time.Time {
+	h := sha256.Sum256([]byte(path.String()))
+	return time.Unix(epoch+int64(binary.BigEndian.Uint32(h[:])%(1<<26)), 0).UTC()
+}
+
+// NewKey creates the OpenPGP key for the user ID, e.g. "Alice <alice@example.com>", from the 32-byte seed.
+// The encryption subkey is derived from the seed using HKDF and both keys use the given creation time.
+func NewKey(seed []byte, userID string, created time.Time) (*Key, error) {
+	if len(seed) != SeedSize {
+		return nil, ErrInvalidSeed
+	}
+	subkey, err := hkdf.Key(sha256.New, seed, nil, []byte(subkeyInfo), curve25519.ScalarSize)
+	if err != nil {
+		return nil, err
+	}
+	// clamp the scalar, as OpenPGP implementations expect the secret to be stored clamped
+	subkey[0] &= 248
+	subkey[31] &= 127
+	subkey[31] |= 64
+
+	k := &Key{
+		primary: ed25519.NewKeyFromSeed(seed),
+		subkey:  subkey,
+		userID:  userID,
+		created: created.Truncate(time.Second),
+	}
+	k.primaryBody = publicKeyBody(k.created, algoEdDSA, oidEd25519, k.primary.Public().(ed25519.PublicKey), nil) //nolint:forcetypeassert
+	subkeyPub, _ := curve25519.X25519(subkey, curve25519.Basepoint)
+	// KDF parameters: SHA-256 and AES-128
+	kdfParams := []byte{3, 1, hashSHA256, cipherAES128}
+	k.subkeyBody = publicKeyBody(k.created, algoECDH, oidCurve25519, subkeyPub, kdfParams)
+	return k, nil
+}
+
+// Fingerprint returns the version 4 fingerprint of the primary key.
+func (k *Key) Fingerprint() []byte {
+	return fingerprint(k.primaryBody)
+}
+
+// KeyID returns the key ID of the primary key, i.e. the last 8 bytes of its fingerprint.
+func (k *Key) KeyID() []byte {
+	return k.Fingerprint()[12:]
+}
+
+// SubkeyFingerprint returns the version 4 fingerprint of the encryption subkey.
+func (k *Key) SubkeyFingerprint() []byte {
+	return fingerprint(k.subkeyBody)
+}
+
+// PublicKey returns the binary transferable public key.
+func (k *Key) PublicKey() []byte {
+	return k.serialize(tagPublicKey, k.primaryBody, tagPublicSubkey, k.subkeyBody)
+}
+
+// PrivateKey returns the binary transferable secret key. The secret key material is not encrypted.
+func (k *Key) PrivateKey() []byte {
+	primary := secretKeyBody(k.primaryBody, k.primary.Seed())
+	// Curve25519 secrets are stored in big-endian, i.e. reversed native, order
+	secret := slices.Clone(k.subkey)
+	slices.Reverse(secret)
+	subkey := secretKeyBody(k.subkeyBody, secret)
+	return k.serialize(tagSecretKey, primary, tagSecretSubkey, subkey)
+}
+
+// ArmoredPublicKey returns the ASCII armored transferable public key.
+func (k *Key) ArmoredPublicKey() string {
+	return armor(publicKeyBlock, k.PublicKey())
+}
+
+// ArmoredPrivateKey returns the ASCII armored transferable secret key.
+func (k *Key) ArmoredPrivateKey() string {
+	return armor(privateKeyBlock, k.PrivateKey())
+}
+
+func (k *Key) serialize(primaryTag byte, primary []byte, subkeyTag byte, subkey []byte) []byte {
+	var out []byte
+	out = appendPacket(out, primaryTag, primary)
+	out = appendPacket(out, tagUserID, []byte(k.userID))
+	out = appendPacket(out, tagSignature, k.certification())
+	out = appendPacket(out, subkeyTag, subkey)
+	return appendPacket(out, tagSignature, k.subkeyBinding())
+}
+
+// certification returns the positive certification of the user ID by the primary key.
+func (k *Key) certification() []byte {
+	var sub []byte
+	sub = appendSubpacket(sub, subCreationTime, k.createdBytes()...)
+	sub = appendSubpacket(sub, subKeyFlags, flagCertify|flagSign)
+	sub = appendSubpacket(sub, subPreferredSymmetric, cipherAES256, cipherAES128)
+	sub = appendSubpacket(sub, subPreferredHash, hashSHA512, hashSHA256)
+	sub = appendSubpacket(sub, subFeatures, 0x01) // modification detection
+	sub = appendSubpacket(sub, subIssuerFingerprint, append([]byte{4}, k.Fingerprint()...)...)
+
+	uid := binary.BigEndian.AppendUint32([]byte{0xB4}, uint32(len(k.userID)))
+	return k.sign(sigPositiveCertification, sub,
+		keyHashPrefix(k.primaryBody), k.primaryBody, uid, []byte(k.userID))
+}
+
+// subkeyBinding returns the binding signature of the encryption subkey by the primary key.
+func (k *Key) subkeyBinding() []byte {
+	var sub []byte
+	sub = appendSubpacket(sub, subCreationTime, k.createdBytes()...)
+	sub = appendSubpacket(sub, subKeyFlags, flagEncryptComms|flagEncryptStorage)
+	sub = appendSubpacket(sub, subIssuerFingerprint, append([]byte{4}, k.Fingerprint()...)...)
+
+	return k.sign(sigSubkeyBinding, sub,
+		keyHashPrefix(k.primaryBody), k.primaryBody, keyHashPrefix(k.subkeyBody), k.subkeyBody)
+}
+
+// sign returns the body of a version 4 EdDSA signature packet over data.
+func (k *Key) sign(sigType byte, hashedSubpackets []byte, data ...[]byte) []byte {
+	hashed := []byte{4, sigType, algoEdDSA, hashSHA256}
+	hashed = binary.BigEndian.AppendUint16(hashed, uint16(len(hashedSubpackets)))
+	hashed = append(hashed, hashedSubpackets...)
+	digest := signatureDigest(hashed, data...)
+	sig := ed25519.Sign(k.primary, digest)
+
+	unhashed := appendSubpacket(nil, subIssuer, k.KeyID()...)
+	body := binary.BigEndian.AppendUint16(hashed, uint16(len(unhashed)))
+	body = append(body, unhashed...)
+	body = append(body, digest[:2]...)
+	body = appendMPI(body, sig[:32])
+	return appendMPI(body, sig[32:])
+}
+
+func (k *Key) createdBytes() []byte {
+	return binary.BigEndian.AppendUint32(nil, uint32(k.created.Unix()))
+}
+
+func sha1Sum(data ...[]byte) []byte {
+	h := sha1.New() //nolint:gosec
+	for _, d := range data {
+		h.Write(d)
+	}
+	return h.Sum(nil)
+}
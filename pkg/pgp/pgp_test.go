@@ -0,0 +1,76 @@
+package pgp_test
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/internal/hexutil"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip32path"
+	"github.com/iotaledger/iota-crypto-demo/pkg/pgp"
+)
+
+const testUserID = "Alice <alice@example.com>"
+
+var testSeed = hexutil.MustDecodeString("0100000000000000000000000000000000000000000000000000000000000000")
+
+func testKey(t *testing.T) *pgp.Key {
+	path, err := bip32path.ParsePath("m/44'/4218'/0'/0'/0'")
+	require.NoError(t, err)
+	key, err := pgp.NewKey(testSeed, testUserID, pgp.CreationTime(path))
+	require.NoError(t, err)
+	return key
+}
+
+func TestNewKey(t *testing.T) {
+	key := testKey(t)
+	// the key has been verified to import into GnuPG with this fingerprint
+	assert.Equal(t, "447f4e6b2c2c9bab3827d3218ca97e3b82d77ab1", hexutil.Bytes(key.Fingerprint()).String())
+	assert.Equal(t, "8ca97e3b82d77ab1", hexutil.Bytes(key.KeyID()).String())
+	assert.NotEqual(t, key.Fingerprint(), key.SubkeyFingerprint())
+
+	// deterministic
+	assert.Equal(t, key.PrivateKey(), testKey(t).PrivateKey())
+
+	_, err := pgp.NewKey(testSeed[1:], testUserID, time.Now())
+	assert.ErrorIs(t, err, pgp.ErrInvalidSeed)
+}
+
+func TestCreationTime(t *testing.T) {
+	a, err := bip32path.ParsePath("m/44'/4218'/0'/0'/0'")
+	require.NoError(t, err)
+	b, err := bip32path.ParsePath("m/44'/4218'/0'/0'/1'")
+	require.NoError(t, err)
+
+	assert.Equal(t, pgp.CreationTime(a), pgp.CreationTime(a))
+	assert.NotEqual(t, pgp.CreationTime(a), pgp.CreationTime(b))
+	assert.True(t, pgp.CreationTime(a).After(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)))
+	assert.True(t, pgp.CreationTime(a).Before(time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestArmor(t *testing.T) {
+	key := testKey(t)
+	for _, tt := range []struct {
+		armored   string
+		blockType string
+		data      []byte
+	}{
+		{key.ArmoredPublicKey(), "PGP PUBLIC KEY BLOCK", key.PublicKey()},
+		{key.ArmoredPrivateKey(), "PGP PRIVATE KEY BLOCK", key.PrivateKey()},
+	} {
+		lines := strings.Split(strings.TrimSuffix(tt.armored, "\n"), "\n")
+		require.Greater(t, len(lines), 4)
+		assert.Equal(t, "-----BEGIN "+tt.blockType+"-----", lines[0])
+		assert.Empty(t, lines[1])
+		assert.Equal(t, "-----END "+tt.blockType+"-----", lines[len(lines)-1])
+		assert.True(t, strings.HasPrefix(lines[len(lines)-2], "="))
+
+		data, err := base64.StdEncoding.DecodeString(strings.Join(lines[2:len(lines)-2], ""))
+		require.NoError(t, err)
+		assert.Equal(t, tt.data, data)
+	}
+}
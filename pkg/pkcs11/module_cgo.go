@@ -0,0 +1,328 @@
+//go:build cgo && (linux || darwin || freebsd)
+
+package pkcs11
+
+/*
+#cgo linux LDFLAGS: -ldl
+#include <dlfcn.h>
+#include <stdlib.h>
+
+// minimal Cryptoki definitions for LP64 platforms, where all CK_ULONG based types are unsigned long
+typedef unsigned long CK_ULONG;
+typedef CK_ULONG CK_RV;
+
+typedef struct {
+	CK_ULONG type;
+	void *pValue;
+	CK_ULONG ulValueLen;
+} CK_ATTRIBUTE;
+
+typedef struct {
+	CK_ULONG mechanism;
+	void *pParameter;
+	CK_ULONG ulParameterLen;
+} CK_MECHANISM;
+
+typedef struct {
+	void *CreateMutex, *DestroyMutex, *LockMutex, *UnlockMutex;
+	CK_ULONG flags;
+	void *pReserved;
+} CK_C_INITIALIZE_ARGS;
+
+// CK_FUNCTION_LIST contains the version followed by the 68 function pointers in the order of the specification.
+typedef struct {
+	unsigned char version[2];
+	void *fn[68];
+} CK_FUNCTION_LIST;
+
+enum {
+	fnInitialize        = 0,
+	fnFinalize          = 1,
+	fnGetSlotList       = 4,
+	fnOpenSession       = 12,
+	fnLogin             = 18,
+	fnCreateObject      = 20,
+	fnGetAttributeValue = 24,
+	fnFindObjectsInit   = 26,
+	fnFindObjects       = 27,
+	fnFindObjectsFinal  = 28,
+	fnSignInit          = 42,
+	fnSign              = 43,
+	fnGenerateKeyPair   = 59,
+};
+
+static CK_RV getFunctionList(void *sym, CK_FUNCTION_LIST **list) {
+	return ((CK_RV (*)(CK_FUNCTION_LIST **))sym)(list);
+}
+
+static CK_RV initialize(CK_FUNCTION_LIST *f) {
+	CK_C_INITIALIZE_ARGS args = {0};
+	args.flags = 0x2; // CKF_OS_LOCKING_OK
+	return ((CK_RV (*)(void *))f->fn[fnInitialize])(&args);
+}
+
+static CK_RV finalize(CK_FUNCTION_LIST *f) {
+	return ((CK_RV (*)(void *))f->fn[fnFinalize])(NULL);
+}
+
+static CK_RV getSlotList(CK_FUNCTION_LIST *f, CK_ULONG *slots, CK_ULONG *count) {
+	return ((CK_RV (*)(unsigned char, CK_ULONG *, CK_ULONG *))f->fn[fnGetSlotList])(1, slots, count);
+}
+
+static CK_RV openSession(CK_FUNCTION_LIST *f, CK_ULONG slot, CK_ULONG *session) {
+	// CKF_SERIAL_SESSION | CKF_RW_SESSION
+	return ((CK_RV (*)(CK_ULONG, CK_ULONG, void *, void *, CK_ULONG *))f->fn[fnOpenSession])(slot, 0x6, NULL, NULL, session);
+}
+
+static CK_RV login(CK_FUNCTION_LIST *f, CK_ULONG session, unsigned char *pin, CK_ULONG pinLen) {
+	// CKU_USER
+	return ((CK_RV (*)(CK_ULONG, CK_ULONG, unsigned char *, CK_ULONG))f->fn[fnLogin])(session, 1, pin, pinLen);
+}
+
+static CK_RV createObject(CK_FUNCTION_LIST *f, CK_ULONG session, CK_ATTRIBUTE *templ, CK_ULONG count, CK_ULONG *object) {
+	return ((CK_RV (*)(CK_ULONG, CK_ATTRIBUTE *, CK_ULONG, CK_ULONG *))f->fn[fnCreateObject])(session, templ, count, object);
+}
+
+static CK_RV getAttributeValue(CK_FUNCTION_LIST *f, CK_ULONG session, CK_ULONG object, CK_ATTRIBUTE *templ, CK_ULONG count) {
+	return ((CK_RV (*)(CK_ULONG, CK_ULONG, CK_ATTRIBUTE *, CK_ULONG))f->fn[fnGetAttributeValue])(session, object, templ, count);
+}
+
+static CK_RV findObjectsInit(CK_FUNCTION_LIST *f, CK_ULONG session, CK_ATTRIBUTE *templ, CK_ULONG count) {
+	return ((CK_RV (*)(CK_ULONG, CK_ATTRIBUTE *, CK_ULONG))f->fn[fnFindObjectsInit])(session, templ, count);
+}
+
+static CK_RV findObjects(CK_FUNCTION_LIST *f, CK_ULONG session, CK_ULONG *objects, CK_ULONG max, CK_ULONG *count) {
+	return ((CK_RV (*)(CK_ULONG, CK_ULONG *, CK_ULONG, CK_ULONG *))f->fn[fnFindObjects])(session, objects, max, count);
+}
+
+static CK_RV findObjectsFinal(CK_FUNCTION_LIST *f, CK_ULONG session) {
+	return ((CK_RV (*)(CK_ULONG))f->fn[fnFindObjectsFinal])(session);
+}
+
+static CK_RV signInit(CK_FUNCTION_LIST *f, CK_ULONG session, CK_ULONG mechanism, CK_ULONG key) {
+	CK_MECHANISM m = {mechanism, NULL, 0};
+	return ((CK_RV (*)(CK_ULONG, CK_MECHANISM *, CK_ULONG))f->fn[fnSignInit])(session, &m, key);
+}
+
+static CK_RV sign(CK_FUNCTION_LIST *f, CK_ULONG session, unsigned char *data, CK_ULONG dataLen, unsigned char *sig, CK_ULONG *sigLen) {
+	return ((CK_RV (*)(CK_ULONG, unsigned char *, CK_ULONG, unsigned char *, CK_ULONG *))f->fn[fnSign])(session, data, dataLen, sig, sigLen);
+}
+
+static CK_RV generateKeyPair(CK_FUNCTION_LIST *f, CK_ULONG session, CK_ULONG mechanism,
+		CK_ATTRIBUTE *pub, CK_ULONG pubCount, CK_ATTRIBUTE *priv, CK_ULONG privCount, CK_ULONG *pubKey, CK_ULONG *privKey) {
+	CK_MECHANISM m = {mechanism, NULL, 0};
+	return ((CK_RV (*)(CK_ULONG, CK_MECHANISM *, CK_ATTRIBUTE *, CK_ULONG, CK_ATTRIBUTE *, CK_ULONG, CK_ULONG *, CK_ULONG *))f->fn[fnGenerateKeyPair])(
+		session, &m, pub, pubCount, priv, privCount, pubKey, privKey);
+}
+*/
+import "C"
+
+import (
+	"fmt"
+	"sync"
+	"unsafe"
+)
+
+// unavailableInformation is the CK_UNAVAILABLE_INFORMATION length of attributes that cannot be read.
+const unavailableInformation = ^C.CK_ULONG(0)
+
+// Module is a PKCS#11 module loaded from a shared library.
+type Module struct {
+	lib  unsafe.Pointer
+	list *C.CK_FUNCTION_LIST
+}
+
+// Open loads and initializes the PKCS#11 module in the shared library at path, e.g. "/usr/lib/softhsm/libsofthsm2.so".
+func Open(path string) (*Module, error) {
+	cpath := C.CString(path)
+	defer C.free(unsafe.Pointer(cpath))
+	lib := C.dlopen(cpath, C.RTLD_NOW|C.RTLD_LOCAL)
+	if lib == nil {
+		return nil, fmt.Errorf("failed loading %s: %s", path, C.GoString(C.dlerror()))
+	}
+	name := C.CString("C_GetFunctionList")
+	defer C.free(unsafe.Pointer(name))
+	sym := C.dlsym(lib, name)
+	if sym == nil {
+		C.dlclose(lib)
+		return nil, fmt.Errorf("%s is not a PKCS#11 module", path)
+	}
+
+	m := &Module{lib: lib}
+	if err := check(C.getFunctionList(sym, &m.list)); err != nil {
+		C.dlclose(lib)
+		return nil, err
+	}
+	if rv := C.initialize(m.list); rv != 0 && rv != errCryptokiAlreadyInitialized {
+		C.dlclose(lib)
+		return nil, Error(rv)
+	}
+	return m, nil
+}
+
+// Close finalizes the module, which closes all its sessions, and unloads the library.
+func (m *Module) Close() error {
+	err := check(C.finalize(m.list))
+	C.dlclose(m.lib)
+	return err
+}
+
+// Slots returns the IDs of all slots with a token present.
+func (m *Module) Slots() ([]uint, error) {
+	var n C.CK_ULONG
+	if err := check(C.getSlotList(m.list, nil, &n)); err != nil {
+		return nil, err
+	}
+	if n == 0 {
+		return nil, nil
+	}
+	ids := make([]C.CK_ULONG, n)
+	if err := check(C.getSlotList(m.list, &ids[0], &n)); err != nil {
+		return nil, err
+	}
+	slots := make([]uint, n)
+	for i := range slots {
+		slots[i] = uint(ids[i])
+	}
+	return slots, nil
+}
+
+// OpenSession opens a read-write session with the token in slot and logs in as user with pin.
+// The session may be used concurrently, all calls are serialized.
+func (m *Module) OpenSession(slot uint, pin string) (Session, error) {
+	s := &session{m: m}
+	if err := check(C.openSession(m.list, C.CK_ULONG(slot), &s.handle)); err != nil {
+		return nil, err
+	}
+	cpin := C.CString(pin)
+	defer C.free(unsafe.Pointer(cpin))
+	rv := C.login(m.list, s.handle, (*C.uchar)(unsafe.Pointer(cpin)), C.CK_ULONG(len(pin)))
+	if rv != 0 && rv != errUserAlreadyLoggedIn {
+		return nil, Error(rv)
+	}
+	return s, nil
+}
+
+// session implements Session using the Cryptoki functions of the module.
+type session struct {
+	mu     sync.Mutex
+	m      *Module
+	handle C.CK_ULONG
+}
+
+func (s *session) FindObjects(template []*Attribute) ([]ObjectHandle, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	attrs, n, free := cTemplate(template)
+	defer free()
+	if err := check(C.findObjectsInit(s.m.list, s.handle, attrs, n)); err != nil {
+		return nil, err
+	}
+	//nolint:errcheck // the result of the search is already known
+	defer C.findObjectsFinal(s.m.list, s.handle)
+
+	var handles []ObjectHandle
+	buf := make([]C.CK_ULONG, 16)
+	for {
+		var count C.CK_ULONG
+		if err := check(C.findObjects(s.m.list, s.handle, &buf[0], C.CK_ULONG(len(buf)), &count)); err != nil {
+			return nil, err
+		}
+		if count == 0 {
+			return handles, nil
+		}
+		for _, h := range buf[:count] {
+			handles = append(handles, ObjectHandle(h))
+		}
+	}
+}
+
+func (s *session) GetAttributeValue(object ObjectHandle, t AttributeType) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	attr := (*C.CK_ATTRIBUTE)(C.calloc(1, C.sizeof_CK_ATTRIBUTE))
+	defer C.free(unsafe.Pointer(attr))
+	attr._type = C.CK_ULONG(t)
+	// the first call returns the length of the value
+	if err := check(C.getAttributeValue(s.m.list, s.handle, C.CK_ULONG(object), attr, 1)); err != nil {
+		return nil, err
+	}
+	if attr.ulValueLen == unavailableInformation {
+		return nil, fmt.Errorf("%w: attribute 0x%x unavailable", ErrInvalidResponse, uint(t))
+	}
+	attr.pValue = C.malloc(attr.ulValueLen + 1)
+	defer C.free(attr.pValue)
+	if err := check(C.getAttributeValue(s.m.list, s.handle, C.CK_ULONG(object), attr, 1)); err != nil {
+		return nil, err
+	}
+	return C.GoBytes(attr.pValue, C.int(attr.ulValueLen)), nil
+}
+
+func (s *session) CreateObject(template []*Attribute) (ObjectHandle, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	attrs, n, free := cTemplate(template)
+	defer free()
+	var h C.CK_ULONG
+	if err := check(C.createObject(s.m.list, s.handle, attrs, n, &h)); err != nil {
+		return 0, err
+	}
+	return ObjectHandle(h), nil
+}
+
+func (s *session) GenerateKeyPair(mechanism uint, public, private []*Attribute) (ObjectHandle, ObjectHandle, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	pub, pubCount, freePub := cTemplate(public)
+	defer freePub()
+	priv, privCount, freePriv := cTemplate(private)
+	defer freePriv()
+	var pubKey, privKey C.CK_ULONG
+	if err := check(C.generateKeyPair(s.m.list, s.handle, C.CK_ULONG(mechanism), pub, pubCount, priv, privCount, &pubKey, &privKey)); err != nil {
+		return 0, 0, err
+	}
+	return ObjectHandle(pubKey), ObjectHandle(privKey), nil
+}
+
+func (s *session) Sign(mechanism uint, key ObjectHandle, message []byte) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := check(C.signInit(s.m.list, s.handle, C.CK_ULONG(mechanism), C.CK_ULONG(key))); err != nil {
+		return nil, err
+	}
+	data := C.CBytes(message)
+	defer C.free(data)
+	sig := make([]byte, 512)
+	sigLen := C.CK_ULONG(len(sig))
+	if err := check(C.sign(s.m.list, s.handle, (*C.uchar)(data), C.CK_ULONG(len(message)), (*C.uchar)(unsafe.Pointer(&sig[0])), &sigLen)); err != nil {
+		return nil, err
+	}
+	return sig[:sigLen], nil
+}
+
+// cTemplate copies the attributes into C memory, which must be released by calling free.
+func cTemplate(template []*Attribute) (attrs *C.CK_ATTRIBUTE, n C.CK_ULONG, free func()) {
+	if len(template) == 0 {
+		return nil, 0, func() {}
+	}
+	attrs = (*C.CK_ATTRIBUTE)(C.calloc(C.size_t(len(template)), C.sizeof_CK_ATTRIBUTE))
+	list := unsafe.Slice(attrs, len(template))
+	for i, a := range template {
+		list[i]._type = C.CK_ULONG(a.Type)
+		list[i].pValue = C.CBytes(a.Value)
+		list[i].ulValueLen = C.CK_ULONG(len(a.Value))
+	}
+	return attrs, C.CK_ULONG(len(template)), func() {
+		for i := range list {
+			C.free(list[i].pValue)
+		}
+		C.free(unsafe.Pointer(attrs))
+	}
+}
+
+func check(rv C.CK_RV) error {
+	if rv != 0 {
+		return Error(rv)
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+//go:build !cgo || !(linux || darwin || freebsd)
+
+package pkcs11
+
+// Module is a PKCS#11 module loaded from a shared library.
+type Module struct{}
+
+// Open loads and initializes the PKCS#11 module in the shared library at path.
+// It returns ErrUnsupported, as loading modules requires cgo on a Unix platform.
+func Open(string) (*Module, error) {
+	return nil, ErrUnsupported
+}
+
+// Close finalizes the module and unloads the library.
+func (m *Module) Close() error {
+	return ErrUnsupported
+}
+
+// Slots returns the IDs of all slots with a token present.
+func (m *Module) Slots() ([]uint, error) {
+	return nil, ErrUnsupported
+}
+
+// OpenSession opens a read-write session with the token in slot and logs in as user with pin.
+func (m *Module) OpenSession(uint, string) (Session, error) {
+	return nil, ErrUnsupported
+}
@@ -0,0 +1,311 @@
+/*
+Package pkcs11 adapts Ed25519 keys held by PKCS#11 tokens, e.g. HSMs or smart cards, to the backend.KeyStore
+interface, so that the final signing step happens on the token while paths and addresses are handled as usual.
+
+Keys on the token are identified by the string form of their derivation path stored as CKA_LABEL. They are either
+generated on the token, in which case they are unrelated to any seed, or derived from a seed with SLIP-10 and imported
+as sensitive, non-extractable objects. Either way, the private key never leaves the token afterwards.
+
+Token works on any Session, the minimal set of Cryptoki operations it requires. Open loads a PKCS#11 module from a
+shared library and provides such sessions on platforms with cgo support.
+*/
+package pkcs11
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip32path"
+	"github.com/iotaledger/iota-crypto-demo/pkg/ed25519"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10/eddsa"
+)
+
+var (
+	// ErrUnsupported is returned when loading PKCS#11 modules is not supported on this platform.
+	ErrUnsupported = errors.New("PKCS#11 not supported on this platform")
+	// ErrKeyNotFound is returned when the token holds no key for a path.
+	ErrKeyNotFound = errors.New("key not found")
+	// ErrKeyExists is returned when a key for the path is already present on the token.
+	ErrKeyExists = errors.New("key already exists")
+	// ErrNotExportable is returned when the seed is requested, which never leaves the token.
+	ErrNotExportable = errors.New("seed not exportable from token")
+	// ErrInvalidResponse is returned when the token returns a malformed key or signature.
+	ErrInvalidResponse = errors.New("invalid response")
+)
+
+// AttributeType is the type of an object attribute, i.e. a CKA_ constant.
+type AttributeType uint
+
+// Attribute types, i.e. the CKA_ constants, used for Ed25519 keys.
+const (
+	AttributeClass       AttributeType = 0x000
+	AttributeToken       AttributeType = 0x001
+	AttributePrivate     AttributeType = 0x002
+	AttributeLabel       AttributeType = 0x003
+	AttributeValue       AttributeType = 0x011
+	AttributeKeyType     AttributeType = 0x100
+	AttributeSensitive   AttributeType = 0x103
+	AttributeSign        AttributeType = 0x108
+	AttributeVerify      AttributeType = 0x10A
+	AttributeExtractable AttributeType = 0x162
+	AttributeECParams    AttributeType = 0x180
+	AttributeECPoint     AttributeType = 0x181
+)
+
+// Object classes, key types and mechanisms of Ed25519 keys as defined in PKCS#11 v3.0.
+const (
+	ClassPrivateKey          = 0x0003 // CKO_PRIVATE_KEY
+	ClassPublicKey           = 0x0002 // CKO_PUBLIC_KEY
+	KeyTypeECEdwards         = 0x0040 // CKK_EC_EDWARDS
+	MechanismECEdwardsKeyGen = 0x1055 // CKM_EC_EDWARDS_KEY_PAIR_GEN
+	MechanismEdDSA           = 0x1057 // CKM_EDDSA
+)
+
+// Error is a PKCS#11 return value other than CKR_OK.
+type Error uint
+
+// return values handled explicitly
+const (
+	errCryptokiAlreadyInitialized = 0x191
+	errUserAlreadyLoggedIn        = 0x100
+)
+
+var errorNames = map[Error]string{
+	0x005: "CKR_GENERAL_ERROR",
+	0x011: "CKR_ATTRIBUTE_SENSITIVE",
+	0x012: "CKR_ATTRIBUTE_TYPE_INVALID",
+	0x013: "CKR_ATTRIBUTE_VALUE_INVALID",
+	0x054: "CKR_FUNCTION_NOT_SUPPORTED",
+	0x060: "CKR_KEY_HANDLE_INVALID",
+	0x070: "CKR_MECHANISM_INVALID",
+	0x0A0: "CKR_PIN_INCORRECT",
+	0x0B3: "CKR_SESSION_HANDLE_INVALID",
+	0x0D0: "CKR_TEMPLATE_INCOMPLETE",
+	0x0D1: "CKR_TEMPLATE_INCONSISTENT",
+	0x0E0: "CKR_TOKEN_NOT_PRESENT",
+	0x0E2: "CKR_TOKEN_WRITE_PROTECTED",
+	0x101: "CKR_USER_NOT_LOGGED_IN",
+}
+
+func (e Error) Error() string {
+	if name, ok := errorNames[e]; ok {
+		return "pkcs11: " + name
+	}
+	return fmt.Sprintf("pkcs11: error 0x%x", uint(e))
+}
+
+// ed25519Params is the DER encoded object identifier of Ed25519 (1.3.101.112) used as CKA_EC_PARAMS.
+var ed25519Params = []byte{0x06, 0x03, 0x2B, 0x65, 0x70}
+
+// Attribute is an attribute of a token object in its binary PKCS#11 encoding.
+type Attribute struct {
+	Type  AttributeType
+	Value []byte
+}
+
+// BoolAttribute returns the attribute of type t with the CK_BBOOL value v.
+func BoolAttribute(t AttributeType, v bool) *Attribute {
+	if v {
+		return &Attribute{t, []byte{1}}
+	}
+	return &Attribute{t, []byte{0}}
+}
+
+// UlongAttribute returns the attribute of type t with the CK_ULONG value v.
+// CK_ULONG is assumed to have the size of a uint, which holds for all LP64 Unix platforms.
+func UlongAttribute(t AttributeType, v uint) *Attribute {
+	if strconv.IntSize == 32 {
+		return &Attribute{t, binary.NativeEndian.AppendUint32(nil, uint32(v))}
+	}
+	return &Attribute{t, binary.NativeEndian.AppendUint64(nil, uint64(v))}
+}
+
+// ObjectHandle identifies an object of a session.
+type ObjectHandle uint
+
+// Session is the subset of the operations of a PKCS#11 session used by Token.
+// Mechanisms are passed without parameters.
+type Session interface {
+	// FindObjects returns the handles of all objects matching template.
+	FindObjects(template []*Attribute) ([]ObjectHandle, error)
+	// GetAttributeValue returns the value of the attribute of type t of the object.
+	GetAttributeValue(object ObjectHandle, t AttributeType) ([]byte, error)
+	// CreateObject creates an object with the attributes of template.
+	CreateObject(template []*Attribute) (ObjectHandle, error)
+	// GenerateKeyPair generates a key pair with mechanism and returns the public and private key handles.
+	GenerateKeyPair(mechanism uint, public, private []*Attribute) (ObjectHandle, ObjectHandle, error)
+	// Sign signs message with key using mechanism.
+	Sign(mechanism uint, key ObjectHandle, message []byte) ([]byte, error)
+}
+
+// Token is a KeyStore for the Ed25519 keys held by a PKCS#11 token.
+type Token struct {
+	session Session
+}
+
+// NewToken returns a Token using the logged in session.
+func NewToken(session Session) *Token {
+	return &Token{session: session}
+}
+
+// Seed implements KeyStore. It always returns ErrNotExportable.
+func (t *Token) Seed() ([]byte, error) {
+	return nil, ErrNotExportable
+}
+
+// PublicKey implements KeyStore.
+func (t *Token) PublicKey(path bip32path.Path) (ed25519.PublicKey, error) {
+	handle, err := t.find(ClassPublicKey, path)
+	if err != nil {
+		return nil, err
+	}
+	point, err := t.session.GetAttributeValue(handle, AttributeECPoint)
+	if err != nil {
+		return nil, err
+	}
+	return decodePoint(point)
+}
+
+// Sign implements KeyStore.
+func (t *Token) Sign(path bip32path.Path, message []byte) ([]byte, error) {
+	handle, err := t.find(ClassPrivateKey, path)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := t.session.Sign(MechanismEdDSA, handle, message)
+	if err != nil {
+		return nil, err
+	}
+	if len(sig) != ed25519.SignatureSize {
+		return nil, fmt.Errorf("%w: signature length %d", ErrInvalidResponse, len(sig))
+	}
+	return sig, nil
+}
+
+// GenerateKey generates a new Ed25519 key pair on the token and stores it for path.
+// The key is not derived from any seed and cannot be backed up outside the token.
+func (t *Token) GenerateKey(path bip32path.Path) (ed25519.PublicKey, error) {
+	if err := t.checkAbsent(path); err != nil {
+		return nil, err
+	}
+	label := []byte(path.String())
+	public, _, err := t.session.GenerateKeyPair(MechanismECEdwardsKeyGen,
+		[]*Attribute{
+			BoolAttribute(AttributeToken, true),
+			BoolAttribute(AttributeVerify, true),
+			{AttributeLabel, label},
+			{AttributeECParams, ed25519Params},
+		},
+		privateTemplate(label),
+	)
+	if err != nil {
+		return nil, err
+	}
+	point, err := t.session.GetAttributeValue(public, AttributeECPoint)
+	if err != nil {
+		return nil, err
+	}
+	return decodePoint(point)
+}
+
+// ImportKey derives the Ed25519 key for path from seed using SLIP-10 and imports it into the token.
+func (t *Token) ImportKey(seed []byte, path bip32path.Path) (ed25519.PublicKey, error) {
+	if err := t.checkAbsent(path); err != nil {
+		return nil, err
+	}
+	key, err := slip10.DeriveKeyFromPath(seed, eddsa.Ed25519(), path)
+	if err != nil {
+		return nil, err
+	}
+	//nolint:forcetypeassert // the Ed25519 curve always returns an eddsa.Seed
+	public, private := key.Key.(eddsa.Seed).Ed25519Key()
+	value := private.Seed()
+	defer clear(value)
+
+	label := []byte(path.String())
+	if _, err := t.session.CreateObject(append(privateTemplate(label),
+		UlongAttribute(AttributeClass, ClassPrivateKey),
+		UlongAttribute(AttributeKeyType, KeyTypeECEdwards),
+		&Attribute{AttributeValue, value},
+	)); err != nil {
+		return nil, err
+	}
+	if _, err := t.session.CreateObject([]*Attribute{
+		UlongAttribute(AttributeClass, ClassPublicKey),
+		UlongAttribute(AttributeKeyType, KeyTypeECEdwards),
+		BoolAttribute(AttributeToken, true),
+		BoolAttribute(AttributeVerify, true),
+		{AttributeLabel, label},
+		{AttributeECParams, ed25519Params},
+		{AttributeECPoint, encodePoint(public)},
+	}); err != nil {
+		return nil, err
+	}
+	return public, nil
+}
+
+// privateTemplate returns the attributes of a persistent private key, which can only be used for signing on the token.
+func privateTemplate(label []byte) []*Attribute {
+	return []*Attribute{
+		BoolAttribute(AttributeToken, true),
+		BoolAttribute(AttributePrivate, true),
+		BoolAttribute(AttributeSensitive, true),
+		BoolAttribute(AttributeExtractable, false),
+		BoolAttribute(AttributeSign, true),
+		{AttributeLabel, label},
+		{AttributeECParams, ed25519Params},
+	}
+}
+
+// find returns the handle of the single key of the given class stored for path.
+func (t *Token) find(class uint, path bip32path.Path) (ObjectHandle, error) {
+	handles, err := t.session.FindObjects([]*Attribute{
+		UlongAttribute(AttributeClass, class),
+		UlongAttribute(AttributeKeyType, KeyTypeECEdwards),
+		{AttributeLabel, []byte(path.String())},
+	})
+	if err != nil {
+		return 0, err
+	}
+	switch len(handles) {
+	case 0:
+		return 0, fmt.Errorf("%w: %s", ErrKeyNotFound, path)
+	case 1:
+		return handles[0], nil
+	default:
+		return 0, fmt.Errorf("%w: %d keys for %s", ErrInvalidResponse, len(handles), path)
+	}
+}
+
+func (t *Token) checkAbsent(path bip32path.Path) error {
+	_, err := t.find(ClassPrivateKey, path)
+	switch {
+	case err == nil:
+		return fmt.Errorf("%w: %s", ErrKeyExists, path)
+	case errors.Is(err, ErrKeyNotFound):
+		return nil
+	default:
+		return err
+	}
+}
+
+// encodePoint returns the CKA_EC_POINT value of the public key, i.e. its DER encoded OCTET STRING.
+func encodePoint(public ed25519.PublicKey) []byte {
+	return append([]byte{0x04, byte(len(public))}, public...)
+}
+
+// decodePoint decodes a CKA_EC_POINT value.
+// Besides the DER encoding required by the specification, the raw public key returned by some tokens is accepted.
+func decodePoint(point []byte) (ed25519.PublicKey, error) {
+	if len(point) == ed25519.PublicKeySize+2 && bytes.HasPrefix(point, []byte{0x04, ed25519.PublicKeySize}) {
+		point = point[2:]
+	}
+	if len(point) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("%w: public key length %d", ErrInvalidResponse, len(point))
+	}
+	return ed25519.PublicKey(append([]byte{}, point...)), nil
+}
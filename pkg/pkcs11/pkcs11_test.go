@@ -0,0 +1,147 @@
+package pkcs11_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip32path"
+	"github.com/iotaledger/iota-crypto-demo/pkg/ed25519"
+	"github.com/iotaledger/iota-crypto-demo/pkg/keystore/backend"
+	"github.com/iotaledger/iota-crypto-demo/pkg/pkcs11"
+)
+
+var seed, _ = hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+
+// softToken simulates a PKCS#11 token holding Ed25519 keys in memory.
+type softToken struct {
+	objects [][]*pkcs11.Attribute
+}
+
+func (s *softToken) attribute(h pkcs11.ObjectHandle, t pkcs11.AttributeType) []byte {
+	for _, a := range s.objects[h] {
+		if a.Type == t {
+			return a.Value
+		}
+	}
+	return nil
+}
+
+func (s *softToken) FindObjects(template []*pkcs11.Attribute) ([]pkcs11.ObjectHandle, error) {
+	var res []pkcs11.ObjectHandle
+	for h := range s.objects {
+		match := true
+		for _, a := range template {
+			match = match && bytes.Equal(s.attribute(pkcs11.ObjectHandle(h), a.Type), a.Value)
+		}
+		if match {
+			res = append(res, pkcs11.ObjectHandle(h))
+		}
+	}
+	return res, nil
+}
+
+func (s *softToken) GetAttributeValue(object pkcs11.ObjectHandle, t pkcs11.AttributeType) ([]byte, error) {
+	if t == pkcs11.AttributeValue {
+		return nil, pkcs11.Error(0x11) // CKR_ATTRIBUTE_SENSITIVE
+	}
+	return s.attribute(object, t), nil
+}
+
+func (s *softToken) CreateObject(template []*pkcs11.Attribute) (pkcs11.ObjectHandle, error) {
+	object := make([]*pkcs11.Attribute, len(template))
+	for i, a := range template {
+		object[i] = &pkcs11.Attribute{Type: a.Type, Value: append([]byte{}, a.Value...)}
+	}
+	s.objects = append(s.objects, object)
+	return pkcs11.ObjectHandle(len(s.objects) - 1), nil
+}
+
+func (s *softToken) GenerateKeyPair(mechanism uint, public, private []*pkcs11.Attribute) (pkcs11.ObjectHandle, pkcs11.ObjectHandle, error) {
+	if mechanism != pkcs11.MechanismECEdwardsKeyGen {
+		return 0, 0, pkcs11.Error(0x70) // CKR_MECHANISM_INVALID
+	}
+	pub, priv, _ := ed25519.GenerateKey(rand.Reader)
+	keyType := pkcs11.UlongAttribute(pkcs11.AttributeKeyType, pkcs11.KeyTypeECEdwards)
+	p, _ := s.CreateObject(append(public, pkcs11.UlongAttribute(pkcs11.AttributeClass, pkcs11.ClassPublicKey), keyType,
+		&pkcs11.Attribute{Type: pkcs11.AttributeECPoint, Value: append([]byte{0x04, 0x20}, pub...)}))
+	k, _ := s.CreateObject(append(private, pkcs11.UlongAttribute(pkcs11.AttributeClass, pkcs11.ClassPrivateKey), keyType,
+		&pkcs11.Attribute{Type: pkcs11.AttributeValue, Value: priv.Seed()}))
+	return p, k, nil
+}
+
+func (s *softToken) Sign(mechanism uint, key pkcs11.ObjectHandle, message []byte) ([]byte, error) {
+	if mechanism != pkcs11.MechanismEdDSA {
+		return nil, pkcs11.Error(0x70) // CKR_MECHANISM_INVALID
+	}
+	return ed25519.Sign(ed25519.NewKeyFromSeed(s.attribute(key, pkcs11.AttributeValue)), message), nil
+}
+
+func TestImportKey(t *testing.T) {
+	var store backend.KeyStore = pkcs11.NewToken(&softToken{})
+	token := store.(*pkcs11.Token)
+
+	path, _ := bip32path.ParsePath("m/44'/4218'/0'/0'/0'")
+	_, err := token.PublicKey(path)
+	assert.ErrorIs(t, err, pkcs11.ErrKeyNotFound)
+
+	public, err := token.ImportKey(seed, path)
+	require.NoError(t, err)
+	expected, err := backend.NewMemory(seed).PublicKey(path)
+	require.NoError(t, err)
+	assert.Equal(t, expected, public)
+
+	_, err = token.ImportKey(seed, path)
+	assert.ErrorIs(t, err, pkcs11.ErrKeyExists)
+
+	res, err := token.PublicKey(path)
+	require.NoError(t, err)
+	assert.Equal(t, expected, res)
+
+	// signatures of the token must be identical to those of the software derived key
+	sig, err := token.Sign(path, []byte("message"))
+	require.NoError(t, err)
+	expectedSig, err := backend.NewMemory(seed).Sign(path, []byte("message"))
+	require.NoError(t, err)
+	assert.Equal(t, expectedSig, sig)
+
+	_, err = token.Seed()
+	assert.ErrorIs(t, err, pkcs11.ErrNotExportable)
+}
+
+func TestGenerateKey(t *testing.T) {
+	token := pkcs11.NewToken(&softToken{})
+
+	path, _ := bip32path.ParsePath("m/44'/4218'/1'/0'/0'")
+	public, err := token.GenerateKey(path)
+	require.NoError(t, err)
+
+	res, err := token.PublicKey(path)
+	require.NoError(t, err)
+	assert.Equal(t, public, res)
+
+	sig, err := token.Sign(path, []byte("message"))
+	require.NoError(t, err)
+	assert.True(t, ed25519.Verify(public, []byte("message"), sig))
+
+	_, err = token.GenerateKey(path)
+	assert.ErrorIs(t, err, pkcs11.ErrKeyExists)
+
+	other, _ := bip32path.ParsePath("m/44'/4218'/1'/0'/1'")
+	_, err = token.Sign(other, []byte("message"))
+	assert.ErrorIs(t, err, pkcs11.ErrKeyNotFound)
+}
+
+func TestError(t *testing.T) {
+	assert.EqualError(t, pkcs11.Error(0xA0), "pkcs11: CKR_PIN_INCORRECT")
+	assert.EqualError(t, pkcs11.Error(0x1234), "pkcs11: error 0x1234")
+}
+
+func TestOpenInvalid(t *testing.T) {
+	_, err := pkcs11.Open("/nonexistent/libpkcs11.so")
+	assert.Error(t, err)
+}
@@ -0,0 +1,148 @@
+package qr
+
+import "strings"
+
+// mode denotes the encoding mode of the data.
+type mode int
+
+const (
+	modeNumeric mode = iota
+	modeAlphanumeric
+	modeByte
+)
+
+// alphanumericCharset contains the characters that can be encoded in alphanumeric mode, in the order of their values.
+const alphanumericCharset = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ $%*+-./:"
+
+// indicator returns the 4-bit mode indicator.
+func (m mode) indicator() uint {
+	return [...]uint{0b0001, 0b0010, 0b0100}[m]
+}
+
+// countBits returns the number of bits of the character count indicator for the given version.
+func (m mode) countBits(version int) int {
+	if version < 10 {
+		return [...]int{10, 9, 8}[m]
+	}
+	return [...]int{12, 11, 16}[m]
+}
+
+// bitLen returns the number of bits needed to encode n characters without the mode and count indicator.
+func (m mode) bitLen(n int) int {
+	switch m {
+	case modeNumeric:
+		return 10*(n/3) + [...]int{0, 4, 7}[n%3]
+	case modeAlphanumeric:
+		return 11*(n/2) + 6*(n%2)
+	}
+	return 8 * n
+}
+
+// selectMode returns the most compact mode that can encode all of data.
+func selectMode(data []byte) mode {
+	m := modeNumeric
+	for _, c := range data {
+		switch {
+		case c >= '0' && c <= '9':
+		case strings.IndexByte(alphanumericCharset, c) >= 0:
+			m = max(m, modeAlphanumeric)
+		default:
+			return modeByte
+		}
+	}
+	return m
+}
+
+// bitBuffer is an append-only sequence of bits.
+type bitBuffer struct {
+	bytes []byte
+	n     int // number of bits
+}
+
+// write appends the lowest n bits of v in big-endian order.
+func (b *bitBuffer) write(v uint, n int) {
+	for i := n - 1; i >= 0; i-- {
+		if b.n%8 == 0 {
+			b.bytes = append(b.bytes, 0)
+		}
+		if v>>i&1 != 0 {
+			b.bytes[b.n/8] |= 0x80 >> (b.n % 8)
+		}
+		b.n++
+	}
+}
+
+// encodeData returns the data codewords of a single segment with mode m, padded to the capacity of version and level.
+func encodeData(data []byte, m mode, version int, level Level) []byte {
+	var b bitBuffer
+	b.write(m.indicator(), 4)
+	b.write(uint(len(data)), m.countBits(version))
+	switch m {
+	case modeNumeric:
+		for i := 0; i < len(data); i += 3 {
+			n := min(3, len(data)-i)
+			var v uint
+			for _, c := range data[i : i+n] {
+				v = v*10 + uint(c-'0')
+			}
+			b.write(v, 3*n+1)
+		}
+	case modeAlphanumeric:
+		for i := 0; i < len(data); i += 2 {
+			v := uint(strings.IndexByte(alphanumericCharset, data[i]))
+			if i+1 < len(data) {
+				v = v*45 + uint(strings.IndexByte(alphanumericCharset, data[i+1]))
+				b.write(v, 11)
+			} else {
+				b.write(v, 6)
+			}
+		}
+	case modeByte:
+		for _, c := range data {
+			b.write(uint(c), 8)
+		}
+	}
+
+	capacity := 8 * dataCodewords(version, level)
+	// add the terminator and pad to a byte boundary
+	b.write(0, min(4, capacity-b.n))
+	b.write(0, (8-b.n%8)%8)
+	// fill the remaining capacity with the alternating pad codewords
+	for pad := uint(0xEC); b.n < capacity; pad ^= 0xEC ^ 0x11 {
+		b.write(pad, 8)
+	}
+	return b.bytes
+}
+
+// addErrorCorrection splits data into blocks, computes their error correction codewords and interleaves the result.
+func addErrorCorrection(data []byte, version int, level Level) []byte {
+	b := blocks[version][level]
+	generator := rsGenerator(b.ecLen)
+
+	numBlocks := b.groups[0] + b.groups[1]
+	dataBlocks := make([][]byte, numBlocks)
+	ecBlocks := make([][]byte, numBlocks)
+	for i := range dataBlocks {
+		n := b.dataLen
+		if i >= b.groups[0] {
+			n++
+		}
+		dataBlocks[i], data = data[:n], data[n:]
+		ecBlocks[i] = rsRemainder(dataBlocks[i], generator)
+	}
+
+	res := make([]byte, 0, numBlocks*(b.dataLen+1+b.ecLen))
+	for i := 0; i <= b.dataLen; i++ {
+		for _, d := range dataBlocks {
+			if i < len(d) {
+				res = append(res, d[i])
+			}
+		}
+	}
+	for i := 0; i < b.ecLen; i++ {
+		for _, e := range ecBlocks {
+			res = append(res, e[i])
+		}
+	}
+	return res
+}
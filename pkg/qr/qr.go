@@ -0,0 +1,167 @@
+/*
+Package qr implements the generation of QR codes as specified in ISO/IEC 18004 for versions 1 to 10.
+
+It is meant to transfer addresses and mnemonic backups between air-gapped devices. Addresses are encoded in their
+all-uppercase Bech32 form, which allows the compact alphanumeric mode, and mnemonics are encoded following the SeedQR
+format.
+*/
+package qr
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+)
+
+// Level denotes the error correction level of a QR code.
+type Level int
+
+// Error correction levels in increasing order of redundancy.
+const (
+	// L allows recovery of approximately 7% of the codewords.
+	L Level = iota
+	// M allows recovery of approximately 15% of the codewords.
+	M
+	// Q allows recovery of approximately 25% of the codewords.
+	Q
+	// H allows recovery of approximately 30% of the codewords.
+	H
+)
+
+// formatBits returns the two bits identifying the level in the format information.
+func (l Level) formatBits() int {
+	return [...]int{0b01, 0b00, 0b11, 0b10}[l]
+}
+
+// QuietZone is the number of light modules surrounding the QR code in the rendered outputs.
+const QuietZone = 4
+
+var (
+	// ErrInvalidLevel is returned when an unknown error correction level is used.
+	ErrInvalidLevel = errors.New("invalid error correction level")
+	// ErrTooLong is returned when the data does not fit in a QR code of the largest supported version.
+	ErrTooLong = errors.New("data too long")
+)
+
+// Code represents an encoded QR code.
+type Code struct {
+	version int
+	size    int
+	modules []bool
+}
+
+// Encode encodes text as a QR code with the error correction level.
+// It uses the most compact of the numeric, alphanumeric or byte mode that can represent text.
+func Encode(text string, level Level) (*Code, error) {
+	data := []byte(text)
+	return encode(data, selectMode(data), level)
+}
+
+// EncodeBytes encodes the binary data as a QR code with the error correction level using the byte mode.
+func EncodeBytes(data []byte, level Level) (*Code, error) {
+	return encode(data, modeByte, level)
+}
+
+func encode(data []byte, m mode, level Level) (*Code, error) {
+	if level < L || level > H {
+		return nil, ErrInvalidLevel
+	}
+	// find the smallest version fitting the data
+	version := 1
+	for ; version <= MaxVersion; version++ {
+		if 4+m.countBits(version)+m.bitLen(len(data)) <= 8*dataCodewords(version, level) &&
+			len(data) < 1<<m.countBits(version) {
+			break
+		}
+	}
+	if version > MaxVersion {
+		return nil, ErrTooLong
+	}
+
+	codewords := addErrorCorrection(encodeData(data, m, version, level), version, level)
+	s := newSymbol(version)
+	s.drawFunctionPatterns(version)
+	s.drawCodewords(codewords)
+
+	// select the mask with the lowest penalty
+	best, bestPenalty := 0, -1
+	for mask := 0; mask < 8; mask++ {
+		s.applyMask(mask)
+		s.drawFormat(level.formatBits(), mask)
+		if p := s.penalty(); bestPenalty < 0 || p < bestPenalty {
+			best, bestPenalty = mask, p
+		}
+		s.applyMask(mask)
+	}
+	s.applyMask(best)
+	s.drawFormat(level.formatBits(), best)
+
+	return &Code{version: version, size: s.size, modules: s.modules}, nil
+}
+
+// Version returns the version of the QR code.
+func (c *Code) Version() int {
+	return c.version
+}
+
+// Size returns the number of modules per side of the QR code.
+func (c *Code) Size() int {
+	return c.size
+}
+
+// Black reports whether the module at column x and row y is dark.
+// Coordinates outside the QR code are light.
+func (c *Code) Black(x, y int) bool {
+	return x >= 0 && x < c.size && y >= 0 && y < c.size && c.modules[y*c.size+x]
+}
+
+// Image returns the QR code as an image with each module rendered as scale x scale pixels.
+// The image includes the quiet zone.
+func (c *Code) Image(scale int) image.Image {
+	scale = max(scale, 1)
+	n := (c.size + 2*QuietZone) * scale
+	img := image.NewPaletted(image.Rect(0, 0, n, n), color.Palette{color.White, color.Black})
+	for y := 0; y < n; y++ {
+		for x := 0; x < n; x++ {
+			if c.Black(x/scale-QuietZone, y/scale-QuietZone) {
+				img.SetColorIndex(x, y, 1)
+			}
+		}
+	}
+	return img
+}
+
+// PNG returns the PNG encoding of the QR code image with the given scale.
+func (c *Code) PNG(scale int) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, c.Image(scale)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ASCII returns a text rendering of the QR code including the quiet zone for terminals.
+// It uses Unicode half blocks, so that each line represents two rows of modules, and assumes dark text on a light
+// background.
+func (c *Code) ASCII() string {
+	var b strings.Builder
+	for y := -QuietZone; y < c.size+QuietZone; y += 2 {
+		for x := -QuietZone; x < c.size+QuietZone; x++ {
+			switch top, bottom := c.Black(x, y), c.Black(x, y+1); {
+			case top && bottom:
+				b.WriteString("█")
+			case top:
+				b.WriteString("▀")
+			case bottom:
+				b.WriteString("▄")
+			default:
+				b.WriteByte(' ')
+			}
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
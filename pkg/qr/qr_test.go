@@ -0,0 +1,120 @@
+//nolint:scopelint
+package qr
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip39"
+)
+
+// test vector from the tutorial at https://www.thonky.com/qr-code-tutorial/.
+func TestCodewords(t *testing.T) {
+	data := encodeData([]byte("HELLO WORLD"), modeAlphanumeric, 1, M)
+	assert.Equal(t, []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}, data)
+
+	codewords := addErrorCorrection(data, 1, M)
+	assert.Equal(t, []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}, codewords[len(data):])
+}
+
+func TestVersion(t *testing.T) {
+	var tests = []*struct {
+		text       string
+		level      Level
+		expVersion int
+	}{
+		{strings.Repeat("1", 41), L, 1},
+		{strings.Repeat("1", 42), L, 2},
+		{strings.Repeat("A", 25), L, 1},
+		{strings.Repeat("A", 26), L, 2},
+		{strings.Repeat("a", 17), L, 1},
+		{strings.Repeat("a", 18), L, 2},
+		{strings.Repeat("a", 271), L, 10},
+		{strings.Repeat("A", 174), H, 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			c, err := Encode(tt.text, tt.level)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expVersion, c.Version())
+			assert.Equal(t, 4*tt.expVersion+17, c.Size())
+		})
+	}
+
+	_, err := Encode(strings.Repeat("a", 272), L)
+	assert.ErrorIs(t, err, ErrTooLong)
+	_, err = Encode("", Level(4))
+	assert.ErrorIs(t, err, ErrInvalidLevel)
+}
+
+func TestFunctionPatterns(t *testing.T) {
+	c, err := EncodeAddress("iota1qrhacyfwlcnzkvzteumekfkrrwks98mpdm37cj4xx3drvmjvnep6xqgyzyx")
+	require.NoError(t, err)
+
+	// finder patterns
+	for _, corner := range [][2]int{{0, 0}, {c.Size() - 7, 0}, {0, c.Size() - 7}} {
+		for i := 0; i < 7; i++ {
+			assert.True(t, c.Black(corner[0]+i, corner[1]))
+			assert.True(t, c.Black(corner[0], corner[1]+i))
+			assert.False(t, c.Black(corner[0]+1+i%5, corner[1]+1))
+		}
+	}
+	// timing patterns
+	for i := 8; i < c.Size()-8; i++ {
+		assert.Equal(t, i%2 == 0, c.Black(i, 6))
+		assert.Equal(t, i%2 == 0, c.Black(6, i))
+	}
+	// dark module
+	assert.True(t, c.Black(8, c.Size()-8))
+
+	lines := strings.Split(strings.TrimSuffix(c.ASCII(), "\n"), "\n")
+	assert.Len(t, lines, (c.Size()+2*QuietZone+1)/2)
+
+	b, err := c.PNG(2)
+	require.NoError(t, err)
+	assert.Equal(t, "\x89PNG", string(b[:4]))
+	assert.Equal(t, 2*(c.Size()+2*QuietZone), c.Image(2).Bounds().Dx())
+}
+
+func TestSeedQR(t *testing.T) {
+	var tests = []*struct {
+		mnemonic          string
+		expPayload        string
+		expVersion        int
+		expCompactVersion int
+	}{
+		{
+			mnemonic:          "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+			expPayload:        strings.Repeat("0000", 11) + "0003",
+			expVersion:        2,
+			expCompactVersion: 1,
+		},
+		{
+			mnemonic:          "zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo vote",
+			expPayload:        strings.Repeat("2047", 23) + "1967",
+			expVersion:        3,
+			expCompactVersion: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mnemonic, func(t *testing.T) {
+			m := bip39.ParseMnemonic(tt.mnemonic)
+			payload, err := SeedQR(m)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expPayload, payload)
+
+			c, err := EncodeSeedQR(m, false)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expVersion, c.Version())
+
+			c, err = EncodeSeedQR(m, true)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expCompactVersion, c.Version())
+		})
+	}
+}
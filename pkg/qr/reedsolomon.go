@@ -0,0 +1,60 @@
+package qr
+
+// gfExp and gfLog contain the exponent and logarithm tables of GF(2⁸) with the reducing polynomial x⁸+x⁴+x³+x²+1.
+var gfExp, gfLog = func() (exp [512]byte, log [256]byte) {
+	x := 1
+	for i := 0; i < 255; i++ {
+		exp[i] = byte(x)
+		log[x] = byte(i)
+		x <<= 1
+		if x&0x100 != 0 {
+			x ^= 0x11D
+		}
+	}
+	// duplicate the table to avoid the modulo in gfMul
+	for i := 255; i < len(exp); i++ {
+		exp[i] = exp[i-255]
+	}
+	return exp, log
+}()
+
+func gfMul(a, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return gfExp[int(gfLog[a])+int(gfLog[b])]
+}
+
+// rsGenerator returns the coefficients of the Reed-Solomon generator polynomial ∏(x - αⁱ) for i = 0,…,n-1,
+// starting with the highest degree and omitting the leading coefficient 1.
+func rsGenerator(n int) []byte {
+	g := make([]byte, n)
+	g[n-1] = 1
+	root := byte(1)
+	for i := 0; i < n; i++ {
+		// multiply by (x - root)
+		for j := 0; j < n; j++ {
+			g[j] = gfMul(g[j], root)
+			if j+1 < n {
+				g[j] ^= g[j+1]
+			}
+		}
+		root = gfMul(root, 2)
+	}
+	return g
+}
+
+// rsRemainder returns the n error correction codewords for data, i.e. the remainder of the division by the
+// generator polynomial.
+func rsRemainder(data []byte, generator []byte) []byte {
+	res := make([]byte, len(generator))
+	for _, b := range data {
+		factor := b ^ res[0]
+		copy(res, res[1:])
+		res[len(res)-1] = 0
+		for i := range res {
+			res[i] ^= gfMul(generator[i], factor)
+		}
+	}
+	return res
+}
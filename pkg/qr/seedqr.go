@@ -0,0 +1,68 @@
+package qr
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"strings"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip39"
+)
+
+// EncodeAddress encodes the Bech32 address s as a QR code.
+// The address is converted to uppercase, so that the alphanumeric mode can be used, and error correction level M is
+// applied.
+func EncodeAddress(s string) (*Code, error) {
+	return Encode(strings.ToUpper(s), M)
+}
+
+// SeedQR returns the payload of the Standard SeedQR of mnemonic, i.e. the four-digit zero-padded word indices.
+// The indices are always those of the English word list, independent of the language of the mnemonic.
+func SeedQR(mnemonic bip39.Mnemonic) (string, error) {
+	entropy, err := bip39.MnemonicToEntropy(mnemonic)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	for _, index := range wordIndices(entropy) {
+		fmt.Fprintf(&b, "%04d", index)
+	}
+	return b.String(), nil
+}
+
+// CompactSeedQR returns the payload of the Compact SeedQR of mnemonic, i.e. its entropy without the checksum.
+func CompactSeedQR(mnemonic bip39.Mnemonic) ([]byte, error) {
+	return bip39.MnemonicToEntropy(mnemonic)
+}
+
+// EncodeSeedQR encodes mnemonic as a Standard SeedQR or, if compact is set, as a Compact SeedQR.
+// As specified by SeedQR, error correction level L is applied.
+func EncodeSeedQR(mnemonic bip39.Mnemonic, compact bool) (*Code, error) {
+	if compact {
+		entropy, err := CompactSeedQR(mnemonic)
+		if err != nil {
+			return nil, err
+		}
+		return EncodeBytes(entropy, L)
+	}
+	payload, err := SeedQR(mnemonic)
+	if err != nil {
+		return nil, err
+	}
+	return Encode(payload, L)
+}
+
+// wordIndices returns the 11-bit word indices of the mnemonic corresponding to entropy.
+func wordIndices(entropy []byte) []int {
+	checksum := sha256.Sum256(entropy)
+	bits := append(append([]byte{}, entropy...), checksum[0])
+	n := len(entropy) * 8 * 33 / 32 / 11
+
+	indices := make([]int, n)
+	for i := range indices {
+		for j := 0; j < 11; j++ {
+			pos := i*11 + j
+			indices[i] = indices[i]<<1 | int(bits[pos/8]>>(7-pos%8)&1)
+		}
+	}
+	return indices
+}
@@ -0,0 +1,265 @@
+package qr
+
+// symbol is the module matrix of a QR code under construction.
+type symbol struct {
+	size     int
+	modules  []bool // dark modules
+	function []bool // modules belonging to function patterns, which are not masked
+}
+
+func newSymbol(version int) *symbol {
+	n := size(version)
+	return &symbol{
+		size:     n,
+		modules:  make([]bool, n*n),
+		function: make([]bool, n*n),
+	}
+}
+
+func (s *symbol) get(x, y int) bool {
+	return s.modules[y*s.size+x]
+}
+
+func (s *symbol) setFunction(x, y int, dark bool) {
+	s.modules[y*s.size+x] = dark
+	s.function[y*s.size+x] = true
+}
+
+// drawFunctionPatterns draws the timing, finder and alignment patterns and reserves the format and version areas.
+func (s *symbol) drawFunctionPatterns(version int) {
+	// timing patterns
+	for i := 0; i < s.size; i++ {
+		s.setFunction(6, i, i%2 == 0)
+		s.setFunction(i, 6, i%2 == 0)
+	}
+	// finder patterns including their separators
+	s.drawFinder(3, 3)
+	s.drawFinder(s.size-4, 3)
+	s.drawFinder(3, s.size-4)
+	// alignment patterns, except those overlapping the finder patterns
+	pos := alignmentPositions[version]
+	last := len(pos) - 1
+	for i := range pos {
+		for j := range pos {
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+			s.drawAlignment(pos[i], pos[j])
+		}
+	}
+	// reserve the format areas with a dummy value
+	s.drawFormat(0, 0)
+	s.drawVersion(version)
+}
+
+func (s *symbol) drawFinder(cx, cy int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			x, y := cx+dx, cy+dy
+			if x < 0 || x >= s.size || y < 0 || y >= s.size {
+				continue
+			}
+			dist := max(abs(dx), abs(dy))
+			s.setFunction(x, y, dist != 2 && dist != 4)
+		}
+	}
+}
+
+func (s *symbol) drawAlignment(cx, cy int) {
+	for dy := -2; dy <= 2; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			s.setFunction(cx+dx, cy+dy, max(abs(dx), abs(dy)) != 1)
+		}
+	}
+}
+
+// drawFormat draws both copies of the format information for the level bits and the mask.
+func (s *symbol) drawFormat(levelBits int, mask int) {
+	data := levelBits<<3 | mask
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = rem<<1 ^ (rem>>9)*0x537
+	}
+	bits := (data<<10 | rem) ^ 0x5412
+
+	bit := func(i int) bool { return bits>>i&1 != 0 }
+	// first copy around the top left finder pattern
+	for i := 0; i <= 5; i++ {
+		s.setFunction(8, i, bit(i))
+	}
+	s.setFunction(8, 7, bit(6))
+	s.setFunction(8, 8, bit(7))
+	s.setFunction(7, 8, bit(8))
+	for i := 9; i < 15; i++ {
+		s.setFunction(14-i, 8, bit(i))
+	}
+	// second copy split between the other two finder patterns
+	for i := 0; i < 8; i++ {
+		s.setFunction(s.size-1-i, 8, bit(i))
+	}
+	for i := 8; i < 15; i++ {
+		s.setFunction(8, s.size-15+i, bit(i))
+	}
+	// the dark module
+	s.setFunction(8, s.size-8, true)
+}
+
+// drawVersion draws both copies of the version information, which is only present for version 7 and above.
+func (s *symbol) drawVersion(version int) {
+	if version < 7 {
+		return
+	}
+	rem := version
+	for i := 0; i < 12; i++ {
+		rem = rem<<1 ^ (rem>>11)*0x1F25
+	}
+	bits := version<<12 | rem
+	for i := 0; i < 18; i++ {
+		dark := bits>>i&1 != 0
+		a, b := s.size-11+i%3, i/3
+		s.setFunction(a, b, dark)
+		s.setFunction(b, a, dark)
+	}
+}
+
+// drawCodewords places the codewords in the non-function modules in the two-module wide zigzag pattern.
+func (s *symbol) drawCodewords(data []byte) {
+	i := 0
+	for right := s.size - 1; right >= 1; right -= 2 {
+		// skip the vertical timing pattern
+		if right == 6 {
+			right = 5
+		}
+		upward := (right+1)&2 == 0
+		for vert := 0; vert < s.size; vert++ {
+			y := vert
+			if upward {
+				y = s.size - 1 - vert
+			}
+			for j := 0; j < 2; j++ {
+				x := right - j
+				if s.function[y*s.size+x] || i >= len(data)*8 {
+					continue
+				}
+				s.modules[y*s.size+x] = data[i/8]>>(7-i%8)&1 != 0
+				i++
+			}
+		}
+	}
+}
+
+// applyMask inverts all non-function modules selected by the mask pattern. Applying it twice undoes it.
+func (s *symbol) applyMask(mask int) {
+	for y := 0; y < s.size; y++ {
+		for x := 0; x < s.size; x++ {
+			var invert bool
+			switch mask {
+			case 0:
+				invert = (x+y)%2 == 0
+			case 1:
+				invert = y%2 == 0
+			case 2:
+				invert = x%3 == 0
+			case 3:
+				invert = (x+y)%3 == 0
+			case 4:
+				invert = (x/3+y/2)%2 == 0
+			case 5:
+				invert = x*y%2+x*y%3 == 0
+			case 6:
+				invert = (x*y%2+x*y%3)%2 == 0
+			case 7:
+				invert = ((x+y)%2+x*y%3)%2 == 0
+			}
+			if invert && !s.function[y*s.size+x] {
+				s.modules[y*s.size+x] = !s.modules[y*s.size+x]
+			}
+		}
+	}
+}
+
+// penalty computes the penalty score of the symbol as defined in ISO/IEC 18004, Section 7.8.3.
+func (s *symbol) penalty() int {
+	score := 0
+	line := make([]bool, s.size)
+	for _, transpose := range []bool{false, true} {
+		for i := 0; i < s.size; i++ {
+			for j := 0; j < s.size; j++ {
+				if transpose {
+					line[j] = s.get(i, j)
+				} else {
+					line[j] = s.get(j, i)
+				}
+			}
+			score += linePenalty(line)
+		}
+	}
+	// 2x2 blocks of the same color
+	dark := 0
+	for y := 0; y < s.size; y++ {
+		for x := 0; x < s.size; x++ {
+			c := s.get(x, y)
+			if c {
+				dark++
+			}
+			if x+1 < s.size && y+1 < s.size && c == s.get(x+1, y) && c == s.get(x, y+1) && c == s.get(x+1, y+1) {
+				score += 3
+			}
+		}
+	}
+	// balance of dark and light modules: 10 points for every 5% deviation from 50%
+	total := s.size * s.size
+	score += abs(dark*20-total*10) / total * 10
+	return score
+}
+
+// linePenalty returns the penalty for runs of the same color and finder-like patterns in a single row or column.
+func linePenalty(line []bool) int {
+	score := 0
+	run := 1
+	for i := 1; i <= len(line); i++ {
+		if i < len(line) && line[i] == line[i-1] {
+			run++
+			continue
+		}
+		if run >= 5 {
+			score += 3 + run - 5
+		}
+		run = 1
+	}
+	// 1:1:3:1:1 patterns preceded or followed by four light modules
+	pattern := []bool{true, false, true, true, true, false, true}
+	for i := 0; i+len(pattern) <= len(line); i++ {
+		match := true
+		for j, p := range pattern {
+			if line[i+j] != p {
+				match = false
+				break
+			}
+		}
+		if !match {
+			continue
+		}
+		if isLight(line, i-4, i) || isLight(line, i+len(pattern), i+len(pattern)+4) {
+			score += 40
+		}
+	}
+	return score
+}
+
+// isLight reports whether all modules of line in [from, to) are light. Modules outside the symbol are light.
+func isLight(line []bool, from, to int) bool {
+	for i := from; i < to; i++ {
+		if i >= 0 && i < len(line) && line[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -0,0 +1,50 @@
+package qr
+
+// MaxVersion is the largest supported QR code version.
+// Version 10 corresponds to 57x57 modules, which is sufficient for addresses and mnemonic backups.
+const MaxVersion = 10
+
+// block describes the structure of the error correction blocks of a certain version and level.
+type block struct {
+	ecLen   int    // number of error correction codewords per block
+	groups  [2]int // number of blocks in each of the two groups
+	dataLen int    // number of data codewords per block in the first group; the second group has one more
+}
+
+// blocks contains the error correction structure as defined in ISO/IEC 18004, Table 9, indexed by version and level.
+var blocks = [MaxVersion + 1][4]block{
+	1:  {{7, [2]int{1, 0}, 19}, {10, [2]int{1, 0}, 16}, {13, [2]int{1, 0}, 13}, {17, [2]int{1, 0}, 9}},
+	2:  {{10, [2]int{1, 0}, 34}, {16, [2]int{1, 0}, 28}, {22, [2]int{1, 0}, 22}, {28, [2]int{1, 0}, 16}},
+	3:  {{15, [2]int{1, 0}, 55}, {26, [2]int{1, 0}, 44}, {18, [2]int{2, 0}, 17}, {22, [2]int{2, 0}, 13}},
+	4:  {{20, [2]int{1, 0}, 80}, {18, [2]int{2, 0}, 32}, {26, [2]int{2, 0}, 24}, {16, [2]int{4, 0}, 9}},
+	5:  {{26, [2]int{1, 0}, 108}, {24, [2]int{2, 0}, 43}, {18, [2]int{2, 2}, 15}, {22, [2]int{2, 2}, 11}},
+	6:  {{18, [2]int{2, 0}, 68}, {16, [2]int{4, 0}, 27}, {24, [2]int{4, 0}, 19}, {28, [2]int{4, 0}, 15}},
+	7:  {{20, [2]int{2, 0}, 78}, {18, [2]int{4, 0}, 31}, {18, [2]int{2, 4}, 14}, {26, [2]int{4, 1}, 13}},
+	8:  {{24, [2]int{2, 0}, 97}, {22, [2]int{2, 2}, 38}, {22, [2]int{4, 2}, 18}, {26, [2]int{4, 2}, 14}},
+	9:  {{30, [2]int{2, 0}, 116}, {22, [2]int{3, 2}, 36}, {20, [2]int{4, 4}, 16}, {24, [2]int{4, 4}, 12}},
+	10: {{18, [2]int{2, 2}, 68}, {26, [2]int{4, 1}, 43}, {24, [2]int{6, 2}, 19}, {28, [2]int{6, 2}, 15}},
+}
+
+// alignmentPositions contains the row/column coordinates of the alignment pattern centers for each version.
+var alignmentPositions = [MaxVersion + 1][]int{
+	2:  {6, 18},
+	3:  {6, 22},
+	4:  {6, 26},
+	5:  {6, 30},
+	6:  {6, 34},
+	7:  {6, 22, 38},
+	8:  {6, 24, 42},
+	9:  {6, 26, 46},
+	10: {6, 28, 50},
+}
+
+// dataCodewords returns the total number of data codewords of the given version and level.
+func dataCodewords(version int, level Level) int {
+	b := blocks[version][level]
+	return b.groups[0]*b.dataLen + b.groups[1]*(b.dataLen+1)
+}
+
+// size returns the number of modules per side of the given version.
+func size(version int) int {
+	return 4*version + 17
+}
@@ -0,0 +1,348 @@
+/*
+Package ristretto255 implements the ristretto255 prime-order group as specified in RFC 9496.
+
+The group is built on top of the edwards25519 curve, so that no second curve implementation is needed, but unlike
+edwards25519 it has prime order and every element has a unique canonical encoding. This makes it a safe foundation
+for higher-level protocols such as VRFs, threshold signatures or Pedersen commitments.
+*/
+package ristretto255
+
+import (
+	"crypto/sha512"
+	"errors"
+	"math/big"
+
+	"filippo.io/edwards25519"
+	"filippo.io/edwards25519/field"
+)
+
+const (
+	// ElementSize is the size, in bytes, of encoded elements.
+	ElementSize = 32
+	// UniformSize is the size, in bytes, of the uniformly random input for deriving an element.
+	UniformSize = 64
+)
+
+// ErrInvalidEncoding is returned when decoding an invalid or non-canonical element encoding.
+var ErrInvalidEncoding = errors.New("ristretto255: invalid element encoding")
+
+// Scalar is an integer modulo the group order ℓ = 2²⁵² + 27742317777372353535851937790883648493.
+// The group order of ristretto255 equals the prime subgroup order of edwards25519, so the scalars are shared.
+type Scalar = edwards25519.Scalar
+
+// NewScalar returns a new zero Scalar.
+func NewScalar() *Scalar {
+	return edwards25519.NewScalar()
+}
+
+// HashToScalar returns the scalar derived from the SHA-512 hash of msg.
+func HashToScalar(msg []byte) *Scalar {
+	h := sha512.Sum512(msg)
+	s, err := edwards25519.NewScalar().SetUniformBytes(h[:])
+	if err != nil {
+		panic("ristretto255: internal error: setting scalar failed")
+	}
+	return s
+}
+
+// constants of RFC 9496, Section 4.1
+var (
+	d                = mustElement("37095705934669439343138083508754565189542113879843219016388785533085940283555")
+	sqrtM1           = mustElement("19681161376707505956807079304988542015446066515923890162744021073123829784752")
+	sqrtADMinusOne   = mustElement("25063068953384623474111414158702152701244531502492656460079210482610430750235")
+	invSqrtAMinusD   = mustElement("54469307008909316920995813868745141605393597292927456921205312896311721017578")
+	oneMinusDSquared = mustElement("1159843021668779879193775521855586647937357759715417654439879720876111806838")
+	dMinusOneSquared = mustElement("40440834346308536858101042469323190826248399146238708352240133220865137265952")
+)
+
+// mustElement returns the field element of the decimal string s.
+func mustElement(s string) *field.Element {
+	n, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		panic("ristretto255: invalid constant " + s)
+	}
+	var b [32]byte
+	n.FillBytes(b[:])
+	// convert to little-endian
+	for i := 0; i < len(b)/2; i++ {
+		b[i], b[len(b)-1-i] = b[len(b)-1-i], b[i]
+	}
+	e, err := new(field.Element).SetBytes(b[:])
+	if err != nil {
+		panic(err)
+	}
+	return e
+}
+
+// Element is an element of the ristretto255 group. The zero value is not valid, use NewElement.
+type Element struct {
+	p edwards25519.Point
+}
+
+// NewElement returns a new Element set to the identity.
+func NewElement() *Element {
+	e := &Element{}
+	e.p.Set(edwards25519.NewIdentityPoint())
+	return e
+}
+
+// NewGeneratorElement returns a new Element set to the canonical generator.
+func NewGeneratorElement() *Element {
+	e := &Element{}
+	e.p.Set(edwards25519.NewGeneratorPoint())
+	return e
+}
+
+// HashToElement returns the element derived from the SHA-512 hash of msg.
+func HashToElement(msg []byte) *Element {
+	h := sha512.Sum512(msg)
+	e, err := new(Element).SetUniformBytes(h[:])
+	if err != nil {
+		panic("ristretto255: internal error: setting element failed")
+	}
+	return e
+}
+
+// Set sets e = x, and returns e.
+func (e *Element) Set(x *Element) *Element {
+	e.p.Set(&x.p)
+	return e
+}
+
+// Equal returns 1 if e is equivalent to x, and 0 otherwise.
+func (e *Element) Equal(x *Element) int {
+	x1, y1, _, _ := e.p.ExtendedCoordinates()
+	x2, y2, _, _ := x.p.ExtendedCoordinates()
+
+	var f0, f1 field.Element
+	f0.Multiply(x1, y2)
+	f1.Multiply(y1, x2)
+	out := f0.Equal(&f1)
+	f0.Multiply(y1, y2)
+	f1.Multiply(x1, x2)
+	return out | f0.Equal(&f1)
+}
+
+// Add sets e = x + y, and returns e.
+func (e *Element) Add(x, y *Element) *Element {
+	e.p.Add(&x.p, &y.p)
+	return e
+}
+
+// Subtract sets e = x - y, and returns e.
+func (e *Element) Subtract(x, y *Element) *Element {
+	e.p.Subtract(&x.p, &y.p)
+	return e
+}
+
+// Negate sets e = -x, and returns e.
+func (e *Element) Negate(x *Element) *Element {
+	e.p.Negate(&x.p)
+	return e
+}
+
+// ScalarMult sets e = s * x, and returns e.
+func (e *Element) ScalarMult(s *Scalar, x *Element) *Element {
+	e.p.ScalarMult(s, &x.p)
+	return e
+}
+
+// ScalarBaseMult sets e = s * G, where G is the generator, and returns e.
+func (e *Element) ScalarBaseMult(s *Scalar) *Element {
+	e.p.ScalarBaseMult(s)
+	return e
+}
+
+// VarTimeMultiScalarMult sets e = ∑scalars[i] * elements[i], and returns e. It will panic if the lengths differ.
+// Execution time depends on the inputs.
+func (e *Element) VarTimeMultiScalarMult(scalars []*Scalar, elements []*Element) *Element {
+	points := make([]*edwards25519.Point, len(elements))
+	for i := range elements {
+		points[i] = &elements[i].p
+	}
+	e.p.VarTimeMultiScalarMult(scalars, points)
+	return e
+}
+
+// Bytes returns the canonical 32-byte encoding of e as specified in RFC 9496, Section 4.3.2.
+func (e *Element) Bytes() []byte {
+	// bytes is outlined, so that the buffer can be stack-allocated when Bytes is inlined
+	var buf [ElementSize]byte
+	return e.bytes(&buf)
+}
+
+func (e *Element) bytes(buf *[ElementSize]byte) []byte {
+	x0, y0, z0, t0 := e.p.ExtendedCoordinates()
+
+	var u1, u2, tmp field.Element
+	u1.Add(z0, y0)
+	tmp.Subtract(z0, y0)
+	u1.Multiply(&u1, &tmp)
+	u2.Multiply(x0, y0)
+
+	var invSqrt field.Element
+	tmp.Square(&u2)
+	tmp.Multiply(&tmp, &u1)
+	invSqrt.SqrtRatio(new(field.Element).One(), &tmp)
+
+	var den1, den2, zInv field.Element
+	den1.Multiply(&invSqrt, &u1)
+	den2.Multiply(&invSqrt, &u2)
+	zInv.Multiply(&den1, &den2)
+	zInv.Multiply(&zInv, t0)
+
+	var ix0, iy0, enchantedDenominator field.Element
+	ix0.Multiply(x0, sqrtM1)
+	iy0.Multiply(y0, sqrtM1)
+	enchantedDenominator.Multiply(&den1, invSqrtAMinusD)
+
+	rotate := tmp.Multiply(t0, &zInv).IsNegative()
+	var x, y, denInv field.Element
+	x.Select(&iy0, x0, rotate)
+	y.Select(&ix0, y0, rotate)
+	denInv.Select(&enchantedDenominator, &den2, rotate)
+
+	tmp.Multiply(&x, &zInv)
+	y.Select(new(field.Element).Negate(&y), &y, tmp.IsNegative())
+
+	var s field.Element
+	s.Subtract(z0, &y)
+	s.Multiply(&denInv, &s)
+	s.Absolute(&s)
+
+	copy(buf[:], s.Bytes())
+	return buf[:]
+}
+
+// SetCanonicalBytes sets e to the decoding of the canonical encoding b as specified in RFC 9496, Section 4.3.1,
+// and returns e. If b is not a valid canonical encoding, SetCanonicalBytes returns nil and an error, and e is
+// unchanged.
+func (e *Element) SetCanonicalBytes(b []byte) (*Element, error) {
+	if len(b) != ElementSize {
+		return nil, ErrInvalidEncoding
+	}
+	s, err := new(field.Element).SetBytes(b)
+	if err != nil {
+		return nil, ErrInvalidEncoding
+	}
+	// reject non-canonical and negative field elements
+	if !equalBytes(s.Bytes(), b) || s.IsNegative() == 1 {
+		return nil, ErrInvalidEncoding
+	}
+
+	one := new(field.Element).One()
+	var ss, u1, u2, u2Squared, v, tmp field.Element
+	ss.Square(s)
+	u1.Subtract(one, &ss)
+	u2.Add(one, &ss)
+	u2Squared.Square(&u2)
+	v.Square(&u1)
+	v.Multiply(&v, d)
+	v.Negate(&v)
+	v.Subtract(&v, &u2Squared)
+
+	var invSqrt field.Element
+	tmp.Multiply(&v, &u2Squared)
+	_, wasSquare := invSqrt.SqrtRatio(one, &tmp)
+
+	var denX, denY field.Element
+	denX.Multiply(&invSqrt, &u2)
+	denY.Multiply(&invSqrt, &denX)
+	denY.Multiply(&denY, &v)
+
+	var x, y, t field.Element
+	x.Multiply(s, &denX)
+	x.Add(&x, &x)
+	x.Absolute(&x)
+	y.Multiply(&u1, &denY)
+	t.Multiply(&x, &y)
+
+	if wasSquare == 0 || t.IsNegative() == 1 || y.Equal(new(field.Element).Zero()) == 1 {
+		return nil, ErrInvalidEncoding
+	}
+	if _, err := e.p.SetExtendedCoordinates(&x, &y, one, &t); err != nil {
+		return nil, ErrInvalidEncoding
+	}
+	return e, nil
+}
+
+// SetUniformBytes deterministically sets e to the element derived from the 64 uniformly random bytes b as specified
+// in RFC 9496, Section 4.3.4, and returns e. If len(b) is not UniformSize, SetUniformBytes returns nil and an error.
+func (e *Element) SetUniformBytes(b []byte) (*Element, error) {
+	if len(b) != UniformSize {
+		return nil, errors.New("ristretto255: invalid SetUniformBytes input length")
+	}
+	var r0, r1 field.Element
+	// SetBytes ignores the most significant bit as required
+	if _, err := r0.SetBytes(b[:32]); err != nil {
+		return nil, err
+	}
+	if _, err := r1.SetBytes(b[32:]); err != nil {
+		return nil, err
+	}
+	p1, p2 := mapToPoint(&r0), mapToPoint(&r1)
+	e.p.Add(p1, p2)
+	return e, nil
+}
+
+// mapToPoint implements the MAP function of RFC 9496, Section 4.3.4.
+func mapToPoint(t *field.Element) *edwards25519.Point {
+	one := new(field.Element).One()
+	minusOne := new(field.Element).Negate(one)
+
+	var r, u, v, tmp field.Element
+	r.Square(t)
+	r.Multiply(&r, sqrtM1)
+	u.Add(&r, one)
+	u.Multiply(&u, oneMinusDSquared)
+	tmp.Multiply(&r, d)
+	v.Subtract(minusOne, &tmp)
+	tmp.Add(&r, d)
+	v.Multiply(&v, &tmp)
+
+	var s field.Element
+	_, wasSquare := s.SqrtRatio(&u, &v)
+	var sPrime field.Element
+	sPrime.Multiply(&s, t)
+	sPrime.Absolute(&sPrime)
+	sPrime.Negate(&sPrime)
+	s.Select(&s, &sPrime, wasSquare)
+	var c field.Element
+	c.Select(minusOne, &r, wasSquare)
+
+	var n field.Element
+	n.Subtract(&r, one)
+	n.Multiply(&n, &c)
+	n.Multiply(&n, dMinusOneSquared)
+	n.Subtract(&n, &v)
+
+	var w0, w1, w2, w3 field.Element
+	w0.Multiply(&s, &v)
+	w0.Add(&w0, &w0)
+	w1.Multiply(&n, sqrtADMinusOne)
+	tmp.Square(&s)
+	w2.Subtract(one, &tmp)
+	w3.Add(one, &tmp)
+
+	var x, y, z, tt field.Element
+	x.Multiply(&w0, &w3)
+	y.Multiply(&w2, &w1)
+	z.Multiply(&w1, &w3)
+	tt.Multiply(&w0, &w2)
+	p, err := new(edwards25519.Point).SetExtendedCoordinates(&x, &y, &z, &tt)
+	if err != nil {
+		panic("ristretto255: internal error: invalid map output")
+	}
+	return p
+}
+
+func equalBytes(a, b []byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	var v byte
+	for i := range a {
+		v |= a[i] ^ b[i]
+	}
+	return v == 0
+}
@@ -0,0 +1,118 @@
+package ristretto255_test
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/internal/hexutil"
+	"github.com/iotaledger/iota-crypto-demo/pkg/ristretto255"
+)
+
+func TestGeneratorMultiples(t *testing.T) {
+	// test vectors from RFC 9496, Section A.1
+	var tests = []string{
+		"0000000000000000000000000000000000000000000000000000000000000000",
+		"e2f2ae0a6abc4e71a884a961c500515f58e30b6aa582dd8db6a65945e08d2d76",
+		"6a493210f7499cd17fecb510ae0cea23a110e8d5b901f8acadd3095c73a3b919",
+		"94741f5d5d52755ece4f23f044ee27d5d1ea1e2bd196b462166b16152a9d0259",
+		"da80862773358b466ffadfe0b3293ab3d9fd53c5ea6c955358f568322daf6a57",
+	}
+
+	g := ristretto255.NewGeneratorElement()
+	e := ristretto255.NewElement()
+	for i, tt := range tests {
+		assert.Equalf(t, tt, hex.EncodeToString(e.Bytes()), "%d*G", i)
+
+		dec, err := ristretto255.NewElement().SetCanonicalBytes(hexutil.MustDecodeString(tt))
+		require.NoError(t, err)
+		assert.Equal(t, 1, dec.Equal(e))
+
+		e.Add(e, g)
+	}
+}
+
+func TestSetUniformBytes(t *testing.T) {
+	// test vector from RFC 9496, Section A.3
+	in := hexutil.MustDecodeString("5d1be09e3d0c82fc538112490e35701979d99e06ca3e2b5b54bffe8b4dc772c14d98b696a1bbfb5ca32c436cc61c16563790306c79eaca7705668b47dffe5bb6")
+	e, err := ristretto255.NewElement().SetUniformBytes(in)
+	require.NoError(t, err)
+	assert.Equal(t, "3066f82a1a747d45120d1740f14358531a8f04bbffe6a819f86dfe50f44a0a46", hex.EncodeToString(e.Bytes()))
+
+	_, err = ristretto255.NewElement().SetUniformBytes(in[:32])
+	assert.Error(t, err)
+}
+
+func TestSetCanonicalBytesInvalid(t *testing.T) {
+	// selection of bad encodings from RFC 9496, Section A.2
+	var tests = []*struct {
+		name string
+		enc  string
+	}{
+		{"non-canonical", "00ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"},
+		{"non-canonical", "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f"},
+		{"non-canonical", "f3ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f"},
+		{"negative", "0100000000000000000000000000000000000000000000000000000000000000"},
+		{"negative", "01ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f"},
+		{"non-square", "26948d35ca62e643e26a83177332e6b6afeb9d08e4268b650f1f5bbd8d81d371"},
+		{"non-square", "4eac077a713c57b4f4397629a4145982c661f48044dd3f96427d40b147d9742f"},
+		{"zero y", "bc7b9bd8cc2827032bfc32880b4f6b8f0d29dbbeced6bd4368ca54594fcf494a"},
+		{"short", "00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ristretto255.NewElement().SetCanonicalBytes(hexutil.MustDecodeString(tt.enc))
+			assert.ErrorIs(t, err, ristretto255.ErrInvalidEncoding)
+		})
+	}
+}
+
+func randomScalar(t *testing.T) *ristretto255.Scalar {
+	var b [64]byte
+	_, err := rand.Read(b[:])
+	require.NoError(t, err)
+	s, err := ristretto255.NewScalar().SetUniformBytes(b[:])
+	require.NoError(t, err)
+	return s
+}
+
+func TestRoundTrip(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		e := ristretto255.NewElement().ScalarBaseMult(randomScalar(t))
+		dec, err := ristretto255.NewElement().SetCanonicalBytes(e.Bytes())
+		require.NoError(t, err)
+		assert.Equal(t, 1, dec.Equal(e))
+		assert.Equal(t, e.Bytes(), dec.Bytes())
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	a, b := randomScalar(t), randomScalar(t)
+	g := ristretto255.NewGeneratorElement()
+	aG := ristretto255.NewElement().ScalarBaseMult(a)
+	bG := ristretto255.NewElement().ScalarMult(b, g)
+
+	// aG + bG == (a+b)G
+	sum := ristretto255.NewElement().Add(aG, bG)
+	assert.Equal(t, 1, sum.Equal(ristretto255.NewElement().ScalarBaseMult(ristretto255.NewScalar().Add(a, b))))
+	// aG - aG == 0
+	assert.Equal(t, 1, ristretto255.NewElement().Subtract(aG, aG).Equal(ristretto255.NewElement()))
+	assert.Equal(t, 1, ristretto255.NewElement().Add(aG, ristretto255.NewElement().Negate(aG)).Equal(ristretto255.NewElement()))
+	// a(bG) == b(aG)
+	assert.Equal(t, ristretto255.NewElement().ScalarMult(a, bG).Bytes(), ristretto255.NewElement().ScalarMult(b, aG).Bytes())
+	// aG + bG via multi-scalar multiplication
+	multi := ristretto255.NewElement().VarTimeMultiScalarMult([]*ristretto255.Scalar{a, b}, []*ristretto255.Element{g, g})
+	assert.Equal(t, 1, multi.Equal(sum))
+	assert.Equal(t, 0, multi.Equal(aG))
+}
+
+func TestHashToElement(t *testing.T) {
+	e1 := ristretto255.HashToElement([]byte("message"))
+	e2 := ristretto255.HashToElement([]byte("message"))
+	assert.Equal(t, e1.Bytes(), e2.Bytes())
+	assert.NotEqual(t, e1.Bytes(), ristretto255.HashToElement([]byte("other message")).Bytes())
+	assert.Equal(t, ristretto255.HashToScalar([]byte("message")).Bytes(), ristretto255.HashToScalar([]byte("message")).Bytes())
+}
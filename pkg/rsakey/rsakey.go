@@ -0,0 +1,179 @@
+/*
+Package rsakey deterministically generates RSA keys from a seed for legacy systems requiring RSA identities.
+
+The standard library does not support seeded key generation, as rsa.GenerateKey ignores the provided randomness and
+its algorithm may change between Go releases. Instead, this package implements its own prime generation from a
+ChaCha20 based DRBG. The algorithm is versioned explicitly, so that a key can always be regenerated from the same
+seed as long as the version is recorded alongside the backup.
+*/
+package rsakey
+
+import (
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/hkdf"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip21"
+	"golang.org/x/crypto/chacha20"
+)
+
+// Version identifies the key generation algorithm.
+type Version uint8
+
+const (
+	// V1 generates primes p and q of half the modulus size with the top two bits set, using ChaCha20 keyed with
+	// HKDF-SHA256 from the seed, the version and the modulus size as DRBG.
+	V1 Version = 1
+
+	// LatestVersion is the version used by GenerateKey.
+	LatestVersion = V1
+)
+
+const (
+	// MinSeedSize is the minimal size, in bytes, of the seed.
+	MinSeedSize = 32
+	// MinBits is the minimal supported modulus size in bits.
+	MinBits = 2048
+	// Label is the SLIP-21 label of the node below which all RSA seeds are derived.
+	Label = "RSA key"
+
+	// publicExponent is the fixed public exponent of all generated keys.
+	publicExponent = 65537
+	// primeRounds is the number of Miller-Rabin rounds in addition to the Baillie-PSW test.
+	primeRounds = 20
+	// hkdfSalt is the salt for deriving the DRBG key.
+	hkdfSalt = "iota-crypto-demo RSA key generation"
+)
+
+var (
+	// ErrInvalidSeed is returned when the seed is too short.
+	ErrInvalidSeed = errors.New("invalid seed")
+	// ErrInvalidBits is returned for unsupported modulus sizes.
+	ErrInvalidBits = errors.New("invalid modulus size")
+	// ErrUnsupportedVersion is returned for unknown generation algorithm versions.
+	ErrUnsupportedVersion = errors.New("unsupported version")
+)
+
+// DeriveKey derives the RSA key with the given modulus size for the SLIP-21 path Label/labels from masterSeed.
+func DeriveKey(masterSeed []byte, bits int, labels ...string) (*rsa.PrivateKey, error) {
+	node := slip21.DeriveNodeFromPath(masterSeed, append([]string{Label}, labels...)...)
+	return GenerateKey(node.Key(), bits)
+}
+
+// GenerateKey generates the RSA key with the given modulus size from seed using LatestVersion.
+func GenerateKey(seed []byte, bits int) (*rsa.PrivateKey, error) {
+	return GenerateKeyVersion(LatestVersion, seed, bits)
+}
+
+// GenerateKeyVersion generates the RSA key with the given modulus size from seed using the algorithm of version v.
+func GenerateKeyVersion(v Version, seed []byte, bits int) (*rsa.PrivateKey, error) {
+	if v != V1 {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, v)
+	}
+	if len(seed) < MinSeedSize {
+		return nil, ErrInvalidSeed
+	}
+	if bits < MinBits || bits%16 != 0 {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidBits, bits)
+	}
+
+	drbg := newDRBG(v, seed, bits)
+	e := big.NewInt(publicExponent)
+	// p and q must differ in their top 100 bits as required by FIPS 186-5
+	minDiff := new(big.Int).Lsh(big.NewInt(1), uint(bits/2-100))
+	for {
+		p := generatePrime(drbg, bits/2, e)
+		q := generatePrime(drbg, bits/2, e)
+		if new(big.Int).Abs(new(big.Int).Sub(p, q)).Cmp(minDiff) <= 0 {
+			continue
+		}
+		key, err := newKey(p, q, e)
+		if err != nil {
+			continue
+		}
+		if key.N.BitLen() != bits {
+			panic("rsakey: invalid modulus size")
+		}
+		return key, nil
+	}
+}
+
+// newDRBG returns the deterministic random bit generator for the given parameters.
+func newDRBG(v Version, seed []byte, bits int) io.Reader {
+	info := binary.BigEndian.AppendUint16([]byte{byte(v)}, uint16(bits))
+	key, err := hkdf.Key(sha256.New, seed, []byte(hkdfSalt), info, chacha20.KeySize)
+	if err != nil {
+		panic(err)
+	}
+	c, err := chacha20.NewUnauthenticatedCipher(key, make([]byte, chacha20.NonceSize))
+	if err != nil {
+		panic(err)
+	}
+	return &keystream{c}
+}
+
+// generatePrime returns the first prime of the given size read from drbg for which e is a valid public exponent.
+func generatePrime(drbg io.Reader, bits int, e *big.Int) *big.Int {
+	b := make([]byte, (bits+7)/8)
+	one := big.NewInt(1)
+	for {
+		if _, err := io.ReadFull(drbg, b); err != nil {
+			panic(err)
+		}
+		// clear the excess bits and set the top two bits so that the product has exactly 2·bits bits
+		if excess := len(b)*8 - bits; excess > 0 {
+			b[0] &= 0xff >> excess
+		}
+		p := new(big.Int).SetBytes(b)
+		p.SetBit(p, bits-1, 1)
+		p.SetBit(p, bits-2, 1)
+		p.SetBit(p, 0, 1)
+
+		if new(big.Int).GCD(nil, nil, new(big.Int).Sub(p, one), e).Cmp(one) != 0 {
+			continue
+		}
+		if p.ProbablyPrime(primeRounds) {
+			return p
+		}
+	}
+}
+
+// newKey assembles the private key from the primes with the private exponent computed modulo λ(n).
+func newKey(p, q, e *big.Int) (*rsa.PrivateKey, error) {
+	one := big.NewInt(1)
+	p1 := new(big.Int).Sub(p, one)
+	q1 := new(big.Int).Sub(q, one)
+	gcd := new(big.Int).GCD(nil, nil, p1, q1)
+	lambda := new(big.Int).Div(new(big.Int).Mul(p1, q1), gcd)
+	d := new(big.Int).ModInverse(e, lambda)
+	if d == nil {
+		return nil, errors.New("no private exponent")
+	}
+
+	key := &rsa.PrivateKey{
+		PublicKey: rsa.PublicKey{N: new(big.Int).Mul(p, q), E: int(e.Int64())},
+		D:         d,
+		Primes:    []*big.Int{p, q},
+	}
+	if err := key.Validate(); err != nil {
+		return nil, err
+	}
+	key.Precompute()
+	return key, nil
+}
+
+// keystream is an io.Reader returning the ChaCha20 keystream.
+type keystream struct {
+	c *chacha20.Cipher
+}
+
+func (k *keystream) Read(p []byte) (int, error) {
+	clear(p)
+	k.c.XORKeyStream(p, p)
+	return len(p), nil
+}
@@ -0,0 +1,54 @@
+package rsakey_test
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/internal/hexutil"
+	"github.com/iotaledger/iota-crypto-demo/internal/rand"
+	"github.com/iotaledger/iota-crypto-demo/pkg/rsakey"
+)
+
+var testSeed = hexutil.MustDecodeString("000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f")
+
+func TestGenerateKey(t *testing.T) {
+	key, err := rsakey.GenerateKey(testSeed, 2048)
+	require.NoError(t, err)
+	require.NoError(t, key.Validate())
+	assert.Equal(t, 2048, key.N.BitLen())
+
+	// the key must never change for the same version
+	h := sha256.Sum256(key.N.Bytes())
+	assert.Equal(t, "776709c78f35e363ed47fe24cab050609c32526c4845d31ce87eee823f9a0dcd", hexutil.Bytes(h[:]).String())
+
+	again, err := rsakey.GenerateKeyVersion(rsakey.V1, testSeed, 2048)
+	require.NoError(t, err)
+	assert.True(t, key.Equal(again))
+
+	digest := sha256.Sum256([]byte("message"))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	require.NoError(t, err)
+	assert.NoError(t, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], sig))
+}
+
+func TestDeriveKey(t *testing.T) {
+	alice, err := rsakey.DeriveKey(testSeed, 2048, "alice")
+	require.NoError(t, err)
+	bob, err := rsakey.DeriveKey(testSeed, 2048, "bob")
+	require.NoError(t, err)
+	assert.False(t, alice.Equal(bob))
+}
+
+func TestGenerateKeyInvalid(t *testing.T) {
+	_, err := rsakey.GenerateKey(testSeed[:16], 2048)
+	assert.ErrorIs(t, err, rsakey.ErrInvalidSeed)
+	_, err = rsakey.GenerateKey(testSeed, 1024)
+	assert.ErrorIs(t, err, rsakey.ErrInvalidBits)
+	_, err = rsakey.GenerateKeyVersion(0, testSeed, 2048)
+	assert.ErrorIs(t, err, rsakey.ErrUnsupportedVersion)
+}
@@ -0,0 +1,72 @@
+package signedmessage
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/cbor"
+)
+
+// cborFields are the text keys of the CBOR map of a signed message in canonical order.
+var cborFields = []string{"address", "message", "version", "publicKey", "signature"}
+
+// MarshalCBOR implements the cbor.Marshaler interface.
+// The signed message is encoded as a map with the same keys as its JSON encoding, where the public key and the
+// signature are byte strings.
+func (m *SignedMessage) MarshalCBOR() ([]byte, error) {
+	if m.Version < 0 {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, m.Version)
+	}
+	return cbor.AppendMap(nil,
+		cbor.Entry{Key: cbor.TextKey("version"), Value: cbor.AppendUint(nil, uint64(m.Version))},
+		cbor.Entry{Key: cbor.TextKey("address"), Value: cbor.AppendText(nil, m.Address)},
+		cbor.Entry{Key: cbor.TextKey("message"), Value: cbor.AppendText(nil, m.Message)},
+		cbor.Entry{Key: cbor.TextKey("publicKey"), Value: cbor.AppendBytes(nil, m.PublicKey)},
+		cbor.Entry{Key: cbor.TextKey("signature"), Value: cbor.AppendBytes(nil, m.Signature)},
+	), nil
+}
+
+// UnmarshalCBOR implements the cbor.Unmarshaler interface.
+// It only decodes the signed message, Verify must be called to check its validity.
+func (m *SignedMessage) UnmarshalCBOR(data []byte) error {
+	d := cbor.NewDecoder(data)
+	entries, err := d.Map()
+	if err != nil {
+		return err
+	}
+	if err := d.Finish(); err != nil {
+		return err
+	}
+	if len(entries) != len(cborFields) {
+		return fmt.Errorf("%w: %d map entries instead of %d", cbor.ErrUnexpectedType, len(entries), len(cborFields))
+	}
+	for i, entry := range entries {
+		if !bytes.Equal(entry.Key, cbor.TextKey(cborFields[i])) {
+			return fmt.Errorf("%w: unexpected map key", cbor.ErrUnexpectedType)
+		}
+	}
+
+	var x SignedMessage
+	if x.Address, err = cbor.NewDecoder(entries[0].Value).Text(); err != nil {
+		return fmt.Errorf("invalid address: %w", err)
+	}
+	if x.Message, err = cbor.NewDecoder(entries[1].Value).Text(); err != nil {
+		return fmt.Errorf("invalid message: %w", err)
+	}
+	version, err := cbor.NewDecoder(entries[2].Value).Uint()
+	if err != nil {
+		return fmt.Errorf("invalid version: %w", err)
+	}
+	if version != Version {
+		return fmt.Errorf("%w: %d", ErrUnsupportedVersion, version)
+	}
+	x.Version = Version
+	if x.PublicKey, err = cbor.NewDecoder(entries[3].Value).Bytes(); err != nil {
+		return fmt.Errorf("invalid public key: %w", err)
+	}
+	if x.Signature, err = cbor.NewDecoder(entries[4].Value).Bytes(); err != nil {
+		return fmt.Errorf("invalid signature: %w", err)
+	}
+	*m = x
+	return nil
+}
@@ -0,0 +1,104 @@
+/*
+Package signedmessage implements signing arbitrary messages with the Ed25519 key
+of an IOTA address, so that the control of the address can be proven off-chain.
+
+A SignedMessage binds the message to the Bech32 encoded address, including its
+network prefix. The signed digest is the BLAKE2b-256 hash of a domain separation
+tag followed by the length-prefixed address and message, so that signatures can
+neither be mistaken for transaction signatures nor replayed for a different
+address or network. Signed messages are serialized as JSON or canonical CBOR.
+*/
+package signedmessage
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/blake2b"
+
+	"github.com/iotaledger/iota-crypto-demo/internal/hexutil"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bech32/address"
+	"github.com/iotaledger/iota-crypto-demo/pkg/ed25519"
+)
+
+// Version is the version of the signed message format implemented by this package.
+const Version = 1
+
+// domain is the domain separation tag prepended to the signed data.
+const domain = "IOTA Signed Message v1"
+
+var (
+	// ErrUnsupportedVersion is returned when a signed message has an unknown version.
+	ErrUnsupportedVersion = errors.New("unsupported version")
+	// ErrInvalidAddress is returned when the address is not a valid Ed25519 address.
+	ErrInvalidAddress = errors.New("invalid address")
+	// ErrInvalidSignature is returned when the public key or signature does not match.
+	ErrInvalidSignature = errors.New("invalid signature")
+)
+
+// SignedMessage is a message signed with the key of an Ed25519 address.
+type SignedMessage struct {
+	// Version is the version of the format.
+	Version int `json:"version"`
+	// Address is the Bech32 encoded address the message is signed for.
+	Address string `json:"address"`
+	// Message is the signed message.
+	Message string `json:"message"`
+	// PublicKey is the Ed25519 public key of the address.
+	PublicKey hexutil.Bytes `json:"publicKey"`
+	// Signature is the Ed25519 signature of the digest.
+	Signature hexutil.Bytes `json:"signature"`
+}
+
+// Digest returns the BLAKE2b-256 hash of the domain separated address and message to be signed.
+func Digest(addr string, message string) []byte {
+	h, _ := blake2b.New256(nil)
+	h.Write([]byte(domain))
+	h.Write(binary.BigEndian.AppendUint32(nil, uint32(len(addr))))
+	h.Write([]byte(addr))
+	h.Write(binary.BigEndian.AppendUint64(nil, uint64(len(message))))
+	h.Write([]byte(message))
+	return h.Sum(nil)
+}
+
+// Sign signs the message with privateKey for its Ed25519 address on the network with the given prefix.
+func Sign(privateKey ed25519.PrivateKey, prefix address.Prefix, message string) (*SignedMessage, error) {
+	publicKey := privateKey.Public().(ed25519.PublicKey) //nolint:forcetypeassert
+	addr, err := address.Bech32(prefix, address.AddressFromPublicKey(publicKey))
+	if err != nil {
+		return nil, err
+	}
+	return &SignedMessage{
+		Version:   Version,
+		Address:   addr,
+		Message:   message,
+		PublicKey: hexutil.Bytes(publicKey),
+		Signature: ed25519.Sign(privateKey, Digest(addr, message)),
+	}, nil
+}
+
+// Verify checks that the signed message is valid, i.e. the public key corresponds to the address and the signature
+// is valid for the address and message.
+func (m *SignedMessage) Verify() error {
+	if m.Version != Version {
+		return fmt.Errorf("%w: %d", ErrUnsupportedVersion, m.Version)
+	}
+	_, addr, err := address.ParseBech32(m.Address)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidAddress, err)
+	}
+	if addr.Version() != address.Ed25519 {
+		return fmt.Errorf("%w: %s address", ErrInvalidAddress, addr.Version())
+	}
+	if len(m.PublicKey) != ed25519.PublicKeySize {
+		return fmt.Errorf("%w: invalid public key length", ErrInvalidSignature)
+	}
+	if !addr.Equal(address.AddressFromPublicKey(ed25519.PublicKey(m.PublicKey))) {
+		return fmt.Errorf("%w: public key does not match address", ErrInvalidSignature)
+	}
+	if !ed25519.Verify(ed25519.PublicKey(m.PublicKey), Digest(m.Address, m.Message), m.Signature) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+//nolint:scopelint
+package signedmessage_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/internal/hexutil"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bech32/address"
+	"github.com/iotaledger/iota-crypto-demo/pkg/ed25519"
+	"github.com/iotaledger/iota-crypto-demo/pkg/signedmessage"
+)
+
+var testKey = ed25519.NewKeyFromSeed(hexutil.MustDecodeString("9d61b19deffd5a60ba844af492ec2cc44449c5697b326919703bac031cae7f60"))
+
+func TestSignVerify(t *testing.T) {
+	m, err := signedmessage.Sign(testKey, address.IOTAMainnet, "hello world")
+	require.NoError(t, err)
+	assert.Equal(t, signedmessage.Version, m.Version)
+	assert.Equal(t, "hello world", m.Message)
+	assert.NoError(t, m.Verify())
+
+	b, err := json.Marshal(m)
+	require.NoError(t, err)
+	var decoded signedmessage.SignedMessage
+	require.NoError(t, json.Unmarshal(b, &decoded))
+	assert.Equal(t, m, &decoded)
+	assert.NoError(t, decoded.Verify())
+}
+
+func TestVerifyInvalid(t *testing.T) {
+	other, err := signedmessage.Sign(ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize)), address.IOTAMainnet, "hello world")
+	require.NoError(t, err)
+	devnet, err := signedmessage.Sign(testKey, address.IOTADevnet, "hello world")
+	require.NoError(t, err)
+
+	var tests = []*struct {
+		name   string
+		modify func(m *signedmessage.SignedMessage)
+		err    error
+	}{
+		{"version", func(m *signedmessage.SignedMessage) { m.Version = 2 }, signedmessage.ErrUnsupportedVersion},
+		{"address", func(m *signedmessage.SignedMessage) { m.Address = "iota1invalid" }, signedmessage.ErrInvalidAddress},
+		{"alias address", func(m *signedmessage.SignedMessage) {
+			m.Address, _ = address.Bech32(address.IOTAMainnet, address.AliasAddressFromOutputID([address.OutputIDLength]byte{}))
+		}, signedmessage.ErrInvalidAddress},
+		{"other address", func(m *signedmessage.SignedMessage) { m.Address = other.Address }, signedmessage.ErrInvalidSignature},
+		{"other network", func(m *signedmessage.SignedMessage) { m.Address = devnet.Address }, signedmessage.ErrInvalidSignature},
+		{"message", func(m *signedmessage.SignedMessage) { m.Message = "hello World" }, signedmessage.ErrInvalidSignature},
+		{"public key", func(m *signedmessage.SignedMessage) { m.PublicKey = m.PublicKey[1:] }, signedmessage.ErrInvalidSignature},
+		{"signature", func(m *signedmessage.SignedMessage) { m.Signature[0] ^= 1 }, signedmessage.ErrInvalidSignature},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := signedmessage.Sign(testKey, address.IOTAMainnet, "hello world")
+			require.NoError(t, err)
+			tt.modify(m)
+			assert.ErrorIs(t, m.Verify(), tt.err)
+		})
+	}
+}
+
+func TestDigest(t *testing.T) {
+	// the length prefixes prevent ambiguous splits between address and message
+	assert.NotEqual(t, signedmessage.Digest("ab", "c"), signedmessage.Digest("a", "bc"))
+}
+
+func TestMarshalCBOR(t *testing.T) {
+	m, err := signedmessage.Sign(testKey, address.IOTAMainnet, "hello world")
+	require.NoError(t, err)
+
+	b, err := m.MarshalCBOR()
+	require.NoError(t, err)
+	// map of 5 entries starting with the shortest key "address"
+	assert.Equal(t, []byte{0xa5, 0x67, 'a', 'd', 'd', 'r', 'e', 's', 's'}, b[:9])
+
+	var decoded signedmessage.SignedMessage
+	require.NoError(t, decoded.UnmarshalCBOR(b))
+	assert.Equal(t, m, &decoded)
+	assert.NoError(t, decoded.Verify())
+
+	m.Version = 2
+	b, err = m.MarshalCBOR()
+	require.NoError(t, err)
+	assert.ErrorIs(t, decoded.UnmarshalCBOR(b), signedmessage.ErrUnsupportedVersion)
+}
@@ -0,0 +1,66 @@
+package signerd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip32path"
+	"github.com/iotaledger/iota-crypto-demo/pkg/signedmessage"
+)
+
+// Client calls the JSON API of a remote signer.
+type Client struct {
+	// BaseURL is the URL of the service, e.g. "https://signer.example.com:8443".
+	BaseURL string
+	// HTTPClient is the client used for the requests, which must be configured for mutual TLS.
+	HTTPClient *http.Client
+}
+
+// Address returns the address of the key derived for path.
+func (c *Client) Address(path bip32path.Path) (*AddressResponse, error) {
+	res := &AddressResponse{}
+	return res, c.call(AddressRoute, &AddressRequest{Path: path}, res)
+}
+
+// SignMessage returns the signed message of message with the key derived for path.
+func (c *Client) SignMessage(path bip32path.Path, message string) (*signedmessage.SignedMessage, error) {
+	res := &signedmessage.SignedMessage{}
+	return res, c.call(SignMessageRoute, &SignMessageRequest{Path: path, Message: message}, res)
+}
+
+// SignEssence returns the signature of the serialized transaction essence with the key derived for path.
+func (c *Client) SignEssence(path bip32path.Path, essence []byte) (*SignEssenceResponse, error) {
+	res := &SignEssenceResponse{}
+	return res, c.call(SignEssenceRoute, &SignEssenceRequest{Path: path, Essence: essence}, res)
+}
+
+func (c *Client) call(route string, req, res any) error {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	resp, err := c.HTTPClient.Post(c.BaseURL+route, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		e := &errorResponse{}
+		if err := json.NewDecoder(resp.Body).Decode(e); err != nil || e.Error == "" {
+			e.Error = resp.Status
+		}
+		switch resp.StatusCode {
+		case http.StatusForbidden:
+			return fmt.Errorf("%w: %s", ErrPathNotAllowed, strings.TrimPrefix(e.Error, ErrPathNotAllowed.Error()+": "))
+		case http.StatusBadRequest:
+			return fmt.Errorf("%w: %s", ErrInvalidRequest, e.Error)
+		default:
+			return fmt.Errorf("remote signer: %s", e.Error)
+		}
+	}
+	return json.NewDecoder(resp.Body).Decode(res)
+}
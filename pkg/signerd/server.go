@@ -0,0 +1,137 @@
+package signerd
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/iotaledger/iota-crypto-demo/internal/hexutil"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip32path"
+)
+
+// maxRequestSize is the maximum size, in bytes, of a request body.
+const maxRequestSize = 1 << 16
+
+// API routes of the service.
+const (
+	AddressRoute     = "/v1/address"
+	SignMessageRoute = "/v1/sign/message"
+	SignEssenceRoute = "/v1/sign/essence"
+)
+
+// AddressRequest requests the address of the key derived for Path.
+type AddressRequest struct {
+	Path bip32path.Path `json:"path"`
+}
+
+// AddressResponse contains the public key and address of a derived key.
+type AddressResponse struct {
+	Path      bip32path.Path `json:"path"`
+	PublicKey hexutil.Bytes  `json:"publicKey"`
+	Address   string         `json:"address"`
+}
+
+// SignMessageRequest requests a signed message of Message with the key derived for Path.
+type SignMessageRequest struct {
+	Path    bip32path.Path `json:"path"`
+	Message string         `json:"message"`
+}
+
+// SignEssenceRequest requests the signature of the serialized transaction Essence with the key derived for Path.
+type SignEssenceRequest struct {
+	Path    bip32path.Path `json:"path"`
+	Essence hexutil.Bytes  `json:"essence"`
+}
+
+// SignEssenceResponse contains the public key, the essence hash and its signature.
+type SignEssenceResponse struct {
+	Path        bip32path.Path `json:"path"`
+	PublicKey   hexutil.Bytes  `json:"publicKey"`
+	EssenceHash hexutil.Bytes  `json:"essenceHash"`
+	Signature   hexutil.Bytes  `json:"signature"`
+}
+
+// errorResponse is returned for all failed requests.
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// Handler returns the HTTP handler serving the JSON API of the signer.
+func Handler(s *Signer) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST "+AddressRoute, handle(func(req *AddressRequest) (any, error) {
+		public, addr, err := s.Address(req.Path)
+		if err != nil {
+			return nil, err
+		}
+		return &AddressResponse{Path: req.Path, PublicKey: hexutil.Bytes(public), Address: addr}, nil
+	}))
+	mux.HandleFunc("POST "+SignMessageRoute, handle(func(req *SignMessageRequest) (any, error) {
+		return s.SignMessage(req.Path, req.Message)
+	}))
+	mux.HandleFunc("POST "+SignEssenceRoute, handle(func(req *SignEssenceRequest) (any, error) {
+		public, sig, err := s.SignEssence(req.Path, req.Essence)
+		if err != nil {
+			return nil, err
+		}
+		return &SignEssenceResponse{
+			Path:        req.Path,
+			PublicKey:   hexutil.Bytes(public),
+			EssenceHash: EssenceHash(req.Essence),
+			Signature:   sig,
+		}, nil
+	}))
+	return mux
+}
+
+// handle decodes the JSON request, calls f and encodes its result or error.
+func handle[T any](f func(*T) (any, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req := new(T)
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestSize)).Decode(req); err != nil {
+			writeJSON(w, http.StatusBadRequest, &errorResponse{fmt.Sprintf("invalid request: %s", err)})
+			return
+		}
+		res, err := f(req)
+		switch {
+		case errors.Is(err, ErrPathNotAllowed):
+			writeJSON(w, http.StatusForbidden, &errorResponse{err.Error()})
+		case errors.Is(err, ErrInvalidPath), errors.Is(err, ErrInvalidEssence):
+			writeJSON(w, http.StatusBadRequest, &errorResponse{err.Error()})
+		case err != nil:
+			writeJSON(w, http.StatusInternalServerError, &errorResponse{err.Error()})
+		default:
+			writeJSON(w, http.StatusOK, res)
+		}
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// ServerTLSConfig returns the TLS configuration of the service, which authenticates itself with cert and requires
+// clients to present a certificate issued by one of clientCAs.
+func ServerTLSConfig(cert tls.Certificate, clientCAs *x509.CertPool) *tls.Config {
+	return &tls.Config{
+		MinVersion:   tls.VersionTLS13,
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCAs,
+	}
+}
+
+// ClientTLSConfig returns the TLS configuration of a client, which authenticates itself with cert and only trusts
+// servers presenting a certificate issued by one of rootCAs.
+func ClientTLSConfig(cert tls.Certificate, rootCAs *x509.CertPool) *tls.Config {
+	return &tls.Config{
+		MinVersion:   tls.VersionTLS13,
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      rootCAs,
+	}
+}
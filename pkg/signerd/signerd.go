@@ -0,0 +1,213 @@
+/*
+Package signerd runs the SLIP-10 derivation of this repository behind a network boundary: a remote signer service
+returns the addresses of derived keys and signs messages and transaction essences with them, but never exports any
+private key. The seed is accessed through a backend.KeyStore, so that it can be kept in an encrypted keystore file
+or the keyring of the operating system.
+
+The service is exposed as a JSON API over HTTPS with mutual TLS, i.e. only clients presenting a certificate of a
+trusted CA are accepted. In addition, a Policy restricts the derivation paths the service signs for.
+*/
+package signerd
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/blake2b"
+
+	"github.com/iotaledger/iota-crypto-demo/internal/hexutil"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bech32/address"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip32path"
+	"github.com/iotaledger/iota-crypto-demo/pkg/ed25519"
+	"github.com/iotaledger/iota-crypto-demo/pkg/keystore/backend"
+	"github.com/iotaledger/iota-crypto-demo/pkg/signedmessage"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10"
+)
+
+var (
+	// ErrPathNotAllowed is returned when the policy does not allow the derivation path.
+	ErrPathNotAllowed = errors.New("path not allowed")
+	// ErrInvalidPath is returned when a path contains non-hardened indices that are not supported by Ed25519.
+	ErrInvalidPath = errors.New("invalid path")
+	// ErrInvalidRequest is returned by the client when the service rejects a request as malformed.
+	ErrInvalidRequest = errors.New("invalid request")
+	// ErrInvalidPattern is returned when a policy pattern cannot be parsed.
+	ErrInvalidPattern = errors.New("invalid pattern")
+	// ErrInvalidEssence is returned when the data to sign is not a serialized transaction essence.
+	ErrInvalidEssence = errors.New("invalid essence")
+)
+
+// TransactionEssenceType is the type byte every serialized transaction essence starts with.
+const TransactionEssenceType = 1
+
+// patternElement matches a single index of a path.
+type patternElement struct {
+	any   bool   // matches any index of the same hardening
+	index uint32 // the matched index, including the hardened bit
+}
+
+// Policy is an allowlist of derivation path patterns.
+type Policy struct {
+	patterns [][]patternElement
+}
+
+// ParsePolicy parses the path patterns of the allowlist.
+// Patterns have the form of BIP-32 paths, where "*" matches any non-hardened and "*'" any hardened index, e.g.
+// "m/44'/4218'/0'/0'/*'" allows all addresses of the first account.
+func ParsePolicy(patterns ...string) (*Policy, error) {
+	p := &Policy{}
+	for _, s := range patterns {
+		elements, err := parsePattern(s)
+		if err != nil {
+			return nil, err
+		}
+		p.patterns = append(p.patterns, elements)
+	}
+	return p, nil
+}
+
+func parsePattern(s string) ([]patternElement, error) {
+	parts := strings.Split(s, "/")
+	if parts[0] != "m" {
+		return nil, fmt.Errorf("%w: %q must start with m", ErrInvalidPattern, s)
+	}
+	elements := make([]patternElement, 0, len(parts)-1)
+	for _, part := range parts[1:] {
+		var e patternElement
+		digits := strings.TrimRight(part, "'H")
+		switch {
+		case len(part)-len(digits) > 1:
+			return nil, fmt.Errorf("%w: %q", ErrInvalidPattern, s)
+		case len(part) > len(digits):
+			e.index = slip10.Hardened
+		}
+		if digits == "*" {
+			e.any = true
+		} else {
+			i, err := strconv.ParseUint(digits, 10, 31)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %q", ErrInvalidPattern, s)
+			}
+			e.index |= uint32(i)
+		}
+		elements = append(elements, e)
+	}
+	return elements, nil
+}
+
+// Allows reports whether path matches one of the patterns of the policy.
+func (p *Policy) Allows(path bip32path.Path) bool {
+	for _, pattern := range p.patterns {
+		if matches(pattern, path) {
+			return true
+		}
+	}
+	return false
+}
+
+func matches(pattern []patternElement, path bip32path.Path) bool {
+	if len(pattern) != len(path) {
+		return false
+	}
+	for i, e := range pattern {
+		if e.any {
+			if (e.index >= slip10.Hardened) != (path[i] >= slip10.Hardened) {
+				return false
+			}
+		} else if e.index != path[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Signer derives Ed25519 keys using a key store and signs with them for all paths allowed by its policy.
+type Signer struct {
+	store  backend.KeyStore
+	prefix address.Prefix
+	policy *Policy
+}
+
+// NewSigner creates a Signer for the seed of store, which encodes its addresses with the network prefix.
+func NewSigner(store backend.KeyStore, prefix address.Prefix, policy *Policy) *Signer {
+	return &Signer{store: store, prefix: prefix, policy: policy}
+}
+
+// Address returns the public key and the Bech32 encoded address of the key derived for path.
+func (s *Signer) Address(path bip32path.Path) (ed25519.PublicKey, string, error) {
+	if err := s.check(path); err != nil {
+		return nil, "", err
+	}
+	public, err := s.store.PublicKey(path)
+	if err != nil {
+		return nil, "", err
+	}
+	addr, err := address.Bech32(s.prefix, address.AddressFromPublicKey(public))
+	if err != nil {
+		return nil, "", err
+	}
+	return public, addr, nil
+}
+
+// SignMessage signs the message with the key derived for path as a signed message of its address.
+func (s *Signer) SignMessage(path bip32path.Path, message string) (*signedmessage.SignedMessage, error) {
+	public, addr, err := s.Address(path)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := s.store.Sign(path, signedmessage.Digest(addr, message))
+	if err != nil {
+		return nil, err
+	}
+	return &signedmessage.SignedMessage{
+		Version:   signedmessage.Version,
+		Address:   addr,
+		Message:   message,
+		PublicKey: hexutil.Bytes(public),
+		Signature: sig,
+	}, nil
+}
+
+// SignEssence signs the serialized transaction essence with the key derived for path.
+// The signer computes the signed hash itself using EssenceHash, and the essence must start with the
+// TransactionEssenceType byte: as the digest of a signed message is the hash of data starting with a text domain
+// separation tag, an essence signature can never be used as the signature of a message.
+// It returns the public key together with the Ed25519 signature of the essence hash.
+func (s *Signer) SignEssence(path bip32path.Path, essence []byte) (ed25519.PublicKey, []byte, error) {
+	if len(essence) == 0 || essence[0] != TransactionEssenceType {
+		return nil, nil, fmt.Errorf("%w: missing transaction essence type", ErrInvalidEssence)
+	}
+	if err := s.check(path); err != nil {
+		return nil, nil, err
+	}
+	public, err := s.store.PublicKey(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	sig, err := s.store.Sign(path, EssenceHash(essence))
+	if err != nil {
+		return nil, nil, err
+	}
+	return public, sig, nil
+}
+
+// EssenceHash returns the BLAKE2b-256 hash of the serialized transaction essence, which is signed by SignEssence.
+func EssenceHash(essence []byte) []byte {
+	hash := blake2b.Sum256(essence)
+	return hash[:]
+}
+
+// check returns an error if the path is not supported or not allowed by the policy.
+func (s *Signer) check(path bip32path.Path) error {
+	for _, index := range path {
+		if index < slip10.Hardened {
+			return fmt.Errorf("%w: %s contains non-hardened indices", ErrInvalidPath, path)
+		}
+	}
+	if !s.policy.Allows(path) {
+		return fmt.Errorf("%w: %s", ErrPathNotAllowed, path)
+	}
+	return nil
+}
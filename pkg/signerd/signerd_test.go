@@ -0,0 +1,201 @@
+//nolint:scopelint // from tests
+package signerd_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/internal/hexutil"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bech32/address"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip32path"
+	"github.com/iotaledger/iota-crypto-demo/pkg/certificate"
+	"github.com/iotaledger/iota-crypto-demo/pkg/ed25519"
+	"github.com/iotaledger/iota-crypto-demo/pkg/keystore/backend"
+	"github.com/iotaledger/iota-crypto-demo/pkg/signedmessage"
+	"github.com/iotaledger/iota-crypto-demo/pkg/signerd"
+)
+
+var seed, _ = hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+
+func TestPolicy(t *testing.T) {
+	policy, err := signerd.ParsePolicy("m/44'/4218'/0'/0'/*'", "m/44'/4218'/*'/1'/7'")
+	require.NoError(t, err)
+
+	var tests = []*struct {
+		path    string
+		allowed bool
+	}{
+		{"m/44'/4218'/0'/0'/0'", true},
+		{"m/44'/4218'/0'/0'/2147483647'", true},
+		{"m/44'/4218'/5'/1'/7'", true},
+		{"m/44'/4218'/5'/1'/8'", false},
+		{"m/44'/4218'/0'/0'/0", false},
+		{"m/44'/4218'/0'/0'", false},
+		{"m/44'/4218'/0'/0'/0'/0'", false},
+		{"m/44'/4219'/0'/0'/0'", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			path, err := bip32path.ParsePath(tt.path)
+			require.NoError(t, err)
+			assert.Equal(t, tt.allowed, policy.Allows(path))
+		})
+	}
+}
+
+func TestParsePolicyInvalid(t *testing.T) {
+	for _, pattern := range []string{"44'/*'", "m/x'", "m/*''", "m/2147483648"} {
+		_, err := signerd.ParsePolicy(pattern)
+		assert.ErrorIs(t, err, signerd.ErrInvalidPattern, pattern)
+	}
+}
+
+func TestSigner(t *testing.T) {
+	policy, err := signerd.ParsePolicy("m/44'/4218'/0'/0'/*'")
+	require.NoError(t, err)
+	s := signerd.NewSigner(backend.NewMemory(seed), address.IOTAMainnet, policy)
+
+	path, _ := bip32path.ParsePath("m/44'/4218'/0'/0'/1'")
+	public, addr, err := s.Address(path)
+	require.NoError(t, err)
+	expected, err := address.Bech32(address.IOTAMainnet, address.AddressFromPublicKey(public))
+	require.NoError(t, err)
+	assert.Equal(t, expected, addr)
+
+	msg, err := s.SignMessage(path, "hello")
+	require.NoError(t, err)
+	assert.NoError(t, msg.Verify())
+	assert.Equal(t, addr, msg.Address)
+
+	essence := append([]byte{signerd.TransactionEssenceType}, make([]byte, 32)...)
+	pub, sig, err := s.SignEssence(path, essence)
+	require.NoError(t, err)
+	assert.Equal(t, public, pub)
+	assert.True(t, ed25519.Verify(pub, signerd.EssenceHash(essence), sig))
+
+	denied, _ := bip32path.ParsePath("m/44'/4218'/1'/0'/1'")
+	_, _, err = s.Address(denied)
+	assert.ErrorIs(t, err, signerd.ErrPathNotAllowed)
+
+	soft, _ := bip32path.ParsePath("m/44'/4218'/0'/0'/1")
+	_, _, err = s.SignEssence(soft, essence)
+	assert.ErrorIs(t, err, signerd.ErrInvalidPath)
+}
+
+func TestSignEssenceDomainSeparation(t *testing.T) {
+	policy, err := signerd.ParsePolicy("m/44'/4218'/0'/0'/*'")
+	require.NoError(t, err)
+	s := signerd.NewSigner(backend.NewMemory(seed), address.IOTAMainnet, policy)
+	path, _ := bip32path.ParsePath("m/44'/4218'/0'/0'/1'")
+	public, addr, err := s.Address(path)
+	require.NoError(t, err)
+
+	// the digest of a message SignMessage never saw, or the data it is the hash of, must not be signed
+	digest := signedmessage.Digest(addr, "forged")
+	for _, essence := range [][]byte{digest, append([]byte("IOTA Signed Message v1"), digest...), nil} {
+		_, _, err := s.SignEssence(path, essence)
+		assert.ErrorIs(t, err, signerd.ErrInvalidEssence)
+	}
+
+	// even an accepted essence with the digest as payload does not result in a valid signed message
+	_, sig, err := s.SignEssence(path, append([]byte{signerd.TransactionEssenceType}, digest...))
+	require.NoError(t, err)
+	forged := &signedmessage.SignedMessage{
+		Version:   signedmessage.Version,
+		Address:   addr,
+		Message:   "forged",
+		PublicKey: hexutil.Bytes(public),
+		Signature: sig,
+	}
+	assert.ErrorIs(t, forged.Verify(), signedmessage.ErrInvalidSignature)
+}
+
+func TestClient(t *testing.T) {
+	policy, err := signerd.ParsePolicy("m/44'/4218'/0'/0'/*'")
+	require.NoError(t, err)
+	signer := signerd.NewSigner(backend.NewMemory(seed), address.IOTAMainnet, policy)
+
+	serverCert, serverPool := testCertificate(t, "m/1'/0'")
+	clientCert, clientPool := testCertificate(t, "m/1'/1'")
+
+	srv := httptest.NewUnstartedServer(signerd.Handler(signer))
+	srv.TLS = signerd.ServerTLSConfig(serverCert, clientPool)
+	srv.StartTLS()
+	defer srv.Close()
+
+	client := &signerd.Client{
+		BaseURL:    srv.URL,
+		HTTPClient: &http.Client{Transport: &http.Transport{TLSClientConfig: signerd.ClientTLSConfig(clientCert, serverPool)}},
+	}
+
+	path, _ := bip32path.ParsePath("m/44'/4218'/0'/0'/3'")
+	public, addr, err := signer.Address(path)
+	require.NoError(t, err)
+
+	t.Run("Address", func(t *testing.T) {
+		res, err := client.Address(path)
+		require.NoError(t, err)
+		assert.Equal(t, path, res.Path)
+		assert.EqualValues(t, public, res.PublicKey)
+		assert.Equal(t, addr, res.Address)
+	})
+	t.Run("SignMessage", func(t *testing.T) {
+		res, err := client.SignMessage(path, "remote")
+		require.NoError(t, err)
+		assert.NoError(t, res.Verify())
+		assert.Equal(t, addr, res.Address)
+	})
+	t.Run("SignEssence", func(t *testing.T) {
+		essence := []byte("\x010123456789abcdef0123456789abcdef")
+		res, err := client.SignEssence(path, essence)
+		require.NoError(t, err)
+		assert.Equal(t, signerd.EssenceHash(essence), res.EssenceHash.Bytes())
+		assert.True(t, ed25519.Verify(ed25519.PublicKey(res.PublicKey), res.EssenceHash, res.Signature))
+	})
+	t.Run("InvalidEssence", func(t *testing.T) {
+		_, err := client.SignEssence(path, signedmessage.Digest(addr, "remote"))
+		assert.ErrorIs(t, err, signerd.ErrInvalidRequest)
+	})
+	t.Run("NotAllowed", func(t *testing.T) {
+		denied, _ := bip32path.ParsePath("m/44'/4218'/1'/0'/0'")
+		_, err := client.Address(denied)
+		assert.ErrorIs(t, err, signerd.ErrPathNotAllowed)
+	})
+	t.Run("InvalidPath", func(t *testing.T) {
+		soft, _ := bip32path.ParsePath("m/44'/4218'/0'/0'/0")
+		_, err := client.SignMessage(soft, "remote")
+		assert.ErrorIs(t, err, signerd.ErrInvalidRequest)
+	})
+	t.Run("NoClientCertificate", func(t *testing.T) {
+		anonymous := &signerd.Client{
+			BaseURL:    srv.URL,
+			HTTPClient: &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: serverPool, MinVersion: tls.VersionTLS13}}},
+		}
+		_, err := anonymous.Address(path)
+		assert.Error(t, err)
+	})
+}
+
+// testCertificate returns a self-signed certificate for the path and a pool containing it.
+func testCertificate(t *testing.T, p string) (tls.Certificate, *x509.CertPool) {
+	path, err := bip32path.ParsePath(p)
+	require.NoError(t, err)
+	der, err := certificate.CreateCertificate(seed, path, &certificate.Template{IPAddresses: []net.IP{net.IPv4(127, 0, 0, 1)}})
+	require.NoError(t, err)
+	key, err := certificate.PrivateKey(seed, path)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: cert}, pool
+}
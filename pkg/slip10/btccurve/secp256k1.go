@@ -220,3 +220,43 @@ func (curve koblitzCurve) ScalarBaseMult(k []byte) (*big.Int, *big.Int) {
 func (curve koblitzCurve) Params() *elliptic.CurveParams {
 	return curve.CurveParams
 }
+
+// Unmarshal converts an uncompressed point, serialized as in SEC 1, version 2.0, section 2.3.3, into an x, y pair.
+// It is called by elliptic.Unmarshal and returns x = nil on error.
+func (curve koblitzCurve) Unmarshal(data []byte) (x, y *big.Int) {
+	byteLen := (curve.BitSize + 7) / 8
+	if len(data) != 1+2*byteLen || data[0] != 4 {
+		return nil, nil
+	}
+	x = new(big.Int).SetBytes(data[1 : 1+byteLen])
+	y = new(big.Int).SetBytes(data[1+byteLen:])
+	if x.Cmp(curve.P) >= 0 || y.Cmp(curve.P) >= 0 || !curve.IsOnCurve(x, y) {
+		return nil, nil
+	}
+	return x, y
+}
+
+// UnmarshalCompressed converts a compressed point, serialized as in SEC 1, version 2.0, section 2.3.3, into an x, y
+// pair. It is called by elliptic.UnmarshalCompressed and returns x = nil on error.
+func (curve koblitzCurve) UnmarshalCompressed(data []byte) (x, y *big.Int) {
+	byteLen := (curve.BitSize + 7) / 8
+	if len(data) != 1+byteLen || (data[0] != 2 && data[0] != 3) {
+		return nil, nil
+	}
+	x = new(big.Int).SetBytes(data[1:])
+	if x.Cmp(curve.P) >= 0 {
+		return nil, nil
+	}
+	// y² = x³ + b
+	y = new(big.Int).Mul(x, x)
+	y.Mul(y, x)
+	y.Add(y, curve.B)
+	y.Mod(y, curve.P)
+	if y.ModSqrt(y, curve.P) == nil {
+		return nil, nil
+	}
+	if byte(y.Bit(0)) != data[0]&1 {
+		y.Sub(curve.P, y)
+	}
+	return x, y
+}
@@ -0,0 +1,100 @@
+package slip10
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/cbor"
+)
+
+// Keys of the CBOR map of extended keys.
+const (
+	cborDepth             = 1
+	cborParentFingerprint = 2
+	cborChildIndex        = 3
+	cborChainCode         = 4
+	cborKeyData           = 5
+)
+
+// MarshalCBOR implements cbor.Marshaler.
+// The extended key is encoded as a map with the integer keys 1 depth, 2 parent fingerprint, 3 child index, 4 chain code
+// and 5 key data, containing the same fields as the BIP-32 serialization but without a version. The curve is not
+// encoded and must be known to the receiver.
+// Private keys are distinguished from public keys by the length of the key data.
+func (e *ExtendedKey) MarshalCBOR() ([]byte, error) {
+	if e.depth > 0xff {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidDepth, e.depth)
+	}
+	return cbor.AppendMap(nil,
+		cbor.Entry{Key: cbor.UintKey(cborDepth), Value: cbor.AppendUint(nil, uint64(e.depth))},
+		cbor.Entry{Key: cbor.UintKey(cborParentFingerprint), Value: cbor.AppendBytes(nil, e.Fingerprint())},
+		cbor.Entry{Key: cbor.UintKey(cborChildIndex), Value: cbor.AppendUint(nil, uint64(e.index))},
+		cbor.Entry{Key: cbor.UintKey(cborChainCode), Value: cbor.AppendBytes(nil, e.ChainCode)},
+		cbor.Entry{Key: cbor.UintKey(cborKeyData), Value: cbor.AppendBytes(nil, e.Key.Bytes())},
+	), nil
+}
+
+// ParseExtendedKeyCBOR parses the CBOR encoding of an extended key for curve as returned by ExtendedKey.MarshalCBOR.
+// Extended public keys can only be parsed, if curve implements PublicKeyParser.
+func ParseExtendedKeyCBOR(data []byte, curve Curve) (*ExtendedKey, error) {
+	d := cbor.NewDecoder(data)
+	entries, err := d.Map()
+	if err != nil {
+		return nil, err
+	}
+	if err := d.Finish(); err != nil {
+		return nil, err
+	}
+	if len(entries) != 5 {
+		return nil, fmt.Errorf("%w: %d map entries instead of 5", ErrInvalidKey, len(entries))
+	}
+	for i, entry := range entries {
+		if !bytes.Equal(entry.Key, cbor.UintKey(uint64(i+1))) {
+			return nil, fmt.Errorf("%w: unexpected map key", ErrInvalidKey)
+		}
+	}
+
+	depth, err := cbor.NewDecoder(entries[cborDepth-1].Value).Uint()
+	if err != nil {
+		return nil, fmt.Errorf("invalid depth: %w", err)
+	}
+	if depth > 0xff {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidDepth, depth)
+	}
+	fingerprint, err := cbor.NewDecoder(entries[cborParentFingerprint-1].Value).Bytes()
+	if err != nil {
+		return nil, fmt.Errorf("invalid parent fingerprint: %w", err)
+	}
+	index, err := cbor.NewDecoder(entries[cborChildIndex-1].Value).Uint()
+	if err != nil {
+		return nil, fmt.Errorf("invalid child index: %w", err)
+	}
+	if index > 0xffffffff {
+		return nil, fmt.Errorf("%w: child index %d out of range", ErrInvalidKey, index)
+	}
+	chainCode, err := cbor.NewDecoder(entries[cborChainCode-1].Value).Bytes()
+	if err != nil {
+		return nil, fmt.Errorf("invalid chain code: %w", err)
+	}
+	keyData, err := cbor.NewDecoder(entries[cborKeyData-1].Value).Bytes()
+	if err != nil {
+		return nil, fmt.Errorf("invalid key data: %w", err)
+	}
+	return newExtendedKey(int(depth), fingerprint, uint32(index), chainCode, keyData, curve)
+}
+
+// parseKey parses the private or public key data of an extended key depending on its length.
+func parseKey(data []byte, curve Curve) (Key, error) {
+	switch len(data) {
+	case PrivateKeySize:
+		return curve.NewPrivateKey(data)
+	case PublicKeySize:
+		parser, ok := curve.(PublicKeyParser)
+		if !ok {
+			return nil, fmt.Errorf("%w: public keys of %s cannot be parsed", ErrInvalidKey, curve.Name())
+		}
+		return parser.ParsePublicKey(data)
+	default:
+		return nil, fmt.Errorf("%w: invalid key data length %d", ErrInvalidKey, len(data))
+	}
+}
@@ -0,0 +1,103 @@
+//nolint:scopelint
+package slip10_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip32path"
+	"github.com/iotaledger/iota-crypto-demo/pkg/cbor"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10/eddsa"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10/elliptic"
+)
+
+var cborCurves = []slip10.Curve{elliptic.Secp256k1(), elliptic.Nist256p1(), eddsa.Ed25519()}
+
+func TestExtendedKeyCBOR(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	for _, curve := range cborCurves {
+		for _, s := range []string{"m", "m/0'", "m/0'/1'/2'"} {
+			t.Run(curve.Name()+s, func(t *testing.T) {
+				path, err := bip32path.ParsePath(s)
+				require.NoError(t, err)
+				key, err := slip10.DeriveKeyFromPath(seed, curve, path)
+				require.NoError(t, err)
+
+				for _, k := range []*slip10.ExtendedKey{key, key.Public()} {
+					b, err := k.MarshalCBOR()
+					require.NoError(t, err)
+					decoded, err := slip10.ParseExtendedKeyCBOR(b, curve)
+					require.NoError(t, err)
+
+					assert.Equal(t, k.IsPrivate(), decoded.IsPrivate())
+					assert.Equal(t, k.ChainCode, decoded.ChainCode)
+					assert.Equal(t, k.Key.Bytes(), decoded.Key.Bytes())
+					assert.Equal(t, k.Fingerprint(), decoded.Fingerprint())
+
+					// the encoding must be stable
+					again, err := decoded.MarshalCBOR()
+					require.NoError(t, err)
+					assert.Equal(t, b, again)
+				}
+			})
+		}
+	}
+}
+
+func TestExtendedKeyCBORSerialize(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	for _, tt := range serializeTests {
+		t.Run(tt.path, func(t *testing.T) {
+			path, err := bip32path.ParsePath(tt.path)
+			require.NoError(t, err)
+			key, err := slip10.DeriveKeyFromPath(seed, elliptic.Secp256k1(), path)
+			require.NoError(t, err)
+
+			b, err := key.Public().MarshalCBOR()
+			require.NoError(t, err)
+			decoded, err := slip10.ParseExtendedKeyCBOR(b, elliptic.Secp256k1())
+			require.NoError(t, err)
+			xpub, err := decoded.XPub()
+			require.NoError(t, err)
+			assert.Equal(t, tt.xpub, xpub)
+
+			// keys decoded without their parent can still be derived further
+			child, err := decoded.DeriveChild(0)
+			require.NoError(t, err)
+			expected, err := key.DeriveChild(0)
+			require.NoError(t, err)
+			assert.Equal(t, expected.Public().Key.Bytes(), child.Key.Bytes())
+		})
+	}
+}
+
+func TestParseExtendedKeyCBORInvalid(t *testing.T) {
+	key, err := slip10.NewMasterKey(make([]byte, 16), elliptic.Secp256k1())
+	require.NoError(t, err)
+	b, err := key.Public().MarshalCBOR()
+	require.NoError(t, err)
+
+	// public key data of the wrong curve
+	_, err = slip10.ParseExtendedKeyCBOR(b, eddsa.Ed25519())
+	assert.ErrorIs(t, err, slip10.ErrInvalidKey)
+	// trailing data
+	_, err = slip10.ParseExtendedKeyCBOR(append(b, 0x00), elliptic.Secp256k1())
+	assert.ErrorIs(t, err, cbor.ErrTrailingData)
+	// truncated data
+	_, err = slip10.ParseExtendedKeyCBOR(b[:len(b)-1], elliptic.Secp256k1())
+	assert.ErrorIs(t, err, cbor.ErrUnexpectedEnd)
+	// master key with a child index
+	invalid := cbor.AppendMap(nil,
+		cbor.Entry{Key: cbor.UintKey(1), Value: cbor.AppendUint(nil, 0)},
+		cbor.Entry{Key: cbor.UintKey(2), Value: cbor.AppendBytes(nil, make([]byte, slip10.FingerprintSize))},
+		cbor.Entry{Key: cbor.UintKey(3), Value: cbor.AppendUint(nil, 1)},
+		cbor.Entry{Key: cbor.UintKey(4), Value: cbor.AppendBytes(nil, key.ChainCode)},
+		cbor.Entry{Key: cbor.UintKey(5), Value: cbor.AppendBytes(nil, key.Key.Bytes())},
+	)
+	_, err = slip10.ParseExtendedKeyCBOR(invalid, elliptic.Secp256k1())
+	assert.ErrorIs(t, err, slip10.ErrInvalidKey)
+}
@@ -28,6 +28,14 @@ func (ed25519Curve) NewPrivateKey(buf []byte) (slip10.Key, error) {
 	return Seed(seed), nil
 }
 
+// ParsePublicKey parses the 33-byte SLIP-10 serialization of a public key, i.e. 0x00 followed by the Ed25519 key.
+func (ed25519Curve) ParsePublicKey(buf []byte) (slip10.Key, error) {
+	if len(buf) != slip10.PublicKeySize || buf[0] != 0x00 {
+		return nil, slip10.ErrInvalidKey
+	}
+	return PublicKey(append([]byte{}, buf[1:]...)), nil
+}
+
 func (ed25519Curve) Name() string {
 	return "ed25519"
 }
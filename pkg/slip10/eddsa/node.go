@@ -0,0 +1,102 @@
+package eddsa
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/ed25519"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10"
+)
+
+// Node is a SLIP-10 extended Ed25519 private key stored in fixed-size arrays.
+// In contrast to slip10.ExtendedKey, the key is never boxed in an interface or copied into a slice on the heap and
+// deriving a child does not allocate. As SLIP-10 only defines hardened derivation for Ed25519, which does not involve
+// any scalar arithmetic, the derivation also runs in constant time.
+type Node struct {
+	Key       [ed25519.SeedSize]byte
+	ChainCode [slip10.ChainCodeSize]byte
+}
+
+// NewMasterNode creates the master node for seed.
+func NewMasterNode(seed []byte) Node {
+	h := hmac.New(sha512.New, Ed25519().HmacKey())
+	h.Write(seed)
+	var sum [sha512.Size]byte
+	h.Sum(sum[:0])
+
+	var n Node
+	n.set(&sum)
+	return n
+}
+
+// DeriveNode derives the node of path from seed.
+// It returns ErrNotHardened if the path contains non-hardened indices.
+func DeriveNode(seed []byte, path []uint32) (Node, error) {
+	n := NewMasterNode(seed)
+	for _, index := range path {
+		if err := n.derive(index, &n); err != nil {
+			n.Clear()
+			return Node{}, err
+		}
+	}
+	return n, nil
+}
+
+// DeriveChild derives the child node with the given hardened index.
+// It returns ErrNotHardened if the index is not hardened.
+func (n *Node) DeriveChild(index uint32) (Node, error) {
+	var child Node
+	if err := n.derive(index, &child); err != nil {
+		return Node{}, err
+	}
+	return child, nil
+}
+
+// derive computes the child with the given index into child, which may alias n.
+func (n *Node) derive(index uint32, child *Node) error {
+	if index < slip10.Hardened {
+		return ErrNotHardened
+	}
+	// I ← HMAC-SHA512(Key = chain_par, Data = 0x00 || ser256(key_par) || ser32(index)),
+	// computed directly as the chain code is shorter than the block size
+	var inner [sha512.BlockSize + 1 + ed25519.SeedSize + 4]byte
+	var outer [sha512.BlockSize + sha512.Size]byte
+	for i := 0; i < sha512.BlockSize; i++ {
+		inner[i] = 0x36
+		outer[i] = 0x5c
+	}
+	for i, c := range n.ChainCode {
+		inner[i] ^= c
+		outer[i] ^= c
+	}
+	copy(inner[sha512.BlockSize+1:], n.Key[:])
+	binary.BigEndian.PutUint32(inner[sha512.BlockSize+1+ed25519.SeedSize:], index)
+	sum := sha512.Sum512(inner[:])
+	copy(outer[sha512.BlockSize:], sum[:])
+	sum = sha512.Sum512(outer[:])
+
+	child.set(&sum)
+	clear(inner[:])
+	clear(outer[:])
+	clear(sum[:])
+	return nil
+}
+
+// set sets the key to I_L and the chain code to I_R of the HMAC result and clears it.
+func (n *Node) set(sum *[sha512.Size]byte) {
+	copy(n.Key[:], sum[:32])
+	copy(n.ChainCode[:], sum[32:])
+	clear(sum[:])
+}
+
+// Seed returns a copy of the key as Seed, e.g. to obtain the Ed25519 key pair.
+func (n *Node) Seed() Seed {
+	return Seed(append([]byte{}, n.Key[:]...))
+}
+
+// Clear overwrites the key and chain code with zeros.
+func (n *Node) Clear() {
+	clear(n.Key[:])
+	clear(n.ChainCode[:])
+}
@@ -33,6 +33,16 @@ func (c Curve) NewPrivateKey(buf []byte) (slip10.Key, error) {
 	return &PrivateKey{sc, c}, nil
 }
 
+// ParsePublicKey parses a compressed public key as returned by PublicKey.Bytes.
+// When buf does not correspond to a valid point on the curve, an error is returned.
+func (c Curve) ParsePublicKey(buf []byte) (slip10.Key, error) {
+	x, y := elliptic.UnmarshalCompressed(c.Curve, buf)
+	if x == nil {
+		return nil, slip10.ErrInvalidKey
+	}
+	return &PublicKey{x, y, c}, nil
+}
+
 type secp256k1Curve struct {
 	Curve
 }
@@ -10,6 +10,7 @@ import (
 )
 
 // PrivateKey implements slip10.Key and represents a private key for elliptic.Curve.
+// The scalar K is a big.Int, so operations on it are not constant time and it cannot reliably be cleared.
 type PrivateKey struct {
 	K     *big.Int
 	Curve elliptic.Curve
@@ -0,0 +1,196 @@
+package elliptic
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"encoding/asn1"
+	"errors"
+	"io"
+	"math/big"
+)
+
+// ErrHashUnavailable is returned when the hash function requested for signing is not available.
+var ErrHashUnavailable = errors.New("hash function unavailable")
+
+// SignerOpts contains options for signing with a Signer.
+type SignerOpts struct {
+	// Hash is the hash function used to compute the signed digest.
+	// It is also used for the HMAC-DRBG during the nonce generation.
+	Hash crypto.Hash
+	// LowS forces the s value of the signature to be at most N/2 as required by Bitcoin's BIP-62.
+	LowS bool
+}
+
+// HashFunc returns Hash so that SignerOpts implements crypto.SignerOpts.
+func (opts *SignerOpts) HashFunc() crypto.Hash {
+	return opts.Hash
+}
+
+// Signer implements crypto.Signer and creates deterministic ECDSA signatures as described in RFC 6979.
+// Signing does not require a source of randomness and the same private key, digest and hash function always
+// produce the same signature.
+type Signer struct {
+	key *PrivateKey
+}
+
+// NewSigner returns a new Signer for the given private key.
+func NewSigner(key *PrivateKey) *Signer {
+	return &Signer{key: key}
+}
+
+// Public returns the corresponding public key as *ecdsa.PublicKey.
+func (s *Signer) Public() crypto.PublicKey {
+	return s.key.ECDSAPrivateKey().Public()
+}
+
+// Sign signs digest with the private key and returns the ASN.1 DER encoded signature.
+// The rand argument is ignored, as the nonce is derived deterministically.
+// The hash function of opts must be available as it is used for the nonce generation.
+// If opts is a *SignerOpts, the LowS option is respected.
+func (s *Signer) Sign(_ io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	var lowS bool
+	if o, ok := opts.(*SignerOpts); ok {
+		lowS = o.LowS
+	}
+	r, ss, err := SignRFC6979(s.key, opts.HashFunc(), digest, lowS)
+	if err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(struct{ R, S *big.Int }{r, ss})
+}
+
+// SignRFC6979 signs digest with the private key using the deterministic nonce generation of RFC 6979.
+// The hash function h must correspond to the function used to compute digest.
+// If lowS is set, the s value of the signature is normalized to be at most N/2.
+func SignRFC6979(key *PrivateKey, h crypto.Hash, digest []byte, lowS bool) (r, s *big.Int, err error) {
+	if h == 0 || !h.Available() {
+		return nil, nil, ErrHashUnavailable
+	}
+	params := key.Curve.Params()
+	n := params.N
+	e := hashToInt(digest, n)
+
+	nonces := newNonceGenerator(h, key.K, digest, n)
+	for {
+		k := nonces.next()
+
+		// r = (k⋅G).x mod n
+		x, _ := key.Curve.ScalarBaseMult(k.Bytes())
+		r = x.Mod(x, n)
+		if r.Sign() == 0 {
+			continue
+		}
+
+		// s = k⁻¹ (e + r⋅d) mod n
+		s = new(big.Int).Mul(r, key.K)
+		s.Add(s, e)
+		s.Mul(s, new(big.Int).ModInverse(k, n))
+		s.Mod(s, n)
+		if s.Sign() == 0 {
+			continue
+		}
+
+		if lowS && s.Cmp(new(big.Int).Rsh(n, 1)) > 0 {
+			s.Sub(n, s)
+		}
+		return r, s, nil
+	}
+}
+
+// VerifyASN1 reports whether the ASN.1 encoded signature sig of digest is valid for the public key.
+func VerifyASN1(key *PublicKey, digest, sig []byte) bool {
+	return ecdsa.VerifyASN1(key.ECDSAPublicKey(), digest, sig)
+}
+
+// nonceGenerator implements the HMAC-DRBG based nonce generation of RFC 6979, section 3.2.
+type nonceGenerator struct {
+	h    crypto.Hash
+	n    *big.Int
+	k, v []byte
+}
+
+func newNonceGenerator(h crypto.Hash, x *big.Int, digest []byte, n *big.Int) *nonceGenerator {
+	g := &nonceGenerator{h: h, n: n}
+	size := h.Size()
+
+	// b. V = 0x01 0x01 0x01 ... 0x01
+	g.v = make([]byte, size)
+	for i := range g.v {
+		g.v[i] = 0x01
+	}
+	// c. K = 0x00 0x00 0x00 ... 0x00
+	g.k = make([]byte, size)
+
+	privateKey := int2octets(x, n)
+	h1 := bits2octets(digest, n)
+
+	// d. K = HMAC_K(V || 0x00 || int2octets(x) || bits2octets(h1))
+	g.k = g.mac(g.k, g.v, []byte{0x00}, privateKey, h1)
+	// e. V = HMAC_K(V)
+	g.v = g.mac(g.k, g.v)
+	// f. K = HMAC_K(V || 0x01 || int2octets(x) || bits2octets(h1))
+	g.k = g.mac(g.k, g.v, []byte{0x01}, privateKey, h1)
+	// g. V = HMAC_K(V)
+	g.v = g.mac(g.k, g.v)
+	return g
+}
+
+// next returns the next candidate nonce k in [1, n-1].
+func (g *nonceGenerator) next() *big.Int {
+	rlen := (g.n.BitLen() + 7) / 8
+	for {
+		// h.1 + h.2
+		t := make([]byte, 0, rlen+g.h.Size())
+		for len(t) < rlen {
+			g.v = g.mac(g.k, g.v)
+			t = append(t, g.v...)
+		}
+		// h.3
+		k := bits2int(t, g.n)
+
+		// K = HMAC_K(V || 0x00), V = HMAC_K(V) to prepare for a potential next iteration
+		g.k = g.mac(g.k, g.v, []byte{0x00})
+		g.v = g.mac(g.k, g.v)
+
+		if k.Sign() > 0 && k.Cmp(g.n) < 0 {
+			return k
+		}
+	}
+}
+
+func (g *nonceGenerator) mac(key []byte, data ...[]byte) []byte {
+	m := hmac.New(g.h.New, key)
+	for _, d := range data {
+		m.Write(d)
+	}
+	return m.Sum(nil)
+}
+
+// bits2int converts b into a non-negative integer of at most qlen bits as described in RFC 6979, section 2.3.2.
+func bits2int(b []byte, n *big.Int) *big.Int {
+	x := new(big.Int).SetBytes(b)
+	if excess := len(b)*8 - n.BitLen(); excess > 0 {
+		x.Rsh(x, uint(excess))
+	}
+	return x
+}
+
+// int2octets returns the big-endian representation of x with length rlen as described in RFC 6979, section 2.3.3.
+func int2octets(x *big.Int, n *big.Int) []byte {
+	return x.FillBytes(make([]byte, (n.BitLen()+7)/8))
+}
+
+// bits2octets as described in RFC 6979, section 2.3.4.
+func bits2octets(b []byte, n *big.Int) []byte {
+	z := bits2int(b, n)
+	if z.Cmp(n) >= 0 {
+		z.Sub(z, n)
+	}
+	return int2octets(z, n)
+}
+
+// hashToInt converts a hash value to an integer as described in SEC 1, section 4.1.3.
+func hashToInt(digest []byte, n *big.Int) *big.Int {
+	return bits2int(digest, n)
+}
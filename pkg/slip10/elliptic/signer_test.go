@@ -0,0 +1,109 @@
+//nolint:scopelint
+package elliptic_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10/elliptic"
+)
+
+func TestSignRFC6979(t *testing.T) {
+	var tests = []*struct {
+		desc    string
+		curve   slip10.Curve
+		key     string
+		message string
+		lowS    bool
+		expR    string
+		expS    string
+	}{
+		// RFC 6979, A.2.5
+		{
+			desc:    "P-256 sample",
+			curve:   elliptic.Nist256p1(),
+			key:     "c9afa9d845ba75166b5c215767b1d6934e50c3db36e89b127b8a622b120f6721",
+			message: "sample",
+			expR:    "efd48b2aacb6a8fd1140dd9cd45e81d69d2c877b56aaf991c34d0ea84eaf3716",
+			expS:    "f7cb1c942d657c41d436c7a1b6e29f65f3e900dbb9aff4064dc4ab2f843acda8",
+		},
+		{
+			desc:    "P-256 test",
+			curve:   elliptic.Nist256p1(),
+			key:     "c9afa9d845ba75166b5c215767b1d6934e50c3db36e89b127b8a622b120f6721",
+			message: "test",
+			expR:    "f1abb023518351cd71d881567b1ea663ed3efcf6c5132b354f28d3b0b7d38367",
+			expS:    "019f4113742a2b14bd25926b49c649155f267e60d3814b4c0cc84250e46f0083",
+		},
+		{
+			desc:    "secp256k1",
+			curve:   elliptic.Secp256k1(),
+			key:     "0000000000000000000000000000000000000000000000000000000000000001",
+			message: "Satoshi Nakamoto",
+			lowS:    true,
+			expR:    "934b1ea10a4b3c1757e2b0c017d0b6143ce3c9a7e6a4a49860d7a6ab210ee3d8",
+			expS:    "2442ce9d2b916064108014783e923ec36b49743e2ffa1c4496f01a512aafd9e5",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			key := newPrivateKey(t, tt.curve, tt.key)
+			digest := sha256.Sum256([]byte(tt.message))
+
+			r, s, err := elliptic.SignRFC6979(key, crypto.SHA256, digest[:], tt.lowS)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expR, hex.EncodeToString(r.FillBytes(make([]byte, 32))))
+			assert.Equal(t, tt.expS, hex.EncodeToString(s.FillBytes(make([]byte, 32))))
+			assert.True(t, ecdsa.Verify(&key.ECDSAPrivateKey().PublicKey, digest[:], r, s))
+		})
+	}
+}
+
+func TestSigner(t *testing.T) {
+	for _, curve := range []slip10.Curve{elliptic.Nist256p1(), elliptic.Secp256k1()} {
+		t.Run(curve.Name(), func(t *testing.T) {
+			seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+			extended, err := slip10.DeriveKeyFromPath(seed, curve, []uint32{0 | slip10.Hardened, 1})
+			require.NoError(t, err)
+			//nolint:forcetypeassert
+			key := extended.Key.(*elliptic.PrivateKey)
+
+			var signer crypto.Signer = elliptic.NewSigner(key)
+			digest := sha256.Sum256([]byte("test message"))
+
+			sig, err := signer.Sign(nil, digest[:], &elliptic.SignerOpts{Hash: crypto.SHA256, LowS: true})
+			require.NoError(t, err)
+			other, err := signer.Sign(nil, digest[:], crypto.SHA256)
+			require.NoError(t, err)
+			again, err := signer.Sign(nil, digest[:], crypto.SHA256)
+			require.NoError(t, err)
+			assert.Equal(t, other, again, "signatures are not deterministic")
+
+			//nolint:forcetypeassert
+			pub := extended.Key.Public().(*elliptic.PublicKey)
+			assert.True(t, elliptic.VerifyASN1(pub, digest[:], sig))
+			assert.True(t, elliptic.VerifyASN1(pub, digest[:], other))
+			assert.True(t, pub.ECDSAPublicKey().Equal(signer.Public()))
+
+			_, err = signer.Sign(nil, digest[:], crypto.Hash(0))
+			assert.ErrorIs(t, err, elliptic.ErrHashUnavailable)
+		})
+	}
+}
+
+func newPrivateKey(t *testing.T, curve slip10.Curve, s string) *elliptic.PrivateKey {
+	b, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	key, err := curve.NewPrivateKey(b)
+	require.NoError(t, err)
+	//nolint:forcetypeassert
+	return key.(*elliptic.PrivateKey)
+}
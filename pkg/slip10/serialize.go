@@ -0,0 +1,142 @@
+package slip10
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/base58"
+)
+
+// Version bytes of the BIP-32 serialization of extended keys.
+const (
+	// MainnetPrivate is the version of mainnet extended private keys, encoded with the "xprv" prefix.
+	MainnetPrivate uint32 = 0x0488ADE4
+	// MainnetPublic is the version of mainnet extended public keys, encoded with the "xpub" prefix.
+	MainnetPublic uint32 = 0x0488B21E
+	// TestnetPrivate is the version of testnet extended private keys, encoded with the "tprv" prefix.
+	TestnetPrivate uint32 = 0x04358394
+	// TestnetPublic is the version of testnet extended public keys, encoded with the "tpub" prefix.
+	TestnetPublic uint32 = 0x043587CF
+
+	// SerializedKeySize is the size, in bytes, of a serialized extended key without its checksum.
+	SerializedKeySize = 4 + 1 + FingerprintSize + 4 + ChainCodeSize + PublicKeySize
+)
+
+var (
+	// ErrInvalidDepth is returned when an extended key is too deep to be serialized.
+	ErrInvalidDepth = errors.New("invalid depth")
+	// ErrUnknownVersion is returned when a serialized extended key has an unknown version.
+	ErrUnknownVersion = errors.New("unknown version")
+)
+
+// Serialize returns the BIP-32 serialization of the extended key with the given version, i.e. version, depth, parent
+// fingerprint, child index, chain code and key data, followed by the Base58Check checksum and encoded in Base58.
+// The serialization is only interoperable for secp256k1 keys, as BIP-32 does not define it for other curves.
+func (e *ExtendedKey) Serialize(version uint32) (string, error) {
+	if e.depth > 0xff {
+		return "", fmt.Errorf("%w: %d", ErrInvalidDepth, e.depth)
+	}
+	b := make([]byte, 0, SerializedKeySize+base58.ChecksumSize)
+	b = binary.BigEndian.AppendUint32(b, version)
+	b = append(b, byte(e.depth))
+	b = append(b, e.Fingerprint()...)
+	b = binary.BigEndian.AppendUint32(b, e.index)
+	b = append(b, e.ChainCode...)
+	if e.IsPrivate() {
+		// private keys are prefixed with 0x00 to match the size of public keys
+		b = append(b, 0x00)
+	}
+	b = append(b, e.Key.Bytes()...)
+	if len(b) != SerializedKeySize {
+		return "", fmt.Errorf("%w: unsupported key size", ErrInvalidKey)
+	}
+	b = append(b, base58.Checksum(b)...)
+	return base58.Encode(b), nil
+}
+
+// XPub returns the mainnet "xpub" serialization of the extended public key.
+func (e *ExtendedKey) XPub() (string, error) {
+	return e.Public().Serialize(MainnetPublic)
+}
+
+// XPrv returns the mainnet "xprv" serialization of the extended private key.
+func (e *ExtendedKey) XPrv() (string, error) {
+	if !e.IsPrivate() {
+		return "", fmt.Errorf("%w: not a private key", ErrInvalidKey)
+	}
+	return e.Serialize(MainnetPrivate)
+}
+
+// Deserialize parses the BIP-32 serialization of an extended key for curve as returned by Serialize and returns it
+// together with its version. Only the versions defined in this package are supported, as they determine whether the
+// key data contains a private or public key. Extended public keys can only be parsed, if curve implements
+// PublicKeyParser.
+func Deserialize(s string, curve Curve) (*ExtendedKey, uint32, error) {
+	b, err := base58.Decode(s)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(b) != SerializedKeySize+base58.ChecksumSize {
+		return nil, 0, fmt.Errorf("%w: invalid length %d", ErrInvalidKey, len(b))
+	}
+	data, sum := b[:SerializedKeySize], b[SerializedKeySize:]
+	if !bytes.Equal(sum, base58.Checksum(data)) {
+		return nil, 0, base58.ErrInvalidChecksum
+	}
+
+	version := binary.BigEndian.Uint32(data)
+	depth := int(data[4])
+	fingerprint := data[5 : 5+FingerprintSize]
+	data = data[5+FingerprintSize:]
+	index := binary.BigEndian.Uint32(data)
+	chainCode := data[4 : 4+ChainCodeSize]
+	keyData := data[4+ChainCodeSize:]
+
+	switch version {
+	case MainnetPrivate, TestnetPrivate:
+		if keyData[0] != 0x00 {
+			return nil, 0, fmt.Errorf("%w: invalid private key prefix", ErrInvalidKey)
+		}
+		keyData = keyData[1:]
+	case MainnetPublic, TestnetPublic:
+	default:
+		return nil, 0, fmt.Errorf("%w: %#08x", ErrUnknownVersion, version)
+	}
+	e, err := newExtendedKey(depth, fingerprint, index, chainCode, keyData, curve)
+	if err != nil {
+		return nil, 0, err
+	}
+	return e, version, nil
+}
+
+// newExtendedKey validates the fields of a decoded extended key and parses its key data for curve.
+func newExtendedKey(
+	depth int, fingerprint []byte, index uint32, chainCode []byte, keyData []byte, curve Curve,
+) (*ExtendedKey, error) {
+	if len(fingerprint) != FingerprintSize {
+		return nil, fmt.Errorf("%w: invalid parent fingerprint length %d", ErrInvalidKey, len(fingerprint))
+	}
+	if depth == 0 && (index != 0 || !bytes.Equal(fingerprint, make([]byte, FingerprintSize))) {
+		return nil, fmt.Errorf("%w: master key with parent", ErrInvalidKey)
+	}
+	if len(chainCode) != ChainCodeSize {
+		return nil, fmt.Errorf("%w: invalid chain code length %d", ErrInvalidKey, len(chainCode))
+	}
+	key, err := parseKey(keyData, curve)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &ExtendedKey{
+		ChainCode: chainCode,
+		Key:       key,
+		depth:     depth,
+		index:     index,
+	}
+	if depth > 0 {
+		e.parentFingerprint = fingerprint
+	}
+	return e, nil
+}
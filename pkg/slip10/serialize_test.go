@@ -0,0 +1,145 @@
+//nolint:scopelint
+package slip10_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/base58"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip32path"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10/eddsa"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10/elliptic"
+)
+
+// test vector 1 of BIP-32
+var serializeTests = []*struct {
+	path string
+	xpub string
+	xprv string
+}{
+	{
+		"m",
+		"xpub661MyMwAqRbcFtXgS5sYJABqqG9YLmC4Q1Rdap9gSE8NqtwybGhePY2gZ29ESFjqJoCu1Rupje8YtGqsefD265TMg7usUDFdp6W1EGMcet8",
+		"xprv9s21ZrQH143K3QTDL4LXw2F7HEK3wJUD2nW2nRk4stbPy6cq3jPPqjiChkVvvNKmPGJxWUtg6LnF5kejMRNNU3TGtRBeJgk33yuGBxrMPHi",
+	},
+	{
+		"m/0'",
+		"xpub68Gmy5EdvgibQVfPdqkBBCHxA5htiqg55crXYuXoQRKfDBFA1WEjWgP6LHhwBZeNK1VTsfTFUHCdrfp1bgwQ9xv5ski8PX9rL2dZXvgGDnw",
+		"xprv9uHRZZhk6KAJC1avXpDAp4MDc3sQKNxDiPvvkX8Br5ngLNv1TxvUxt4cV1rGL5hj6KCesnDYUhd7oWgT11eZG7XnxHrnYeSvkzY7d2bhkJ7",
+	},
+	{
+		"m/0'/1",
+		"xpub6ASuArnXKPbfEwhqN6e3mwBcDTgzisQN1wXN9BJcM47sSikHjJf3UFHKkNAWbWMiGj7Wf5uMash7SyYq527Hqck2AxYysAA7xmALppuCkwQ",
+		"xprv9wTYmMFdV23N2TdNG573QoEsfRrWKQgWeibmLntzniatZvR9BmLnvSxqu53Kw1UmYPxLgboyZQaXwTCg8MSY3H2EU4pWcQDnRnrVA1xe8fs",
+	},
+}
+
+func TestSerialize(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	for _, tt := range serializeTests {
+		t.Run(tt.path, func(t *testing.T) {
+			path, err := bip32path.ParsePath(tt.path)
+			require.NoError(t, err)
+			key, err := slip10.DeriveKeyFromPath(seed, elliptic.Secp256k1(), path)
+			require.NoError(t, err)
+
+			xpub, err := key.XPub()
+			require.NoError(t, err)
+			assert.Equal(t, tt.xpub, xpub)
+			xprv, err := key.XPrv()
+			require.NoError(t, err)
+			assert.Equal(t, tt.xprv, xprv)
+
+			pub, err := key.Public().Serialize(slip10.MainnetPublic)
+			require.NoError(t, err)
+			assert.Equal(t, tt.xpub, pub)
+			_, err = key.Public().XPrv()
+			assert.ErrorIs(t, err, slip10.ErrInvalidKey)
+		})
+	}
+}
+
+func TestDeserialize(t *testing.T) {
+	for _, tt := range serializeTests {
+		t.Run(tt.path, func(t *testing.T) {
+			public, version, err := slip10.Deserialize(tt.xpub, elliptic.Secp256k1())
+			require.NoError(t, err)
+			assert.Equal(t, slip10.MainnetPublic, version)
+			assert.False(t, public.IsPrivate())
+			xpub, err := public.XPub()
+			require.NoError(t, err)
+			assert.Equal(t, tt.xpub, xpub)
+
+			private, version, err := slip10.Deserialize(tt.xprv, elliptic.Secp256k1())
+			require.NoError(t, err)
+			assert.Equal(t, slip10.MainnetPrivate, version)
+			assert.True(t, private.IsPrivate())
+			xprv, err := private.XPrv()
+			require.NoError(t, err)
+			assert.Equal(t, tt.xprv, xprv)
+			// the public key of the deserialized private key must match
+			xpub, err = private.XPub()
+			require.NoError(t, err)
+			assert.Equal(t, tt.xpub, xpub)
+		})
+	}
+}
+
+func TestDeserializeEd25519(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	path, _ := bip32path.ParsePath("m/44'/4218'/0'")
+	key, err := slip10.DeriveKeyFromPath(seed, eddsa.Ed25519(), path)
+	require.NoError(t, err)
+	xpub, err := key.XPub()
+	require.NoError(t, err)
+
+	public, _, err := slip10.Deserialize(xpub, eddsa.Ed25519())
+	require.NoError(t, err)
+	assert.Equal(t, key.Public().Key, public.Key)
+	assert.Equal(t, key.ChainCode, public.ChainCode)
+	assert.Equal(t, key.Fingerprint(), public.Fingerprint())
+}
+
+func TestDeserializeInvalid(t *testing.T) {
+	xpub := serializeTests[1].xpub
+	var tests = []*struct {
+		name string
+		s    string
+		err  error
+	}{
+		{"checksum", xpub[:len(xpub)-1] + "x", base58.ErrInvalidChecksum},
+		{"length", xpub[:len(xpub)-2], slip10.ErrInvalidKey},
+		{"character", "0" + xpub[1:], base58.ErrInvalidCharacter},
+		{"version", serializeWithVersion(t, xpub, 0x049d7cb2), slip10.ErrUnknownVersion},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := slip10.Deserialize(tt.s, elliptic.Secp256k1())
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestKeyFingerprint(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	master, err := slip10.NewMasterKey(seed, elliptic.Secp256k1())
+	require.NoError(t, err)
+	child, err := master.DeriveChild(slip10.Hardened)
+	require.NoError(t, err)
+	assert.Equal(t, child.Fingerprint(), master.KeyFingerprint())
+	// fingerprint of the master key of BIP-32 test vector 1
+	assert.Equal(t, "3442193e", hex.EncodeToString(master.KeyFingerprint()))
+}
+
+// serializeWithVersion replaces the version of the serialized key s.
+func serializeWithVersion(t *testing.T, s string, version uint32) string {
+	key, _, err := slip10.Deserialize(s, elliptic.Secp256k1())
+	require.NoError(t, err)
+	res, err := key.Serialize(version)
+	require.NoError(t, err)
+	return res
+}
@@ -14,6 +14,12 @@ SLIP-0010 provides an extension of BIP-0032. As such, when the secp256k1 curve
 is selected this package is fully compatible to the corresponding derivations
 described in BIP-0032.
 
+The derivation of ExtendedKey boxes the keys in the Key interface and
+allocates for every child. Private keys of secp256k1 and NIST P-256 are
+scalars in math/big, whose arithmetic is not constant time. For Ed25519, whose
+derivation only consists of HMAC-SHA512, eddsa.Node provides a derivation on
+fixed-size arrays without any heap allocations.
+
 This package is tested against the test vectors provided in the official
 SLIP-0010 specification.
 */
@@ -56,7 +62,10 @@ type ExtendedKey struct {
 	ChainCode []byte
 	Key       Key
 
-	parent Key // the parent key needed for the fingerprint computation
+	parent            Key    // the parent key needed for the fingerprint computation
+	parentFingerprint []byte // the fingerprint of a decoded key, whose parent key is unknown
+	depth             int    // the number of derivations from the master key
+	index             uint32 // the index of the last derivation
 }
 
 // A Curve represents a curve type to derive private and public key pairs for.
@@ -73,6 +82,13 @@ type Curve interface {
 	NewPrivateKey(buf []byte) (Key, error)
 }
 
+// A PublicKeyParser is a Curve that can also parse the serialization of its public keys.
+type PublicKeyParser interface {
+	// ParsePublicKey parses a public key serialized as returned by its Bytes method.
+	// It returns ErrInvalidKey if buf does not correspond to a valid public key.
+	ParsePublicKey(buf []byte) (Key, error)
+}
+
 // A Key represents a private or public key for a curve.
 type Key interface {
 	// Bytes serializes the key as a byte slice.
@@ -198,6 +214,8 @@ step2:
 		ChainCode: chainCode,
 		Key:       childKey,
 		parent:    e.Key,
+		depth:     e.depth + 1,
+		index:     index,
 	}, nil
 }
 
@@ -213,18 +231,30 @@ func (e *ExtendedKey) Public() *ExtendedKey {
 		ChainCode: e.ChainCode,
 		Key:       e.Key.Public(),
 		parent:    e.parent,
+		depth:     e.depth,
+		index:     e.index,
+
+		parentFingerprint: e.parentFingerprint,
 	}
 }
 
 // Fingerprint returns the fingerprint of the parent's key.
 func (e *ExtendedKey) Fingerprint() []byte {
 	if e.parent == nil {
+		if e.parentFingerprint != nil {
+			return append([]byte{}, e.parentFingerprint...)
+		}
 		return make([]byte, FingerprintSize)
 	}
 	parentBytes := e.parent.Public().Bytes()
 	return hash160(parentBytes)[:FingerprintSize]
 }
 
+// KeyFingerprint returns the fingerprint of the key itself, which is the parent fingerprint of all its children.
+func (e *ExtendedKey) KeyFingerprint() []byte {
+	return hash160(e.Key.Public().Bytes())[:FingerprintSize]
+}
+
 func uint32Bytes(i uint32) []byte {
 	bytes := make([]byte, 4)
 	binary.BigEndian.PutUint32(bytes, i)
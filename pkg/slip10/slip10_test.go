@@ -5,9 +5,7 @@ import (
 	"crypto/ecdsa"
 	cryptorand "crypto/rand"
 	"encoding/hex"
-	"encoding/json"
 	"math/rand"
-	"os"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -15,27 +13,13 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
-	"github.com/iotaledger/iota-crypto-demo/internal/hexutil"
-	"github.com/iotaledger/iota-crypto-demo/pkg/bip32path"
 	"github.com/iotaledger/iota-crypto-demo/pkg/ed25519"
 	"github.com/iotaledger/iota-crypto-demo/pkg/slip10"
 	"github.com/iotaledger/iota-crypto-demo/pkg/slip10/eddsa"
 	"github.com/iotaledger/iota-crypto-demo/pkg/slip10/elliptic"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10/vectors"
 )
 
-type Test struct {
-	Path        bip32path.Path `json:"chain"`
-	Fingerprint hexutil.Bytes  `json:"fingerprint"`
-	ChainCode   hexutil.Bytes  `json:"chainCode"`
-	Private     hexutil.Bytes  `json:"private"`
-	Public      hexutil.Bytes  `json:"public"`
-}
-
-type TestVector struct {
-	Seed  hexutil.Bytes `json:"seed"`
-	Tests []Test        `json:"tests"`
-}
-
 func TestSecp256k1(t *testing.T) {
 	tvs := readJSONTests(t)
 	runCurveTests(t, elliptic.Secp256k1(), tvs)
@@ -109,17 +93,13 @@ func TestEd25519PublicDerivation(t *testing.T) {
 	require.ErrorIs(t, err, eddsa.ErrNotHardened)
 }
 
-func readJSONTests(t *testing.T) []TestVector {
-	b, err := os.ReadFile(filepath.Join("testdata", t.Name()+".json"))
-	require.NoError(t, err)
-
-	var tvs []TestVector
-	err = json.Unmarshal(b, &tvs)
+func readJSONTests(t *testing.T) []vectors.Vector {
+	tvs, err := vectors.LoadFile(filepath.Join("testdata", t.Name()+".json"))
 	require.NoError(t, err)
 	return tvs
 }
 
-func runCurveTests(t *testing.T, curve slip10.Curve, tvs []TestVector) {
+func runCurveTests(t *testing.T, curve slip10.Curve, tvs []vectors.Vector) {
 	for _, tv := range tvs {
 		t.Run("", func(t *testing.T) {
 			runTests(t, tv.Seed, curve, tv.Tests)
@@ -127,7 +107,7 @@ func runCurveTests(t *testing.T, curve slip10.Curve, tvs []TestVector) {
 	}
 }
 
-func runTests(t *testing.T, seed []byte, curve slip10.Curve, tests []Test) {
+func runTests(t *testing.T, seed []byte, curve slip10.Curve, tests []vectors.Test) {
 	for _, tt := range tests {
 		t.Run(strings.ReplaceAll(tt.Path.String(), "/", "|"), func(t *testing.T) {
 			privateKey, err := slip10.DeriveKeyFromPath(seed, curve, tt.Path)
@@ -184,3 +164,67 @@ func BenchmarkHardenedDerivation(b *testing.B) {
 		key, _ = key.DeriveChild(index)
 	}
 }
+
+func TestEd25519Node(t *testing.T) {
+	tvs, err := vectors.LoadFile(filepath.Join("testdata", "TestEd25519.json"))
+	require.NoError(t, err)
+	for _, tv := range tvs {
+		for _, tt := range tv.Tests {
+			t.Run(strings.ReplaceAll(tt.Path.String(), "/", "|"), func(t *testing.T) {
+				node, err := eddsa.DeriveNode(tv.Seed, tt.Path)
+				require.NoError(t, err)
+				assert.EqualValues(t, tt.ChainCode, node.ChainCode[:], "unexpected chain code")
+				assert.EqualValues(t, tt.Private, node.Key[:], "unexpected private key")
+				assert.EqualValues(t, tt.Public, node.Seed().Public().Bytes(), "unexpected public key")
+
+				key, err := slip10.DeriveKeyFromPath(tv.Seed, eddsa.Ed25519(), tt.Path)
+				require.NoError(t, err)
+				child, err := node.DeriveChild(7 | slip10.Hardened)
+				require.NoError(t, err)
+				expected, err := key.DeriveChild(7 | slip10.Hardened)
+				require.NoError(t, err)
+				assert.EqualValues(t, expected.Key.Bytes(), child.Key[:])
+				assert.EqualValues(t, expected.ChainCode, child.ChainCode[:])
+			})
+		}
+	}
+}
+
+func TestEd25519NodeNotHardened(t *testing.T) {
+	node := eddsa.NewMasterNode(make([]byte, 16))
+	_, err := node.DeriveChild(0)
+	assert.ErrorIs(t, err, eddsa.ErrNotHardened)
+	_, err = eddsa.DeriveNode(make([]byte, 16), []uint32{slip10.Hardened, 1})
+	assert.ErrorIs(t, err, eddsa.ErrNotHardened)
+}
+
+func TestEd25519NodeAllocs(t *testing.T) {
+	node := eddsa.NewMasterNode(make([]byte, 16))
+	allocs := testing.AllocsPerRun(100, func() {
+		node, _ = node.DeriveChild(slip10.Hardened)
+	})
+	assert.Zero(t, allocs)
+}
+
+func BenchmarkEd25519Derivation(b *testing.B) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	key, err := slip10.NewMasterKey(seed, eddsa.Ed25519())
+	require.NoError(b, err)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		key, _ = key.DeriveChild(uint32(i) | slip10.Hardened)
+	}
+}
+
+func BenchmarkEd25519NodeDerivation(b *testing.B) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	node := eddsa.NewMasterNode(seed)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		node, _ = node.DeriveChild(uint32(i) | slip10.Hardened)
+	}
+}
@@ -0,0 +1,137 @@
+package slip10
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/iotaledger/iota-crypto-demo/internal/hexutil"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip32path"
+)
+
+// ErrInvalidTreeSize is returned when the requested tree dimensions are invalid.
+var ErrInvalidTreeSize = errors.New("invalid tree size")
+
+// maxTreeNodes limits the number of nodes in a tree created by DumpTree.
+const maxTreeNodes = 1 << 16
+
+// TreeNode represents a single extended key in a derivation tree.
+type TreeNode struct {
+	Path        bip32path.Path `json:"chain"`
+	Fingerprint hexutil.Bytes  `json:"fingerprint"`
+	ChainCode   hexutil.Bytes  `json:"chainCode"`
+	Public      hexutil.Bytes  `json:"public"`
+	Address     string         `json:"address,omitempty"`
+	Children    []*TreeNode    `json:"children,omitempty"`
+
+	key Key
+}
+
+// DumpTree derives the tree of extended keys for the curve from seed.
+// Starting from the master key, each node has breadth hardened children with the indices 0H to (breadth-1)H, up to a
+// depth of maxDepth. Only public information is contained in the returned tree.
+func DumpTree(seed []byte, curve Curve, maxDepth int, breadth int) (*TreeNode, error) {
+	if maxDepth < 0 || breadth < 0 || treeSize(maxDepth, breadth) > maxTreeNodes {
+		return nil, fmt.Errorf("%w: depth=%d, breadth=%d", ErrInvalidTreeSize, maxDepth, breadth)
+	}
+	key, err := NewMasterKey(seed, curve)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate master key: %w", err)
+	}
+	return dumpTree(key, bip32path.Path{}, maxDepth, breadth)
+}
+
+func dumpTree(key *ExtendedKey, path bip32path.Path, depth int, breadth int) (*TreeNode, error) {
+	node := &TreeNode{
+		Path:        path,
+		Fingerprint: key.Fingerprint(),
+		ChainCode:   key.ChainCode,
+		Public:      key.Key.Public().Bytes(),
+		key:         key.Key.Public(),
+	}
+	if depth == 0 {
+		return node, nil
+	}
+	for i := 0; i < breadth; i++ {
+		index := uint32(i) | Hardened
+		child, err := key.DeriveChild(index)
+		if err != nil {
+			return nil, fmt.Errorf("failed to derive child key: %w", err)
+		}
+		childPath := append(path[:len(path):len(path)], index)
+		childNode, err := dumpTree(child, childPath, depth-1, breadth)
+		if err != nil {
+			return nil, err
+		}
+		node.Children = append(node.Children, childNode)
+	}
+	return node, nil
+}
+
+// Walk calls fn for each node of the tree in depth-first pre-order.
+// If fn returns an error, the walk is stopped and the error is returned.
+func (n *TreeNode) Walk(fn func(*TreeNode) error) error {
+	if err := fn(n); err != nil {
+		return err
+	}
+	for _, child := range n.Children {
+		if err := child.Walk(fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetAddresses sets the Address of each node in the tree to the result of addr applied to the node's public key.
+// This allows to annotate the tree with curve-specific addresses.
+func (n *TreeNode) SetAddresses(addr func(Key) (string, error)) error {
+	return n.Walk(func(node *TreeNode) error {
+		s, err := addr(node.key)
+		if err != nil {
+			return fmt.Errorf("failed to compute address for %s: %w", node.Path, err)
+		}
+		node.Address = s
+		return nil
+	})
+}
+
+// DOT returns the tree in the Graphviz DOT language.
+func (n *TreeNode) DOT() string {
+	var b strings.Builder
+	b.WriteString("digraph slip10 {\n")
+	b.WriteString("\tnode [shape=box, fontname=monospace];\n")
+	_ = n.Walk(func(node *TreeNode) error {
+		fmt.Fprintf(&b, "\t%q [label=%q];\n", node.Path.String(), node.label())
+		for _, child := range node.Children {
+			fmt.Fprintf(&b, "\t%q -> %q;\n", node.Path.String(), child.Path.String())
+		}
+		return nil
+	})
+	b.WriteString("}\n")
+	return b.String()
+}
+
+func (n *TreeNode) label() string {
+	var b strings.Builder
+	b.WriteString(n.Path.String())
+	fmt.Fprintf(&b, "\nfingerprint: %s", n.Fingerprint)
+	fmt.Fprintf(&b, "\nchain code: %s", n.ChainCode)
+	fmt.Fprintf(&b, "\npublic: %s", n.Public)
+	if n.Address != "" {
+		fmt.Fprintf(&b, "\naddress: %s", n.Address)
+	}
+	return b.String()
+}
+
+// treeSize returns the number of nodes in a full tree, saturating at maxTreeNodes+1.
+func treeSize(depth int, breadth int) int {
+	size, level := 1, 1
+	for i := 0; i < depth; i++ {
+		level *= breadth
+		size += level
+		if size > maxTreeNodes {
+			return maxTreeNodes + 1
+		}
+	}
+	return size
+}
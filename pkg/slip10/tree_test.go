@@ -0,0 +1,74 @@
+package slip10_test
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/bech32/address"
+	"github.com/iotaledger/iota-crypto-demo/pkg/ed25519"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10/eddsa"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10/elliptic"
+)
+
+func TestDumpTree(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+
+	for _, curve := range []slip10.Curve{eddsa.Ed25519(), elliptic.Nist256p1(), elliptic.Secp256k1()} {
+		t.Run(curve.Name(), func(t *testing.T) {
+			tree, err := slip10.DumpTree(seed, curve, 2, 3)
+			require.NoError(t, err)
+
+			count := 0
+			require.NoError(t, tree.Walk(func(node *slip10.TreeNode) error {
+				count++
+				key, err := slip10.DeriveKeyFromPath(seed, curve, node.Path)
+				require.NoError(t, err)
+				assert.EqualValues(t, key.Fingerprint(), node.Fingerprint)
+				assert.EqualValues(t, key.ChainCode, node.ChainCode)
+				assert.EqualValues(t, key.Key.Public().Bytes(), node.Public)
+				return nil
+			}))
+			assert.Equal(t, 1+3+9, count)
+
+			b, err := json.Marshal(tree)
+			require.NoError(t, err)
+			var decoded slip10.TreeNode
+			require.NoError(t, json.Unmarshal(b, &decoded))
+			assert.Equal(t, tree.Children[2].Children[1].Path, decoded.Children[2].Children[1].Path)
+			assert.Equal(t, tree.Children[2].Children[1].Public, decoded.Children[2].Children[1].Public)
+
+			dot := tree.DOT()
+			assert.True(t, strings.HasPrefix(dot, "digraph"))
+			assert.Contains(t, dot, `"m/2'" -> "m/2'/1'"`)
+		})
+	}
+}
+
+func TestDumpTreeAddresses(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	tree, err := slip10.DumpTree(seed, eddsa.Ed25519(), 1, 2)
+	require.NoError(t, err)
+
+	require.NoError(t, tree.SetAddresses(func(key slip10.Key) (string, error) {
+		//nolint:forcetypeassert
+		return address.Bech32(address.IOTAMainnet, address.AddressFromPublicKey(ed25519.PublicKey(key.(eddsa.PublicKey))))
+	}))
+	require.NoError(t, tree.Walk(func(node *slip10.TreeNode) error {
+		assert.True(t, strings.HasPrefix(node.Address, "iota1"))
+		return nil
+	}))
+	assert.Contains(t, tree.DOT(), tree.Children[1].Address)
+}
+
+func TestDumpTreeInvalidSize(t *testing.T) {
+	_, err := slip10.DumpTree(nil, eddsa.Ed25519(), -1, 1)
+	assert.ErrorIs(t, err, slip10.ErrInvalidTreeSize)
+	_, err = slip10.DumpTree(nil, eddsa.Ed25519(), 20, 20)
+	assert.ErrorIs(t, err, slip10.ErrInvalidTreeSize)
+}
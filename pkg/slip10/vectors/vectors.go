@@ -0,0 +1,145 @@
+/*
+Package vectors provides loading and generation of SLIP-0010 test vectors.
+
+The JSON format is compatible with the vectors contained in the official
+SLIP-0010 specification, extended by an optional curve name and address per
+test. This allows other implementations to consume vectors generated by this
+reference code.
+*/
+package vectors
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/iotaledger/iota-crypto-demo/internal/hexutil"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip32path"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10"
+)
+
+// ErrMismatch is returned when a test vector does not match the computed values.
+var ErrMismatch = errors.New("vector mismatch")
+
+// Test represents a single derivation of a test vector.
+type Test struct {
+	Path        bip32path.Path `json:"chain"`
+	Fingerprint hexutil.Bytes  `json:"fingerprint"`
+	ChainCode   hexutil.Bytes  `json:"chainCode"`
+	Private     hexutil.Bytes  `json:"private"`
+	Public      hexutil.Bytes  `json:"public"`
+	Address     string         `json:"address,omitempty"`
+}
+
+// Vector represents a set of derivations from the same seed.
+type Vector struct {
+	Curve string        `json:"curve,omitempty"`
+	Seed  hexutil.Bytes `json:"seed"`
+	Tests []Test        `json:"tests"`
+}
+
+// AddressFunc computes the address string of a public key.
+type AddressFunc func(slip10.Key) (string, error)
+
+// Load reads a JSON encoded list of test vectors from r.
+func Load(r io.Reader) ([]Vector, error) {
+	var vs []Vector
+	if err := json.NewDecoder(r).Decode(&vs); err != nil {
+		return nil, fmt.Errorf("failed to decode vectors: %w", err)
+	}
+	return vs, nil
+}
+
+// LoadFile reads a JSON encoded list of test vectors from the named file.
+func LoadFile(name string) ([]Vector, error) {
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	return Load(bytes.NewReader(b))
+}
+
+// Write writes the list of test vectors as indented JSON to w.
+func Write(w io.Writer, vs []Vector) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(vs)
+}
+
+// WriteFile writes the list of test vectors as indented JSON to the named file.
+func WriteFile(name string, vs []Vector) error {
+	var buf bytes.Buffer
+	if err := Write(&buf, vs); err != nil {
+		return err
+	}
+	return os.WriteFile(name, buf.Bytes(), 0o600)
+}
+
+// Generate derives the keys for each path from seed and returns the resulting test vector.
+// If addr is not nil, it is used to compute the address of each derived public key.
+func Generate(seed []byte, curve slip10.Curve, paths []bip32path.Path, addr AddressFunc) (*Vector, error) {
+	v := &Vector{
+		Curve: curve.Name(),
+		Seed:  append(hexutil.Bytes{}, seed...),
+		Tests: make([]Test, 0, len(paths)),
+	}
+	for _, path := range paths {
+		test, err := generateTest(seed, curve, path, addr)
+		if err != nil {
+			return nil, err
+		}
+		v.Tests = append(v.Tests, *test)
+	}
+	return v, nil
+}
+
+// Verify checks that all tests of the vector match the values computed using curve.
+// If addr is not nil, the addresses of the tests that contain one are also checked.
+func (v *Vector) Verify(curve slip10.Curve, addr AddressFunc) error {
+	if v.Curve != "" && v.Curve != curve.Name() {
+		return fmt.Errorf("%w: curve %s != %s", ErrMismatch, v.Curve, curve.Name())
+	}
+	for _, exp := range v.Tests {
+		act, err := generateTest(v.Seed, curve, exp.Path, addr)
+		if err != nil {
+			return err
+		}
+		switch {
+		case !bytes.Equal(exp.Fingerprint, act.Fingerprint):
+			return fmt.Errorf("%w: fingerprint of %s", ErrMismatch, exp.Path)
+		case !bytes.Equal(exp.ChainCode, act.ChainCode):
+			return fmt.Errorf("%w: chain code of %s", ErrMismatch, exp.Path)
+		case !bytes.Equal(exp.Private, act.Private):
+			return fmt.Errorf("%w: private key of %s", ErrMismatch, exp.Path)
+		case !bytes.Equal(exp.Public, act.Public):
+			return fmt.Errorf("%w: public key of %s", ErrMismatch, exp.Path)
+		case addr != nil && exp.Address != "" && exp.Address != act.Address:
+			return fmt.Errorf("%w: address of %s", ErrMismatch, exp.Path)
+		}
+	}
+	return nil
+}
+
+func generateTest(seed []byte, curve slip10.Curve, path bip32path.Path, addr AddressFunc) (*Test, error) {
+	key, err := slip10.DeriveKeyFromPath(seed, curve, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive %s: %w", path, err)
+	}
+	test := &Test{
+		Path:        path,
+		Fingerprint: key.Fingerprint(),
+		ChainCode:   key.ChainCode,
+		Private:     key.Key.Bytes(),
+		Public:      key.Key.Public().Bytes(),
+	}
+	if addr != nil {
+		test.Address, err = addr(key.Key.Public())
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute address of %s: %w", path, err)
+		}
+	}
+	return test, nil
+}
@@ -0,0 +1,73 @@
+package vectors_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/bech32/address"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip32path"
+	"github.com/iotaledger/iota-crypto-demo/pkg/ed25519"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10/eddsa"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10/elliptic"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10/vectors"
+)
+
+func TestLoadOfficial(t *testing.T) {
+	for name, curve := range map[string]slip10.Curve{
+		"TestEd25519":        eddsa.Ed25519(),
+		"TestNist256p1":      elliptic.Nist256p1(),
+		"TestSecp256k1":      elliptic.Secp256k1(),
+		"TestNist256p1Retry": elliptic.Nist256p1(),
+	} {
+		t.Run(name, func(t *testing.T) {
+			vs, err := vectors.LoadFile(filepath.Join("..", "testdata", name+".json"))
+			require.NoError(t, err)
+			require.NotEmpty(t, vs)
+			for _, v := range vs {
+				assert.NoError(t, v.Verify(curve, nil))
+			}
+		})
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	var paths []bip32path.Path
+	for _, s := range []string{"m", "m/44'/4218'/0'/0'", "m/44'/4218'/0'/0'/1'"} {
+		path, err := bip32path.ParsePath(s)
+		require.NoError(t, err)
+		paths = append(paths, path)
+	}
+	addr := func(key slip10.Key) (string, error) {
+		//nolint:forcetypeassert
+		return address.Bech32(address.IOTAMainnet, address.AddressFromPublicKey(ed25519.PublicKey(key.(eddsa.PublicKey))))
+	}
+
+	v, err := vectors.Generate(seed, eddsa.Ed25519(), paths, addr)
+	require.NoError(t, err)
+	require.Len(t, v.Tests, len(paths))
+	assert.Equal(t, eddsa.Ed25519().Name(), v.Curve)
+	require.NoError(t, v.Verify(eddsa.Ed25519(), addr))
+
+	var buf bytes.Buffer
+	require.NoError(t, vectors.Write(&buf, []vectors.Vector{*v}))
+	vs, err := vectors.Load(&buf)
+	require.NoError(t, err)
+	require.Len(t, vs, 1)
+	assert.Equal(t, *v, vs[0])
+
+	// tests without an address only check the keys
+	vs[0].Tests[1].Address = ""
+	assert.NoError(t, vs[0].Verify(eddsa.Ed25519(), addr))
+
+	// tampering must be detected
+	vs[0].Tests[1].Address = "iota1invalid"
+	assert.ErrorIs(t, vs[0].Verify(eddsa.Ed25519(), addr), vectors.ErrMismatch)
+	assert.ErrorIs(t, vs[0].Verify(elliptic.Secp256k1(), nil), vectors.ErrMismatch)
+}
@@ -0,0 +1,57 @@
+/*
+Package slip21 implements the SLIP-0021 hierarchical derivation of symmetric keys.
+
+Each node of the tree is 64 bytes long and its children are identified by arbitrary byte string labels. The first half
+of a node is the derivation key for its children, while the second half is the symmetric key of the node.
+
+This package is tested against the test vectors provided in the official SLIP-0021 specification.
+*/
+package slip21
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+)
+
+// KeySize is the size, in bytes, of the symmetric key of a node.
+const KeySize = 32
+
+// masterKey is the HMAC key used for the master node generation.
+const masterKey = "Symmetric key seed"
+
+// Node represents a node of the SLIP-21 derivation tree.
+type Node [64]byte
+
+// NewMasterNode returns the master node for the seed.
+func NewMasterNode(seed []byte) *Node {
+	return hmacSHA512([]byte(masterKey), seed)
+}
+
+// DeriveNodeFromPath derives the node for the path of labels from the seed.
+func DeriveNodeFromPath(seed []byte, labels ...string) *Node {
+	n := NewMasterNode(seed)
+	for _, label := range labels {
+		n = n.Child([]byte(label))
+	}
+	return n
+}
+
+// Child derives the child node with the given label.
+func (n *Node) Child(label []byte) *Node {
+	return hmacSHA512(n[:32], append([]byte{0}, label...))
+}
+
+// Key returns the symmetric key of the node.
+func (n *Node) Key() []byte {
+	key := make([]byte, KeySize)
+	copy(key, n[32:])
+	return key
+}
+
+func hmacSHA512(key []byte, data []byte) *Node {
+	h := hmac.New(sha512.New, key)
+	h.Write(data)
+	var n Node
+	h.Sum(n[:0])
+	return &n
+}
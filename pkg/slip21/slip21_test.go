@@ -0,0 +1,42 @@
+//nolint:scopelint
+package slip21_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/iotaledger/iota-crypto-demo/internal/hexutil"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip21"
+)
+
+// test vectors from the SLIP-0021 specification, for the mnemonic "all all all all all all all all all all all all"
+const testSeed = "c76c4ac4f4e4a00d6b274d5c39c700bb4a7ddc04fbc6f78e85ca75007b5b495f74a9043eeb77bdd53aa6fc3a0e31462270316fa04b8c19114c8798706cd02ac8"
+
+var slip21Tests = []*struct {
+	labels []string
+	key    string
+}{
+	{nil, "dbf12b44133eaab506a740f6565cc117228cbf1dd70635cfa8ddfdc9af734756"},
+	{[]string{"SLIP-0021"}, "1d065e3ac1bbe5c7fad32cf2305f7d709dc070d672044a19e610c77cdf33de0d"},
+	{[]string{"SLIP-0021", "Master encryption key"}, "ea163130e35bbafdf5ddee97a17b39cef2be4b4f390180d65b54cf05c6a82fde"},
+	{[]string{"SLIP-0021", "Authentication key"}, "47194e938ab24cc82bfa25f6486ed54bebe79c40ae2a5a32ea6db294d81861a6"},
+}
+
+func TestSLIP21(t *testing.T) {
+	seed := hexutil.MustDecodeString(testSeed)
+	for _, tt := range slip21Tests {
+		t.Run(fmtLabels(tt.labels), func(t *testing.T) {
+			n := slip21.DeriveNodeFromPath(seed, tt.labels...)
+			assert.Equal(t, tt.key, hexutil.Bytes(n.Key()).String())
+		})
+	}
+}
+
+func fmtLabels(labels []string) string {
+	s := "m"
+	for _, l := range labels {
+		s += "/\"" + l + "\""
+	}
+	return s
+}
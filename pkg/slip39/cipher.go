@@ -0,0 +1,56 @@
+package slip39
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+const (
+	// baseIterationCount is the total number of PBKDF2 iterations for an iteration exponent of zero.
+	baseIterationCount = 10000
+	// roundCount is the number of rounds of the Feistel network.
+	roundCount = 4
+)
+
+// salt returns the salt prefix of the encryption for the identifier.
+func salt(identifier uint16, extendable bool) []byte {
+	if extendable {
+		return nil
+	}
+	return binary.BigEndian.AppendUint16([]byte(customizationNonExtendable), identifier)
+}
+
+// roundFunction computes the Feistel round function F of round i for the half r.
+func roundFunction(i int, passphrase []byte, e int, salt, r []byte) []byte {
+	password := append([]byte{byte(i)}, passphrase...)
+	iterations := (baseIterationCount << e) / roundCount
+	return pbkdf2.Key(password, append(append([]byte{}, salt...), r...), iterations, len(r), sha256.New)
+}
+
+// feistel applies the four-round Feistel network to secret using the rounds in the given order.
+func feistel(secret, passphrase []byte, e int, identifier uint16, extendable bool, rounds [roundCount]int) []byte {
+	half := len(secret) / 2
+	l := append([]byte{}, secret[:half]...)
+	r := append([]byte{}, secret[half:]...)
+	s := salt(identifier, extendable)
+	for _, i := range rounds {
+		f := roundFunction(i, passphrase, e, s, r)
+		for j := range l {
+			l[j] ^= f[j]
+		}
+		l, r = r, l
+	}
+	return append(r, l...)
+}
+
+// encrypt encrypts the master secret with the passphrase.
+func encrypt(masterSecret, passphrase []byte, e int, identifier uint16, extendable bool) []byte {
+	return feistel(masterSecret, passphrase, e, identifier, extendable, [roundCount]int{0, 1, 2, 3})
+}
+
+// decrypt decrypts the encrypted master secret with the passphrase.
+func decrypt(encrypted, passphrase []byte, e int, identifier uint16, extendable bool) []byte {
+	return feistel(encrypted, passphrase, e, identifier, extendable, [roundCount]int{3, 2, 1, 0})
+}
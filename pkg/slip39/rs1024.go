@@ -0,0 +1,59 @@
+package slip39
+
+// checksumWords is the number of words of the RS1024 checksum.
+const checksumWords = 3
+
+// customization strings of the checksum
+const (
+	customizationNonExtendable = "shamir"
+	customizationExtendable    = "shamir_extendable"
+)
+
+func customization(extendable bool) string {
+	if extendable {
+		return customizationExtendable
+	}
+	return customizationNonExtendable
+}
+
+var rs1024Gen = [10]uint32{
+	0xE0E040, 0x1C1C080, 0x3838100, 0x7070200, 0xE0E0009,
+	0x1C0C2412, 0x38086C24, 0x3090FC48, 0x21B1F890, 0x3F3F120,
+}
+
+func rs1024Polymod(chk uint32, values []int) uint32 {
+	for _, v := range values {
+		b := chk >> 20
+		chk = (chk&0xFFFFF)<<10 ^ uint32(v)
+		for i := range rs1024Gen {
+			if (b>>i)&1 != 0 {
+				chk ^= rs1024Gen[i]
+			}
+		}
+	}
+	return chk
+}
+
+func customizationPolymod(cs string) uint32 {
+	values := make([]int, len(cs))
+	for i := range cs {
+		values[i] = int(cs[i])
+	}
+	return rs1024Polymod(1, values)
+}
+
+// rs1024CreateChecksum returns the checksum words for data.
+func rs1024CreateChecksum(cs string, data []int) [checksumWords]int {
+	polymod := rs1024Polymod(customizationPolymod(cs), data)
+	polymod = rs1024Polymod(polymod, make([]int, checksumWords)) ^ 1
+	var res [checksumWords]int
+	for i := range res {
+		res[i] = int(polymod>>(wordBits*(checksumWords-1-i))) & (WordCount - 1)
+	}
+	return res
+}
+
+// rs1024VerifyChecksum reports whether data, including its checksum words, has a valid checksum.
+func rs1024VerifyChecksum(cs string, data []int) bool {
+	return rs1024Polymod(customizationPolymod(cs), data) == 1
+}
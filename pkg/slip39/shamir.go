@@ -0,0 +1,123 @@
+package slip39
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"fmt"
+	"io"
+)
+
+const (
+	// digestLength is the length, in bytes, of the digest protecting the shared secret.
+	digestLength = 4
+	// digestIndex is the x-coordinate of the share containing the digest.
+	digestIndex = 254
+	// secretIndex is the x-coordinate of the shared secret.
+	secretIndex = 255
+)
+
+// gfExp and gfLog contain the exponent and logarithm tables of GF(2⁸) with the Rijndael polynomial x⁸+x⁴+x³+x+1
+// and the generator x+1.
+var gfExp, gfLog = func() (exp [255]byte, log [256]byte) {
+	x := 1
+	for i := 0; i < 255; i++ {
+		exp[i] = byte(x)
+		log[x] = byte(i)
+		// multiply by x+1
+		x ^= x << 1
+		if x&0x100 != 0 {
+			x ^= 0x11B
+		}
+	}
+	return exp, log
+}()
+
+// rawShare is a point on the polynomials of all bytes of a secret.
+type rawShare struct {
+	x     byte
+	value []byte
+}
+
+// interpolate returns the value of the polynomials defined by shares at x using Lagrange interpolation.
+func interpolate(shares []rawShare, x byte) []byte {
+	for _, s := range shares {
+		if s.x == x {
+			return append([]byte{}, s.value...)
+		}
+	}
+
+	logProd := 0
+	for _, s := range shares {
+		logProd += int(gfLog[s.x^x])
+	}
+	res := make([]byte, len(shares[0].value))
+	for _, s := range shares {
+		// the logarithm of the Lagrange basis polynomial of s evaluated at x
+		logBasis := logProd - int(gfLog[s.x^x])
+		for _, other := range shares {
+			if other.x != s.x {
+				logBasis -= int(gfLog[s.x^other.x])
+			}
+		}
+		logBasis = (logBasis%255 + 255) % 255
+		for i, v := range s.value {
+			if v != 0 {
+				res[i] ^= gfExp[(int(gfLog[v])+logBasis)%255]
+			}
+		}
+	}
+	return res
+}
+
+// splitSecret splits secret into count shares, of which threshold are required to recover it.
+func splitSecret(rand io.Reader, threshold, count int, secret []byte) ([]rawShare, error) {
+	if threshold == 1 {
+		shares := make([]rawShare, count)
+		for i := range shares {
+			shares[i] = rawShare{x: byte(i), value: append([]byte{}, secret...)}
+		}
+		return shares, nil
+	}
+
+	randomShareCount := threshold - 2
+	shares := make([]rawShare, 0, count)
+	for i := 0; i < randomShareCount; i++ {
+		value := make([]byte, len(secret))
+		if _, err := io.ReadFull(rand, value); err != nil {
+			return nil, err
+		}
+		shares = append(shares, rawShare{x: byte(i), value: value})
+	}
+	randomPart := make([]byte, len(secret)-digestLength)
+	if _, err := io.ReadFull(rand, randomPart); err != nil {
+		return nil, err
+	}
+	digestShare := append(createDigest(randomPart, secret), randomPart...)
+
+	baseShares := append(append([]rawShare{}, shares...),
+		rawShare{x: digestIndex, value: digestShare},
+		rawShare{x: secretIndex, value: secret})
+	for i := randomShareCount; i < count; i++ {
+		shares = append(shares, rawShare{x: byte(i), value: interpolate(baseShares, byte(i))})
+	}
+	return shares, nil
+}
+
+// recoverSecret recovers the secret from threshold shares and verifies its digest.
+func recoverSecret(threshold int, shares []rawShare) ([]byte, error) {
+	if threshold == 1 {
+		return shares[0].value, nil
+	}
+	secret := interpolate(shares, secretIndex)
+	digestShare := interpolate(shares, digestIndex)
+	if !hmac.Equal(digestShare[:digestLength], createDigest(digestShare[digestLength:], secret)) {
+		return nil, fmt.Errorf("%w: digest mismatch", ErrInvalidShares)
+	}
+	return secret, nil
+}
+
+func createDigest(randomData, secret []byte) []byte {
+	h := hmac.New(sha256.New, randomData)
+	h.Write(secret)
+	return h.Sum(nil)[:digestLength]
+}
@@ -0,0 +1,130 @@
+package slip39
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+const (
+	// headerWords is the number of words encoding the share parameters.
+	headerWords = 4
+	// minSecretSize is the minimal size, in bytes, of a master secret.
+	minSecretSize = 16
+	// minMnemonicWords is the minimal number of words of a valid share mnemonic.
+	minMnemonicWords = headerWords + (8*minSecretSize+wordBits-1)/wordBits + checksumWords
+	// maxShareCount is the maximal number of groups and of members per group.
+	maxShareCount = 16
+	// maxIterationExponent is the maximal iteration exponent that can be encoded.
+	maxIterationExponent = 15
+)
+
+// Mnemonic represents a single SLIP-39 share as a sequence of words.
+type Mnemonic []string
+
+// ParseMnemonic parses s as a sequence of space separated words.
+func ParseMnemonic(s string) Mnemonic {
+	return strings.Fields(strings.ToLower(s))
+}
+
+// String returns all the words of the mnemonic as a single string of space separated words.
+func (m Mnemonic) String() string {
+	return strings.Join(m, " ")
+}
+
+// share contains the decoded content of a share mnemonic.
+type share struct {
+	identifier        uint16
+	extendable        bool
+	iterationExponent int
+	groupIndex        int
+	groupThreshold    int
+	groupCount        int
+	memberIndex       int
+	memberThreshold   int
+	value             []byte
+}
+
+// mnemonic encodes the share as a mnemonic.
+func (s *share) mnemonic() Mnemonic {
+	var ext uint64
+	if s.extendable {
+		ext = 1
+	}
+	header := uint64(s.identifier)<<25 | ext<<24 | uint64(s.iterationExponent)<<20 |
+		uint64(s.groupIndex)<<16 | uint64(s.groupThreshold-1)<<12 | uint64(s.groupCount-1)<<8 |
+		uint64(s.memberIndex)<<4 | uint64(s.memberThreshold-1)
+
+	valueWords := (8*len(s.value) + wordBits - 1) / wordBits
+	data := make([]int, headerWords+valueWords, headerWords+valueWords+checksumWords)
+	for i := 0; i < headerWords; i++ {
+		data[i] = int(header>>(wordBits*(headerWords-1-i))) & (WordCount - 1)
+	}
+	// the value is left-padded with zero bits to a multiple of the word size
+	v := new(big.Int).SetBytes(s.value)
+	mask := big.NewInt(WordCount - 1)
+	for i := len(data) - 1; i >= headerWords; i-- {
+		data[i] = int(new(big.Int).And(v, mask).Int64())
+		v.Rsh(v, wordBits)
+	}
+	checksum := rs1024CreateChecksum(customization(s.extendable), data)
+	data = append(data, checksum[:]...)
+
+	m := make(Mnemonic, len(data))
+	for i, idx := range data {
+		m[i] = words[idx]
+	}
+	return m
+}
+
+// parseShare decodes the share mnemonic m.
+func parseShare(m Mnemonic) (*share, error) {
+	if len(m) < minMnemonicWords {
+		return nil, fmt.Errorf("%w: too few words", ErrInvalidMnemonic)
+	}
+	data := make([]int, len(m))
+	for i, w := range m {
+		idx, ok := wordIndex[strings.ToLower(w)]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown word %q", ErrInvalidMnemonic, w)
+		}
+		data[i] = idx
+	}
+
+	var header uint64
+	for _, idx := range data[:headerWords] {
+		header = header<<wordBits | uint64(idx)
+	}
+	s := &share{
+		identifier:        uint16(header >> 25),
+		extendable:        header>>24&1 != 0,
+		iterationExponent: int(header >> 20 & 0xF),
+		groupIndex:        int(header >> 16 & 0xF),
+		groupThreshold:    int(header>>12&0xF) + 1,
+		groupCount:        int(header>>8&0xF) + 1,
+		memberIndex:       int(header >> 4 & 0xF),
+		memberThreshold:   int(header&0xF) + 1,
+	}
+	if !rs1024VerifyChecksum(customization(s.extendable), data) {
+		return nil, ErrInvalidChecksum
+	}
+	if s.groupThreshold > s.groupCount {
+		return nil, fmt.Errorf("%w: group threshold exceeds group count", ErrInvalidMnemonic)
+	}
+
+	valueData := data[headerWords : len(data)-checksumWords]
+	padding := wordBits * len(valueData) % 16
+	if padding > 8 {
+		return nil, fmt.Errorf("%w: invalid length", ErrInvalidMnemonic)
+	}
+	v := new(big.Int)
+	for _, idx := range valueData {
+		v.Lsh(v, wordBits).Or(v, big.NewInt(int64(idx)))
+	}
+	n := (wordBits*len(valueData) - padding) / 8
+	if v.BitLen() > 8*n {
+		return nil, fmt.Errorf("%w: invalid padding", ErrInvalidMnemonic)
+	}
+	s.value = v.FillBytes(make([]byte, n))
+	return s, nil
+}
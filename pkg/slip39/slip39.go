@@ -0,0 +1,204 @@
+/*
+Package slip39 implements the SLIP-0039 specification of splitting a master secret into mnemonic shares using
+Shamir's secret sharing.
+
+The master secret is encrypted with a passphrase and split in two levels: It is first divided into groups, of which
+a threshold is required, and each group secret is then divided into member shares. Every share is encoded as a
+mnemonic of words from the SLIP-39 word list protected by an RS1024 checksum.
+*/
+package slip39
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+var (
+	// ErrInvalidMnemonic is returned when trying to use a malformed mnemonic.
+	ErrInvalidMnemonic = errors.New("invalid mnemonic")
+	// ErrInvalidChecksum is returned when the checksum of a mnemonic does not match.
+	ErrInvalidChecksum = errors.New("invalid checksum")
+	// ErrInvalidSecretSize is returned when trying to split a master secret with an invalid size.
+	ErrInvalidSecretSize = errors.New("invalid secret size")
+	// ErrInvalidPassphrase is returned when the passphrase contains non-printable ASCII characters.
+	ErrInvalidPassphrase = errors.New("invalid passphrase")
+	// ErrInvalidConfig is returned when the group or member thresholds are invalid.
+	ErrInvalidConfig = errors.New("invalid config")
+	// ErrInvalidShares is returned when the shares are inconsistent or insufficient to recover the master secret.
+	ErrInvalidShares = errors.New("invalid shares")
+	// ErrInsufficientShares is returned when consistent shares do not satisfy the thresholds yet.
+	// It wraps ErrInvalidShares.
+	ErrInsufficientShares = fmt.Errorf("%w: insufficient shares", ErrInvalidShares)
+)
+
+// Group describes a group of member shares.
+type Group struct {
+	// Threshold is the number of member shares required to recover the group secret.
+	Threshold int
+	// Count is the total number of member shares of the group.
+	Count int
+}
+
+// Config specifies how a master secret is split.
+type Config struct {
+	// GroupThreshold is the number of groups required to recover the master secret.
+	GroupThreshold int
+	// Groups describes each group of shares.
+	Groups []Group
+	// IterationExponent determines the number of PBKDF2 iterations, 10000·2ᵉ, used for the encryption.
+	IterationExponent int
+	// Extendable marks the shares as extendable, i.e. the encryption does not depend on the random identifier.
+	Extendable bool
+}
+
+// Split encrypts masterSecret with passphrase and splits it into mnemonic shares as specified by cfg.
+// The result contains the member shares of each group in the order of cfg.Groups.
+func Split(rand io.Reader, masterSecret []byte, passphrase string, cfg *Config) ([][]Mnemonic, error) {
+	if len(masterSecret) < minSecretSize || len(masterSecret)%2 != 0 {
+		return nil, ErrInvalidSecretSize
+	}
+	if err := validatePassphrase(passphrase); err != nil {
+		return nil, err
+	}
+	if cfg.GroupThreshold < 1 || cfg.GroupThreshold > len(cfg.Groups) || len(cfg.Groups) > maxShareCount {
+		return nil, fmt.Errorf("%w: invalid group threshold", ErrInvalidConfig)
+	}
+	for _, g := range cfg.Groups {
+		if g.Threshold < 1 || g.Threshold > g.Count || g.Count > maxShareCount {
+			return nil, fmt.Errorf("%w: invalid member threshold", ErrInvalidConfig)
+		}
+		if g.Threshold == 1 && g.Count > 1 {
+			return nil, fmt.Errorf("%w: member threshold 1 requires a single share", ErrInvalidConfig)
+		}
+	}
+	if cfg.IterationExponent < 0 || cfg.IterationExponent > maxIterationExponent {
+		return nil, fmt.Errorf("%w: invalid iteration exponent", ErrInvalidConfig)
+	}
+
+	var id [2]byte
+	if _, err := io.ReadFull(rand, id[:]); err != nil {
+		return nil, err
+	}
+	identifier := binary.BigEndian.Uint16(id[:]) & 0x7FFF
+
+	encrypted := encrypt(masterSecret, []byte(passphrase), cfg.IterationExponent, identifier, cfg.Extendable)
+	groupShares, err := splitSecret(rand, cfg.GroupThreshold, len(cfg.Groups), encrypted)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([][]Mnemonic, len(cfg.Groups))
+	for i, g := range cfg.Groups {
+		memberShares, err := splitSecret(rand, g.Threshold, g.Count, groupShares[i].value)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = make([]Mnemonic, len(memberShares))
+		for j, m := range memberShares {
+			s := &share{
+				identifier:        identifier,
+				extendable:        cfg.Extendable,
+				iterationExponent: cfg.IterationExponent,
+				groupIndex:        int(groupShares[i].x),
+				groupThreshold:    cfg.GroupThreshold,
+				groupCount:        len(cfg.Groups),
+				memberIndex:       int(m.x),
+				memberThreshold:   g.Threshold,
+				value:             m.value,
+			}
+			res[i][j] = s.mnemonic()
+		}
+	}
+	return res, nil
+}
+
+// Combine recovers the master secret from the mnemonic shares and decrypts it with passphrase.
+// The shares must all belong to the same split and satisfy the group and member thresholds.
+func Combine(mnemonics []Mnemonic, passphrase string) ([]byte, error) {
+	if len(mnemonics) == 0 {
+		return nil, fmt.Errorf("%w: no shares", ErrInvalidShares)
+	}
+	if err := validatePassphrase(passphrase); err != nil {
+		return nil, err
+	}
+
+	shares := make([]*share, len(mnemonics))
+	for i, m := range mnemonics {
+		s, err := parseShare(m)
+		if err != nil {
+			return nil, err
+		}
+		shares[i] = s
+	}
+
+	first := shares[0]
+	groups := map[int][]*share{}
+	for _, s := range shares {
+		if s.identifier != first.identifier || s.extendable != first.extendable ||
+			s.iterationExponent != first.iterationExponent || s.groupThreshold != first.groupThreshold ||
+			s.groupCount != first.groupCount || len(s.value) != len(first.value) {
+			return nil, fmt.Errorf("%w: mismatching parameters", ErrInvalidShares)
+		}
+		members := groups[s.groupIndex]
+		if len(members) > 0 && members[0].memberThreshold != s.memberThreshold {
+			return nil, fmt.Errorf("%w: mismatching member thresholds", ErrInvalidShares)
+		}
+		for _, other := range members {
+			if other.memberIndex == s.memberIndex && !bytes.Equal(other.value, s.value) {
+				return nil, fmt.Errorf("%w: conflicting member shares", ErrInvalidShares)
+			}
+		}
+		groups[s.groupIndex] = appendMember(members, s)
+	}
+	if len(groups) < first.groupThreshold {
+		return nil, fmt.Errorf("%w: missing groups", ErrInsufficientShares)
+	}
+
+	groupShares := make([]rawShare, 0, len(groups))
+	for index, members := range groups {
+		if len(members) < members[0].memberThreshold {
+			continue
+		}
+		memberShares := make([]rawShare, members[0].memberThreshold)
+		for i := range memberShares {
+			memberShares[i] = rawShare{x: byte(members[i].memberIndex), value: members[i].value}
+		}
+		value, err := recoverSecret(members[0].memberThreshold, memberShares)
+		if err != nil {
+			return nil, err
+		}
+		groupShares = append(groupShares, rawShare{x: byte(index), value: value})
+	}
+	if len(groupShares) < first.groupThreshold {
+		return nil, fmt.Errorf("%w: missing member shares", ErrInsufficientShares)
+	}
+
+	encrypted, err := recoverSecret(first.groupThreshold, groupShares[:first.groupThreshold])
+	if err != nil {
+		return nil, err
+	}
+	return decrypt(encrypted, []byte(passphrase), first.iterationExponent, first.identifier, first.extendable), nil
+}
+
+// appendMember appends s to members unless a share with the same member index is already present.
+func appendMember(members []*share, s *share) []*share {
+	for _, other := range members {
+		if other.memberIndex == s.memberIndex {
+			return members
+		}
+	}
+	return append(members, s)
+}
+
+// validatePassphrase checks that the passphrase only contains printable ASCII characters.
+func validatePassphrase(passphrase string) error {
+	for i := 0; i < len(passphrase); i++ {
+		if passphrase[i] < 32 || passphrase[i] > 126 {
+			return ErrInvalidPassphrase
+		}
+	}
+	return nil
+}
@@ -0,0 +1,209 @@
+package slip39_test
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/internal/hexutil"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip39"
+)
+
+// TestCombine uses a selection of the test vectors of the official SLIP-0039 specification with the passphrase
+// "TREZOR": single shares of 128 and 256 bits, basic and two-level group sharing, an extendable mnemonic and invalid
+// checksums, padding, identifiers, iteration exponents and insufficient shares.
+// Split is tested by round trips through Combine.
+func TestCombine(t *testing.T) {
+	var tests = []*struct {
+		desc      string
+		mnemonics []string
+		secret    []byte
+		expErr    error
+	}{
+		{
+			"valid mnemonic without sharing (128 bits)",
+			[]string{"duckling enlarge academic academic agency result length solution fridge kidney coal piece deal husband erode duke ajar critical decision keyboard"},
+			hexutil.MustDecodeString("bb54aac4b89dc868ba37d9cc21b2cece"),
+			nil,
+		},
+		{
+			"mnemonic with invalid checksum (128 bits)",
+			[]string{"duckling enlarge academic academic agency result length solution fridge kidney coal piece deal husband erode duke ajar critical decision kidney"},
+			nil,
+			slip39.ErrInvalidChecksum,
+		},
+		{
+			"mnemonic with unknown word",
+			[]string{"duckling enlarge academic academic agency result length solution fridge kidney coal piece deal husband erode duke ajar critical decision keyboards"},
+			nil,
+			slip39.ErrInvalidMnemonic,
+		},
+		{
+			"mnemonic too short",
+			[]string{"duckling enlarge academic academic agency result length solution fridge kidney"},
+			nil,
+			slip39.ErrInvalidMnemonic,
+		},
+		{
+			"mnemonic with invalid padding (128 bits)",
+			[]string{"duckling enlarge academic academic email result length solution fridge kidney coal piece deal husband erode duke ajar music cargo fitness"},
+			nil,
+			slip39.ErrInvalidMnemonic,
+		},
+		{
+			"basic sharing 2-of-3 (128 bits)",
+			[]string{
+				"shadow pistol academic always adequate wildlife fancy gross oasis cylinder mustang wrist rescue view short owner flip making coding armed",
+				"shadow pistol academic acid actress prayer class unknown daughter sweater depict flip twice unkind craft early superior advocate guest smoking",
+			},
+			hexutil.MustDecodeString("b43ceb7e57a0ea8766221624d01b0864"),
+			nil,
+		},
+		{
+			"basic sharing 2-of-3 with a single share (128 bits)",
+			[]string{"shadow pistol academic always adequate wildlife fancy gross oasis cylinder mustang wrist rescue view short owner flip making coding armed"},
+			nil,
+			slip39.ErrInsufficientShares,
+		},
+		{
+			"mnemonics with different identifiers (128 bits)",
+			[]string{
+				"adequate smoking academic acid debut wine petition glen cluster slow rhyme slow simple epidemic rumor junk tracks treat olympic tolerate",
+				"adequate stay academic agency agency formal party ting frequent learn upstairs remember smear leaf damage anatomy ladle market hush corner",
+			},
+			nil,
+			slip39.ErrInvalidShares,
+		},
+		{
+			"mnemonics with different iteration exponents (128 bits)",
+			[]string{
+				"peasant leaves academic acid desert exact olympic math alive axle trial tackle drug deny decent smear dominant desert bucket remind",
+				"peasant leader academic agency cultural blessing percent network envelope medal junk primary human pumps jacket fragment payroll ticket evoke voice",
+			},
+			nil,
+			slip39.ErrInvalidShares,
+		},
+		{
+			"threshold number of groups and members in each group (128 bits)",
+			[]string{
+				"eraser senior beard romp adorn nuclear spill corner cradle style ancient family general leader ambition exchange unusual garlic promise voice",
+				"eraser senior ceramic snake clay various huge numb argue hesitate auction category timber browser greatest hanger petition script leaf pickup",
+				"eraser senior ceramic shaft dynamic become junior wrist silver peasant force math alto coal amazing segment yelp velvet image paces",
+				"eraser senior ceramic round column hawk trust auction smug shame alive greatest sheriff living perfect corner chest sled fumes adequate",
+			},
+			hexutil.MustDecodeString("7c3397a292a5941682d7a4ae2d898d11"),
+			nil,
+		},
+		{
+			"valid mnemonic without sharing (256 bits)",
+			[]string{"theory painting academic academic armed sweater year military elder discuss acne wildlife boring employer fused large satoshi bundle carbon diagnose anatomy hamster leaves tracks paces beyond phantom capital marvel lips brave detect luck"},
+			hexutil.MustDecodeString("989baf9dcaad5b10ca33dfd8cc75e42477025dce88ae83e75a230086a0e00e92"),
+			nil,
+		},
+		{
+			"valid extendable mnemonic without sharing (128 bits)",
+			[]string{"testify swimming academic academic column loyalty smear include exotic bedroom exotic wrist lobe cover grief golden smart junior estimate learn"},
+			hexutil.MustDecodeString("1679b4516e0ee5954351d288a838f45e"),
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			mnemonics := make([]slip39.Mnemonic, len(tt.mnemonics))
+			for i, s := range tt.mnemonics {
+				mnemonics[i] = slip39.ParseMnemonic(s)
+			}
+			secret, err := slip39.Combine(mnemonics, "TREZOR")
+			if tt.expErr != nil {
+				require.ErrorIs(t, err, tt.expErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.secret, secret)
+		})
+	}
+}
+
+func TestSplitCombine(t *testing.T) {
+	secret := make([]byte, 32)
+	_, err := rand.Read(secret)
+	require.NoError(t, err)
+
+	for _, extendable := range []bool{false, true} {
+		cfg := &slip39.Config{
+			GroupThreshold: 2,
+			Groups:         []slip39.Group{{1, 1}, {2, 3}, {3, 5}},
+			Extendable:     extendable,
+		}
+		groups, err := slip39.Split(rand.Reader, secret, "passphrase", cfg)
+		require.NoError(t, err)
+		require.Len(t, groups, len(cfg.Groups))
+		for i, g := range groups {
+			require.Len(t, g, cfg.Groups[i].Count)
+		}
+
+		// any two groups with sufficient members recover the secret
+		recovered, err := slip39.Combine([]slip39.Mnemonic{groups[0][0], groups[1][2], groups[1][0]}, "passphrase")
+		require.NoError(t, err)
+		assert.Equal(t, secret, recovered)
+
+		recovered, err = slip39.Combine(append(append([]slip39.Mnemonic{}, groups[1][1:]...), groups[2][1:4]...), "passphrase")
+		require.NoError(t, err)
+		assert.Equal(t, secret, recovered)
+
+		// a different passphrase yields a different secret
+		recovered, err = slip39.Combine([]slip39.Mnemonic{groups[0][0], groups[1][0], groups[1][1]}, "")
+		require.NoError(t, err)
+		assert.NotEqual(t, secret, recovered)
+
+		// insufficient member shares
+		_, err = slip39.Combine([]slip39.Mnemonic{groups[0][0], groups[1][0], groups[2][0], groups[2][1]}, "passphrase")
+		assert.ErrorIs(t, err, slip39.ErrInvalidShares)
+		assert.ErrorIs(t, err, slip39.ErrInsufficientShares)
+
+		// insufficient groups
+		_, err = slip39.Combine([]slip39.Mnemonic{groups[2][0], groups[2][1], groups[2][2]}, "passphrase")
+		assert.ErrorIs(t, err, slip39.ErrInvalidShares)
+		assert.ErrorIs(t, err, slip39.ErrInsufficientShares)
+	}
+}
+
+func TestSplitMnemonicString(t *testing.T) {
+	secret := make([]byte, 16)
+	groups, err := slip39.Split(rand.Reader, secret, "", &slip39.Config{GroupThreshold: 1, Groups: []slip39.Group{{1, 1}}})
+	require.NoError(t, err)
+	m := groups[0][0]
+	assert.Len(t, m, 20)
+
+	recovered, err := slip39.Combine([]slip39.Mnemonic{slip39.ParseMnemonic(m.String())}, "")
+	require.NoError(t, err)
+	assert.Equal(t, secret, recovered)
+}
+
+func TestSplitInvalid(t *testing.T) {
+	var tests = []*struct {
+		desc       string
+		secret     []byte
+		passphrase string
+		cfg        *slip39.Config
+		expErr     error
+	}{
+		{"secret too short", make([]byte, 14), "", &slip39.Config{GroupThreshold: 1, Groups: []slip39.Group{{1, 1}}}, slip39.ErrInvalidSecretSize},
+		{"odd secret length", make([]byte, 17), "", &slip39.Config{GroupThreshold: 1, Groups: []slip39.Group{{1, 1}}}, slip39.ErrInvalidSecretSize},
+		{"non-printable passphrase", make([]byte, 16), "\n", &slip39.Config{GroupThreshold: 1, Groups: []slip39.Group{{1, 1}}}, slip39.ErrInvalidPassphrase},
+		{"group threshold too large", make([]byte, 16), "", &slip39.Config{GroupThreshold: 2, Groups: []slip39.Group{{1, 1}}}, slip39.ErrInvalidConfig},
+		{"member threshold too large", make([]byte, 16), "", &slip39.Config{GroupThreshold: 1, Groups: []slip39.Group{{3, 2}}}, slip39.ErrInvalidConfig},
+		{"member threshold 1 with multiple shares", make([]byte, 16), "", &slip39.Config{GroupThreshold: 1, Groups: []slip39.Group{{1, 2}}}, slip39.ErrInvalidConfig},
+		{"iteration exponent too large", make([]byte, 16), "", &slip39.Config{GroupThreshold: 1, Groups: []slip39.Group{{1, 1}}, IterationExponent: 16}, slip39.ErrInvalidConfig},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			_, err := slip39.Split(rand.Reader, tt.secret, tt.passphrase, tt.cfg)
+			assert.ErrorIs(t, err, tt.expErr)
+		})
+	}
+}
@@ -0,0 +1,190 @@
+/*
+Package smt implements a sparse Merkle tree with 256-bit keys supporting membership and non-membership proofs.
+
+Every possible key corresponds to a leaf of a Merkle tree of depth 256. Empty subtrees hash to the all-zero digest,
+which allows the root to be computed from the non-empty leaves only. Leaf and node hashes use the domain separation
+prefixes of the merkle package.
+
+Proofs only contain the non-empty siblings along the path of the key together with a bitmap marking their depths,
+so that they stay compact even though each path consists of 256 nodes.
+*/
+package smt
+
+import (
+	"bytes"
+	"crypto"
+	"sort"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/merkle"
+)
+
+const (
+	// KeySize is the size, in bytes, of a key.
+	KeySize = 32
+	// Depth is the depth of the tree, i.e. the number of bits of a key.
+	Depth = 8 * KeySize
+)
+
+// Key is the 256-bit key of a leaf.
+type Key [KeySize]byte
+
+// Entry is a key-value pair of the tree.
+type Entry struct {
+	Key   Key
+	Value []byte
+}
+
+// Proof proves the value of a key, or its absence, in a tree with a given root.
+type Proof struct {
+	// Bitmap marks the depths at which the sibling is not empty, with the most significant bit being the root level.
+	Bitmap [KeySize]byte
+	// Siblings contains the non-empty siblings from the leaf level up to the root level.
+	Siblings [][]byte
+}
+
+// Tree is a sparse Merkle tree.
+type Tree struct {
+	hash   crypto.Hash
+	leaves map[Key][]byte
+	root   []byte
+}
+
+// New creates a new empty tree using the provided hash function.
+func New(h crypto.Hash) *Tree {
+	return &Tree{hash: h, leaves: map[Key][]byte{}}
+}
+
+// Get returns the value of key, or nil if the key is not present.
+func (t *Tree) Get(key Key) []byte {
+	return t.leaves[key]
+}
+
+// Len returns the number of non-empty leaves.
+func (t *Tree) Len() int {
+	return len(t.leaves)
+}
+
+// Update sets the values of all entries as a single batch. An empty value removes the key from the tree.
+func (t *Tree) Update(entries ...Entry) {
+	for _, e := range entries {
+		if len(e.Value) == 0 {
+			delete(t.leaves, e.Key)
+		} else {
+			t.leaves[e.Key] = append([]byte{}, e.Value...)
+		}
+	}
+	t.root = nil
+}
+
+// Root returns the root hash of the tree.
+func (t *Tree) Root() []byte {
+	if t.root == nil {
+		t.root = t.subtree(0, t.sortedKeys())
+	}
+	return t.root
+}
+
+// Prove returns the proof of the value of key. If key is not present, the proof proves its absence.
+func (t *Tree) Prove(key Key) *Proof {
+	keys := t.sortedKeys()
+	proof := &Proof{}
+	var siblings [][]byte
+	for depth := 0; depth < Depth; depth++ {
+		split := sort.Search(len(keys), func(i int) bool { return bit(keys[i], depth) == 1 })
+		var sibling []byte
+		if bit(key, depth) == 0 {
+			sibling, keys = t.subtree(depth+1, keys[split:]), keys[:split]
+		} else {
+			sibling, keys = t.subtree(depth+1, keys[:split]), keys[split:]
+		}
+		if !isEmpty(sibling) {
+			proof.Bitmap[depth/8] |= 0x80 >> (depth % 8)
+			siblings = append(siblings, sibling)
+		}
+	}
+	// reverse the siblings, so that they are ordered from the leaf level up
+	for i := range siblings {
+		proof.Siblings = append(proof.Siblings, siblings[len(siblings)-1-i])
+	}
+	return proof
+}
+
+// VerifyProof checks whether proof proves that key has value in the tree with the given root using hash function h.
+// An empty value verifies the absence of key.
+func VerifyProof(h crypto.Hash, root []byte, key Key, value []byte, proof *Proof) bool {
+	node := make([]byte, h.Size())
+	if len(value) > 0 {
+		node = hashLeaf(h, key, value)
+	}
+	siblings := proof.Siblings
+	for depth := Depth - 1; depth >= 0; depth-- {
+		sibling := make([]byte, h.Size())
+		if proof.Bitmap[depth/8]&(0x80>>(depth%8)) != 0 {
+			if len(siblings) == 0 {
+				return false
+			}
+			sibling, siblings = siblings[0], siblings[1:]
+		}
+		if bit(key, depth) == 0 {
+			node = hashNode(h, node, sibling)
+		} else {
+			node = hashNode(h, sibling, node)
+		}
+	}
+	return len(siblings) == 0 && bytes.Equal(node, root)
+}
+
+// subtree returns the hash of the subtree at the given depth containing exactly the sorted keys.
+func (t *Tree) subtree(depth int, keys []Key) []byte {
+	if len(keys) == 0 {
+		return make([]byte, t.hash.Size())
+	}
+	if depth == Depth {
+		return hashLeaf(t.hash, keys[0], t.leaves[keys[0]])
+	}
+	split := sort.Search(len(keys), func(i int) bool { return bit(keys[i], depth) == 1 })
+	return hashNode(t.hash, t.subtree(depth+1, keys[:split]), t.subtree(depth+1, keys[split:]))
+}
+
+func (t *Tree) sortedKeys() []Key {
+	keys := make([]Key, 0, len(t.leaves))
+	for k := range t.leaves {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return bytes.Compare(keys[i][:], keys[j][:]) < 0 })
+	return keys
+}
+
+// bit returns the bit of key at the given depth, i.e. whether the path continues left (0) or right (1).
+func bit(key Key, depth int) int {
+	return int(key[depth/8]>>(7-depth%8)) & 1
+}
+
+func isEmpty(h []byte) bool {
+	for _, b := range h {
+		if b != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func hashLeaf(h crypto.Hash, key Key, value []byte) []byte {
+	d := h.New()
+	d.Write([]byte{merkle.LeafHashPrefix})
+	d.Write(key[:])
+	d.Write(value)
+	return d.Sum(nil)
+}
+
+// hashNode returns the hash of the inner node with children l and r, where two empty children form an empty node.
+func hashNode(h crypto.Hash, l, r []byte) []byte {
+	if isEmpty(l) && isEmpty(r) {
+		return make([]byte, h.Size())
+	}
+	d := h.New()
+	d.Write([]byte{merkle.NodeHashPrefix})
+	d.Write(l)
+	d.Write(r)
+	return d.Sum(nil)
+}
@@ -0,0 +1,69 @@
+package smt_test
+
+import (
+	"crypto"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/smt"
+	_ "golang.org/x/crypto/blake2b" // BLAKE2b_256 is the default hashing algorithm
+)
+
+func key(s string) smt.Key {
+	return sha256.Sum256([]byte(s))
+}
+
+func TestRoot(t *testing.T) {
+	tree := smt.New(crypto.BLAKE2b_256)
+	assert.Equal(t, make([]byte, 32), tree.Root())
+
+	tree.Update(smt.Entry{Key: key("a"), Value: []byte("1")}, smt.Entry{Key: key("b"), Value: []byte("2")})
+	batched := tree.Root()
+
+	// the root must not depend on the order of the updates
+	other := smt.New(crypto.BLAKE2b_256)
+	other.Update(smt.Entry{Key: key("b"), Value: []byte("2")})
+	other.Update(smt.Entry{Key: key("a"), Value: []byte("1")})
+	assert.Equal(t, batched, other.Root())
+
+	// removing a key restores the previous root
+	other.Update(smt.Entry{Key: key("c"), Value: []byte("3")})
+	assert.NotEqual(t, batched, other.Root())
+	other.Update(smt.Entry{Key: key("c")})
+	assert.Equal(t, batched, other.Root())
+	assert.Equal(t, 2, other.Len())
+}
+
+func TestProof(t *testing.T) {
+	tree := smt.New(crypto.BLAKE2b_256)
+	for _, s := range []string{"a", "b", "c", "d", "e"} {
+		tree.Update(smt.Entry{Key: key(s), Value: []byte(s)})
+	}
+	root := tree.Root()
+
+	for _, s := range []string{"a", "b", "c", "d", "e"} {
+		proof := tree.Prove(key(s))
+		assert.True(t, smt.VerifyProof(crypto.BLAKE2b_256, root, key(s), []byte(s), proof))
+		assert.False(t, smt.VerifyProof(crypto.BLAKE2b_256, root, key(s), []byte("x"), proof))
+		assert.False(t, smt.VerifyProof(crypto.BLAKE2b_256, root, key(s), nil, proof))
+	}
+
+	// non-membership
+	proof := tree.Prove(key("x"))
+	assert.True(t, smt.VerifyProof(crypto.BLAKE2b_256, root, key("x"), nil, proof))
+	assert.False(t, smt.VerifyProof(crypto.BLAKE2b_256, root, key("x"), []byte("x"), proof))
+
+	// tampered proofs
+	proof = tree.Prove(key("a"))
+	proof.Siblings = proof.Siblings[1:]
+	assert.False(t, smt.VerifyProof(crypto.BLAKE2b_256, root, key("a"), []byte("a"), proof))
+}
+
+func TestEmptyProof(t *testing.T) {
+	tree := smt.New(crypto.BLAKE2b_256)
+	proof := tree.Prove(key("a"))
+	assert.Empty(t, proof.Siblings)
+	assert.True(t, smt.VerifyProof(crypto.BLAKE2b_256, tree.Root(), key("a"), nil, proof))
+}
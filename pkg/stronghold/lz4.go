@@ -0,0 +1,94 @@
+package stronghold
+
+import (
+	"errors"
+)
+
+const minMatch = 4
+
+var errCorrupted = errors.New("corrupted LZ4 block")
+
+// compress encodes src as an LZ4 block.
+// For simplicity, the data is stored as a single sequence of literals, which is a valid but uncompressed LZ4 block.
+func compress(src []byte) []byte {
+	dst := make([]byte, 0, len(src)+len(src)/255+2)
+	n := len(src)
+	if n < 15 {
+		dst = append(dst, byte(n<<4))
+	} else {
+		dst = append(dst, 0xF0)
+		dst = appendLength(dst, n-15)
+	}
+	return append(dst, src...)
+}
+
+// decompress decodes the LZ4 block src.
+func decompress(src []byte) ([]byte, error) {
+	dst := []byte{}
+	for i := 0; i < len(src); {
+		token := src[i]
+		i++
+
+		literals := int(token >> 4)
+		if literals == 15 {
+			var err error
+			if literals, i, err = readLength(src, i, literals); err != nil {
+				return nil, err
+			}
+		}
+		if literals > len(src)-i {
+			return nil, errCorrupted
+		}
+		dst = append(dst, src[i:i+literals]...)
+		i += literals
+		// the last sequence only contains literals
+		if i == len(src) {
+			break
+		}
+
+		if i+2 > len(src) {
+			return nil, errCorrupted
+		}
+		offset := int(src[i]) | int(src[i+1])<<8
+		i += 2
+		if offset == 0 || offset > len(dst) {
+			return nil, errCorrupted
+		}
+		length := int(token & 0x0F)
+		if length == 15 {
+			var err error
+			if length, i, err = readLength(src, i, length); err != nil {
+				return nil, err
+			}
+		}
+		// the match may overlap the bytes it produces, so it has to be copied byte by byte
+		start := len(dst) - offset
+		for j := 0; j < length+minMatch; j++ {
+			dst = append(dst, dst[start+j])
+		}
+	}
+	return dst, nil
+}
+
+// readLength reads the additional length bytes starting at src[i] and adds them to n.
+func readLength(src []byte, i int, n int) (int, int, error) {
+	for {
+		if i >= len(src) {
+			return 0, 0, errCorrupted
+		}
+		b := src[i]
+		i++
+		n += int(b)
+		if b != 255 {
+			return n, i, nil
+		}
+	}
+}
+
+// appendLength appends n encoded as additional length bytes.
+func appendLength(dst []byte, n int) []byte {
+	for ; n >= 255; n -= 255 {
+		dst = append(dst, 255)
+	}
+	return append(dst, byte(n))
+}
@@ -0,0 +1,37 @@
+//nolint:scopelint
+package stronghold
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecompress(t *testing.T) {
+	var tests = []*struct {
+		name  string
+		block []byte
+		data  string
+	}{
+		{"empty", []byte{0x00}, ""},
+		{"literals", []byte{0x50, 'h', 'e', 'l', 'l', 'o'}, "hello"},
+		{"match", []byte{0x32, 'a', 'b', 'c', 0x03, 0x00, 0x10, 'x'}, "abcabcabcx"},
+		{"long match", []byte{0x1F, 'a', 0x01, 0x00, 0x0A, 0x00}, strings.Repeat("a", 1+15+10+minMatch)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := decompress(tt.block)
+			require.NoError(t, err)
+			assert.Equal(t, tt.data, string(data))
+		})
+	}
+}
+
+func TestDecompressCorrupted(t *testing.T) {
+	for _, block := range [][]byte{{0x50, 'a'}, {0x10, 'a', 0x02, 0x00}, {0x10, 'a', 0x00}, {0xF0}} {
+		_, err := decompress(block)
+		assert.ErrorIs(t, err, errCorrupted)
+	}
+}
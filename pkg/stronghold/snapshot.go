@@ -0,0 +1,143 @@
+/*
+Package stronghold implements reading and writing the encrypted container of version 3 Stronghold snapshots as used
+by IOTA wallets.
+
+A snapshot file consists of the magic bytes "PARTI", the version and the LZ4 compressed state encrypted with
+XChaCha20-Poly1305. The encryption key is obtained by an ephemeral X25519 key exchange with the public key of the
+snapshot key, which the wallets derive from the password using KeyFromPassword.
+
+The package is limited to this container: Read returns the decrypted state in its serialized form and Write expects
+it in that form. Decoding the client vaults of the state and importing their seed or mnemonic records is not
+supported, so migrating keys between wallets requires a decoder of the state on top of this package.
+*/
+package stronghold
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/curve25519"
+)
+
+// KeySize is the size, in bytes, of a snapshot key.
+const KeySize = 32
+
+var (
+	magic     = []byte("PARTI")
+	versionV3 = []byte{0x03, 0x00}
+)
+
+var (
+	// ErrInvalidSnapshot is returned when the data is not a valid snapshot file.
+	ErrInvalidSnapshot = errors.New("invalid snapshot")
+	// ErrUnsupportedVersion is returned when a snapshot has a version other than 3.
+	ErrUnsupportedVersion = errors.New("unsupported version")
+	// ErrDecryptionFailed is returned when the snapshot cannot be decrypted with the given key.
+	ErrDecryptionFailed = errors.New("decryption failed")
+)
+
+// KeyFromPassword returns the snapshot key corresponding to password, i.e. its BLAKE2b-256 hash.
+func KeyFromPassword(password string) []byte {
+	h := blake2b.Sum256([]byte(password))
+	return h[:]
+}
+
+// Read reads a snapshot from r, decrypts it with key and returns the decompressed serialized state.
+func Read(r io.Reader, key []byte) ([]byte, error) {
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("%w: invalid key length %d", ErrDecryptionFailed, len(key))
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.HasPrefix(data, magic) {
+		return nil, fmt.Errorf("%w: invalid magic", ErrInvalidSnapshot)
+	}
+	data = data[len(magic):]
+	if len(data) < len(versionV3) {
+		return nil, fmt.Errorf("%w: missing version", ErrInvalidSnapshot)
+	}
+	if !bytes.Equal(data[:len(versionV3)], versionV3) {
+		return nil, fmt.Errorf("%w: %x", ErrUnsupportedVersion, data[:len(versionV3)])
+	}
+	data = data[len(versionV3):]
+	if len(data) < curve25519.PointSize+chacha20poly1305.Overhead {
+		return nil, fmt.Errorf("%w: too short", ErrInvalidSnapshot)
+	}
+
+	ephemeralPub := data[:curve25519.PointSize]
+	tag := data[curve25519.PointSize : curve25519.PointSize+chacha20poly1305.Overhead]
+	ciphertext := data[curve25519.PointSize+chacha20poly1305.Overhead:]
+
+	pub, err := curve25519.X25519(key, curve25519.Basepoint)
+	if err != nil {
+		return nil, err
+	}
+	shared, err := curve25519.X25519(key, ephemeralPub)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSnapshot, err)
+	}
+	aead, nonce := newCipher(shared, ephemeralPub, pub)
+	// the tag precedes the ciphertext in the snapshot file
+	compressed, err := aead.Open(nil, nonce, append(append([]byte{}, ciphertext...), tag...), nil)
+	if err != nil {
+		return nil, ErrDecryptionFailed
+	}
+	state, err := decompress(compressed)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSnapshot, err)
+	}
+	return state, nil
+}
+
+// Write compresses the serialized state, encrypts it with key and writes the resulting snapshot to w.
+// The ephemeral X25519 key is read from rand.
+func Write(w io.Writer, rand io.Reader, key []byte, state []byte) error {
+	if len(key) != KeySize {
+		return fmt.Errorf("invalid key length %d", len(key))
+	}
+	ephemeral := make([]byte, curve25519.ScalarSize)
+	if _, err := io.ReadFull(rand, ephemeral); err != nil {
+		return err
+	}
+	ephemeralPub, err := curve25519.X25519(ephemeral, curve25519.Basepoint)
+	if err != nil {
+		return err
+	}
+	pub, err := curve25519.X25519(key, curve25519.Basepoint)
+	if err != nil {
+		return err
+	}
+	shared, err := curve25519.X25519(ephemeral, pub)
+	if err != nil {
+		return err
+	}
+	aead, nonce := newCipher(shared, ephemeralPub, pub)
+	sealed := aead.Seal(nil, nonce, compress(state), nil)
+	ciphertext, tag := sealed[:len(sealed)-aead.Overhead()], sealed[len(sealed)-aead.Overhead():]
+
+	var buf bytes.Buffer
+	buf.Write(magic)
+	buf.Write(versionV3)
+	buf.Write(ephemeralPub)
+	buf.Write(tag)
+	buf.Write(ciphertext)
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+// newCipher returns the XChaCha20-Poly1305 cipher keyed with the shared X25519 secret as well as the nonce, which is
+// derived from the ephemeral and the snapshot public key.
+func newCipher(shared, ephemeralPub, pub []byte) (cipher.AEAD, []byte) {
+	aead, _ := chacha20poly1305.NewX(shared)
+	h, _ := blake2b.New256(nil)
+	h.Write(ephemeralPub)
+	h.Write(pub)
+	return aead, h.Sum(nil)[:chacha20poly1305.NonceSizeX]
+}
@@ -0,0 +1,53 @@
+package stronghold_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/internal/rand"
+	"github.com/iotaledger/iota-crypto-demo/pkg/stronghold"
+)
+
+func TestReadWrite(t *testing.T) {
+	key := stronghold.KeyFromPassword("password")
+	for _, size := range []int{0, 1, 14, 15, 16, 300, 1 << 16} {
+		state := make([]byte, size)
+		_, _ = rand.Reader.Read(state)
+
+		var buf bytes.Buffer
+		require.NoError(t, stronghold.Write(&buf, rand.Reader, key, state))
+		assert.True(t, bytes.HasPrefix(buf.Bytes(), []byte("PARTI\x03\x00")))
+
+		res, err := stronghold.Read(bytes.NewReader(buf.Bytes()), key)
+		require.NoError(t, err)
+		assert.Equal(t, state, res)
+	}
+}
+
+func TestReadInvalid(t *testing.T) {
+	key := stronghold.KeyFromPassword("password")
+	var buf bytes.Buffer
+	require.NoError(t, stronghold.Write(&buf, rand.Reader, key, []byte("state")))
+	snapshot := buf.Bytes()
+
+	_, err := stronghold.Read(bytes.NewReader(snapshot), stronghold.KeyFromPassword("wrong"))
+	assert.ErrorIs(t, err, stronghold.ErrDecryptionFailed)
+
+	_, err = stronghold.Read(bytes.NewReader(snapshot[1:]), key)
+	assert.ErrorIs(t, err, stronghold.ErrInvalidSnapshot)
+
+	v2 := append([]byte("PARTI\x02\x00"), snapshot[7:]...)
+	_, err = stronghold.Read(bytes.NewReader(v2), key)
+	assert.ErrorIs(t, err, stronghold.ErrUnsupportedVersion)
+
+	_, err = stronghold.Read(bytes.NewReader(snapshot[:20]), key)
+	assert.ErrorIs(t, err, stronghold.ErrInvalidSnapshot)
+
+	tampered := bytes.Clone(snapshot)
+	tampered[len(tampered)-1] ^= 1
+	_, err = stronghold.Read(bytes.NewReader(tampered), key)
+	assert.ErrorIs(t, err, stronghold.ErrDecryptionFailed)
+}
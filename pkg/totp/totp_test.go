@@ -0,0 +1,78 @@
+//nolint:scopelint
+package totp_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/internal/hexutil"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip21"
+	"github.com/iotaledger/iota-crypto-demo/pkg/totp"
+)
+
+const rfcSecret = "12345678901234567890"
+
+// test vectors from RFC 4226, Appendix D
+var hotpTests = []string{"755224", "287082", "359152", "969429", "338314", "254676", "287922", "162583", "399871", "520489"}
+
+func TestHOTP(t *testing.T) {
+	for counter, expected := range hotpTests {
+		assert.Equal(t, expected, totp.HOTP([]byte(rfcSecret), uint64(counter), 6))
+	}
+}
+
+// SHA-1 test vectors from RFC 6238, Appendix B
+var totpTests = []*struct {
+	unix int64
+	code string
+}{
+	{59, "94287082"},
+	{1111111109, "07081804"},
+	{1111111111, "14050471"},
+	{1234567890, "89005924"},
+	{2000000000, "69279037"},
+	{20000000000, "65353130"},
+}
+
+func TestCode(t *testing.T) {
+	key := &totp.Key{Secret: []byte(rfcSecret), Digits: 8, Period: totp.DefaultPeriod}
+	for _, tt := range totpTests {
+		t.Run(tt.code, func(t *testing.T) {
+			assert.Equal(t, tt.code, key.Code(time.Unix(tt.unix, 0)))
+		})
+	}
+}
+
+func TestDeriveKey(t *testing.T) {
+	seed := hexutil.MustDecodeString("000102030405060708090a0b0c0d0e0f")
+	key, err := totp.DeriveKey(seed, "Example", "alice@example.com")
+	require.NoError(t, err)
+
+	node := slip21.DeriveNodeFromPath(seed, totp.Label, "Example", "alice@example.com")
+	assert.Equal(t, node.Key()[:totp.SecretSize], key.Secret)
+	assert.Len(t, key.Base32Secret(), 32)
+
+	other, err := totp.DeriveKey(seed, "Example", "bob@example.com")
+	require.NoError(t, err)
+	assert.NotEqual(t, key.Secret, other.Secret)
+
+	_, err = totp.DeriveKey(seed, "Exa:mple", "alice")
+	assert.ErrorIs(t, err, totp.ErrInvalidLabel)
+	_, err = totp.DeriveKey(seed, "Example", "")
+	assert.ErrorIs(t, err, totp.ErrInvalidLabel)
+}
+
+func TestURI(t *testing.T) {
+	key := &totp.Key{
+		Issuer:  "ACME Co",
+		Account: "john.doe@email.com",
+		Secret:  []byte(rfcSecret),
+		Digits:  totp.DefaultDigits,
+		Period:  totp.DefaultPeriod,
+	}
+	assert.Equal(t, "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ", key.Base32Secret())
+	assert.Equal(t, "otpauth://totp/ACME%20Co:john.doe@email.com?algorithm=SHA1&digits=6&issuer=ACME%20Co&period=30&secret=GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ", key.URI())
+}
@@ -0,0 +1,226 @@
+/*
+Package trinary provides the ternary conversions of legacy IOTA data structures.
+
+A trit is a balanced ternary digit -1, 0 or 1 and three trits form a tryte, which is represented by a character of the
+alphabet "9ABCDEFGHIJKLMNOPQRSTUVWXYZ". All conversions use the little-endian trit order of the legacy protocol.
+Binary data is converted with the b1t6 encoding, mapping every byte to six trits, i.e. two trytes.
+*/
+package trinary
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// TryteAlphabet are the characters representing the tryte values 0, 1, …, 13, -13, …, -1.
+	TryteAlphabet = "9ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	// TritsPerTryte is the number of trits in one tryte.
+	TritsPerTryte = 3
+	// MinTryteValue is the smallest value of a tryte.
+	MinTryteValue = -13
+	// MaxTryteValue is the largest value of a tryte.
+	MaxTryteValue = 13
+	// TrytesPerByte is the number of trytes of the b1t6 encoding of a byte.
+	TrytesPerByte = 2
+)
+
+var (
+	// ErrInvalidTrit is returned when a trit is not -1, 0 or 1.
+	ErrInvalidTrit = errors.New("invalid trit")
+	// ErrInvalidTryte is returned when a character is not part of the tryte alphabet.
+	ErrInvalidTryte = errors.New("invalid tryte")
+	// ErrInvalidLength is returned when the length of the input does not match the conversion.
+	ErrInvalidLength = errors.New("invalid length")
+)
+
+// Trits is a slice of balanced ternary digits.
+type Trits []int8
+
+// ValidTrits checks whether all trits are valid.
+func ValidTrits(trits Trits) error {
+	for i, t := range trits {
+		if t < -1 || t > 1 {
+			return fmt.Errorf("%w: %d at index %d", ErrInvalidTrit, t, i)
+		}
+	}
+	return nil
+}
+
+// ValidTrytes checks whether trytes only contains characters of the tryte alphabet.
+func ValidTrytes(trytes string) error {
+	for i := 0; i < len(trytes); i++ {
+		if strings.IndexByte(TryteAlphabet, trytes[i]) < 0 {
+			return fmt.Errorf("%w: %q at index %d", ErrInvalidTryte, trytes[i], i)
+		}
+	}
+	return nil
+}
+
+// TritsToTrytes converts trits to trytes. The number of trits must be a multiple of TritsPerTryte.
+func TritsToTrytes(trits Trits) (string, error) {
+	if len(trits)%TritsPerTryte != 0 {
+		return "", fmt.Errorf("%w: %d trits", ErrInvalidLength, len(trits))
+	}
+	if err := ValidTrits(trits); err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	b.Grow(len(trits) / TritsPerTryte)
+	for i := 0; i < len(trits); i += TritsPerTryte {
+		b.WriteByte(tryteChar(int(trits[i]) + 3*int(trits[i+1]) + 9*int(trits[i+2])))
+	}
+	return b.String(), nil
+}
+
+// MustTritsToTrytes converts trits to trytes. It panics if the trits cannot be converted.
+func MustTritsToTrytes(trits Trits) string {
+	trytes, err := TritsToTrytes(trits)
+	if err != nil {
+		panic(err)
+	}
+	return trytes
+}
+
+// TrytesToTrits converts trytes to trits.
+func TrytesToTrits(trytes string) (Trits, error) {
+	if err := ValidTrytes(trytes); err != nil {
+		return nil, err
+	}
+	trits := make(Trits, 0, len(trytes)*TritsPerTryte)
+	for i := 0; i < len(trytes); i++ {
+		trits = appendTryteTrits(trits, tryteValue(trytes[i]))
+	}
+	return trits, nil
+}
+
+// MustTrytesToTrits converts trytes to trits. It panics if the trytes are invalid.
+func MustTrytesToTrits(trytes string) Trits {
+	trits, err := TrytesToTrits(trytes)
+	if err != nil {
+		panic(err)
+	}
+	return trits
+}
+
+// IntToTrits returns the balanced ternary representation of v using the minimal number of trits.
+func IntToTrits(v int64) Trits {
+	var trits Trits
+	for v != 0 {
+		t := v % 3
+		v /= 3
+		// map the remainder from {-2, …, 2} to a balanced trit
+		switch {
+		case t > 1:
+			t -= 3
+			v++
+		case t < -1:
+			t += 3
+			v--
+		}
+		trits = append(trits, int8(t))
+	}
+	return trits
+}
+
+// TritsToInt returns the integer value of the balanced ternary trits.
+// The result is only meaningful, if it does not overflow an int64.
+func TritsToInt(trits Trits) int64 {
+	var v int64
+	for i := len(trits) - 1; i >= 0; i-- {
+		v = 3*v + int64(trits[i])
+	}
+	return v
+}
+
+// AddTrits adds the balanced ternary numbers a and b.
+// The result has the length of the longer input, i.e. an overflow of the most significant trit is discarded.
+func AddTrits(a, b Trits) Trits {
+	n := max(len(a), len(b))
+	sum := make(Trits, n)
+	carry := 0
+	for i := 0; i < n; i++ {
+		v := carry
+		if i < len(a) {
+			v += int(a[i])
+		}
+		if i < len(b) {
+			v += int(b[i])
+		}
+		t := balancedMod(v, 3)
+		sum[i] = int8(t)
+		carry = (v - t) / 3
+	}
+	return sum
+}
+
+// BytesToTrytes encodes src in trytes using the b1t6 encoding.
+// Each byte is interpreted as a signed integer v and represented by the two trytes t₀ and t₁ with v = t₀ + 27·t₁.
+func BytesToTrytes(src []byte) string {
+	var b strings.Builder
+	b.Grow(len(src) * TrytesPerByte)
+	for _, x := range src {
+		v := int(int8(x))
+		t0 := balancedMod(v, 27)
+		b.WriteByte(tryteChar(t0))
+		b.WriteByte(tryteChar((v - t0) / 27))
+	}
+	return b.String()
+}
+
+// TrytesToBytes decodes the b1t6 encoded trytes.
+// It returns an error if the trytes are not the canonical encoding of a byte sequence.
+func TrytesToBytes(trytes string) ([]byte, error) {
+	if len(trytes)%TrytesPerByte != 0 {
+		return nil, fmt.Errorf("%w: %d trytes", ErrInvalidLength, len(trytes))
+	}
+	if err := ValidTrytes(trytes); err != nil {
+		return nil, err
+	}
+	dst := make([]byte, len(trytes)/TrytesPerByte)
+	for i := range dst {
+		v := tryteValue(trytes[2*i]) + 27*tryteValue(trytes[2*i+1])
+		if v < -128 || v > 127 {
+			return nil, fmt.Errorf("%w: %q at index %d does not encode a byte", ErrInvalidTryte, trytes[2*i:2*i+2], 2*i)
+		}
+		dst[i] = byte(int8(v))
+	}
+	return dst, nil
+}
+
+// tryteChar returns the character of the tryte value v.
+func tryteChar(v int) byte {
+	if v < 0 {
+		v += len(TryteAlphabet)
+	}
+	return TryteAlphabet[v]
+}
+
+// tryteValue returns the value of the valid tryte character c.
+func tryteValue(c byte) int {
+	v := strings.IndexByte(TryteAlphabet, c)
+	if v > MaxTryteValue {
+		v -= len(TryteAlphabet)
+	}
+	return v
+}
+
+// appendTryteTrits appends the three trits of the tryte value v.
+func appendTryteTrits(trits Trits, v int) Trits {
+	for i := 0; i < TritsPerTryte; i++ {
+		t := balancedMod(v, 3)
+		trits = append(trits, int8(t))
+		v = (v - t) / 3
+	}
+	return trits
+}
+
+// balancedMod returns v mod m in the range [-(m-1)/2, (m-1)/2] for odd m.
+func balancedMod(v, m int) int {
+	r := ((v % m) + m) % m
+	if r > m/2 {
+		r -= m
+	}
+	return r
+}
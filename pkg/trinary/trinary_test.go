@@ -0,0 +1,101 @@
+//nolint:scopelint
+package trinary_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/internal/hexutil"
+	"github.com/iotaledger/iota-crypto-demo/pkg/trinary"
+)
+
+var tryteTests = []*struct {
+	trytes string
+	trits  trinary.Trits
+}{
+	{"", trinary.Trits{}},
+	{"9", trinary.Trits{0, 0, 0}},
+	{"A", trinary.Trits{1, 0, 0}},
+	{"M", trinary.Trits{1, 1, 1}},
+	{"N", trinary.Trits{-1, -1, -1}},
+	{"Z", trinary.Trits{-1, 0, 0}},
+	{"IOTA", trinary.Trits{0, 0, 1, 0, -1, -1, -1, 1, -1, 1, 0, 0}},
+}
+
+func TestTrytes(t *testing.T) {
+	for _, tt := range tryteTests {
+		t.Run(tt.trytes, func(t *testing.T) {
+			trits, err := trinary.TrytesToTrits(tt.trytes)
+			require.NoError(t, err)
+			assert.Equal(t, tt.trits, trits)
+
+			trytes, err := trinary.TritsToTrytes(tt.trits)
+			require.NoError(t, err)
+			assert.Equal(t, tt.trytes, trytes)
+		})
+	}
+}
+
+func TestInvalid(t *testing.T) {
+	_, err := trinary.TrytesToTrits("IOTa")
+	assert.ErrorIs(t, err, trinary.ErrInvalidTryte)
+	_, err = trinary.TritsToTrytes(trinary.Trits{0, 1})
+	assert.ErrorIs(t, err, trinary.ErrInvalidLength)
+	_, err = trinary.TritsToTrytes(trinary.Trits{0, 2, 0})
+	assert.ErrorIs(t, err, trinary.ErrInvalidTrit)
+}
+
+func TestIntToTrits(t *testing.T) {
+	assert.Empty(t, trinary.IntToTrits(0))
+	assert.Equal(t, trinary.Trits{1}, trinary.IntToTrits(1))
+	assert.Equal(t, trinary.Trits{-1, 1}, trinary.IntToTrits(2))
+	assert.Equal(t, trinary.Trits{1, -1}, trinary.IntToTrits(-2))
+
+	for _, v := range []int64{-1000, -123, -5, 5, 123, 1000, math.MaxInt64, math.MinInt64 + 1} {
+		assert.Equal(t, v, trinary.TritsToInt(trinary.IntToTrits(v)), "%d", v)
+	}
+}
+
+func TestAddTrits(t *testing.T) {
+	for _, tt := range [][2]int64{{0, 0}, {1, 1}, {13, 1}, {-40, 27}, {1000, -1234}} {
+		a, b := trinary.IntToTrits(tt[0]), trinary.IntToTrits(tt[1])
+		a = append(a, make(trinary.Trits, 8)...) // room for the carry
+		assert.Equal(t, tt[0]+tt[1], trinary.TritsToInt(trinary.AddTrits(a, b)), "%d+%d", tt[0], tt[1])
+	}
+	// overflow of the most significant trit is discarded
+	assert.Equal(t, trinary.Trits{-1, -1}, trinary.AddTrits(trinary.Trits{1, 1}, trinary.Trits{1}))
+}
+
+var b1t6Tests = []*struct {
+	bytes  string
+	trytes string
+}{
+	{"", ""},
+	{"00", "99"},
+	{"01", "A9"},
+	{"7f", "SE"},
+	{"80", "GV"},
+	{"ff", "Z9"},
+	{"00017f80ff", "99A9SEGVZ9"},
+}
+
+func TestB1T6(t *testing.T) {
+	for _, tt := range b1t6Tests {
+		t.Run(tt.trytes, func(t *testing.T) {
+			src := hexutil.MustDecodeString(tt.bytes)
+			assert.Equal(t, tt.trytes, trinary.BytesToTrytes(src))
+
+			dst, err := trinary.TrytesToBytes(tt.trytes)
+			require.NoError(t, err)
+			assert.Equal(t, src, dst)
+		})
+	}
+
+	_, err := trinary.TrytesToBytes("MM")
+	assert.ErrorIs(t, err, trinary.ErrInvalidTryte)
+	_, err = trinary.TrytesToBytes("999")
+	assert.ErrorIs(t, err, trinary.ErrInvalidLength)
+}
@@ -0,0 +1,156 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10"
+)
+
+// Default gap limits of the discovery as recommended by BIP-44.
+const (
+	// DefaultAddressGap is the default number of consecutive unused addresses after which the scan of a chain stops.
+	DefaultAddressGap uint32 = 20
+	// DefaultAccountGap is the default number of consecutive unused accounts after which the discovery stops.
+	DefaultAccountGap uint32 = 1
+)
+
+// UsedChecker reports whether addr has been used, e.g. by querying a node for outputs ever owned by the address.
+// It is typically implemented as an adapter of a node API client.
+type UsedChecker func(ctx context.Context, addr *Address) (bool, error)
+
+// DiscoveryOptions specifies the gap limits of the discovery.
+type DiscoveryOptions struct {
+	// AddressGap is the number of consecutive unused addresses after which the scan of a chain stops.
+	// If zero, DefaultAddressGap is used.
+	AddressGap uint32
+	// AccountGap is the number of consecutive accounts without any used addresses after which the discovery stops.
+	// If zero, DefaultAccountGap is used.
+	AccountGap uint32
+}
+
+// DiscoveredAccount is an account with at least one used address found by Discover.
+type DiscoveredAccount struct {
+	Index uint32
+	// Used contains the used addresses of the External chain followed by those of the Internal chain, each ordered by
+	// their index.
+	Used []*Address
+	// NextExternal is the index following the last used address on the External chain.
+	NextExternal uint32
+	// NextInternal is the index following the last used address on the Internal chain.
+	NextInternal uint32
+}
+
+// Discover implements the BIP-44 account discovery: Starting with account 0, the External and Internal chain of each
+// account are scanned until AddressGap consecutive addresses are unused. Accounts are scanned until AccountGap
+// consecutive accounts do not contain any used address. Discover only returns the discovered structure, it does not
+// change the state of the wallet. Use Restore to apply it.
+func (w *Wallet) Discover(ctx context.Context, used UsedChecker, opts *DiscoveryOptions) ([]DiscoveredAccount, error) {
+	addressGap, accountGap := DefaultAddressGap, DefaultAccountGap
+	if opts != nil && opts.AddressGap > 0 {
+		addressGap = opts.AddressGap
+	}
+	if opts != nil && opts.AccountGap > 0 {
+		accountGap = opts.AccountGap
+	}
+
+	var res []DiscoveredAccount
+	var gap uint32
+	for account := uint32(0); account < slip10.Hardened && gap < accountGap; account++ {
+		d := DiscoveredAccount{Index: account}
+		for _, chain := range []Chain{External, Internal} {
+			addrs, err := w.scanChain(ctx, used, account, chain, addressGap)
+			if err != nil {
+				return nil, err
+			}
+			if len(addrs) > 0 {
+				*d.next(chain) = addrs[len(addrs)-1].Index + 1
+			}
+			d.Used = append(d.Used, addrs...)
+		}
+		if len(d.Used) == 0 {
+			gap++
+			continue
+		}
+		gap = 0
+		res = append(res, d)
+	}
+	return res, nil
+}
+
+// Restore creates the discovered accounts, which are not yet present in the wallet, and marks their addresses as used.
+func (w *Wallet) Restore(accounts []DiscoveredAccount) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for i := range accounts {
+		d := &accounts[i]
+		if err := checkIndices(d.Index, External, 0); err != nil {
+			return err
+		}
+		updated := AccountState{Index: d.Index}
+		s, ok := w.accounts[d.Index]
+		if ok {
+			updated = *s
+		}
+		for _, chain := range []Chain{External, Internal} {
+			next := *d.next(chain)
+			if next > slip10.Hardened {
+				return fmt.Errorf("%w: address index %d", ErrIndexOutOfRange, next)
+			}
+			if next > *updated.next(chain) {
+				*updated.next(chain) = next
+			}
+		}
+		if ok && updated == *s {
+			continue
+		}
+		if err := w.store.Save(updated); err != nil {
+			return fmt.Errorf("failed to save account: %w", err)
+		}
+		w.accounts[d.Index] = &updated
+	}
+	return nil
+}
+
+// scanChain returns the used addresses of chain of account until gap consecutive addresses are unused.
+func (w *Wallet) scanChain(
+	ctx context.Context, used UsedChecker, account uint32, chain Chain, gap uint32,
+) ([]*Address, error) {
+	var res []*Address
+	var unused uint32
+	for index := uint32(0); index < slip10.Hardened && unused < gap; index++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		w.mu.Lock()
+		addr, err := w.address(account, chain, index)
+		w.mu.Unlock()
+		if errors.Is(err, ErrNotExported) {
+			// a watch-only wallet can only scan the exported addresses
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		ok, err := used(ctx, addr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check address %s: %w", addr.Bech32, err)
+		}
+		if !ok {
+			unused++
+			continue
+		}
+		unused = 0
+		res = append(res, addr)
+	}
+	return res, nil
+}
+
+func (d *DiscoveredAccount) next(chain Chain) *uint32 {
+	if chain == Internal {
+		return &d.NextInternal
+	}
+	return &d.NextExternal
+}
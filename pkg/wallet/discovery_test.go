@@ -0,0 +1,96 @@
+//nolint:scopelint // from tests
+package wallet_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/wallet"
+)
+
+func TestDiscover(t *testing.T) {
+	// addresses derived from the seed, which have been used according to the node
+	var usedPaths = []string{
+		"m/44'/4218'/0'/0'/0'",
+		"m/44'/4218'/0'/0'/3'",
+		"m/44'/4218'/0'/1'/0'",
+		"m/44'/4218'/1'/1'/1'",
+		// account 3 is not discovered, as account 2 is unused
+		"m/44'/4218'/3'/0'/0'",
+	}
+	w, err := wallet.New(seed, wallet.NewMemoryStore(), nil)
+	require.NoError(t, err)
+
+	var checked int
+	used := func(_ context.Context, addr *wallet.Address) (bool, error) {
+		checked++
+		for _, p := range usedPaths {
+			if p == addr.Path.String() {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+	accounts, err := w.Discover(context.Background(), used, &wallet.DiscoveryOptions{AddressGap: 5})
+	require.NoError(t, err)
+	require.Len(t, accounts, 2)
+
+	var paths []string
+	for _, a := range accounts {
+		for _, addr := range a.Used {
+			paths = append(paths, addr.Path.String())
+		}
+	}
+	assert.Equal(t, usedPaths[:4], paths)
+	assert.Equal(t, wallet.DiscoveredAccount{Index: 0, NextExternal: 4, NextInternal: 1}, withoutUsed(accounts[0]))
+	assert.Equal(t, wallet.DiscoveredAccount{Index: 1, NextExternal: 0, NextInternal: 2}, withoutUsed(accounts[1]))
+	// account 0: 4+5 external, 1+5 internal; account 1: 5 external, 2+5 internal; account 2: 5+5
+	assert.Equal(t, 9+6+5+7+10, checked)
+
+	// with a larger account gap, account 3 is found as well
+	accounts, err = w.Discover(context.Background(), used, &wallet.DiscoveryOptions{AddressGap: 5, AccountGap: 2})
+	require.NoError(t, err)
+	require.Len(t, accounts, 3)
+	assert.EqualValues(t, 3, accounts[2].Index)
+
+	// discovering does not create any accounts
+	assert.Empty(t, w.Accounts())
+	_, err = w.CreateAccount("existing")
+	require.NoError(t, err)
+	require.NoError(t, w.MarkUsed(0, wallet.Internal, 7))
+
+	require.NoError(t, w.Restore(accounts))
+	assert.Equal(t, []wallet.AccountState{
+		{Index: 0, Name: "existing", NextExternal: 4, NextInternal: 8},
+		{Index: 1, NextInternal: 2},
+		{Index: 3, NextExternal: 1},
+	}, w.Accounts())
+	addr, err := w.NextReceiveAddress(0)
+	require.NoError(t, err)
+	assert.EqualValues(t, 4, addr.Index)
+}
+
+func TestDiscoverError(t *testing.T) {
+	w, err := wallet.New(seed, wallet.NewMemoryStore(), nil)
+	require.NoError(t, err)
+
+	_, err = w.Discover(context.Background(), func(context.Context, *wallet.Address) (bool, error) {
+		return false, errStore
+	}, nil)
+	assert.ErrorIs(t, err, errStore)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = w.Discover(ctx, func(context.Context, *wallet.Address) (bool, error) {
+		return false, nil
+	}, nil)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func withoutUsed(a wallet.DiscoveredAccount) wallet.DiscoveredAccount {
+	a.Used = nil
+	return a
+}
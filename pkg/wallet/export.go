@@ -0,0 +1,219 @@
+package wallet
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/iotaledger/iota-crypto-demo/internal/hexutil"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bech32/address"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip32path"
+	"github.com/iotaledger/iota-crypto-demo/pkg/ed25519"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10/eddsa"
+)
+
+// ExportVersion is the version of the Export format.
+const ExportVersion = 1
+
+var (
+	// ErrWatchOnly is returned when an operation requires private keys, which a watch-only wallet does not have.
+	ErrWatchOnly = errors.New("watch-only wallet")
+	// ErrNotExported is returned when a watch-only wallet does not contain the public key of an address.
+	ErrNotExported = errors.New("address not exported")
+	// ErrInvalidExport is returned when an Export is malformed or inconsistent.
+	ErrInvalidExport = errors.New("invalid export")
+)
+
+// Export bundles the public information of the accounts of a wallet, so that a watch-only wallet can be provisioned
+// without access to the seed. It is encoded as JSON.
+type Export struct {
+	Version int `json:"version"`
+	// Network is the bech32 human-readable part of the network prefix.
+	Network  string `json:"network"`
+	CoinType uint32 `json:"coinType"`
+	// MasterFingerprint is the fingerprint of the master key, identifying the seed.
+	MasterFingerprint hexutil.Bytes     `json:"masterFingerprint"`
+	Accounts          []ExportedAccount `json:"accounts"`
+}
+
+// ExportedAccount contains the extended public key of an account.
+// As Ed25519 does not support the derivation of public child keys, the xpub alone cannot be used to compute addresses.
+// Instead, the public keys of the first addresses of both chains are included.
+type ExportedAccount struct {
+	AccountState
+	Path bip32path.Path `json:"path"`
+	// XPub is the BIP-32 serialization of the extended public key of the account.
+	XPub string `json:"xpub"`
+	// Fingerprint is the fingerprint of the account key.
+	Fingerprint hexutil.Bytes `json:"fingerprint"`
+	// External contains the public keys of the addresses on the External chain starting from index 0.
+	External []hexutil.Bytes `json:"external"`
+	// Internal contains the public keys of the addresses on the Internal chain starting from index 0.
+	Internal []hexutil.Bytes `json:"internal"`
+}
+
+// watchedAccount contains the exported keys of an account of a watch-only wallet.
+type watchedAccount struct {
+	keys [2][]ed25519.PublicKey // the public keys of the addresses indexed by chain
+}
+
+// Export exports all accounts of the wallet. For each chain it includes the public keys of the addresses up to
+// lookahead addresses after the next unused one. If lookahead is zero, DefaultAddressGap is used.
+func (w *Wallet) Export(lookahead uint32) (*Export, error) {
+	if w.IsWatchOnly() {
+		return nil, ErrWatchOnly
+	}
+	if lookahead == 0 {
+		lookahead = DefaultAddressGap
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	res := &Export{
+		Version:           ExportVersion,
+		Network:           w.prefix.String(),
+		CoinType:          w.coinType,
+		MasterFingerprint: w.MasterFingerprint(),
+	}
+	for _, s := range w.sortedAccounts() {
+		key, err := w.coinKey.DeriveChild(s.Index | slip10.Hardened)
+		if err != nil {
+			return nil, err
+		}
+		xpub, err := key.XPub()
+		fingerprint := key.KeyFingerprint()
+		clearKey(key)
+		if err != nil {
+			return nil, err
+		}
+		path, _ := w.Path(s.Index, External, 0)
+		a := ExportedAccount{
+			AccountState: s,
+			Path:         path[:3],
+			XPub:         xpub,
+			Fingerprint:  fingerprint,
+		}
+		for _, chain := range []Chain{External, Internal} {
+			n := min(uint64(*s.next(chain))+uint64(lookahead), uint64(slip10.Hardened))
+			keys := make([]hexutil.Bytes, n)
+			for i := range keys {
+				public, err := w.publicKey(s.Index, chain, uint32(i))
+				if err != nil {
+					return nil, err
+				}
+				keys[i] = hexutil.Bytes(public)
+			}
+			if chain == External {
+				a.External = keys
+			} else {
+				a.Internal = keys
+			}
+		}
+		res.Accounts = append(res.Accounts, a)
+	}
+	return res, nil
+}
+
+// Import returns a watch-only wallet for the accounts of export. The state of the accounts is merged with store, so
+// that addresses handed out by the watch-only wallet are never returned twice.
+// The exported public keys cannot be verified against the xpub, as Ed25519 does not support public derivation. The
+// export must therefore be transferred from the signing wallet over an authentic channel.
+func Import(export *Export, store Store) (*Wallet, error) {
+	if export.Version != ExportVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidExport, export.Version)
+	}
+	prefix, err := address.ParsePrefix(export.Network)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidExport, err)
+	}
+	if export.CoinType >= slip10.Hardened {
+		return nil, fmt.Errorf("%w: coin type %d", ErrIndexOutOfRange, export.CoinType)
+	}
+	if len(export.MasterFingerprint) != slip10.FingerprintSize {
+		return nil, fmt.Errorf("%w: invalid master fingerprint", ErrInvalidExport)
+	}
+	states, err := loadStates(store)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &Wallet{
+		prefix:      prefix,
+		coinType:    export.CoinType,
+		store:       store,
+		fingerprint: append([]byte{}, export.MasterFingerprint...),
+		watch:       map[uint32]*watchedAccount{},
+		accounts:    map[uint32]*AccountState{},
+	}
+	for i := range export.Accounts {
+		a := &export.Accounts[i]
+		if _, ok := w.watch[a.Index]; ok {
+			return nil, fmt.Errorf("%w: %d", ErrAccountExists, a.Index)
+		}
+		watched, err := w.importAccount(a)
+		if err != nil {
+			return nil, fmt.Errorf("account %d: %w", a.Index, err)
+		}
+		w.watch[a.Index] = watched
+
+		// the store takes precedence, but must not go back behind the exported state
+		updated, stored := a.AccountState, states[a.Index]
+		if stored != nil {
+			updated.Name = stored.Name
+			updated.NextExternal = max(updated.NextExternal, stored.NextExternal)
+			updated.NextInternal = max(updated.NextInternal, stored.NextInternal)
+		}
+		if stored == nil || updated != *stored {
+			if err := store.Save(updated); err != nil {
+				return nil, fmt.Errorf("failed to save account: %w", err)
+			}
+		}
+		w.accounts[a.Index] = &updated
+	}
+	for index := range states {
+		if _, ok := w.accounts[index]; !ok {
+			return nil, fmt.Errorf("%w: stored account %d not exported", ErrAccountNotFound, index)
+		}
+	}
+	return w, nil
+}
+
+// importAccount validates an exported account and parses its keys.
+func (w *Wallet) importAccount(a *ExportedAccount) (*watchedAccount, error) {
+	if err := checkIndices(a.Index, External, 0); err != nil {
+		return nil, err
+	}
+	if a.NextExternal > slip10.Hardened || a.NextInternal > slip10.Hardened {
+		return nil, ErrIndexOutOfRange
+	}
+	path, _ := w.Path(a.Index, External, 0)
+	if a.Path.String() != path[:3].String() {
+		return nil, fmt.Errorf("%w: unexpected path %s", ErrInvalidExport, a.Path)
+	}
+	key, version, err := slip10.Deserialize(a.XPub, eddsa.Ed25519())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidExport, err)
+	}
+	if version != slip10.MainnetPublic || key.IsPrivate() {
+		return nil, fmt.Errorf("%w: not an extended public key", ErrInvalidExport)
+	}
+	if !bytes.Equal(a.Fingerprint, key.KeyFingerprint()) {
+		return nil, fmt.Errorf("%w: fingerprint does not match xpub", ErrInvalidExport)
+	}
+
+	res := &watchedAccount{}
+	for chain, keys := range [][]hexutil.Bytes{a.External, a.Internal} {
+		if uint64(len(keys)) > uint64(slip10.Hardened) {
+			return nil, ErrIndexOutOfRange
+		}
+		for _, k := range keys {
+			if len(k) != ed25519.PublicKeySize {
+				return nil, fmt.Errorf("%w: invalid public key length %d", ErrInvalidExport, len(k))
+			}
+			res.keys[chain] = append(res.keys[chain], append(ed25519.PublicKey{}, k...))
+		}
+	}
+	return res, nil
+}
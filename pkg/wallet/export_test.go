@@ -0,0 +1,184 @@
+//nolint:scopelint // from tests
+package wallet_test
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/bech32/address"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10/eddsa"
+	"github.com/iotaledger/iota-crypto-demo/pkg/wallet"
+)
+
+func newSigningWallet(t *testing.T) *wallet.Wallet {
+	w, err := wallet.New(seed, wallet.NewMemoryStore(), &wallet.Options{Prefix: address.ShimmerMainnet})
+	require.NoError(t, err)
+	_, err = w.CreateAccount("first")
+	require.NoError(t, err)
+	_, err = w.CreateAccount("second")
+	require.NoError(t, err)
+	_, err = w.NextReceiveAddress(1)
+	require.NoError(t, err)
+	return w
+}
+
+func TestExport(t *testing.T) {
+	w := newSigningWallet(t)
+	export, err := w.Export(3)
+	require.NoError(t, err)
+
+	assert.Equal(t, wallet.ExportVersion, export.Version)
+	assert.Equal(t, "smr", export.Network)
+	assert.EqualValues(t, 4219, export.CoinType)
+	assert.Equal(t, w.MasterFingerprint(), export.MasterFingerprint.Bytes())
+	require.Len(t, export.Accounts, 2)
+
+	a := export.Accounts[1]
+	assert.Equal(t, wallet.AccountState{Index: 1, Name: "second", NextExternal: 1}, a.AccountState)
+	assert.Equal(t, "m/44'/4219'/1'", a.Path.String())
+	assert.Len(t, a.External, 4)
+	assert.Len(t, a.Internal, 3)
+
+	// the xpub corresponds to the account key
+	key, err := slip10.DeriveKeyFromPath(seed, eddsa.Ed25519(), a.Path)
+	require.NoError(t, err)
+	xpub, err := key.XPub()
+	require.NoError(t, err)
+	assert.Equal(t, xpub, a.XPub)
+	assert.Equal(t, key.KeyFingerprint(), a.Fingerprint.Bytes())
+
+	master, err := slip10.NewMasterKey(seed, eddsa.Ed25519())
+	require.NoError(t, err)
+	assert.Equal(t, master.KeyFingerprint(), w.MasterFingerprint())
+}
+
+func TestImport(t *testing.T) {
+	w := newSigningWallet(t)
+	export, err := w.Export(2)
+	require.NoError(t, err)
+
+	// the export is transferred as JSON
+	data, err := json.Marshal(export)
+	require.NoError(t, err)
+	var decoded wallet.Export
+	require.NoError(t, json.Unmarshal(data, &decoded))
+
+	watch, err := wallet.Import(&decoded, wallet.NewMemoryStore())
+	require.NoError(t, err)
+	assert.True(t, watch.IsWatchOnly())
+	assert.Equal(t, address.ShimmerMainnet, watch.Prefix())
+	assert.Equal(t, w.MasterFingerprint(), watch.MasterFingerprint())
+	assert.Equal(t, w.Accounts(), watch.Accounts())
+
+	// the watch-only wallet hands out the same addresses as the signing wallet
+	for i := 0; i < 2; i++ {
+		expected, err := w.NextReceiveAddress(1)
+		require.NoError(t, err)
+		addr, err := watch.NextReceiveAddress(1)
+		require.NoError(t, err)
+		assert.Equal(t, expected, addr)
+	}
+	_, err = watch.NextReceiveAddress(1)
+	assert.ErrorIs(t, err, wallet.ErrNotExported)
+	state, err := watch.Account(1)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, state.NextExternal)
+
+	// discovery stops at the last exported address
+	accounts, err := watch.Discover(context.Background(), func(_ context.Context, addr *wallet.Address) (bool, error) {
+		return addr.Index == 1, nil
+	}, nil)
+	require.NoError(t, err)
+	require.Len(t, accounts, 2)
+
+	_, err = watch.PrivateKey(0, wallet.External, 0)
+	assert.ErrorIs(t, err, wallet.ErrWatchOnly)
+	_, err = watch.CreateAccount("")
+	assert.ErrorIs(t, err, wallet.ErrWatchOnly)
+	_, err = watch.Export(1)
+	assert.ErrorIs(t, err, wallet.ErrWatchOnly)
+}
+
+func TestImportStore(t *testing.T) {
+	export, err := newSigningWallet(t).Export(5)
+	require.NoError(t, err)
+
+	store := wallet.NewMemoryStore()
+	require.NoError(t, store.Save(wallet.AccountState{Index: 0, Name: "renamed", NextInternal: 2}))
+	watch, err := wallet.Import(export, store)
+	require.NoError(t, err)
+
+	expected := []wallet.AccountState{
+		{Index: 0, Name: "renamed", NextInternal: 2},
+		{Index: 1, Name: "second", NextExternal: 1},
+	}
+	assert.Equal(t, expected, watch.Accounts())
+	states, err := store.Load()
+	require.NoError(t, err)
+	assert.Equal(t, expected, states)
+
+	// accounts in the store must be part of the export
+	require.NoError(t, store.Save(wallet.AccountState{Index: 5}))
+	_, err = wallet.Import(export, store)
+	assert.ErrorIs(t, err, wallet.ErrAccountNotFound)
+}
+
+func TestImportInvalid(t *testing.T) {
+	var tests = []*struct {
+		name   string
+		modify func(*wallet.Export)
+		err    error
+	}{
+		{"version", func(e *wallet.Export) { e.Version = 2 }, wallet.ErrInvalidExport},
+		{"network", func(e *wallet.Export) { e.Network = "foo" }, wallet.ErrInvalidExport},
+		{"master fingerprint", func(e *wallet.Export) { e.MasterFingerprint = nil }, wallet.ErrInvalidExport},
+		{"path", func(e *wallet.Export) { e.Accounts[0].Path = e.Accounts[1].Path }, wallet.ErrInvalidExport},
+		{"xpub", func(e *wallet.Export) { e.Accounts[0].XPub = e.Accounts[0].XPub[1:] }, wallet.ErrInvalidExport},
+		{"fingerprint", func(e *wallet.Export) { e.Accounts[0].Fingerprint = e.Accounts[1].Fingerprint }, wallet.ErrInvalidExport},
+		{"public key", func(e *wallet.Export) { e.Accounts[0].External[0] = e.Accounts[0].External[0][1:] }, wallet.ErrInvalidExport},
+		{"duplicate", func(e *wallet.Export) { e.Accounts[1] = e.Accounts[0] }, wallet.ErrAccountExists},
+		{"index", func(e *wallet.Export) { e.Accounts[0].Index = slip10.Hardened }, wallet.ErrIndexOutOfRange},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			export, err := newSigningWallet(t).Export(1)
+			require.NoError(t, err)
+			tt.modify(export)
+			_, err = wallet.Import(export, wallet.NewMemoryStore())
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestExportJSON(t *testing.T) {
+	w, err := wallet.New(seed, wallet.NewMemoryStore(), nil)
+	require.NoError(t, err)
+	_, err = w.CreateAccount("")
+	require.NoError(t, err)
+	export, err := w.Export(1)
+	require.NoError(t, err)
+
+	data, err := json.Marshal(export.Accounts[0])
+	require.NoError(t, err)
+	var fields map[string]any
+	require.NoError(t, json.Unmarshal(data, &fields))
+	assert.ElementsMatch(t,
+		[]string{"index", "nextExternal", "nextInternal", "path", "xpub", "fingerprint", "external", "internal"},
+		keys(fields))
+	assert.Equal(t, "m/44'/4218'/0'", fields["path"])
+	assert.Equal(t, hex.EncodeToString(export.Accounts[0].Fingerprint), fields["fingerprint"])
+}
+
+func keys(m map[string]any) []string {
+	res := make([]string, 0, len(m))
+	for k := range m {
+		res = append(res, k)
+	}
+	return res
+}
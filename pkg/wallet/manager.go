@@ -0,0 +1,202 @@
+package wallet
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"path/filepath"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/bech32/address"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip39"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10/eddsa"
+)
+
+// StoreFunc returns the Store of the wallet, whose master key has the given fingerprint.
+type StoreFunc func(fingerprint []byte) (Store, error)
+
+// MemoryStores returns a StoreFunc creating a new MemoryStore for every opened wallet.
+func MemoryStores() StoreFunc {
+	return func([]byte) (Store, error) { return NewMemoryStore(), nil }
+}
+
+// FileStores returns a StoreFunc keeping the accounts of each wallet in a separate FileStore in dir, which is named
+// after the hex encoded fingerprint of the wallet, so that the file names do not reveal the passphrases.
+func FileStores(dir string) StoreFunc {
+	return func(fingerprint []byte) (Store, error) {
+		return NewFileStore(filepath.Join(dir, hex.EncodeToString(fingerprint)+".json")), nil
+	}
+}
+
+// Manager owns a mnemonic and opens its wallets. As BIP-39 derives a different seed for every passphrase, each
+// passphrase leads to a separate, hidden wallet with its own accounts, whose existence cannot be detected without
+// knowing the passphrase.
+type Manager struct {
+	mnemonic bip39.Mnemonic
+	stores   StoreFunc
+	opts     Options
+}
+
+// NewManager returns a Manager for mnemonic, obtaining the store of each wallet from stores.
+// If stores is nil, MemoryStores is used.
+func NewManager(mnemonic bip39.Mnemonic, stores StoreFunc, opts *Options) (*Manager, error) {
+	if _, err := bip39.MnemonicToEntropy(mnemonic); err != nil {
+		return nil, err
+	}
+	m := &Manager{
+		mnemonic: append(bip39.Mnemonic{}, mnemonic...),
+		stores:   stores,
+	}
+	if m.stores == nil {
+		m.stores = MemoryStores()
+	}
+	if opts != nil {
+		m.opts = *opts
+	}
+	return m, nil
+}
+
+// Wallet opens the standard wallet of the mnemonic, i.e. the one without a passphrase.
+func (m *Manager) Wallet() (*Wallet, error) {
+	return m.HiddenWallet("")
+}
+
+// HiddenWallet opens the wallet of the mnemonic with the given passphrase.
+// Its accounts are isolated from those of all other passphrases.
+func (m *Manager) HiddenWallet(passphrase string) (*Wallet, error) {
+	seed, err := bip39.MnemonicToSeed(m.mnemonic, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	defer clear(seed)
+
+	master, err := slip10.NewMasterKey(seed, eddsa.Ed25519())
+	if err != nil {
+		return nil, err
+	}
+	fingerprint := master.KeyFingerprint()
+	clearKey(master)
+	store, err := m.stores(fingerprint)
+	if err != nil {
+		return nil, err
+	}
+	return New(seed, store, &m.opts)
+}
+
+// ScanOptions specifies the addresses compared across passphrases.
+type ScanOptions struct {
+	// Accounts is the number of accounts starting from 0. If zero, only the first account is used.
+	Accounts uint32
+	// Addresses is the number of addresses per chain and account. If zero, DefaultAddressGap is used.
+	Addresses uint32
+}
+
+// PassphraseAddresses contains the first addresses of the wallet of a passphrase.
+type PassphraseAddresses struct {
+	Passphrase string
+	// Fingerprint is the fingerprint of the master key of the wallet.
+	Fingerprint []byte
+	// Addresses contains the addresses of the External chain followed by those of the Internal chain for every account.
+	Addresses []*Address
+}
+
+// CompareAddresses derives the first addresses of the wallets of all passphrases, e.g. to compare them with the
+// addresses of a backup in order to find out whether and which passphrase has been used.
+// The wallets are not opened, so that their stores are neither created nor modified.
+func (m *Manager) CompareAddresses(passphrases []string, opts *ScanOptions) ([]PassphraseAddresses, error) {
+	accounts, addresses := uint32(1), DefaultAddressGap
+	if opts != nil && opts.Accounts > 0 {
+		accounts = opts.Accounts
+	}
+	if opts != nil && opts.Addresses > 0 {
+		addresses = opts.Addresses
+	}
+
+	res := make([]PassphraseAddresses, 0, len(passphrases))
+	for _, passphrase := range passphrases {
+		w, err := m.scanWallet(passphrase)
+		if err != nil {
+			return nil, err
+		}
+		p := PassphraseAddresses{Passphrase: passphrase, Fingerprint: w.MasterFingerprint()}
+		for account := uint32(0); account < accounts; account++ {
+			for _, chain := range []Chain{External, Internal} {
+				for index := uint32(0); index < addresses; index++ {
+					addr, err := w.address(account, chain, index)
+					if err != nil {
+						w.Clear()
+						return nil, err
+					}
+					p.Addresses = append(p.Addresses, addr)
+				}
+			}
+		}
+		w.Clear()
+		res = append(res, p)
+	}
+	return res, nil
+}
+
+// PassphraseMatch is a used address found in the wallet of a passphrase.
+type PassphraseMatch struct {
+	Passphrase string
+	Address    *Address
+}
+
+// FindPassphrase returns the first used address of the wallet of each passphrase as reported by used, skipping the
+// passphrases whose compared addresses are all unused. Use KnownAddresses to search for the addresses of a backup.
+func (m *Manager) FindPassphrase(
+	ctx context.Context, passphrases []string, used UsedChecker, opts *ScanOptions,
+) ([]PassphraseMatch, error) {
+	wallets, err := m.CompareAddresses(passphrases, opts)
+	if err != nil {
+		return nil, err
+	}
+	var res []PassphraseMatch
+	for _, p := range wallets {
+		for _, addr := range p.Addresses {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			ok, err := used(ctx, addr)
+			if err != nil {
+				return nil, fmt.Errorf("failed to check address %s: %w", addr.Bech32, err)
+			}
+			if ok {
+				res = append(res, PassphraseMatch{Passphrase: p.Passphrase, Address: addr})
+				break
+			}
+		}
+	}
+	return res, nil
+}
+
+// KnownAddresses returns a UsedChecker reporting the given bech32 addresses as used, independent of their network.
+func KnownAddresses(addrs ...string) (UsedChecker, error) {
+	known := make([]address.Address, len(addrs))
+	for i, s := range addrs {
+		_, addr, err := address.ParseBech32(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid address %q: %w", s, err)
+		}
+		known[i] = addr
+	}
+	return func(_ context.Context, addr *Address) (bool, error) {
+		for _, k := range known {
+			if k.Equal(addr.Address) {
+				return true, nil
+			}
+		}
+		return false, nil
+	}, nil
+}
+
+// scanWallet returns a wallet for passphrase, which is not backed by the store of the manager.
+func (m *Manager) scanWallet(passphrase string) (*Wallet, error) {
+	seed, err := bip39.MnemonicToSeed(m.mnemonic, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	defer clear(seed)
+	return New(seed, NewMemoryStore(), &m.opts)
+}
@@ -0,0 +1,106 @@
+//nolint:scopelint // from tests
+package wallet_test
+
+import (
+	"context"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip39"
+	"github.com/iotaledger/iota-crypto-demo/pkg/wallet"
+)
+
+var testMnemonic = bip39.ParseMnemonic("legal winner thank year wave sausage worth useful legal winner thank yellow")
+
+func TestHiddenWallet(t *testing.T) {
+	dir := t.TempDir()
+	m, err := wallet.NewManager(testMnemonic, wallet.FileStores(dir), nil)
+	require.NoError(t, err)
+
+	standard, err := m.Wallet()
+	require.NoError(t, err)
+	_, err = standard.CreateAccount("standard")
+	require.NoError(t, err)
+	hidden, err := m.HiddenWallet("TREZOR")
+	require.NoError(t, err)
+	assert.NotEqual(t, standard.MasterFingerprint(), hidden.MasterFingerprint())
+	assert.Empty(t, hidden.Accounts())
+	_, err = hidden.CreateAccount("hidden")
+	require.NoError(t, err)
+
+	// the hidden wallet matches a wallet of the seed with the passphrase
+	expected, err := wallet.FromMnemonic(testMnemonic, "TREZOR", wallet.NewMemoryStore(), nil)
+	require.NoError(t, err)
+	_, err = expected.CreateAccount("")
+	require.NoError(t, err)
+	addr, err := hidden.NextReceiveAddress(0)
+	require.NoError(t, err)
+	expectedAddr, err := expected.NextReceiveAddress(0)
+	require.NoError(t, err)
+	assert.Equal(t, expectedAddr, addr)
+
+	// every wallet has its own file named after its fingerprint
+	for _, w := range []*wallet.Wallet{standard, hidden} {
+		_, err := os.Stat(filepath.Join(dir, hex.EncodeToString(w.MasterFingerprint())+".json"))
+		assert.NoError(t, err)
+	}
+	reopened, err := m.HiddenWallet("TREZOR")
+	require.NoError(t, err)
+	assert.Equal(t, []wallet.AccountState{{Index: 0, Name: "hidden", NextExternal: 1}}, reopened.Accounts())
+
+	_, err = wallet.NewManager(bip39.ParseMnemonic("legal winner"), nil, nil)
+	assert.Error(t, err)
+}
+
+func TestCompareAddresses(t *testing.T) {
+	m, err := wallet.NewManager(testMnemonic, nil, nil)
+	require.NoError(t, err)
+
+	wallets, err := m.CompareAddresses([]string{"", "TREZOR"}, &wallet.ScanOptions{Accounts: 2, Addresses: 3})
+	require.NoError(t, err)
+	require.Len(t, wallets, 2)
+	for _, p := range wallets {
+		require.Len(t, p.Addresses, 2*2*3)
+		last := p.Addresses[len(p.Addresses)-1]
+		assert.Equal(t, "m/44'/4218'/1'/1'/2'", last.Path.String())
+	}
+	assert.NotEqual(t, wallets[0].Addresses[0].Bech32, wallets[1].Addresses[0].Bech32)
+
+	w, err := wallet.FromMnemonic(testMnemonic, "TREZOR", wallet.NewMemoryStore(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, w.MasterFingerprint(), wallets[1].Fingerprint)
+}
+
+func TestFindPassphrase(t *testing.T) {
+	m, err := wallet.NewManager(testMnemonic, nil, nil)
+	require.NoError(t, err)
+
+	// an address of the backup, which has been derived with a passphrase
+	w, err := m.HiddenWallet("secret")
+	require.NoError(t, err)
+	_, err = w.CreateAccount("")
+	require.NoError(t, err)
+	addr, err := w.Address(0, wallet.Internal, 4)
+	require.NoError(t, err)
+
+	used, err := wallet.KnownAddresses(addr.Bech32)
+	require.NoError(t, err)
+	matches, err := m.FindPassphrase(context.Background(), []string{"", "TREZOR", "secret"}, used, &wallet.ScanOptions{Addresses: 5})
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	assert.Equal(t, "secret", matches[0].Passphrase)
+	assert.Equal(t, addr, matches[0].Address)
+
+	// the address is outside of the scanned range
+	matches, err = m.FindPassphrase(context.Background(), []string{"secret"}, used, &wallet.ScanOptions{Addresses: 4})
+	require.NoError(t, err)
+	assert.Empty(t, matches)
+
+	_, err = wallet.KnownAddresses("invalid")
+	assert.Error(t, err)
+}
@@ -0,0 +1,138 @@
+package wallet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// Store persists the state of the accounts of a wallet.
+type Store interface {
+	// Load returns the state of all stored accounts.
+	Load() ([]AccountState, error)
+	// Save stores the state of an account, replacing any previous state with the same index.
+	Save(state AccountState) error
+}
+
+// MemoryStore is a Store keeping the accounts in memory.
+type MemoryStore struct {
+	mu       sync.Mutex
+	accounts map[uint32]AccountState
+}
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{accounts: map[uint32]AccountState{}}
+}
+
+// Load implements Store.
+func (m *MemoryStore) Load() ([]AccountState, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return sortedStates(m.accounts), nil
+}
+
+// Save implements Store.
+func (m *MemoryStore) Save(state AccountState) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.accounts[state.Index] = state
+	return nil
+}
+
+// FileStore is a Store keeping the accounts in a JSON file.
+// The file is rewritten atomically on every Save and never contains any key material.
+type FileStore struct {
+	name string
+
+	mu sync.Mutex
+}
+
+// fileContent is the JSON content of a FileStore.
+type fileContent struct {
+	Accounts []AccountState `json:"accounts"`
+}
+
+// NewFileStore returns a Store for the file name. A missing file corresponds to a wallet without accounts.
+func NewFileStore(name string) *FileStore {
+	return &FileStore{name: name}
+}
+
+// Load implements Store.
+func (f *FileStore) Load() ([]AccountState, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.read()
+}
+
+// Save implements Store.
+func (f *FileStore) Save(state AccountState) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	states, err := f.read()
+	if err != nil {
+		return err
+	}
+	accounts := make(map[uint32]AccountState, len(states)+1)
+	for _, s := range states {
+		accounts[s.Index] = s
+	}
+	accounts[state.Index] = state
+
+	data, err := json.MarshalIndent(&fileContent{Accounts: sortedStates(accounts)}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(f.name, data)
+}
+
+func (f *FileStore) read() ([]AccountState, error) {
+	data, err := os.ReadFile(f.name)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var c fileContent
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("invalid wallet file %s: %w", f.name, err)
+	}
+	return c.Accounts, nil
+}
+
+// writeFileAtomic writes data to a temporary file in the same directory and renames it to name.
+func writeFileAtomic(name string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(name), filepath.Base(name)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		return errors.Join(err, tmp.Close())
+	}
+	if err := tmp.Sync(); err != nil {
+		return errors.Join(err, tmp.Close())
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), name)
+}
+
+func sortedStates(accounts map[uint32]AccountState) []AccountState {
+	res := make([]AccountState, 0, len(accounts))
+	for _, s := range accounts {
+		res = append(res, s)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Index < res[j].Index })
+	return res
+}
@@ -0,0 +1,471 @@
+/*
+Package wallet implements a deterministic wallet on top of the bip39, slip10 and address packages.
+
+A Wallet owns a seed and manages BIP-44 style accounts, whose Ed25519 keys are derived with SLIP-10 at the path
+
+	m/44'/coin_type'/account'/chain'/address_index'
+
+As SLIP-10 only defines hardened derivation for Ed25519, every level of the path is hardened. For each account the
+wallet tracks the next unused index of the external (receive) and internal (change) chain and persists it through a
+Store before an address is handed out, so that an address is never returned twice, even across restarts.
+
+Export bundles the account xpubs together with the public keys of the first addresses of each chain, from which
+Import provisions a watch-only wallet that can hand out addresses but not sign.
+*/
+package wallet
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/bech32/address"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip32path"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip39"
+	"github.com/iotaledger/iota-crypto-demo/pkg/ed25519"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10/eddsa"
+)
+
+// Purpose is the BIP-44 purpose of the first level of all derivation paths.
+const Purpose uint32 = 44
+
+// Chain is the fourth level of a derivation path, distinguishing receive and change addresses.
+type Chain uint32
+
+const (
+	// External is the chain of the addresses handed out to receive funds.
+	External Chain = 0
+	// Internal is the chain of the change addresses, which are not communicated outside the wallet.
+	Internal Chain = 1
+)
+
+func (c Chain) String() string {
+	switch c {
+	case External:
+		return "external"
+	case Internal:
+		return "internal"
+	default:
+		return fmt.Sprintf("Chain(%d)", uint32(c))
+	}
+}
+
+var (
+	// ErrAccountNotFound is returned when an account has not been created.
+	ErrAccountNotFound = errors.New("account not found")
+	// ErrAccountExists is returned when the Store contains the same account more than once.
+	ErrAccountExists = errors.New("account already exists")
+	// ErrInvalidChain is returned when a chain is neither External nor Internal.
+	ErrInvalidChain = errors.New("invalid chain")
+	// ErrIndexOutOfRange is returned when an account or address index does not fit into a hardened index.
+	ErrIndexOutOfRange = errors.New("index out of range")
+)
+
+// AccountState is the persisted state of an account.
+type AccountState struct {
+	// Index is the account index, i.e. the third level of the derivation path.
+	Index uint32 `json:"index"`
+	// Name is an optional label of the account.
+	Name string `json:"name,omitempty"`
+	// NextExternal is the index of the next unused address on the External chain.
+	NextExternal uint32 `json:"nextExternal"`
+	// NextInternal is the index of the next unused address on the Internal chain.
+	NextInternal uint32 `json:"nextInternal"`
+}
+
+// next returns a pointer to the next unused index of chain.
+func (s *AccountState) next(chain Chain) *uint32 {
+	if chain == Internal {
+		return &s.NextInternal
+	}
+	return &s.NextExternal
+}
+
+// Options specifies the network of the wallet.
+type Options struct {
+	// Prefix is the network of the returned bech32 addresses. The zero value corresponds to address.IOTAMainnet.
+	Prefix address.Prefix
+	// CoinType is the SLIP-44 coin type of the derivation paths. If zero, the coin type of Prefix is used.
+	CoinType uint32
+}
+
+// Address is an address derived by the wallet.
+type Address struct {
+	Account   uint32
+	Chain     Chain
+	Index     uint32
+	Path      bip32path.Path
+	PublicKey ed25519.PublicKey
+	Address   address.Ed25519Address
+	// Bech32 is the bech32 encoding of Address using the prefix of the wallet.
+	Bech32 string
+}
+
+// Wallet is a deterministic wallet managing the accounts of a single seed.
+// A watch-only Wallet returned by Import does not have access to the seed, but hands out the exported addresses.
+// It is safe for concurrent use.
+type Wallet struct {
+	prefix      address.Prefix
+	coinType    uint32
+	store       Store
+	fingerprint []byte // the fingerprint of the master key
+
+	mu       sync.Mutex
+	coinKey  *slip10.ExtendedKey // the extended key of m/44'/coin_type', nil for watch-only wallets
+	coin     eddsa.Node          // the node of coinKey
+	watch    map[uint32]*watchedAccount
+	accounts map[uint32]*AccountState
+}
+
+// New returns a wallet for seed, whose accounts are loaded from and persisted to store.
+// If opts is nil, the addresses are derived for the IOTA mainnet.
+func New(seed []byte, store Store, opts *Options) (*Wallet, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+	coinType := opts.CoinType
+	if coinType == 0 {
+		coinType = opts.Prefix.CoinType()
+	}
+	if coinType >= slip10.Hardened {
+		return nil, fmt.Errorf("%w: coin type %d", ErrIndexOutOfRange, coinType)
+	}
+	states, err := loadStates(store)
+	if err != nil {
+		return nil, err
+	}
+
+	master, err := slip10.NewMasterKey(seed, eddsa.Ed25519())
+	if err != nil {
+		return nil, err
+	}
+	purpose, err := master.DeriveChild(Purpose | slip10.Hardened)
+	if err != nil {
+		return nil, err
+	}
+	coinKey, err := purpose.DeriveChild(coinType | slip10.Hardened)
+	if err != nil {
+		return nil, err
+	}
+	w := &Wallet{
+		prefix:      opts.Prefix,
+		coinType:    coinType,
+		store:       store,
+		fingerprint: master.KeyFingerprint(),
+		coinKey:     coinKey,
+		accounts:    states,
+	}
+	copy(w.coin.Key[:], coinKey.Key.Bytes())
+	copy(w.coin.ChainCode[:], coinKey.ChainCode)
+	// the purpose key is still referenced as parent of the coin type key
+	clearKey(master)
+	return w, nil
+}
+
+// loadStates loads and validates the accounts of store.
+func loadStates(store Store) (map[uint32]*AccountState, error) {
+	states, err := store.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load accounts: %w", err)
+	}
+	res := make(map[uint32]*AccountState, len(states))
+	for i := range states {
+		s := states[i]
+		if s.Index >= slip10.Hardened || s.NextExternal > slip10.Hardened || s.NextInternal > slip10.Hardened {
+			return nil, fmt.Errorf("%w: account %d", ErrIndexOutOfRange, s.Index)
+		}
+		if _, ok := res[s.Index]; ok {
+			return nil, fmt.Errorf("%w: %d", ErrAccountExists, s.Index)
+		}
+		res[s.Index] = &s
+	}
+	return res, nil
+}
+
+// FromMnemonic returns a wallet for the seed of mnemonic and passphrase as described in New.
+func FromMnemonic(mnemonic bip39.Mnemonic, passphrase string, store Store, opts *Options) (*Wallet, error) {
+	seed, err := bip39.MnemonicToSeed(mnemonic, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	defer clear(seed)
+	return New(seed, store, opts)
+}
+
+// Prefix returns the network prefix of the bech32 addresses.
+func (w *Wallet) Prefix() address.Prefix {
+	return w.prefix
+}
+
+// CoinType returns the SLIP-44 coin type of the derivation paths.
+func (w *Wallet) CoinType() uint32 {
+	return w.coinType
+}
+
+// MasterFingerprint returns the fingerprint of the master key, identifying the seed of the wallet.
+func (w *Wallet) MasterFingerprint() []byte {
+	return append([]byte{}, w.fingerprint...)
+}
+
+// IsWatchOnly returns whether the wallet has been imported without access to the private keys.
+func (w *Wallet) IsWatchOnly() bool {
+	return w.coinKey == nil
+}
+
+// CreateAccount creates a new account with the lowest unused account index and the given name.
+func (w *Wallet) CreateAccount(name string) (AccountState, error) {
+	if w.IsWatchOnly() {
+		return AccountState{}, ErrWatchOnly
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var index uint32
+	for ; index < slip10.Hardened; index++ {
+		if _, ok := w.accounts[index]; !ok {
+			break
+		}
+	}
+	if index == slip10.Hardened {
+		return AccountState{}, fmt.Errorf("%w: no free account index", ErrIndexOutOfRange)
+	}
+	s := AccountState{Index: index, Name: name}
+	if err := w.store.Save(s); err != nil {
+		return AccountState{}, fmt.Errorf("failed to save account: %w", err)
+	}
+	w.accounts[index] = &s
+	return s, nil
+}
+
+// Accounts returns the state of all accounts ordered by their index.
+func (w *Wallet) Accounts() []AccountState {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.sortedAccounts()
+}
+
+func (w *Wallet) sortedAccounts() []AccountState {
+	res := make([]AccountState, 0, len(w.accounts))
+	for _, s := range w.accounts {
+		res = append(res, *s)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Index < res[j].Index })
+	return res
+}
+
+// Account returns the state of the account with the given index.
+func (w *Wallet) Account(account uint32) (AccountState, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	s, ok := w.accounts[account]
+	if !ok {
+		return AccountState{}, fmt.Errorf("%w: %d", ErrAccountNotFound, account)
+	}
+	return *s, nil
+}
+
+// Path returns the derivation path of the address with the given index on chain of account.
+func (w *Wallet) Path(account uint32, chain Chain, index uint32) (bip32path.Path, error) {
+	if err := checkIndices(account, chain, index); err != nil {
+		return nil, err
+	}
+	return bip32path.Path{
+		Purpose | slip10.Hardened,
+		w.coinType | slip10.Hardened,
+		account | slip10.Hardened,
+		uint32(chain) | slip10.Hardened,
+		index | slip10.Hardened,
+	}, nil
+}
+
+// Address returns the address with the given index on chain of account.
+// It does not change the state of the account.
+func (w *Wallet) Address(account uint32, chain Chain, index uint32) (*Address, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if _, ok := w.accounts[account]; !ok {
+		return nil, fmt.Errorf("%w: %d", ErrAccountNotFound, account)
+	}
+	return w.address(account, chain, index)
+}
+
+// NextReceiveAddress returns the next unused address on the External chain of account.
+// The new state of the account is persisted before the address is returned.
+func (w *Wallet) NextReceiveAddress(account uint32) (*Address, error) {
+	return w.nextAddress(account, External)
+}
+
+// NextChangeAddress returns the next unused address on the Internal chain of account.
+// The new state of the account is persisted before the address is returned.
+func (w *Wallet) NextChangeAddress(account uint32) (*Address, error) {
+	return w.nextAddress(account, Internal)
+}
+
+func (w *Wallet) nextAddress(account uint32, chain Chain) (*Address, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	s, ok := w.accounts[account]
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", ErrAccountNotFound, account)
+	}
+	addr, err := w.address(account, chain, *s.next(chain))
+	if err != nil {
+		return nil, err
+	}
+	if err := w.update(s, chain, addr.Index+1); err != nil {
+		return nil, err
+	}
+	return addr, nil
+}
+
+// MarkUsed marks the address with the given index on chain of account as used, e.g. when it received funds without
+// being handed out by this wallet. Subsequent calls to NextReceiveAddress or NextChangeAddress return higher indices.
+func (w *Wallet) MarkUsed(account uint32, chain Chain, index uint32) error {
+	if err := checkIndices(account, chain, index); err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	s, ok := w.accounts[account]
+	if !ok {
+		return fmt.Errorf("%w: %d", ErrAccountNotFound, account)
+	}
+	if index < *s.next(chain) {
+		return nil
+	}
+	return w.update(s, chain, index+1)
+}
+
+// PrivateKey returns the Ed25519 private key of the address with the given index on chain of account.
+func (w *Wallet) PrivateKey(account uint32, chain Chain, index uint32) (ed25519.PrivateKey, error) {
+	if w.IsWatchOnly() {
+		return nil, ErrWatchOnly
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if _, ok := w.accounts[account]; !ok {
+		return nil, fmt.Errorf("%w: %d", ErrAccountNotFound, account)
+	}
+	n, err := w.derive(account, chain, index)
+	if err != nil {
+		return nil, err
+	}
+	defer n.Clear()
+	return ed25519.NewKeyFromSeed(n.Key[:]), nil
+}
+
+// Clear overwrites the key material held by the wallet with zeros.
+// The wallet must not be used afterwards.
+func (w *Wallet) Clear() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.coin.Clear()
+	if w.coinKey != nil {
+		clearKey(w.coinKey)
+	}
+}
+
+// clearKey overwrites the private key and chain code of an Ed25519 extended key with zeros.
+func clearKey(key *slip10.ExtendedKey) {
+	if seed, ok := key.Key.(eddsa.Seed); ok {
+		clear(seed)
+	}
+	clear(key.ChainCode)
+}
+
+// update persists the next unused index of chain and only then applies it to s.
+func (w *Wallet) update(s *AccountState, chain Chain, next uint32) error {
+	updated := *s
+	*updated.next(chain) = next
+	if err := w.store.Save(updated); err != nil {
+		return fmt.Errorf("failed to save account: %w", err)
+	}
+	*s = updated
+	return nil
+}
+
+func (w *Wallet) address(account uint32, chain Chain, index uint32) (*Address, error) {
+	public, err := w.publicKey(account, chain, index)
+	if err != nil {
+		return nil, err
+	}
+	addr := address.AddressFromPublicKey(public)
+	bech, err := address.Bech32(w.prefix, addr)
+	if err != nil {
+		return nil, err
+	}
+	path, _ := w.Path(account, chain, index)
+	return &Address{
+		Account:   account,
+		Chain:     chain,
+		Index:     index,
+		Path:      path,
+		PublicKey: public,
+		Address:   addr,
+		Bech32:    bech,
+	}, nil
+}
+
+// publicKey returns the public key of the address, which for watch-only wallets must have been exported.
+func (w *Wallet) publicKey(account uint32, chain Chain, index uint32) (ed25519.PublicKey, error) {
+	if w.IsWatchOnly() {
+		if err := checkIndices(account, chain, index); err != nil {
+			return nil, err
+		}
+		watched, ok := w.watch[account]
+		if !ok || index >= uint32(len(watched.keys[chain])) {
+			path, _ := w.Path(account, chain, index)
+			return nil, fmt.Errorf("%w: %s", ErrNotExported, path)
+		}
+		return watched.keys[chain][index], nil
+	}
+	n, err := w.derive(account, chain, index)
+	if err != nil {
+		return nil, err
+	}
+	defer n.Clear()
+	public, _ := n.Seed().Ed25519Key()
+	return public, nil
+}
+
+// derive derives the node of the address from the cached coin type node.
+func (w *Wallet) derive(account uint32, chain Chain, index uint32) (eddsa.Node, error) {
+	if err := checkIndices(account, chain, index); err != nil {
+		return eddsa.Node{}, err
+	}
+	n := w.coin
+	for _, i := range []uint32{account, uint32(chain), index} {
+		child, err := n.DeriveChild(i | slip10.Hardened)
+		n.Clear()
+		if err != nil {
+			return eddsa.Node{}, err
+		}
+		n = child
+	}
+	return n, nil
+}
+
+func checkIndices(account uint32, chain Chain, index uint32) error {
+	if chain != External && chain != Internal {
+		return fmt.Errorf("%w: %d", ErrInvalidChain, uint32(chain))
+	}
+	if account >= slip10.Hardened {
+		return fmt.Errorf("%w: account %d", ErrIndexOutOfRange, account)
+	}
+	if index >= slip10.Hardened {
+		return fmt.Errorf("%w: address index %d", ErrIndexOutOfRange, index)
+	}
+	return nil
+}
@@ -0,0 +1,211 @@
+//nolint:scopelint // from tests
+package wallet_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/bech32/address"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip32path"
+	"github.com/iotaledger/iota-crypto-demo/pkg/bip39"
+	"github.com/iotaledger/iota-crypto-demo/pkg/ed25519"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10/eddsa"
+	"github.com/iotaledger/iota-crypto-demo/pkg/wallet"
+)
+
+var seed, _ = hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+
+func TestWalletPaths(t *testing.T) {
+	var tests = []*struct {
+		opts *wallet.Options
+		path string
+		hrp  string
+	}{
+		{nil, "m/44'/4218'/0'/0'/0'", "iota"},
+		{&wallet.Options{Prefix: address.IOTADevnet}, "m/44'/4218'/0'/0'/0'", "atoi"},
+		{&wallet.Options{Prefix: address.ShimmerMainnet}, "m/44'/4219'/0'/0'/0'", "smr"},
+		{&wallet.Options{Prefix: address.ShimmerMainnet, CoinType: 1}, "m/44'/1'/0'/0'/0'", "smr"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			w, err := wallet.New(seed, wallet.NewMemoryStore(), tt.opts)
+			require.NoError(t, err)
+			_, err = w.CreateAccount("")
+			require.NoError(t, err)
+
+			addr, err := w.NextReceiveAddress(0)
+			require.NoError(t, err)
+			assert.Equal(t, tt.path, addr.Path.String())
+
+			key, err := slip10.DeriveKeyFromPath(seed, eddsa.Ed25519(), addr.Path)
+			require.NoError(t, err)
+			public, _ := key.Key.(eddsa.Seed).Ed25519Key()
+			assert.Equal(t, public, addr.PublicKey)
+			assert.Equal(t, address.AddressFromPublicKey(public), addr.Address)
+
+			prefix, parsed, err := address.ParseBech32(addr.Bech32)
+			require.NoError(t, err)
+			assert.Equal(t, tt.hrp, prefix.String())
+			assert.True(t, addr.Address.Equal(parsed))
+		})
+	}
+}
+
+func TestWalletNextAddress(t *testing.T) {
+	w, err := wallet.New(seed, wallet.NewMemoryStore(), nil)
+	require.NoError(t, err)
+
+	_, err = w.NextReceiveAddress(0)
+	assert.ErrorIs(t, err, wallet.ErrAccountNotFound)
+
+	first, err := w.CreateAccount("first")
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, first.Index)
+	second, err := w.CreateAccount("second")
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, second.Index)
+
+	for i := uint32(0); i < 3; i++ {
+		addr, err := w.NextReceiveAddress(1)
+		require.NoError(t, err)
+		assert.Equal(t, wallet.External, addr.Chain)
+		assert.Equal(t, i, addr.Index)
+
+		expected, err := w.Address(1, wallet.External, i)
+		require.NoError(t, err)
+		assert.Equal(t, expected, addr)
+	}
+	change, err := w.NextChangeAddress(1)
+	require.NoError(t, err)
+	assert.Equal(t, "m/44'/4218'/1'/1'/0'", change.Path.String())
+
+	state, err := w.Account(1)
+	require.NoError(t, err)
+	assert.Equal(t, wallet.AccountState{Index: 1, Name: "second", NextExternal: 3, NextInternal: 1}, state)
+
+	// marking an address as used skips all lower indices
+	require.NoError(t, w.MarkUsed(0, wallet.External, 9))
+	require.NoError(t, w.MarkUsed(0, wallet.External, 5))
+	addr, err := w.NextReceiveAddress(0)
+	require.NoError(t, err)
+	assert.EqualValues(t, 10, addr.Index)
+
+	assert.ErrorIs(t, w.MarkUsed(0, wallet.Chain(2), 0), wallet.ErrInvalidChain)
+	assert.ErrorIs(t, w.MarkUsed(0, wallet.External, slip10.Hardened), wallet.ErrIndexOutOfRange)
+	assert.ErrorIs(t, w.MarkUsed(2, wallet.External, 0), wallet.ErrAccountNotFound)
+}
+
+func TestWalletPrivateKey(t *testing.T) {
+	w, err := wallet.New(seed, wallet.NewMemoryStore(), nil)
+	require.NoError(t, err)
+	_, err = w.CreateAccount("")
+	require.NoError(t, err)
+
+	addr, err := w.NextChangeAddress(0)
+	require.NoError(t, err)
+	key, err := w.PrivateKey(0, wallet.Internal, addr.Index)
+	require.NoError(t, err)
+	sig := ed25519.Sign(key, []byte("message"))
+	assert.True(t, ed25519.Verify(addr.PublicKey, []byte("message"), sig))
+}
+
+func TestWalletFromMnemonic(t *testing.T) {
+	mnemonic := bip39.ParseMnemonic("legal winner thank year wave sausage worth useful legal winner thank yellow")
+	w, err := wallet.FromMnemonic(mnemonic, "TREZOR", wallet.NewMemoryStore(), nil)
+	require.NoError(t, err)
+	_, err = w.CreateAccount("")
+	require.NoError(t, err)
+	addr, err := w.NextReceiveAddress(0)
+	require.NoError(t, err)
+
+	s, err := bip39.MnemonicToSeed(mnemonic, "TREZOR")
+	require.NoError(t, err)
+	path, _ := bip32path.ParsePath("m/44'/4218'/0'/0'/0'")
+	key, err := slip10.DeriveKeyFromPath(s, eddsa.Ed25519(), path)
+	require.NoError(t, err)
+	public, _ := key.Key.(eddsa.Seed).Ed25519Key()
+	assert.Equal(t, public, addr.PublicKey)
+
+	_, err = wallet.FromMnemonic(bip39.ParseMnemonic("legal winner"), "", wallet.NewMemoryStore(), nil)
+	assert.Error(t, err)
+}
+
+func TestFileStore(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "wallet.json")
+
+	w, err := wallet.New(seed, wallet.NewFileStore(name), nil)
+	require.NoError(t, err)
+	_, err = w.CreateAccount("savings")
+	require.NoError(t, err)
+	_, err = w.CreateAccount("spending")
+	require.NoError(t, err)
+	first, err := w.NextReceiveAddress(1)
+	require.NoError(t, err)
+
+	// a wallet restored from the same file continues with fresh addresses
+	w, err = wallet.New(seed, wallet.NewFileStore(name), nil)
+	require.NoError(t, err)
+	assert.Equal(t, []wallet.AccountState{
+		{Index: 0, Name: "savings"},
+		{Index: 1, Name: "spending", NextExternal: 1},
+	}, w.Accounts())
+	next, err := w.NextReceiveAddress(1)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, next.Index)
+	assert.NotEqual(t, first.Bech32, next.Bech32)
+}
+
+func TestDuplicateAccount(t *testing.T) {
+	store := &duplicateStore{}
+	_, err := wallet.New(seed, store, nil)
+	assert.ErrorIs(t, err, wallet.ErrAccountExists)
+}
+
+func TestFailingStore(t *testing.T) {
+	store := &failingStore{MemoryStore: wallet.NewMemoryStore()}
+	w, err := wallet.New(seed, store, nil)
+	require.NoError(t, err)
+	_, err = w.CreateAccount("")
+	require.NoError(t, err)
+
+	store.fail = true
+	_, err = w.NextReceiveAddress(0)
+	assert.ErrorIs(t, err, errStore)
+	_, err = w.CreateAccount("")
+	assert.ErrorIs(t, err, errStore)
+
+	// the failed calls must not have changed the state
+	store.fail = false
+	assert.Len(t, w.Accounts(), 1)
+	addr, err := w.NextReceiveAddress(0)
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, addr.Index)
+}
+
+var errStore = errors.New("store failed")
+
+type failingStore struct {
+	*wallet.MemoryStore
+	fail bool
+}
+
+func (s *failingStore) Save(state wallet.AccountState) error {
+	if s.fail {
+		return errStore
+	}
+	return s.MemoryStore.Save(state)
+}
+
+type duplicateStore struct{}
+
+func (duplicateStore) Load() ([]wallet.AccountState, error) {
+	return []wallet.AccountState{{Index: 0}, {Index: 0}}, nil
+}
+
+func (duplicateStore) Save(wallet.AccountState) error { return nil }
@@ -0,0 +1,123 @@
+/*
+Package wif implements the Wallet Import Format (WIF) for secp256k1 private keys.
+
+This allows private keys derived using the slip10 package to be imported into
+Bitcoin-family wallets.
+*/
+package wif
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/base58"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10"
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10/elliptic"
+)
+
+const compressedFlag = 0x01
+
+// Errors returned during WIF encoding and decoding.
+var (
+	ErrInvalidCurve    = errors.New("private key is not a secp256k1 key")
+	ErrInvalidLength   = errors.New("invalid length")
+	ErrInvalidChecksum = errors.New("invalid checksum")
+	ErrInvalidNetwork  = errors.New("invalid network")
+	ErrInvalidKey      = errors.New("invalid key")
+)
+
+// Network denotes the version byte identifying the network of a WIF key.
+type Network byte
+
+// Supported networks.
+const (
+	Mainnet Network = 0x80
+	Testnet Network = 0xEF
+)
+
+func (n Network) String() string {
+	switch n {
+	case Mainnet:
+		return "mainnet"
+	case Testnet:
+		return "testnet"
+	}
+	return fmt.Sprintf("Network(%#02x)", byte(n))
+}
+
+// Key represents a private key in Wallet Import Format.
+type Key struct {
+	// Network is the network the key is meant to be used on.
+	Network Network
+	// PrivateKey is the secp256k1 private key.
+	PrivateKey *elliptic.PrivateKey
+	// Compressed denotes whether the corresponding public key is used in its compressed form.
+	Compressed bool
+}
+
+// Encode returns the WIF encoding of the secp256k1 private key for the given network.
+func Encode(network Network, key *elliptic.PrivateKey, compressed bool) (string, error) {
+	if network != Mainnet && network != Testnet {
+		return "", ErrInvalidNetwork
+	}
+	if key.Curve.Params().Name != elliptic.Secp256k1().Name() {
+		return "", ErrInvalidCurve
+	}
+
+	payload := key.Bytes()
+	if compressed {
+		payload = append(payload, compressedFlag)
+	}
+	return base58.CheckEncode(byte(network), payload), nil
+}
+
+// String returns the WIF encoding of k.
+func (k *Key) String() string {
+	s, err := Encode(k.Network, k.PrivateKey, k.Compressed)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// Decode decodes the WIF encoded string s.
+func Decode(s string) (*Key, error) {
+	payload, err := base58.Decode(s)
+	if err != nil {
+		return nil, err
+	}
+
+	var compressed bool
+	switch len(payload) {
+	case 1 + slip10.PrivateKeySize + base58.ChecksumSize:
+		compressed = false
+	case 1 + slip10.PrivateKeySize + 1 + base58.ChecksumSize:
+		compressed = true
+	default:
+		return nil, ErrInvalidLength
+	}
+
+	data, sum := payload[:len(payload)-base58.ChecksumSize], payload[len(payload)-base58.ChecksumSize:]
+	if !bytes.Equal(sum, base58.Checksum(data)) {
+		return nil, ErrInvalidChecksum
+	}
+	network := Network(data[0])
+	if network != Mainnet && network != Testnet {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidNetwork, network)
+	}
+	if compressed && data[len(data)-1] != compressedFlag {
+		return nil, fmt.Errorf("%w: invalid compression flag", ErrInvalidKey)
+	}
+
+	key, err := elliptic.Secp256k1().NewPrivateKey(data[1 : 1+slip10.PrivateKeySize])
+	if err != nil {
+		return nil, fmt.Errorf("%w: scalar out of range", ErrInvalidKey)
+	}
+	//nolint:forcetypeassert // elliptic curves always return a PrivateKey
+	return &Key{
+		Network:    network,
+		PrivateKey: key.(*elliptic.PrivateKey),
+		Compressed: compressed,
+	}, nil
+}
@@ -0,0 +1,101 @@
+//nolint:scopelint
+package wif_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota-crypto-demo/pkg/slip10/elliptic"
+	"github.com/iotaledger/iota-crypto-demo/pkg/wif"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	var tests = []*struct {
+		key        string
+		network    wif.Network
+		compressed bool
+		expS       string
+	}{
+		{
+			key:        "0c28fca386c7a227600b2fe50b7cae11ec86d3bf1fbe471be89827e19d72aa1d",
+			network:    wif.Mainnet,
+			compressed: false,
+			expS:       "5HueCGU8rMjxEXxiPuD5BDku4MkFqeZyd4dZ1jvhTVqvbTLvyTJ",
+		},
+		{
+			key:        "0c28fca386c7a227600b2fe50b7cae11ec86d3bf1fbe471be89827e19d72aa1d",
+			network:    wif.Mainnet,
+			compressed: true,
+			expS:       "KwdMAjGmerYanjeui5SHS7JkmpZvVipYvB2LJGU1ZxJwYvP98617",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expS, func(t *testing.T) {
+			b, err := hex.DecodeString(tt.key)
+			require.NoError(t, err)
+			key, err := elliptic.Secp256k1().NewPrivateKey(b)
+			require.NoError(t, err)
+
+			//nolint:forcetypeassert
+			s, err := wif.Encode(tt.network, key.(*elliptic.PrivateKey), tt.compressed)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expS, s)
+
+			decoded, err := wif.Decode(s)
+			require.NoError(t, err)
+			assert.Equal(t, tt.network, decoded.Network)
+			assert.Equal(t, tt.compressed, decoded.Compressed)
+			assert.Equal(t, b, decoded.PrivateKey.Bytes())
+			assert.Equal(t, s, decoded.String())
+		})
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	b := make([]byte, 32)
+	b[31] = 1
+	key, err := elliptic.Secp256k1().NewPrivateKey(b)
+	require.NoError(t, err)
+
+	for _, network := range []wif.Network{wif.Mainnet, wif.Testnet} {
+		for _, compressed := range []bool{false, true} {
+			//nolint:forcetypeassert
+			s, err := wif.Encode(network, key.(*elliptic.PrivateKey), compressed)
+			require.NoError(t, err)
+			decoded, err := wif.Decode(s)
+			require.NoError(t, err)
+			assert.Equal(t, network, decoded.Network)
+			assert.Equal(t, compressed, decoded.Compressed)
+			assert.Equal(t, b, decoded.PrivateKey.Bytes())
+		}
+	}
+}
+
+func TestInvalid(t *testing.T) {
+	b := make([]byte, 32)
+	b[31] = 1
+	key, err := elliptic.Nist256p1().NewPrivateKey(b)
+	require.NoError(t, err)
+	//nolint:forcetypeassert
+	_, err = wif.Encode(wif.Mainnet, key.(*elliptic.PrivateKey), true)
+	assert.ErrorIs(t, err, wif.ErrInvalidCurve)
+
+	var tests = []*struct {
+		s      string
+		expErr error
+	}{
+		{"5HueCGU8rMjxEXxiPuD5BDku4MkFqeZyd4dZ1jvhTVqvbTLvyTj", wif.ErrInvalidChecksum},
+		{"KwdMAjGmerYanjeui5SHS7JkmpZvVipYvB2LJGU1ZxJwYvP98618", wif.ErrInvalidChecksum},
+		{"5HueCGU8rMjxEXxiPuD5BDku4MkFqeZyd4dZ1jvhTVqvb", wif.ErrInvalidLength},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			_, err := wif.Decode(tt.s)
+			assert.ErrorIs(t, err, tt.expErr)
+		})
+	}
+}